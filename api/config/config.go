@@ -47,6 +47,24 @@ type APIConfig struct {
 	Auth0ClientSecret       string
 	Auth0Namespace          string
 
+	// Where users log in. Empty or "auth0" uses the Auth0 settings above. "oidc" accepts JWTs from the OpenID Connect
+	// provider (eg a Keycloak realm) at OIDCIssuerURL. "local" accepts JWTs signed with LocalJWTSecret, which can be
+	// made with the local-jwt tool, for offline development and tests (only allowed if EnvironmentName is local, dev or
+	// test). User management, reviewer magic links and assigning a group's default roles need the Auth0 management API,
+	// so are only available with Auth0
+	IdentityProvider string
+
+	// OIDC settings. Claim names default to preferred_username, email and permissions. Nested claims are named by
+	// their path separated by ., eg realm_access.roles for Keycloak realm roles, which must be named as PIXLISE
+	// permissions to grant them
+	OIDCIssuerURL        string
+	OIDCAudience         string
+	OIDCUsernameClaim    string
+	OIDCEmailClaim       string
+	OIDCPermissionClaims []string
+
+	LocalJWTSecret string
+
 	// New user creation
 	Auth0NewUserRoleID string
 	DefaultUserGroupId string // The GroupId of the group a new user is added to by default as a member
@@ -59,6 +77,13 @@ type APIConfig struct {
 	ManualUploadBucket string
 	DataBackupBucket   string
 
+	// File storage backend for the above buckets. Empty or "s3" uses AWS S3, optionally at S3Endpoint if
+	// an S3-compatible store (eg MinIO) is being used. "local" treats each bucket as a directory under
+	// LocalStorageRoot, so the API can run on a laptop without any S3 access
+	StorageBackend   string
+	S3Endpoint       string
+	LocalStorageRoot string
+
 	DataSourceSNSTopic string
 
 	EnvironmentName string
@@ -97,12 +122,20 @@ type APIConfig struct {
 	// How often we run memoisation GC
 	MemoisationGCIntervalSec uint
 
+	// Limits applied to each expression run, so a misbehaving expression can't tie up the API
+	ExpressionLimits ExpressionLimitsConfig
+
 	// Admin-only features: backup & restore settings, and allowing impersonate user menu option
 	BackupEnabled             bool
 	RestoreEnabled            bool
 	RestoreExcludeCollections []string
 	ImpersonateEnabled        bool
 
+	// Backup set retention. Sets are deleted once they are older than BackupKeepDays AND not one of the newest
+	// BackupKeepCount complete sets. 0 means no limit for either, if both are 0, all sets are kept
+	BackupKeepCount uint
+	BackupKeepDays  uint
+
 	// Settings that control what kind of job processing EC2 instances we create
 	Jobs JobConfig
 
@@ -110,6 +143,8 @@ type APIConfig struct {
 	ImportJobMaxTimeSec uint32
 	KubeConfig          string // Env sets this via command line parameter
 	QuantExecutor       string
+	LocalPiquantPath    string // PIQUANT executable run by the "local" QuantExecutor
+	LocalPiquantWorkers uint   // How many PIQUANT processes the "local" QuantExecutor runs at once. If 0, CPU count / Jobs.CoresPerNode
 	QuantNamespace      string // Used for running large multi-node quants
 	HotQuantNamespace   string // Used for faster PIQUANT runs, eg executing a spectral fit
 	KubernetesLocation  string // "internal" vs "external"
@@ -120,6 +155,15 @@ type APIConfig struct {
 type JobConfig struct {
 	LegacyJobs bool
 
+	// Where job nodes get started: "ec2", "kubernetes" or "local" (job node runs in the API process). If left
+	// empty we start EC2 instances if an AWSSecret is configured, otherwise local
+	NodeLauncher string
+
+	// Kubernetes job nodes are pods running NodeDockerImage, which must have pixlise-job-node as its entrypoint
+	NodeDockerImage    string
+	NodeNamespace      string
+	NodeServiceAccount string
+
 	// Configuring AWS EC2 instance type for job node
 	CoresPerNode  uint
 	InstanceType  string
@@ -132,6 +176,14 @@ type JobConfig struct {
 	MaxNodeRunTimeSec uint32
 	NodeCountOverride uint // Forces PMC list generation to create this many nodes. Mainly usable for testing.
 	MaxQuantNodes     uint // Limiting how many nodes we run simultaneously
+	MaxRunningNodes   uint // How many job nodes can be running at once, across all jobs. Jobs wait for free nodes past this. 0 means unlimited
+
+	// What to do when nodes of a map quant fail. Failed nodes are re-queued up to QuantMaxNodeRetries times, waiting
+	// QuantRetryBackoffSec (doubled on each retry). If QuantCompleteWithSucceededNodes is set and nodes still fail, the
+	// quant is made from the nodes that succeeded, and notes which PMCs are missing
+	QuantMaxNodeRetries             uint32
+	QuantRetryBackoffSec            uint32
+	QuantCompleteWithSucceededNodes bool
 
 	// Jobs run in docker, these configure what container and settings they use
 	AWSSecret         string
@@ -139,6 +191,18 @@ type JobConfig struct {
 	RunnerDockerImage string // Docker image to run expressions, python code and PIQUANT on the back end
 }
 
+// Limits applied when running user-written Lua expressions. A limit left at 0 is not applied
+type ExpressionLimitsConfig struct {
+	MaxRunTimeSec   uint   // Wall-clock time an expression can run for, not counting time paused in the debugger
+	MaxInstructions uint64 // Roughly how many Lua VM instructions an expression can execute
+	MaxMapSize      uint   // How many values a map created by makeMap can contain
+	MaxCacheBytes   uint   // How large a table saved with writeCache can be, once converted to JSON
+
+	// Standard Lua libraries expressions can use, eg "base", "table", "string", "math". If empty, a safe set is allowed.
+	// If "os" is allowed, expressions only get os.time and os.clock
+	AllowedLuaLibs []string
+}
+
 func homeDir() string {
 	if h := os.Getenv("HOME"); h != "" {
 		return h
@@ -276,6 +340,18 @@ func applyConfigLimits(cfg *APIConfig) {
 	if cfg.MemoiseCacheTimeOutSec <= 0 {
 		cfg.MemoiseCacheTimeOutSec = 86400
 	}
+
+	if cfg.ExpressionLimits.MaxRunTimeSec <= 0 {
+		cfg.ExpressionLimits.MaxRunTimeSec = 10 * 60
+	}
+
+	if cfg.ExpressionLimits.MaxMapSize <= 0 {
+		cfg.ExpressionLimits.MaxMapSize = 1000000
+	}
+
+	if cfg.ExpressionLimits.MaxCacheBytes <= 0 {
+		cfg.ExpressionLimits.MaxCacheBytes = 15 * 1024 * 1024 // Mongo documents can't be over 16MB
+	}
 }
 
 func ReadJobConfig(cfg *APIConfig, fs fileaccess.FileAccess) error {
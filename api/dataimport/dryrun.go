@@ -0,0 +1,363 @@
+// Licensed to NASA JPL under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. NASA JPL licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package dataimport
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pixlise/core/v4/api/dataimport/datasetArchive"
+	"github.com/pixlise/core/v4/api/dataimport/internal/converterSelector"
+	"github.com/pixlise/core/v4/api/dataimport/internal/converters/converter"
+	"github.com/pixlise/core/v4/api/dataimport/internal/dataConvertModels"
+	"github.com/pixlise/core/v4/api/dataimport/internal/importerutils"
+	"github.com/pixlise/core/v4/core/fileaccess"
+	"github.com/pixlise/core/v4/core/logger"
+	"github.com/pixlise/core/v4/core/utils"
+	protos "github.com/pixlise/core/v4/generated-protos"
+)
+
+// DryRunUpload - Runs the importer on a manually uploaded scan without saving anything, returning a report of what
+// it found. uploadFS is expected to contain what ProcessSDF/ProcessBreadboard/ProcessUserDefined wrote into the
+// manual upload bucket, and doesn't have to be the real bucket - callers can process into a memory FS so nothing is
+// written. configFS is used to read the pseudo-intensity ranges from the config bucket
+func DryRunUpload(
+	localFS fileaccess.FileAccess,
+	uploadFS fileaccess.FileAccess,
+	configFS fileaccess.FileAccess,
+	configBucket string,
+	manualUploadBucket string,
+	datasetBucket string,
+	datasetID string,
+	log logger.ILogger,
+) (*protos.ScanImportReport, error) {
+	workingDir, err := os.MkdirTemp("", "dryrun")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(workingDir)
+
+	uploads := datasetArchive.NewDatasetArchiveDownloader(uploadFS, localFS, log, datasetBucket, manualUploadBucket)
+	localDownloadPath, localUnzippedPath, err := uploads.DownloadFromDatasetUploads(datasetID, workingDir)
+	if err != nil {
+		return nil, err
+	}
+
+	config := datasetArchive.NewDatasetArchiveDownloader(configFS, localFS, log, datasetBucket, manualUploadBucket)
+	localRangesPath, err := config.DownloadPseudoIntensityRangesFile(configBucket, localDownloadPath, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return DryRunImport(localFS, configFS, localUnzippedPath, localRangesPath, datasetBucket, datasetID, log)
+}
+
+// DryRunImport - Runs the converter that would be selected for the files in localImportPath, and reports what it
+// read without saving anything. Any warnings logged by the converter are included in the report
+func DryRunImport(
+	localFS fileaccess.FileAccess,
+	remoteFS fileaccess.FileAccess,
+	localImportPath string,
+	localPseudoIntensityRangesPath string,
+	datasetBucket string,
+	datasetID string,
+	log logger.ILogger,
+) (*protos.ScanImportReport, error) {
+	warnLog := &warningLogger{log: log}
+
+	importer, data, contextImageSrcPath, err := runConverter(localFS, remoteFS, localImportPath, localPseudoIntensityRangesPath, datasetBucket, datasetID, warnLog)
+	if err != nil {
+		return nil, err
+	}
+
+	report := makeImportReport(data, contextImageSrcPath)
+	report.Format = strings.TrimPrefix(fmt.Sprintf("%T", importer), "*")
+	report.Warnings = append(warnLog.warnings, report.Warnings...)
+	return report, nil
+}
+
+// runConverter - Picks a converter by inspecting the directory we're about to import from, runs it and applies any
+// custom meta overrides. Returns the converter used, the data it read and the path context images are relative to
+func runConverter(
+	localFS fileaccess.FileAccess,
+	remoteFS fileaccess.FileAccess,
+	localImportPath string,
+	localPseudoIntensityRangesPath string,
+	datasetBucket string,
+	datasetID string,
+	log logger.ILogger,
+) (converter.DataConverter, *dataConvertModels.OutputData, string, error) {
+	importer, err := converterSelector.SelectDataConverter(localFS, remoteFS, datasetBucket, localImportPath, log)
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	log.Infof("Running dataset converter...")
+	data, contextImageSrcPath, err := importer.Import(localImportPath, localPseudoIntensityRangesPath, datasetID, log)
+	if err != nil {
+		return nil, nil, "", fmt.Errorf("Import failed: %v", err)
+	}
+
+	// Apply any overrides we may have
+	customMetaFields, err := readLocalCustomMeta(log, localImportPath)
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	if len(customMetaFields.Title) > 0 && customMetaFields.Title != " " {
+		log.Infof("Applying custom title: %v", customMetaFields.Title)
+		data.Meta.Title = customMetaFields.Title
+	}
+
+	if len(customMetaFields.DefaultContextImage) > 0 {
+		log.Infof("Applying custom default context image: %v", customMetaFields.DefaultContextImage)
+		data.DefaultContextImage = customMetaFields.DefaultContextImage
+	}
+
+	return importer, data, contextImageSrcPath, nil
+}
+
+func makeImportReport(data *dataConvertModels.OutputData, contextImageSrcPath string) *protos.ScanImportReport {
+	report := &protos.ScanImportReport{
+		Title:           data.Meta.Title,
+		Instrument:      data.Instrument,
+		PmcCount:        uint32(len(data.PerPMCData)),
+		PmcsWithoutBeam: []int32{},
+		ContextImages:   []string{},
+		MissingImages:   []string{},
+		MatchedImages:   []string{},
+		Warnings:        []string{},
+	}
+
+	pmcs := utils.GetMapKeys(data.PerPMCData)
+	sort.Slice(pmcs, func(i, j int) bool { return pmcs[i] < pmcs[j] })
+
+	// Count spectra by read type and detector, and check each PMC with Normal spectra has a beam location
+	spectrumCounts := map[string]*protos.ScanImportReport_SpectrumCount{}
+	normalSpectra := dataConvertModels.DetectorSampleByPMC{}
+	normalDetectors := map[string]bool{}
+	uncalibrated := 0
+
+	for _, pmc := range pmcs {
+		item := data.PerPMCData[pmc]
+		for _, spectrum := range item.DetectorSpectra {
+			readType := spectrum.Meta["READTYPE"].SValue
+			detector := spectrum.Meta["DETECTOR_ID"].SValue
+
+			key := readType + "/" + detector
+			if _, ok := spectrumCounts[key]; !ok {
+				spectrumCounts[key] = &protos.ScanImportReport_SpectrumCount{ReadType: readType, Detector: detector}
+			}
+			spectrumCounts[key].Count++
+
+			if readType == "Normal" {
+				normalSpectra[pmc] = append(normalSpectra[pmc], spectrum)
+				normalDetectors[detector] = true
+			}
+
+			_, hasXPerChan := spectrum.Meta["XPERCHAN"]
+			_, hasOffset := spectrum.Meta["OFFSET"]
+			if !hasXPerChan || !hasOffset {
+				uncalibrated++
+			}
+		}
+
+		if len(normalSpectra[pmc]) > 0 && item.Beam == nil {
+			report.PmcsWithoutBeam = append(report.PmcsWithoutBeam, pmc)
+		}
+	}
+
+	keys := utils.GetMapKeys(spectrumCounts)
+	sort.Strings(keys)
+	for _, key := range keys {
+		report.SpectrumCounts = append(report.SpectrumCounts, spectrumCounts[key])
+	}
+
+	if len(normalSpectra) <= 0 {
+		report.Warnings = append(report.Warnings, "No Normal spectra found")
+	}
+
+	// Each PMC should have at most one Normal spectrum per detector
+	report.Warnings = append(report.Warnings, importerutils.FindMoreFoundMSA(normalSpectra, "Normal spectrum", len(normalDetectors))...)
+
+	if uncalibrated > 0 {
+		report.Warnings = append(report.Warnings, fmt.Sprintf("%v spectra have no energy calibration (XPERCHAN/OFFSET)", uncalibrated))
+	}
+
+	if len(report.PmcsWithoutBeam) > 0 {
+		report.Warnings = append(report.Warnings, fmt.Sprintf("%v PMCs with spectra have no beam location", len(report.PmcsWithoutBeam)))
+	}
+
+	report.HousekeepingColumns, report.Warnings = makeHousekeepingColumns(data, pmcs, report.Warnings)
+
+	// Check the images the converter referenced are all there
+	pmcImages := map[int32]string{}
+	defaultFound := false
+	for _, pmc := range pmcs {
+		item := data.PerPMCData[pmc]
+		if len(item.ContextImageSrc) <= 0 {
+			continue
+		}
+
+		pmcImages[pmc] = item.ContextImageSrc
+		report.ContextImages = append(report.ContextImages, item.ContextImageSrc)
+		if !imageExists(contextImageSrcPath, item.ContextImageSrc) {
+			report.MissingImages = append(report.MissingImages, item.ContextImageSrc)
+		}
+
+		if data.DefaultContextImage == item.ContextImageDst {
+			defaultFound = true
+		}
+	}
+
+	for _, img := range append(append([]dataConvertModels.ImageMeta{}, data.RGBUImages...), data.DISCOImages...) {
+		if !imageExists(contextImageSrcPath, img.FileName) {
+			report.MissingImages = append(report.MissingImages, img.FileName)
+		}
+		if data.DefaultContextImage == img.FileName {
+			defaultFound = true
+		}
+	}
+
+	for _, matched := range data.MatchedAlignedImages {
+		report.MatchedImages = append(report.MatchedImages, matched.MatchedImageName)
+
+		if _, err := os.Stat(matched.MatchedImageFullPath); err != nil {
+			report.MissingImages = append(report.MissingImages, matched.MatchedImageName)
+		}
+		if _, ok := pmcImages[matched.AlignedBeamPMC]; !ok {
+			report.Warnings = append(report.Warnings, fmt.Sprintf("Matched image %v is aligned to PMC %v which has no context image", matched.MatchedImageName, matched.AlignedBeamPMC))
+		}
+	}
+
+	if len(report.MissingImages) > 0 {
+		report.Warnings = append(report.Warnings, fmt.Sprintf("%v images referenced by the import were not found", len(report.MissingImages)))
+	}
+
+	if len(data.DefaultContextImage) <= 0 {
+		if len(report.ContextImages) > 0 {
+			report.Warnings = append(report.Warnings, "No main context image determined")
+		}
+	} else if !defaultFound {
+		report.Warnings = append(report.Warnings, fmt.Sprintf("Main context image %v is not one of the imported images", data.DefaultContextImage))
+	}
+
+	return report
+}
+
+// Lists the housekeeping columns in header order, with the type the converter picked for each. If a column has
+// values of different types, it's reported as the most general of them (string, then float) with a warning
+func makeHousekeepingColumns(data *dataConvertModels.OutputData, pmcs []int32, warnings []string) ([]*protos.ScanImportReport_HousekeepingColumn, []string) {
+	columns := []*protos.ScanImportReport_HousekeepingColumn{}
+	colTypes := map[int]map[protos.Experiment_MetaDataType]bool{}
+
+	for c, name := range data.HousekeepingHeaders {
+		columns = append(columns, &protos.ScanImportReport_HousekeepingColumn{Name: name})
+		colTypes[c] = map[protos.Experiment_MetaDataType]bool{}
+	}
+
+	for _, pmc := range pmcs {
+		item := data.PerPMCData[pmc]
+		for c, value := range item.Housekeeping {
+			if c >= len(item.HousekeepingHeaderIdxs) {
+				break
+			}
+
+			col := int(item.HousekeepingHeaderIdxs[c])
+			if col < 0 || col >= len(columns) {
+				warnings = append(warnings, fmt.Sprintf("PMC %v has housekeeping value for unknown column index %v", pmc, col))
+				continue
+			}
+
+			columns[col].PmcCount++
+			colTypes[col][value.DataType] = true
+		}
+	}
+
+	for c, column := range columns {
+		types := colTypes[c]
+		if types[protos.Experiment_MT_STRING] {
+			column.DataType = protos.ScanMetaDataType_MT_STRING
+		} else if types[protos.Experiment_MT_FLOAT] {
+			column.DataType = protos.ScanMetaDataType_MT_FLOAT
+		} else {
+			column.DataType = protos.ScanMetaDataType_MT_INT
+		}
+
+		if len(types) > 1 {
+			warnings = append(warnings, fmt.Sprintf("Housekeeping column %v has values of %v different types, treating as %v", column.Name, len(types), column.DataType))
+		}
+		if column.PmcCount <= 0 {
+			warnings = append(warnings, fmt.Sprintf("Housekeeping column %v has no values", column.Name))
+		}
+	}
+
+	return columns, warnings
+}
+
+func imageExists(contextImageSrcPath string, fileName string) bool {
+	_, err := os.Stat(filepath.Join(contextImageSrcPath, fileName))
+	return err == nil
+}
+
+// Passes everything through to the logger we wrap, but remembers errors and anything logged as a warning so they
+// can be reported back from a dry run
+type warningLogger struct {
+	log      logger.ILogger
+	warnings []string
+}
+
+func (l *warningLogger) remember(format string, a ...interface{}) {
+	l.warnings = append(l.warnings, strings.TrimSpace(fmt.Sprintf(format, a...)))
+}
+
+func (l *warningLogger) isWarning(format string) bool {
+	return strings.HasPrefix(strings.ToLower(strings.TrimSpace(format)), "warning")
+}
+
+func (l *warningLogger) Printf(level logger.LogLevel, format string, a ...interface{}) {
+	if level == logger.LogError || (level == logger.LogInfo && l.isWarning(format)) {
+		l.remember(format, a...)
+	}
+	l.log.Printf(level, format, a...)
+}
+func (l *warningLogger) Debugf(format string, a ...interface{}) {
+	l.log.Debugf(format, a...)
+}
+func (l *warningLogger) Infof(format string, a ...interface{}) {
+	if l.isWarning(format) {
+		l.remember(format, a...)
+	}
+	l.log.Infof(format, a...)
+}
+func (l *warningLogger) Errorf(format string, a ...interface{}) {
+	l.remember(format, a...)
+	l.log.Errorf(format, a...)
+}
+func (l *warningLogger) SetLogLevel(level logger.LogLevel) {
+	l.log.SetLogLevel(level)
+}
+func (l *warningLogger) GetLogLevel() logger.LogLevel {
+	return l.log.GetLogLevel()
+}
+func (l *warningLogger) Close() {
+	l.log.Close()
+}
@@ -0,0 +1,156 @@
+// Licensed to NASA JPL under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. NASA JPL licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package dataimport
+
+import (
+	"fmt"
+	"os"
+	"path"
+
+	"github.com/pixlise/core/v4/api/dataimport/internal/dataConvertModels"
+	"github.com/pixlise/core/v4/api/filepaths"
+	"github.com/pixlise/core/v4/core/fileaccess"
+	"github.com/pixlise/core/v4/core/logger"
+	protos "github.com/pixlise/core/v4/generated-protos"
+)
+
+func printImportReport(report *protos.ScanImportReport, err error) {
+	fmt.Printf("err: %v\n", err)
+	if report == nil {
+		return
+	}
+
+	fmt.Printf("Format: %v, title: %v, instrument: %v, PMCs: %v\n", report.Format, report.Title, report.Instrument, report.PmcCount)
+	for _, count := range report.SpectrumCounts {
+		fmt.Printf("Spectra %v/%v: %v\n", count.ReadType, count.Detector, count.Count)
+	}
+	fmt.Printf("PMCs without beam: %v\n", report.PmcsWithoutBeam)
+	for _, col := range report.HousekeepingColumns {
+		fmt.Printf("Housekeeping %v: %v, %v PMCs\n", col.Name, col.DataType, col.PmcCount)
+	}
+	fmt.Printf("Images: %v, missing: %v, matched: %v\n", report.ContextImages, report.MissingImages, report.MatchedImages)
+	for _, warning := range report.Warnings {
+		fmt.Printf("  - %v\n", warning)
+	}
+}
+
+// Uploads processed into memory, as the scan upload handler does for a dry run
+func ExampleDryRunUpload() {
+	uploadFS := fileaccess.MakeMemoryAccess()
+	manualBucket := "manual-bucket"
+	uploadPath := path.Join(filepaths.DatasetUploadRoot, "dry123")
+
+	for _, name := range []string{"point_01.spx", "point_02.spx"} {
+		data, err := os.ReadFile("./internal/converters/bruker/test-data/spx/" + name)
+		if err != nil {
+			fmt.Println(err)
+		}
+		uploadFS.WriteObject(manualBucket, path.Join(uploadPath, name), data)
+	}
+
+	report, err := DryRunUpload(&fileaccess.FSAccess{}, uploadFS, &fileaccess.FSAccess{}, "./test-data/Manual_OK/config-bucket", manualBucket, "dataset-bucket", "dry123", &logger.NullLogger{})
+	printImportReport(report, err)
+
+	// Nothing written to the upload area
+	files, _ := uploadFS.ListObjects(manualBucket, "")
+	fmt.Printf("Upload files: %v\n", len(files))
+
+	// Output:
+	// err: <nil>
+	// Format: bruker.BrukerImport, title: dry123, instrument: GENERIC_XRF, PMCs: 3
+	// Spectra BulkSum/A: 1
+	// Spectra MaxValue/A: 1
+	// Spectra Normal/A: 2
+	// PMCs without beam: []
+	// Images: [], missing: [], matched: []
+	//   - Warning: No import.json found, defaults will be used
+	// Upload files: 2
+}
+
+func Example_makeImportReport() {
+	spectrum := func(pmc int32, readType string, detector string, calibrated bool) dataConvertModels.DetectorSample {
+		meta := dataConvertModels.MetaData{
+			"PMC":         dataConvertModels.IntMetaValue(pmc),
+			"READTYPE":    dataConvertModels.StringMetaValue(readType),
+			"DETECTOR_ID": dataConvertModels.StringMetaValue(detector),
+		}
+		if calibrated {
+			meta["XPERCHAN"] = dataConvertModels.FloatMetaValue(10)
+			meta["OFFSET"] = dataConvertModels.FloatMetaValue(0)
+		}
+		return dataConvertModels.DetectorSample{Meta: meta, Spectrum: []int64{1, 2, 3}}
+	}
+
+	data := &dataConvertModels.OutputData{
+		DatasetID:  "report123",
+		Instrument: protos.ScanInstrument_JPL_BREADBOARD,
+		Meta:       dataConvertModels.FileMetaData{Title: "Report test"},
+		PerPMCData: map[int32]*dataConvertModels.PMCData{},
+		MatchedAlignedImages: []dataConvertModels.MatchedAlignedImageMeta{
+			{AlignedBeamPMC: 7, MatchedImageName: "watson.png", MatchedImageFullPath: "./test-data/does-not-exist/watson.png"},
+		},
+	}
+
+	data.SetPMCData(
+		dataConvertModels.BeamLocationByPMC{
+			1: {X: 1, Y: 2},
+			3: {X: 3, Y: 4},
+		},
+		dataConvertModels.HousekeepingData{
+			Header: []string{"SCLK", "Temp", "Note"},
+			Data: map[int32][]dataConvertModels.MetaValue{
+				1: {dataConvertModels.IntMetaValue(100), dataConvertModels.FloatMetaValue(20.5), dataConvertModels.StringMetaValue("ok")},
+				2: {dataConvertModels.IntMetaValue(101), dataConvertModels.IntMetaValue(21)},
+			},
+		},
+		dataConvertModels.DetectorSampleByPMC{
+			1: {spectrum(1, "Normal", "A", true), spectrum(1, "Normal", "B", true)},
+			2: {spectrum(2, "Normal", "A", true), spectrum(2, "Normal", "A", true), spectrum(2, "Normal", "B", true)},
+			3: {spectrum(3, "Normal", "A", false), spectrum(3, "Normal", "B", true)},
+			4: {spectrum(4, "BulkSum", "A", true), spectrum(4, "MaxValue", "A", true)},
+		},
+		map[int32]string{1: "context.png"},
+		dataConvertModels.PseudoIntensities{},
+		map[int32]string{},
+	)
+
+	// Custom meta can name a default context image that the import doesn't have
+	data.DefaultContextImage = "main.png"
+
+	printImportReport(makeImportReport(data, "./test-data/does-not-exist"), nil)
+
+	// Output:
+	// err: <nil>
+	// Format: , title: Report test, instrument: JPL_BREADBOARD, PMCs: 4
+	// Spectra BulkSum/A: 1
+	// Spectra MaxValue/A: 1
+	// Spectra Normal/A: 4
+	// Spectra Normal/B: 3
+	// PMCs without beam: [2]
+	// Housekeeping SCLK: MT_INT, 2 PMCs
+	// Housekeeping Temp: MT_FLOAT, 2 PMCs
+	// Housekeeping Note: MT_STRING, 1 PMCs
+	// Images: [context.png], missing: [context.png watson.png], matched: [watson.png]
+	//   - PMC 2 has 3 Normal spectrum entries
+	//   - 1 spectra have no energy calibration (XPERCHAN/OFFSET)
+	//   - 1 PMCs with spectra have no beam location
+	//   - Housekeeping column Temp has values of 2 different types, treating as MT_FLOAT
+	//   - Matched image watson.png is aligned to PMC 7 which has no context image
+	//   - 2 images referenced by the import were not found
+	//   - Main context image main.png is not one of the imported images
+}
@@ -29,7 +29,6 @@ import (
 	"time"
 
 	"github.com/pixlise/core/v4/api/dataimport/datasetArchive"
-	"github.com/pixlise/core/v4/api/dataimport/internal/converterSelector"
 	"github.com/pixlise/core/v4/api/dataimport/internal/output"
 	"github.com/pixlise/core/v4/api/filepaths"
 	"github.com/pixlise/core/v4/core/fileaccess"
@@ -172,9 +171,8 @@ func ImportFromLocalFileSystem(
 	datasetID string, // Dataset ID being imported. Some importers may need this, others (who have dataset ID in file names being imported) can verify it matches this expected one
 	log logger.ILogger) (string, error) {
 
-	// Pick an importer by inspecting the directory we're about to import from
-	importer, err := converterSelector.SelectDataConverter(localFS, remoteFS, datasetBucket, localImportPath, log)
-
+	// Pick an importer and run it
+	_, data, contextImageSrcPath, err := runConverter(localFS, remoteFS, localImportPath, localPseudoIntensityRangesPath, datasetBucket, datasetID, log)
 	if err != nil {
 		return "", err
 	}
@@ -187,28 +185,6 @@ func ImportFromLocalFileSystem(
 		return "", err
 	}
 
-	log.Infof("Running dataset converter...")
-	data, contextImageSrcPath, err := importer.Import(localImportPath, localPseudoIntensityRangesPath, datasetID, log)
-	if err != nil {
-		return "", fmt.Errorf("Import failed: %v", err)
-	}
-
-	// Apply any overrides we may have
-	customMetaFields, err := readLocalCustomMeta(log, localImportPath)
-	if err != nil {
-		return "", err
-	}
-
-	if len(customMetaFields.Title) > 0 && customMetaFields.Title != " " {
-		log.Infof("Applying custom title: %v", customMetaFields.Title)
-		data.Meta.Title = customMetaFields.Title
-	}
-
-	if len(customMetaFields.DefaultContextImage) > 0 {
-		log.Infof("Applying custom default context image: %v", customMetaFields.DefaultContextImage)
-		data.DefaultContextImage = customMetaFields.DefaultContextImage
-	}
-
 	// Form the output path
 	outPath := filepath.Join(outputScanPath, data.DatasetID)
 
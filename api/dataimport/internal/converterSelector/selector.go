@@ -21,20 +21,34 @@ import (
 	"errors"
 	"fmt"
 	"path/filepath"
-	"strings"
+	"sort"
 
 	"github.com/pixlise/core/v4/api/dataimport/internal/converters/converter"
-	"github.com/pixlise/core/v4/api/dataimport/internal/converters/jplbreadboard"
-	pixlidspipeline "github.com/pixlise/core/v4/api/dataimport/internal/converters/pixl-ids-pipeline"
-	pixlsdf "github.com/pixlise/core/v4/api/dataimport/internal/converters/pixl-sdf"
-	importwds "github.com/pixlise/core/v4/api/dataimport/internal/converters/sem"
-	"github.com/pixlise/core/v4/api/dataimport/internal/converters/soff"
 	dataimportModel "github.com/pixlise/core/v4/api/dataimport/models"
 	"github.com/pixlise/core/v4/core/fileaccess"
 	"github.com/pixlise/core/v4/core/logger"
+
+	// Converters register themselves with the converter package when imported, making them available to select from.
+	// To support a new format, import its package here
+	_ "github.com/pixlise/core/v4/api/dataimport/internal/converters/bruker"
+	_ "github.com/pixlise/core/v4/api/dataimport/internal/converters/jplbreadboard"
+	_ "github.com/pixlise/core/v4/api/dataimport/internal/converters/nexus"
+	_ "github.com/pixlise/core/v4/api/dataimport/internal/converters/pixl-ids-pipeline"
+	_ "github.com/pixlise/core/v4/api/dataimport/internal/converters/pixl-sdf"
+	_ "github.com/pixlise/core/v4/api/dataimport/internal/converters/sem"
+	_ "github.com/pixlise/core/v4/api/dataimport/internal/converters/soff"
 )
 
-// SelectDataConverter - Looks in specified path and determines what importer to use.
+// Detection - How confident a converter is that it can import a directory, and why
+type Detection struct {
+	Plugin     converter.Plugin
+	Confidence float32
+	Reason     string
+}
+
+// SelectDataConverter - Looks in specified path and determines what importer to use. Each converter reports how
+// confident it is that it can import the files, and the most confident one is used. detector.json can also name
+// the converter to use, skipping detection
 func SelectDataConverter(localFS fileaccess.FileAccess, remoteFS fileaccess.FileAccess, datasetBucket string, importPath string, log logger.ILogger) (converter.DataConverter, error) {
 	items, err := localFS.ListObjects(importPath, "")
 	if err != nil {
@@ -42,58 +56,37 @@ func SelectDataConverter(localFS fileaccess.FileAccess, remoteFS fileaccess.File
 	}
 
 	log.Infof("SelectDataConverter: Path contains %v files...", len(items))
-	/*
-		// Check if it's a combined dataset
-		combinedFiles, _ /*imageFileNames* /, _ /*combinedFile1Meta* /, _ /*combinedFile2Meta* /, err := combined.GetCombinedBeamFiles(importPath, log)
-		if len(combinedFiles) > 0 && err == nil {
-			// It's a combined dataset, interpret it as such
-			return combined.MakeCombinedDatasetImporter(SelectDataConverter, remoteFS, datasetBucket), nil
-		}
-	*/
-	// Check if it's a PIXL FM style dataset
-	log.Infof("Checking path \"%v\" for PIXL FM structure...", importPath)
-	pathType, err := pixlidspipeline.DetectPIXLFMStructure(importPath)
-	if len(pathType) > 0 && err == nil {
-		// We know it's a PIXL FM type dataset... it'll later be determined which one
-		return pixlidspipeline.PIXLFM{}, nil
-	}
-
-	log.Infof("Assuming non-PIXL-FM dataset...")
-
-	// If it's all TIF files, assume it's the image map importer format
-	if importwds.IsWDSMapFormat(importPath) {
-		return &importwds.ImageMaps{}, nil
-	}
 
-	// Check if it's SOFF
-	soffFile, err := soff.GetSOFFDescriptionFile(importPath)
-	if err != nil {
-		return nil, err
+	// Manually uploaded datasets contain a detector.json to direct our operation
+	detPath := filepath.Join(importPath, "detector.json")
+	var detectorFile dataimportModel.DetectorChoice
+	err = localFS.ReadJSON(detPath, "", &detectorFile, false)
+	if err == nil {
+		log.Infof("Loaded detector.json, detector: \"%v\", converter: \"%v\"", detectorFile.Detector, detectorFile.Converter)
+	} else {
+		log.Infof("Failed to open detector.json when determining dataset type. Error: %v", err)
 	}
 
-	if len(soffFile) > 0 {
-		return &soff.SOFFImport{}, nil
+	if len(detectorFile.Converter) > 0 {
+		for _, plugin := range converter.Plugins() {
+			if plugin.Name == detectorFile.Converter {
+				log.Infof("Using converter %v specified in detector.json", plugin.Name)
+				return plugin.Make(detectorFile.Detector), nil
+			}
+		}
+		return nil, fmt.Errorf("Unknown converter specified in detector.json: %v", detectorFile.Converter)
 	}
 
-	log.Infof("Assuming non-SOFF format dataset...")
+	detections := DetectConverters(importPath, detectorFile.Detector, log)
+	if len(detections) > 0 {
+		best := detections[0]
+		log.Infof("Matched %v with confidence %v: %v", best.Plugin.Name, best.Confidence, best.Reason)
 
-	// Try to read a detector.json - manually uploaded datasets will contain this to direct our operation...
-	detPath := filepath.Join(importPath, "detector.json")
-	var detectorFile dataimportModel.DetectorChoice
-	err = localFS.ReadJSON(detPath, "", &detectorFile, false)
-	if err == nil {
-		log.Infof("Loaded detector.json...")
-
-		// We found it, work out based on what's in there
-		if strings.HasSuffix(detectorFile.Detector, "-breadboard") {
-			log.Infof("Assuming breadboard dataset...")
-			return jplbreadboard.MSATestData{}, nil
-		} else if detectorFile.Detector == "pixl-em" || detectorFile.Detector == "pixl-fm" {
-			log.Infof("Assuming PIXL %v dataset...", strings.ToUpper(detectorFile.Detector[5:]))
-			return pixlsdf.MakePIXLSDF(detectorFile.Detector == "pixl-fm"), nil
+		if len(detections) > 1 && detections[1].Confidence >= best.Confidence {
+			log.Infof("WARNING: %v also matched with confidence %v: %v. Using %v, set converter in detector.json to choose", detections[1].Plugin.Name, detections[1].Confidence, detections[1].Reason, best.Plugin.Name)
 		}
-	} else {
-		log.Errorf("Failed to open detector.json when determining dataset type. Error: %v", err)
+
+		return best.Plugin.Make(detectorFile.Detector), nil
 	}
 
 	// Log the paths to help us diagnose issues...
@@ -107,3 +100,24 @@ func SelectDataConverter(localFS fileaccess.FileAccess, remoteFS fileaccess.File
 	// Unknown
 	return nil, errors.New("Failed to determine dataset type to import.")
 }
+
+// DetectConverters - Asks each converter if it can import the files in importPath, returning those which can, most
+// confident first. Equally confident converters are in priority order
+func DetectConverters(importPath string, detector string, log logger.ILogger) []Detection {
+	result := []Detection{}
+	for _, plugin := range converter.Plugins() {
+		confidence, reason, err := plugin.Detect(importPath, detector)
+		if err != nil {
+			log.Errorf("Converter %v failed to check path \"%v\": %v", plugin.Name, importPath, err)
+			continue
+		}
+
+		if confidence > 0 {
+			log.Infof("Converter %v can import with confidence %v: %v", plugin.Name, confidence, reason)
+			result = append(result, Detection{Plugin: plugin, Confidence: confidence, Reason: reason})
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool { return result[i].Confidence > result[j].Confidence })
+	return result
+}
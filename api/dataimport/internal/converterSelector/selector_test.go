@@ -0,0 +1,130 @@
+// Licensed to NASA JPL under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. NASA JPL licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package converterSelector
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/pixlise/core/v4/api/dataimport/internal/converters/converter"
+	"github.com/pixlise/core/v4/core/fileaccess"
+	"github.com/pixlise/core/v4/core/logger"
+)
+
+func printDetections(importPath string, detector string) {
+	for _, d := range DetectConverters(importPath, detector, &logger.NullLogger{}) {
+		fmt.Printf("%v: %v, %v\n", d.Plugin.Name, d.Confidence, d.Reason)
+	}
+}
+
+func Example_registeredConverters() {
+	for _, plugin := range converter.Plugins() {
+		fmt.Println(plugin.Name)
+	}
+
+	// Registering the same name twice is a bug
+	defer func() {
+		fmt.Println(recover())
+	}()
+	converter.Register(converter.Plugin{Name: "bruker"})
+
+	// Output:
+	// pixl-fm
+	// wds-maps
+	// soff
+	// hdf5-xrf-map
+	// bruker
+	// breadboard
+	// pixl-sdf
+	// Converter registered twice: bruker
+}
+
+func ExampleDetectConverters() {
+	fmt.Println("BCF:")
+	printDetections("../converters/bruker/test-data/bcf", "")
+	fmt.Println("SPX:")
+	printDetections("../converters/bruker/test-data/spx", "")
+	fmt.Println("HDF5:")
+	printDetections("../converters/nexus/test-data/maps", "")
+	fmt.Println("Breadboard:")
+	printDetections("../converters/nexus/test-data/maps", "jpl-breadboard")
+	fmt.Println("Unknown detector:")
+	printDetections("../converters/nexus/test-data/maps", "pixl-sdf")
+
+	// Output:
+	// BCF:
+	// bruker: 0.8, Found BCF file: Basalt map.bcf
+	// SPX:
+	// bruker: 0.5, Found 2 SPX files
+	// HDF5:
+	// hdf5-xrf-map: 0.8, Found HDF5 file: 2xfm_0001.h5
+	// Breadboard:
+	// hdf5-xrf-map: 0.8, Found HDF5 file: 2xfm_0001.h5
+	// breadboard: 0.3, detector.json specifies jpl-breadboard
+	// Unknown detector:
+	// hdf5-xrf-map: 0.8, Found HDF5 file: 2xfm_0001.h5
+}
+
+func ExampleSelectDataConverter() {
+	// Make a directory which both the HDF5 and Bruker converters can import
+	dir, err := os.MkdirTemp("", "selector")
+	if err != nil {
+		fmt.Println(err)
+	}
+	defer os.RemoveAll(dir)
+
+	for _, f := range []string{"../converters/bruker/test-data/bcf/Basalt map.bcf", "../converters/nexus/test-data/nexus-grid/scan.nxs"} {
+		err = fileaccess.CopyFileLocally(f, filepath.Join(dir, filepath.Base(f)))
+		if err != nil {
+			fmt.Println(err)
+		}
+	}
+
+	localFS := &fileaccess.FSAccess{}
+	log := &logger.StdOutLoggerForTest{}
+	conv, err := SelectDataConverter(localFS, localFS, "", dir, log)
+	fmt.Printf("%T|%v\n", conv, err)
+	fmt.Println(log.LogContains("Matched hdf5-xrf-map with confidence 0.8: Found HDF5 file: scan.nxs"))
+	fmt.Println(log.LogContains("WARNING: bruker also matched with confidence 0.8: Found BCF file: Basalt map.bcf. Using hdf5-xrf-map, set converter in detector.json to choose"))
+
+	// Choosing explicitly
+	err = localFS.WriteJSON(filepath.Join(dir, "detector.json"), "", map[string]string{"converter": "bruker"})
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	conv, err = SelectDataConverter(localFS, localFS, "", dir, log)
+	fmt.Printf("%T|%v\n", conv, err)
+
+	// Choosing one that doesn't exist
+	err = localFS.WriteJSON(filepath.Join(dir, "detector.json"), "", map[string]string{"detector": "pixl-fm", "converter": "something"})
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	conv, err = SelectDataConverter(localFS, localFS, "", dir, log)
+	fmt.Printf("%T|%v\n", conv, err)
+
+	// Output:
+	// *nexus.NeXusImport|<nil>
+	// true
+	// true
+	// *bruker.BrukerImport|<nil>
+	// <nil>|Unknown converter specified in detector.json: something
+}
@@ -0,0 +1,350 @@
+// Licensed to NASA JPL under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. NASA JPL licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package bruker
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// A BCF hypermap: a spectrum per pixel, stored in EDSDatabase/SpectrumDataN, and a header (EDSDatabase/HeaderData)
+// which is XML describing the acquisition, including the sum spectrum of each SpectrumDataN. Files usually have one
+// SpectrumData, but can have one per detector
+type bcfHypermap struct {
+	name     string
+	width    int
+	height   int
+	channels int
+
+	// mm per pixel, 0 if not known
+	pixelSizeX float32
+	pixelSizeY float32
+
+	// mm, if stageOK
+	stageX  float32
+	stageY  float32
+	stageOK bool
+
+	detectors []bcfDetectorMap
+}
+
+type bcfDetectorMap struct {
+	// Sum spectrum, for calibration and timing
+	sum *brukerSpectrum
+
+	// Spectrum of each pixel which was scanned, by pixel index (y*width+x)
+	pixels map[int32][]int64
+}
+
+const bcfHeaderFile = "EDSDatabase/HeaderData"
+const bcfSpectrumDataPrefix = "EDSDatabase/SpectrumData"
+
+// Pixel data starts after this header
+const bcfHypermapDataStart = 0x1A0
+
+// Bytes in the header of each pixel
+const bcfPixelHeaderSize = 22
+
+// Ways pixel data can be packed
+const bcfPacked16BitPulses = 0
+const bcfPacked12BitPulses = 1
+
+func readBCFFile(filePath string) (*bcfHypermap, error) {
+	sfs, err := openSFS(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer sfs.Close()
+
+	headerData, err := sfs.readFile(bcfHeaderFile)
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := parseXML(headerData)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %v", bcfHeaderFile, err)
+	}
+
+	db := root.findClass("TRTSpectrumDatabase")
+	if db == nil {
+		return nil, fmt.Errorf("%v: No spectrum database found", bcfHeaderFile)
+	}
+
+	result := &bcfHypermap{name: db.attr("Name")}
+
+	// Pixel size in um, if the SEM told us
+	if sem := db.findClass("TRTSEMData"); sem != nil {
+		if dx, ok := sem.float("DX"); ok {
+			result.pixelSizeX = float32(dx * umToMM)
+		}
+		if dy, ok := sem.float("DY"); ok {
+			result.pixelSizeY = float32(dy * umToMM)
+		}
+	}
+
+	result.stageX, result.stageY, result.stageOK = readStagePosition(db.findClass("TRTSEMStageData"))
+
+	if chCount, ok := db.float("ChCount"); ok {
+		result.channels = int(chCount)
+	}
+
+	// Read each hypermap we find
+	files := sfs.listFiles()
+	for idx := 0; ; idx++ {
+		dataFile := fmt.Sprintf("%v%v", bcfSpectrumDataPrefix, idx)
+		found := false
+		for _, f := range files {
+			if f == dataFile {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			break
+		}
+
+		sumNode := db.child(strings.TrimPrefix(dataFile, "EDSDatabase/")).findClass("TRTSpectrum")
+		if sumNode == nil {
+			return nil, fmt.Errorf("No sum spectrum found for %v", dataFile)
+		}
+
+		sum, err := readSpectrum(sumNode)
+		if err != nil {
+			return nil, fmt.Errorf("%v sum spectrum: %v", dataFile, err)
+		}
+
+		if result.channels <= 0 {
+			result.channels = len(sum.channels)
+		}
+
+		data, err := sfs.readFile(dataFile)
+		if err != nil {
+			return nil, err
+		}
+
+		width, height, pixels, err := parseHypermap(data, result.channels)
+		if err != nil {
+			return nil, fmt.Errorf("%v: %v", dataFile, err)
+		}
+
+		if len(result.detectors) > 0 && (width != result.width || height != result.height) {
+			return nil, fmt.Errorf("%v: size %vx%v differs from first hypermap size %vx%v", dataFile, width, height, result.width, result.height)
+		}
+
+		result.width = width
+		result.height = height
+		result.detectors = append(result.detectors, bcfDetectorMap{sum: sum, pixels: pixels})
+	}
+
+	if len(result.detectors) <= 0 {
+		return nil, errors.New("No hypermap found in BCF file")
+	}
+
+	return result, nil
+}
+
+// Reading the binary hypermap, keeping track of how far we got. Any read past the end sets err and returns 0
+type hypermapReader struct {
+	data   []byte
+	offset int
+	err    error
+}
+
+func (r *hypermapReader) bytes(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if n < 0 || r.offset+n > len(r.data) {
+		r.err = fmt.Errorf("Unexpected end of data at %v reading %v bytes", r.offset, n)
+		return nil
+	}
+	result := r.data[r.offset : r.offset+n]
+	r.offset += n
+	return result
+}
+
+func (r *hypermapReader) uint(n int) uint64 {
+	b := r.bytes(n)
+	if b == nil {
+		return 0
+	}
+
+	result := uint64(0)
+	for c := n - 1; c >= 0; c-- {
+		result = result<<8 | uint64(b[c])
+	}
+	return result
+}
+
+// Hypermap data is stored line by line, each line having a pixel count and then the pixels which were scanned. Each
+// pixel is stored either as a list of pulses (the channel of each x-ray detected), or as a packed spectrum
+func parseHypermap(data []byte, channels int) (int, int, map[int32][]int64, error) {
+	if len(data) < bcfHypermapDataStart {
+		return 0, 0, nil, errors.New("Hypermap too short")
+	}
+
+	height := int(int32(binary.LittleEndian.Uint32(data[0:])))
+	width := int(int32(binary.LittleEndian.Uint32(data[4:])))
+	if height <= 0 || width <= 0 || width*height > len(data) {
+		return 0, 0, nil, fmt.Errorf("Invalid hypermap size: %vx%v", width, height)
+	}
+
+	pixels := map[int32][]int64{}
+	r := &hypermapReader{data: data, offset: bcfHypermapDataStart}
+
+	for y := 0; y < height && r.err == nil; y++ {
+		pixelCount := int(r.uint(4))
+		for c := 0; c < pixelCount && r.err == nil; c++ {
+			x := int(r.uint(4))
+			chanCount := int(r.uint(2))
+			r.uint(2) // Channels stored for this pixel
+			r.uint(4) // Unknown
+			flag := int(r.uint(2))
+			r.uint(2) // Sometimes the size of the packed data
+			pulseCount := int(r.uint(2))
+			dataSize := int(r.uint(4))
+
+			if r.err != nil {
+				break
+			}
+
+			if x >= width {
+				return 0, 0, nil, fmt.Errorf("Pixel x=%v outside of hypermap width %v on line %v", x, width, y)
+			}
+
+			spectrum := make([]int64, channels)
+			pixelData := r.bytes(dataSize)
+			if r.err != nil {
+				break
+			}
+
+			var err error
+			switch flag {
+			case bcfPacked16BitPulses:
+				for i := 0; i+1 < len(pixelData); i += 2 {
+					addPulse(spectrum, int(binary.LittleEndian.Uint16(pixelData[i:])))
+				}
+			case bcfPacked12BitPulses:
+				unpack12BitPulses(pixelData, pulseCount, spectrum)
+			default:
+				err = unpackSpectrum(pixelData, chanCount, spectrum)
+				if err == nil && pulseCount > 0 && len(pixelData) >= 4 {
+					// Additional pulses stored after the spectrum, the last 4 bytes of which are their size
+					extraSize := int(binary.LittleEndian.Uint32(pixelData[len(pixelData)-4:]))
+					extra := r.bytes(extraSize)
+					for i := 0; i+1 < len(extra) && i/2 < pulseCount; i += 2 {
+						addPulse(spectrum, int(binary.LittleEndian.Uint16(extra[i:])))
+					}
+				}
+			}
+
+			if err != nil {
+				return 0, 0, nil, fmt.Errorf("Pixel %v,%v: %v", x, y, err)
+			}
+
+			pixels[int32(y*width+x)] = spectrum
+		}
+	}
+
+	if r.err != nil {
+		return 0, 0, nil, r.err
+	}
+
+	return width, height, pixels, nil
+}
+
+func addPulse(spectrum []int64, channel int) {
+	// Pulses beyond the channels we're storing are ignored
+	if channel < len(spectrum) {
+		spectrum[channel]++
+	}
+}
+
+// Pulses packed into 12 bits each, 2 pulses per 3 bytes once each pair of bytes is swapped
+func unpack12BitPulses(data []byte, pulseCount int, spectrum []int64) {
+	swapped := make([]byte, len(data))
+	for i := 0; i+1 < len(data); i += 2 {
+		swapped[i] = data[i+1]
+		swapped[i+1] = data[i]
+	}
+
+	for p := 0; p < pulseCount; p++ {
+		i := (p / 2) * 3
+		if i+2 >= len(swapped) {
+			break
+		}
+
+		if p%2 == 0 {
+			addPulse(spectrum, int(swapped[i])<<4|int(swapped[i+1])>>4)
+		} else {
+			addPulse(spectrum, int(swapped[i+1]&0x0F)<<8|int(swapped[i+2]))
+		}
+	}
+}
+
+// A spectrum packed as a series of runs of channels. Each run has a header of the value size and channel count, then
+// a gain (base value) and the values of each channel stored as an offset from the gain. The data ends with 4 bytes
+// which are not part of the spectrum (the size of any additional pulses stored after it)
+func unpackSpectrum(data []byte, chanCount int, spectrum []int64) error {
+	r := &hypermapReader{data: data}
+	channel := 0
+	for r.offset < len(data)-4 && r.err == nil {
+		// Size here is in nibbles, 1 meaning 4 bits per value
+		size := int(r.uint(1))
+		runLength := int(r.uint(1))
+
+		if size == 0 {
+			channel += runLength
+			continue
+		}
+
+		var values []uint64
+		switch size {
+		case 1:
+			gain := r.uint(1)
+			packed := r.bytes((runLength + 1) / 2)
+			for i := 0; i < runLength && r.err == nil; i++ {
+				nibble := packed[i/2] & 0x0F
+				if i%2 == 1 {
+					nibble = packed[i/2] >> 4
+				}
+				values = append(values, uint64(nibble)+gain)
+			}
+		case 2, 4, 8:
+			gain := r.uint(size)
+			for i := 0; i < runLength && r.err == nil; i++ {
+				values = append(values, r.uint(size/2)+gain)
+			}
+		default:
+			return fmt.Errorf("Unexpected packed value size: %v", size)
+		}
+
+		for _, v := range values {
+			if channel < len(spectrum) && channel < chanCount {
+				spectrum[channel] = int64(v)
+			}
+			channel++
+		}
+	}
+
+	return r.err
+}
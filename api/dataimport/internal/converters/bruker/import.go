@@ -0,0 +1,316 @@
+// Licensed to NASA JPL under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. NASA JPL licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package bruker imports data from Bruker Esprit: BCF hypermaps (a spectrum per pixel of a map) or SPX files (one
+// spectrum each, which we import as a list of points)
+package bruker
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pixlise/core/v4/api/dataimport/internal/converters/converter"
+	"github.com/pixlise/core/v4/api/dataimport/internal/dataConvertModels"
+	"github.com/pixlise/core/v4/api/dataimport/internal/importerutils"
+	dataimportModel "github.com/pixlise/core/v4/api/dataimport/models"
+	"github.com/pixlise/core/v4/api/sessionuser"
+	"github.com/pixlise/core/v4/core/fileaccess"
+	"github.com/pixlise/core/v4/core/logger"
+	protos "github.com/pixlise/core/v4/generated-protos"
+)
+
+type BrukerImport struct {
+}
+
+// Detector IDs we give each hypermap in a BCF file, PIXLISE supports up to 2 detectors
+var detectorIDs = []string{"A", "B"}
+
+// GetBrukerFiles - Returns the BCF file in the import path if there is one, otherwise all SPX files. Both are empty if
+// it's not a Bruker dataset
+func GetBrukerFiles(importPath string) (string, []string, error) {
+	localFS := &fileaccess.FSAccess{}
+	items, err := localFS.ListObjects(importPath, "")
+	if err != nil {
+		return "", []string{}, err
+	}
+
+	spxFiles := []string{}
+	for _, item := range items {
+		filePath := filepath.Join(importPath, item)
+		ext := strings.ToLower(filepath.Ext(item))
+		if ext == ".bcf" && isBCFFile(filePath) {
+			return filePath, []string{}, nil
+		} else if ext == ".spx" {
+			spxFiles = append(spxFiles, filePath)
+		}
+	}
+
+	sort.Strings(spxFiles)
+	return "", spxFiles, nil
+}
+
+var Plugin = converter.Plugin{
+	Name:        "bruker",
+	Description: "Bruker Esprit BCF hypermaps or SPX spectra",
+	Priority:    30,
+	Detect: func(importPath string, detector string) (float32, string, error) {
+		bcfFile, spxFiles, err := GetBrukerFiles(importPath)
+		if err != nil {
+			return 0, "", err
+		}
+		if len(bcfFile) > 0 {
+			return converter.ConfidenceHigh, "Found BCF file: " + filepath.Base(bcfFile), nil
+		}
+		if len(spxFiles) > 0 {
+			return converter.ConfidenceMedium, fmt.Sprintf("Found %v SPX files", len(spxFiles)), nil
+		}
+		return 0, "", nil
+	},
+	Make: func(detector string) converter.DataConverter { return &BrukerImport{} },
+}
+
+func init() {
+	converter.Register(Plugin)
+}
+
+func (b *BrukerImport) Import(importPath string, pseudoIntensityRangesPath string, datasetIDExpected string, log logger.ILogger) (*dataConvertModels.OutputData, string, error) {
+	localFS := &fileaccess.FSAccess{}
+
+	// Check if we can load the import instructions JSON file
+	var params dataimportModel.BreadboardImportParams
+	err := localFS.ReadJSON(importPath, "import.json", &params, false)
+	if err != nil {
+		// If there is no import.json file, we can use some suitable defaults, so just warn here
+		log.Infof("Warning: No import.json found, defaults will be used")
+	}
+
+	bcfFile, spxFiles, err := GetBrukerFiles(importPath)
+	if err != nil {
+		return nil, "", err
+	}
+
+	beamLookup := dataConvertModels.BeamLocationByPMC{}
+	spectraLookup := dataConvertModels.DetectorSampleByPMC{}
+	contextImgsPerPMC := map[int32]string{}
+	title := ""
+
+	if len(bcfFile) > 0 {
+		hypermap, err := readBCFFile(bcfFile)
+		if err != nil {
+			return nil, "", err
+		}
+
+		log.Infof("Read %v: %vx%v hypermap of %v channels, %v detector(s)", filepath.Base(bcfFile), hypermap.width, hypermap.height, hypermap.channels, len(hypermap.detectors))
+
+		beamLookup, spectraLookup = makeHypermapBeamsAndSpectra(hypermap, filepath.Base(bcfFile), log)
+
+		imgName := datasetIDExpected + "-total-counts.png"
+		err = importerutils.WriteTotalCountsImage(filepath.Join(importPath, imgName), hypermap.width, hypermap.height, spectraLookup)
+		if err != nil {
+			return nil, "", err
+		}
+		contextImgsPerPMC[0] = imgName
+
+		title = hypermap.name
+	} else if len(spxFiles) > 0 {
+		beamLookup, spectraLookup, err = readSPXPoints(spxFiles)
+		if err != nil {
+			return nil, "", err
+		}
+
+		log.Infof("Read %v SPX files", len(spxFiles))
+	} else {
+		return nil, "", errors.New("No BCF or SPX files found in path: " + importPath)
+	}
+
+	// Calibration in import.json overrides what's in the file
+	if params.XPerChanA != 0 || params.XPerChanB != 0 {
+		overrideCalibration(spectraLookup, params)
+	}
+
+	// Add the bulk sum and max value spectra after the last PMC
+	bulkPMC := int32(0)
+	for pmc := range spectraLookup {
+		bulkPMC = max(bulkPMC, pmc+1)
+	}
+	spectraLookup[bulkPMC] = importerutils.MakeBulkMaxSpectra(spectraLookup, bulkPMC)
+
+	meta := dataConvertModels.FileMetaData{
+		TargetID:   params.TargetID,
+		Target:     params.Target,
+		SiteID:     params.SiteID,
+		Site:       params.Site,
+		Title:      params.Title,
+		SOL:        params.SOL,
+		Instrument: "Bruker",
+	}
+
+	if len(meta.Title) <= 0 {
+		meta.Title = title
+	}
+	if len(meta.Title) <= 0 {
+		meta.Title = datasetIDExpected
+	}
+
+	detectorConfig := params.DetectorConfig
+	if len(detectorConfig) <= 0 {
+		detectorConfig = "Breadboard"
+	}
+
+	creatorId := params.CreatorUserId
+	if len(creatorId) <= 0 {
+		creatorId = sessionuser.PIXLISESystemUserId
+	}
+
+	data := &dataConvertModels.OutputData{
+		DatasetID:      datasetIDExpected,
+		Instrument:     protos.ScanInstrument_GENERIC_XRF,
+		Meta:           meta,
+		DetectorConfig: detectorConfig,
+		BulkQuantFile:  params.BulkQuantFile,
+		PseudoRanges:   []dataConvertModels.PseudoIntensityRange{},
+		PerPMCData:     map[int32]*dataConvertModels.PMCData{},
+		CreatorUserId:  creatorId,
+	}
+
+	data.SetPMCData(beamLookup, dataConvertModels.HousekeepingData{}, spectraLookup, contextImgsPerPMC, dataConvertModels.PseudoIntensities{}, map[int32]string{})
+
+	return data, importPath, nil
+}
+
+func makeSpectrumMeta(pmc int32, detectorID string, sourceFile string, spectrum *brukerSpectrum, liveTime float32, realTime float32) dataConvertModels.MetaData {
+	meta := dataConvertModels.MetaData{
+		"PMC":         dataConvertModels.IntMetaValue(pmc),
+		"DETECTOR_ID": dataConvertModels.StringMetaValue(detectorID),
+		"READTYPE":    dataConvertModels.StringMetaValue("Normal"),
+		"SOURCEFILE":  dataConvertModels.StringMetaValue(sourceFile),
+		"XPERCHAN":    dataConvertModels.FloatMetaValue(spectrum.xperchan),
+		"OFFSET":      dataConvertModels.FloatMetaValue(spectrum.offset),
+	}
+
+	if liveTime > 0 {
+		meta["LIVETIME"] = dataConvertModels.FloatMetaValue(liveTime)
+	}
+	if realTime > 0 {
+		meta["REALTIME"] = dataConvertModels.FloatMetaValue(realTime)
+	}
+
+	return meta
+}
+
+// Each scanned pixel becomes a PMC, numbered y*width+x. Positions are relative to the stage position if we know it
+func makeHypermapBeamsAndSpectra(hypermap *bcfHypermap, sourceFile string, log logger.ILogger) (dataConvertModels.BeamLocationByPMC, dataConvertModels.DetectorSampleByPMC) {
+	beamLookup := dataConvertModels.BeamLocationByPMC{}
+	spectraLookup := dataConvertModels.DetectorSampleByPMC{}
+
+	detectors := hypermap.detectors
+	if len(detectors) > len(detectorIDs) {
+		log.Infof("WARNING: BCF file contains %v hypermaps, only importing the first %v", len(detectors), len(detectorIDs))
+		detectors = detectors[0:len(detectorIDs)]
+	}
+
+	for d, detector := range detectors {
+		// BCF only stores the time for the whole map, so each pixel gets an equal share
+		pixelCount := float32(len(detector.pixels))
+		liveTime := detector.sum.liveTime / pixelCount
+		realTime := detector.sum.realTime / pixelCount
+
+		for pmc, spectrum := range detector.pixels {
+			col := float32(int(pmc) % hypermap.width)
+			row := float32(int(pmc) / hypermap.width)
+
+			if _, ok := beamLookup[pmc]; !ok {
+				loc := dataConvertModels.BeamLocation{
+					X:  col,
+					Y:  row,
+					IJ: map[int32]dataConvertModels.BeamLocationProj{0: {I: col, J: row}},
+				}
+
+				if hypermap.pixelSizeX > 0 && hypermap.pixelSizeY > 0 {
+					loc.X = hypermap.stageX + col*hypermap.pixelSizeX
+					loc.Y = hypermap.stageY + row*hypermap.pixelSizeY
+				}
+
+				beamLookup[pmc] = loc
+			}
+
+			spectraLookup[pmc] = append(spectraLookup[pmc], dataConvertModels.DetectorSample{
+				Meta:     makeSpectrumMeta(pmc, detectorIDs[d], sourceFile, detector.sum, liveTime, realTime),
+				Spectrum: spectrum,
+			})
+		}
+	}
+
+	return beamLookup, spectraLookup
+}
+
+// Each SPX file becomes a PMC, in file name order. If the files don't have stage positions, they're laid out in a line
+func readSPXPoints(spxFiles []string) (dataConvertModels.BeamLocationByPMC, dataConvertModels.DetectorSampleByPMC, error) {
+	beamLookup := dataConvertModels.BeamLocationByPMC{}
+	spectraLookup := dataConvertModels.DetectorSampleByPMC{}
+
+	channelCount := 0
+	for c, spxFile := range spxFiles {
+		spectrum, err := readSPXFile(spxFile)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		// Bulk sum needs all spectra to be the same length
+		if c == 0 {
+			channelCount = len(spectrum.channels)
+		} else if len(spectrum.channels) != channelCount {
+			return nil, nil, fmt.Errorf("%v has %v channels, expected %v", filepath.Base(spxFile), len(spectrum.channels), channelCount)
+		}
+
+		pmc := int32(c)
+		loc := dataConvertModels.BeamLocation{X: float32(c)}
+		if spectrum.stageOK {
+			loc.X = spectrum.stageX
+			loc.Y = spectrum.stageY
+		}
+		beamLookup[pmc] = loc
+
+		spectraLookup[pmc] = []dataConvertModels.DetectorSample{
+			{
+				Meta:     makeSpectrumMeta(pmc, detectorIDs[0], filepath.Base(spxFile), spectrum, spectrum.liveTime, spectrum.realTime),
+				Spectrum: spectrum.channels,
+			},
+		}
+	}
+
+	return beamLookup, spectraLookup, nil
+}
+
+func overrideCalibration(spectraLookup dataConvertModels.DetectorSampleByPMC, params dataimportModel.BreadboardImportParams) {
+	for _, samples := range spectraLookup {
+		for _, sample := range samples {
+			xperchan, offset := params.XPerChanA, params.OffsetA
+			if sample.Meta["DETECTOR_ID"].SValue == "B" {
+				xperchan, offset = params.XPerChanB, params.OffsetB
+			}
+
+			if xperchan != 0 {
+				sample.Meta["XPERCHAN"] = dataConvertModels.FloatMetaValue(xperchan)
+				sample.Meta["OFFSET"] = dataConvertModels.FloatMetaValue(offset)
+			}
+		}
+	}
+}
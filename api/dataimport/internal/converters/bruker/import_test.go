@@ -0,0 +1,155 @@
+// Licensed to NASA JPL under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. NASA JPL licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package bruker
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pixlise/core/v4/api/dataimport/internal/dataConvertModels"
+	"github.com/pixlise/core/v4/core/fileaccess"
+	"github.com/pixlise/core/v4/core/logger"
+	"github.com/pixlise/core/v4/core/utils"
+	protos "github.com/pixlise/core/v4/generated-protos"
+)
+
+// Import writes a context image into the import path, so we work on a copy of the test data
+func importTestData(dir string, datasetID string) {
+	importPath, err := os.MkdirTemp("", "bruker-test")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer os.RemoveAll(importPath)
+
+	files, _ := os.ReadDir(dir)
+	for _, file := range files {
+		err = fileaccess.CopyFileLocally(filepath.Join(dir, file.Name()), filepath.Join(importPath, file.Name()))
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+	}
+
+	bcfFile, spxFiles, err := GetBrukerFiles(importPath)
+	fmt.Printf("GetBrukerFiles: \"%v\", %v, %v\n", strings.TrimPrefix(bcfFile, importPath), len(spxFiles), err)
+
+	importer := &BrukerImport{}
+	data, contextImgDir, err := importer.Import(importPath, "", datasetID, &logger.NullLogger{})
+	fmt.Printf("Import: %v\n", err)
+	if err != nil {
+		return
+	}
+
+	fmt.Printf("contextImgDir matches: %v\n", contextImgDir == importPath)
+	fmt.Printf("Meta: %+v\n", data.Meta)
+	fmt.Printf("Instrument: %v, DetectorConfig: %v, DefaultContextImage: \"%v\"\n", data.Instrument, data.DetectorConfig, data.DefaultContextImage)
+
+	pmcs := utils.GetMapKeys(data.PerPMCData)
+	sort.Slice(pmcs, func(i, j int) bool { return pmcs[i] < pmcs[j] })
+	for _, pmc := range pmcs {
+		fmt.Printf("PMC %v", pmc)
+		pmcData := data.PerPMCData[pmc]
+		if pmcData.Beam != nil {
+			fmt.Printf(" beam: %.3f,%.3f IJ: %v", pmcData.Beam.X, pmcData.Beam.Y, pmcData.Beam.IJ)
+		}
+		fmt.Println()
+
+		for _, s := range pmcData.DetectorSpectra {
+			printSpectrum(s)
+		}
+	}
+
+	if len(data.DefaultContextImage) > 0 {
+		_, err = os.Stat(filepath.Join(importPath, data.DefaultContextImage))
+		fmt.Printf("Context image: %v\n", err)
+	}
+}
+
+func printSpectrum(s dataConvertModels.DetectorSample) {
+	meta := ""
+	for _, key := range []string{"DETECTOR_ID", "READTYPE", "SOURCEFILE", "XPERCHAN", "OFFSET", "LIVETIME", "REALTIME"} {
+		if v, ok := s.Meta[key]; ok {
+			if v.DataType == protos.Experiment_MT_FLOAT {
+				meta += fmt.Sprintf(" %v=%.3f", key, v.FValue)
+			} else {
+				meta += fmt.Sprintf(" %v=%v", key, v.SValue)
+			}
+		}
+	}
+	fmt.Printf(" %v %v\n", meta, s.Spectrum)
+}
+
+func Example_importBCF() {
+	importTestData("./test-data/bcf", "bcf123")
+
+	// Output:
+	// GetBrukerFiles: "/Basalt map.bcf", 0, <nil>
+	// Import: <nil>
+	// contextImgDir matches: true
+	// Meta: {RTT: SCLK:0 SOL: SiteID:0 Site: DriveID:0 TargetID: Target: Title:Basalt µ-map Instrument:Bruker PMCOffset:0}
+	// Instrument: GENERIC_XRF, DetectorConfig: Breadboard, DefaultContextImage: "bcf123-total-counts.png"
+	// PMC 0 beam: 12.000,-3.500 IJ: map[0:{0 0}]
+	//   DETECTOR_ID=A READTYPE=Normal SOURCEFILE=Basalt map.bcf XPERCHAN=10.000 OFFSET=-475.000 LIVETIME=1.000 REALTIME=1.200 [0 1 2 3 0 0 0 0 0 0 0 0 0 0 0 1]
+	// PMC 1 beam: 12.002,-3.500 IJ: map[0:{1 0}]
+	//   DETECTOR_ID=A READTYPE=Normal SOURCEFILE=Basalt map.bcf XPERCHAN=10.000 OFFSET=-475.000 LIVETIME=1.000 REALTIME=1.200 [0 0 0 0 2 1 1 3 0 0 0 0 0 0 0 0]
+	// PMC 2 beam: 12.005,-3.500 IJ: map[0:{2 0}]
+	//   DETECTOR_ID=A READTYPE=Normal SOURCEFILE=Basalt map.bcf XPERCHAN=10.000 OFFSET=-475.000 LIVETIME=1.000 REALTIME=1.200 [11 11 12 13 0 0 0 0 3 4 18 5 6 1000 1200 1301]
+	// PMC 3 beam: 12.000,-3.498 IJ: map[0:{0 1}]
+	//   DETECTOR_ID=A READTYPE=Normal SOURCEFILE=Basalt map.bcf XPERCHAN=10.000 OFFSET=-475.000 LIVETIME=1.000 REALTIME=1.200 [70000 70001 70002 70002 70002 70002 70002 70002 70002 70002 70002 70002 70002 70002 70002 70002]
+	// PMC 5 beam: 12.005,-3.498 IJ: map[0:{2 1}]
+	//   DETECTOR_ID=A READTYPE=Normal SOURCEFILE=Basalt map.bcf XPERCHAN=10.000 OFFSET=-475.000 LIVETIME=1.000 REALTIME=1.200 [0 0 0 0 0 0 0 0 2 1 0 0 0 0 0 0]
+	// PMC 6
+	//   DETECTOR_ID=A READTYPE=BulkSum SOURCEFILE=GeneratedByPIXLISEConverter XPERCHAN=10.000 OFFSET=-475.000 LIVETIME=5.000 [70011 70013 70016 70018 70004 70003 70003 70005 70007 70007 70020 70007 70008 71002 71202 71304]
+	//   DETECTOR_ID=A READTYPE=MaxValue SOURCEFILE=GeneratedByPIXLISEConverter XPERCHAN=10.000 OFFSET=-475.000 LIVETIME=5.000 [70000 70001 70002 70002 70002 70002 70002 70002 70002 70002 70002 70002 70002 70002 70002 70002]
+	// Context image: <nil>
+}
+
+func Example_importSPX() {
+	importTestData("./test-data/spx", "spx456")
+
+	// Output:
+	// GetBrukerFiles: "", 2, <nil>
+	// Import: <nil>
+	// contextImgDir matches: true
+	// Meta: {RTT: SCLK:0 SOL: SiteID:0 Site: DriveID:0 TargetID: Target: Title:spx456 Instrument:Bruker PMCOffset:0}
+	// Instrument: GENERIC_XRF, DetectorConfig: Breadboard, DefaultContextImage: ""
+	// PMC 0 beam: 1.000,2.000 IJ: map[]
+	//   DETECTOR_ID=A READTYPE=Normal SOURCEFILE=point_01.spx XPERCHAN=10.000 OFFSET=-475.000 LIVETIME=2.500 REALTIME=3.000 [0 2 4 6 8 10 12 14 16 18 20 22 24 26 28 30]
+	// PMC 1 beam: 1.100,2.000 IJ: map[]
+	//   DETECTOR_ID=A READTYPE=Normal SOURCEFILE=point_02.spx XPERCHAN=10.000 OFFSET=-475.000 LIVETIME=2.500 REALTIME=3.000 [0 1 2 3 4 0 1 2 3 4 0 1 2 3 4 0]
+	// PMC 2
+	//   DETECTOR_ID=A READTYPE=BulkSum SOURCEFILE=GeneratedByPIXLISEConverter XPERCHAN=10.000 OFFSET=-475.000 LIVETIME=5.000 [0 3 6 9 12 10 13 16 19 22 20 23 26 29 32 30]
+	//   DETECTOR_ID=A READTYPE=MaxValue SOURCEFILE=GeneratedByPIXLISEConverter XPERCHAN=10.000 OFFSET=-475.000 LIVETIME=5.000 [0 2 4 6 8 10 12 14 16 18 20 22 24 26 28 30]
+}
+
+func Example_importNoBrukerFiles() {
+	bcfFile, spxFiles, err := GetBrukerFiles("../soff/test_data")
+	fmt.Printf("GetBrukerFiles: \"%v\", %v, %v\n", bcfFile, spxFiles, err)
+
+	importer := &BrukerImport{}
+	_, _, err = importer.Import("../soff/test_data", "", "abc", &logger.NullLogger{})
+	fmt.Printf("Import: %v\n", err)
+
+	// Output:
+	// GetBrukerFiles: "", [], <nil>
+	// Import: No BCF or SPX files found in path: ../soff/test_data
+}
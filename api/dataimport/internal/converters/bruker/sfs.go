@@ -0,0 +1,333 @@
+// Licensed to NASA JPL under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. NASA JPL licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package bruker
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strings"
+)
+
+// BCF files are an AidAim "Single File System" (SFS) container, which is a virtual file system stored in fixed size
+// chunks. Each chunk starts with a 32 byte header, the first 4 bytes of which is the index of the next chunk when a
+// table spans several chunks. The layout is not documented, this follows what open source readers (eg HyperSpy) do.
+const sfsSignature = "AAMVHFSS"
+
+// Chunks are counted from this offset in the file
+const sfsChunkBase = 0x118
+
+// Bytes in a chunk header
+const sfsChunkHeaderSize = 0x20
+
+// Bytes per file system tree item
+const sfsTreeItemSize = 0x200
+
+// Start of each file when the container is compressed
+const sfsCompressedSignature = "AACS"
+
+// Offset of the first compressed block
+const sfsCompressedDataStart = 0x80
+
+// Size of the header before each compressed block
+const sfsCompressedBlockHeaderSize = 16
+
+// Hypermaps can be big, but we don't want a corrupt file to make us allocate everything
+const maxSFSFileBytes = 8 * 1024 * 1024 * 1024
+
+type sfsItem struct {
+	name        string
+	parent      int32
+	isDir       bool
+	size        uint64
+	chunkTable  uint32
+	dataOffsets []int64
+}
+
+type sfsContainer struct {
+	file        *os.File
+	fileSize    int64
+	chunkSize   uint32
+	usableChunk uint32
+	compressed  bool
+	items       []*sfsItem
+}
+
+func isBCFFile(filePath string) bool {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+
+	sig := make([]byte, len(sfsSignature))
+	_, err = io.ReadFull(f, sig)
+	return err == nil && string(sig) == sfsSignature
+}
+
+func openSFS(filePath string) (*sfsContainer, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	sfs := &sfsContainer{file: f, fileSize: info.Size()}
+	err = sfs.readTree()
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("Failed to read BCF file: %v. Error: %v", filePath, err)
+	}
+
+	return sfs, nil
+}
+
+func (s *sfsContainer) Close() error {
+	return s.file.Close()
+}
+
+func (s *sfsContainer) readAt(offset int64, size int) ([]byte, error) {
+	buf := make([]byte, size)
+	_, err := s.file.ReadAt(buf, offset)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read %v bytes at %v: %v", size, offset, err)
+	}
+	return buf, nil
+}
+
+func (s *sfsContainer) chunkOffset(chunk uint32) int64 {
+	return int64(chunk)*int64(s.chunkSize) + sfsChunkBase
+}
+
+// Reads a table which starts at the given chunk and may continue across several chunks
+func (s *sfsContainer) readChunkedTable(firstChunk uint32, size int) ([]byte, error) {
+	result := []byte{}
+	chunk := firstChunk
+	for len(result) < size {
+		header, err := s.readAt(s.chunkOffset(chunk), sfsChunkHeaderSize)
+		if err != nil {
+			return nil, err
+		}
+
+		readLen := min(int(s.usableChunk), size-len(result))
+		data, err := s.readAt(s.chunkOffset(chunk)+sfsChunkHeaderSize, readLen)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, data...)
+		chunk = binary.LittleEndian.Uint32(header)
+	}
+
+	return result, nil
+}
+
+func (s *sfsContainer) readTree() error {
+	header, err := s.readAt(0, 0x14C)
+	if err != nil {
+		return err
+	}
+
+	if string(header[0:len(sfsSignature)]) != sfsSignature {
+		return errors.New("Not an SFS container")
+	}
+
+	s.chunkSize = binary.LittleEndian.Uint32(header[0x128:])
+	if s.chunkSize <= sfsChunkHeaderSize || s.chunkSize > 64*1024*1024 {
+		return fmt.Errorf("Invalid chunk size: %v", s.chunkSize)
+	}
+	s.usableChunk = s.chunkSize - sfsChunkHeaderSize
+
+	treeChunk := binary.LittleEndian.Uint32(header[0x140:])
+	itemCount := binary.LittleEndian.Uint32(header[0x144:])
+	if itemCount > 100000 {
+		return fmt.Errorf("Invalid file count: %v", itemCount)
+	}
+
+	tree, err := s.readChunkedTable(treeChunk, int(itemCount)*sfsTreeItemSize)
+	if err != nil {
+		return err
+	}
+
+	for c := 0; c < int(itemCount); c++ {
+		raw := tree[c*sfsTreeItemSize : (c+1)*sfsTreeItemSize]
+
+		// Layout: pointer table chunk (4), size (8), 3 timestamps (24), permissions (4), parent (4), reserved (176),
+		// is dir (1), reserved (3), name (256), reserved (32)
+		item := &sfsItem{
+			chunkTable: binary.LittleEndian.Uint32(raw[0:]),
+			size:       binary.LittleEndian.Uint64(raw[4:]),
+			parent:     int32(binary.LittleEndian.Uint32(raw[40:])),
+			isDir:      raw[220] != 0,
+			name:       strings.TrimRight(string(raw[224:480]), "\x00"),
+		}
+
+		if item.parent >= int32(itemCount) {
+			return fmt.Errorf("Invalid parent for %v", item.name)
+		}
+
+		if !item.isDir {
+			if item.size > maxSFSFileBytes {
+				return fmt.Errorf("File %v too large: %v bytes", item.name, item.size)
+			}
+
+			// Files are stored in the container's chunks, so can't be bigger than the container
+			if item.size > uint64(s.fileSize) {
+				return fmt.Errorf("File %v is %v bytes, larger than the container", item.name, item.size)
+			}
+
+			chunkCount := int((item.size + uint64(s.usableChunk) - 1) / uint64(s.usableChunk))
+			table, err := s.readChunkedTable(item.chunkTable, chunkCount*4)
+			if err != nil {
+				return err
+			}
+
+			for i := 0; i < chunkCount; i++ {
+				item.dataOffsets = append(item.dataOffsets, s.chunkOffset(binary.LittleEndian.Uint32(table[i*4:]))+sfsChunkHeaderSize)
+			}
+		}
+
+		s.items = append(s.items, item)
+	}
+
+	// Compression applies to the whole container, so we check the first file
+	for _, item := range s.items {
+		if !item.isDir && item.size >= uint64(len(sfsCompressedSignature)) {
+			sig, err := s.readAt(item.dataOffsets[0], len(sfsCompressedSignature))
+			if err != nil {
+				return err
+			}
+			s.compressed = string(sig) == sfsCompressedSignature
+			break
+		}
+	}
+
+	return nil
+}
+
+func (s *sfsContainer) itemPath(item *sfsItem) string {
+	parts := []string{item.name}
+	for parent, depth := item.parent, 0; parent >= 0 && depth < len(s.items); depth++ {
+		parts = append([]string{s.items[parent].name}, parts...)
+		parent = s.items[parent].parent
+	}
+	return strings.Join(parts, "/")
+}
+
+// Returns the paths of all files in the container
+func (s *sfsContainer) listFiles() []string {
+	result := []string{}
+	for _, item := range s.items {
+		if !item.isDir {
+			result = append(result, s.itemPath(item))
+		}
+	}
+	return result
+}
+
+// Reads the whole of a file, decompressing it if needed
+func (s *sfsContainer) readFile(filePath string) ([]byte, error) {
+	for _, item := range s.items {
+		if !item.isDir && s.itemPath(item) == filePath {
+			raw, err := s.readRaw(item)
+			if err != nil {
+				return nil, err
+			}
+
+			if s.compressed {
+				return decompressSFSFile(raw)
+			}
+			return raw, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%v not found in BCF file", filePath)
+}
+
+func (s *sfsContainer) readRaw(item *sfsItem) ([]byte, error) {
+	// The size comes from the file, so before allocating, check it fits in the chunks the file has for it
+	if item.size > uint64(len(item.dataOffsets))*uint64(s.usableChunk) || item.size > uint64(s.fileSize) {
+		return nil, fmt.Errorf("File %v is %v bytes, more than the container holds for it", item.name, item.size)
+	}
+
+	result := make([]byte, 0, item.size)
+	for _, offset := range item.dataOffsets {
+		readLen := min(uint64(s.usableChunk), item.size-uint64(len(result)))
+		data, err := s.readAt(offset, int(readLen))
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, data...)
+	}
+	return result, nil
+}
+
+// Compressed files are a header followed by a series of zlib compressed blocks
+func decompressSFSFile(raw []byte) ([]byte, error) {
+	if len(raw) < sfsCompressedDataStart || string(raw[0:len(sfsCompressedSignature)]) != sfsCompressedSignature {
+		return nil, errors.New("Invalid compressed file header")
+	}
+
+	blockCount := binary.LittleEndian.Uint32(raw[16:])
+	if blockCount > math.MaxInt32 {
+		return nil, fmt.Errorf("Invalid compressed block count: %v", blockCount)
+	}
+
+	var result bytes.Buffer
+	offset := sfsCompressedDataStart
+	for c := 0; c < int(blockCount); c++ {
+		if offset+sfsCompressedBlockHeaderSize > len(raw) {
+			return nil, fmt.Errorf("Compressed block %v header out of range", c)
+		}
+
+		blockSize := int(binary.LittleEndian.Uint32(raw[offset:]))
+		offset += sfsCompressedBlockHeaderSize
+		if blockSize > len(raw)-offset {
+			return nil, fmt.Errorf("Compressed block %v out of range", c)
+		}
+
+		zr, err := zlib.NewReader(bytes.NewReader(raw[offset : offset+blockSize]))
+		if err != nil {
+			return nil, fmt.Errorf("Failed to decompress block %v: %v", c, err)
+		}
+
+		_, err = io.Copy(&result, zr)
+		zr.Close()
+		if err != nil {
+			return nil, fmt.Errorf("Failed to decompress block %v: %v", c, err)
+		}
+
+		if result.Len() > maxSFSFileBytes {
+			return nil, errors.New("Decompressed file too large")
+		}
+
+		offset += blockSize
+	}
+
+	return result.Bytes(), nil
+}
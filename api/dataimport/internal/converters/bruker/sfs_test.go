@@ -0,0 +1,56 @@
+// Licensed to NASA JPL under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. NASA JPL licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package bruker
+
+import (
+	"fmt"
+	"strings"
+)
+
+func Example_sfsContainer_readRaw() {
+	sfs, err := openSFS("./test-data/bcf/Basalt map.bcf")
+	fmt.Println(err)
+	defer sfs.Close()
+
+	var item *sfsItem
+	for _, it := range sfs.items {
+		if !it.isDir && strings.HasSuffix(it.name, "HeaderData") {
+			item = it
+		}
+	}
+
+	raw, err := sfs.readRaw(item)
+	fmt.Println(len(raw) > 0, uint64(len(raw)) == item.size, err)
+
+	// A corrupt size can't be bigger than the chunks listed for the file, or the container
+	corrupt := *item
+	corrupt.size = uint64(len(item.dataOffsets))*uint64(sfs.usableChunk) + 1
+	_, err = sfs.readRaw(&corrupt)
+	fmt.Println(strings.Replace(err.Error(), fmt.Sprintf("%v", corrupt.size), "<size>", 1))
+
+	corrupt.size = 4 * 1024 * 1024 * 1024
+	corrupt.dataOffsets = make([]int64, corrupt.size/uint64(sfs.usableChunk))
+	_, err = sfs.readRaw(&corrupt)
+	fmt.Println(err)
+
+	// Output:
+	// <nil>
+	// true true <nil>
+	// File HeaderData is <size> bytes, more than the container holds for it
+	// File HeaderData is 4294967296 bytes, more than the container holds for it
+}
@@ -0,0 +1,140 @@
+// Licensed to NASA JPL under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. NASA JPL licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package bruker
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// A spectrum as stored in a TRTSpectrum class, which is what an SPX file contains, and what BCF files store the sum
+// spectrum of each hypermap as
+type brukerSpectrum struct {
+	name     string
+	channels []int64
+
+	// eV
+	xperchan float32
+	offset   float32
+
+	// Seconds, 0 if not in the file
+	liveTime float32
+	realTime float32
+
+	// mm, if stageOK
+	stageX  float32
+	stageY  float32
+	stageOK bool
+}
+
+// Bruker stores calibration in keV
+const keVToEV = 1000
+
+// Bruker stores times in ms
+const msToSec = 0.001
+
+// Bruker stage coordinates are in um
+const umToMM = 0.001
+
+func readSPXFile(filePath string) (*brukerSpectrum, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := parseXML(data)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %v", filePath, err)
+	}
+
+	node := root.findClass("TRTSpectrum")
+	if node == nil {
+		return nil, fmt.Errorf("%v: No spectrum found", filePath)
+	}
+
+	spectrum, err := readSpectrum(node)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %v", filePath, err)
+	}
+
+	// Stage position may be stored with the spectrum or elsewhere in the file
+	stage := node.findClass("TRTSEMStageData")
+	if stage == nil {
+		stage = root.findClass("TRTSEMStageData")
+	}
+	spectrum.stageX, spectrum.stageY, spectrum.stageOK = readStagePosition(stage)
+
+	return spectrum, nil
+}
+
+func readSpectrum(node *xmlNode) (*brukerSpectrum, error) {
+	result := &brukerSpectrum{name: node.attr("Name")}
+
+	header := node.classes("TRTSpectrumHeader")
+	if len(header) <= 0 {
+		return nil, errors.New("No spectrum header found")
+	}
+
+	calibAbs, okAbs := header[0].float("CalibAbs")
+	calibLin, okLin := header[0].float("CalibLin")
+	if !okAbs || !okLin {
+		return nil, errors.New("No energy calibration found")
+	}
+
+	result.offset = float32(calibAbs * keVToEV)
+	result.xperchan = float32(calibLin * keVToEV)
+
+	// Hardware header has the timing
+	hardware := node.findClass("TRTSpectrumHardwareHeader")
+	if hardware != nil {
+		if v, ok := hardware.float("LifeTime"); ok {
+			result.liveTime = float32(v * msToSec)
+		}
+		if v, ok := hardware.float("RealTime"); ok {
+			result.realTime = float32(v * msToSec)
+		}
+	}
+
+	channels := strings.Split(node.text("Channels"), ",")
+	for c, chStr := range channels {
+		v, err := strconv.ParseInt(strings.TrimSpace(chStr), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to read channel %v: %v", c, err)
+		}
+		result.channels = append(result.channels, v)
+	}
+
+	if count, ok := header[0].float("ChannelCount"); ok && int(count) != len(result.channels) {
+		return nil, fmt.Errorf("Expected %v channels, got %v", count, len(result.channels))
+	}
+
+	return result, nil
+}
+
+func readStagePosition(stage *xmlNode) (float32, float32, bool) {
+	if stage == nil {
+		return 0, 0, false
+	}
+
+	x, okX := stage.float("X")
+	y, okY := stage.float("Y")
+	return float32(x * umToMM), float32(y * umToMM), okX && okY
+}
@@ -0,0 +1,131 @@
+// Licensed to NASA JPL under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. NASA JPL licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package bruker
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"golang.org/x/text/encoding/charmap"
+)
+
+// Bruker XML is a tree of serialised classes, each a <ClassInstance Type="TRT..."> element containing values as child
+// elements. Which classes are present varies by software version and instrument, so rather than defining structs for
+// all of them we read a generic tree and look up what we need
+type xmlNode struct {
+	XMLName  xml.Name
+	Attrs    []xml.Attr `xml:",any,attr"`
+	Content  string     `xml:",chardata"`
+	Children []*xmlNode `xml:",any"`
+}
+
+func parseXML(data []byte) (*xmlNode, error) {
+	// Files are usually written as WINDOWS-1252, which the xml package doesn't support itself
+	decoder := xml.NewDecoder(bytes.NewReader(bytes.TrimRight(data, "\x00")))
+	decoder.CharsetReader = func(label string, input io.Reader) (io.Reader, error) {
+		switch strings.ToLower(label) {
+		case "windows-1252", "cp1252":
+			return charmap.Windows1252.NewDecoder().Reader(input), nil
+		case "iso-8859-1", "latin1":
+			return charmap.ISO8859_1.NewDecoder().Reader(input), nil
+		}
+		return nil, fmt.Errorf("Unsupported XML encoding: %v", label)
+	}
+
+	root := &xmlNode{}
+	err := decoder.Decode(root)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse XML: %v", err)
+	}
+
+	return root, nil
+}
+
+func (n *xmlNode) attr(name string) string {
+	for _, a := range n.Attrs {
+		if a.Name.Local == name {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+// Returns the first child element with the given name, or nil
+func (n *xmlNode) child(name string) *xmlNode {
+	if n == nil {
+		return nil
+	}
+
+	for _, c := range n.Children {
+		if c.XMLName.Local == name {
+			return c
+		}
+	}
+	return nil
+}
+
+// Returns the first ClassInstance of the given type, searching the whole tree below this node (depth first), or nil
+func (n *xmlNode) findClass(classType string) *xmlNode {
+	if n == nil {
+		return nil
+	}
+
+	for _, c := range n.Children {
+		if c.XMLName.Local == "ClassInstance" && c.attr("Type") == classType {
+			return c
+		}
+		if found := c.findClass(classType); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// Returns all ClassInstances of the given type directly below this node
+func (n *xmlNode) classes(classType string) []*xmlNode {
+	result := []*xmlNode{}
+	if n == nil {
+		return result
+	}
+
+	for _, c := range n.Children {
+		if c.XMLName.Local == "ClassInstance" && c.attr("Type") == classType {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+func (n *xmlNode) text(name string) string {
+	c := n.child(name)
+	if c == nil {
+		return ""
+	}
+	return strings.TrimSpace(c.Content)
+}
+
+// Reads a number, returning false if not found. Some files are written with a decimal comma, depending on the locale
+// of the PC that wrote them
+func (n *xmlNode) float(name string) (float64, bool) {
+	v, err := strconv.ParseFloat(strings.Replace(n.text(name), ",", ".", 1), 64)
+	return v, err == nil
+}
@@ -0,0 +1,86 @@
+// Licensed to NASA JPL under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. NASA JPL licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package converter
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Confidence levels converters report when detecting if they can import a directory. If several converters can, the
+// most confident one is used
+const (
+	// Directory structure only this format has
+	ConfidenceCertain float32 = 1
+
+	// Found a file with the format's signature or naming
+	ConfidenceHigh float32 = 0.8
+
+	// Found files with the extension this format uses, but other formats may use it too
+	ConfidenceMedium float32 = 0.5
+
+	// Nothing in the files themselves, but detector.json says it's this format
+	ConfidenceHint float32 = 0.3
+)
+
+// Plugin - Describes a converter to the selector, so it can detect which converter to use for a directory. Each
+// converter package exports one of these, and registers it from its init() (see Register)
+type Plugin struct {
+	// Name of the converter, which can also be set in detector.json to skip detection
+	Name        string
+	Description string
+
+	// If several converters are equally confident they can import a directory, the one with the highest priority wins
+	Priority int
+
+	// Returns how confident we are that this converter can import the files in importPath (0 if it can't), and a
+	// reason to log. detector is what detector.json says the data came from, if there is one
+	Detect func(importPath string, detector string) (float32, string, error)
+
+	// Makes the converter, detector being what detector.json says the data came from, if there is one
+	Make func(detector string) DataConverter
+}
+
+var registeredPlugins = []Plugin{}
+var registeredPluginsLock = sync.Mutex{}
+
+// Register - Makes a converter available to the selector. Converter packages call this from their init(), so a
+// converter is available as long as its package is imported. Panics if a converter with the same name is registered
+func Register(plugin Plugin) {
+	registeredPluginsLock.Lock()
+	defer registeredPluginsLock.Unlock()
+
+	for _, p := range registeredPlugins {
+		if p.Name == plugin.Name {
+			panic(fmt.Sprintf("Converter registered twice: %v", plugin.Name))
+		}
+	}
+
+	registeredPlugins = append(registeredPlugins, plugin)
+}
+
+// Plugins - Returns the registered converters, highest priority first
+func Plugins() []Plugin {
+	registeredPluginsLock.Lock()
+	defer registeredPluginsLock.Unlock()
+
+	result := append([]Plugin{}, registeredPlugins...)
+	sort.SliceStable(result, func(i, j int) bool { return result[i].Priority > result[j].Priority })
+	return result
+}
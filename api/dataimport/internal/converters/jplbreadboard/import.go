@@ -25,6 +25,7 @@ import (
 	"strings"
 
 	dataImportHelpers "github.com/pixlise/core/v4/api/dataimport/dataimportHelpers"
+	"github.com/pixlise/core/v4/api/dataimport/internal/converters/converter"
 	"github.com/pixlise/core/v4/api/dataimport/internal/dataConvertModels"
 	"github.com/pixlise/core/v4/api/dataimport/internal/importerutils"
 	dataimportModel "github.com/pixlise/core/v4/api/dataimport/models"
@@ -37,6 +38,24 @@ import (
 type MSATestData struct {
 }
 
+// Breadboard data has nothing distinctive about it, we only know it from detector.json
+var Plugin = converter.Plugin{
+	Name:        "breadboard",
+	Description: "MSA files from JPL or SBU breadboards, configured by an import.json",
+	Priority:    20,
+	Detect: func(importPath string, detector string) (float32, string, error) {
+		if !strings.HasSuffix(detector, "-breadboard") {
+			return 0, "", nil
+		}
+		return converter.ConfidenceHint, "detector.json specifies " + detector, nil
+	},
+	Make: func(detector string) converter.DataConverter { return MSATestData{} },
+}
+
+func init() {
+	converter.Register(Plugin)
+}
+
 // Import - Implementing Importer interface, expects importPath to point to a directory containing importable files, with an import.json
 //
 //	containing fields specific to this importer
@@ -0,0 +1,231 @@
+// Licensed to NASA JPL under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. NASA JPL licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package nexus imports XRF maps stored in HDF5 files, such as NeXus (.nxs) files written by synchrotron beamlines and
+// lab instruments, or the HDF5 output of fitting software such as MAPS or PyMca. See xrfMap.go for what we look for in
+// the file.
+package nexus
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/pixlise/core/v4/api/dataimport/internal/converters/converter"
+	"github.com/pixlise/core/v4/api/dataimport/internal/dataConvertModels"
+	"github.com/pixlise/core/v4/api/dataimport/internal/importerutils"
+	dataimportModel "github.com/pixlise/core/v4/api/dataimport/models"
+	"github.com/pixlise/core/v4/api/sessionuser"
+	"github.com/pixlise/core/v4/core/fileaccess"
+	"github.com/pixlise/core/v4/core/hdf5"
+	"github.com/pixlise/core/v4/core/logger"
+	protos "github.com/pixlise/core/v4/generated-protos"
+)
+
+type NeXusImport struct {
+}
+
+// GetHDF5File - Returns the path of the first HDF5 file in the import path, or an empty string if there isn't one. We
+// check the file signature rather than the extension as these come as .h5, .hdf5, .nxs, .nx5 and more
+func GetHDF5File(importPath string) (string, error) {
+	localFS := &fileaccess.FSAccess{}
+	items, err := localFS.ListObjects(importPath, "")
+	if err != nil {
+		return "", err
+	}
+
+	for _, item := range items {
+		filePath := filepath.Join(importPath, item)
+		if hdf5.IsHDF5(filePath) {
+			return filePath, nil
+		}
+	}
+
+	return "", nil
+}
+
+var Plugin = converter.Plugin{
+	Name:        "hdf5-xrf-map",
+	Description: "XRF maps in HDF5 files, such as NeXus files from beamlines or MAPS/PyMca output",
+	Priority:    40,
+	Detect: func(importPath string, detector string) (float32, string, error) {
+		hdf5File, err := GetHDF5File(importPath)
+		if len(hdf5File) <= 0 || err != nil {
+			return 0, "", err
+		}
+		return converter.ConfidenceHigh, "Found HDF5 file: " + filepath.Base(hdf5File), nil
+	},
+	Make: func(detector string) converter.DataConverter { return &NeXusImport{} },
+}
+
+func init() {
+	converter.Register(Plugin)
+}
+
+func (n *NeXusImport) Import(importPath string, pseudoIntensityRangesPath string, datasetIDExpected string, log logger.ILogger) (*dataConvertModels.OutputData, string, error) {
+	localFS := &fileaccess.FSAccess{}
+
+	// Check if we can load the import instructions JSON file
+	var params dataimportModel.BreadboardImportParams
+	err := localFS.ReadJSON(importPath, "import.json", &params, false)
+	if err != nil {
+		// If there is no import.json file, we can use some suitable defaults, so just warn here
+		log.Infof("Warning: No import.json found, defaults will be used")
+	}
+
+	hdf5Path, err := GetHDF5File(importPath)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if len(hdf5Path) <= 0 {
+		return nil, "", errors.New("No HDF5 file found in path: " + importPath)
+	}
+
+	f, err := hdf5.Open(hdf5Path)
+	if err != nil {
+		return nil, "", err
+	}
+	defer f.Close()
+
+	xrf, err := readXRFMap(f, log)
+	if err != nil {
+		return nil, "", fmt.Errorf("Failed to read XRF map from %v: %v", filepath.Base(hdf5Path), err)
+	}
+
+	log.Infof("Read %v", xrf.describe())
+
+	// Calibration in import.json overrides what's in the file
+	if params.XPerChanA != 0 {
+		xrf.xperchan = params.XPerChanA
+		xrf.offset = params.OffsetA
+	}
+
+	beamLookup, spectraLookup := makeBeamsAndSpectra(xrf, filepath.Base(hdf5Path))
+
+	// Add the bulk sum and max value spectra
+	bulkPMC := int32(xrf.pixelCount())
+	spectraLookup[bulkPMC] = importerutils.MakeBulkMaxSpectra(spectraLookup, bulkPMC)
+
+	// Grid maps get a total counts image to use as a context image
+	contextImgsPerPMC := map[int32]string{}
+	if xrf.isGrid() {
+		imgName := datasetIDExpected + "-total-counts.png"
+		err = importerutils.WriteTotalCountsImage(filepath.Join(importPath, imgName), xrf.pixelDims[1], xrf.pixelDims[0], spectraLookup)
+		if err != nil {
+			return nil, "", err
+		}
+		contextImgsPerPMC[0] = imgName
+	}
+
+	meta := dataConvertModels.FileMetaData{
+		TargetID:   params.TargetID,
+		Target:     params.Target,
+		Title:      params.Title,
+		Instrument: xrf.instrument,
+	}
+
+	if len(meta.Title) <= 0 {
+		meta.Title = xrf.title
+	}
+	if len(meta.Title) <= 0 {
+		meta.Title = datasetIDExpected
+	}
+	if len(meta.Target) <= 0 {
+		meta.Target = xrf.sample
+	}
+
+	detectorConfig := params.DetectorConfig
+	if len(detectorConfig) <= 0 {
+		detectorConfig = "Breadboard"
+	}
+
+	creatorId := params.CreatorUserId
+	if len(creatorId) <= 0 {
+		creatorId = sessionuser.PIXLISESystemUserId
+	}
+
+	data := &dataConvertModels.OutputData{
+		DatasetID:      datasetIDExpected,
+		Instrument:     protos.ScanInstrument_UNKNOWN_INSTRUMENT,
+		Meta:           meta,
+		DetectorConfig: detectorConfig,
+		BulkQuantFile:  params.BulkQuantFile,
+		PseudoRanges:   []dataConvertModels.PseudoIntensityRange{},
+		PerPMCData:     map[int32]*dataConvertModels.PMCData{},
+		CreatorUserId:  creatorId,
+	}
+
+	data.SetPMCData(beamLookup, dataConvertModels.HousekeepingData{}, spectraLookup, contextImgsPerPMC, dataConvertModels.PseudoIntensities{}, map[int32]string{})
+
+	return data, importPath, nil
+}
+
+// Each pixel becomes a PMC, numbered across rows (y*width+x) for grid maps
+func makeBeamsAndSpectra(xrf *xrfMap, sourceFile string) (dataConvertModels.BeamLocationByPMC, dataConvertModels.DetectorSampleByPMC) {
+	beamLookup := dataConvertModels.BeamLocationByPMC{}
+	spectraLookup := dataConvertModels.DetectorSampleByPMC{}
+
+	width := xrf.pixelCount()
+	if xrf.isGrid() {
+		width = xrf.pixelDims[1]
+	}
+
+	for c := 0; c < xrf.pixelCount(); c++ {
+		pmc := int32(c)
+		col := float32(c % width)
+		row := float32(c / width)
+
+		// If the file has no positions, use the pixel coordinates
+		loc := dataConvertModels.BeamLocation{X: col, Y: row}
+		if len(xrf.x) > 0 {
+			loc.X = float32(xrf.x[c])
+			loc.Y = float32(xrf.y[c])
+		}
+
+		if xrf.isGrid() {
+			loc.IJ = map[int32]dataConvertModels.BeamLocationProj{0: {I: col, J: row}}
+		}
+
+		beamLookup[pmc] = loc
+
+		meta := dataConvertModels.MetaData{
+			"PMC":         dataConvertModels.IntMetaValue(pmc),
+			"DETECTOR_ID": dataConvertModels.StringMetaValue("A"),
+			"READTYPE":    dataConvertModels.StringMetaValue("Normal"),
+			"SOURCEFILE":  dataConvertModels.StringMetaValue(sourceFile),
+			"XPERCHAN":    dataConvertModels.FloatMetaValue(xrf.xperchan),
+			"OFFSET":      dataConvertModels.FloatMetaValue(xrf.offset),
+		}
+
+		if len(xrf.liveTime) > 0 {
+			meta["LIVETIME"] = dataConvertModels.FloatMetaValue(float32(xrf.liveTime[c]))
+		}
+		if len(xrf.realTime) > 0 {
+			meta["REALTIME"] = dataConvertModels.FloatMetaValue(float32(xrf.realTime[c]))
+		}
+
+		spectraLookup[pmc] = []dataConvertModels.DetectorSample{
+			{
+				Meta:     meta,
+				Spectrum: xrf.spectrum(c),
+			},
+		}
+	}
+
+	return beamLookup, spectraLookup
+}
@@ -0,0 +1,177 @@
+// Licensed to NASA JPL under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. NASA JPL licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package nexus
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/pixlise/core/v4/api/dataimport/internal/dataConvertModels"
+	"github.com/pixlise/core/v4/core/fileaccess"
+	"github.com/pixlise/core/v4/core/logger"
+	"github.com/pixlise/core/v4/core/utils"
+	protos "github.com/pixlise/core/v4/generated-protos"
+)
+
+// Import writes a context image into the import path, so we work on a copy of the test data
+func importTestData(dir string, datasetID string) {
+	importPath, err := os.MkdirTemp("", "nexus-test")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer os.RemoveAll(importPath)
+
+	files, _ := os.ReadDir(dir)
+	for _, file := range files {
+		err = fileaccess.CopyFileLocally(filepath.Join(dir, file.Name()), filepath.Join(importPath, file.Name()))
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+	}
+
+	hdf5File, err := GetHDF5File(importPath)
+	fmt.Printf("GetHDF5File: %v, %v\n", filepath.Base(hdf5File), err)
+
+	importer := &NeXusImport{}
+	data, contextImgDir, err := importer.Import(importPath, "", datasetID, &logger.NullLogger{})
+	fmt.Printf("Import: %v\n", err)
+	if err != nil {
+		return
+	}
+
+	fmt.Printf("contextImgDir matches: %v\n", contextImgDir == importPath)
+	fmt.Printf("Meta: %+v\n", data.Meta)
+	fmt.Printf("Instrument: %v, DetectorConfig: %v, DefaultContextImage: %v\n", data.Instrument, data.DetectorConfig, data.DefaultContextImage)
+
+	pmcs := utils.GetMapKeys(data.PerPMCData)
+	sort.Slice(pmcs, func(i, j int) bool { return pmcs[i] < pmcs[j] })
+	for _, pmc := range pmcs {
+		fmt.Printf("PMC %v", pmc)
+		pmcData := data.PerPMCData[pmc]
+		if pmcData.Beam != nil {
+			fmt.Printf(" beam: %.3f,%.3f IJ: %v", pmcData.Beam.X, pmcData.Beam.Y, pmcData.Beam.IJ)
+		}
+		fmt.Println()
+
+		for _, s := range pmcData.DetectorSpectra {
+			printSpectrum(s)
+		}
+	}
+
+	if len(data.DefaultContextImage) > 0 {
+		_, err = os.Stat(filepath.Join(importPath, data.DefaultContextImage))
+		fmt.Printf("Context image: %v\n", err)
+	}
+}
+
+func printSpectrum(s dataConvertModels.DetectorSample) {
+	meta := ""
+	for _, key := range []string{"DETECTOR_ID", "READTYPE", "SOURCEFILE", "XPERCHAN", "OFFSET", "LIVETIME", "REALTIME"} {
+		if v, ok := s.Meta[key]; ok {
+			if v.DataType == protos.Experiment_MT_FLOAT {
+				meta += fmt.Sprintf(" %v=%.3f", key, v.FValue)
+			} else {
+				meta += fmt.Sprintf(" %v=%v", key, v.SValue)
+			}
+		}
+	}
+	fmt.Printf(" %v %v\n", meta, s.Spectrum)
+}
+
+func Example_importNeXusGrid() {
+	importTestData("./test-data/nexus-grid", "grid123")
+
+	// Output:
+	// GetHDF5File: scan.nxs, <nil>
+	// Import: <nil>
+	// contextImgDir matches: true
+	// Meta: {RTT: SCLK:0 SOL: SiteID:0 Site: DriveID:0 TargetID: Target:Basalt chip Title:Basalt map Instrument:Lab XRF PMCOffset:0}
+	// Instrument: UNKNOWN_INSTRUMENT, DetectorConfig: Breadboard, DefaultContextImage: grid123-total-counts.png
+	// PMC 0 beam: 0.000,1.000 IJ: map[0:{0 0}]
+	//   DETECTOR_ID=A READTYPE=Normal SOURCEFILE=scan.nxs XPERCHAN=10.000 OFFSET=100.000 LIVETIME=0.500 [1 2 3 1 2 13 1 2 3 1 2 3 1 2 3 1]
+	// PMC 1 beam: 0.050,1.000 IJ: map[0:{1 0}]
+	//   DETECTOR_ID=A READTYPE=Normal SOURCEFILE=scan.nxs XPERCHAN=10.000 OFFSET=100.000 LIVETIME=0.750 [2 3 4 2 3 24 2 3 4 2 3 4 2 3 4 2]
+	// PMC 2 beam: 0.100,1.000 IJ: map[0:{2 0}]
+	//   DETECTOR_ID=A READTYPE=Normal SOURCEFILE=scan.nxs XPERCHAN=10.000 OFFSET=100.000 LIVETIME=1.000 [3 4 5 3 4 35 3 4 5 3 4 5 3 4 5 3]
+	// PMC 3 beam: 0.150,1.000 IJ: map[0:{3 0}]
+	//   DETECTOR_ID=A READTYPE=Normal SOURCEFILE=scan.nxs XPERCHAN=10.000 OFFSET=100.000 LIVETIME=1.250 [4 5 6 4 5 46 4 5 6 4 5 6 4 5 6 4]
+	// PMC 4 beam: 0.000,1.050 IJ: map[0:{0 1}]
+	//   DETECTOR_ID=A READTYPE=Normal SOURCEFILE=scan.nxs XPERCHAN=10.000 OFFSET=100.000 LIVETIME=1.500 [5 6 7 5 6 57 5 6 7 5 6 7 5 6 7 5]
+	// PMC 5 beam: 0.050,1.050 IJ: map[0:{1 1}]
+	//   DETECTOR_ID=A READTYPE=Normal SOURCEFILE=scan.nxs XPERCHAN=10.000 OFFSET=100.000 LIVETIME=1.750 [6 7 8 6 7 68 6 7 8 6 7 8 6 7 8 6]
+	// PMC 6 beam: 0.100,1.050 IJ: map[0:{2 1}]
+	//   DETECTOR_ID=A READTYPE=Normal SOURCEFILE=scan.nxs XPERCHAN=10.000 OFFSET=100.000 LIVETIME=2.000 [7 8 9 7 8 79 7 8 9 7 8 9 7 8 9 7]
+	// PMC 7 beam: 0.150,1.050 IJ: map[0:{3 1}]
+	//   DETECTOR_ID=A READTYPE=Normal SOURCEFILE=scan.nxs XPERCHAN=10.000 OFFSET=100.000 LIVETIME=2.250 [8 9 10 8 9 90 8 9 10 8 9 10 8 9 10 8]
+	// PMC 8 beam: 0.000,1.100 IJ: map[0:{0 2}]
+	//   DETECTOR_ID=A READTYPE=Normal SOURCEFILE=scan.nxs XPERCHAN=10.000 OFFSET=100.000 LIVETIME=2.500 [9 10 11 9 10 101 9 10 11 9 10 11 9 10 11 9]
+	// PMC 9 beam: 0.050,1.100 IJ: map[0:{1 2}]
+	//   DETECTOR_ID=A READTYPE=Normal SOURCEFILE=scan.nxs XPERCHAN=10.000 OFFSET=100.000 LIVETIME=2.750 [10 11 12 10 11 112 10 11 12 10 11 12 10 11 12 10]
+	// PMC 10 beam: 0.100,1.100 IJ: map[0:{2 2}]
+	//   DETECTOR_ID=A READTYPE=Normal SOURCEFILE=scan.nxs XPERCHAN=10.000 OFFSET=100.000 LIVETIME=3.000 [11 12 13 11 12 123 11 12 13 11 12 13 11 12 13 11]
+	// PMC 11 beam: 0.150,1.100 IJ: map[0:{3 2}]
+	//   DETECTOR_ID=A READTYPE=Normal SOURCEFILE=scan.nxs XPERCHAN=10.000 OFFSET=100.000 LIVETIME=3.250 [12 13 14 12 13 134 12 13 14 12 13 14 12 13 14 12]
+	// PMC 12
+	//   DETECTOR_ID=A READTYPE=BulkSum SOURCEFILE=GeneratedByPIXLISEConverter XPERCHAN=10.000 OFFSET=100.000 LIVETIME=22.500 [78 90 102 78 90 882 78 90 102 78 90 102 78 90 102 78]
+	//   DETECTOR_ID=A READTYPE=MaxValue SOURCEFILE=GeneratedByPIXLISEConverter XPERCHAN=10.000 OFFSET=100.000 LIVETIME=22.500 [12 13 14 12 13 134 12 13 14 12 13 14 12 13 14 12]
+	// Context image: <nil>
+}
+
+func Example_importMAPS() {
+	importTestData("./test-data/maps", "maps456")
+
+	// Output:
+	// GetHDF5File: 2xfm_0001.h5, <nil>
+	// Import: <nil>
+	// contextImgDir matches: true
+	// Meta: {RTT: SCLK:0 SOL: SiteID:0 Site: DriveID:0 TargetID:12 Target: Title:MAPS scan Instrument: PMCOffset:0}
+	// Instrument: UNKNOWN_INSTRUMENT, DetectorConfig: Breadboard, DefaultContextImage: maps456-total-counts.png
+	// PMC 0 beam: 10.000,5.000 IJ: map[0:{0 0}]
+	//   DETECTOR_ID=A READTYPE=Normal SOURCEFILE=2xfm_0001.h5 XPERCHAN=20.500 OFFSET=-3.000 REALTIME=2.000 [0 1 1 2 2 3 3 4 4 5 5 6 6 7 7 8]
+	// PMC 1 beam: 10.500,5.000 IJ: map[0:{1 0}]
+	//   DETECTOR_ID=A READTYPE=Normal SOURCEFILE=2xfm_0001.h5 XPERCHAN=20.500 OFFSET=-3.000 REALTIME=2.000 [0 1 1 2 2 3 3 4 4 25 5 6 6 7 7 8]
+	// PMC 2 beam: 11.000,5.000 IJ: map[0:{2 0}]
+	//   DETECTOR_ID=A READTYPE=Normal SOURCEFILE=2xfm_0001.h5 XPERCHAN=20.500 OFFSET=-3.000 REALTIME=2.000 [0 1 1 2 2 3 3 4 4 45 5 6 6 7 7 8]
+	// PMC 3 beam: 10.000,5.250 IJ: map[0:{0 1}]
+	//   DETECTOR_ID=A READTYPE=Normal SOURCEFILE=2xfm_0001.h5 XPERCHAN=20.500 OFFSET=-3.000 REALTIME=2.000 [0 1 1 2 2 3 3 4 4 65 5 6 6 7 7 8]
+	// PMC 4 beam: 10.500,5.250 IJ: map[0:{1 1}]
+	//   DETECTOR_ID=A READTYPE=Normal SOURCEFILE=2xfm_0001.h5 XPERCHAN=20.500 OFFSET=-3.000 REALTIME=2.000 [0 1 1 2 2 3 3 4 4 85 5 6 6 7 7 8]
+	// PMC 5 beam: 11.000,5.250 IJ: map[0:{2 1}]
+	//   DETECTOR_ID=A READTYPE=Normal SOURCEFILE=2xfm_0001.h5 XPERCHAN=20.500 OFFSET=-3.000 REALTIME=2.000 [0 1 1 2 2 3 3 4 4 105 5 6 6 7 7 8]
+	// PMC 6
+	//   DETECTOR_ID=A READTYPE=BulkSum SOURCEFILE=GeneratedByPIXLISEConverter XPERCHAN=20.500 OFFSET=-3.000 [0 6 6 12 12 18 18 24 24 330 30 36 36 42 42 48]
+	//   DETECTOR_ID=A READTYPE=MaxValue SOURCEFILE=GeneratedByPIXLISEConverter XPERCHAN=20.500 OFFSET=-3.000 [0 1 1 2 2 3 3 4 4 105 5 6 6 7 7 8]
+	// Context image: <nil>
+}
+
+func Example_importNoHDF5() {
+	hdf5File, err := GetHDF5File("../soff/test_data")
+	fmt.Printf("GetHDF5File: \"%v\", %v\n", hdf5File, err)
+
+	importer := &NeXusImport{}
+	_, _, err = importer.Import("../soff/test_data", "", "abc", &logger.NullLogger{})
+	fmt.Printf("Import: %v\n", err)
+
+	// Output:
+	// GetHDF5File: "", <nil>
+	// Import: No HDF5 file found in path: ../soff/test_data
+}
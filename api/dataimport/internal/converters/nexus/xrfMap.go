@@ -0,0 +1,564 @@
+// Licensed to NASA JPL under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. NASA JPL licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package nexus
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/pixlise/core/v4/core/hdf5"
+	"github.com/pixlise/core/v4/core/logger"
+)
+
+// Everything we need from an HDF5 file to make a dataset. There's no one standard layout for XRF maps, so we look for:
+//   - The spectra: the signal of an NXdata group, or failing that, the biggest 2D (point, channel) or 3D (y, x, channel)
+//     numeric dataset. Some software (eg MAPS) stores them channel first, (channel, y, x), which we also handle
+//   - Energy calibration: from an energy axis of the NXdata group, or a 1D dataset named energy, or an energy_calib
+//     dataset with offset and slope
+//   - Positions: from the NXdata axes, or datasets named x/y, otherwise we use the pixel coordinates
+//   - Live/real time: datasets named live_time/real_time with a value per pixel
+type xrfMap struct {
+	spectraPath  string
+	spectra      *hdf5.Data
+	channelFirst bool
+	channels     int
+
+	// Either (height, width) for grid maps, or (points) for a list of points
+	pixelDims []int
+
+	// Per pixel, in mm if we know the units. Empty if not in the file
+	x []float64
+	y []float64
+
+	// eV calibration, 0 if not found
+	xperchan float32
+	offset   float32
+
+	// Per pixel, in seconds. Empty if not in the file
+	liveTime []float64
+	realTime []float64
+
+	title      string
+	instrument string
+	sample     string
+}
+
+func (m *xrfMap) pixelCount() int {
+	count := 1
+	for _, d := range m.pixelDims {
+		count *= d
+	}
+	return count
+}
+
+func (m *xrfMap) isGrid() bool {
+	return len(m.pixelDims) == 2
+}
+
+// Returns the spectrum of the given pixel, rounding to whole counts
+func (m *xrfMap) spectrum(pixel int) []int64 {
+	result := make([]int64, m.channels)
+	pixels := m.pixelCount()
+
+	for ch := range result {
+		idx := pixel*m.channels + ch
+		if m.channelFirst {
+			idx = ch*pixels + pixel
+		}
+
+		v := m.spectra.Float64(idx)
+		if v > 0 {
+			result[ch] = int64(v + 0.5)
+		}
+	}
+
+	return result
+}
+
+// Names (lower case) of datasets we look for if the NXdata group doesn't tell us
+var xDatasetNames = []string{"x", "x_axis", "x_position", "x_positions", "sample_x", "samx"}
+var yDatasetNames = []string{"y", "y_axis", "y_position", "y_positions", "sample_y", "samy"}
+var energyDatasetNames = []string{"energy", "energy_axis", "energies"}
+var energyCalibDatasetNames = []string{"energy_calib", "energy_calibration", "calibration"}
+var liveTimeDatasetNames = []string{"live_time", "livetime", "elapsed_live_time"}
+var realTimeDatasetNames = []string{"real_time", "realtime", "elapsed_real_time"}
+
+// NeXus "axes" attributes name a dataset per dimension, "." for dimensions without one
+const noAxis = "."
+
+func readXRFMap(f *hdf5.File, log logger.ILogger) (*xrfMap, error) {
+	// Find everything we may be interested in
+	datasets := []*hdf5.Object{}
+	groupsByClass := map[string][]*hdf5.Object{}
+	err := f.Walk(func(obj *hdf5.Object) error {
+		if obj.IsDataset() {
+			datasets = append(datasets, obj)
+		} else if nxClass := obj.AttrString("NX_class"); len(nxClass) > 0 {
+			groupsByClass[nxClass] = append(groupsByClass[nxClass], obj)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &xrfMap{}
+
+	spectraObj, axes := findNXDataSignal(f, groupsByClass["NXdata"])
+	if spectraObj == nil {
+		spectraObj = findLargestSpectraDataset(datasets)
+		axes = []string{}
+	}
+
+	if spectraObj == nil {
+		return nil, errors.New("Failed to find spectra, expected a 2D (point, channel) or 3D (y, x, channel) numeric dataset")
+	}
+
+	result.spectraPath = spectraObj.Path
+	dims := []int{}
+	for _, d := range spectraObj.Dims() {
+		dims = append(dims, int(d))
+	}
+
+	// Work out which axis is the channel axis, and read the energy calibration
+	energy := findAxisDataset(f, spectraObj, axes, -1, func(obj *hdf5.Object) bool {
+		return isEnergyUnits(obj.AttrString("units")) || strings.Contains(strings.ToLower(obj.Name()), "energy")
+	})
+	if energy == nil {
+		energy = findDatasetByName(datasets, spectraObj, energyDatasetNames, func(obj *hdf5.Object) bool {
+			return len(obj.Dims()) == 1 && (int(obj.Dims()[0]) == dims[0] || int(obj.Dims()[0]) == dims[len(dims)-1])
+		})
+	}
+
+	result.channelFirst = decideChannelFirst(dims, energy, len(axes) > 0)
+
+	if result.channelFirst {
+		result.channels = dims[0]
+		result.pixelDims = dims[1:]
+	} else {
+		result.channels = dims[len(dims)-1]
+		result.pixelDims = dims[0 : len(dims)-1]
+	}
+
+	if energy != nil && int(energy.ElementCount()) == result.channels {
+		result.xperchan, result.offset, err = readEnergyAxisCalibration(energy)
+	} else {
+		calib := findDatasetByName(datasets, spectraObj, energyCalibDatasetNames, func(obj *hdf5.Object) bool {
+			return obj.ElementCount() >= 2 && obj.ElementCount() <= 3
+		})
+		if calib != nil {
+			result.xperchan, result.offset, err = readEnergyCalibCoefficients(calib)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if result.xperchan == 0 {
+		log.Infof("WARNING: No energy calibration found for %v", result.spectraPath)
+	}
+
+	// Positions, which can be per-pixel or per-row/column
+	result.x, result.y, err = readPositions(f, datasets, spectraObj, axes, result)
+	if err != nil {
+		return nil, err
+	}
+
+	result.liveTime, err = readPerPixelValues(datasets, spectraObj, liveTimeDatasetNames, result.pixelDims)
+	if err != nil {
+		return nil, err
+	}
+
+	result.realTime, err = readPerPixelValues(datasets, spectraObj, realTimeDatasetNames, result.pixelDims)
+	if err != nil {
+		return nil, err
+	}
+
+	result.title = readFirstString(groupsByClass["NXentry"], "title")
+	result.instrument = readFirstString(groupsByClass["NXinstrument"], "name")
+	result.sample = readFirstString(groupsByClass["NXsample"], "name")
+
+	// Finally, read the spectra themselves
+	result.spectra, err = spectraObj.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func isSpectraShape(obj *hdf5.Object) bool {
+	rank := len(obj.Dims())
+	return obj.IsDataset() && obj.Type().IsNumeric() && (rank == 2 || rank == 3) && obj.ElementCount() > 0
+}
+
+// Returns the signal dataset of the first NXdata group that looks like it has spectra, and the names of its axes
+func findNXDataSignal(f *hdf5.File, nxDataGroups []*hdf5.Object) (*hdf5.Object, []string) {
+	for _, group := range nxDataGroups {
+		signal := group.AttrString("signal")
+
+		// Older NeXus files mark the signal with an attribute on the dataset instead
+		if len(signal) <= 0 {
+			for _, name := range group.Children() {
+				child, err := group.Child(name)
+				if err == nil && child.IsDataset() {
+					if attr := child.Attr("signal"); attr != nil && (attr.Value.Float64(0) == 1 || attr.Value.String(0) == "1") {
+						signal = name
+						break
+					}
+				}
+			}
+		}
+
+		if len(signal) <= 0 {
+			continue
+		}
+
+		obj, err := group.Child(signal)
+		if err != nil || !isSpectraShape(obj) {
+			continue
+		}
+
+		axes := []string{}
+		if attr := group.Attr("axes"); attr != nil {
+			axes = attr.Value.Strings()
+		} else if attr := obj.Attr("axes"); attr != nil {
+			axes = attr.Value.Strings()
+		}
+
+		// Axes used to be stored as one string separated by : or ,
+		if len(axes) == 1 {
+			axes = strings.FieldsFunc(axes[0], func(r rune) bool { return r == ':' || r == ',' })
+		}
+
+		if len(axes) != len(obj.Dims()) {
+			axes = []string{}
+		}
+
+		return obj, axes
+	}
+
+	return nil, []string{}
+}
+
+func findLargestSpectraDataset(datasets []*hdf5.Object) *hdf5.Object {
+	var result *hdf5.Object
+	for _, obj := range datasets {
+		if isSpectraShape(obj) && (result == nil || obj.ElementCount() > result.ElementCount()) {
+			result = obj
+		}
+	}
+	return result
+}
+
+// Returns the dataset named by the NXdata axes for the given dimension (or any dimension if dim is -1) which passes the
+// filter, or nil if none does
+func findAxisDataset(f *hdf5.File, spectraObj *hdf5.Object, axes []string, dim int, accept func(obj *hdf5.Object) bool) *hdf5.Object {
+	for c, name := range axes {
+		if (dim >= 0 && c != dim) || name == noAxis {
+			continue
+		}
+
+		obj, err := f.Get(path.Join(path.Dir(spectraObj.Path), name))
+		if err == nil && obj.IsDataset() && obj.Type().IsNumeric() && accept(obj) {
+			return obj
+		}
+	}
+
+	return nil
+}
+
+// Looks for a dataset with one of the given names (case insensitive) which passes the filter. We prefer datasets in the
+// same group as the spectra, then the closest one to it in the tree
+func findDatasetByName(datasets []*hdf5.Object, spectraObj *hdf5.Object, names []string, accept func(obj *hdf5.Object) bool) *hdf5.Object {
+	var result *hdf5.Object
+	resultScore := -1
+
+	spectraParts := strings.Split(spectraObj.Path, "/")
+	for _, obj := range datasets {
+		nameMatch := false
+		for _, name := range names {
+			if strings.ToLower(obj.Name()) == name {
+				nameMatch = true
+				break
+			}
+		}
+
+		if !nameMatch || obj.Path == spectraObj.Path || !obj.Type().IsNumeric() || !accept(obj) {
+			continue
+		}
+
+		// Score by how much of the path is shared with the spectra
+		score := 0
+		parts := strings.Split(obj.Path, "/")
+		for score < len(parts)-1 && score < len(spectraParts)-1 && parts[score] == spectraParts[score] {
+			score++
+		}
+
+		if score > resultScore {
+			result = obj
+			resultScore = score
+		}
+	}
+
+	return result
+}
+
+// The channel axis is last, as NeXus recommends, unless we can see otherwise
+func decideChannelFirst(dims []int, energy *hdf5.Object, hasAxes bool) bool {
+	last := dims[len(dims)-1]
+	if energy != nil {
+		energyLen := int(energy.ElementCount())
+		return energyLen != last && energyLen == dims[0]
+	}
+
+	if hasAxes {
+		return false
+	}
+
+	// Spectra are generally a power of 2 channels, so if only the first dimension looks like that, it's channel first
+	return isLikelyChannelCount(dims[0]) && !isLikelyChannelCount(last)
+}
+
+func isLikelyChannelCount(n int) bool {
+	return n >= 256 && n&(n-1) == 0
+}
+
+func isEnergyUnits(units string) bool {
+	u := strings.ToLower(units)
+	return u == "ev" || u == "kev"
+}
+
+// PIXLISE wants eV
+func energyToEV(units string, values []float64) {
+	scale := 1.0
+	if strings.ToLower(units) == "kev" {
+		scale = 1000
+	} else if len(units) <= 0 {
+		// If all values are small, it's likely keV
+		maxValue := 0.0
+		for _, v := range values {
+			maxValue = max(maxValue, v)
+		}
+
+		if maxValue > 0 && maxValue < 200 {
+			scale = 1000
+		}
+	}
+
+	for c := range values {
+		values[c] *= scale
+	}
+}
+
+// Forms the linear calibration from an energy value per channel
+func readEnergyAxisCalibration(obj *hdf5.Object) (float32, float32, error) {
+	data, err := obj.Read()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	energy := data.Float64s()
+	if len(energy) < 2 {
+		return 0, 0, nil
+	}
+
+	energyToEV(obj.AttrString("units"), energy)
+
+	xperchan := (energy[len(energy)-1] - energy[0]) / float64(len(energy)-1)
+	return float32(xperchan), float32(energy[0]), nil
+}
+
+// Reads offset, slope (and possibly a quadratic term we can't use) as stored by MAPS
+func readEnergyCalibCoefficients(obj *hdf5.Object) (float32, float32, error) {
+	data, err := obj.Read()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	coeffs := data.Float64s()
+	energyToEV(obj.AttrString("units"), coeffs)
+
+	return float32(coeffs[1]), float32(coeffs[0]), nil
+}
+
+func readPositions(f *hdf5.File, datasets []*hdf5.Object, spectraObj *hdf5.Object, axes []string, m *xrfMap) ([]float64, []float64, error) {
+	// Dimensions of the pixels in the spectra dataset
+	xDim := len(m.pixelDims) - 1
+	yDim := -1
+	if m.isGrid() {
+		yDim = 0
+	}
+	if m.channelFirst {
+		xDim++
+		yDim++
+	}
+
+	x, err := readPositionAxis(f, datasets, spectraObj, axes, xDim, true, xDatasetNames, m)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	y := []float64{}
+	if m.isGrid() || len(x) > 0 {
+		y, err = readPositionAxis(f, datasets, spectraObj, axes, yDim, false, yDatasetNames, m)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if len(x) <= 0 || len(y) <= 0 {
+		return []float64{}, []float64{}, nil
+	}
+
+	return x, y, nil
+}
+
+// Reads the position of each pixel along the given axis (dim being its dimension in the spectra dataset, -1 if none).
+// Positions can be stored per pixel, or along the axis for grid maps. Returns an empty slice if not found
+func readPositionAxis(f *hdf5.File, datasets []*hdf5.Object, spectraObj *hdf5.Object, axes []string, dim int, isX bool, names []string, m *xrfMap) ([]float64, error) {
+	pixelCount := m.pixelCount()
+
+	// Length along the axis, for grid maps
+	axisLen := -1
+	if m.isGrid() {
+		axisLen = m.pixelDims[0]
+		if isX {
+			axisLen = m.pixelDims[1]
+		}
+	}
+
+	accept := func(obj *hdf5.Object) bool {
+		count := int(obj.ElementCount())
+		return count == pixelCount || (len(obj.Dims()) == 1 && count == axisLen)
+	}
+
+	obj := (*hdf5.Object)(nil)
+	if dim >= 0 {
+		obj = findAxisDataset(f, spectraObj, axes, dim, accept)
+	}
+	if obj == nil {
+		obj = findDatasetByName(datasets, spectraObj, names, accept)
+	}
+	if obj == nil {
+		return []float64{}, nil
+	}
+
+	data, err := obj.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	values := data.Float64s()
+	scale := positionScaleToMM(obj.AttrString("units"))
+	for c := range values {
+		values[c] *= scale
+	}
+
+	if len(values) == pixelCount {
+		return values, nil
+	}
+
+	// Expand the axis values to every pixel
+	result := make([]float64, pixelCount)
+	width := m.pixelDims[1]
+	for c := range result {
+		if isX {
+			result[c] = values[c%width]
+		} else {
+			result[c] = values[c/width]
+		}
+	}
+
+	return result, nil
+}
+
+// Beam locations are stored in mm for non-PIXL instruments. If we don't know the units, we leave the values as they are
+func positionScaleToMM(units string) float64 {
+	switch strings.ToLower(units) {
+	case "m":
+		return 1000
+	case "cm":
+		return 10
+	case "um", "µm", "micron", "microns":
+		return 0.001
+	case "nm":
+		return 0.000001
+	}
+	return 1
+}
+
+// Reads a dataset of one value per pixel, or a scalar which applies to all pixels. Returns an empty slice if not found
+func readPerPixelValues(datasets []*hdf5.Object, spectraObj *hdf5.Object, names []string, pixelDims []int) ([]float64, error) {
+	pixelCount := 1
+	for _, d := range pixelDims {
+		pixelCount *= d
+	}
+
+	obj := findDatasetByName(datasets, spectraObj, names, func(obj *hdf5.Object) bool {
+		count := int(obj.ElementCount())
+		return count == pixelCount || count == 1
+	})
+
+	if obj == nil {
+		return []float64{}, nil
+	}
+
+	data, err := obj.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	values := data.Float64s()
+	if len(values) == pixelCount {
+		return values, nil
+	}
+
+	result := make([]float64, pixelCount)
+	for c := range result {
+		result[c] = values[0]
+	}
+	return result, nil
+}
+
+// Reads a string dataset (eg the title of an NXentry) from the first group that has one
+func readFirstString(groups []*hdf5.Object, name string) string {
+	for _, group := range groups {
+		obj, err := group.Child(name)
+		if err != nil || !obj.IsDataset() || !obj.Type().IsString() {
+			continue
+		}
+
+		data, err := obj.Read()
+		if err == nil && data.Len() > 0 {
+			return data.String(0)
+		}
+	}
+
+	return ""
+}
+
+func (m *xrfMap) describe() string {
+	layout := "points"
+	if m.isGrid() {
+		layout = fmt.Sprintf("%vx%v grid", m.pixelDims[1], m.pixelDims[0])
+	}
+	return fmt.Sprintf("%v: %v spectra of %v channels in a %v", m.spectraPath, m.pixelCount(), m.channels, layout)
+}
@@ -26,6 +26,7 @@ import (
 	"unicode/utf8"
 
 	dataImportHelpers "github.com/pixlise/core/v4/api/dataimport/dataimportHelpers"
+	"github.com/pixlise/core/v4/api/dataimport/internal/converters/converter"
 	"github.com/pixlise/core/v4/api/dataimport/internal/dataConvertModels"
 	"github.com/pixlise/core/v4/api/dataimport/internal/importerutils"
 	"github.com/pixlise/core/v4/core/fileaccess"
@@ -419,6 +420,24 @@ func DetectPIXLFMStructure(importPath string) (string, error) {
 	return "", errors.New("unknown data source type")
 }
 
+var Plugin = converter.Plugin{
+	Name:        "pixl-fm",
+	Description: "PIXL flight model data as delivered by the IDS pipeline (RSI, housekeeping, beam locations, images)",
+	Priority:    70,
+	Detect: func(importPath string, detector string) (float32, string, error) {
+		pathType, err := DetectPIXLFMStructure(importPath)
+		if len(pathType) <= 0 || err != nil {
+			return 0, "", nil
+		}
+		return converter.ConfidenceCertain, "Found PIXL FM directory structure: " + pathType, nil
+	},
+	Make: func(detector string) converter.DataConverter { return PIXLFM{} },
+}
+
+func init() {
+	converter.Register(Plugin)
+}
+
 func validatePaths(importPath string, validpaths []string) error {
 	validated := []string{}
 	c, _ := os.ReadDir(importPath)
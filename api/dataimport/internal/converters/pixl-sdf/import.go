@@ -25,6 +25,7 @@ import (
 	"time"
 
 	dataImportHelpers "github.com/pixlise/core/v4/api/dataimport/dataimportHelpers"
+	"github.com/pixlise/core/v4/api/dataimport/internal/converters/converter"
 	"github.com/pixlise/core/v4/api/dataimport/internal/converters/jplbreadboard"
 	"github.com/pixlise/core/v4/api/dataimport/internal/dataConvertModels"
 	"github.com/pixlise/core/v4/api/dataimport/internal/importerutils"
@@ -49,6 +50,24 @@ func MakePIXLSDF(isFM bool) PIXLSDF {
 	return PIXLSDF{isFM: isFM}
 }
 
+// SDF uploads are converted to RSI files before import, so we only know them from detector.json
+var Plugin = converter.Plugin{
+	Name:        "pixl-sdf",
+	Description: "PIXL EM or FM data uploaded as an SDF (science data frame) capture",
+	Priority:    10,
+	Detect: func(importPath string, detector string) (float32, string, error) {
+		if detector != "pixl-em" && detector != "pixl-fm" {
+			return 0, "", nil
+		}
+		return converter.ConfidenceHint, "detector.json specifies " + detector, nil
+	},
+	Make: func(detector string) converter.DataConverter { return MakePIXLSDF(detector == "pixl-fm") },
+}
+
+func init() {
+	converter.Register(Plugin)
+}
+
 type importCalibrationOverride struct {
 	evStart   float32
 	evPerChan float32
@@ -8,6 +8,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/pixlise/core/v4/api/dataimport/internal/converters/converter"
 	"github.com/pixlise/core/v4/api/dataimport/internal/dataConvertModels"
 	dataimportModel "github.com/pixlise/core/v4/api/dataimport/models"
 	"github.com/pixlise/core/v4/api/sessionuser"
@@ -43,6 +44,23 @@ func IsWDSMapFormat(importPath string) bool {
 	return count > 0
 }
 
+var Plugin = converter.Plugin{
+	Name:        "wds-maps",
+	Description: "User-defined scans of TIF maps (*" + suffixImageMap + "), one per element or channel",
+	Priority:    60,
+	Detect: func(importPath string, detector string) (float32, string, error) {
+		if !IsWDSMapFormat(importPath) {
+			return 0, "", nil
+		}
+		return converter.ConfidenceHigh, "All user-data files are " + suffixImageMap + " images", nil
+	},
+	Make: func(detector string) converter.DataConverter { return &ImageMaps{} },
+}
+
+func init() {
+	converter.Register(Plugin)
+}
+
 type ImageMaps struct {
 }
 
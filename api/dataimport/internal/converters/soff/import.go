@@ -25,6 +25,7 @@ import (
 	"strings"
 
 	dataImportHelpers "github.com/pixlise/core/v4/api/dataimport/dataimportHelpers"
+	"github.com/pixlise/core/v4/api/dataimport/internal/converters/converter"
 	"github.com/pixlise/core/v4/api/dataimport/internal/dataConvertModels"
 	"github.com/pixlise/core/v4/api/dataimport/internal/importerutils"
 	"github.com/pixlise/core/v4/core/fileaccess"
@@ -278,6 +279,24 @@ func GetSOFFDescriptionFile(importPath string) (string, error) {
 	return "", nil
 }
 
+var Plugin = converter.Plugin{
+	Name:        "soff",
+	Description: "PIXL data described by a SOFF (PDS4 style) XML file",
+	Priority:    50,
+	Detect: func(importPath string, detector string) (float32, string, error) {
+		soffFile, err := GetSOFFDescriptionFile(importPath)
+		if len(soffFile) <= 0 || err != nil {
+			return 0, "", err
+		}
+		return converter.ConfidenceMedium, "Found XML file: " + filepath.Base(soffFile), nil
+	},
+	Make: func(detector string) converter.DataConverter { return &SOFFImport{} },
+}
+
+func init() {
+	converter.Register(Plugin)
+}
+
 func readSOFF(xmlPath string) (*productObservational, error) {
 	localFS := &fileaccess.FSAccess{}
 
@@ -0,0 +1,139 @@
+// Licensed to NASA JPL under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. NASA JPL licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package importerutils
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"sort"
+
+	"github.com/pixlise/core/v4/api/dataimport/internal/dataConvertModels"
+	"github.com/pixlise/core/v4/core/utils"
+)
+
+// MakeBulkMaxSpectra - For importers of data which doesn't come with bulk sum and max value spectra, generates them for
+// each detector from its Normal spectra. Calibration is copied from the lowest PMC spectrum, live times are summed
+func MakeBulkMaxSpectra(spectraLookup dataConvertModels.DetectorSampleByPMC, bulkPMC int32) []dataConvertModels.DetectorSample {
+	pmcs := utils.GetMapKeys(spectraLookup)
+	sort.Slice(pmcs, func(i, j int) bool { return pmcs[i] < pmcs[j] })
+
+	bulk := map[string][]int64{}
+	maxValue := map[string][]int64{}
+	firstMeta := map[string]dataConvertModels.MetaData{}
+	liveTime := map[string]float32{}
+	detectors := []string{}
+
+	for _, pmc := range pmcs {
+		for _, sample := range spectraLookup[pmc] {
+			if sample.Meta["READTYPE"].SValue != "Normal" {
+				continue
+			}
+
+			detector := sample.Meta["DETECTOR_ID"].SValue
+			if _, ok := bulk[detector]; !ok {
+				detectors = append(detectors, detector)
+				bulk[detector] = make([]int64, len(sample.Spectrum))
+				maxValue[detector] = make([]int64, len(sample.Spectrum))
+				firstMeta[detector] = sample.Meta
+			}
+
+			for ch, v := range sample.Spectrum {
+				if ch < len(bulk[detector]) {
+					bulk[detector][ch] += v
+					maxValue[detector][ch] = max(maxValue[detector][ch], v)
+				}
+			}
+
+			if lt, ok := sample.Meta["LIVETIME"]; ok {
+				liveTime[detector] += lt.FValue
+			}
+		}
+	}
+
+	sort.Strings(detectors)
+
+	result := []dataConvertModels.DetectorSample{}
+	for _, detector := range detectors {
+		for _, readType := range []string{"BulkSum", "MaxValue"} {
+			meta := dataConvertModels.MetaData{
+				"PMC":         dataConvertModels.IntMetaValue(bulkPMC),
+				"DETECTOR_ID": dataConvertModels.StringMetaValue(detector),
+				"READTYPE":    dataConvertModels.StringMetaValue(readType),
+				"SOURCEFILE":  dataConvertModels.StringMetaValue("GeneratedByPIXLISEConverter"),
+			}
+
+			for _, key := range []string{"XPERCHAN", "OFFSET"} {
+				if v, ok := firstMeta[detector][key]; ok {
+					meta[key] = v
+				}
+			}
+
+			if lt, ok := liveTime[detector]; ok {
+				meta["LIVETIME"] = dataConvertModels.FloatMetaValue(lt)
+			}
+
+			spectrum := bulk[detector]
+			if readType == "MaxValue" {
+				spectrum = maxValue[detector]
+			}
+
+			result = append(result, dataConvertModels.DetectorSample{Meta: meta, Spectrum: spectrum})
+		}
+	}
+
+	return result
+}
+
+// WriteTotalCountsImage - Writes a grayscale PNG of the total counts of each pixel of a map, where the PMC of each pixel
+// is y*width+x. Importers of maps which don't come with an image use this as the context image
+func WriteTotalCountsImage(imgPath string, width int, height int, spectraLookup dataConvertModels.DetectorSampleByPMC) error {
+	totals := make([]int64, width*height)
+	maxTotal := int64(0)
+	for c := range totals {
+		for _, sample := range spectraLookup[int32(c)] {
+			if sample.Meta["READTYPE"].SValue != "Normal" {
+				continue
+			}
+
+			for _, v := range sample.Spectrum {
+				totals[c] += v
+			}
+		}
+		maxTotal = max(maxTotal, totals[c])
+	}
+
+	img := image.NewGray(image.Rect(0, 0, width, height))
+	for c, total := range totals {
+		v := uint8(0)
+		if maxTotal > 0 {
+			v = uint8(total * 255 / maxTotal)
+		}
+		img.SetGray(c%width, c/width, color.Gray{Y: v})
+	}
+
+	imgFile, err := os.Create(imgPath)
+	if err != nil {
+		return fmt.Errorf("Failed to create total counts image: %v", err)
+	}
+	defer imgFile.Close()
+
+	return png.Encode(imgFile, img)
+}
@@ -0,0 +1,55 @@
+// Licensed to NASA JPL under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. NASA JPL licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package importerutils
+
+import (
+	"fmt"
+
+	"github.com/pixlise/core/v4/api/dataimport/internal/dataConvertModels"
+)
+
+func Example_makeBulkMaxSpectra() {
+	makeSample := func(pmc int32, detector string, readType string, xperchan float32, spectrum []int64) dataConvertModels.DetectorSample {
+		meta := dataConvertModels.MetaData{
+			"PMC":         dataConvertModels.IntMetaValue(pmc),
+			"DETECTOR_ID": dataConvertModels.StringMetaValue(detector),
+			"READTYPE":    dataConvertModels.StringMetaValue(readType),
+			"XPERCHAN":    dataConvertModels.FloatMetaValue(xperchan),
+		}
+		if detector == "A" {
+			meta["LIVETIME"] = dataConvertModels.FloatMetaValue(0.5)
+		}
+		return dataConvertModels.DetectorSample{Meta: meta, Spectrum: spectrum}
+	}
+
+	spectra := dataConvertModels.DetectorSampleByPMC{
+		3: {makeSample(3, "B", "Normal", 11, []int64{1, 2, 3}), makeSample(3, "A", "Normal", 9, []int64{4, 0, 1})},
+		1: {makeSample(1, "A", "Normal", 10, []int64{1, 5, 2}), makeSample(1, "B", "Normal", 12, []int64{0, 0, 7})},
+		2: {makeSample(2, "A", "Dwell", 10, []int64{100, 100, 100})},
+	}
+
+	for _, s := range MakeBulkMaxSpectra(spectra, 4) {
+		fmt.Println(s.ToString())
+	}
+
+	// Output:
+	// meta [DETECTOR_ID:A/s LIVETIME:1/f PMC:4/i READTYPE:BulkSum/s SOURCEFILE:GeneratedByPIXLISEConverter/s XPERCHAN:10/f] spectrum [5 5 3]
+	// meta [DETECTOR_ID:A/s LIVETIME:1/f PMC:4/i READTYPE:MaxValue/s SOURCEFILE:GeneratedByPIXLISEConverter/s XPERCHAN:10/f] spectrum [4 5 2]
+	// meta [DETECTOR_ID:B/s PMC:4/i READTYPE:BulkSum/s SOURCEFILE:GeneratedByPIXLISEConverter/s XPERCHAN:12/f] spectrum [1 2 10]
+	// meta [DETECTOR_ID:B/s PMC:4/i READTYPE:MaxValue/s SOURCEFILE:GeneratedByPIXLISEConverter/s XPERCHAN:12/f] spectrum [1 2 7]
+}
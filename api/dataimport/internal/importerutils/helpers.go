@@ -18,14 +18,30 @@
 package importerutils
 
 import (
+	"fmt"
+	"sort"
+
 	"github.com/pixlise/core/v4/api/dataimport/internal/dataConvertModels"
 	"github.com/pixlise/core/v4/core/logger"
+	"github.com/pixlise/core/v4/core/utils"
 )
 
 func LogIfMoreFoundMSA(m dataConvertModels.DetectorSampleByPMC, typename string, morethan int, log logger.ILogger) {
-	for k, v := range m {
-		if len(v) > morethan {
-			log.Infof("PMC %d has %d %s entries", k, len(v), typename)
+	for _, msg := range FindMoreFoundMSA(m, typename, morethan) {
+		log.Infof("%v", msg)
+	}
+}
+
+// FindMoreFoundMSA - Returns a message for each PMC (in PMC order) with more than the expected number of spectra
+func FindMoreFoundMSA(m dataConvertModels.DetectorSampleByPMC, typename string, morethan int) []string {
+	pmcs := utils.GetMapKeys(m)
+	sort.Slice(pmcs, func(i, j int) bool { return pmcs[i] < pmcs[j] })
+
+	result := []string{}
+	for _, pmc := range pmcs {
+		if len(m[pmc]) > morethan {
+			result = append(result, fmt.Sprintf("PMC %d has %d %s entries", pmc, len(m[pmc]), typename))
 		}
 	}
+	return result
 }
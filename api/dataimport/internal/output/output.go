@@ -391,6 +391,12 @@ func (s *PIXLISEDataSaver) Save(
 		return fmt.Errorf("Failed to get dataset file size for: %v", outFilePath)
 	}
 
+	// Also write it in chunks, so the API can read only the parts of large scans that it needs
+	err = writeDatasetChunks(&exp, outputDatasetPath, outPrefix, jobLog)
+	if err != nil {
+		return err
+	}
+
 	var prevSavedScan *protos.ScanItem
 	if prevSavedScan, err = scan.ReadScanItem(data.DatasetID, db); err != nil {
 		// NOTE: this is largely paranoia, in case ReadScanItem changes and returns errors even on read
@@ -1065,3 +1071,27 @@ func (s *PIXLISEDataSaver) saveMetaData(exp *protos.Experiment) error {
 
 	return nil
 }
+
+func writeDatasetChunks(exp *protos.Experiment, outputDatasetPath string, outPrefix string, jobLog logger.ILogger) error {
+	index, chunks := scan.MakeChunks(exp, scan.DefaultLocationsPerChunk)
+
+	jobLog.Infof("Writing %v dataset chunk files...", len(chunks))
+
+	files := map[string]proto.Message{outPrefix + filepaths.DatasetChunkIndexFileName: index}
+	for name, chunk := range chunks {
+		files[outPrefix+name] = chunk
+	}
+
+	for name, msg := range files {
+		out, err := proto.Marshal(msg)
+		if err != nil {
+			return fmt.Errorf("Failed to encode dataset chunk %v: %v", name, err)
+		}
+
+		if err := os.WriteFile(filepath.Join(outputDatasetPath, name), out, 0644); err != nil {
+			return fmt.Errorf("Failed to write dataset chunk %v: %v", name, err)
+		}
+	}
+
+	return nil
+}
@@ -56,4 +56,7 @@ type BreadboardImportParams struct {
 
 type DetectorChoice struct {
 	Detector string `json:"detector"`
+
+	// Optional, name of the converter to use, skipping detection
+	Converter string `json:"converter,omitempty"`
 }
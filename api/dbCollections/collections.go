@@ -4,6 +4,8 @@ package dbCollections
 
 // NOTE DON'T FORGET TO UPDATE GetAllCollections() BELOW!!!
 
+const AuditLogName = "auditLog"
+const AutoQuantProfilesName = "autoQuantProfiles"
 const ConnectTempTokensName = "connectTempTokens"
 const DetectorConfigsName = "detectorConfigs"
 const DiffractionDetectedPeakStatusesName = "diffractionDetectedPeakStatuses"
@@ -25,7 +27,9 @@ const MistROIsName = "mistROIs"
 const ModulesName = "modules"
 const ModuleVersionsName = "moduleVersions"
 const NotificationsName = "notifications"
+const ObjectRevisionsName = "objectRevisions"
 const OwnershipName = "ownership"
+const PiquantRegressionsName = "piquantRegressions"
 const PiquantVersionName = "piquantVersion"
 const QuantificationsName = "quantifications"
 const QuantificationZStacksName = "quantificationZStacks"
@@ -48,6 +52,8 @@ const WidgetDataName = "widgetData"
 
 func GetAllCollections() []string {
 	return []string{
+		AuditLogName,
+		AutoQuantProfilesName,
 		DetectorConfigsName,
 		DiffractionDetectedPeakStatusesName,
 		DiffractionManualPeaksName,
@@ -66,7 +72,9 @@ func GetAllCollections() []string {
 		ModulesName,
 		ModuleVersionsName,
 		NotificationsName,
+		ObjectRevisionsName,
 		OwnershipName,
+		PiquantRegressionsName,
 		PiquantVersionName,
 		QuantificationsName,
 		QuantificationZStacksName,
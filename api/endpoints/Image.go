@@ -132,6 +132,12 @@ func GetImage(params apiRouter.ApiHandlerStreamParams) (*s3.GetObjectOutput, str
 
 	dbImage := dbImages[0]
 
+	// Images with sharing set need the user to have access. Those without are accessible to anyone who can see their scans
+	_, err = wsHelpers.CheckOptionalObjectAccessForUser(false, dbImage.ImagePath, protos.ObjectType_OT_IMAGE, userId, memberOfGroupIds, viewerOfGroupIds, params.Svcs.MongoDB)
+	if err != nil {
+		return nil, "", "", "", 0, err
+	}
+
 	for _, scanId := range dbImage.AssociatedScanIds {
 		_, err := wsHelpers.CheckObjectAccessForUser(false, scanId, protos.ObjectType_OT_SCAN, userId, memberOfGroupIds, viewerOfGroupIds, params.Svcs.MongoDB)
 		if err != nil {
@@ -669,6 +675,12 @@ func PutImage(params apiRouter.ApiHandlerGenericParams) error {
 
 	// From this point on, we consider the image created. If IJ generation fails or anything else, we don't roll back and delete
 
+	// The uploader can set its sharing, and it stays visible to whoever can see the scan
+	_, err = wsHelpers.WriteOptionalOwnershipForUser(scanImage.ImagePath, protos.ObjectType_OT_IMAGE, req.OriginScanId, userId, params.Svcs.TimeStamper.GetTimeNowSec(), params.Svcs.MongoDB)
+	if err != nil {
+		params.Svcs.Log.Errorf("Failed to write ownership for uploaded image: %v. Error: %v", scanImage.ImagePath, err)
+	}
+
 	if generateCoords {
 		_, err = wsHelpers.GenerateIJs(scanImage.ImagePath, req.OriginScanId, scan.Instrument, params.Svcs)
 		if err != nil {
@@ -37,6 +37,7 @@ import (
 type AuthMiddleWareData struct {
 	RoutePermissionsRequired map[string]string
 	JWTValidator             jwtparser.JWTInterface
+	PermissionClaims         []string
 	Logger                   logger.ILogger
 }
 
@@ -158,7 +159,7 @@ func (a *AuthMiddleWareData) Middleware(next http.Handler) http.Handler {
 		}
 
 		// Make sure the permission required matches one of the claims
-		permissions, err := jwtparser.ReadPermissions(claims)
+		permissions, err := jwtparser.ReadPermissions(claims, a.PermissionClaims)
 		if err != nil {
 			// No permission defined, so just fail it
 			a.Logger.Errorf("No permissions defined in claims. Error: %v", err)
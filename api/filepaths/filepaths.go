@@ -22,6 +22,7 @@ package filepaths
 import (
 	"fmt"
 	"path"
+	"strings"
 )
 
 // This package contains all file paths that the PIXLISE API should ever need to access. All been centralised
@@ -84,6 +85,8 @@ Root directory containing our dataset files
   - Datasets/
     ----<dataset-id>/
     --------dataset.bin
+    --------dataset-chunks.bin
+    --------dataset-<kind>-<chunk>.bin
     --------Context image files (.png or .jpg)
     --------RGBU multi-spectral files (.tif)
     --------diffraction-db.bin
@@ -93,6 +96,20 @@ Root directory containing our dataset files
 // The dataset file containing all spectra, housekeeping, beam locations, etc. Created by data-converter
 const DatasetFileName = "dataset.bin"
 
+// Index of the chunked copy of the dataset file, which allows reading only some locations/kinds of data. Created
+// when the dataset is imported, so older datasets may not have it
+const DatasetChunkIndexFileName = "dataset-chunks.bin"
+
+// A chunk of the dataset file, containing one kind of data (eg spectra) for a range of locations
+func MakeDatasetChunkFileName(kind string, chunkIdx int) string {
+	return fmt.Sprintf("dataset-%v-%v.bin", kind, chunkIdx)
+}
+
+// Checks if the file name is the chunk index or one of the chunks of the dataset file
+func IsDatasetChunkFileName(fileName string) bool {
+	return strings.HasPrefix(fileName, "dataset-") && strings.HasSuffix(fileName, ".bin")
+}
+
 // Diffraction peak database, generated by diffraction-detector when dataset is imported
 const DiffractionDBFileName = "diffraction-db.bin"
 
@@ -1,6 +1,9 @@
 package jobconfig
 
 import (
+	"encoding/json"
+	"fmt"
+
 	"github.com/pixlise/core/v4/api/quantification"
 	protos "github.com/pixlise/core/v4/generated-protos"
 )
@@ -8,24 +11,94 @@ import (
 type JobGroupConfig struct {
 	JobGroupId       string         `bson:"_id,omitempty"` // Job group ID
 	JobType          protos.JobType // Job type, mostly for annotation of job state
-	CompletionMethod string         // What to do when the job is completed, one of the JobComplete_* names
+	CompletionMethod string         // What to do when the job is completed, the name of a job kind registered with the job manager
 	DockerImage      string         // Docker image to run in each node
 	FastStart        bool           // May go unused - but could be a way to run it locally on this machine if we know it's a quick job
 	NodeCount        uint           // Node count, because NodeConfig can be asked to retrieve config of each node, but here we know the total
 	NodeConfig       JobConfig      // Node config sources
+	RetryPolicy      JobRetryPolicy // What to do if nodes fail
+	Priority         int32          // Jobs with a higher priority are started first, one of the job.JobPriority* values
+
+	// Not stored, filled in when running the completion method for a job group that completed with the nodes that
+	// succeeded (see JobRetryPolicy.CompleteWithSucceededNodes). Lists the indexes of the nodes that failed
+	FailedNodeIndexes []uint32 `bson:"-"`
 
-	// Job meta-data - this contains task specific fields
-	// TODO: Remove this or make it generic somehow! Perhaps move these to a json file that is saved with the job and expect it to be available at the end?
-	// The problem is they are available at job creation, but also needed in the job completion task for certain jobs
-	AssociatedScanId string   // Empty if none, or if it's across scans
-	JobName          string   // Optional job name, eg used for quants
-	ElementList      []string // Optional element list, eg used for quants
+	// Job meta-data
+	AssociatedScanId string // Empty if none, or if it's across scans
+	JobName          string // Optional job name, eg used for quants
 	RequestorUserId  string
-	OutputTitle      string // Optional, ends up in the title of an output file, eg the first row of a CSV
-	Combined         bool
-	QuantByROI       bool
-	ROIs             []quantification.ROIItemWithPMCs
-	// Need configs for:
-	// NodeOutputCombining - how to combine the outputs, eg PIQUANT map commands
-	// Do we need to write overall job output/logs somewhere?
+
+	// Parameters specific to the kind of job, serialised as JSON so this struct doesn't need to know about every kind of job.
+	// Job kinds are registered with the job manager under their CompletionMethod name, which is given these parameters
+	// when the job completes. See SetPayload/GetPayload
+	Payload string
+
+	// Quant job parameters, as stored by job groups submitted before Payload existed. Never written any more, only read
+	// so jobs already in the DB can still be listed, resumed and completed. See GetPayload
+	ElementList []string                         `bson:",omitempty"`
+	OutputTitle string                           `bson:",omitempty"`
+	Combined    bool                             `bson:",omitempty"`
+	QuantByROI  bool                             `bson:",omitempty"`
+	ROIs        []quantification.ROIItemWithPMCs `bson:",omitempty"`
+}
+
+// The legacy quant fields of JobGroupConfig, serialised with the same names as the quant job payload
+type legacyQuantPayload struct {
+	ElementList []string
+	OutputTitle string
+	Combined    bool
+	QuantByROI  bool
+	ROIs        []quantification.ROIItemWithPMCs
+}
+
+// By default if any node of a job group fails, the whole job group fails. This allows failed (or timed out) nodes to be
+// re-queued, and if they still fail, for the job group to still complete with the outputs of the nodes that succeeded
+type JobRetryPolicy struct {
+	MaxNodeRetries             uint32 // How many times a failed node gets re-queued
+	RetryBackoffSec            uint32 // How long to wait before re-queuing a failed node, doubled on each subsequent retry
+	CompleteWithSucceededNodes bool   // If nodes are still failed after all retries, run the completion method with the nodes that succeeded
+}
+
+// Stores the parameters specific to the kind of job this is
+func (jg *JobGroupConfig) SetPayload(payload interface{}) error {
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("Failed to serialise job %v payload: %v", jg.JobGroupId, err)
+	}
+
+	jg.Payload = string(b)
+	return nil
+}
+
+// Reads the parameters stored with SetPayload. Job groups stored before payloads existed were all quants, whose
+// parameters were in the legacy fields, so if there is no payload we read those instead
+func (jg *JobGroupConfig) GetPayload(payload interface{}) error {
+	jsonPayload := jg.Payload
+	if len(jsonPayload) <= 0 {
+		if !jg.hasLegacyQuantPayload() {
+			return fmt.Errorf("Job %v has no payload", jg.JobGroupId)
+		}
+
+		b, err := json.Marshal(&legacyQuantPayload{
+			ElementList: jg.ElementList,
+			OutputTitle: jg.OutputTitle,
+			Combined:    jg.Combined,
+			QuantByROI:  jg.QuantByROI,
+			ROIs:        jg.ROIs,
+		})
+		if err != nil {
+			return fmt.Errorf("Failed to read job %v legacy payload: %v", jg.JobGroupId, err)
+		}
+		jsonPayload = string(b)
+	}
+
+	err := json.Unmarshal([]byte(jsonPayload), payload)
+	if err != nil {
+		return fmt.Errorf("Failed to read job %v payload: %v", jg.JobGroupId, err)
+	}
+	return nil
+}
+
+func (jg *JobGroupConfig) hasLegacyQuantPayload() bool {
+	return len(jg.ElementList) > 0 || len(jg.OutputTitle) > 0 || jg.Combined || jg.QuantByROI || len(jg.ROIs) > 0
 }
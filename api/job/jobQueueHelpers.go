@@ -133,3 +133,90 @@ func UpdateJobQueueItem(jobId string, state protos.JobQueueItem_State, message s
 	}
 	return nil
 }
+
+// Puts a failed job queue item back in the queue so it can be picked up by a job node again, but not before retryAfterUnixSec
+func RequeueJobQueueItem(jobId string, retryCount uint32, retryAfterUnixSec int64, message string, db *mongo.Database, ts timestamper.ITimeStamper) error {
+	nowUnixSec := ts.GetTimeNowSec()
+	ctx := context.TODO()
+
+	v := bson.M{
+		"state":                       protos.JobQueueItem_UNKNOWN,
+		"message":                     message,
+		"lastupdatedtimestampunixsec": nowUnixSec,
+		"instanceid":                  "",
+		"retrycount":                  retryCount,
+		"retryafterunixsec":           retryAfterUnixSec,
+	}
+
+	dbResult, err := db.Collection(dbCollections.JobQueueName).UpdateByID(ctx, jobId, bson.D{{Key: "$set", Value: v}})
+
+	if err != nil {
+		return err
+	}
+
+	if dbResult.ModifiedCount != 1 {
+		return fmt.Errorf("RequeueJobQueueItem: Expected modified count of 1, got %v", dbResult.ModifiedCount)
+	}
+	return nil
+}
+
+// Cancels a job group's items in the job queue. Items that haven't started running are removed, running ones are set
+// to cancelled so the node running them can stop. Returns how many were removed and how many were set to cancelled
+func CancelJobQueueItems(jobGroupId string, db *mongo.Database, ts timestamper.ITimeStamper) (int64, int64, error) {
+	ctx := context.TODO()
+	coll := db.Collection(dbCollections.JobQueueName)
+
+	delResult, err := coll.DeleteMany(ctx, bson.M{
+		"jobgroupid": jobGroupId,
+		"state":      bson.M{"$in": []protos.JobQueueItem_State{protos.JobQueueItem_UNKNOWN, protos.JobQueueItem_ASSIGNED}},
+	})
+	if err != nil {
+		return 0, 0, fmt.Errorf("CancelJobQueueItems: Failed to remove queued jobs for %v: %v", jobGroupId, err)
+	}
+
+	updResult, err := coll.UpdateMany(ctx, bson.M{"jobgroupid": jobGroupId, "state": protos.JobQueueItem_RUNNING}, bson.D{{Key: "$set", Value: bson.M{
+		"state":                       protos.JobQueueItem_CANCELLED,
+		"message":                     "Cancelled",
+		"lastupdatedtimestampunixsec": ts.GetTimeNowSec(),
+	}}})
+	if err != nil {
+		return delResult.DeletedCount, 0, fmt.Errorf("CancelJobQueueItems: Failed to cancel running jobs for %v: %v", jobGroupId, err)
+	}
+
+	return delResult.DeletedCount, updResult.ModifiedCount, nil
+}
+
+// Checks if a job that a node is running has been cancelled. If the job is no longer in the queue at all, its job
+// group has been cleared out, so the node should stop running it too
+func IsJobQueueItemCancelled(jobId string, db *mongo.Database) (bool, error) {
+	result := db.Collection(dbCollections.JobQueueName).FindOne(context.TODO(), bson.M{"_id": jobId})
+	if result.Err() != nil {
+		if result.Err() == mongo.ErrNoDocuments {
+			return true, nil
+		}
+		return false, result.Err()
+	}
+
+	jobItem := &protos.JobQueueItem{}
+	if err := result.Decode(jobItem); err != nil {
+		return false, err
+	}
+
+	return jobItem.State == protos.JobQueueItem_CANCELLED, nil
+}
+
+// For jobs that work through a list of items, records how far through the list they are. This is picked up by the
+// job manager and sent out as part of the job status
+func UpdateJobQueueItemProgress(jobId string, itemsComplete uint32, itemsTotal uint32, db *mongo.Database, ts timestamper.ITimeStamper) error {
+	v := bson.M{
+		"itemscomplete":               itemsComplete,
+		"itemstotal":                  itemsTotal,
+		"lastupdatedtimestampunixsec": ts.GetTimeNowSec(),
+	}
+
+	_, err := db.Collection(dbCollections.JobQueueName).UpdateByID(context.TODO(), jobId, bson.D{{Key: "$set", Value: v}})
+	if err != nil {
+		return fmt.Errorf("UpdateJobQueueItemProgress: Failed to update progress of %v: %v", jobId, err)
+	}
+	return nil
+}
@@ -138,7 +138,7 @@ func watchJob(jobId string, watchUntilUnixSec uint32, db *mongo.Database, logger
 			sendUpdate(doc)
 
 			// If job has completed, stop here
-			if doc.Status == protos.JobStatus_COMPLETE || doc.Status == protos.JobStatus_ERROR {
+			if doc.Status == protos.JobStatus_COMPLETE || doc.Status == protos.JobStatus_ERROR || doc.Status == protos.JobStatus_CANCELLED {
 				break
 			}
 		} else {
@@ -1,16 +1,19 @@
 package jobnode
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/exec"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/pixlise/core/v4/api/filepaths"
 	"github.com/pixlise/core/v4/api/job"
 	"github.com/pixlise/core/v4/api/job/jobrunner"
+	expressionrunner "github.com/pixlise/core/v4/api/job/jobrunner/expression-runner"
 	"github.com/pixlise/core/v4/core/fileaccess"
 	"github.com/pixlise/core/v4/core/logger"
 	"github.com/pixlise/core/v4/core/timestamper"
@@ -152,12 +155,39 @@ func (jn *JobNode) startJob(jobItem *protos.JobQueueItem, wg *sync.WaitGroup) {
 	// Set up the path to read the job from
 	jobPath := filepaths.GetJobDataPath(jobItem.AssociatedScanId, jobItem.JobGroupId, "")
 
+	// Some kinds of job need DB access so are run in this process instead of by a job runner, check if this is one
 	var outStr, msg string
-	if len(jn.jobContainer) <= 0 {
+	jobGroupCfg, cfgErr := jobrunner.ReadJobGroupConfig(jn.jobBucket, jobPath, jn.fs)
+	if cfgErr == nil && jobGroupCfg.NodeConfig.Command == expressionrunner.ExpressionBatchCommand {
+		err = expressionrunner.RunExpressionBatch(jobGroupCfg, jobItem.JobId, uint(jobItem.NodeIndex), jn.fs, jn.db, jn.log, jn.ts)
+
+		if cancelled, _ := job.IsJobQueueItemCancelled(jobItem.JobId, jn.db); cancelled {
+			// Job queue item is already marked cancelled (or removed), nothing more to report
+			jn.log.Infof("Job %v was cancelled", jobItem.JobId)
+			return
+		}
+
+		if err != nil {
+			msg = fmt.Sprintf("Job %v failed on instance %v: %v", jobItem.JobGroupId, jn.instanceId, err)
+		}
+
+		outStr = "No output saved from in-process job run"
+	} else if len(jn.jobContainer) <= 0 {
 		fmt.Println("WARNING: Running job locally, recommended for use for tests only!")
 
 		// Mainly for tests, so we avoid docker and can run/debug all our code in one process
-		err = jobrunner.RunJob(jn.jobBucket, jobPath, uint(jobItem.NodeIndex), jn.fs)
+		ctx, cancel := context.WithCancel(context.Background())
+		stopWatching := jn.watchForCancel(jobItem.JobId, cancel)
+		err = jobrunner.RunJob(ctx, jn.jobBucket, jobPath, uint(jobItem.NodeIndex), jn.fs)
+		cancelled := stopWatching()
+		cancel()
+
+		if cancelled {
+			// Job queue item is already marked cancelled (or removed), nothing more to report
+			jn.log.Infof("Job %v was cancelled", jobItem.JobId)
+			return
+		}
+
 		if err != nil {
 			jn.log.Errorf("Failed to start job %v (node %v): %v", jobItem.JobGroupId, jobItem.NodeIndex, err)
 		}
@@ -165,8 +195,9 @@ func (jn *JobNode) startJob(jobItem *protos.JobQueueItem, wg *sync.WaitGroup) {
 		outStr = "No output saved from local job run"
 	} else {
 		// Run it in docker using our job runner container
+		containerName := fmt.Sprintf("%v-%v-%v", jn.jobRunnerNamePrefix, jn.jobStartedCount, utils.RandStringBytesMaskImpr(6))
 		cmd := exec.Command("docker", "run",
-			"--name", fmt.Sprintf("%v-%v-%v", jn.jobRunnerNamePrefix, jn.jobStartedCount, utils.RandStringBytesMaskImpr(6)),
+			"--name", containerName,
 			"-e", "AWS_ACCESS_KEY_ID="+os.Getenv("AWS_ACCESS_KEY_ID"),
 			"-e", "AWS_SECRET_ACCESS_KEY="+os.Getenv("AWS_SECRET_ACCESS_KEY"),
 			"-e", "AWS_REGION="+os.Getenv("AWS_REGION"),
@@ -176,8 +207,22 @@ func (jn *JobNode) startJob(jobItem *protos.JobQueueItem, wg *sync.WaitGroup) {
 			"-e", fmt.Sprintf("%v=%v", jobrunner.EnvNodeIndexName, strconv.Itoa(int(jobItem.NodeIndex))),
 			jn.jobContainer)
 
+		stopWatching := jn.watchForCancel(jobItem.JobId, func() {
+			out, err := exec.Command("docker", "kill", containerName).CombinedOutput()
+			if err != nil {
+				jn.log.Errorf("Instance %v failed to kill container %v for cancelled job %v: %v. %v", jn.instanceId, containerName, jobItem.JobId, err, string(out))
+			}
+		})
 		out, err := cmd.CombinedOutput()
+		cancelled := stopWatching()
+
 		outStr = string(out)
+		if cancelled {
+			// Job queue item is already marked cancelled (or removed), nothing more to report
+			jn.log.Infof("Job %v was cancelled, output:\n-----------------\n%v\n-----------------", jobItem.JobId, outStr)
+			return
+		}
+
 		if err != nil {
 			if len(outStr) > 0 {
 				outStr = "\n" + outStr
@@ -218,3 +263,46 @@ func (jn *JobNode) startJob(jobItem *protos.JobQueueItem, wg *sync.WaitGroup) {
 		jn.log.Errorf("Failed to update job queue item %v to failed status: %v", jobItem.JobId, err)
 	}
 }
+
+// How often a node checks if a job it's running has been cancelled
+const jobCancelCheckInterval = 10 * time.Second
+
+// Polls the job queue while a job runs, and calls stopJob if the job is cancelled (eg to kill its docker container).
+// Returns a function that stops watching, which returns true if the job was cancelled
+func (jn *JobNode) watchForCancel(jobId string, stopJob func()) func() bool {
+	stop := make(chan bool)
+	result := make(chan bool, 1)
+
+	go func() {
+		ticker := time.NewTicker(jobCancelCheckInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-stop:
+				result <- false
+				return
+			case <-ticker.C:
+				cancelled, err := job.IsJobQueueItemCancelled(jobId, jn.db)
+				if err != nil {
+					jn.log.Errorf("Instance %v failed to check if job %v was cancelled: %v", jn.instanceId, jobId, err)
+					continue
+				}
+
+				if cancelled {
+					jn.log.Infof("Instance %v stopping cancelled job %v", jn.instanceId, jobId)
+					stopJob()
+
+					<-stop
+					result <- true
+					return
+				}
+			}
+		}
+	}()
+
+	return func() bool {
+		close(stop)
+		return <-result
+	}
+}
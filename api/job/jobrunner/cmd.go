@@ -1,11 +1,12 @@
 package jobrunner
 
 import (
+	"context"
 	"os/exec"
 )
 
-func runCommand(command string, args []string) (string, error) {
-	cmd := exec.Command(command, args...)
+func runCommand(ctx context.Context, command string, args []string) (string, error) {
+	cmd := exec.CommandContext(ctx, command, args...)
 	cmdStdOut, err := cmd.CombinedOutput()
 	return string(cmdStdOut), err
 }
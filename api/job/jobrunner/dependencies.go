@@ -1,6 +1,7 @@
 package jobrunner
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -13,7 +14,7 @@ func installPythonLibs(pythonPath string) error {
 		// Run pip
 		pipPath := filepath.Join(pythonPath, "pip")
 		fmt.Printf("Running %v to install requirements.txt...\n", pipPath)
-		out, err := runCommand(pipPath, []string{"install", "-r", "requirements.txt"})
+		out, err := runCommand(context.Background(), pipPath, []string{"install", "-r", "requirements.txt"})
 		if err != nil {
 			fmt.Printf("Failed to install python libraries:\n%v\n", string(out))
 			return err
@@ -48,7 +49,7 @@ func installLuaLibs() error {
 	// Run all commands, return if an error happens
 	for _, args := range allargs {
 		fmt.Printf("Executing: %v\n", strings.Join(args, " "))
-		out, err := runCommand(args[0], args[1:])
+		out, err := runCommand(context.Background(), args[0], args[1:])
 		if err != nil {
 			fmt.Printf("Error while installing lua library [%v]: %v\n", strings.Join(args, ","), string(out))
 			return err
@@ -0,0 +1,498 @@
+package expressionrunner
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pixlise/core/v4/api/config"
+	"github.com/pixlise/core/v4/api/services"
+	protos "github.com/pixlise/core/v4/generated-protos"
+	"github.com/yuin/gopher-lua/ast"
+	"github.com/yuin/gopher-lua/parse"
+)
+
+// Static analysis of expressions. Without running an expression, we parse it (and its modules) to find out what data
+// it reads, so we can warn if it can't run on a scan, and build memoisation keys from only the data it depends on
+
+// What an expression needs to run
+type ExpressionDependencies struct {
+	// Our runtime functions it calls, eg "element", "housekeeping", sorted
+	RuntimeFunctions []string
+
+	// Quant columns it reads, eg "Fe_%" for element("Fe", "%", "A") and "chisq" for data("chisq", "A")
+	QuantColumns []string
+
+	// Housekeeping (scan metadata) columns it reads via housekeeping()
+	HousekeepingColumns []string
+
+	// Pseudo-intensities it reads via pseudo()
+	PseudoIntensities []string
+
+	// Globals it reads which are never assigned, and aren't defined by Lua or our runtime. These will be nil when it runs
+	UndefinedGlobals []string
+
+	// If any of the data reading calls had arguments that weren't string literals, we can't know everything they read,
+	// so the column lists above may be incomplete
+	HasComputedArgs bool
+
+	UsesQuant       bool // Reads anything from the quantification, or the quant ID itself
+	UsesSpectra     bool // Reads spectra
+	UsesDiffraction bool // Reads diffraction peaks or roughness
+}
+
+// Lua base library globals, as defined by gopher-lua
+var luaBaseGlobals = []string{
+	"_G", "_GOPHER_LUA_VERSION", "_VERSION", "assert", "collectgarbage", "dofile", "error", "getfenv", "getmetatable",
+	"ipairs", "load", "loadfile", "loadstring", "module", "newproxy", "next", "pairs", "pcall", "print", "rawequal",
+	"rawget", "rawset", "require", "select", "setfenv", "setmetatable", "tonumber", "tostring", "type", "unpack", "xpcall",
+}
+
+// Locals declared in the source we prepend in Run()
+var preludeLocals = []string{"elevAngle", "quantId", "scanId", "maxSpectrumChannel", "instrument", "userId", "lastMap", "makeMap"}
+
+// Analysing requires reading modules from the DB, so we keep the results, by expression ID. Expressions store the module
+// versions they reference, so they can only change if the expression is modified, at which point the entry is replaced
+type analysisCacheItem struct {
+	modifiedUnixSec uint32
+	deps            *ExpressionDependencies
+}
+
+var analysisCache = map[string]analysisCacheItem{}
+var analysisCacheLock = sync.Mutex{}
+
+// So the cache can't grow without limit. If full, an entry is evicted to make room
+const maxAnalysisCacheItems = 1000
+
+// Analyses an expression along with the modules it references
+func AnalyseExpression(expr *protos.DataExpression, svcs *services.APIServices) (*ExpressionDependencies, error) {
+	if expr.SourceLanguage != "LUA" {
+		return nil, fmt.Errorf("Expression %v is not Lua", expr.Id)
+	}
+
+	if len(expr.Id) > 0 {
+		analysisCacheLock.Lock()
+		item, ok := analysisCache[expr.Id]
+		analysisCacheLock.Unlock()
+
+		if ok && item.modifiedUnixSec == expr.ModifiedUnixSec {
+			return item.deps, nil
+		}
+	}
+
+	builtInSources, moduleSources, err := readModuleSources(expr, svcs)
+	if err != nil {
+		return nil, err
+	}
+
+	deps, err := analyseSources(builtInSources, moduleSources, expr.SourceCode, svcs.Config.ExpressionLimits)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(expr.Id) > 0 {
+		analysisCacheLock.Lock()
+		addToAnalysisCache(expr.Id, analysisCacheItem{modifiedUnixSec: expr.ModifiedUnixSec, deps: deps})
+		analysisCacheLock.Unlock()
+	}
+
+	return deps, nil
+}
+
+// Must be called with analysisCacheLock held
+func addToAnalysisCache(exprId string, item analysisCacheItem) {
+	if _, ok := analysisCache[exprId]; !ok && len(analysisCache) >= maxAnalysisCacheItems {
+		// Map iteration order is random, so this evicts an arbitrary entry
+		for id := range analysisCache {
+			delete(analysisCache, id)
+			break
+		}
+	}
+
+	analysisCache[exprId] = item
+}
+
+// Analyses the expression source, which runs after the built-in and referenced modules. Built-in modules are only looked
+// at for what they define, their own calls aren't counted as dependencies of the expression
+func analyseSources(builtInSources []string, moduleSources []string, source string, limits config.ExpressionLimitsConfig) (*ExpressionDependencies, error) {
+	a := newExpressionAnalyser(limits)
+
+	for c, src := range builtInSources {
+		if err := a.analyseChunk(src, fmt.Sprintf("built-in module %v", c+1), false); err != nil {
+			return nil, err
+		}
+	}
+
+	for c, src := range moduleSources {
+		if err := a.analyseChunk(src, fmt.Sprintf("module %v", c+1), true); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := a.analyseChunk(source, "expression", true); err != nil {
+		return nil, err
+	}
+
+	return a.result(), nil
+}
+
+type expressionAnalyser struct {
+	// Local variable scopes, innermost last. The first one holds the prelude locals, and lives as long as the analyser,
+	// as all modules and the expression are run as one chunk
+	scopes []map[string]bool
+
+	knownGlobals    map[string]bool
+	assignedGlobals map[string]bool
+	readGlobals     map[string]bool
+
+	// Whether we're in code whose calls we count
+	recording bool
+
+	runtimeFunctions    map[string]bool
+	quantColumns        map[string]bool
+	housekeepingColumns map[string]bool
+	pseudoIntensities   map[string]bool
+	hasComputedArgs     bool
+	readsQuantId        bool
+
+	// Set if a runtime function is used other than by calling it directly, eg f = element, or through _G. We can't
+	// tell what that reads, so assume everything
+	hasIndirectRuntimeAccess bool
+}
+
+func newExpressionAnalyser(limits config.ExpressionLimitsConfig) *expressionAnalyser {
+	a := &expressionAnalyser{
+		scopes:              []map[string]bool{{}},
+		knownGlobals:        map[string]bool{contextIdLuaVarName: true, "makeMapRaw": true},
+		assignedGlobals:     map[string]bool{},
+		readGlobals:         map[string]bool{},
+		runtimeFunctions:    map[string]bool{},
+		quantColumns:        map[string]bool{},
+		housekeepingColumns: map[string]bool{},
+		pseudoIntensities:   map[string]bool{},
+	}
+
+	for _, name := range preludeLocals {
+		a.scopes[0][name] = true
+	}
+
+	for name := range runtimeFunctions {
+		a.knownGlobals[name] = true
+	}
+
+	// Only the libraries expressions are allowed are defined, so anything else will be nil when run
+	allowedLibs := limits.AllowedLuaLibs
+	if len(allowedLibs) <= 0 {
+		allowedLibs = defaultAllowedLuaLibs
+	}
+
+	for _, libName := range allowedLibs {
+		if libName == "base" {
+			for _, name := range luaBaseGlobals {
+				a.knownGlobals[name] = true
+			}
+		} else {
+			a.knownGlobals[libName] = true
+		}
+	}
+
+	for _, name := range disallowedLibFuncs["base"] {
+		delete(a.knownGlobals, name)
+	}
+
+	return a
+}
+
+func (a *expressionAnalyser) analyseChunk(source string, name string, recording bool) error {
+	stmts, err := parse.Parse(strings.NewReader(source), name)
+	if err != nil {
+		return fmt.Errorf("Failed to parse %v: %v", name, err)
+	}
+
+	a.recording = recording
+	a.walkStmts(stmts)
+	return nil
+}
+
+func (a *expressionAnalyser) result() *ExpressionDependencies {
+	undefined := map[string]bool{}
+	for name := range a.readGlobals {
+		if !a.assignedGlobals[name] && !a.knownGlobals[name] {
+			undefined[name] = true
+		}
+	}
+
+	deps := &ExpressionDependencies{
+		RuntimeFunctions:    sortedKeys(a.runtimeFunctions),
+		QuantColumns:        sortedKeys(a.quantColumns),
+		HousekeepingColumns: sortedKeys(a.housekeepingColumns),
+		PseudoIntensities:   sortedKeys(a.pseudoIntensities),
+		UndefinedGlobals:    sortedKeys(undefined),
+		HasComputedArgs:     a.hasComputedArgs,
+	}
+
+	// Cached values could have been written by anything, so if we read them, assume we depend on everything
+	readsCache := a.runtimeFunctions["readCache"] || a.runtimeFunctions["readMap"] || a.hasIndirectRuntimeAccess
+
+	if a.hasIndirectRuntimeAccess {
+		deps.HasComputedArgs = true
+	}
+
+	deps.UsesQuant = readsCache || a.readsQuantId || a.runtimeFunctions["element"] || a.runtimeFunctions["elementSum"] || a.runtimeFunctions["data"] || a.runtimeFunctions["exists"]
+	deps.UsesSpectra = readsCache || a.runtimeFunctions["spectrum"] || a.runtimeFunctions["spectrumDiff"]
+	deps.UsesDiffraction = readsCache || a.runtimeFunctions["diffractionPeaks"] || a.runtimeFunctions["roughness"]
+
+	return deps
+}
+
+func sortedKeys(m map[string]bool) []string {
+	result := []string{}
+	for k := range m {
+		result = append(result, k)
+	}
+	sort.Strings(result)
+	return result
+}
+
+func (a *expressionAnalyser) pushScope(names ...string) {
+	scope := map[string]bool{}
+	for _, name := range names {
+		scope[name] = true
+	}
+	a.scopes = append(a.scopes, scope)
+}
+
+func (a *expressionAnalyser) popScope() {
+	a.scopes = a.scopes[0 : len(a.scopes)-1]
+}
+
+func (a *expressionAnalyser) declareLocals(names []string) {
+	for _, name := range names {
+		a.scopes[len(a.scopes)-1][name] = true
+	}
+}
+
+// Returns the index of the scope the name is a local in, or -1 if it's a global
+func (a *expressionAnalyser) findLocal(name string) int {
+	for c := len(a.scopes) - 1; c >= 0; c-- {
+		if a.scopes[c][name] {
+			return c
+		}
+	}
+	return -1
+}
+
+// Globals which give access to any other global by name, so could be used to get at a runtime function
+var globalLookupNames = map[string]bool{"_G": true, "getfenv": true}
+
+// Called for every identifier read, except for the function name in direct calls to runtime functions, which are
+// recorded by recordRuntimeCall
+func (a *expressionAnalyser) readName(name string) {
+	scopeIdx := a.findLocal(name)
+	if scopeIdx < 0 {
+		if a.recording {
+			a.readGlobals[name] = true
+
+			if _, ok := runtimeFunctions[name]; ok {
+				a.runtimeFunctions[name] = true
+				a.hasIndirectRuntimeAccess = true
+			} else if globalLookupNames[name] {
+				a.hasIndirectRuntimeAccess = true
+			}
+		}
+	} else if scopeIdx == 0 && name == "quantId" && a.recording {
+		a.readsQuantId = true
+	}
+}
+
+func (a *expressionAnalyser) walkBlock(stmts []ast.Stmt, locals ...string) {
+	a.pushScope(locals...)
+	a.walkStmts(stmts)
+	a.popScope()
+}
+
+func (a *expressionAnalyser) walkStmts(stmts []ast.Stmt) {
+	for _, stmt := range stmts {
+		a.walkStmt(stmt)
+	}
+}
+
+func (a *expressionAnalyser) walkStmt(stmt ast.Stmt) {
+	switch s := stmt.(type) {
+	case *ast.AssignStmt:
+		a.walkExprs(s.Rhs)
+		for _, lhs := range s.Lhs {
+			if ident, ok := lhs.(*ast.IdentExpr); ok {
+				if a.findLocal(ident.Value) < 0 {
+					a.assignedGlobals[ident.Value] = true
+				}
+			} else {
+				a.walkExpr(lhs)
+			}
+		}
+	case *ast.LocalAssignStmt:
+		// A local function can call itself, so its name is visible in its body. Other locals aren't visible in their
+		// own initialisers
+		if len(s.Names) == 1 && len(s.Exprs) == 1 {
+			if _, ok := s.Exprs[0].(*ast.FunctionExpr); ok {
+				a.declareLocals(s.Names)
+			}
+		}
+		a.walkExprs(s.Exprs)
+		a.declareLocals(s.Names)
+	case *ast.FuncCallStmt:
+		a.walkExpr(s.Expr)
+	case *ast.DoBlockStmt:
+		a.walkBlock(s.Stmts)
+	case *ast.WhileStmt:
+		a.walkExpr(s.Condition)
+		a.walkBlock(s.Stmts)
+	case *ast.RepeatStmt:
+		// The condition can see locals declared in the loop body
+		a.pushScope()
+		a.walkStmts(s.Stmts)
+		a.walkExpr(s.Condition)
+		a.popScope()
+	case *ast.IfStmt:
+		a.walkExpr(s.Condition)
+		a.walkBlock(s.Then)
+		a.walkBlock(s.Else)
+	case *ast.NumberForStmt:
+		a.walkExpr(s.Init)
+		a.walkExpr(s.Limit)
+		a.walkExpr(s.Step)
+		a.walkBlock(s.Stmts, s.Name)
+	case *ast.GenericForStmt:
+		a.walkExprs(s.Exprs)
+		a.walkBlock(s.Stmts, s.Names...)
+	case *ast.FuncDefStmt:
+		if s.Name.Receiver != nil {
+			// function a.b:c() - reads a, defines a method with an implicit self
+			a.walkExpr(s.Name.Receiver)
+			a.walkFunction(s.Func, true)
+		} else {
+			if ident, ok := s.Name.Func.(*ast.IdentExpr); ok {
+				if a.findLocal(ident.Value) < 0 {
+					a.assignedGlobals[ident.Value] = true
+				}
+			} else {
+				a.walkExpr(s.Name.Func)
+			}
+			a.walkFunction(s.Func, false)
+		}
+	case *ast.ReturnStmt:
+		a.walkExprs(s.Exprs)
+	}
+}
+
+func (a *expressionAnalyser) walkFunction(f *ast.FunctionExpr, isMethod bool) {
+	locals := append([]string{}, f.ParList.Names...)
+	if isMethod {
+		locals = append(locals, "self")
+	}
+	if f.ParList.HasVargs {
+		// gopher-lua defines the 5.0 style arg table for vararg functions
+		locals = append(locals, "arg")
+	}
+
+	a.walkBlock(f.Stmts, locals...)
+}
+
+func (a *expressionAnalyser) walkExprs(exprs []ast.Expr) {
+	for _, expr := range exprs {
+		a.walkExpr(expr)
+	}
+}
+
+func (a *expressionAnalyser) walkExpr(expr ast.Expr) {
+	switch e := expr.(type) {
+	case *ast.IdentExpr:
+		a.readName(e.Value)
+	case *ast.AttrGetExpr:
+		a.walkExpr(e.Object)
+		a.walkExpr(e.Key)
+	case *ast.TableExpr:
+		for _, field := range e.Fields {
+			a.walkExpr(field.Key)
+			a.walkExpr(field.Value)
+		}
+	case *ast.FuncCallExpr:
+		isRuntimeCall := false
+		if ident, ok := e.Func.(*ast.IdentExpr); ok && a.findLocal(ident.Value) < 0 {
+			if _, ok := runtimeFunctions[ident.Value]; ok {
+				a.recordRuntimeCall(ident.Value, e.Args)
+				isRuntimeCall = true
+			}
+		}
+		if !isRuntimeCall {
+			a.walkExpr(e.Func)
+		}
+		a.walkExpr(e.Receiver)
+		a.walkExprs(e.Args)
+	case *ast.LogicalOpExpr:
+		a.walkExpr(e.Lhs)
+		a.walkExpr(e.Rhs)
+	case *ast.RelationalOpExpr:
+		a.walkExpr(e.Lhs)
+		a.walkExpr(e.Rhs)
+	case *ast.StringConcatOpExpr:
+		a.walkExpr(e.Lhs)
+		a.walkExpr(e.Rhs)
+	case *ast.ArithmeticOpExpr:
+		a.walkExpr(e.Lhs)
+		a.walkExpr(e.Rhs)
+	case *ast.UnaryMinusOpExpr:
+		a.walkExpr(e.Expr)
+	case *ast.UnaryNotOpExpr:
+		a.walkExpr(e.Expr)
+	case *ast.UnaryLenOpExpr:
+		a.walkExpr(e.Expr)
+	case *ast.FunctionExpr:
+		a.walkFunction(e, false)
+	}
+}
+
+func (a *expressionAnalyser) recordRuntimeCall(name string, args []ast.Expr) {
+	if !a.recording {
+		return
+	}
+
+	a.runtimeFunctions[name] = true
+
+	switch name {
+	case "element": // args(symbol, column, detector)
+		symbol, ok1 := a.stringArg(args, 0)
+		column, ok2 := a.stringArg(args, 1)
+		if ok1 && ok2 {
+			if column == "%-as-mmol" {
+				column = "%"
+			}
+			a.quantColumns[symbol+"_"+column] = true
+		}
+	case "data": // args(column, detector)
+		if column, ok := a.stringArg(args, 0); ok {
+			a.quantColumns[column] = true
+		}
+	case "housekeeping": // args(column)
+		if column, ok := a.stringArg(args, 0); ok {
+			a.housekeepingColumns[column] = true
+		}
+	case "pseudo": // args(elem)
+		if elem, ok := a.stringArg(args, 0); ok {
+			a.pseudoIntensities[elem] = true
+		}
+	}
+}
+
+// Returns the argument if it's a string literal. If not, we note that we can't know everything the expression reads
+func (a *expressionAnalyser) stringArg(args []ast.Expr, idx int) (string, bool) {
+	if idx < len(args) {
+		if str, ok := args[idx].(*ast.StringExpr); ok {
+			return str.Value, true
+		}
+	}
+
+	a.hasComputedArgs = true
+	return "", false
+}
@@ -0,0 +1,113 @@
+package expressionrunner
+
+import (
+	"fmt"
+
+	"github.com/pixlise/core/v4/api/config"
+)
+
+func printAnalysis(builtIn []string, modules []string, source string) {
+	deps, err := analyseSources(builtIn, modules, source, config.ExpressionLimitsConfig{})
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	fmt.Printf("funcs: %v\n", deps.RuntimeFunctions)
+	fmt.Printf("quant: %v, housekeeping: %v, pseudo: %v\n", deps.QuantColumns, deps.HousekeepingColumns, deps.PseudoIntensities)
+	fmt.Printf("undefined: %v, computed: %v\n", deps.UndefinedGlobals, deps.HasComputedArgs)
+	fmt.Printf("uses quant: %v, spectra: %v, diffraction: %v\n", deps.UsesQuant, deps.UsesSpectra, deps.UsesDiffraction)
+}
+
+func Example_expressionrunner_analyseSources() {
+	builtIn := []string{snipReturnModuleLine(debugModule), snipReturnModuleLine(mapModule)}
+
+	fmt.Println("Simple element map:")
+	printAnalysis(builtIn, []string{}, `return Map.add(element("Fe", "%", "A"), element("Ca", "%-as-mmol", "B"))`)
+
+	fmt.Println("Module, locals, methods and recursion:")
+	printAnalysis(builtIn, []string{`Helper = {}
+local function fact(n)
+    if n <= 1 then return 1 end
+    return n * fact(n-1)
+end
+function Helper:hk(name)
+    return housekeeping(name)
+end
+function Helper.count(...)
+    return #arg
+end
+`}, `local col = "Fe"
+for i, v in ipairs({1, 2}) do
+    col = col..v
+end
+local r = Helper:hk(col)
+local t = housekeeping("f_pixl_analog_fpga")
+if notDefined then
+    r = data(col, "A")
+end
+return Map.add(r, pseudo("Na"))`)
+
+	fmt.Println("No data read, but reads quantId:")
+	printAnalysis(builtIn, []string{}, `print(quantId)
+return makeMap(os.time())`)
+
+	fmt.Println("Locals shadowing runtime functions and disallowed libraries:")
+	printAnalysis(builtIn, []string{}, `local spectrum = function(a) return a end
+local f = io.open("x")
+dofile("y")
+repeat local x = 1 until x == 1
+return spectrum(3)`)
+
+	fmt.Println("Calls in built-in modules are not counted:")
+	printAnalysis([]string{`function DoSpectrum() return spectrum(1, 2, "A") end`}, []string{}, `return roughness()`)
+
+	fmt.Println("Runtime function aliased:")
+	printAnalysis(builtIn, []string{}, `local f = element
+return f("Fe", "%", "A")`)
+
+	fmt.Println("Runtime function looked up by name:")
+	printAnalysis(builtIn, []string{}, `return _G["ele".."ment"]("Fe", "%", "A")`)
+
+	fmt.Println("Syntax error:")
+	printAnalysis(builtIn, []string{}, `return element("Fe", "%"`)
+
+	// Output:
+	// Simple element map:
+	// funcs: [element]
+	// quant: [Ca_% Fe_%], housekeeping: [], pseudo: []
+	// undefined: [], computed: false
+	// uses quant: true, spectra: false, diffraction: false
+	// Module, locals, methods and recursion:
+	// funcs: [data housekeeping pseudo]
+	// quant: [], housekeeping: [f_pixl_analog_fpga], pseudo: [Na]
+	// undefined: [notDefined], computed: true
+	// uses quant: true, spectra: false, diffraction: false
+	// No data read, but reads quantId:
+	// funcs: []
+	// quant: [], housekeeping: [], pseudo: []
+	// undefined: [], computed: false
+	// uses quant: true, spectra: false, diffraction: false
+	// Locals shadowing runtime functions and disallowed libraries:
+	// funcs: []
+	// quant: [], housekeeping: [], pseudo: []
+	// undefined: [dofile io], computed: false
+	// uses quant: false, spectra: false, diffraction: false
+	// Calls in built-in modules are not counted:
+	// funcs: [roughness]
+	// quant: [], housekeeping: [], pseudo: []
+	// undefined: [], computed: false
+	// uses quant: false, spectra: false, diffraction: true
+	// Runtime function aliased:
+	// funcs: [element]
+	// quant: [], housekeeping: [], pseudo: []
+	// undefined: [], computed: true
+	// uses quant: true, spectra: true, diffraction: true
+	// Runtime function looked up by name:
+	// funcs: []
+	// quant: [], housekeeping: [], pseudo: []
+	// undefined: [], computed: true
+	// uses quant: true, spectra: true, diffraction: true
+	// Syntax error:
+	// Error: Failed to parse expression: expression at EOF:   syntax error
+}
@@ -0,0 +1,151 @@
+package expressionrunner
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pixlise/core/v4/api/config"
+	"github.com/pixlise/core/v4/api/dbCollections"
+	"github.com/pixlise/core/v4/api/job"
+	jobconfig "github.com/pixlise/core/v4/api/job/config"
+	"github.com/pixlise/core/v4/api/services"
+	"github.com/pixlise/core/v4/core/fileaccess"
+	"github.com/pixlise/core/v4/core/logger"
+	"github.com/pixlise/core/v4/core/timestamper"
+	protos "github.com/pixlise/core/v4/generated-protos"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Batch expression jobs run a list of expressions on job nodes, memoising each result so it's ready when the client
+// asks for it. These run in the job node process rather than in a job runner container, as they need DB access.
+// The job node recognises them by this command
+const ExpressionBatchCommand = "pixlise-expression-batch"
+
+type ExpressionBatchItem struct {
+	ScanId       string
+	QuantId      string
+	ExpressionId string
+	RoiId        string
+	Units        protos.DataUnit
+}
+
+// Parameters stored with batch expression jobs
+type ExpressionBatchJobPayload struct {
+	Items []ExpressionBatchItem
+
+	// Job nodes only know about the jobs bucket, but running expressions requires reading scans and quants
+	DatasetsBucket string
+	UsersBucket    string
+	ConfigBucket   string
+
+	// So expressions are run with the same limits as the API would use
+	ExpressionLimits config.ExpressionLimitsConfig
+}
+
+// Returns the items a given node runs. Each node gets a contiguous range, so if the items are sorted by scan, nodes
+// only have to download a few scans
+func GetExpressionBatchNodeItems(items []ExpressionBatchItem, nodeIndex uint, nodeCount uint) []ExpressionBatchItem {
+	if nodeCount <= 0 {
+		return []ExpressionBatchItem{}
+	}
+
+	perNode := (uint(len(items)) + nodeCount - 1) / nodeCount
+	start := nodeIndex * perNode
+	end := start + perNode
+
+	if start >= uint(len(items)) {
+		return []ExpressionBatchItem{}
+	}
+	if end > uint(len(items)) {
+		end = uint(len(items))
+	}
+
+	return items[start:end]
+}
+
+// Runs this node's share of the items of a batch expression job, memoising the result of each one. Progress is written
+// to the job queue item as we go, and we stop early if the job is cancelled. If any expressions fail, we still run the
+// rest, but return an error listing the failures
+func RunExpressionBatch(jg *jobconfig.JobGroupConfig, jobId string, nodeIndex uint, fs fileaccess.FileAccess, db *mongo.Database, log logger.ILogger, ts timestamper.ITimeStamper) error {
+	payload := ExpressionBatchJobPayload{}
+	err := jg.GetPayload(&payload)
+	if err != nil {
+		return err
+	}
+
+	svcs := &services.APIServices{
+		Config: config.APIConfig{
+			DatasetsBucket:   payload.DatasetsBucket,
+			UsersBucket:      payload.UsersBucket,
+			ConfigBucket:     payload.ConfigBucket,
+			ExpressionLimits: payload.ExpressionLimits,
+		},
+		Log:         log,
+		FS:          fs,
+		TimeStamper: ts,
+		MongoDB:     db,
+	}
+
+	items := GetExpressionBatchNodeItems(payload.Items, nodeIndex, jg.NodeCount)
+	total := uint32(len(items))
+
+	log.Infof("Job %v running %v expressions...", jobId, total)
+
+	failures := []string{}
+	for c, item := range items {
+		cancelled, err := job.IsJobQueueItemCancelled(jobId, db)
+		if err != nil {
+			log.Errorf("Job %v failed to check if it was cancelled: %v", jobId, err)
+		} else if cancelled {
+			return fmt.Errorf("Job %v was cancelled after %v of %v expressions", jobId, c, total)
+		}
+
+		err = job.UpdateJobQueueItemProgress(jobId, uint32(c), total, db, ts)
+		if err != nil {
+			log.Errorf("%v", err)
+		}
+
+		err = runBatchItem(item, jg.RequestorUserId, svcs)
+		if err != nil {
+			log.Errorf("Job %v expression %v on scan %v failed: %v", jobId, item.ExpressionId, item.ScanId, err)
+			failures = append(failures, fmt.Sprintf("%v (scan %v)", item.ExpressionId, item.ScanId))
+		}
+	}
+
+	err = job.UpdateJobQueueItemProgress(jobId, total, total, db, ts)
+	if err != nil {
+		log.Errorf("%v", err)
+	}
+
+	if len(failures) > 0 {
+		return fmt.Errorf("%v of %v expressions failed: %v", len(failures), total, strings.Join(failures, ", "))
+	}
+
+	return nil
+}
+
+func runBatchItem(item ExpressionBatchItem, requestorUserId string, svcs *services.APIServices) error {
+	scanItem := &protos.ScanItem{}
+	err := readOne(dbCollections.ScansName, bson.M{"_id": item.ScanId}, scanItem, svcs.MongoDB)
+	if err != nil {
+		return fmt.Errorf("Failed to read scan: %v", err)
+	}
+
+	exprItem := &protos.DataExpression{}
+	err = readOne(dbCollections.ExpressionsName, bson.M{"_id": item.ExpressionId}, exprItem, svcs.MongoDB)
+	if err != nil {
+		return fmt.Errorf("Failed to read expression: %v", err)
+	}
+
+	m, totalMs, goMs, err := RunExpression(item.ExpressionId, item.ScanId, item.QuantId, svcs, false, false)
+	if err != nil {
+		return err
+	}
+
+	svcs.Log.Infof("Expression \"%v\" took total %vms (%vms in Go runtime)", item.ExpressionId, totalMs, goMs)
+
+	memCacheKey := MakeMemoisationKey(scanItem, exprItem, item.QuantId, item.RoiId, item.Units)
+	_, _, err = Memoise(memCacheKey, item.ScanId, item.QuantId, exprItem, requestorUserId, m, svcs)
+	return err
+}
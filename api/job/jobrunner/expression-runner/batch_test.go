@@ -0,0 +1,41 @@
+package expressionrunner
+
+import (
+	"fmt"
+
+	protos "github.com/pixlise/core/v4/generated-protos"
+)
+
+func Example_expressionrunner_GetExpressionBatchNodeItems() {
+	items := []ExpressionBatchItem{}
+	for c := 0; c < 7; c++ {
+		items = append(items, ExpressionBatchItem{ExpressionId: fmt.Sprintf("expr%v", c)})
+	}
+
+	for node := uint(0); node < 4; node++ {
+		ids := []string{}
+		for _, item := range GetExpressionBatchNodeItems(items, node, 3) {
+			ids = append(ids, item.ExpressionId)
+		}
+		fmt.Printf("%v: %v\n", node, ids)
+	}
+
+	fmt.Printf("none: %v\n", len(GetExpressionBatchNodeItems(items, 0, 0)))
+
+	// Output:
+	// 0: [expr0 expr1 expr2]
+	// 1: [expr3 expr4 expr5]
+	// 2: [expr6]
+	// 3: []
+	// none: 0
+}
+
+func Example_expressionrunner_MakeMemoisationKey() {
+	scanItem := &protos.ScanItem{Id: "602735105", ContentCounts: map[string]int32{"NormalSpectra": 3298, "DwellSpectra": 90}}
+	exprItem := &protos.DataExpression{Id: "q2ns80oc4452eldt", ModifiedUnixSec: 1772129285}
+
+	fmt.Println(MakeMemoisationKey(scanItem, exprItem, "quant-aqpxxfk6i05gcsy3", "AllPoints-602735105", protos.DataUnit_UNIT_DEFAULT))
+
+	// Output:
+	// {"scanId":"602735105","exprId":"q2ns80oc4452eldt","quantId":"quant-aqpxxfk6i05gcsy3","roiId":"AllPoints-602735105","units":0},Resp:false,exprMod:1772129285,spectra:3298,90,0
+}
@@ -9,8 +9,7 @@ function DebugHelp.listAllTables(offset, story, recursive)
     local n,v
     for n,v in pairs(story) do
         if n ~= "loaded" and n ~= "_G" then
-            io.write (offset .. n .. " " )
-            print (v)
+            print (offset .. n .. " " .. tostring(v))
             if type(v) == "table" and recursive then
                 DebugHelp.listAllTables(offset .. "--> ",v)
             end
@@ -102,6 +101,11 @@ end
 local function makeStackTrace(startLevel)
     local trace = "Stack trace:"
 
+    -- Only available if the debug library was allowed
+    if debug == nil then
+        return trace.." not available"
+    end
+
     local level = startLevel+1
     while true do
         local info = debug.getinfo(level)
@@ -131,7 +135,10 @@ end
 
 local function makeAssertReport(var, expType)
     local result = ""
-    local caller = debug.getinfo(2, "n")
+    local caller = nil
+    if debug ~= nil then
+        caller = debug.getinfo(2, "n")
+    end
     if caller ~= nil and caller.name ~= nil then
         result = result..caller.name.." "
     end
@@ -0,0 +1,505 @@
+package expressionrunner
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pixlise/core/v4/api/services"
+	"github.com/pixlise/core/v4/core/errorwithstatus"
+	protos "github.com/pixlise/core/v4/generated-protos"
+	lua "github.com/yuin/gopher-lua"
+)
+
+// Debug sessions let a client step through an expression as it runs. The expression runs in its own goroutine, and
+// an instruction hook checks for breakpoints/steps whenever the line changes. When paused, we send the client an update
+// with the call stack and locals, then block until it sends a command. We also trace every runtime function call
+
+// If nobody sends a command for this long while paused, the session is stopped
+const debugIdleTimeout = 10 * time.Minute
+
+// How many debug sessions a user can have running at once
+const maxDebugSessionsPerUser = 3
+
+// Limits on how much we send to the client
+const maxDebugTraceItems = 5000
+const maxDebugValueLength = 10000
+
+// Where the expression or one of its modules is in the source we run
+type sourceSegment struct {
+	moduleId  string // Blank for the expression itself
+	firstLine int    // Line in the source we run that this segment starts on (1-based)
+	lineCount int
+}
+
+// Makes a segment for source that's about to be appended to sourceBefore
+func makeSourceSegment(moduleId string, sourceBefore string, source string) sourceSegment {
+	return sourceSegment{
+		moduleId:  moduleId,
+		firstLine: strings.Count(sourceBefore, "\n") + 1,
+		lineCount: strings.Count(source, "\n") + 1,
+	}
+}
+
+func offsetSourceSegments(segments []sourceSegment, lines int) []sourceSegment {
+	result := []sourceSegment{}
+	for _, seg := range segments {
+		seg.firstLine += lines
+		result = append(result, seg)
+	}
+	return result
+}
+
+// Returns the module and line within it for a line of the source we run. Returns false if the line isn't in the
+// expression or a module it references, eg if it's in a built-in module
+func findSourceLine(segments []sourceSegment, line int) (string, uint32, bool) {
+	for _, seg := range segments {
+		if line >= seg.firstLine && line < seg.firstLine+seg.lineCount {
+			return seg.moduleId, uint32(line - seg.firstLine + 1), true
+		}
+	}
+	return "", 0, false
+}
+
+type DebugSession struct {
+	id          string
+	userId      string
+	wsSessionId string // The web socket session that started it, so it can be stopped when that closes
+	onUpdate    func(upd *protos.ExpressionDebugUpd)
+
+	// Accessed by the expression and whoever sends commands
+	lock        sync.Mutex
+	breakpoints map[string]map[uint32]bool // module ID -> lines
+	paused      bool
+	stopped     atomic.Bool
+
+	// Only sent to while paused, so a buffer of 1 means senders never block
+	commands chan protos.ExpressionDebugCommand
+
+	// Only accessed by the expression's goroutine
+	pausedTime      time.Duration // So time spent paused isn't counted towards the run time limit
+	stepCommand     protos.ExpressionDebugCommand
+	stepDepth       int
+	lastLine        int
+	currentLocation *protos.ExpressionDebugLocation
+	pendingTrace    *protos.ExpressionDebugTraceItem
+	trace           []*protos.ExpressionDebugTraceItem
+	traceDropped    uint32
+}
+
+var debugSessions = map[string]*DebugSession{}
+var debugSessionsLock sync.Mutex
+
+func newDebugSession(id string, userId string, wsSessionId string, breakpoints []*protos.ExpressionDebugLocation, pauseOnStart bool, onUpdate func(upd *protos.ExpressionDebugUpd)) *DebugSession {
+	s := &DebugSession{
+		id:          id,
+		userId:      userId,
+		wsSessionId: wsSessionId,
+		onUpdate:    onUpdate,
+		commands:    make(chan protos.ExpressionDebugCommand, 1),
+		stepCommand: protos.ExpressionDebugCommand_EDC_CONTINUE,
+		trace:       []*protos.ExpressionDebugTraceItem{},
+	}
+
+	if pauseOnStart {
+		s.stepCommand = protos.ExpressionDebugCommand_EDC_STEP_IN
+	}
+
+	s.setBreakpoints(breakpoints)
+	return s
+}
+
+// Starts running the expression in a debug session, returning the session ID. Updates are sent via onUpdate, from the
+// goroutine running the expression, and the last one has a state other than EDS_PAUSED. Fails if the user already has
+// the maximum number of sessions running
+func StartDebugSession(expressionId string, scanId string, quantId string, userId string, wsSessionId string, breakpoints []*protos.ExpressionDebugLocation, pauseOnStart bool, svcs *services.APIServices, onUpdate func(upd *protos.ExpressionDebugUpd)) (string, error) {
+	s := newDebugSession(svcs.IDGen.GenObjectID(), userId, wsSessionId, breakpoints, pauseOnStart, onUpdate)
+
+	debugSessionsLock.Lock()
+	running := 0
+	for _, other := range debugSessions {
+		if other.userId == userId {
+			running++
+		}
+	}
+
+	if running >= maxDebugSessionsPerUser {
+		debugSessionsLock.Unlock()
+		return "", errorwithstatus.MakeBadRequestError(fmt.Errorf("Too many debug sessions running, only %v are allowed at once", maxDebugSessionsPerUser))
+	}
+
+	debugSessions[s.id] = s
+	debugSessionsLock.Unlock()
+
+	go s.run(expressionId, scanId, quantId, svcs)
+	return s.id, nil
+}
+
+// Stops all debug sessions started from a web socket session, eg when it closes
+func StopDebugSessionsForWSSession(wsSessionId string) {
+	toStop := []*DebugSession{}
+
+	debugSessionsLock.Lock()
+	for _, s := range debugSessions {
+		if s.wsSessionId == wsSessionId {
+			toStop = append(toStop, s)
+		}
+	}
+	debugSessionsLock.Unlock()
+
+	for _, s := range toStop {
+		s.sendCommand(protos.ExpressionDebugCommand_EDC_STOP)
+	}
+}
+
+// Sends a command to a debug session. Only the user who started it can control it
+func SendDebugCommand(sessionId string, userId string, command protos.ExpressionDebugCommand, setBreakpoints bool, breakpoints []*protos.ExpressionDebugLocation) error {
+	debugSessionsLock.Lock()
+	s, ok := debugSessions[sessionId]
+	debugSessionsLock.Unlock()
+
+	if !ok {
+		return errorwithstatus.MakeNotFoundError(sessionId)
+	}
+
+	if s.userId != userId {
+		return errorwithstatus.MakeUnauthorisedError(errors.New("Debug session was started by another user"))
+	}
+
+	if setBreakpoints {
+		s.setBreakpoints(breakpoints)
+	}
+
+	return s.sendCommand(command)
+}
+
+func (s *DebugSession) run(expressionId string, scanId string, quantId string, svcs *services.APIServices) {
+	defer func() {
+		debugSessionsLock.Lock()
+		delete(debugSessions, s.id)
+		debugSessionsLock.Unlock()
+	}()
+
+	ch := make(chan exprResult)
+	go runExpressionInternal(ch, expressionId, scanId, quantId, svcs, false, false, s)
+	result := <-ch
+
+	upd := &protos.ExpressionDebugUpd{SessionId: s.id, State: protos.ExpressionDebugState_EDS_FINISHED}
+	if s.stopped.Load() {
+		upd.State = protos.ExpressionDebugState_EDS_STOPPED
+	} else if result.err != nil {
+		upd.State = protos.ExpressionDebugState_EDS_ERROR
+		upd.Error = result.err.Error()
+	}
+
+	upd.Trace, upd.TraceItemsDropped = s.takeTrace()
+	s.onUpdate(upd)
+}
+
+func (s *DebugSession) setBreakpoints(breakpoints []*protos.ExpressionDebugLocation) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.breakpoints = map[string]map[uint32]bool{}
+	for _, bp := range breakpoints {
+		if _, ok := s.breakpoints[bp.ModuleId]; !ok {
+			s.breakpoints[bp.ModuleId] = map[uint32]bool{}
+		}
+		s.breakpoints[bp.ModuleId][bp.Line] = true
+	}
+}
+
+func (s *DebugSession) sendCommand(command protos.ExpressionDebugCommand) error {
+	if command == protos.ExpressionDebugCommand_EDC_NONE {
+		return nil
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if command == protos.ExpressionDebugCommand_EDC_STOP {
+		// If it's running, the instruction hook notices this and stops it
+		s.stopped.Store(true)
+	} else if !s.paused {
+		return errorwithstatus.MakeBadRequestError(fmt.Errorf("Debug session %v is not paused", s.id))
+	}
+
+	if s.paused {
+		s.paused = false
+		s.commands <- command
+	}
+	return nil
+}
+
+// Called before every Lua VM instruction. Returns false to stop the expression
+func (s *DebugSession) onInstruction(L *lua.LState, segments []sourceSegment) bool {
+	if s.stopped.Load() {
+		return false
+	}
+
+	dbg, ok := L.GetStack(0)
+	if !ok {
+		return true
+	}
+
+	// Only interested when we get to a new line
+	if _, err := L.GetInfo("l", dbg, lua.LNil); err != nil || dbg.CurrentLine == s.lastLine {
+		return true
+	}
+	s.lastLine = dbg.CurrentLine
+
+	moduleId, line, ok := findSourceLine(segments, dbg.CurrentLine)
+	if !ok {
+		// Not in code the user can see, so nothing to pause on
+		s.currentLocation = nil
+		return true
+	}
+	s.currentLocation = &protos.ExpressionDebugLocation{ModuleId: moduleId, Line: line}
+
+	depth := luaStackDepth(L)
+	if !s.shouldPause(moduleId, line, depth) {
+		return true
+	}
+
+	return s.pause(L, segments, depth)
+}
+
+func (s *DebugSession) shouldPause(moduleId string, line uint32, depth int) bool {
+	s.lock.Lock()
+	isBreakpoint := s.breakpoints[moduleId][line]
+	s.lock.Unlock()
+
+	if isBreakpoint {
+		return true
+	}
+
+	switch s.stepCommand {
+	case protos.ExpressionDebugCommand_EDC_STEP_IN:
+		return true
+	case protos.ExpressionDebugCommand_EDC_STEP_OVER:
+		return depth <= s.stepDepth
+	case protos.ExpressionDebugCommand_EDC_STEP_OUT:
+		return depth < s.stepDepth
+	}
+
+	return false
+}
+
+// Tells the client where we are, then waits for a command. Returns false if we're to stop
+func (s *DebugSession) pause(L *lua.LState, segments []sourceSegment, depth int) bool {
+	upd := &protos.ExpressionDebugUpd{
+		SessionId: s.id,
+		State:     protos.ExpressionDebugState_EDS_PAUSED,
+		Stack:     readDebugStack(L, segments),
+		Locals:    readDebugLocals(L),
+	}
+	upd.Trace, upd.TraceItemsDropped = s.takeTrace()
+
+	s.lock.Lock()
+	s.paused = true
+	s.lock.Unlock()
+
+	s.onUpdate(upd)
+
+	pausedAt := time.Now()
+	defer func() { s.pausedTime += time.Since(pausedAt) }()
+
+	var command protos.ExpressionDebugCommand
+	select {
+	case command = <-s.commands:
+	case <-time.After(debugIdleTimeout):
+		s.lock.Lock()
+		// Check again, in case a command was sent as we timed out
+		if s.paused {
+			s.paused = false
+			command = protos.ExpressionDebugCommand_EDC_STOP
+			s.stopped.Store(true)
+		} else {
+			command = <-s.commands
+		}
+		s.lock.Unlock()
+	}
+
+	if command == protos.ExpressionDebugCommand_EDC_STOP {
+		return false
+	}
+
+	s.stepCommand = command
+	s.stepDepth = depth
+	return true
+}
+
+// Returns how long the expression has spent paused
+func (s *DebugSession) getPausedTime() time.Duration {
+	return s.pausedTime
+}
+
+// Called when one of our runtime functions is called, and when it returns
+func (s *DebugSession) beginTraceItem(funcName string, args []interface{}) {
+	item := &protos.ExpressionDebugTraceItem{Function: funcName, Args: []string{}, CalledFrom: s.currentLocation}
+	for _, arg := range args {
+		item.Args = append(item.Args, fmt.Sprintf("%v", arg))
+	}
+	s.pendingTrace = item
+}
+
+func (s *DebugSession) endTraceItem(duration time.Duration) {
+	if s.pendingTrace == nil {
+		return
+	}
+
+	s.pendingTrace.DurationMs = float32(duration.Microseconds()) / 1000
+	if len(s.trace) < maxDebugTraceItems {
+		s.trace = append(s.trace, s.pendingTrace)
+	} else {
+		s.traceDropped++
+	}
+	s.pendingTrace = nil
+}
+
+// Returns the trace items gathered since the last call
+func (s *DebugSession) takeTrace() ([]*protos.ExpressionDebugTraceItem, uint32) {
+	trace, dropped := s.trace, s.traceDropped
+	s.trace = []*protos.ExpressionDebugTraceItem{}
+	s.traceDropped = 0
+	return trace, dropped
+}
+
+func luaStackDepth(L *lua.LState) int {
+	depth := 0
+	for {
+		if _, ok := L.GetStack(depth); !ok {
+			return depth
+		}
+		depth++
+	}
+}
+
+// Returns the call stack, innermost first, only including frames in the expression or its modules
+func readDebugStack(L *lua.LState, segments []sourceSegment) []*protos.ExpressionDebugLocation {
+	stack := []*protos.ExpressionDebugLocation{}
+	for level := 0; ; level++ {
+		dbg, ok := L.GetStack(level)
+		if !ok {
+			break
+		}
+
+		if _, err := L.GetInfo("nl", dbg, lua.LNil); err != nil {
+			continue
+		}
+
+		if moduleId, line, ok := findSourceLine(segments, dbg.CurrentLine); ok {
+			stack = append(stack, &protos.ExpressionDebugLocation{ModuleId: moduleId, Line: line, FunctionName: dbg.Name})
+		}
+	}
+	return stack
+}
+
+// Returns the locals of the innermost function, in the order they were declared
+func readDebugLocals(L *lua.LState) []*protos.ExpressionDebugVariable {
+	locals := []*protos.ExpressionDebugVariable{}
+
+	dbg, ok := L.GetStack(0)
+	if !ok {
+		return locals
+	}
+
+	// What gopher-lua reports
+	names := []string{}
+	values := []lua.LValue{}
+	for n := 1; ; n++ {
+		name, value := L.GetLocal(dbg, n)
+		if len(name) <= 0 || name == "(*temporary)" {
+			break
+		}
+		names = append(names, name)
+		values = append(values, value)
+	}
+
+	// gopher-lua only reports locals that were in scope before the previous instruction, but we pause before the
+	// first instruction of a line, so it misses any declared by the line before. We find those from the function's
+	// debug info, and read them from the registers after the ones gopher-lua found
+	fn, err := L.GetInfo("fl", dbg, lua.LNil)
+	if f, ok := fn.(*lua.LFunction); ok && err == nil && !f.IsG {
+		for _, name := range findLocalsStartingOnLine(f.Proto, dbg.CurrentLine, names) {
+			names = append(names, name)
+			values = append(values, L.Get(len(names)))
+		}
+	}
+
+	for c, name := range names {
+		// Lua internal variables, eg (for index)
+		if strings.HasPrefix(name, "(") {
+			continue
+		}
+
+		locals = append(locals, &protos.ExpressionDebugVariable{Name: name, Value: formatDebugValue(L, values[c])})
+	}
+
+	return locals
+}
+
+// Finds the instruction we're paused at, by looking for one at the start of the line where the locals in scope before
+// it match what gopher-lua reported. Returns the names of the locals that come into scope at that instruction
+func findLocalsStartingOnLine(proto *lua.FunctionProto, line int, reportedNames []string) []string {
+	for pc, pcLine := range proto.DbgSourcePositions {
+		if pcLine != line || (pc > 0 && proto.DbgSourcePositions[pc-1] == line) {
+			continue
+		}
+
+		inScope := []string{}
+		starting := []string{}
+		for _, local := range proto.DbgLocals {
+			if local.StartPc < pc && pc < local.EndPc {
+				inScope = append(inScope, local.Name)
+			} else if local.StartPc == pc && pc < local.EndPc {
+				starting = append(starting, local.Name)
+			}
+		}
+
+		if slices.Equal(inScope, reportedNames) {
+			return starting
+		}
+	}
+
+	return []string{}
+}
+
+func formatDebugValue(L *lua.LState, value lua.LValue) string {
+	result := ""
+
+	switch v := value.(type) {
+	case *lua.LTable:
+		readMap, readArray := readLuaTable(L, v)
+
+		var b []byte
+		var err error
+		if len(readArray) > 0 {
+			b, err = json.Marshal(readArray)
+		} else {
+			b, err = json.Marshal(readMap)
+		}
+
+		// eg NaN values can't be written as JSON
+		if err != nil {
+			result = fmt.Sprintf("%v", readMap)
+		} else {
+			result = string(b)
+		}
+	case lua.LString:
+		result = strconv.Quote(string(v))
+	default:
+		result = value.String()
+	}
+
+	if len(result) > maxDebugValueLength {
+		result = result[0:maxDebugValueLength] + "..."
+	}
+	return result
+}
@@ -0,0 +1,155 @@
+package expressionrunner
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pixlise/core/v4/api/config"
+	protos "github.com/pixlise/core/v4/generated-protos"
+	lua "github.com/yuin/gopher-lua"
+)
+
+// Runs the source in a debug session, printing each update. Every time it pauses, the next command is sent
+func runDebugSource(segments []sourceSegment, source string, breakpoints []*protos.ExpressionDebugLocation, pauseOnStart bool, commands []protos.ExpressionDebugCommand) {
+	var s *DebugSession
+	s = newDebugSession("debug123", "user123", "ws123", breakpoints, pauseOnStart, func(upd *protos.ExpressionDebugUpd) {
+		stack := []string{}
+		for _, loc := range upd.Stack {
+			stack = append(stack, fmt.Sprintf("%v:%v:%v", loc.ModuleId, loc.Line, loc.FunctionName))
+		}
+		locals := []string{}
+		for _, v := range upd.Locals {
+			locals = append(locals, v.Name+"="+v.Value)
+		}
+		fmt.Printf("%v stack: [%v] locals: [%v]\n", upd.State, strings.Join(stack, ", "), strings.Join(locals, ", "))
+
+		if len(commands) > 0 {
+			cmd := commands[0]
+			commands = commands[1:]
+			if err := s.sendCommand(cmd); err != nil {
+				fmt.Printf("sendCommand: %v\n", err)
+			}
+		}
+	})
+
+	state, err := newLimitedLuaState(config.ExpressionLimitsConfig{})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer state.Close()
+
+	state.setInstructionHook(func(L *lua.LState) bool { return s.onInstruction(L, segments) })
+
+	err = state.checkLimitError(state.L.DoString(source))
+	if err != nil {
+		fmt.Printf("Stopped: %v, error: %v\n", s.stopped.Load(), strings.Contains(err.Error(), errExpressionStopped.Error()))
+	} else {
+		fmt.Printf("Returned: %v\n", state.L.Get(-1))
+	}
+}
+
+func Example_expressionrunner_debugSession() {
+	// A "module" in lines 1-6, then the "expression"
+	source := `Helper = {}
+function Helper.double(x)
+	local y = x * 2
+	return y
+end
+
+local a = 1
+local t = {a, "two", {3}}
+local b = Helper.double(a)
+return b + 1`
+
+	segments := []sourceSegment{
+		makeSourceSegment("helperModule", "", source[0:strings.Index(source, "\nlocal a")]),
+		makeSourceSegment("", source[0:strings.Index(source, "local a")], source[strings.Index(source, "local a"):]),
+	}
+
+	fmt.Println("Breakpoint in module, step out, continue:")
+	runDebugSource(segments, source, []*protos.ExpressionDebugLocation{{ModuleId: "helperModule", Line: 3}}, false, []protos.ExpressionDebugCommand{
+		protos.ExpressionDebugCommand_EDC_STEP_OUT,
+		protos.ExpressionDebugCommand_EDC_CONTINUE,
+	})
+
+	fmt.Println("Pause on start, step over and in:")
+	runDebugSource(segments, source, []*protos.ExpressionDebugLocation{}, true, []protos.ExpressionDebugCommand{
+		protos.ExpressionDebugCommand_EDC_STEP_OVER,
+		protos.ExpressionDebugCommand_EDC_STEP_OVER,
+		protos.ExpressionDebugCommand_EDC_STEP_OVER,
+		protos.ExpressionDebugCommand_EDC_STEP_IN,
+		protos.ExpressionDebugCommand_EDC_STOP,
+	})
+
+	fmt.Println("No breakpoints:")
+	runDebugSource(segments, source, []*protos.ExpressionDebugLocation{}, false, []protos.ExpressionDebugCommand{})
+
+	fmt.Println("Commands while running:")
+	s := newDebugSession("debug123", "user123", "ws123", []*protos.ExpressionDebugLocation{}, false, nil)
+	fmt.Println(s.sendCommand(protos.ExpressionDebugCommand_EDC_STEP_IN))
+	fmt.Println(s.sendCommand(protos.ExpressionDebugCommand_EDC_STOP), s.stopped.Load())
+
+	// Output:
+	// Breakpoint in module, step out, continue:
+	// EDS_PAUSED stack: [helperModule:3:double, :3:main chunk] locals: [x=1]
+	// EDS_PAUSED stack: [:4:main chunk] locals: [a=1, t=[1,"two",[3]], b=2]
+	// Returned: 3
+	// Pause on start, step over and in:
+	// EDS_PAUSED stack: [helperModule:1:main chunk] locals: []
+	// EDS_PAUSED stack: [helperModule:2:main chunk] locals: []
+	// EDS_PAUSED stack: [:1:main chunk] locals: []
+	// EDS_PAUSED stack: [:2:main chunk] locals: [a=1]
+	// EDS_PAUSED stack: [:3:main chunk] locals: [a=1, t=[1,"two",[3]]]
+	// Stopped: true, error: true
+	// No breakpoints:
+	// Returned: 3
+	// Commands while running:
+	// Debug session debug123 is not paused
+	// <nil> true
+}
+
+func Example_expressionrunner_findSourceLine() {
+	segments := offsetSourceSegments([]sourceSegment{
+		makeSourceSegment("mod1", "\n\n", "line1\nline2\nline3"),
+		makeSourceSegment("", "\n\nline1\nline2\nline3\n", "expr1\nexpr2"),
+	}, 10)
+
+	for _, line := range []int{1, 12, 13, 15, 16, 17, 18} {
+		moduleId, modLine, ok := findSourceLine(segments, line)
+		fmt.Printf("%v: \"%v\" %v %v\n", line, moduleId, modLine, ok)
+	}
+
+	// Output:
+	// 1: "" 0 false
+	// 12: "" 0 false
+	// 13: "mod1" 1 true
+	// 15: "mod1" 3 true
+	// 16: "" 1 true
+	// 17: "" 2 true
+	// 18: "" 0 false
+}
+
+func Example_expressionrunner_formatDebugValue() {
+	L := lua.NewState()
+	defer L.Close()
+
+	err := L.DoString(`Obj = {name = "thing"}
+Obj.__index = Obj
+Obj.self = Obj`)
+	fmt.Println(err)
+
+	fmt.Println(formatDebugValue(L, L.GetGlobal("Obj")))
+	fmt.Println(formatDebugValue(L, lua.LString("hello \"there\"")))
+	fmt.Println(formatDebugValue(L, lua.LNumber(3.5)))
+	fmt.Println(formatDebugValue(L, lua.LNil))
+	fmt.Println(len(formatDebugValue(L, lua.LString(strings.Repeat("a", maxDebugValueLength*2)))))
+
+	// Output:
+	// <nil>
+	// {"__index":"\u003ccircular reference\u003e","name":"thing","self":"\u003ccircular reference\u003e"}
+	// "hello \"there\""
+	// 3.5
+	// nil
+	// 10003
+}
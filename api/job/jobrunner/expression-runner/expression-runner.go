@@ -8,6 +8,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/pixlise/core/v4/api/config"
 	"github.com/pixlise/core/v4/api/dbCollections"
 	"github.com/pixlise/core/v4/api/piquant"
 	"github.com/pixlise/core/v4/api/services"
@@ -55,7 +56,7 @@ import (
 func RunExpression(expressionId string, scanId string, quantId string, svcs *services.APIServices, saveCode bool, debug bool) (*PMCDataValues, uint64, uint64, error) {
 	ch := make(chan exprResult)
 
-	go runExpressionInternal(ch, expressionId, scanId, quantId, svcs, saveCode, debug)
+	go runExpressionInternal(ch, expressionId, scanId, quantId, svcs, saveCode, debug, nil)
 	result := <-ch
 
 	return result.values, result.totalRuntimeMs, result.totalGoFunctionRuntimeMs, result.err
@@ -68,13 +69,26 @@ type exprResult struct {
 	err                      error
 }
 
-func runExpressionInternal(ch chan exprResult, expressionId string, scanId string, quantId string, svcs *services.APIServices, saveCode bool, debug bool) {
+func runExpressionInternal(ch chan exprResult, expressionId string, scanId string, quantId string, svcs *services.APIServices, saveCode bool, debug bool, debugSession *DebugSession) {
+	// Expressions are user code, if anything goes badly wrong running one, we report it as an error rather than
+	// take down the whole process
+	defer func() {
+		if r := recover(); r != nil {
+			ch <- exprResult{err: fmt.Errorf("Expression %v failed: %v", expressionId, r)}
+		}
+	}()
+
 	r, err := makeExpressionRunner(expressionId, scanId, quantId, svcs)
 	if err != nil {
-		ch <- exprResult{}
+		ch <- exprResult{err: err}
 		return
 	}
 
+	if debugSession != nil {
+		// Time spent paused doesn't count towards the run time limit, debug sessions have their own idle timeout for that
+		r.debugSession = debugSession
+	}
+
 	if debug {
 		// If we're debugging:
 		r.debugUseLocalSourceFile = true
@@ -138,6 +152,14 @@ type expressionRunner struct {
 	debugUseLocalSourceFile bool
 	writeLuaSource          bool
 
+	// Set if a client is stepping through the expression as it runs
+	debugSession *DebugSession
+	// Where the expression and its modules are in the source we run, so the debugger can work out lines in each
+	sourceSegments []sourceSegment
+
+	limits        config.ExpressionLimitsConfig
+	limitExceeded *ExpressionLimitError // Set if one of our runtime functions stopped the expression for going over a limit
+
 	totalGoFunctionRuntimeNs uint64
 	totalRuntimeMs           uint64
 }
@@ -157,6 +179,7 @@ func makeExpressionRunner(expressionId string, scanId string, quantId string, sv
 		allPeaks:                []*protos.ClientDiffractionPeak{},
 		roughnessItems:          []*protos.ClientRoughnessItem{},
 		manualPeaks:             map[string]*protos.ManualDiffractionPeak{},
+		limits:                  svcs.Config.ExpressionLimits,
 	}
 
 	if PTable == nil {
@@ -186,7 +209,7 @@ func (e *expressionRunner) Run() (*PMCDataValues, error) {
 	}
 
 	// Retrieve the expression source and all of its modules first
-	allSource, err := e.fetchSourceCode()
+	allSource, segments, err := e.fetchSourceCode()
 
 	if err != nil {
 		return nil, err
@@ -215,7 +238,7 @@ lastMap = m
 	return m
 end
 `
-	allSource = fmt.Sprintf(`local elevAngle = %v
+	prelude := fmt.Sprintf(`local elevAngle = %v
 local quantId = "%v"
 local scanId = "%v"
 local maxSpectrumChannel = %v
@@ -228,7 +251,10 @@ local userId = "%v"
 		4096,
 		"PIXL_FM",
 		sessionuser.PIXLISESystemUserId,
-		makeMapLuaCache) + allSource
+		makeMapLuaCache)
+
+	allSource = prelude + allSource
+	e.sourceSegments = offsetSourceSegments(segments, strings.Count(prelude, "\n"))
 
 	// Replace table.unpack with unpack because gopher-lua is 5.1, table.unpack came in 5.2 but they're the same thing apparently
 	allSource = strings.ReplaceAll(allSource, "table.unpack(", "unpack(")
@@ -274,14 +300,28 @@ func (e *expressionRunner) doString(source string, L *lua.LState) error {
 }
 
 func (e *expressionRunner) runSource(source string, contextId int, makeMapSuffix string) (*PMCDataValues, error) {
-	L := lua.NewState()
-	defer L.Close()
+	state, err := newLimitedLuaState(e.limits)
+	if err != nil {
+		return nil, err
+	}
+	defer state.Close()
 
+	if e.debugSession != nil {
+		state.excludePausedTime(e.debugSession.getPausedTime)
+		state.setInstructionHook(func(L *lua.LState) bool {
+			return e.debugSession.onInstruction(L, e.sourceSegments)
+		})
+	}
+
+	L := state.L
 	e.defineRuntime(L, contextId, makeMapSuffix)
 
-	err := e.doString(source, L)
+	err = e.doString(source, L)
+	if e.limitExceeded != nil {
+		return nil, e.limitExceeded
+	}
 	if err != nil {
-		return nil, err
+		return nil, state.checkLimitError(err)
 	}
 
 	// Get the result if there is one
@@ -331,20 +371,44 @@ func (e *expressionRunner) Log() logger.ILogger {
 	return e.svcs.Log
 }
 
-func (e *expressionRunner) fetchSourceCode() (string, error) {
+func (e *expressionRunner) fetchSourceCode() (string, []sourceSegment, error) {
 	// Read expression
 	expr := &protos.DataExpression{}
 	err := readOne(dbCollections.ExpressionsName, bson.M{"_id": e.expressionId}, expr, e.svcs.MongoDB)
 	if err != nil {
-		return "", err
+		return "", nil, err
 	}
 
 	if expr.SourceLanguage != "LUA" {
-		return "", fmt.Errorf("Error: Expression %v is not Lua", e.expressionId)
+		return "", nil, fmt.Errorf("Error: Expression %v is not Lua", e.expressionId)
+	}
+
+	builtInSources, moduleSources, err := readModuleSources(expr, e.svcs)
+	if err != nil {
+		return "", nil, err
 	}
 
 	allSource := ""
+	segments := []sourceSegment{}
 
+	for _, modSrc := range builtInSources {
+		allSource = allSource + "\n" + modSrc + "\n"
+	}
+
+	for c, modSrc := range moduleSources {
+		allSource = allSource + "\n"
+		segments = append(segments, makeSourceSegment(expr.ModuleReferences[c].ModuleId, allSource, modSrc))
+		allSource = allSource + modSrc + "\n"
+	}
+
+	segments = append(segments, makeSourceSegment("", allSource, expr.SourceCode))
+	allSource = allSource + expr.SourceCode
+	return allSource, segments, nil
+}
+
+// Returns the source code of the built-in modules and the modules referenced by the expression, ready to be prepended
+// to the expression source
+func readModuleSources(expr *protos.DataExpression, svcs *services.APIServices) ([]string, []string, error) {
 	// Read built-in modules
 	// builtInModules := []string{"./built-in-modules/Map.lua", "./built-in-modules/DebugHelp.lua"}
 	// for _, modPath := range builtInModules {
@@ -352,25 +416,24 @@ func (e *expressionRunner) fetchSourceCode() (string, error) {
 	// 	if err != nil {
 	// 		return "", err
 	// 	}
+	builtInSources := []string{}
 	builtInModules := []string{debugModule, mapModule}
 	for _, modSrcFile := range builtInModules {
-		modSrc := snipReturnModuleLine(string(modSrcFile))
-		allSource = allSource + "\n" + modSrc + "\n"
+		builtInSources = append(builtInSources, snipReturnModuleLine(string(modSrcFile)))
 	}
 
 	// Read modules
+	moduleSources := []string{}
 	for _, modRef := range expr.ModuleReferences {
-		_, modVer, err := readModule(modRef.ModuleId, modRef.Version, e.svcs)
+		_, modVer, err := readModule(modRef.ModuleId, modRef.Version, svcs)
 		if err != nil {
-			return "", err
+			return nil, nil, err
 		}
 
-		modSrc := snipReturnModuleLine(modVer.SourceCode)
-		allSource = allSource + "\n" + modSrc + "\n"
+		moduleSources = append(moduleSources, snipReturnModuleLine(modVer.SourceCode))
 	}
 
-	allSource = allSource + expr.SourceCode
-	return allSource, nil
+	return builtInSources, moduleSources, nil
 }
 
 func snipReturnModuleLine(src string) string {
@@ -0,0 +1,285 @@
+package expressionrunner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pixlise/core/v4/api/config"
+	protos "github.com/pixlise/core/v4/generated-protos"
+	lua "github.com/yuin/gopher-lua"
+)
+
+// Expressions are user-written code, so we run them with limits to stop a bad one from tying up whoever is running
+// it (API or job node). If an expression goes over a limit, the error returned is an ExpressionLimitError
+
+type ExpressionLimitError struct {
+	Limit   protos.ExpressionLimit
+	Message string
+}
+
+func (e *ExpressionLimitError) Error() string {
+	return e.Message
+}
+
+// Returns the limit that was exceeded, if the error is because of one
+func GetExpressionLimitExceeded(err error) (protos.ExpressionLimit, bool) {
+	var limitErr *ExpressionLimitError
+	if errors.As(err, &limitErr) {
+		return limitErr.Limit, true
+	}
+	return protos.ExpressionLimit_EL_NONE, false
+}
+
+// Standard Lua libraries we allow if none are configured. io and package would allow expressions to reach outside of
+// the Lua VM, and debug to reach into other functions' locals and upvalues. Modules use os.time, and os is cut down to
+// only the functions in allowedLibFuncs
+var defaultAllowedLuaLibs = []string{"base", "table", "string", "math", "os"}
+
+var luaLibs = map[string]lua.LGFunction{
+	"base":      lua.OpenBase,
+	"package":   lua.OpenPackage,
+	"table":     lua.OpenTable,
+	"io":        lua.OpenIo,
+	"os":        lua.OpenOs,
+	"string":    lua.OpenString,
+	"math":      lua.OpenMath,
+	"debug":     lua.OpenDebug,
+	"channel":   lua.OpenChannel,
+	"coroutine": lua.OpenCoroutine,
+}
+
+// Library functions that access files or the process, which we don't want expressions doing, even if the library is allowed
+var disallowedLibFuncs = map[string][]string{
+	"base": {"dofile", "loadfile"},
+}
+
+// Libraries where only some functions are safe for expressions. Everything else is removed, even if the library is allowed
+var allowedLibFuncs = map[string][]string{
+	"os": {"time", "clock"},
+}
+
+// gopher-lua checks its context before executing every VM instruction, so we can count instructions by counting how
+// often it does that. Once the budget is used up, the context reports itself as done
+type instructionBudgetContext struct {
+	context.Context
+	budget uint64
+	count  uint64
+
+	exceeded     chan struct{}
+	exceededOnce sync.Once
+}
+
+var errInstructionBudgetExceeded = errors.New("instruction budget exceeded")
+
+func (c *instructionBudgetContext) Done() <-chan struct{} {
+	c.count++
+	if c.count > c.budget {
+		c.exceededOnce.Do(func() { close(c.exceeded) })
+		return c.exceeded
+	}
+	return c.Context.Done()
+}
+
+func (c *instructionBudgetContext) Err() error {
+	if c.count > c.budget {
+		return errInstructionBudgetExceeded
+	}
+	return c.Context.Err()
+}
+
+// Like context.WithTimeout, except time spent paused in the debugger can be left out, so a user looking at a paused
+// expression doesn't use up its run time. Reading the clock is slower than running an instruction, so we only do it
+// every runTimeCheckInterval instructions
+type runTimeContext struct {
+	context.Context
+	limit      time.Duration
+	started    time.Time
+	pausedTime func() time.Duration
+	count      uint64
+
+	exceeded   chan struct{}
+	isExceeded bool
+}
+
+const runTimeCheckInterval = 1000
+
+func (c *runTimeContext) Done() <-chan struct{} {
+	c.count++
+	if !c.isExceeded && c.count%runTimeCheckInterval == 0 {
+		elapsed := time.Since(c.started)
+		if c.pausedTime != nil {
+			elapsed -= c.pausedTime()
+		}
+
+		if elapsed > c.limit {
+			c.isExceeded = true
+			close(c.exceeded)
+		}
+	}
+
+	if c.isExceeded {
+		return c.exceeded
+	}
+	return c.Context.Done()
+}
+
+func (c *runTimeContext) Err() error {
+	if c.isExceeded {
+		return context.DeadlineExceeded
+	}
+	return c.Context.Err()
+}
+
+// A Lua VM to run an expression in, with limits applied. Must be closed when done
+type limitedLuaState struct {
+	L       *lua.LState
+	limits  config.ExpressionLimitsConfig
+	ctx     context.Context
+	cancel  context.CancelFunc
+	runTime *runTimeContext // nil if there's no run time limit
+}
+
+func newLimitedLuaState(limits config.ExpressionLimitsConfig) (*limitedLuaState, error) {
+	L := lua.NewState(lua.Options{SkipOpenLibs: true})
+
+	allowedLibs := limits.AllowedLuaLibs
+	if len(allowedLibs) <= 0 {
+		allowedLibs = defaultAllowedLuaLibs
+	}
+
+	for _, libName := range allowedLibs {
+		open, ok := luaLibs[libName]
+		if !ok {
+			L.Close()
+			return nil, fmt.Errorf("Unknown Lua library: %v", libName)
+		}
+
+		// Same as what lua.OpenLibs does, except base is named "" in gopher-lua
+		name := libName
+		if name == "base" {
+			name = lua.BaseLibName
+		}
+
+		L.Push(L.NewFunction(open))
+		L.Push(lua.LString(name))
+		L.Call(1, 0)
+
+		for _, f := range disallowedLibFuncs[libName] {
+			if name == lua.BaseLibName {
+				L.SetGlobal(f, lua.LNil)
+			} else if lib, ok := L.GetGlobal(name).(*lua.LTable); ok {
+				lib.RawSetString(f, lua.LNil)
+			}
+		}
+
+		if allowed, ok := allowedLibFuncs[libName]; ok {
+			if lib, ok := L.GetGlobal(name).(*lua.LTable); ok {
+				restricted := L.NewTable()
+				for _, f := range allowed {
+					restricted.RawSetString(f, lib.RawGetString(f))
+				}
+				L.SetGlobal(name, restricted)
+			}
+		}
+	}
+
+	result := &limitedLuaState{L: L, limits: limits}
+
+	result.ctx, result.cancel = context.WithCancel(context.Background())
+
+	if limits.MaxRunTimeSec > 0 {
+		result.runTime = &runTimeContext{Context: result.ctx, limit: time.Duration(limits.MaxRunTimeSec) * time.Second, started: time.Now(), exceeded: make(chan struct{})}
+		result.ctx = result.runTime
+	}
+
+	if limits.MaxInstructions > 0 {
+		result.ctx = &instructionBudgetContext{Context: result.ctx, budget: limits.MaxInstructions, exceeded: make(chan struct{})}
+	}
+
+	L.SetContext(result.ctx)
+	return result, nil
+}
+
+// gopher-lua has no debug hooks, but we can use the same trick as the instruction budget to call a function before
+// every VM instruction. If the hook returns false, the context reports itself as done, stopping the expression
+type instructionHookContext struct {
+	context.Context
+	L    *lua.LState
+	hook func(L *lua.LState) bool
+
+	stopped   chan struct{}
+	isStopped bool
+}
+
+var errExpressionStopped = errors.New("expression stopped")
+
+func (c *instructionHookContext) Done() <-chan struct{} {
+	if !c.isStopped && !c.hook(c.L) {
+		c.isStopped = true
+		close(c.stopped)
+	}
+	if c.isStopped {
+		return c.stopped
+	}
+	return c.Context.Done()
+}
+
+func (c *instructionHookContext) Err() error {
+	if c.isStopped {
+		return errExpressionStopped
+	}
+	return c.Context.Err()
+}
+
+// Calls hook before every VM instruction, stopping the expression if it returns false. Used by the debugger
+func (s *limitedLuaState) setInstructionHook(hook func(L *lua.LState) bool) {
+	s.ctx = &instructionHookContext{Context: s.ctx, L: s.L, hook: hook, stopped: make(chan struct{})}
+	s.L.SetContext(s.ctx)
+}
+
+// Leaves the time pausedTime returns out of the run time limit. Used by the debugger, for the time spent paused
+func (s *limitedLuaState) excludePausedTime(pausedTime func() time.Duration) {
+	if s.runTime != nil {
+		// Only count pauses since we started
+		before := pausedTime()
+		s.runTime.pausedTime = func() time.Duration { return pausedTime() - before }
+	}
+}
+
+func (s *limitedLuaState) Close() {
+	s.L.Close()
+	s.cancel()
+}
+
+// If the Lua VM stopped with an error because of one of our context based limits, this returns an ExpressionLimitError
+// describing it, otherwise the error passed in
+func (s *limitedLuaState) checkLimitError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	switch s.ctx.Err() {
+	case errInstructionBudgetExceeded:
+		return &ExpressionLimitError{
+			Limit:   protos.ExpressionLimit_EL_INSTRUCTIONS,
+			Message: fmt.Sprintf("Expression exceeded the limit of %v instructions", s.limits.MaxInstructions),
+		}
+	case context.DeadlineExceeded:
+		return &ExpressionLimitError{
+			Limit:   protos.ExpressionLimit_EL_RUN_TIME,
+			Message: fmt.Sprintf("Expression exceeded the run time limit of %v sec", s.limits.MaxRunTimeSec),
+		}
+	}
+
+	return err
+}
+
+// Called from our Lua runtime functions when they hit a limit. The error is raised in Lua to stop the expression, and
+// we also remember it, so it's still reported if the expression catches the error with pcall
+func (e *expressionRunner) raiseLimitError(L *lua.LState, limit protos.ExpressionLimit, message string) int {
+	e.limitExceeded = &ExpressionLimitError{Limit: limit, Message: message}
+	return reportLuaRuntimeError(L, e.limitExceeded)
+}
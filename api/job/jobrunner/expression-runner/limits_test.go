@@ -0,0 +1,110 @@
+package expressionrunner
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pixlise/core/v4/api/config"
+)
+
+func runLimited(limits config.ExpressionLimitsConfig, source string) {
+	state, err := newLimitedLuaState(limits)
+	if err != nil {
+		fmt.Printf("newLimitedLuaState: %v\n", err)
+		return
+	}
+	defer state.Close()
+
+	err = state.checkLimitError(state.L.DoString(source))
+	if err != nil {
+		limit, isLimit := GetExpressionLimitExceeded(err)
+		if isLimit {
+			fmt.Printf("%v: %v\n", limit, err)
+		} else {
+			fmt.Println("error")
+		}
+		return
+	}
+
+	fmt.Println(state.L.Get(-1))
+}
+
+func Example_expressionrunner_newLimitedLuaState() {
+	runLimited(config.ExpressionLimitsConfig{MaxInstructions: 1000}, "local a = 0\nwhile true do a = a + 1 end")
+	runLimited(config.ExpressionLimitsConfig{MaxInstructions: 1000}, "local a = 0\nfor i = 1, 10 do a = a + i end\nreturn a")
+	runLimited(config.ExpressionLimitsConfig{MaxInstructions: 1000}, "local a = 0\nwhile true do pcall(function() a = a + 1 end) end")
+	runLimited(config.ExpressionLimitsConfig{MaxRunTimeSec: 1}, "while true do end")
+
+	// Only allowed libraries are available, and the functions we don't allow are removed from them
+	runLimited(config.ExpressionLimitsConfig{}, "return io == nil and os.execute == nil and dofile == nil and os.time ~= nil and string.len(\"abc\") == 3")
+	runLimited(config.ExpressionLimitsConfig{}, "return debug == nil and os.clock ~= nil and os.date == nil and os.getenv == nil")
+	runLimited(config.ExpressionLimitsConfig{AllowedLuaLibs: []string{"base", "os", "debug"}}, "return os.date == nil and debug.getinfo ~= nil")
+	runLimited(config.ExpressionLimitsConfig{AllowedLuaLibs: []string{"base"}}, "return string == nil")
+	runLimited(config.ExpressionLimitsConfig{AllowedLuaLibs: []string{"base", "sockets"}}, "return 1")
+
+	// Output:
+	// EL_INSTRUCTIONS: Expression exceeded the limit of 1000 instructions
+	// 55
+	// EL_INSTRUCTIONS: Expression exceeded the limit of 1000 instructions
+	// EL_RUN_TIME: Expression exceeded the run time limit of 1 sec
+	// true
+	// true
+	// true
+	// true
+	// newLimitedLuaState: Unknown Lua library: sockets
+}
+
+func Example_expressionrunner_runTimeContext() {
+	// Started 2 sec ago with a 1 sec limit, so only within the limit if it spent over 1 sec of that paused
+	for _, paused := range []time.Duration{1500 * time.Millisecond, 500 * time.Millisecond} {
+		ctx := &runTimeContext{Context: context.Background(), limit: time.Second, started: time.Now().Add(-2 * time.Second), exceeded: make(chan struct{})}
+		ctx.pausedTime = func() time.Duration { return paused }
+
+		for c := 0; c < runTimeCheckInterval; c++ {
+			ctx.Done()
+		}
+		fmt.Printf("Paused %v: %v\n", paused, ctx.Err())
+	}
+
+	// Output:
+	// Paused 1.5s: <nil>
+	// Paused 500ms: context deadline exceeded
+}
+
+func Example_expressionrunner_builtInModulesDefaultLimits() {
+	// Built-in modules are loaded for every expression, so they must only use what's allowed by default
+	builtIn := snipReturnModuleLine(debugModule) + "\n" + snipReturnModuleLine(mapModule) + "\n"
+	builtIn = strings.ReplaceAll(builtIn, "table.unpack(", "unpack(")
+
+	runLimited(config.ExpressionLimitsConfig{}, builtIn+`
+DebugHelp.listAllTables("", {a=1}, true)
+
+-- These print timings and blank lines, which we can't compare
+local realPrint = print
+print = function() end
+DebugHelp.printTable("t", {1})
+DebugHelp.logPerf("perf")
+print = realPrint
+
+local m = {{1, 2, 3}, {1, -4, 9}}
+Map.printDebugMap(m, "m")
+local ms = {
+    Map.mul(m, 2), Map.add(m, 1), Map.min(m, 0), Map.max(m, 0), Map.abs(m), Map.And(m, m), Map.Or(m, m),
+    Map.div(m, 2), Map.sub(m, 1), Map.over(m, 0), Map.over_undef(m, 0), Map.under(m, 0), Map.under_undef(m, 0),
+    Map.sin(m), Map.cos(m), Map.tan(m), Map.asin(Map.div(m, 10)), Map.acos(Map.div(m, 10)), Map.atan(m), Map.exp(m),
+    Map.ln(Map.abs(m)), Map.pow(m, 2), Map.Not(m), Map.normalise(m), Map.threshold(m, 1, 0.5),
+}
+Map.setPMCValue(m, 4, 16)
+Map.replaceBadValues(m, 0)
+return #ms..","..Map.getPMCValue(m, 4)..","..Map.getNthPMC(m, 2)..","..Map.getNthValue(m, 3)..","..#Map.getPMCs(m)..","..#Map.getValues(m)`)
+
+	// Output:
+	// a 1
+	// m map size: 3
+	// 1=1
+	// 2=-4
+	// 3=9
+	// 25,16,2,9,4,4
+}
@@ -163,6 +163,15 @@ func makeLuaTableGeneric(L *lua.LState, data map[string]interface{}) *lua.LTable
 }
 
 func readLuaTable(L *lua.LState, t *lua.LTable) (map[string]interface{}, []interface{}) { //map[interface{}]interface{} {
+	return readLuaTableInternal(L, t, map[*lua.LTable]bool{})
+}
+
+// Tables can reference themselves (eg class tables in modules set __index = self), so we keep track of the tables
+// we're in the middle of reading, and write a placeholder if we find one again
+func readLuaTableInternal(L *lua.LState, t *lua.LTable, reading map[*lua.LTable]bool) (map[string]interface{}, []interface{}) {
+	reading[t] = true
+	defer delete(reading, t)
+
 	//result := map[interface{}]interface{}{}
 	result := map[string]interface{}{}
 	resultArray := []interface{}{}
@@ -184,8 +193,10 @@ func readLuaTable(L *lua.LState, t *lua.LTable) (map[string]interface{}, []inter
 		var value interface{}
 
 		vTable, ok := v.(*lua.LTable)
-		if ok && vTable != nil {
-			readTable, readArray := readLuaTable(L, vTable)
+		if ok && vTable != nil && reading[vTable] {
+			value = "<circular reference>"
+		} else if ok && vTable != nil {
+			readTable, readArray := readLuaTableInternal(L, vTable, reading)
 
 			if len(readArray) > 0 {
 				value = readArray
@@ -0,0 +1,138 @@
+package expressionrunner
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pixlise/core/v4/api/dbCollections"
+	"github.com/pixlise/core/v4/api/services"
+	protos "github.com/pixlise/core/v4/generated-protos"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/protobuf/proto"
+)
+
+// Expression results are memoised so the client (and anything else running expressions) can retrieve them without
+// running them again. The API and job nodes both write them, so the key format and stored data have to match what
+// the client code expects
+
+// Written to match fromMemoised() in client code
+func FromMemoised(data []byte) (*protos.MemDataQueryResult, error) {
+	memResult := &protos.MemDataQueryResult{}
+	err := proto.Unmarshal(data, memResult)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// NOTE: we read the same MemDataQueryResult structure as we return
+	return memResult, nil
+}
+
+// Written to match toMemoised() in client code
+func ToMemoised(m *PMCDataValues, expr *protos.DataExpression) (*protos.MemDataQueryResult, []byte, error) {
+	// We copy to protobuf structs which then serialise to binary
+	// NOTE: This is an experiment, if it works, maybe we'll switch all code to use these structs!
+	memValues := []*protos.MemPMCDataValue{}
+
+	for _, val := range m.Values {
+		memValues = append(memValues, &protos.MemPMCDataValue{
+			Pmc:         uint32(val.PMC),
+			Value:       float32(val.Value),
+			IsUndefined: val.IsUndefined,
+			Label:       val.Label,
+		})
+	}
+
+	//memPixelIndexSet := result.region ? Array.from(result.region.pixelIndexSet) : [];
+
+	memResult := &protos.MemDataQueryResult{
+		ResultValues: &protos.MemPMCDataValues{
+			MinValue: float32(*m.ValueRange.Min),
+			MaxValue: float32(*m.ValueRange.Max),
+			Values:   memValues,
+			IsBinary: m.IsBinary,
+			Warning:  m.Warning,
+		},
+		IsPMCTable: true,
+	}
+
+	if expr != nil {
+		memResult.Expression = expr
+	}
+	/*
+		if (result.region) {
+			memResult.region = MemRegionSettings.create({
+			region: result.region.region,
+			displaySettings: ROIItemDisplaySettings.create({
+				colour: result.region.displaySettings.colour.asString(),
+				shape: result.region.displaySettings.shape,
+			}),
+			pixelIndexSet: memPixelIndexSet,
+			});
+		}*/
+
+	b, err := proto.Marshal(memResult)
+	return memResult, b, err
+}
+
+// NOTE: The client makes these keys too to look up memoised results, so this must stay in the same format as the client's.
+// Don't leave parts out based on what the expression reads, the client wouldn't find the result
+func MakeMemoisationKey(scanItem *protos.ScanItem, exprItem *protos.DataExpression, quantId string, roiId string, units protos.DataUnit) string {
+	// Keys are of the form:
+	// {"scanId":"602735105","exprId":"q2ns80oc4452eldt","quantId":"quant-aqpxxfk6i05gcsy3","roiId":"AllPoints-602735105","units":0},Resp:false,exprMod:1772129285,spectra:3298,90,0
+	// So we need scan summary details and the expression last modified time
+	normalSpectraCount := scanItem.ContentCounts["NormalSpectra"]
+	dwellSpectraCount := scanItem.ContentCounts["DwellSpectra"]
+
+	spectrumTimeStamp := 0 // Comes from SpectrumResp.timeStampUnixSec, seems to always be 0 for now??
+
+	return fmt.Sprintf(
+		`{"scanId":"%v","exprId":"%v","quantId":"%v","roiId":"%v","units":%v},Resp:false,exprMod:%v,spectra:%v,%v,%v`,
+		scanItem.Id,
+		exprItem.Id,
+		quantId,
+		roiId,
+		units.Number(),
+		exprItem.ModifiedUnixSec,
+		normalSpectraCount,
+		dwellSpectraCount,
+		spectrumTimeStamp,
+	)
+}
+
+// Writes an expression result to the memoisation cache
+func Memoise(memCacheKey string, scanId string, quantId string, exprItem *protos.DataExpression, requestorUserId string, m *PMCDataValues, svcs *services.APIServices) (*protos.MemoisedItem, *protos.MemDataQueryResult, error) {
+	memResult, data, err := ToMemoised(m, exprItem)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Failed to create memoise item for expression: %v. Error: %v", exprItem.Id, err)
+	}
+
+	ctx := context.TODO()
+	coll := svcs.MongoDB.Collection(dbCollections.MemoisedItemsName)
+	opt := options.Update().SetUpsert(true)
+
+	timestamp := uint32(svcs.TimeStamper.GetTimeNowSec())
+	item := &protos.MemoisedItem{
+		Key:                 memCacheKey,
+		MemoTimeUnixSec:     timestamp,
+		Data:                data,
+		ScanId:              scanId,
+		QuantId:             quantId,
+		ExprId:              exprItem.Id,
+		DataSize:            uint32(len(data)),
+		LastReadTimeUnixSec: timestamp, // Right now this is the last time it was accessed. To be updated in future get calls
+		MemoWriterUserId:    requestorUserId,
+	}
+
+	result, err := coll.UpdateByID(ctx, memCacheKey, bson.D{{Key: "$set", Value: item}}, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if result.UpsertedCount == 0 && (result.MatchedCount != result.ModifiedCount) {
+		svcs.Log.Errorf("memoise expression result for: %v got unexpected DB write result: %+v", memCacheKey, result)
+	}
+
+	return item, memResult, nil
+}
@@ -24,25 +24,33 @@ import (
 // quant/scan etc to load
 var contextIdLuaVarName = "execContextId"
 
+// The Go functions we provide to Lua, by the global name Lua calls them by. makeMap is defined separately, as it's given
+// a suffix so a Lua wrapper can cache its result
+var runtimeFunctions = map[string]lua.LGFunction{
+	"element":          element,
+	"elementSum":       elementSum,
+	"data":             data,
+	"spectrum":         spectrum,
+	"spectrumDiff":     spectrumDiff,
+	"pseudo":           pseudo,
+	"housekeeping":     housekeeping,
+	"diffractionPeaks": diffractionPeaks,
+	"roughness":        roughness,
+	"position":         position,
+	"exists":           exists,
+	"writeCache":       writeCache,
+	"readCache":        readCache,
+	"readMap":          readMap,
+	"atomicMass":       atomicMass,
+}
+
 func (e *expressionRunner) defineRuntime(L *lua.LState, contextId int, makeMapSuffix string) {
 	L.SetGlobal(contextIdLuaVarName, lua.LNumber(contextId))
 
-	L.SetGlobal("element", L.NewFunction(element))
-	L.SetGlobal("elementSum", L.NewFunction(elementSum))
-	L.SetGlobal("data", L.NewFunction(data))
-	L.SetGlobal("spectrum", L.NewFunction(spectrum))
-	L.SetGlobal("spectrumDiff", L.NewFunction(spectrumDiff))
-	L.SetGlobal("pseudo", L.NewFunction(pseudo))
-	L.SetGlobal("housekeeping", L.NewFunction(housekeeping))
-	L.SetGlobal("diffractionPeaks", L.NewFunction(diffractionPeaks))
-	L.SetGlobal("roughness", L.NewFunction(roughness))
-	L.SetGlobal("position", L.NewFunction(position))
+	for name, f := range runtimeFunctions {
+		L.SetGlobal(name, L.NewFunction(f))
+	}
 	L.SetGlobal("makeMap"+makeMapSuffix, L.NewFunction(makeMap))
-	L.SetGlobal("exists", L.NewFunction(exists))
-	L.SetGlobal("writeCache", L.NewFunction(writeCache))
-	L.SetGlobal("readCache", L.NewFunction(readCache))
-	L.SetGlobal("readMap", L.NewFunction(readMap))
-	L.SetGlobal("atomicMass", L.NewFunction(atomicMass))
 }
 
 func getContext(L *lua.LState) *expressionRunner {
@@ -123,6 +131,10 @@ func (e *expressionRunner) funcPrintArgs(funcName string, args ...interface{}) {
 	}
 	//f = fmt.Sprintf(f, args)
 	e.Log().Debugf("    Lua runtime:   "+funcName+"("+f+")", args...)
+
+	if e.debugSession != nil {
+		e.debugSession.beginTraceItem(funcName, args)
+	}
 }
 
 func (e *expressionRunner) funcEnd(startTime time.Time) {
@@ -131,6 +143,10 @@ func (e *expressionRunner) funcEnd(startTime time.Time) {
 	e.Log().Debugf("                -> %vms", runtime.Milliseconds())
 
 	e.totalGoFunctionRuntimeNs += uint64(runtime.Nanoseconds())
+
+	if e.debugSession != nil {
+		e.debugSession.endTraceItem(runtime)
+	}
 }
 
 func element(L *lua.LState) int { // args(symbol, column, detector)
@@ -599,6 +615,10 @@ func makeMap(L *lua.LState) int { // args(value)
 
 	e.funcPrintArgs("makeMap", value)
 
+	if len(e.quantData.LocationSet) > 0 && e.limits.MaxMapSize > 0 && uint(len(e.quantData.LocationSet[0].Location)) > e.limits.MaxMapSize {
+		return e.raiseLimitError(L, protos.ExpressionLimit_EL_MAP_SIZE, fmt.Sprintf("makeMap would create a map of %v values, the limit is %v", len(e.quantData.LocationSet[0].Location), e.limits.MaxMapSize))
+	}
+
 	result := PMCDataValues{}
 	result.IsBinary = true // pre-set for detection in addValue
 	if len(e.quantData.LocationSet) > 0 {
@@ -709,6 +729,10 @@ func writeCache(L *lua.LState) int { // args(k, v)
 		} else {
 			b, err = json.Marshal(readMap)
 		}
+
+		if e.limits.MaxCacheBytes > 0 && uint(len(b)) > e.limits.MaxCacheBytes {
+			return e.raiseLimitError(L, protos.ExpressionLimit_EL_CACHE_SIZE, fmt.Sprintf("writeCache for %v would save %v bytes, the limit is %v", k, len(b), e.limits.MaxCacheBytes))
+		}
 		/*
 			if len(readArray) > 0 {
 				// b, err = json.Marshal(readArray)
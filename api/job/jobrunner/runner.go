@@ -1,6 +1,7 @@
 package jobrunner
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path"
@@ -18,13 +19,26 @@ var EnvBucketName = "JOB_BUCKET"
 var EnvPathName = "JOB_PATH"
 var EnvNodeIndexName = "NODE_INDEX"
 
+// Reads the job group config that the job manager wrote to the jobs bucket when the job was submitted
+func ReadJobGroupConfig(jobBucket string, jobPath string, remoteFS fileaccess.FileAccess) (*jobconfig.JobGroupConfig, error) {
+	jobParamPath := path.Join(jobPath, quantification.JobParamsFileName)
+	jobGroupCfg := &jobconfig.JobGroupConfig{}
+	err := remoteFS.ReadJSON(jobBucket, jobParamPath, jobGroupCfg, false)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read job config s3://%v/%v: %v", jobBucket, jobParamPath, err)
+	}
+
+	return jobGroupCfg, nil
+}
+
 // Downloads files required for job to run and sets up libraries. Requires JOB_CONFIG environment variable
 // to be set to a JobConfig structure
 // Parameters:
 // - jobBucket - The S3 bucket to read job config from
 // - jobPath - Path to the job in S3
 // - localBucketPath - use this to set a local path to simulate bucket access from, useful for unit tests
-func RunJob(jobBucket string, jobPath string, nodeIndex uint, remoteFS fileaccess.FileAccess) error {
+// If ctx is cancelled, the job stops, killing the command if it's running
+func RunJob(ctx context.Context, jobBucket string, jobPath string, nodeIndex uint, remoteFS fileaccess.FileAccess) error {
 	if len(jobBucket) <= 0 {
 		return fmt.Errorf("RunJob: bucket not set")
 	}
@@ -40,11 +54,9 @@ func RunJob(jobBucket string, jobPath string, nodeIndex uint, remoteFS fileacces
 	jobLog.Infof("Running job from s3://%v/%v for node %v", jobBucket, jobPath, nodeIndex)
 
 	// Read config from S3 (or our local simulator!)
-	jobParamPath := path.Join(jobPath, quantification.JobParamsFileName)
-	var jobGroupCfg jobconfig.JobGroupConfig
-	err := remoteFS.ReadJSON(jobBucket, jobParamPath, &jobGroupCfg, false)
+	jobGroupCfg, err := ReadJobGroupConfig(jobBucket, jobPath, remoteFS)
 	if err != nil {
-		return fmt.Errorf("Failed to read job config s3://%v/%v: %v", jobBucket, jobParamPath, err)
+		return err
 	}
 
 	cfg := jobGroupCfg.NodeConfig.FlattenJobConfig(nodeIndex)
@@ -59,6 +71,10 @@ func RunJob(jobBucket string, jobPath string, nodeIndex uint, remoteFS fileacces
 	// Download required files
 	jobLog.Infof("Downloading files...")
 	for _, reqFile := range cfg.RequiredFiles {
+		if ctx.Err() != nil {
+			return fmt.Errorf("Job %v stopped: %v", cfg.JobId, ctx.Err())
+		}
+
 		err := downloadFile(jobLog, remoteFS, reqFile.RemoteBucket, reqFile.RemotePath, reqFile.LocalPath)
 		if err != nil {
 			return err
@@ -104,7 +120,10 @@ func RunJob(jobBucket string, jobPath string, nodeIndex uint, remoteFS fileacces
 	// This way we can just test the file download and upload capabilities separately
 	startUnixSec := time.Now().Unix()
 
-	cmdStdOut, err := runCommand(commandToRun, cfg.Args)
+	cmdStdOut, err := runCommand(ctx, commandToRun, cfg.Args)
+	if ctx.Err() != nil {
+		return fmt.Errorf("Job %v stopped: %v", cfg.JobId, ctx.Err())
+	}
 	if err != nil {
 		outErr := fmt.Errorf("Job %v failed: %v", cfg.JobId, err)
 		jobLog.Errorf("%v", outErr)
@@ -1,12 +1,14 @@
 package jobrunner
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
 	"os"
 	"path"
 	"path/filepath"
+	"time"
 
 	jobconfig "github.com/pixlise/core/v4/api/job/config"
 	"github.com/pixlise/core/v4/core/fileaccess"
@@ -16,10 +18,10 @@ import (
 func Example_jobrunner_RunJob_BadConfigs() {
 	fs := fileaccess.MakeFSAccessS3Simulator("./test-bucket-root")
 
-	fmt.Printf("%v\n", RunJob("", "", 10, fs))
-	fmt.Printf("%v\n", RunJob("bucket", "", 10, fs))
-	fmt.Printf("%v\n", RunJob("bucket", "path/to/job", 1000000, fs))
-	fmt.Printf("%v\n", RunJob("bucket", "path/to/job", 10, fs))
+	fmt.Printf("%v\n", RunJob(context.Background(), "", "", 10, fs))
+	fmt.Printf("%v\n", RunJob(context.Background(), "bucket", "", 10, fs))
+	fmt.Printf("%v\n", RunJob(context.Background(), "bucket", "path/to/job", 1000000, fs))
+	fmt.Printf("%v\n", RunJob(context.Background(), "bucket", "path/to/job", 10, fs))
 
 	// Output:
 	// RunJob: bucket not set
@@ -84,7 +86,7 @@ func Example_jobrunner_RunJob_NoCommand() {
 		JobGroupId: "Job001",
 	}, "./test-bucket-root/job-bucket/path/to/job001/params.json")
 
-	fmt.Printf("Job: %v\n", RunJob("job-bucket", "path/to/job001", 4, fs))
+	fmt.Printf("Job: %v\n", RunJob(context.Background(), "job-bucket", "path/to/job001", 4, fs))
 
 	// Output:
 	// INFO: Running job from s3://job-bucket/path/to/job001 for node 4
@@ -92,6 +94,37 @@ func Example_jobrunner_RunJob_NoCommand() {
 	// Job: No command specified
 }
 
+func Example_jobrunner_RunJob_Cancelled() {
+	origWD, fs := initTest()
+	defer os.Chdir(origWD)
+
+	writeConfig(jobconfig.JobGroupConfig{
+		JobGroupId: "Job001",
+		NodeConfig: jobconfig.JobConfig{
+			Command: "sleep",
+			Args:    []string{"30"},
+		},
+	}, "./test-bucket-root/job-bucket/path/to/job001/params.json")
+
+	// Should be killed long before it finishes sleeping
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	fmt.Printf("Job: %v\n", RunJob(ctx, "job-bucket", "path/to/job001", 4, fs))
+	fmt.Printf("Stopped early: %v\n", time.Since(start) < 10*time.Second)
+
+	// Output:
+	// INFO: Running job from s3://job-bucket/path/to/job001 for node 4
+	// DEBUG: Job config struct: jobconfig.JobConfig{JobId:"-4", RequiredFiles:[]jobconfig.JobFilePath{}, Command:"sleep", Args:[]string{"30"}, ArgIndexToApplyNodeIndexes:[]int(nil), OutputFiles:[]jobconfig.JobFilePath{}}
+	// INFO: Downloading files...
+	// INFO: Checking for required libraries...
+	// INFO: Running job...
+	// DEBUG: exec.Command starting "sleep", args: [30]
+	// Job: Job -4 stopped: context deadline exceeded
+	// Stopped early: true
+}
+
 func Example_jobrunner_RunJob_BadInputLocalPath() {
 	origWD, fs := initTest()
 	defer os.Chdir(origWD)
@@ -105,7 +138,7 @@ func Example_jobrunner_RunJob_BadInputLocalPath() {
 		},
 	}, "./test-bucket-root/job-bucket/path/to/job001/params.json")
 
-	fmt.Printf("Job: %v\n", RunJob("job-bucket", "path/to/job001", 4, fs))
+	fmt.Printf("Job: %v\n", RunJob(context.Background(), "job-bucket", "path/to/job001", 4, fs))
 
 	// Output:
 	// INFO: Running job from s3://job-bucket/path/to/job001 for node 4
@@ -129,7 +162,7 @@ func Example_jobrunner_RunJob_BadInputRemotePath() {
 		},
 	}, "./test-bucket-root/job-bucket/path/to/job001/params.json")
 
-	fmt.Printf("Job: %v\n", RunJob("job-bucket", "path/to/job001", 4, fs))
+	fmt.Printf("Job: %v\n", RunJob(context.Background(), "job-bucket", "path/to/job001", 4, fs))
 
 	// Output:
 	// INFO: Running job from s3://job-bucket/path/to/job001 for node 4
@@ -156,7 +189,7 @@ func Example_jobrunner_RunJob_BadCommand() {
 		},
 	}, "./test-bucket-root/job-bucket/path/to/job001/params.json")
 
-	fmt.Printf("Job: %v\n", RunJob("job-bucket", "path/to/job001", 4, fs))
+	fmt.Printf("Job: %v\n", RunJob(context.Background(), "job-bucket", "path/to/job001", 4, fs))
 
 	// Output:
 	// INFO: Running job from s3://job-bucket/path/to/job001 for node 4
@@ -185,7 +218,7 @@ func Example_jobrunner_RunJob_UploadNotThere() {
 		},
 	}, "./test-bucket-root/job-bucket/path/to/job001/params.json")
 
-	fmt.Printf("Job: %v\n", RunJob("job-bucket", "path/to/job001", 4, fs))
+	fmt.Printf("Job: %v\n", RunJob(context.Background(), "job-bucket", "path/to/job001", 4, fs))
 
 	// Output:
 	// INFO: Running job from s3://job-bucket/path/to/job001 for node 4
@@ -225,7 +258,7 @@ func Example_jobrunner_RunJob_DownloadUploadOK() {
 	fmt.Printf("Write S3 input2.csv: %v\n", fs.WriteObject("test-piquant", "Example_jobrunner_RunJob_DownloadUploadOK/input2.csv", []byte("hello2")))
 	fmt.Printf("Write local data.txt: %v\n", os.WriteFile("data.txt", []byte("hello"), dirperm))
 
-	fmt.Printf("Job: %v\n", RunJob("job-bucket", "path/to/job001", 4, fs))
+	fmt.Printf("Job: %v\n", RunJob(context.Background(), "job-bucket", "path/to/job001", 4, fs))
 
 	// Output:
 	// Write S3 input.csv: <nil>
@@ -280,7 +313,7 @@ func Example_jobrunner_RunJob_SeedAndDownloadOK() {
 	err := fileaccess.CopyToBucket(fs, filepath.Join(origWD, "test-files"), "test-piquant", "Example_jobrunner_RunJob_SeedAndDownloadOK", false, l)
 	fmt.Printf("CopyToBucket: %v\n", err)
 
-	fmt.Printf("Job: %v\n", RunJob("job-bucket", "path/to/job001", 4, fs))
+	fmt.Printf("Job: %v\n", RunJob(context.Background(), "job-bucket", "path/to/job001", 4, fs))
 
 	// Output:
 	// CopyToBucket: <nil>
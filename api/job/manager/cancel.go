@@ -0,0 +1,44 @@
+package jobmanager
+
+import (
+	"fmt"
+
+	"github.com/pixlise/core/v4/api/job"
+	"github.com/pixlise/core/v4/core/errorwithstatus"
+	protos "github.com/pixlise/core/v4/generated-protos"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Cancels a job group. Jobs that haven't started yet are removed from the queue, and nodes running the others will
+// notice they were cancelled and kill them. The job status is set to cancelled straight away, the next job queue check
+// clears out anything left in the queue for it
+func (jm *JobManager) CancelJob(jobGroupId string) error {
+	status, err := jm.readJobStatus(jobGroupId)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return errorwithstatus.MakeNotFoundError(jobGroupId)
+		}
+		return err
+	}
+
+	// Only jobs we queued can be cancelled, anything else (eg imports) isn't run by job nodes
+	if _, err := jm.readJobGroupConfig(jobGroupId); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return errorwithstatus.MakeBadRequestError(fmt.Errorf("Job %v cannot be cancelled", jobGroupId))
+		}
+		return err
+	}
+
+	if status.Status >= protos.JobStatus_GATHERING_RESULTS {
+		return errorwithstatus.MakeBadRequestError(fmt.Errorf("Job %v cannot be cancelled, it is already %v", jobGroupId, status.Status.String()))
+	}
+
+	removedCount, cancelledCount, err := job.CancelJobQueueItems(jobGroupId, jm.svcs.MongoDB, jm.svcs.TimeStamper)
+	if err != nil {
+		return err
+	}
+
+	jm.svcs.Log.Infof("CancelJob: %v removed %v queued jobs, cancelled %v running jobs", jobGroupId, removedCount, cancelledCount)
+
+	return jm.updateJobStatus(jobGroupId, protos.JobStatus_CANCELLED, fmt.Sprintf("Cancelled with %v nodes running", cancelledCount), true)
+}
@@ -2,6 +2,7 @@ package jobmanager
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"path"
 
@@ -14,12 +15,13 @@ import (
 	"github.com/pixlise/core/v4/api/sessionuser"
 	"github.com/pixlise/core/v4/api/ws/wsHelpers"
 	"github.com/pixlise/core/v4/core/scan"
+	"github.com/pixlise/core/v4/core/utils"
 	protos "github.com/pixlise/core/v4/generated-protos"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
-func completeQuantMultiNodeJob(jg *jobconfig.JobGroupConfig, jstatus *protos.JobStatus, session *melody.Session, svcs *services.APIServices) error {
+func completeQuantMultiNodeJob(jg *jobconfig.JobGroupConfig, payload *QuantJobPayload, jstatus *protos.JobStatus, session *melody.Session, svcs *services.APIServices) error {
 	if len(jg.AssociatedScanId) <= 0 {
 		return fmt.Errorf("Failed to complete multi-node quant job %v: No associated scan ID found!", jg.JobGroupId)
 	}
@@ -70,19 +72,24 @@ func completeQuantMultiNodeJob(jg *jobconfig.JobGroupConfig, jstatus *protos.Job
 		return fmt.Errorf("Failed to determine quantification output file index for %v", quantification.OutputCSVName)
 	}
 
-	if jg.QuantByROI {
+	if payload.QuantByROI {
 		jobCfg := jg.NodeConfig.FlattenJobConfig(0)
 		pmcFile := path.Base(jobCfg.OutputFiles[outputFileIdx].RemotePath)
-		outputCSV, err = quantification.ProcessQuantROIsToPMCs(svcs.FS, svcs.Config.PiquantJobsBucket, jobS3Path, jg.OutputTitle, pmcFile, jg.Combined, jg.ROIs)
+		outputCSV, err = quantification.ProcessQuantROIsToPMCs(svcs.FS, svcs.Config.PiquantJobsBucket, jobS3Path, payload.OutputTitle, pmcFile, payload.Combined, payload.ROIs)
 		errMsg = "Error when duplicating quant rows for ROI PMCs"
 	} else {
 		pmcFiles := []string{}
 		for c := uint(0); c < jg.NodeCount; c++ {
+			// Nodes that failed won't have any output
+			if utils.ItemInSlice(uint32(c), jg.FailedNodeIndexes) {
+				continue
+			}
+
 			jobCfg := jg.NodeConfig.FlattenJobConfig(c)
 			pmcFiles = append(pmcFiles, jobCfg.OutputFiles[outputFileIdx].RemotePath)
 		}
 
-		outputCSV, err = quantification.CombineQuantOutputsForResultFilePaths(svcs.FS, svcs.Config.PiquantJobsBucket, jg.OutputTitle, pmcFiles)
+		outputCSV, err = quantification.CombineQuantOutputsForResultFilePaths(svcs.FS, svcs.Config.PiquantJobsBucket, payload.OutputTitle, pmcFiles)
 		errMsg = "Error when combining quants"
 	}
 	if err != nil {
@@ -132,6 +139,14 @@ func completeQuantMultiNodeJob(jg *jobconfig.JobGroupConfig, jstatus *protos.Job
 	}
 
 	completeMsg := fmt.Sprintf("Nodes ran: %v", jg.NodeCount)
+	if len(jg.FailedNodeIndexes) > 0 {
+		missingPMCs, err := getFailedNodePMCs(jg, svcs)
+		if err != nil {
+			svcs.Log.Errorf("Quant job %v failed to determine missing PMCs: %v", jobId, err)
+		}
+
+		completeMsg = fmt.Sprintf("Nodes ran: %v, %v failed. Missing PMCs: %v", jg.NodeCount, len(jg.FailedNodeIndexes), missingPMCs)
+	}
 	now := svcs.TimeStamper.GetTimeNowSec()
 	summary := &protos.QuantificationSummary{
 		Id:     jobId,
@@ -159,7 +174,7 @@ func completeQuantMultiNodeJob(jg *jobconfig.JobGroupConfig, jstatus *protos.Job
 			OutputFilePath:   quantOutPath,
 			OtherLogFiles:    piquantLogList,
 			Name:             jg.JobName,
-			Elements:         jg.ElementList,
+			Elements:         payload.ElementList,
 			RequestorUserId:  jg.RequestorUserId,
 		},
 	}
@@ -205,7 +220,40 @@ func completeQuantMultiNodeJob(jg *jobconfig.JobGroupConfig, jstatus *protos.Job
 	return nil
 }
 
-func completeQuantSingleMapJob(jg *jobconfig.JobGroupConfig, jstatus *protos.JobStatus, session *melody.Session, svcs *services.APIServices) error {
+// Reads the PMC lists of each failed node to find which PMCs won't be in the quantification
+func getFailedNodePMCs(jg *jobconfig.JobGroupConfig, svcs *services.APIServices) ([]int32, error) {
+	pmcFileIdx := -1
+	for i, f := range jg.NodeConfig.RequiredFiles {
+		if path.Ext(f.RemotePath) == ".pmcs" {
+			pmcFileIdx = i
+			break
+		}
+	}
+
+	if pmcFileIdx < 0 {
+		return []int32{}, errors.New("Failed to find node PMC list file")
+	}
+
+	result := []int32{}
+	for _, nodeIdx := range jg.FailedNodeIndexes {
+		pmcFile := jg.NodeConfig.FlattenJobConfig(uint(nodeIdx)).RequiredFiles[pmcFileIdx]
+		contents, err := svcs.FS.ReadObject(pmcFile.RemoteBucket, pmcFile.RemotePath)
+		if err != nil {
+			return result, fmt.Errorf("Failed to read PMC list for node %v: %v", nodeIdx, err)
+		}
+
+		pmcs, err := quantification.ReadPMCListFilePMCs(string(contents))
+		if err != nil {
+			return result, fmt.Errorf("Failed to read PMC list for node %v: %v", nodeIdx, err)
+		}
+
+		result = append(result, pmcs...)
+	}
+
+	return result, nil
+}
+
+func completeQuantSingleMapJob(jg *jobconfig.JobGroupConfig, payload *QuantJobPayload, jstatus *protos.JobStatus, session *melody.Session, svcs *services.APIServices) error {
 	/*
 		// NOTE: Missing status writes - we only write those for map commands! saveQuantJobStatus quits if it's not a map anyway...
 
@@ -1,7 +1,6 @@
 package jobmanager
 
 import (
-	"context"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
@@ -11,10 +10,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/secretsmanager"
 	"github.com/aws/aws-secretsmanager-caching-go/secretcache"
-	"github.com/pixlise/core/v4/api/dbCollections"
-	"github.com/pixlise/core/v4/api/job/jobnode"
-	protos "github.com/pixlise/core/v4/generated-protos"
-	"go.mongodb.org/mongo-driver/bson"
+	"github.com/pixlise/core/v4/api/services"
 )
 
 // Speed comparison: Quantifying Tanda tula sol 1777 on prod with all 1377 PMCs, elements Al,Si,Ca,Fe ran on 15 nodes, 93 spectra/node:
@@ -45,9 +41,44 @@ import (
 // t3.xlarge 4/node 4 elements as 50 jobs/13 nodes: 250 sec
 // t3.2xlarge 8/node 4 elements as 50 jobs/7 nodes: 270 sec
 
+// Starts job nodes as EC2 instances. Each instance runs a startup script which downloads the job node
+// executable, and has AWS credentials (read from secrets manager) passed to it so it can access our buckets
+type ec2JobNodeLauncher struct {
+	svcs             *services.APIServices
+	startedNodeCount uint
+}
+
+func (l *ec2JobNodeLauncher) StartNodes(jobsForNodes [][]string) error {
+	// Read the credentials from secrets manager
+	awsKey, awsSecret, awsRegion, err := readSecretsManager(l.svcs.SecretsManager, l.svcs.Config.Jobs.AWSSecret)
+	if err != nil {
+		return fmt.Errorf("JobNode AWS secret read failed: %v", err)
+	}
+
+	// Start each node
+	allStartedIds := []*string{}
+	for _, jobs := range jobsForNodes {
+		l.svcs.Log.Debugf("  Starting EC2 job node for jobs: %v...", strings.Join(jobs, ","))
+		startedIds, err := l.startEC2JobNode(jobs, awsKey, awsSecret, awsRegion)
+		if err != nil {
+			return err
+		}
+
+		allStartedIds = append(allStartedIds, startedIds...)
+	}
+
+	input := &ec2.DescribeInstancesInput{InstanceIds: allStartedIds}
+	err = l.svcs.EC2.WaitUntilInstanceRunning(input)
+	if err != nil {
+		l.svcs.Log.Infof("  WARNING: Failed to wait for instances to start running: %v", err)
+	}
+
+	return nil
+}
+
 // Called to start a job node
-func (jm *JobManager) startEC2JobNode(jobIds []string, awsKey string, awsSecret string, awsRegion string) ([]*string, error) {
-	if len(jobIds) <= 0 || len(jobIds) > int(jm.svcs.Config.Jobs.CoresPerNode) {
+func (l *ec2JobNodeLauncher) startEC2JobNode(jobIds []string, awsKey string, awsSecret string, awsRegion string) ([]*string, error) {
+	if len(jobIds) <= 0 || len(jobIds) > int(l.svcs.Config.Jobs.CoresPerNode) {
 		return []*string{}, fmt.Errorf("Invalid job count when starting EC2 job nodes: %v", len(jobIds))
 	}
 
@@ -60,15 +91,15 @@ func (jm *JobManager) startEC2JobNode(jobIds []string, awsKey string, awsSecret
 
 	jobIdListStr := strings.Join(jobIds, ",")
 
-	if jm.svcs.Config.Jobs.MaxNodeRunTimeSec < 60 {
-		return []*string{}, fmt.Errorf("Cannot start job node that runs for only %vsec", jm.svcs.Config.Jobs.MaxNodeRunTimeSec)
+	if l.svcs.Config.Jobs.MaxNodeRunTimeSec < 60 {
+		return []*string{}, fmt.Errorf("Cannot start job node that runs for only %vsec", l.svcs.Config.Jobs.MaxNodeRunTimeSec)
 	}
 
-	if len(jm.svcs.Config.Jobs.AWSSecret) <= 0 {
+	if len(l.svcs.Config.Jobs.AWSSecret) <= 0 {
 		return []*string{}, fmt.Errorf("JobNode AWS secret not set")
 	}
 
-	jobNodeInstanceName := fmt.Sprintf("job-node-%v", jm.svcs.Config.EnvironmentName)
+	jobNodeInstanceName := fmt.Sprintf("job-node-%v", l.svcs.Config.EnvironmentName)
 
 	startupScript := fmt.Sprintf(`#!/bin/bash
 set -e
@@ -107,46 +138,46 @@ echo "Running job node..."
 echo "PIXLISE job node shutting down in 1 minute..."
 shutdown -h +1
 `,
-		jm.svcs.Config.Jobs.MaxNodeRunTimeSec/60,
-		jm.svcs.Config.Jobs.MaxNodeRunTimeSec,
+		l.svcs.Config.Jobs.MaxNodeRunTimeSec/60,
+		l.svcs.Config.Jobs.MaxNodeRunTimeSec,
 		awsKey, awsSecret,
 		awsRegion, awsRegion,
-		jm.svcs.Config.Jobs.NodeS3Path,
-		jm.svcs.Config.PiquantJobsBucket,
-		jm.svcs.Config.Jobs.RunnerDockerImage,
-		jm.svcs.Config.MongoSecret,
-		jm.svcs.Config.EnvironmentName,
-		jm.svcs.Config.Jobs.MaxNodeRunTimeSec-5,
+		l.svcs.Config.Jobs.NodeS3Path,
+		l.svcs.Config.PiquantJobsBucket,
+		l.svcs.Config.Jobs.RunnerDockerImage,
+		l.svcs.Config.MongoSecret,
+		l.svcs.Config.EnvironmentName,
+		l.svcs.Config.Jobs.MaxNodeRunTimeSec-5,
 		jobIdListStr,
 	)
 
 	input := &ec2.RunInstancesInput{
 		// placement (AZ - not setting it here?!)
-		ImageId:      aws.String(jm.svcs.Config.Jobs.AMI),
-		InstanceType: aws.String(jm.svcs.Config.Jobs.InstanceType),
+		ImageId:      aws.String(l.svcs.Config.Jobs.AMI),
+		InstanceType: aws.String(l.svcs.Config.Jobs.InstanceType),
 		TagSpecifications: []*ec2.TagSpecification{
 			{
 				ResourceType: aws.String("instance"),
 				Tags: []*ec2.Tag{
 					{Key: aws.String("Name"), Value: aws.String(jobNodeInstanceName)},
 					{Key: aws.String("pixlise:instance-use"), Value: aws.String("job-node")},
-					{Key: aws.String("pixlise:environment"), Value: aws.String(jm.svcs.Config.EnvironmentName)},
-					{Key: aws.String("pixlise:starter-instance-id"), Value: aws.String(jm.svcs.InstanceId)},
+					{Key: aws.String("pixlise:environment"), Value: aws.String(l.svcs.Config.EnvironmentName)},
+					{Key: aws.String("pixlise:starter-instance-id"), Value: aws.String(l.svcs.InstanceId)},
 					{Key: aws.String("pixlise:job-ids"), Value: aws.String(jobIdListStr)},
 				},
 			},
 		},
-		KeyName:          aws.String(jm.svcs.Config.Jobs.KeyName),
-		SecurityGroupIds: []*string{aws.String(jm.svcs.Config.Jobs.SecurityGroup)},
+		KeyName:          aws.String(l.svcs.Config.Jobs.KeyName),
+		SecurityGroupIds: []*string{aws.String(l.svcs.Config.Jobs.SecurityGroup)},
 		MaxCount:         aws.Int64(int64(1)),
 		MinCount:         aws.Int64(int64(1)),
 		UserData:         aws.String(base64.StdEncoding.EncodeToString([]byte(startupScript))),
 	}
-	if len(jm.svcs.Config.Jobs.SubnetId) > 0 {
-		input.SetSubnetId(jm.svcs.Config.Jobs.SubnetId)
+	if len(l.svcs.Config.Jobs.SubnetId) > 0 {
+		input.SetSubnetId(l.svcs.Config.Jobs.SubnetId)
 	}
 
-	res, err := jm.svcs.EC2.RunInstances(input)
+	res, err := l.svcs.EC2.RunInstances(input)
 	if err != nil {
 		return []*string{}, err
 	}
@@ -159,8 +190,8 @@ shutdown -h +1
 		instanceStrs = append(instanceStrs, *inst.InstanceId)
 	}
 
-	jm.svcs.Log.Infof("   Started %v instance(s) [%v]", len(instances), strings.Join(instanceStrs, ","))
-	jm.startedNodeCount = jm.startedNodeCount + 1
+	l.svcs.Log.Infof("   Started %v instance(s) [%v]", len(instances), strings.Join(instanceStrs, ","))
+	l.startedNodeCount = l.startedNodeCount + 1
 
 	return instances, err
 }
@@ -194,15 +225,7 @@ func readSecretsManager(secretsManager *secretsmanager.SecretsManager, secretNam
 	return info.Key, info.Secret, info.Region, nil
 }
 
-func (jm *JobManager) getRunningNodes() ([]string, error) {
-	// For testing/local mode, if we have already started that one thread, say there's just us as the node
-	if jm.isLocalTestMode() {
-		if jm.localJobNode == nil {
-			return []string{}, nil
-		}
-		return []string{jm.svcs.InstanceId}, nil
-	}
-
+func (l *ec2JobNodeLauncher) GetRunningNodes() ([]string, error) {
 	// Only grab instances that are running or just started
 	filters := []*ec2.Filter{
 		{
@@ -211,7 +234,7 @@ func (jm *JobManager) getRunningNodes() ([]string, error) {
 		},
 		{
 			Name:   aws.String("tag:pixlise:environment"),
-			Values: []*string{aws.String(jm.svcs.Config.EnvironmentName)},
+			Values: []*string{aws.String(l.svcs.Config.EnvironmentName)},
 		},
 		{
 			Name:   aws.String("tag:pixlise:instance-use"),
@@ -220,7 +243,7 @@ func (jm *JobManager) getRunningNodes() ([]string, error) {
 	}
 
 	request := &ec2.DescribeInstancesInput{Filters: filters}
-	result, err := jm.svcs.EC2.DescribeInstances(request)
+	result, err := l.svcs.EC2.DescribeInstances(request)
 
 	if err != nil {
 		return []string{}, err
@@ -269,103 +292,3 @@ func getJobsPerNode(jobIds []string, jobsPerNode uint) [][]string {
 
 	return result
 }
-
-// Starts enough nodes to handle the job ids passed
-
-// If there is a JobAWSSecret configured we start the node on a new EC2 instance,
-// otherwise (for testing really) we just start it in a new thread
-
-func (jm *JobManager) startJobNodes(jobIds []string) error {
-	if len(jobIds) <= 0 {
-		return fmt.Errorf("startJobNodes: No job ids specified")
-	}
-
-	if jm.isLocalTestMode() {
-		// No JobAWSSecret configured, so we just run in local mode. If we have not
-		// yet started a job node thread, start one now
-		jm.svcs.Log.Debugf("  startJobNodes running in local mode, ensuring one job node thread is running...")
-
-		if jm.localJobNode != nil {
-			jm.svcs.Log.Infof("  startJobNodes skipped, already running a local one")
-			return nil
-		}
-
-		// Start a local one
-		jm.svcs.Log.Infof("  startJobNodes starting local job node")
-		jm.localJobNode = jobnode.CreateJobNode(
-			"local-job",
-			jm.svcs.Config.Jobs.RunnerDockerImage,
-			jm.svcs.Config.PiquantJobsBucket,
-			jm.svcs.InstanceId,
-			jm.svcs.FS,
-			jm.svcs.MongoDB,
-			jm.svcs.Log,
-			jm.svcs.TimeStamper)
-
-		jm.localJobNode.StartJobs(jobIds)
-
-		return nil
-	}
-
-	jm.svcs.Log.Debugf("  Querying running node count...")
-
-	// Read the credentials from secrets manager
-	awsKey, awsSecret, awsRegion, err := readSecretsManager(jm.svcs.SecretsManager, jm.svcs.Config.Jobs.AWSSecret)
-	if err != nil {
-		return fmt.Errorf("JobNode AWS secret read failed: %v", err)
-	}
-
-	instanceIds, err := jm.getRunningNodes()
-	if err != nil {
-		return err
-	}
-
-	jm.svcs.Log.Debugf("  Instance IDs retrieved: %v", strings.Join(instanceIds, ","))
-
-	// If this seems like way too many jobs, stop here, so we don't infinitely start up EC2s
-	if len(instanceIds) > int(jm.svcs.Config.Jobs.MaxQuantNodes)*4 {
-		return fmt.Errorf("Too many job nodes active (%v), no more will be started", len(instanceIds))
-	}
-
-	// Change their state, we're assigning them...
-	nowUnixSec := jm.svcs.TimeStamper.GetTimeNowSec()
-	ctx := context.TODO()
-
-	filter := bson.M{"_id": bson.M{"$in": jobIds}}
-	dbResult, err := jm.svcs.MongoDB.Collection(dbCollections.JobQueueName).UpdateMany(ctx, filter, bson.D{{Key: "$set", Value: bson.M{
-		"state":                       protos.JobQueueItem_ASSIGNED,
-		"lastupdatedtimestampunixsec": nowUnixSec,
-	}}})
-
-	if err != nil {
-		return fmt.Errorf("Failed to set jobs to assigned state: %v", err)
-	}
-
-	if dbResult.ModifiedCount != int64(len(jobIds)) {
-		jm.svcs.Log.Infof("  WARNING: startJobNodes expected modified count of %v, got %v", len(jobIds), dbResult.ModifiedCount)
-	}
-
-	// Work out how many job nodes are needed.
-	jobsForNodes := getJobsPerNode(jobIds, jm.svcs.Config.Jobs.CoresPerNode)
-
-	// Start each node
-	allStartedIds := []*string{}
-	for _, jobs := range jobsForNodes {
-		jm.svcs.Log.Debugf("  Starting EC2 job node for jobs: %v...", strings.Join(jobs, ","))
-		startedIds, err := jm.startEC2JobNode(jobs, awsKey, awsSecret, awsRegion)
-		if err != nil {
-			return err
-		}
-
-		allStartedIds = append(allStartedIds, startedIds...)
-	}
-
-	input := &ec2.DescribeInstancesInput{InstanceIds: allStartedIds}
-	err = jm.svcs.EC2.WaitUntilInstanceRunning(input)
-	if err != nil {
-		jm.svcs.Log.Infof("  WARNING: Failed to wait for instances to start running: %v", err)
-	}
-
-	jm.svcs.Log.Debugf("  %v nodes started.", len(jobsForNodes))
-	return nil
-}
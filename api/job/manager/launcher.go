@@ -0,0 +1,155 @@
+package jobmanager
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pixlise/core/v4/api/dbCollections"
+	"github.com/pixlise/core/v4/api/services"
+	protos "github.com/pixlise/core/v4/generated-protos"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Job nodes pick up jobs from the job queue and run them. A launcher is responsible for starting job nodes
+// somewhere - an EC2 instance, a Kubernetes pod or in this process - and for reporting which are still running
+type JobNodeLauncher interface {
+	// Starts a job node for each list of job ids passed in
+	StartNodes(jobsForNodes [][]string) error
+
+	// Returns the ids of job nodes that are still running. A job node records its id against the jobs it runs,
+	// so this is used to detect jobs that will never finish because their node has gone
+	GetRunningNodes() ([]string, error)
+}
+
+const (
+	JobNodeLauncherEC2        = "ec2"
+	JobNodeLauncherKubernetes = "kubernetes"
+	JobNodeLauncherLocal      = "local"
+)
+
+func makeJobNodeLauncher(svcs *services.APIServices) (JobNodeLauncher, error) {
+	launcherName := svcs.Config.Jobs.NodeLauncher
+
+	// If not specified, we do what we always did: use EC2 if we have credentials to pass to it,
+	// otherwise run locally
+	if len(launcherName) <= 0 {
+		launcherName = JobNodeLauncherLocal
+		if len(svcs.Config.Jobs.AWSSecret) > 0 {
+			launcherName = JobNodeLauncherEC2
+		}
+	}
+
+	switch launcherName {
+	case JobNodeLauncherEC2:
+		return &ec2JobNodeLauncher{svcs: svcs}, nil
+	case JobNodeLauncherKubernetes:
+		if len(svcs.Config.Jobs.NodeDockerImage) <= 0 || len(svcs.Config.Jobs.NodeNamespace) <= 0 {
+			return nil, fmt.Errorf("Kubernetes job node launcher requires NodeDockerImage and NodeNamespace to be configured")
+		}
+		return &kubernetesJobNodeLauncher{svcs: svcs}, nil
+	case JobNodeLauncherLocal:
+		return makeLocalJobNodeLauncher(svcs), nil
+	}
+
+	return nil, fmt.Errorf("Unknown job node launcher: %v", launcherName)
+}
+
+func (jm *JobManager) getRunningNodes() ([]string, error) {
+	return jm.launcher.GetRunningNodes()
+}
+
+// Starts enough nodes to handle the job ids passed, using whichever launcher is configured
+func (jm *JobManager) startJobNodes(jobIds []string) error {
+	if len(jobIds) <= 0 {
+		return fmt.Errorf("startJobNodes: No job ids specified")
+	}
+
+	jm.svcs.Log.Debugf("  Querying running node count...")
+
+	instanceIds, err := jm.getRunningNodes()
+	if err != nil {
+		return err
+	}
+
+	jm.svcs.Log.Debugf("  Instance IDs retrieved: %v", strings.Join(instanceIds, ","))
+
+	// If this seems like way too many nodes, stop here, so we don't infinitely start them up
+	if len(instanceIds) > int(jm.svcs.Config.Jobs.MaxQuantNodes)*4 {
+		return fmt.Errorf("Too many job nodes active (%v), no more will be started", len(instanceIds))
+	}
+
+	// Only start as many nodes as we have room for. The job ids are passed in priority order, so any we leave waiting
+	// are the lowest priority ones, and they get started on a later queue check once nodes have finished
+	jobIds, jobsLeftWaiting := jm.limitJobsToFreeNodes(jobIds, len(instanceIds))
+	if jobsLeftWaiting {
+		time.AfterFunc(waitingJobQueueCheckSec*time.Second, func() {
+			jm.runCheckJobQueueOnce("jobmanager-waiting")
+		})
+	}
+
+	if len(jobIds) <= 0 {
+		return nil
+	}
+
+	// Change their state, we're assigning them...
+	nowUnixSec := jm.svcs.TimeStamper.GetTimeNowSec()
+	ctx := context.TODO()
+
+	filter := bson.M{"_id": bson.M{"$in": jobIds}}
+	dbResult, err := jm.svcs.MongoDB.Collection(dbCollections.JobQueueName).UpdateMany(ctx, filter, bson.D{{Key: "$set", Value: bson.M{
+		"state":                       protos.JobQueueItem_ASSIGNED,
+		"lastupdatedtimestampunixsec": nowUnixSec,
+	}}})
+
+	if err != nil {
+		return fmt.Errorf("Failed to set jobs to assigned state: %v", err)
+	}
+
+	if dbResult.ModifiedCount != int64(len(jobIds)) {
+		jm.svcs.Log.Infof("  WARNING: startJobNodes expected modified count of %v, got %v", len(jobIds), dbResult.ModifiedCount)
+	}
+
+	// Work out how many job nodes are needed.
+	jobsForNodes := getJobsPerNode(jobIds, jm.svcs.Config.Jobs.CoresPerNode)
+
+	err = jm.launcher.StartNodes(jobsForNodes)
+	if err != nil {
+		return err
+	}
+
+	jm.svcs.Log.Debugf("  %v nodes started.", len(jobsForNodes))
+	return nil
+}
+
+// We won't see a change in the job queue when a node shuts down, so if jobs are left waiting for a free node, this
+// is how long until we check the queue again
+const waitingJobQueueCheckSec = 30
+
+// Returns the jobs there are free nodes for, and whether any were left waiting, in which case the caller needs to check
+// the queue again later
+func (jm *JobManager) limitJobsToFreeNodes(jobIds []string, runningNodeCount int) ([]string, bool) {
+	maxNodes := int(jm.svcs.Config.Jobs.MaxRunningNodes)
+	if maxNodes <= 0 {
+		return jobIds, false
+	}
+
+	jobsPerNode := int(jm.svcs.Config.Jobs.CoresPerNode)
+	if jobsPerNode < 1 {
+		jobsPerNode = 1
+	}
+
+	freeJobs := (maxNodes - runningNodeCount) * jobsPerNode
+	if freeJobs < 0 {
+		freeJobs = 0
+	}
+
+	if len(jobIds) <= freeJobs {
+		return jobIds, false
+	}
+
+	jm.svcs.Log.Infof("  startJobNodes: %v nodes running, max is %v. Starting %v of %v waiting jobs", runningNodeCount, maxNodes, freeJobs, len(jobIds))
+
+	return jobIds[0:freeJobs], true
+}
@@ -0,0 +1,122 @@
+package jobmanager
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pixlise/core/v4/api/config"
+	"github.com/pixlise/core/v4/api/services"
+	"github.com/pixlise/core/v4/core/kubernetes"
+	"github.com/pixlise/core/v4/core/utils"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Starts job nodes as Kubernetes pods. The pod runs pixlise-job-node as its entrypoint, which runs the jobs
+// in-process, so the node image must contain everything a job runner needs. Unlike EC2 nodes, no AWS
+// credentials are passed in, pods are expected to get access via their service account
+type kubernetesJobNodeLauncher struct {
+	svcs       *services.APIServices
+	kubeHelper kubernetes.KubeHelper
+}
+
+const kubernetesJobNodeLabel = "pixlise.org/instance-use"
+const kubernetesJobNodeEnvLabel = "pixlise.org/environment"
+
+func (l *kubernetesJobNodeLauncher) bootstrap() {
+	// Make sure that the kubernetes client is set up
+	l.kubeHelper.Kubeconfig = l.svcs.Config.KubeConfig
+	l.kubeHelper.Bootstrap(l.svcs.Config.KubernetesLocation, l.svcs.Log)
+}
+
+func (l *kubernetesJobNodeLauncher) StartNodes(jobsForNodes [][]string) error {
+	l.bootstrap()
+
+	for _, jobs := range jobsForNodes {
+		podName := "job-node-" + strings.ToLower(l.svcs.Config.EnvironmentName) + "-" + utils.RandStringBytesMaskImpr(8)
+		pod, err := makeJobNodePodObject(podName, jobs, l.svcs.Config)
+		if err != nil {
+			return err
+		}
+
+		l.svcs.Log.Debugf("  Starting kubernetes job node %v for jobs: %v...", podName, strings.Join(jobs, ","))
+
+		_, err = l.kubeHelper.Clientset.CoreV1().Pods(pod.Namespace).Create(context.TODO(), pod, metav1.CreateOptions{})
+		if err != nil {
+			return fmt.Errorf("Failed to create job node pod %v in namespace %v: %v", podName, pod.Namespace, err)
+		}
+	}
+
+	return nil
+}
+
+func (l *kubernetesJobNodeLauncher) GetRunningNodes() ([]string, error) {
+	l.bootstrap()
+
+	pods, err := l.kubeHelper.Clientset.CoreV1().Pods(l.svcs.Config.Jobs.NodeNamespace).List(context.TODO(), metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%v=job-node,%v=%v", kubernetesJobNodeLabel, kubernetesJobNodeEnvLabel, l.svcs.Config.EnvironmentName),
+	})
+	if err != nil {
+		return []string{}, err
+	}
+
+	// Only report pods that are running or about to
+	podNames := []string{}
+	for _, pod := range pods.Items {
+		if pod.Status.Phase == apiv1.PodPending || pod.Status.Phase == apiv1.PodRunning {
+			podNames = append(podNames, pod.Name)
+		}
+	}
+
+	return podNames, nil
+}
+
+func makeJobNodePodObject(podName string, jobIds []string, cfg config.APIConfig) (*apiv1.Pod, error) {
+	// Ensure no jobs have , in their ids because we'll be putting them in a string list separated by ,
+	for _, id := range jobIds {
+		if strings.Contains(id, ",") {
+			return nil, fmt.Errorf("Invalid job id specified, illegal , character detected: %v", id)
+		}
+	}
+
+	if cfg.Jobs.MaxNodeRunTimeSec < 60 {
+		return nil, fmt.Errorf("Cannot start job node that runs for only %vsec", cfg.Jobs.MaxNodeRunTimeSec)
+	}
+
+	// Kubernetes kills the pod if it goes over time, the node itself gives up a little earlier
+	maxRunTimeSec := int64(cfg.Jobs.MaxNodeRunTimeSec)
+
+	return &apiv1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      podName,
+			Namespace: cfg.Jobs.NodeNamespace,
+			Labels: map[string]string{
+				"app.kubernetes.io/managed-by": "pixlise",
+				kubernetesJobNodeLabel:         "job-node",
+				kubernetesJobNodeEnvLabel:      cfg.EnvironmentName,
+			},
+		},
+		Spec: apiv1.PodSpec{
+			ImagePullSecrets:      []apiv1.LocalObjectReference{{Name: "api-auth"}},
+			RestartPolicy:         apiv1.RestartPolicyNever,
+			ServiceAccountName:    cfg.Jobs.NodeServiceAccount,
+			ActiveDeadlineSeconds: &maxRunTimeSec,
+			Containers: []apiv1.Container{
+				{
+					Name:            "job-node",
+					Image:           cfg.Jobs.NodeDockerImage,
+					ImagePullPolicy: apiv1.PullAlways,
+					Args: []string{
+						"-bucket", cfg.PiquantJobsBucket,
+						"-mongoSecret", cfg.MongoSecret,
+						"-envName", cfg.EnvironmentName,
+						"-maxRunTimeSec", fmt.Sprintf("%v", cfg.Jobs.MaxNodeRunTimeSec-5),
+						"-instanceId", podName,
+						"-jobs", strings.Join(jobIds, ","),
+					},
+				},
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,75 @@
+package jobmanager
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/pixlise/core/v4/api/job/jobnode"
+	"github.com/pixlise/core/v4/api/services"
+)
+
+// Runs job nodes in this process, each in its own go routine. Jobs are run in the configured runner docker
+// image, or if none is configured, directly in this process. This allows the whole job queue to work on a
+// dev machine or in CI without starting anything in AWS
+type localJobNodeLauncher struct {
+	svcs             *services.APIServices
+	startedNodeCount uint
+
+	runningNodesLock sync.Mutex
+	runningNodes     map[string]bool
+}
+
+func makeLocalJobNodeLauncher(svcs *services.APIServices) *localJobNodeLauncher {
+	return &localJobNodeLauncher{svcs: svcs, runningNodes: map[string]bool{}}
+}
+
+func (l *localJobNodeLauncher) StartNodes(jobsForNodes [][]string) error {
+	for _, jobs := range jobsForNodes {
+		l.startedNodeCount = l.startedNodeCount + 1
+
+		// Needs to be unique, as this is what's written as the instance id of each job this node runs
+		nodeId := fmt.Sprintf("%v-local-%v", l.svcs.InstanceId, l.startedNodeCount)
+
+		l.svcs.Log.Infof("  startJobNodes starting local job node %v", nodeId)
+		node := jobnode.CreateJobNode(
+			"local-job",
+			l.svcs.Config.Jobs.RunnerDockerImage,
+			l.svcs.Config.PiquantJobsBucket,
+			nodeId,
+			l.svcs.FS,
+			l.svcs.MongoDB,
+			l.svcs.Log,
+			l.svcs.TimeStamper)
+
+		l.runningNodesLock.Lock()
+		l.runningNodes[nodeId] = true
+		l.runningNodesLock.Unlock()
+
+		go func(jobIds []string) {
+			// Returns once all jobs are finished
+			node.StartJobs(jobIds)
+
+			l.runningNodesLock.Lock()
+			delete(l.runningNodes, nodeId)
+			l.runningNodesLock.Unlock()
+
+			l.svcs.Log.Infof("Local job node %v finished", nodeId)
+		}(jobs)
+	}
+
+	return nil
+}
+
+func (l *localJobNodeLauncher) GetRunningNodes() ([]string, error) {
+	l.runningNodesLock.Lock()
+	defer l.runningNodesLock.Unlock()
+
+	result := []string{}
+	for id := range l.runningNodes {
+		result = append(result, id)
+	}
+
+	sort.Strings(result)
+	return result, nil
+}
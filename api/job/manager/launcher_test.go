@@ -0,0 +1,99 @@
+package jobmanager
+
+import (
+	"fmt"
+
+	"github.com/pixlise/core/v4/api/config"
+	"github.com/pixlise/core/v4/api/services"
+	"github.com/pixlise/core/v4/core/logger"
+)
+
+func Example_jobmanager_makeJobNodeLauncher() {
+	for _, cfg := range []config.JobConfig{
+		{},
+		{AWSSecret: "job/secret"},
+		{NodeLauncher: "local", AWSSecret: "job/secret"},
+		{NodeLauncher: "ec2"},
+		{NodeLauncher: "kubernetes"},
+		{NodeLauncher: "kubernetes", NodeDockerImage: "job-node:latest", NodeNamespace: "jobs"},
+		{NodeLauncher: "lambda"},
+	} {
+		svcs := &services.APIServices{Config: config.APIConfig{Jobs: cfg}}
+		l, err := makeJobNodeLauncher(svcs)
+		fmt.Printf("%v: %T|%v\n", cfg.NodeLauncher, l, err)
+	}
+
+	// Output:
+	// : *jobmanager.localJobNodeLauncher|<nil>
+	// : *jobmanager.ec2JobNodeLauncher|<nil>
+	// local: *jobmanager.localJobNodeLauncher|<nil>
+	// ec2: *jobmanager.ec2JobNodeLauncher|<nil>
+	// kubernetes: <nil>|Kubernetes job node launcher requires NodeDockerImage and NodeNamespace to be configured
+	// kubernetes: *jobmanager.kubernetesJobNodeLauncher|<nil>
+	// lambda: <nil>|Unknown job node launcher: lambda
+}
+
+func Example_jobmanager_makeJobNodePodObject() {
+	cfg := config.APIConfig{
+		EnvironmentName:   "dev",
+		PiquantJobsBucket: "job-bucket",
+		MongoSecret:       "mongo/secret",
+		Jobs: config.JobConfig{
+			NodeDockerImage:    "job-node:latest",
+			NodeNamespace:      "jobs",
+			NodeServiceAccount: "job-node-svc",
+			MaxNodeRunTimeSec:  600,
+		},
+	}
+
+	pod, err := makeJobNodePodObject("job-node-dev-abc", []string{"job1", "job2"}, cfg)
+	fmt.Printf("%v\n", err)
+	fmt.Printf("%v/%v, labels: %v\n", pod.Namespace, pod.Name, pod.Labels)
+	fmt.Printf("svc: %v, deadline: %v, image: %v\n", pod.Spec.ServiceAccountName, *pod.Spec.ActiveDeadlineSeconds, pod.Spec.Containers[0].Image)
+	fmt.Printf("args: %v\n", pod.Spec.Containers[0].Args)
+
+	_, err = makeJobNodePodObject("job-node-dev-abc", []string{"job1", "job,2"}, cfg)
+	fmt.Printf("%v\n", err)
+
+	cfg.Jobs.MaxNodeRunTimeSec = 30
+	_, err = makeJobNodePodObject("job-node-dev-abc", []string{"job1"}, cfg)
+	fmt.Printf("%v\n", err)
+
+	// Output:
+	// <nil>
+	// jobs/job-node-dev-abc, labels: map[app.kubernetes.io/managed-by:pixlise pixlise.org/environment:dev pixlise.org/instance-use:job-node]
+	// svc: job-node-svc, deadline: 600, image: job-node:latest
+	// args: [-bucket job-bucket -mongoSecret mongo/secret -envName dev -maxRunTimeSec 595 -instanceId job-node-dev-abc -jobs job1,job2]
+	// Invalid job id specified, illegal , character detected: job,2
+	// Cannot start job node that runs for only 30sec
+}
+
+func Example_jobmanager_limitJobsToFreeNodes() {
+	jobIds := []string{"j1", "j2", "j3", "j4", "j5"}
+
+	jm := &JobManager{svcs: &services.APIServices{Log: &logger.NullLogger{}}}
+	for _, c := range []struct {
+		maxNodes     uint
+		coresPerNode uint
+		running      int
+	}{
+		{0, 2, 10},
+		{10, 2, 0},
+		{10, 2, 8},
+		{10, 0, 8},
+		{10, 2, 10},
+		{10, 2, 12},
+	} {
+		jm.svcs.Config.Jobs.MaxRunningNodes = c.maxNodes
+		jm.svcs.Config.Jobs.CoresPerNode = c.coresPerNode
+		fmt.Println(jm.limitJobsToFreeNodes(jobIds, c.running))
+	}
+
+	// Output:
+	// [j1 j2 j3 j4 j5] false
+	// [j1 j2 j3 j4 j5] false
+	// [j1 j2 j3 j4] true
+	// [j1 j2] true
+	// [] true
+	// [] true
+}
@@ -3,7 +3,6 @@ package jobmanager
 import (
 	"github.com/olahol/melody"
 	jobconfig "github.com/pixlise/core/v4/api/job/config"
-	"github.com/pixlise/core/v4/api/job/jobnode"
 	"github.com/pixlise/core/v4/api/services"
 	protos "github.com/pixlise/core/v4/generated-protos"
 )
@@ -17,25 +16,31 @@ type JobManager struct {
 	svcs                 *services.APIServices
 	jobCompletionMethods map[string]JobManagerCompletionFunction
 	useFileCache         bool
-	localJobNode         *jobnode.JobNode
+	launcher             JobNodeLauncher
 	startNodes           bool
-	startedNodeCount     uint
 	userSessionLookup    map[string]*melody.Session
 }
 
 func CreateJobManager(svcs *services.APIServices, startupQueueCheckDelaySec int, monitorJobQueue bool, useFileCache bool, startNodes bool) (*JobManager, error) {
+	launcher, err := makeJobNodeLauncher(svcs)
+	if err != nil {
+		return nil, err
+	}
+
 	// Make a job manager
 	jm := &JobManager{
-		svcs: svcs,
-		jobCompletionMethods: map[string]JobManagerCompletionFunction{
-			JobComplete_CombineCSVs: completeQuantMultiNodeJob,
-			JobComplete_SingleCSV:   completeQuantSingleMapJob,
-		},
-		useFileCache:      useFileCache,
-		startNodes:        startNodes,
-		userSessionLookup: map[string]*melody.Session{},
+		svcs:                 svcs,
+		jobCompletionMethods: map[string]JobManagerCompletionFunction{},
+		useFileCache:         useFileCache,
+		launcher:             launcher,
+		startNodes:           startNodes,
+		userSessionLookup:    map[string]*melody.Session{},
 	}
 
+	RegisterJobKind(jm, JobComplete_CombineCSVs, completeQuantMultiNodeJob)
+	RegisterJobKind(jm, JobComplete_SingleCSV, completeQuantSingleMapJob)
+	RegisterJobKind(jm, JobComplete_ExpressionBatch, completeExpressionBatchJob)
+
 	if startupQueueCheckDelaySec > 0 {
 		// Check for unfinished jobs in a little bit, and start monitoring the job queue for new insertions
 		go jm.startupCheckQueue(startupQueueCheckDelaySec)
@@ -54,6 +59,23 @@ func (jm *JobManager) RegisterCompletionMethod(name string, f JobManagerCompleti
 	jm.jobCompletionMethods[name] = f
 }
 
+// A job kind's completion function, given the parameters that were stored with the job group when it was submitted
+type JobKindCompletionFunction[T any] func(*jobconfig.JobGroupConfig, *T, *protos.JobStatus, *melody.Session, *services.APIServices) error
+
+// Registers a kind of job whose parameters of type T are stored with the job group (see JobGroupConfig.SetPayload). The
+// completion function is registered as the completion method for this job kind, and gets the parameters read back. This
+// way new kinds of jobs can be added without the job group config needing to know about them
+func RegisterJobKind[T any](jm *JobManager, kind string, complete JobKindCompletionFunction[T]) {
+	jm.RegisterCompletionMethod(kind, func(jg *jobconfig.JobGroupConfig, jstatus *protos.JobStatus, session *melody.Session, svcs *services.APIServices) error {
+		payload := new(T)
+		if err := jg.GetPayload(payload); err != nil {
+			return err
+		}
+
+		return complete(jg, payload, jstatus, session, svcs)
+	})
+}
+
 // If we don't have an AWS secret set, we can only run stuff locally because we don't have credentials
 // to pass to a job node we're creating. This is useful for running tests! So wherever we need to do
 //
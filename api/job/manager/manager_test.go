@@ -1,6 +1,75 @@
 package jobmanager
 
+import (
+	"fmt"
+
+	"github.com/olahol/melody"
+	jobconfig "github.com/pixlise/core/v4/api/job/config"
+	"github.com/pixlise/core/v4/api/services"
+	protos "github.com/pixlise/core/v4/generated-protos"
+)
+
 // Public for tests ONLY!
 func (jm *JobManager) RunCheckJobQueueForTest() {
 	jm.checkJobQueue()
 }
+
+type testPolygonJobPayload struct {
+	ScanId  string
+	Indexes []uint32
+}
+
+func Example_jobmanager_RegisterJobKind() {
+	jm := &JobManager{jobCompletionMethods: map[string]JobManagerCompletionFunction{}}
+	RegisterJobKind(jm, "polygon", func(jg *jobconfig.JobGroupConfig, payload *testPolygonJobPayload, jstatus *protos.JobStatus, session *melody.Session, svcs *services.APIServices) error {
+		fmt.Printf("Completing %v: %+v\n", jg.JobGroupId, *payload)
+		return nil
+	})
+
+	jg := &jobconfig.JobGroupConfig{JobGroupId: "job123", CompletionMethod: "polygon"}
+	fmt.Println(jm.jobCompletionMethods["polygon"](jg, nil, nil, nil))
+
+	fmt.Println(jg.SetPayload(&testPolygonJobPayload{ScanId: "scan1", Indexes: []uint32{3, 5}}))
+	fmt.Println(jg.Payload)
+	fmt.Println(jm.jobCompletionMethods["polygon"](jg, nil, nil, nil))
+
+	jg.Payload = "{\"ScanId\": 3"
+	fmt.Println(jm.jobCompletionMethods["polygon"](jg, nil, nil, nil))
+
+	// Output:
+	// Job job123 has no payload
+	// <nil>
+	// {"ScanId":"scan1","Indexes":[3,5]}
+	// Completing job123: {ScanId:scan1 Indexes:[3 5]}
+	// <nil>
+	// Failed to read job job123 payload: unexpected end of JSON input
+}
+
+func Example_jobmanager_RegisterJobKind_LegacyQuantJob() {
+	jm := &JobManager{jobCompletionMethods: map[string]JobManagerCompletionFunction{}}
+	RegisterJobKind(jm, JobComplete_CombineCSVs, func(jg *jobconfig.JobGroupConfig, payload *QuantJobPayload, jstatus *protos.JobStatus, session *melody.Session, svcs *services.APIServices) error {
+		fmt.Printf("Completing %v: %v, %v, combined=%v, byROI=%v, ROIs=%v\n", jg.JobGroupId, payload.ElementList, payload.OutputTitle, payload.Combined, payload.QuantByROI, len(payload.ROIs))
+		return nil
+	})
+
+	// Stored before payloads existed, so only has the legacy fields
+	jg := &jobconfig.JobGroupConfig{
+		JobGroupId:       "quant123",
+		CompletionMethod: JobComplete_CombineCSVs,
+		ElementList:      []string{"Fe", "Ca"},
+		OutputTitle:      "Quant title",
+		Combined:         true,
+	}
+	fmt.Println(jm.jobCompletionMethods[JobComplete_CombineCSVs](jg, nil, nil, nil))
+
+	// Payload takes precedence if both exist
+	fmt.Println(jg.SetPayload(&QuantJobPayload{ElementList: []string{"Ti"}, OutputTitle: "New title"}))
+	fmt.Println(jm.jobCompletionMethods[JobComplete_CombineCSVs](jg, nil, nil, nil))
+
+	// Output:
+	// Completing quant123: [Fe Ca], Quant title, combined=true, byROI=false, ROIs=0
+	// <nil>
+	// <nil>
+	// Completing quant123: [Ti], New title, combined=false, byROI=false, ROIs=0
+	// <nil>
+}
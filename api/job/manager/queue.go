@@ -35,6 +35,7 @@ func (jm *JobManager) QueueJob(jg *jobconfig.JobGroupConfig) error {
 			CreatedTimeStampUnixSec:     nowUnixSec,
 			LastUpdatedTimeStampUnixSec: nowUnixSec,
 			State:                       protos.JobQueueItem_UNKNOWN,
+			Priority:                    jg.Priority,
 		})
 	}
 
@@ -157,43 +158,78 @@ func (jm *JobManager) checkJobQueue() error {
 	}
 
 	// Main queue processing:
+	// - Remove any that have been cancelled
+	// - Re-queue failed jobs if the job group's retry policy allows
 	// - Remove any that have all completed
 	// - Mark job group as running if a child node is running
 	// - Get a list of ALL jobs in queue that are not yet running
-	notStartedIds := []string{}
+	notStartedJobs := []*protos.JobQueueItem{}
 	for jobGroupId, jobs := range groupsAndJobs {
-		assignedCount, runningCount, completedCount, failedCount, idsNotStarted := jm.countJobNodeStates(jobs)
-		ranCount := failedCount + completedCount
+		existingJobStatus := existingJobStates[jobGroupId]
 
-		notStartedIds = append(notStartedIds, idsNotStarted...)
+		// If the job group was cancelled, we just clear it out. Any nodes still running its jobs will notice they're gone and stop
+		if existingJobStatus != nil && existingJobStatus.Status == protos.JobStatus_CANCELLED {
+			jm.clearJob(jobGroupId, groupsAndJobs)
+			continue
+		}
 
-		existingJobStatus := existingJobStates[jobGroupId]
+		assignedCount, runningCount, completedCount, failedCount, notStarted := jm.countJobNodeStates(jobs, nowUnixSec)
+
+		// If anything failed, we need the job group config to know what the retry policy is
+		var retryPolicy jobconfig.JobRetryPolicy
+		if failedCount > 0 {
+			jg, err := jm.readJobGroupConfig(jobGroupId)
+			if err != nil {
+				jm.svcs.Log.Errorf("CheckJobQueue failed to read job group %v config: %v", jobGroupId, err)
+			} else {
+				retryPolicy = jg.RetryPolicy
+
+				if err = jm.retryFailedJobs(retryPolicy, jobs, nowUnixSec); err != nil {
+					jm.svcs.Log.Errorf("CheckJobQueue failed to retry jobs for job group %v: %v", jobGroupId, err)
+				}
+
+				// Count again, as some may have been re-queued
+				assignedCount, runningCount, completedCount, failedCount, notStarted = jm.countJobNodeStates(jobs, nowUnixSec)
+			}
+		}
+
+		ranCount := failedCount + completedCount
+
+		notStartedJobs = append(notStartedJobs, notStarted...)
 
 		jm.svcs.Log.Debugf("  CheckJobQueue job group %v has %v ran, %v completed nodes of %v", jobGroupId, ranCount, completedCount, len(jobs))
 
 		// If the job group has any jobs in the queue marked running, mark the job group as running too
 		if runningCount > 0 {
-			runningStatus := fmt.Sprintf("Running on %v nodes with %v waiting, %v assigned, %v complete, %v failed", runningCount, len(idsNotStarted), assignedCount, completedCount, failedCount)
+			runningStatus := fmt.Sprintf("Running on %v nodes with %v waiting, %v assigned, %v complete, %v failed", runningCount, len(notStarted), assignedCount, completedCount, failedCount)
+			if itemsComplete, itemsTotal := getJobItemProgress(jobs); itemsTotal > 0 {
+				runningStatus += fmt.Sprintf(", %v of %v items done", itemsComplete, itemsTotal)
+			}
 			if existingJobStatus != nil && existingJobStatus.Message != runningStatus {
 				jm.updateJobStatusWithInMemory(jobGroupId, protos.JobStatus_RUNNING, runningStatus, existingJobStatus, true, "CheckJobQueue RunningCheck")
 			}
 		} else {
 			// None are in a running state...
 
+			allRan := completedCount+failedCount >= len(jobs)
+
+			// If some failed (after all retries) we may be allowed to complete with the nodes that succeeded
+			completeWithFailures := allRan && failedCount > 0 && completedCount > 0 && retryPolicy.CompleteWithSucceededNodes
+
 			// If they've all been completed, do the completion task (if there is one)
-			if completedCount >= len(jobs) && failedCount == 0 {
+			if (completedCount >= len(jobs) && failedCount == 0) || completeWithFailures {
 				// We only try to complete a job if we have a status for it!
 				if existingJobStatus != nil {
-					jm.completeJob(jobGroupId, len(jobs), existingJobStatus)
+					jm.completeJob(jobGroupId, len(jobs), existingJobStatus, getFailedNodeIndexes(jobs))
 				}
 			} // NOT else!! The following code needs to be able to run in complete AND failed scenarios!
 
 			// If they've all been run, delete it from the queue
-			if completedCount+failedCount >= len(jobs) {
+			if allRan {
 				jm.clearJob(jobGroupId, groupsAndJobs)
 
 				// If they're not all completed, we just mark the job as failed
-				if failedCount > 0 {
+				if failedCount > 0 && !completeWithFailures {
 					if err = jm.updateJobStatusWithInMemory(jobGroupId, protos.JobStatus_ERROR, fmt.Sprintf("%v nodes failed", failedCount), existingJobStatus, true, "CheckJobQueue FailCheck"); err == nil {
 						jm.svcs.Log.Infof("  Marking job %v as ERROR due to nodes not all completing", jobGroupId)
 					}
@@ -202,7 +238,14 @@ func (jm *JobManager) checkJobQueue() error {
 		}
 	}
 
-	// Start nodes as needed, assigning jobs to each one
+	// Start nodes as needed, assigning jobs to each one. If we can't start them all, the highest priority ones go first
+	job.SortJobQueueItemsByPriority(notStartedJobs)
+
+	notStartedIds := []string{}
+	for _, jobItem := range notStartedJobs {
+		notStartedIds = append(notStartedIds, jobItem.JobId)
+	}
+
 	jm.svcs.Log.Debugf("  CheckJobQueue found %v not-started jobs", len(notStartedIds))
 	if len(notStartedIds) > 0 && jm.startNodes {
 		return jm.startJobNodes(notStartedIds)
@@ -216,13 +259,13 @@ func (jm *JobManager) checkJobQueue() error {
 // RUNNING state jobs
 // COMPLETE state jobs
 // FAILED state jobs
-// NotStartedIds: JobIds of jobs that are in the UNKNOWN state
-func (jm *JobManager) countJobNodeStates(jobs []*protos.JobQueueItem) (int, int, int, int, []string) {
+// NotStarted: Jobs that are in the UNKNOWN state, excluding re-queued ones still waiting to be retried
+func (jm *JobManager) countJobNodeStates(jobs []*protos.JobQueueItem, nowUnixSec int64) (int, int, int, int, []*protos.JobQueueItem) {
 	assigned := 0
 	running := 0
 	completed := 0
 	failed := 0
-	notStartedIds := []string{}
+	notStarted := []*protos.JobQueueItem{}
 
 	for _, job := range jobs {
 		if job.State == protos.JobQueueItem_ASSIGNED {
@@ -241,12 +284,25 @@ func (jm *JobManager) countJobNodeStates(jobs []*protos.JobQueueItem) (int, int,
 			failed = failed + 1
 		}
 
-		if job.State == protos.JobQueueItem_UNKNOWN {
-			notStartedIds = append(notStartedIds, job.JobId)
+		if job.State == protos.JobQueueItem_UNKNOWN && job.RetryAfterUnixSec <= nowUnixSec {
+			notStarted = append(notStarted, job)
 		}
 	}
 
-	return assigned, running, completed, failed, notStartedIds
+	return assigned, running, completed, failed, notStarted
+}
+
+// For jobs that work through a list of items, adds up how far through their lists the nodes are
+func getJobItemProgress(jobs []*protos.JobQueueItem) (uint32, uint32) {
+	complete := uint32(0)
+	total := uint32(0)
+
+	for _, job := range jobs {
+		complete += job.ItemsComplete
+		total += job.ItemsTotal
+	}
+
+	return complete, total
 }
 
 func (jm *JobManager) checkJobTimeout(jobItem *protos.JobQueueItem, runningInstanceIds []string, nowUnixSec int64) error {
@@ -293,7 +349,7 @@ func (jm *JobManager) checkJobTimeout(jobItem *protos.JobQueueItem, runningInsta
 	return nil
 }
 
-func (jm *JobManager) completeJob(jobGroupId string, nodeCount int, existingStatus *protos.JobStatus) {
+func (jm *JobManager) completeJob(jobGroupId string, nodeCount int, existingStatus *protos.JobStatus, failedNodeIndexes []uint32) {
 	if existingStatus.Status >= protos.JobStatus_GATHERING_RESULTS {
 		jm.svcs.Log.Errorf("Skipped job completion for for: %v - its status is %v", jobGroupId, existingStatus.Status)
 		return
@@ -303,13 +359,18 @@ func (jm *JobManager) completeJob(jobGroupId string, nodeCount int, existingStat
 	// Set the job status to gathering results
 	jm.updateJobStatusWithInMemory(jobGroupId, protos.JobStatus_GATHERING_RESULTS, fmt.Sprintf("Combining CSVs from %v nodes...", nodeCount), existingStatus, true, "CheckJobQueue completeJob")
 
-	err := jm.onJobGroupCompletion(jobGroupId, existingStatus)
+	err := jm.onJobGroupCompletion(jobGroupId, existingStatus, failedNodeIndexes)
 	if err != nil {
 		// Set the job status to gathering results
 		jm.updateJobStatusWithInMemory(jobGroupId, protos.JobStatus_ERROR, fmt.Sprintf("Failed to complete job group %v: %v", jobGroupId, err), existingStatus, true, "CheckJobQueue completeJob-failed")
 	} else {
+		completeMsg := fmt.Sprintf("Nodes ran: %v", nodeCount)
+		if len(failedNodeIndexes) > 0 {
+			completeMsg = fmt.Sprintf("Nodes ran: %v, %v failed", nodeCount, len(failedNodeIndexes))
+		}
+
 		// Set the job status to gathering results
-		if err = jm.updateJobStatusWithInMemory(jobGroupId, protos.JobStatus_COMPLETE, fmt.Sprintf("Nodes ran: %v", nodeCount), existingStatus, true, "CheckJobQueue completeJob-success"); err == nil {
+		if err = jm.updateJobStatusWithInMemory(jobGroupId, protos.JobStatus_COMPLETE, completeMsg, existingStatus, true, "CheckJobQueue completeJob-success"); err == nil {
 			jm.svcs.Log.Debugf("  CheckJobQueue completed job group %v", jobGroupId)
 		}
 	}
@@ -345,22 +406,33 @@ func (jm *JobManager) clearJob(jobGroupId string, groupsAndJobs map[string][]*pr
 	}
 }
 
-func (jm *JobManager) onJobGroupCompletion(jobGroupId string, jobStatus *protos.JobStatus) error {
-	// Check if we have to do anything
+func (jm *JobManager) readJobGroupConfig(jobGroupId string) (*jobconfig.JobGroupConfig, error) {
 	ctx := context.TODO()
 	coll := jm.svcs.MongoDB.Collection(dbCollections.JobsName)
 
 	filter := bson.M{"_id": jobGroupId}
 	jobGroup := coll.FindOne(ctx, filter, options.FindOne())
 	if jobGroup.Err() != nil {
-		return jobGroup.Err()
+		return nil, jobGroup.Err()
 	}
 
 	jg := &jobconfig.JobGroupConfig{}
 	if err := jobGroup.Decode(jg); err != nil {
+		return nil, err
+	}
+
+	return jg, nil
+}
+
+func (jm *JobManager) onJobGroupCompletion(jobGroupId string, jobStatus *protos.JobStatus, failedNodeIndexes []uint32) error {
+	// Check if we have to do anything
+	jg, err := jm.readJobGroupConfig(jobGroupId)
+	if err != nil {
 		return err
 	}
 
+	jg.FailedNodeIndexes = failedNodeIndexes
+
 	// Check if we have this completion method registered at all
 	if len(jg.CompletionMethod) <= 0 {
 		jm.svcs.Log.Infof("Job Group %v has no completion method defined", jobGroupId)
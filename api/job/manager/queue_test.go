@@ -9,6 +9,7 @@ import (
 
 	"github.com/olahol/melody"
 	"github.com/pixlise/core/v4/api/dbCollections"
+	"github.com/pixlise/core/v4/api/job"
 	jobconfig "github.com/pixlise/core/v4/api/job/config"
 	"github.com/pixlise/core/v4/api/services"
 	"github.com/pixlise/core/v4/core/logger"
@@ -52,7 +53,6 @@ func Example_jobmanager_QueueStartup() {
 			NodeConfig:       jobconfig.JobConfig{},
 			AssociatedScanId: "",
 			JobName:          "job1",
-			ElementList:      []string{},
 			RequestorUserId:  "abc123",
 		},
 		&jobconfig.JobGroupConfig{
@@ -65,7 +65,6 @@ func Example_jobmanager_QueueStartup() {
 			NodeConfig:       jobconfig.JobConfig{},
 			AssociatedScanId: "1234567890",
 			JobName:          "job2",
-			ElementList:      []string{},
 			RequestorUserId:  "abc123",
 		},
 		&jobconfig.JobGroupConfig{
@@ -78,7 +77,6 @@ func Example_jobmanager_QueueStartup() {
 			NodeConfig:       jobconfig.JobConfig{},
 			AssociatedScanId: "",
 			JobName:          "job3",
-			ElementList:      []string{},
 			RequestorUserId:  "abc123",
 		},
 		&jobconfig.JobGroupConfig{
@@ -91,7 +89,6 @@ func Example_jobmanager_QueueStartup() {
 			NodeConfig:       jobconfig.JobConfig{},
 			AssociatedScanId: "",
 			JobName:          "job3",
-			ElementList:      []string{},
 			RequestorUserId:  "abc123",
 		},
 		&jobconfig.JobGroupConfig{
@@ -104,7 +101,6 @@ func Example_jobmanager_QueueStartup() {
 			NodeConfig:       jobconfig.JobConfig{},
 			AssociatedScanId: "",
 			JobName:          "job4",
-			ElementList:      []string{},
 			RequestorUserId:  "abc123",
 		},
 		&jobconfig.JobGroupConfig{
@@ -117,7 +113,6 @@ func Example_jobmanager_QueueStartup() {
 			NodeConfig:       jobconfig.JobConfig{},
 			AssociatedScanId: "",
 			JobName:          "job5",
-			ElementList:      []string{},
 			RequestorUserId:  "abc123",
 		},
 	)
@@ -356,3 +351,25 @@ func completeJobFunc(jg *jobconfig.JobGroupConfig, jstatus *protos.JobStatus, se
 	fmt.Printf("completeJob func called for: %v, state: %v, session exists: %v\n", jg.JobGroupId, jstatus.Status, sess != nil)
 	return nil
 }
+
+func Example_jobmanager_sortJobsByPriority() {
+	jobs := []*protos.JobQueueItem{
+		{JobId: "auto-quant-0", Priority: job.JobPriorityLow, CreatedTimeStampUnixSec: 100},
+		{JobId: "quant-1", Priority: job.JobPriorityNormal, CreatedTimeStampUnixSec: 200, NodeIndex: 1},
+		{JobId: "quant-0", Priority: job.JobPriorityNormal, CreatedTimeStampUnixSec: 200, NodeIndex: 0},
+		{JobId: "older-quant-0", Priority: job.JobPriorityNormal, CreatedTimeStampUnixSec: 150},
+		{JobId: "fit-0", Priority: job.JobPriorityHigh, CreatedTimeStampUnixSec: 300},
+	}
+
+	job.SortJobQueueItemsByPriority(jobs)
+	for _, j := range jobs {
+		fmt.Println(j.JobId)
+	}
+
+	// Output:
+	// fit-0
+	// older-quant-0
+	// quant-0
+	// quant-1
+	// auto-quant-0
+}
@@ -0,0 +1,95 @@
+package jobmanager
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pixlise/core/v4/api/config"
+	"github.com/pixlise/core/v4/api/job"
+	jobconfig "github.com/pixlise/core/v4/api/job/config"
+	protos "github.com/pixlise/core/v4/generated-protos"
+)
+
+// We don't want exponential backoff to leave a job waiting for hours
+const maxRetryBackoffSec = 30 * 60
+
+// Works out how long to wait before re-queuing a job that has already been retried retryCount times
+func getRetryBackoffSec(policy jobconfig.JobRetryPolicy, retryCount uint32) int64 {
+	backoff := int64(policy.RetryBackoffSec)
+	for c := uint32(0); c < retryCount && backoff < maxRetryBackoffSec; c++ {
+		backoff *= 2
+	}
+
+	if backoff > maxRetryBackoffSec {
+		backoff = maxRetryBackoffSec
+	}
+
+	return backoff
+}
+
+// The retry policy for a quant job running the given PIQUANT command. Fits are run interactively with the user waiting
+// for the result, and on one node, so they aren't retried
+func getQuantRetryPolicy(cfg config.JobConfig, command string) jobconfig.JobRetryPolicy {
+	if command != "map" {
+		return jobconfig.JobRetryPolicy{}
+	}
+
+	return jobconfig.JobRetryPolicy{
+		MaxNodeRetries:             cfg.QuantMaxNodeRetries,
+		RetryBackoffSec:            cfg.QuantRetryBackoffSec,
+		CompleteWithSucceededNodes: cfg.QuantCompleteWithSucceededNodes,
+	}
+}
+
+// Re-queues any failed jobs that the retry policy allows to be retried. The job items passed in are updated to
+// match what was written to the DB, so they can be counted again afterwards
+func (jm *JobManager) retryFailedJobs(policy jobconfig.JobRetryPolicy, jobs []*protos.JobQueueItem, nowUnixSec int64) error {
+	earliestRetrySec := int64(-1)
+
+	for _, jobItem := range jobs {
+		if jobItem.State != protos.JobQueueItem_FAILED || jobItem.RetryCount >= policy.MaxNodeRetries {
+			continue
+		}
+
+		backoffSec := getRetryBackoffSec(policy, jobItem.RetryCount)
+		retryCount := jobItem.RetryCount + 1
+		msg := fmt.Sprintf("Retry %v of %v after failure: %v", retryCount, policy.MaxNodeRetries, jobItem.Message)
+
+		jm.svcs.Log.Infof("  CheckJobQueue re-queuing failed job %v in %v sec. %v", jobItem.JobId, backoffSec, msg)
+
+		err := job.RequeueJobQueueItem(jobItem.JobId, retryCount, nowUnixSec+backoffSec, msg, jm.svcs.MongoDB, jm.svcs.TimeStamper)
+		if err != nil {
+			return fmt.Errorf("JobManager queue check failed to re-queue job %v. Error: %v", jobItem.JobId, err)
+		}
+
+		jobItem.State = protos.JobQueueItem_UNKNOWN
+		jobItem.Message = msg
+		jobItem.InstanceId = ""
+		jobItem.RetryCount = retryCount
+		jobItem.RetryAfterUnixSec = nowUnixSec + backoffSec
+
+		if earliestRetrySec < 0 || backoffSec < earliestRetrySec {
+			earliestRetrySec = backoffSec
+		}
+	}
+
+	// Nothing will change in the job queue while we wait for the backoff time, so make sure we check it again then
+	if earliestRetrySec > 0 {
+		time.AfterFunc(time.Duration(earliestRetrySec+1)*time.Second, func() {
+			jm.runCheckJobQueueOnce("jobmanager-retry")
+		})
+	}
+
+	return nil
+}
+
+// Returns the node indexes of jobs that failed
+func getFailedNodeIndexes(jobs []*protos.JobQueueItem) []uint32 {
+	result := []uint32{}
+	for _, jobItem := range jobs {
+		if jobItem.State == protos.JobQueueItem_FAILED {
+			result = append(result, jobItem.NodeIndex)
+		}
+	}
+	return result
+}
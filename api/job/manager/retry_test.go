@@ -0,0 +1,52 @@
+package jobmanager
+
+import (
+	"fmt"
+
+	"github.com/pixlise/core/v4/api/config"
+	jobconfig "github.com/pixlise/core/v4/api/job/config"
+	protos "github.com/pixlise/core/v4/generated-protos"
+)
+
+func Example_jobmanager_getRetryBackoffSec() {
+	policy := jobconfig.JobRetryPolicy{MaxNodeRetries: 20, RetryBackoffSec: 30}
+	for _, c := range []uint32{0, 1, 2, 5, 6, 10} {
+		fmt.Printf("%v: %v\n", c, getRetryBackoffSec(policy, c))
+	}
+
+	fmt.Printf("none: %v\n", getRetryBackoffSec(jobconfig.JobRetryPolicy{MaxNodeRetries: 2}, 3))
+
+	// Output:
+	// 0: 30
+	// 1: 60
+	// 2: 120
+	// 5: 960
+	// 6: 1800
+	// 10: 1800
+	// none: 0
+}
+
+func Example_jobmanager_getFailedNodeIndexes() {
+	fmt.Printf("%v\n", getFailedNodeIndexes([]*protos.JobQueueItem{
+		{NodeIndex: 0, State: protos.JobQueueItem_COMPLETE},
+		{NodeIndex: 1, State: protos.JobQueueItem_FAILED},
+		{NodeIndex: 2, State: protos.JobQueueItem_RUNNING},
+		{NodeIndex: 3, State: protos.JobQueueItem_FAILED},
+	}))
+
+	// Output:
+	// [1 3]
+}
+
+func Example_jobmanager_getQuantRetryPolicy() {
+	cfg := config.JobConfig{QuantMaxNodeRetries: 2, QuantRetryBackoffSec: 60, QuantCompleteWithSucceededNodes: true}
+
+	fmt.Printf("map: %+v\n", getQuantRetryPolicy(cfg, "map"))
+	fmt.Printf("fit: %+v\n", getQuantRetryPolicy(cfg, "quant"))
+	fmt.Printf("unconfigured: %+v\n", getQuantRetryPolicy(config.JobConfig{}, "map"))
+
+	// Output:
+	// map: {MaxNodeRetries:2 RetryBackoffSec:60 CompleteWithSucceededNodes:true}
+	// fit: {MaxNodeRetries:0 RetryBackoffSec:0 CompleteWithSucceededNodes:false}
+	// unconfigured: {MaxNodeRetries:0 RetryBackoffSec:0 CompleteWithSucceededNodes:false}
+}
@@ -0,0 +1,113 @@
+package jobmanager
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/olahol/melody"
+	"github.com/pixlise/core/v4/api/job"
+	jobconfig "github.com/pixlise/core/v4/api/job/config"
+	expressionrunner "github.com/pixlise/core/v4/api/job/jobrunner/expression-runner"
+	"github.com/pixlise/core/v4/api/services"
+	"github.com/pixlise/core/v4/api/sessionuser"
+	"github.com/pixlise/core/v4/core/errorwithstatus"
+	protos "github.com/pixlise/core/v4/generated-protos"
+)
+
+var JobComplete_ExpressionBatch = "expression_batch"
+
+// How many expressions we want each node to run. If nothing they need is memoised, expressions can take minutes each
+const expressionsPerNode = 4
+
+// Runs a list of expressions on job nodes, memoising the results so they can be read quickly when the client
+// requests them. The job status shows how many have been run so far
+func (jm *JobManager) SubmitExpressionBatchJob(requests []*protos.DataSourceParams, requestorUserSess *sessionuser.SessionUser, requestorSession *melody.Session) (*protos.JobStatus, error) {
+	// Call the internal one, log the resulting errors if any
+	status, err := jm.internalSubmitExpressionBatchJob(requests, requestorUserSess, requestorSession)
+	if err != nil {
+		jm.svcs.Log.Errorf("SubmitExpressionBatchJob error: %v", err)
+	}
+	return status, err
+}
+
+func (jm *JobManager) internalSubmitExpressionBatchJob(requests []*protos.DataSourceParams, requestorUserSess *sessionuser.SessionUser, requestorSession *melody.Session) (*protos.JobStatus, error) {
+	if len(requests) <= 0 {
+		return nil, errorwithstatus.MakeBadRequestError(errors.New("Expected at least one expression to run"))
+	}
+
+	items := []expressionrunner.ExpressionBatchItem{}
+	for _, req := range requests {
+		items = append(items, expressionrunner.ExpressionBatchItem{
+			ScanId:       req.ScanId,
+			QuantId:      req.QuantId,
+			ExpressionId: req.ExpressionId,
+			RoiId:        req.RoiId,
+			Units:        req.Units,
+		})
+	}
+
+	// Nodes run a contiguous range of items, so sorting by scan means each node reads as few scans as possible
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].ScanId != items[j].ScanId {
+			return items[i].ScanId < items[j].ScanId
+		}
+		return items[i].QuantId < items[j].QuantId
+	})
+
+	jobId := fmt.Sprintf("expr-batch-%v", jm.svcs.IDGen.GenObjectID())
+
+	// If we don't have a user, use the built-in PIXLISE user
+	requestorUserId := sessionuser.PIXLISESystemUserId
+	if requestorUserSess != nil {
+		requestorUserId = requestorUserSess.User.Id
+	}
+
+	jg := &jobconfig.JobGroupConfig{
+		JobGroupId:       jobId,
+		JobType:          protos.JobType_JT_RUN_EXPRESSION_BATCH,
+		CompletionMethod: JobComplete_ExpressionBatch,
+		DockerImage:      jm.svcs.Config.Jobs.RunnerDockerImage,
+		NodeCount:        getExpressionBatchNodeCount(len(items), jm.svcs.Config.Jobs.MaxQuantNodes),
+		NodeConfig: jobconfig.JobConfig{
+			JobId:   jobId + "-node",
+			Command: expressionrunner.ExpressionBatchCommand,
+		},
+		JobName:         fmt.Sprintf("Run %v expressions", len(items)),
+		RequestorUserId: requestorUserId,
+		// Anything that was run is memoised, so no point failing the whole job if one node fails
+		RetryPolicy: jobconfig.JobRetryPolicy{CompleteWithSucceededNodes: true},
+		Priority:    job.JobPriorityNormal,
+	}
+
+	err := jg.SetPayload(&expressionrunner.ExpressionBatchJobPayload{
+		Items:            items,
+		DatasetsBucket:   jm.svcs.Config.DatasetsBucket,
+		UsersBucket:      jm.svcs.Config.UsersBucket,
+		ConfigBucket:     jm.svcs.Config.ConfigBucket,
+		ExpressionLimits: jm.svcs.Config.ExpressionLimits,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return jm.internalSubmitJob(jg, []string{}, requestorSession)
+}
+
+// Works out how many nodes to spread the items across, making sure we don't ask for more than can run at once
+func getExpressionBatchNodeCount(itemCount int, maxNodes uint) uint {
+	nodeCount := (uint(itemCount) + expressionsPerNode - 1) / expressionsPerNode
+	if maxNodes > 0 && nodeCount > maxNodes {
+		nodeCount = maxNodes
+	}
+	if nodeCount <= 0 {
+		nodeCount = 1
+	}
+	return nodeCount
+}
+
+func completeExpressionBatchJob(jg *jobconfig.JobGroupConfig, payload *expressionrunner.ExpressionBatchJobPayload, jstatus *protos.JobStatus, session *melody.Session, svcs *services.APIServices) error {
+	// Nodes memoise results as they go, so there's nothing left to gather
+	svcs.Log.Infof("Expression batch job %v completed running %v expressions on %v nodes (%v failed)", jg.JobGroupId, len(payload.Items), jg.NodeCount, len(jg.FailedNodeIndexes))
+	return nil
+}
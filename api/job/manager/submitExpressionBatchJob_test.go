@@ -0,0 +1,33 @@
+package jobmanager
+
+import (
+	"fmt"
+
+	protos "github.com/pixlise/core/v4/generated-protos"
+)
+
+func Example_jobmanager_getExpressionBatchNodeCount() {
+	fmt.Printf("%v\n", getExpressionBatchNodeCount(1, 10))
+	fmt.Printf("%v\n", getExpressionBatchNodeCount(4, 10))
+	fmt.Printf("%v\n", getExpressionBatchNodeCount(9, 10))
+	fmt.Printf("%v\n", getExpressionBatchNodeCount(100, 10))
+	fmt.Printf("%v\n", getExpressionBatchNodeCount(100, 0))
+
+	// Output:
+	// 1
+	// 1
+	// 3
+	// 10
+	// 25
+}
+
+func Example_jobmanager_getJobItemProgress() {
+	fmt.Println(getJobItemProgress([]*protos.JobQueueItem{
+		{ItemsComplete: 2, ItemsTotal: 4},
+		{ItemsComplete: 0, ItemsTotal: 4},
+		{},
+	}))
+
+	// Output:
+	// 2 8
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/olahol/melody"
 	"github.com/pixlise/core/v4/api/dbCollections"
 	"github.com/pixlise/core/v4/api/filepaths"
+	"github.com/pixlise/core/v4/api/job"
 	jobconfig "github.com/pixlise/core/v4/api/job/config"
 	"github.com/pixlise/core/v4/api/piquant"
 	"github.com/pixlise/core/v4/api/quantification"
@@ -19,8 +20,27 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
+// Parameters stored with quant jobs, needed when they complete (see completeQuantMultiNodeJob and completeQuantSingleMapJob)
+type QuantJobPayload struct {
+	ElementList []string
+	OutputTitle string // Ends up as the first row of the output CSV
+	Combined    bool
+	QuantByROI  bool
+	ROIs        []quantification.ROIItemWithPMCs
+}
+
 // Submit function for each kind of job type we support
 func (jm *JobManager) SubmitQuantJob(createParams *protos.QuantCreateParams, requestorUserSess *sessionuser.SessionUser, requestorSession *melody.Session) (*protos.JobStatus, error) {
+	// Fits are run interactively, the user is waiting for the result, so they get to skip ahead of any quants
+	priority := job.JobPriorityNormal
+	if createParams.Command != "map" {
+		priority = job.JobPriorityHigh
+	}
+
+	return jm.SubmitQuantJobWithPriority(createParams, requestorUserSess, requestorSession, priority)
+}
+
+func (jm *JobManager) SubmitQuantJobWithPriority(createParams *protos.QuantCreateParams, requestorUserSess *sessionuser.SessionUser, requestorSession *melody.Session, priority int32) (*protos.JobStatus, error) {
 	prefix := "quant"
 	jobType := protos.JobType_JT_UNKNOWN
 	jobCompletionMethod := ""
@@ -35,7 +55,7 @@ func (jm *JobManager) SubmitQuantJob(createParams *protos.QuantCreateParams, req
 	}
 
 	// Call the internal one, log the resulting errors if any
-	status, err := jm.internalSubmitQuantJob(createParams, requestorUserSess, requestorSession, prefix, jobType, jobCompletionMethod)
+	status, err := jm.internalSubmitQuantJob(createParams, requestorUserSess, requestorSession, prefix, jobType, jobCompletionMethod, priority)
 	if err != nil {
 		jm.svcs.Log.Errorf("SubmitQuantJob error: %v", err)
 	}
@@ -67,7 +87,8 @@ func (jm *JobManager) internalSubmitQuantJob(
 	requestorSession *melody.Session,
 	idPrefix string,
 	jobType protos.JobType,
-	completeMethod string) (*protos.JobStatus, error) {
+	completeMethod string,
+	priority int32) (*protos.JobStatus, error) {
 	err := quantification.IsValidCreateParam(createParams, jm.svcs, requestorUserSess)
 	if err != nil {
 		return nil, errorwithstatus.MakeBadRequestError(err)
@@ -239,15 +260,23 @@ func (jm *JobManager) internalSubmitQuantJob(
 		},
 		AssociatedScanId: createParams.ScanId,
 		JobName:          createParams.Name,
-		ElementList:      createParams.Elements,
-		OutputTitle:      csvTitleRow,
-		Combined:         combined,
-		QuantByROI:       quantByROI,
-		ROIs:             rois,
 		RequestorUserId:  requestorUserId,
+		RetryPolicy:      getQuantRetryPolicy(jm.svcs.Config.Jobs, createParams.Command),
+		Priority:         priority,
 	}
 
-	return jm.internalSubmitJob(jg, requestorSession)
+	err = jg.SetPayload(&QuantJobPayload{
+		ElementList: createParams.Elements,
+		OutputTitle: csvTitleRow,
+		Combined:    combined,
+		QuantByROI:  quantByROI,
+		ROIs:        rois,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return jm.internalSubmitJob(jg, createParams.Elements, requestorSession)
 }
 
 /*
@@ -390,11 +419,18 @@ job
 		return jm.internalSubmitJob(jg)
 	}
 */
-func (jm *JobManager) internalSubmitJob(jg *jobconfig.JobGroupConfig, requestorSession *melody.Session) (*protos.JobStatus, error) {
+// Submits a job group to be run. The elements are only used to annotate the job status
+func (jm *JobManager) internalSubmitJob(jg *jobconfig.JobGroupConfig, elements []string, requestorSession *melody.Session) (*protos.JobStatus, error) {
 	if len(jg.JobGroupId) <= 0 {
 		return nil, errors.New("SubmitJob: JobGroupId not specified")
 	}
 
+	if len(jg.CompletionMethod) > 0 {
+		if _, ok := jm.jobCompletionMethods[jg.CompletionMethod]; !ok {
+			return nil, fmt.Errorf("SubmitJob: Unknown completion method: %v", jg.CompletionMethod)
+		}
+	}
+
 	// Check other fields are valid
 	if len(jg.AssociatedScanId) > 100 {
 		return nil, errors.New("SubmitJob: AssociatedScanId too long")
@@ -456,7 +492,7 @@ func (jm *JobManager) internalSubmitJob(jg *jobconfig.JobGroupConfig, requestorS
 		JobType:          jg.JobType,
 		JobItemId:        itemId,
 		Name:             jg.JobName,
-		Elements:         jg.ElementList,
+		Elements:         elements,
 		RequestorUserId:  jg.RequestorUserId,
 	}
 
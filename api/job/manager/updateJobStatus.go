@@ -51,6 +51,15 @@ func (jm *JobManager) updateJobStatus(jobId string, status protos.JobStatus_Stat
 					},
 				}
 
+				// Only quant jobs are shown as quants being created, others just show up in the job list
+				if dbStatus.JobType == protos.JobType_JT_RUN_EXPRESSION_BATCH {
+					wsUpd.Contents = &protos.WSMessage_JobListUpd{
+						JobListUpd: &protos.JobListUpd{
+							Job: dbStatus,
+						},
+					}
+				}
+
 				wsHelpers.SendForSession(sess, &wsUpd)
 			}
 		}
@@ -0,0 +1,29 @@
+package job
+
+import (
+	"sort"
+
+	protos "github.com/pixlise/core/v4/generated-protos"
+)
+
+// Queued jobs are started highest priority first, so interactive jobs that a user is waiting on (eg spectral fits)
+// don't have to wait behind bulk jobs like auto-quantifications
+const (
+	JobPriorityLow    int32 = -10
+	JobPriorityNormal int32 = 0
+	JobPriorityHigh   int32 = 10
+)
+
+// Sorts job queue items so the highest priority ones are first. Items of equal priority stay in the order they were
+// queued in
+func SortJobQueueItemsByPriority(items []*protos.JobQueueItem) {
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].Priority != items[j].Priority {
+			return items[i].Priority > items[j].Priority
+		}
+		if items[i].CreatedTimeStampUnixSec != items[j].CreatedTimeStampUnixSec {
+			return items[i].CreatedTimeStampUnixSec < items[j].CreatedTimeStampUnixSec
+		}
+		return items[i].NodeIndex < items[j].NodeIndex
+	})
+}
@@ -0,0 +1,184 @@
+package quantification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+
+	"github.com/pixlise/core/v4/api/dbCollections"
+	protos "github.com/pixlise/core/v4/generated-protos"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Auto-quant profiles define what quantifications run automatically when a scan is imported. They're stored in the DB
+// and edited by admins. If none are stored, we run the defaults below, which is what PIXLISE always used to run. To turn
+// off auto-quants entirely, the stored profiles have to be disabled rather than deleted
+
+// The defaults use the PIXL detector config, so they only run on scans from PIXL itself. There are no defaults for other
+// instruments (breadboards, generic XRF, etc), so those scans aren't auto-quantified unless an admin adds a profile for them
+var pixlInstruments = []protos.ScanInstrument{protos.ScanInstrument_PIXL_FM, protos.ScanInstrument_PIXL_EM}
+
+func DefaultAutoQuantProfiles() []*protos.AutoQuantProfile {
+	return []*protos.AutoQuantProfile{
+		{
+			Id:   "default-pds",
+			Name: "AutoQuant-PDS",
+			// PDS: intended "Na2O", "MgO", "Al2O3", "SiO2", "P2O5", "SO3", "Cl", "K2O", "CaO", "TiO2", "Cr2O3", "MnO", "FeO-T", "NiO", "ZnO", "Br"
+			// But we must specify elements only! Expecting PIQUANT to determine the oxide states to write
+			Elements:              []string{"Na", "Mg", "Al", "Si", "P", "S", "Cl", "K", "Ca", "Ti", "Cr", "Mn", "Fe", "Ni", "Zn", "Br"},
+			Instruments:           slices.Clone(pixlInstruments),
+			DetectorConfig:        "PIXL",
+			DetectorConfigVersion: "v7",
+			QuantModes:            []string{quantModeCombinedAB, quantModeSeparateAB},
+			Parameters:            "-Fe,1",
+			RunTimeSec:            300,
+		},
+		{
+			Id:   "default-pixl",
+			Name: "AutoQuant-PIXL",
+			// PIXL: intended "Na2O", "MgO", "Al2O3", "SiO2", "P2O5", "SO3", "Cl", "K2O", "CaO", "TiO2", "Cr2O3", "MnO", "FeO-T", "NiO", "ZnO", "GeO", "Br", "Rb2O", "SrO", "Y2O3", "ZrO2"
+			// But we must specify elements only! Expecting PIQUANT to determine the oxide states to write
+			Elements:              []string{"Na", "Mg", "Al", "Si", "P", "S", "Cl", "K", "Ca", "Ti", "Cr", "Mn", "Fe", "Ni", "Zn", "Ge", "Br", "Rb", "Sr", "Y", "Zr"},
+			Instruments:           slices.Clone(pixlInstruments),
+			DetectorConfig:        "PIXL",
+			DetectorConfigVersion: "v7",
+			QuantModes:            []string{quantModeCombinedAB, quantModeSeparateAB},
+			Parameters:            "-Fe,1",
+			RunTimeSec:            300,
+		},
+	}
+}
+
+// Reads the stored profiles, or the defaults if there are none
+func ReadAutoQuantProfiles(db *mongo.Database) ([]*protos.AutoQuantProfile, error) {
+	ctx := context.TODO()
+	coll := db.Collection(dbCollections.AutoQuantProfilesName)
+
+	cursor, err := coll.Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "name", Value: 1}}))
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read auto-quant profiles: %v", err)
+	}
+
+	profiles := []*protos.AutoQuantProfile{}
+	err = cursor.All(ctx, &profiles)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to decode auto-quant profiles: %v", err)
+	}
+
+	if len(profiles) <= 0 {
+		return DefaultAutoQuantProfiles(), nil
+	}
+
+	return profiles, nil
+}
+
+// Returns the enabled profiles that match the scan
+func getAutoQuantProfilesForScan(scan *protos.ScanItem, profiles []*protos.AutoQuantProfile) ([]*protos.AutoQuantProfile, error) {
+	result := []*protos.AutoQuantProfile{}
+	for _, profile := range profiles {
+		if profile.Disabled {
+			continue
+		}
+
+		matches, err := autoQuantProfileMatchesScan(profile, scan)
+		if err != nil {
+			return nil, err
+		}
+
+		if matches {
+			result = append(result, profile)
+		}
+	}
+
+	return result, nil
+}
+
+func autoQuantProfileMatchesScan(profile *protos.AutoQuantProfile, scan *protos.ScanItem) (bool, error) {
+	if len(profile.Instruments) > 0 && !slices.Contains(profile.Instruments, scan.Instrument) {
+		return false, nil
+	}
+
+	for key, pattern := range profile.ScanMetaMatch {
+		r, err := regexp.Compile(pattern)
+		if err != nil {
+			return false, fmt.Errorf("Auto-quant profile %v has invalid scan meta match for %v: %v", profile.Name, key, err)
+		}
+
+		// If the scan doesn't have this meta value, we match against a blank string
+		if !r.MatchString(scan.Meta[key]) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func IsValidAutoQuantProfile(profile *protos.AutoQuantProfile) error {
+	if len(profile.Name) <= 0 {
+		return errors.New("Name not supplied")
+	}
+
+	if len(profile.Elements) <= 0 || len(profile.Elements[0]) <= 0 {
+		return errors.New("Elements not supplied")
+	}
+
+	if len(profile.DetectorConfig) <= 0 || len(profile.DetectorConfigVersion) <= 0 {
+		return errors.New("DetectorConfig and DetectorConfigVersion must be supplied")
+	}
+
+	if len(profile.QuantModes) <= 0 {
+		return errors.New("QuantModes not supplied")
+	}
+
+	for _, m := range profile.QuantModes {
+		if m != quantModeCombinedAB && m != quantModeSeparateAB && m != quantModeCombinedABBulk && m != quantModeSeparateABBulk {
+			return fmt.Errorf("Invalid quant mode: %v", m)
+		}
+	}
+
+	if profile.RunTimeSec < 1 {
+		return errors.New("RunTimeSec is invalid")
+	}
+
+	if len(profile.Parameters) > 0 {
+		err := validateParameters(profile.Parameters)
+		if err != nil {
+			return err
+		}
+	}
+
+	for key, pattern := range profile.ScanMetaMatch {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("Invalid scan meta match for %v: %v", key, err)
+		}
+	}
+
+	return nil
+}
+
+// If no profiles are stored, this stores the defaults, so they can be edited or deleted like any other profile
+func StoreDefaultAutoQuantProfilesIfNone(db *mongo.Database) error {
+	ctx := context.TODO()
+	coll := db.Collection(dbCollections.AutoQuantProfilesName)
+
+	count, err := coll.CountDocuments(ctx, bson.M{})
+	if err != nil {
+		return err
+	}
+
+	if count > 0 {
+		return nil
+	}
+
+	items := []interface{}{}
+	for _, profile := range DefaultAutoQuantProfiles() {
+		items = append(items, profile)
+	}
+
+	_, err = coll.InsertMany(ctx, items)
+	return err
+}
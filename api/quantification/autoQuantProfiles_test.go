@@ -0,0 +1,88 @@
+package quantification
+
+import (
+	"fmt"
+
+	protos "github.com/pixlise/core/v4/generated-protos"
+)
+
+func Example_getAutoQuantProfilesForScan() {
+	profiles := []*protos.AutoQuantProfile{
+		{Name: "Any"},
+		{Name: "PIXL", Instruments: []protos.ScanInstrument{protos.ScanInstrument_PIXL_FM, protos.ScanInstrument_PIXL_EM}},
+		{Name: "Disabled", Disabled: true},
+		{Name: "Breadboard", Instruments: []protos.ScanInstrument{protos.ScanInstrument_JPL_BREADBOARD}, ScanMetaMatch: map[string]string{"Target": "^Cal.*"}},
+		{Name: "XRF with no target", Instruments: []protos.ScanInstrument{protos.ScanInstrument_GENERIC_XRF}, ScanMetaMatch: map[string]string{"Target": "^$"}},
+	}
+
+	scans := []*protos.ScanItem{
+		{Id: "FM", Instrument: protos.ScanInstrument_PIXL_FM},
+		{Id: "BB cal", Instrument: protos.ScanInstrument_JPL_BREADBOARD, Meta: map[string]string{"Target": "Calibration"}},
+		{Id: "BB rock", Instrument: protos.ScanInstrument_JPL_BREADBOARD, Meta: map[string]string{"Target": "Rock"}},
+		{Id: "XRF", Instrument: protos.ScanInstrument_GENERIC_XRF},
+	}
+
+	for _, scan := range scans {
+		matched, err := getAutoQuantProfilesForScan(scan, profiles)
+		names := []string{}
+		for _, p := range matched {
+			names = append(names, p.Name)
+		}
+		fmt.Printf("%v: %v, %v\n", scan.Id, names, err)
+	}
+
+	_, err := getAutoQuantProfilesForScan(scans[0], []*protos.AutoQuantProfile{{Name: "Bad", ScanMetaMatch: map[string]string{"Target": "("}}})
+	fmt.Println(err)
+
+	// Defaults are only for PIXL
+	for _, scan := range []*protos.ScanItem{{Id: "EM", Instrument: protos.ScanInstrument_PIXL_EM}, scans[0], scans[1], scans[3]} {
+		matched, err := getAutoQuantProfilesForScan(scan, DefaultAutoQuantProfiles())
+		fmt.Printf("%v defaults: %v, %v\n", scan.Id, len(matched), err)
+	}
+
+	// Output:
+	// FM: [Any PIXL], <nil>
+	// BB cal: [Any Breadboard], <nil>
+	// BB rock: [Any], <nil>
+	// XRF: [Any XRF with no target], <nil>
+	// Auto-quant profile Bad has invalid scan meta match for Target: error parsing regexp: missing closing ): `(`
+	// EM defaults: 2, <nil>
+	// FM defaults: 2, <nil>
+	// BB cal defaults: 0, <nil>
+	// XRF defaults: 0, <nil>
+}
+
+func Example_quantification_IsValidAutoQuantProfile() {
+	for _, p := range DefaultAutoQuantProfiles() {
+		fmt.Println(IsValidAutoQuantProfile(p))
+	}
+
+	p := DefaultAutoQuantProfiles()[0]
+	p.Name = ""
+	fmt.Println(IsValidAutoQuantProfile(p))
+
+	p = DefaultAutoQuantProfiles()[0]
+	p.DetectorConfigVersion = ""
+	fmt.Println(IsValidAutoQuantProfile(p))
+
+	p = DefaultAutoQuantProfiles()[0]
+	p.QuantModes = []string{"Combined", "Weird"}
+	fmt.Println(IsValidAutoQuantProfile(p))
+
+	p = DefaultAutoQuantProfiles()[0]
+	p.Parameters = "-Fe,1; rm -rf /"
+	fmt.Println(IsValidAutoQuantProfile(p))
+
+	p = DefaultAutoQuantProfiles()[0]
+	p.ScanMetaMatch = map[string]string{"Sol": "[0-9"}
+	fmt.Println(IsValidAutoQuantProfile(p))
+
+	// Output:
+	// <nil>
+	// <nil>
+	// Name not supplied
+	// DetectorConfig and DetectorConfigVersion must be supplied
+	// Invalid quant mode: Weird
+	// Invalid parameters passed: -Fe,1; rm -rf /
+	// Invalid scan meta match for Sol: error parsing regexp: missing closing ]: `[0-9`
+}
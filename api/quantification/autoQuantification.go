@@ -3,14 +3,18 @@ package quantification
 import (
 	"context"
 	"fmt"
+	"path"
 	"strconv"
 	"strings"
 
 	"github.com/pixlise/core/v4/api/dbCollections"
+	"github.com/pixlise/core/v4/api/filepaths"
+	"github.com/pixlise/core/v4/api/job"
 	"github.com/pixlise/core/v4/api/services"
 	"github.com/pixlise/core/v4/api/sessionuser"
 	"github.com/pixlise/core/v4/api/ws/wsHelpers"
 	"github.com/pixlise/core/v4/core/logger"
+	"github.com/pixlise/core/v4/core/scan"
 	protos "github.com/pixlise/core/v4/generated-protos"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -20,23 +24,33 @@ import (
 func RunAutoQuantifications(scanId string, svcs *services.APIServices, onlyIfNotExists bool) {
 	svcs.Log.Infof("Request to run auto-quantifications for scan: %v", scanId)
 
-	// TODO: Make these configuration parameters!
-	quantNames := []string{"AutoQuant-PDS", "AutoQuant-PIXL"}
-	quantModes := []string{quantModeCombinedAB, quantModeSeparateAB}
-	quantElements := [][]string{
-		// PDS: intended "Na2O", "MgO", "Al2O3", "SiO2", "P2O5", "SO3", "Cl", "K2O", "CaO", "TiO2", "Cr2O3", "MnO", "FeO-T", "NiO", "ZnO", "Br"
-		// But we must specify elements only! Expecting PIQUANT to determine the oxide states to write
-		{"Na", "Mg", "Al", "Si", "P", "S", "Cl", "K", "Ca", "Ti", "Cr", "Mn", "Fe", "Ni", "Zn", "Br"},
-		// PIXL: intended "Na2O", "MgO", "Al2O3", "SiO2", "P2O5", "SO3", "Cl", "K2O", "CaO", "TiO2", "Cr2O3", "MnO", "FeO-T", "NiO", "ZnO", "GeO", "Br", "Rb2O", "SrO", "Y2O3", "ZrO2"
-		// But we must specify elements only! Expecting PIQUANT to determine the oxide states to write
-		{"Na", "Mg", "Al", "Si", "P", "S", "Cl", "K", "Ca", "Ti", "Cr", "Mn", "Fe", "Ni", "Zn", "Ge", "Br", "Rb", "Sr", "Y", "Zr"},
+	scanItem, err := scan.ReadScanItem(scanId, svcs.MongoDB)
+	if err != nil {
+		svcs.Log.Errorf("AutoQuant failed to read scan %v: %v", scanId, err)
+		return
+	}
+
+	allProfiles, err := ReadAutoQuantProfiles(svcs.MongoDB)
+	if err != nil {
+		svcs.Log.Errorf("AutoQuant %v", err)
+		return
+	}
+
+	profiles, err := getAutoQuantProfilesForScan(scanItem, allProfiles)
+	if err != nil {
+		svcs.Log.Errorf("AutoQuant %v", err)
+		return
+	}
+
+	if len(profiles) <= 0 {
+		svcs.Log.Infof("AutoQuant found no profiles matching scan %v (instrument: %v). Skipping auto-quantification", scanId, scanItem.Instrument)
+		return
 	}
-	detector := "PIXL/PiquantConfigs/v7"
 
 	allNames := []string{}
-	for _, name := range quantNames {
-		for _, m := range quantModes {
-			allNames = append(allNames, makeAutoQuantName(name, m))
+	for _, profile := range profiles {
+		for _, m := range profile.QuantModes {
+			allNames = append(allNames, makeAutoQuantName(profile.Name, m))
 		}
 	}
 
@@ -71,24 +85,33 @@ func RunAutoQuantifications(scanId string, svcs *services.APIServices, onlyIfNot
 	}
 
 	// Start all the quants
-	for c, name := range quantNames {
-		for _, m := range quantModes {
+	for _, profile := range profiles {
+		for _, m := range profile.QuantModes {
 			params := &protos.QuantCreateParams{
 				Command:        "map",
-				Name:           makeAutoQuantName(name, m),
+				Name:           makeAutoQuantName(profile.Name, m),
 				ScanId:         scanId,
 				Pmcs:           pmcs,
-				Elements:       quantElements[c],
-				DetectorConfig: detector,
-				Parameters:     "-Fe,1",
-				RunTimeSec:     300,
+				Elements:       profile.Elements,
+				DetectorConfig: profile.DetectorConfig + "/" + profile.DetectorConfigVersion,
+				Parameters:     profile.Parameters,
+				RunTimeSec:     profile.RunTimeSec,
 				QuantMode:      m,
 				RoiIDs:         []string{},
 				IncludeDwells:  false,
 			}
 
-			i := MakeQuantJobUpdater(params, nil, svcs.Notifier, svcs.MongoDB, svcs.FS, svcs.Config.UsersBucket)
-			_, err := CreateJob(params, sessionuser.PIXLISESystemUserId, svcs, nil, i.SendQuantJobUpdate)
+			var err error
+			if svcs.Config.Jobs.LegacyJobs {
+				params.DetectorConfig = path.Join(profile.DetectorConfig, filepaths.PiquantConfigSubDir, profile.DetectorConfigVersion)
+
+				i := MakeQuantJobUpdater(params, nil, svcs.Notifier, svcs.MongoDB, svcs.FS, svcs.Config.UsersBucket)
+				_, err = CreateJob(params, sessionuser.PIXLISESystemUserId, svcs, nil, i.SendQuantJobUpdate, nil)
+			} else {
+				// These are bulk jobs nobody is waiting on, so they're started after anything users have requested
+				_, err = svcs.JobManager.SubmitQuantJobWithPriority(params, nil, nil, job.JobPriorityLow)
+			}
+
 			if err != nil {
 				svcs.Log.Errorf("AutoQuant failed to create quant job: %v. Error: %v", params.Name, err)
 				return
@@ -39,6 +39,7 @@ import (
 	protos "github.com/pixlise/core/v4/generated-protos"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/protobuf/proto"
 )
 
 // JobParamsFileName - File name of job params file
@@ -49,8 +50,9 @@ const JobRequestFileName = "request.json"
 // forming something along the lines of result000001.csv
 const OutputCSVName = "result.csv"
 
-// CreateJob - creates a new quantification job
-func CreateJob(createParams *protos.QuantCreateParams, requestorUserId string, svcs *services.APIServices, sessUser *sessionuser.SessionUser, sendUpdate func(*protos.JobStatus)) (*protos.JobStatus, error) {
+// CreateJob - creates a new quantification job. While a map quant runs, sendProgress (if not nil) is called with how far
+// it has got. These updates aren't saved in the job status
+func CreateJob(createParams *protos.QuantCreateParams, requestorUserId string, svcs *services.APIServices, sessUser *sessionuser.SessionUser, sendUpdate func(*protos.JobStatus), sendProgress func(*protos.JobStatus, *protos.QuantProgress)) (*protos.JobStatus, error) {
 	// Get configured PIQUANT docker container
 	piquantVersion, err := piquant.GetPiquantVersion(svcs)
 
@@ -114,9 +116,11 @@ func CreateJob(createParams *protos.QuantCreateParams, requestorUserId string, s
 	// Trigger task to start in a go routine, so we don't block!
 	r := quantNodeRunner{
 		jobId:              jobId,
+		jobStatus:          jobStatus,
 		quantStartSettings: params,
 		svcs:               svcs,
 		sessUser:           sessUser,
+		sendProgress:       sendProgress,
 	}
 
 	go r.triggerPiquantNodes()
@@ -126,11 +130,13 @@ func CreateJob(createParams *protos.QuantCreateParams, requestorUserId string, s
 
 type quantNodeRunner struct {
 	jobId              string
+	jobStatus          *protos.JobStatus
 	quantStartSettings *protos.QuantStartingParameters
 	svcs               *services.APIServices
 	isJob              bool
 	logId              string
 	sessUser           *sessionuser.SessionUser
+	sendProgress       func(*protos.JobStatus, *protos.QuantProgress)
 }
 
 // This should be triggered as a go routine from quant creation endpoint so we can return a job id there quickly and do the processing offline
@@ -147,7 +153,7 @@ func (r *quantNodeRunner) triggerPiquantNodes() {
 	jobDataPath := filepaths.GetJobDataPath(userParams.ScanId, r.jobId, "")
 
 	// Get quant runner interface
-	runner, err := quantRunner.GetQuantRunner(svcs.Config.QuantExecutor)
+	runner, err := quantRunner.GetQuantRunner(svcs.Config.QuantExecutor, svcs.FS)
 	if err != nil {
 		r.completeJobState(false, fmt.Sprintf("Failed to start quant runner: %v", err), "", []string{})
 		return
@@ -155,28 +161,8 @@ func (r *quantNodeRunner) triggerPiquantNodes() {
 
 	r.updateJobState(protos.JobStatus_PREPARING_NODES, fmt.Sprintf("Cores/Node: %v", r.quantStartSettings.CoresPerNode))
 
-	datasetPathOnly := path.Dir(r.quantStartSettings.ScanFilePath)
-
 	// Gather required params (these are static, same data passed to each node)
-	piquantParams := quantRunner.PiquantParams{
-		RunTimeEnv:  svcs.Config.EnvironmentName,
-		JobID:       r.jobId,
-		JobsPath:    jobRoot,
-		DatasetPath: datasetPathOnly,
-		// NOTE: not using path.Join because we want this as / deliberately, this is being
-		//       saved in a config file that runs in docker/linux
-		DetectorConfig: filepaths.RootDetectorConfig + "/" + userParams.DetectorConfig + "/",
-		Elements:       userParams.Elements,
-		Parameters:     fmt.Sprintf("%v -t,%v", userParams.Parameters, r.quantStartSettings.CoresPerNode),
-		//DatasetsBucket:    params.DatasetsBucket,
-		//ConfigBucket:      params.ConfigBucket,
-		DatasetsBucket:    svcs.Config.DatasetsBucket,
-		ConfigBucket:      svcs.Config.ConfigBucket,
-		PiquantJobsBucket: r.quantStartSettings.PiquantJobsBucket,
-		QuantName:         userParams.Name,
-		PMCListName:       "", // PMC List Name will be filled in later
-		Command:           userParams.Command,
-	}
+	piquantParams := makePiquantParams(r.jobId, userParams, r.quantStartSettings.CoresPerNode, r.quantStartSettings.PiquantJobsBucket, svcs)
 
 	piquantParamsStr, err := json.MarshalIndent(piquantParams, "", utils.PrettyPrintIndentForJSON)
 	if err == nil {
@@ -193,8 +179,21 @@ func (r *quantNodeRunner) triggerPiquantNodes() {
 	// Save running state as we are blocked after this!
 	r.updateJobState(protos.JobStatus_RUNNING, fmt.Sprintf("Node count: %v, Spectra/Node: %v", len(pmcFiles), spectraPerNode))
 
+	// Track how far the nodes have got, so we can send out progress while PIQUANT runs
+	var onProgress quantRunner.NodeProgressFunc
+	if userParams.Command == "map" && r.sendProgress != nil {
+		spectraCounts := readPMCListSpectraCounts(svcs.FS, r.quantStartSettings.PiquantJobsBucket, jobDataPath, pmcFiles, uint32(spectraPerNode))
+		tracker := makeQuantProgressTracker(pmcFiles, spectraCounts, len(userParams.Elements), svcs.TimeStamper.GetTimeNowSec())
+
+		onProgress = func(pmcListName string, spectraComplete uint32, finished bool) {
+			if progress := tracker.setNodeProgress(pmcListName, spectraComplete, finished, svcs.TimeStamper.GetTimeNowSec()); progress != nil {
+				r.sendProgressUpdate(progress)
+			}
+		}
+	}
+
 	// Run piquant job(s)
-	err = runner.RunPiquant(r.quantStartSettings.PIQUANTVersion, piquantParams, pmcFiles, svcs.Config, r.quantStartSettings.RequestorUserId, svcs.Log)
+	err = runner.RunPiquant(r.quantStartSettings.PIQUANTVersion, piquantParams, pmcFiles, svcs.Config, r.quantStartSettings.RequestorUserId, svcs.Log, onProgress)
 
 	piquantLogList := []string{}
 
@@ -405,6 +404,27 @@ func (r *quantNodeRunner) triggerPiquantNodes() {
 	r.completeJobState(true, completeMsg, quantOutPath, piquantLogList)
 }
 
+// Makes the parameters passed to each PIQUANT node. The PMC list name is filled in by the runner for each node
+func makePiquantParams(jobId string, userParams *protos.QuantCreateParams, coresPerNode uint32, piquantJobsBucket string, svcs *services.APIServices) quantRunner.PiquantParams {
+	return quantRunner.PiquantParams{
+		RunTimeEnv:  svcs.Config.EnvironmentName,
+		JobID:       jobId,
+		JobsPath:    filepaths.GetJobDataPath(userParams.ScanId, "", ""),
+		DatasetPath: path.Dir(filepaths.GetScanFilePath(userParams.ScanId, filepaths.DatasetFileName)),
+		// NOTE: not using path.Join because we want this as / deliberately, this is being
+		//       saved in a config file that runs in docker/linux
+		DetectorConfig:    filepaths.RootDetectorConfig + "/" + userParams.DetectorConfig + "/",
+		Elements:          userParams.Elements,
+		Parameters:        fmt.Sprintf("%v -t,%v", userParams.Parameters, coresPerNode),
+		DatasetsBucket:    svcs.Config.DatasetsBucket,
+		ConfigBucket:      svcs.Config.ConfigBucket,
+		PiquantJobsBucket: piquantJobsBucket,
+		QuantName:         userParams.Name,
+		PMCListName:       "", // PMC List Name will be filled in later
+		Command:           userParams.Command,
+	}
+}
+
 func PreparePMCLists(userParams *protos.QuantCreateParams, sessUser *sessionuser.SessionUser, nodePMCFileName string, jobDataPath string, svcs *services.APIServices, useFileCache bool) (
 	[]string, uint, []ROIItemWithPMCs, bool, bool, error) {
 	// Generate the lists, and then save each, and start the quantification
@@ -443,6 +463,16 @@ func (r *quantNodeRunner) updateJobState(status protos.JobStatus_Status, message
 	}
 }
 
+// Sends out progress along with our job status. This isn't saved to the DB, it's only of interest while the job is running
+func (r *quantNodeRunner) sendProgressUpdate(progress *protos.QuantProgress) {
+	status := proto.Clone(r.jobStatus).(*protos.JobStatus)
+	status.Status = protos.JobStatus_RUNNING
+	status.Message = formatQuantProgressMessage(progress)
+	status.LastUpdateUnixTimeSec = uint32(r.svcs.TimeStamper.GetTimeNowSec())
+
+	r.sendProgress(status, progress)
+}
+
 func (r *quantNodeRunner) completeJobState(success bool, message string, outputFilePath string, otherLogFiles []string) {
 	if r.isJob {
 		job.CompleteJob(r.jobId, success, message, outputFilePath, otherLogFiles, r.svcs.MongoDB, r.svcs.TimeStamper, r.svcs.Log)
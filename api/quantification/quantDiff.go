@@ -0,0 +1,270 @@
+package quantification
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/pixlise/core/v4/api/dbCollections"
+	"github.com/pixlise/core/v4/api/ws/wsHelpers"
+	"github.com/pixlise/core/v4/core/errorwithstatus"
+	"github.com/pixlise/core/v4/core/indexcompression"
+	protos "github.com/pixlise/core/v4/generated-protos"
+)
+
+// If no outlier threshold is given, PMCs whose delta is further than this many standard deviations from the mean delta
+// are outliers
+const defaultOutlierStdDevs = 3
+
+// Compares each quant to the first one (the reference) for each ROI. Values are compared per detector, so A/B quants are
+// only compared to A/B quants, and only for PMCs both quants have values for
+func QuantDiff(scanId string, quantIds []string, roiIds []string, outlierThreshold float32, includePMCDeltas bool, exprPB *protos.Experiment, hctx wsHelpers.HandlerContext) ([]*protos.QuantElementComparison, error) {
+	if len(quantIds) < 2 {
+		return nil, errorwithstatus.MakeBadRequestError(errors.New("Must specify at least 2 quant IDs"))
+	}
+
+	if len(roiIds) <= 0 {
+		return nil, errorwithstatus.MakeBadRequestError(errors.New("Must specify at least 1 ROI ID"))
+	}
+
+	if outlierThreshold < 0 {
+		return nil, errorwithstatus.MakeBadRequestError(errors.New("Outlier threshold cannot be negative"))
+	}
+
+	// Read the ROIs, noting which PMCs are in each. AllPoints is left as nil, meaning no filtering
+	roiPMCs := map[string]map[int32]bool{}
+	for _, roiId := range roiIds {
+		if roiId == allPointsROIId {
+			roiPMCs[roiId] = nil
+			continue
+		}
+
+		roiItem, _, err := wsHelpers.GetUserObjectById[protos.ROIItem](false, roiId, protos.ObjectType_OT_ROI, dbCollections.RegionsOfInterestName, hctx)
+		if err != nil {
+			return nil, err
+		}
+
+		// The ROI's location indexes only make sense for the scan it was made on
+		if roiItem.ScanId != scanId {
+			return nil, errorwithstatus.MakeBadRequestError(fmt.Errorf("ROI %v is not for scan %v", roiId, scanId))
+		}
+
+		locIdxs, err := indexcompression.DecodeIndexList(roiItem.ScanEntryIndexesEncoded, -1)
+		if err != nil {
+			return nil, err
+		}
+
+		pmcs, err := getPMCsForLocationIndexes(locIdxs, exprPB)
+		if err != nil {
+			return nil, err
+		}
+
+		roiPMCs[roiId] = map[int32]bool{}
+		for _, pmc := range pmcs {
+			roiPMCs[roiId][pmc] = true
+		}
+	}
+
+	quants := []*protos.Quantification{}
+	for _, quantId := range quantIds {
+		quantDBItem, _, err := wsHelpers.GetUserObjectById[protos.QuantificationSummary](false, quantId, protos.ObjectType_OT_QUANTIFICATION, dbCollections.QuantificationsName, hctx)
+		if err != nil {
+			return nil, err
+		}
+
+		// Quants are compared by PMC, so must all be of the same scan
+		if quantDBItem.ScanId != scanId {
+			return nil, errorwithstatus.MakeBadRequestError(fmt.Errorf("Quantification %v is not for scan %v", quantId, scanId))
+		}
+
+		quantPath := path.Join(quantDBItem.Status.OutputFilePath, quantId+".bin")
+		quantFile, err := wsHelpers.ReadQuantificationFile(quantId, quantPath, hctx.Svcs)
+		if err != nil {
+			return nil, err
+		}
+
+		quants = append(quants, quantFile)
+	}
+
+	result := []*protos.QuantElementComparison{}
+	for _, roiId := range roiIds {
+		for c := 1; c < len(quants); c++ {
+			result = append(result, compareQuants(roiId, roiPMCs[roiId], quants[0], quantIds[c], quants[c], outlierThreshold, includePMCDeltas)...)
+		}
+	}
+
+	return result, nil
+}
+
+// Compares the weight % columns of quant to reference, for PMCs in roiPMCs (or all PMCs if it's nil)
+func compareQuants(roiId string, roiPMCs map[int32]bool, reference *protos.Quantification, quantId string, quant *protos.Quantification, outlierThreshold float32, includePMCDeltas bool) []*protos.QuantElementComparison {
+	result := []*protos.QuantElementComparison{}
+
+	// Find the columns both have
+	elements := []string{}
+	refColIdxs := []int32{}
+	colIdxs := []int32{}
+	for _, column := range getWeightPercentColumnsInQuant(reference) {
+		colIdx := getQuantColumnIndex(quant, column)
+		if colIdx >= 0 {
+			elements = append(elements, column)
+			refColIdxs = append(refColIdxs, getQuantColumnIndex(reference, column))
+			colIdxs = append(colIdxs, colIdx)
+		}
+	}
+
+	for _, refLocSet := range reference.LocationSet {
+		var locSet *protos.Quantification_QuantLocationSet
+		for _, ls := range quant.LocationSet {
+			if ls.Detector == refLocSet.Detector {
+				locSet = ls
+				break
+			}
+		}
+
+		if locSet == nil {
+			continue
+		}
+
+		// Pair up the locations by PMC
+		refLocs := map[int32]*protos.Quantification_QuantLocation{}
+		for _, loc := range refLocSet.Location {
+			if roiPMCs == nil || roiPMCs[loc.Pmc] {
+				refLocs[loc.Pmc] = loc
+			}
+		}
+
+		pmcs := []int32{}
+		locs := map[int32]*protos.Quantification_QuantLocation{}
+		for _, loc := range locSet.Location {
+			if _, ok := refLocs[loc.Pmc]; ok {
+				pmcs = append(pmcs, loc.Pmc)
+				locs[loc.Pmc] = loc
+			}
+		}
+		sort.Slice(pmcs, func(i, j int) bool { return pmcs[i] < pmcs[j] })
+
+		for c, element := range elements {
+			refValues := []float64{}
+			values := []float64{}
+			for _, pmc := range pmcs {
+				refValues = append(refValues, float64(refLocs[pmc].Values[refColIdxs[c]].Fvalue))
+				values = append(values, float64(locs[pmc].Values[colIdxs[c]].Fvalue))
+			}
+
+			result = append(result, compareElementValues(roiId, refLocSet.Detector, element, quantId, pmcs, refValues, values, outlierThreshold, includePMCDeltas))
+		}
+	}
+
+	return result
+}
+
+func compareElementValues(roiId string, detector string, element string, quantId string, pmcs []int32, refValues []float64, values []float64, outlierThreshold float32, includePMCDeltas bool) *protos.QuantElementComparison {
+	deltas := make([]float64, len(values))
+	for c := range values {
+		deltas[c] = values[c] - refValues[c]
+	}
+
+	refMean, refStdDev := meanAndStdDev(refValues)
+	mean, stdDev := meanAndStdDev(values)
+	meanDelta, deltaStdDev := meanAndStdDev(deltas)
+
+	correlation := 0.0
+	if refStdDev > 0 && stdDev > 0 {
+		covariance := 0.0
+		for c := range values {
+			covariance += (refValues[c] - refMean) * (values[c] - mean)
+		}
+		correlation = covariance / float64(len(values)) / (refStdDev * stdDev)
+	}
+
+	result := &protos.QuantElementComparison{
+		RoiId:           roiId,
+		Detector:        detector,
+		Element:         element,
+		QuantId:         quantId,
+		PmcCount:        uint32(len(pmcs)),
+		ReferenceMean:   float32(refMean),
+		ReferenceStdDev: float32(refStdDev),
+		Mean:            float32(mean),
+		StdDev:          float32(stdDev),
+		MeanDelta:       float32(meanDelta),
+		DeltaStdDev:     float32(deltaStdDev),
+		Correlation:     float32(correlation),
+		OutlierPMCs:     []int32{},
+	}
+
+	for c, pmc := range pmcs {
+		isOutlier := deltaStdDev > 0 && math.Abs(deltas[c]-meanDelta) > defaultOutlierStdDevs*deltaStdDev
+		if outlierThreshold > 0 {
+			isOutlier = math.Abs(deltas[c]) > float64(outlierThreshold)
+		}
+
+		if isOutlier {
+			result.OutlierPMCs = append(result.OutlierPMCs, pmc)
+		}
+
+		if includePMCDeltas {
+			result.PmcDeltas = append(result.PmcDeltas, &protos.QuantPMCDelta{Pmc: pmc, ReferenceValue: float32(refValues[c]), Value: float32(values[c])})
+		}
+	}
+
+	return result
+}
+
+// Population mean and standard deviation
+func meanAndStdDev(values []float64) (float64, float64) {
+	if len(values) <= 0 {
+		return 0, 0
+	}
+
+	sum := 0.0
+	for _, v := range values {
+		sum += v
+	}
+	mean := sum / float64(len(values))
+
+	sumSq := 0.0
+	for _, v := range values {
+		sumSq += (v - mean) * (v - mean)
+	}
+
+	return mean, math.Sqrt(sumSq / float64(len(values)))
+}
+
+// Forms a CSV with a row per comparison
+func FormQuantDiffCSV(comparisons []*protos.QuantElementComparison) string {
+	var sb strings.Builder
+	sb.WriteString("ROI, Detector, Element, Quant, PMC Count, Reference Mean, Reference Std Dev, Mean, Std Dev, Mean Delta, Delta Std Dev, Correlation, Outlier PMCs\n")
+
+	for _, item := range comparisons {
+		outliers := []string{}
+		for _, pmc := range item.OutlierPMCs {
+			outliers = append(outliers, fmt.Sprintf("%v", pmc))
+		}
+
+		sb.WriteString(fmt.Sprintf("%v, %v, %v, %v, %v, %v, %v, %v, %v, %v, %v, %v, %v\n",
+			item.RoiId, item.Detector, item.Element, item.QuantId, item.PmcCount,
+			item.ReferenceMean, item.ReferenceStdDev, item.Mean, item.StdDev, item.MeanDelta, item.DeltaStdDev, item.Correlation,
+			strings.Join(outliers, " ")))
+	}
+
+	return sb.String()
+}
+
+// Forms a CSV with a row per PMC of each comparison. Only has rows if PMC deltas were included in the comparisons
+func FormQuantDiffPMCDeltasCSV(comparisons []*protos.QuantElementComparison) string {
+	var sb strings.Builder
+	sb.WriteString("ROI, Detector, Element, Quant, PMC, Reference Value, Value, Delta\n")
+
+	for _, item := range comparisons {
+		for _, delta := range item.PmcDeltas {
+			sb.WriteString(fmt.Sprintf("%v, %v, %v, %v, %v, %v, %v, %v\n", item.RoiId, item.Detector, item.Element, item.QuantId, delta.Pmc, delta.ReferenceValue, delta.Value, delta.Value-delta.ReferenceValue))
+		}
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,87 @@
+package quantification
+
+import (
+	"fmt"
+
+	protos "github.com/pixlise/core/v4/generated-protos"
+)
+
+func makeQuantDiffTestQuant(detector string, labels []string, pmcValues map[int32][]float32) *protos.Quantification {
+	quant := &protos.Quantification{Labels: labels, LocationSet: []*protos.Quantification_QuantLocationSet{{Detector: detector}}}
+
+	for pmc := int32(1); pmc <= 10; pmc++ {
+		values, ok := pmcValues[pmc]
+		if !ok {
+			continue
+		}
+
+		loc := &protos.Quantification_QuantLocation{Pmc: pmc}
+		for _, v := range values {
+			loc.Values = append(loc.Values, &protos.Quantification_QuantLocation_QuantDataItem{Fvalue: v})
+		}
+		quant.LocationSet[0].Location = append(quant.LocationSet[0].Location, loc)
+	}
+
+	return quant
+}
+
+func Example_quantification_compareQuants() {
+	reference := makeQuantDiffTestQuant("Combined", []string{"FeO-T_%", "SiO2_%", "FeO-T_int"}, map[int32][]float32{
+		1: {10, 40, 100},
+		2: {12, 42, 100},
+		3: {14, 44, 100},
+		4: {16, 46, 100},
+		5: {18, 48, 100},
+		6: {20, 50, 100},
+	})
+
+	// Has no SiO2 but has CaO, no PMC 6 but has 7, and PMC 4 differs far more than the rest
+	quant := makeQuantDiffTestQuant("Combined", []string{"CaO_%", "FeO-T_%"}, map[int32][]float32{
+		1: {5, 11},
+		2: {5, 13},
+		3: {5, 15},
+		4: {5, 26},
+		5: {5, 19},
+		7: {5, 30},
+	})
+
+	printComparisons := func(comparisons []*protos.QuantElementComparison) {
+		for _, c := range comparisons {
+			fmt.Printf("%v %v %v %v: count=%v ref=%.2f±%.2f value=%.2f±%.2f delta=%.2f±%.2f corr=%.3f outliers=%v deltas=%v\n",
+				c.RoiId, c.Detector, c.Element, c.QuantId, c.PmcCount, c.ReferenceMean, c.ReferenceStdDev, c.Mean, c.StdDev, c.MeanDelta, c.DeltaStdDev, c.Correlation, c.OutlierPMCs, len(c.PmcDeltas))
+		}
+	}
+
+	fmt.Println("All points, default outliers:")
+	printComparisons(compareQuants("AllPoints", nil, reference, "quant2", quant, 0, false))
+
+	fmt.Println("All points, outliers over 1.5%:")
+	comparisons := compareQuants("AllPoints", nil, reference, "quant2", quant, 1.5, true)
+	printComparisons(comparisons)
+
+	fmt.Println("ROI:")
+	printComparisons(compareQuants("roi1", map[int32]bool{1: true, 2: true, 6: true}, reference, "quant2", quant, 0, false))
+
+	fmt.Println("Different detectors:")
+	printComparisons(compareQuants("AllPoints", nil, reference, "quant3", makeQuantDiffTestQuant("A", []string{"FeO-T_%"}, map[int32][]float32{1: {3}}), 0, false))
+
+	fmt.Print(FormQuantDiffCSV(comparisons))
+	fmt.Print(FormQuantDiffPMCDeltasCSV(comparisons))
+
+	// Output:
+	// All points, default outliers:
+	// AllPoints Combined FeO-T_% quant2: count=5 ref=14.00±2.83 value=16.80±5.31 delta=2.80±3.60 corr=0.773 outliers=[] deltas=0
+	// All points, outliers over 1.5%:
+	// AllPoints Combined FeO-T_% quant2: count=5 ref=14.00±2.83 value=16.80±5.31 delta=2.80±3.60 corr=0.773 outliers=[4] deltas=5
+	// ROI:
+	// roi1 Combined FeO-T_% quant2: count=2 ref=11.00±1.00 value=12.00±1.00 delta=1.00±0.00 corr=1.000 outliers=[] deltas=0
+	// Different detectors:
+	// ROI, Detector, Element, Quant, PMC Count, Reference Mean, Reference Std Dev, Mean, Std Dev, Mean Delta, Delta Std Dev, Correlation, Outlier PMCs
+	// AllPoints, Combined, FeO-T_%, quant2, 5, 14, 2.828427, 16.8, 5.3065996, 2.8, 3.6, 0.7728526, 4
+	// ROI, Detector, Element, Quant, PMC, Reference Value, Value, Delta
+	// AllPoints, Combined, FeO-T_%, quant2, 1, 10, 11, 1
+	// AllPoints, Combined, FeO-T_%, quant2, 2, 12, 13, 1
+	// AllPoints, Combined, FeO-T_%, quant2, 3, 14, 15, 1
+	// AllPoints, Combined, FeO-T_%, quant2, 4, 16, 26, 10
+	// AllPoints, Combined, FeO-T_%, quant2, 5, 18, 19, 1
+}
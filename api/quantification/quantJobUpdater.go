@@ -70,9 +70,26 @@ func (i *QuantJobUpdater) SendQuantJobUpdate(status *protos.JobStatus) {
 	}
 }
 
-// We send updates for ephemeral quant jobs (that are short running) this way...
-func (i *QuantJobUpdater) SendEphemeralQuantJobUpdate(status *protos.JobStatus) {
-	// We only send out an update for a completed job...
+// We send updates for ephemeral quant jobs (that are short running) this way... Progress of running map quants also goes
+// out this way, as we don't store it anywhere, it's only sent to the session that created the quant
+func (i *QuantJobUpdater) SendEphemeralQuantJobUpdate(status *protos.JobStatus, progress *protos.QuantProgress) {
+	if progress != nil {
+		if i.session != nil {
+			wsUpd := protos.WSMessage{
+				Contents: &protos.WSMessage_QuantCreateUpd{
+					QuantCreateUpd: &protos.QuantCreateUpd{
+						Status:   status,
+						Progress: progress,
+					},
+				},
+			}
+
+			wsHelpers.SendForSession(i.session, &wsUpd)
+		}
+		return
+	}
+
+	// Otherwise we only send out an update for a completed job...
 	if status.Status == protos.JobStatus_COMPLETE && i.session != nil {
 		// We send out the result data, as opposed to a status
 		userOutputFilePath := filepaths.GetUserLastPiquantOutputPath(status.RequestorUserId, i.params.ScanId, i.params.Command, filepaths.QuantLastOutputFileName+".csv")
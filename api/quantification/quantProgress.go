@@ -0,0 +1,142 @@
+package quantification
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pixlise/core/v4/api/quantification/quantRunner"
+	"github.com/pixlise/core/v4/core/fileaccess"
+	protos "github.com/pixlise/core/v4/generated-protos"
+)
+
+// Don't send progress updates more often than this, unless it's the one saying we're done
+const quantProgressMinIntervalSec = 2
+
+// Combines the progress reported by each node running PIQUANT into progress for the whole quant, with an estimate of
+// how long it has left to run
+type quantProgressTracker struct {
+	lock            sync.Mutex
+	startUnixSec    int64
+	secPerSpectrum  float64
+	nodes           []*protos.QuantNodeProgress
+	lastSentUnixSec int64
+}
+
+func makeQuantProgressTracker(pmcListNames []string, nodeSpectraCounts []uint32, elementCount int, startUnixSec int64) *quantProgressTracker {
+	t := &quantProgressTracker{
+		startUnixSec:   startUnixSec,
+		secPerSpectrum: quantRunner.EstimateSecPerSpectrum(uint(elementCount)),
+	}
+
+	for c, name := range pmcListNames {
+		t.nodes = append(t.nodes, &protos.QuantNodeProgress{PmcListName: name, SpectraTotal: nodeSpectraCounts[c]})
+	}
+
+	return t
+}
+
+// Records progress reported for a node. Returns the overall progress if it's time to send out an update, otherwise nil
+func (t *quantProgressTracker) setNodeProgress(pmcListName string, spectraComplete uint32, finished bool, nowUnixSec int64) *protos.QuantProgress {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	var node *protos.QuantNodeProgress
+	for _, n := range t.nodes {
+		if n.PmcListName == pmcListName {
+			node = n
+			break
+		}
+	}
+
+	if node == nil {
+		return nil
+	}
+
+	if finished || spectraComplete > node.SpectraTotal {
+		node.SpectraComplete = node.SpectraTotal
+	} else {
+		node.SpectraComplete = spectraComplete
+	}
+
+	progress := t.getProgress(nowUnixSec)
+	if progress.SpectraComplete < progress.SpectraTotal && nowUnixSec-t.lastSentUnixSec < quantProgressMinIntervalSec {
+		return nil
+	}
+
+	t.lastSentUnixSec = nowUnixSec
+	return progress
+}
+
+func (t *quantProgressTracker) getProgress(nowUnixSec int64) *protos.QuantProgress {
+	result := &protos.QuantProgress{}
+
+	mostRemaining := uint32(0)
+	for _, node := range t.nodes {
+		result.SpectraComplete += node.SpectraComplete
+		result.SpectraTotal += node.SpectraTotal
+		result.Nodes = append(result.Nodes, &protos.QuantNodeProgress{PmcListName: node.PmcListName, SpectraComplete: node.SpectraComplete, SpectraTotal: node.SpectraTotal})
+
+		if remaining := node.SpectraTotal - node.SpectraComplete; remaining > mostRemaining {
+			mostRemaining = remaining
+		}
+	}
+
+	if result.SpectraTotal > 0 {
+		result.PercentComplete = float32(result.SpectraComplete) / float32(result.SpectraTotal) * 100
+	}
+
+	elapsedSec := float64(nowUnixSec - t.startUnixSec)
+	if elapsedSec < 0 {
+		elapsedSec = 0
+	}
+
+	etaSec := 0.0
+	if result.SpectraComplete > 0 {
+		// Extrapolate from how fast it has gone so far
+		etaSec = elapsedSec * float64(result.SpectraTotal-result.SpectraComplete) / float64(result.SpectraComplete)
+	} else {
+		// Nothing reported yet, so go by how long PIQUANT usually takes per spectrum. Nodes run in parallel so it's the
+		// one with the most left to do that decides when we finish
+		etaSec = float64(mostRemaining)*t.secPerSpectrum - elapsedSec
+	}
+
+	if etaSec > 0 {
+		result.EtaSec = uint32(etaSec + 0.5)
+	}
+
+	return result
+}
+
+// Forms the job status message for a progress update
+func formatQuantProgressMessage(progress *protos.QuantProgress) string {
+	return fmt.Sprintf("Running on %v nodes: %v of %v spectra done (%.0f%%), about %v remaining",
+		len(progress.Nodes), progress.SpectraComplete, progress.SpectraTotal, progress.PercentComplete, time.Duration(progress.EtaSec)*time.Second)
+}
+
+// Reads how many spectra each node will process, which is the number of lines in its PMC list after the dataset file
+// name. If a PMC list can't be read, we assume it has defaultCount
+func readPMCListSpectraCounts(fs fileaccess.FileAccess, jobBucket string, jobDataPath string, pmcListNames []string, defaultCount uint32) []uint32 {
+	result := []uint32{}
+
+	for _, name := range pmcListNames {
+		count := defaultCount
+
+		pmcList, err := fs.ReadObject(jobBucket, path.Join(jobDataPath, name))
+		if err == nil {
+			count = 0
+			lines := strings.Split(string(pmcList), "\n")
+			for c := 1; c < len(lines); c++ {
+				if len(strings.TrimSpace(lines[c])) > 0 {
+					count++
+				}
+			}
+		}
+
+		result = append(result, count)
+	}
+
+	return result
+}
@@ -0,0 +1,71 @@
+package quantification
+
+import (
+	"fmt"
+
+	"github.com/pixlise/core/v4/core/fileaccess"
+	protos "github.com/pixlise/core/v4/generated-protos"
+)
+
+func Example_quantProgressTracker() {
+	printProgress := func(progress *protos.QuantProgress) {
+		if progress == nil {
+			fmt.Println("nil")
+			return
+		}
+
+		fmt.Printf("%v/%v %.1f%% eta=%v nodes=%v\n", progress.SpectraComplete, progress.SpectraTotal, progress.PercentComplete, progress.EtaSec, len(progress.Nodes))
+		fmt.Println(formatQuantProgressMessage(progress))
+	}
+
+	// 4 elements is about 5.5 sec per spectrum
+	t := makeQuantProgressTracker([]string{"node1.pmcs", "node2.pmcs"}, []uint32{100, 80}, 4, 1000)
+
+	// Nothing reported yet, so ETA is modelled from the node with the most spectra
+	printProgress(t.getProgress(1000))
+	printProgress(t.getProgress(1100))
+
+	// Unknown node is ignored
+	printProgress(t.setNodeProgress("node3.pmcs", 10, false, 1100))
+
+	// Once spectra are done, ETA comes from how fast it's going
+	printProgress(t.setNodeProgress("node1.pmcs", 30, false, 1120))
+
+	// Too soon to send again
+	printProgress(t.setNodeProgress("node2.pmcs", 30, false, 1121))
+
+	printProgress(t.setNodeProgress("node2.pmcs", 60, false, 1180))
+
+	// Finishing a node counts all its spectra. The last one is always sent
+	printProgress(t.setNodeProgress("node2.pmcs", 0, true, 1200))
+	printProgress(t.setNodeProgress("node1.pmcs", 0, true, 1201))
+
+	// Output:
+	// 0/180 0.0% eta=554 nodes=2
+	// Running on 2 nodes: 0 of 180 spectra done (0%), about 9m14s remaining
+	// 0/180 0.0% eta=454 nodes=2
+	// Running on 2 nodes: 0 of 180 spectra done (0%), about 7m34s remaining
+	// nil
+	// 30/180 16.7% eta=600 nodes=2
+	// Running on 2 nodes: 30 of 180 spectra done (17%), about 10m0s remaining
+	// nil
+	// 90/180 50.0% eta=180 nodes=2
+	// Running on 2 nodes: 90 of 180 spectra done (50%), about 3m0s remaining
+	// 110/180 61.1% eta=127 nodes=2
+	// Running on 2 nodes: 110 of 180 spectra done (61%), about 2m7s remaining
+	// 180/180 100.0% eta=0 nodes=2
+	// Running on 2 nodes: 180 of 180 spectra done (100%), about 0s remaining
+}
+
+func Example_readPMCListSpectraCounts() {
+	fs := fileaccess.MakeMemoryAccess()
+	fmt.Println(fs.WriteObject("jobs", "JobData/scan1/job1/node1.pmcs", []byte("dataset.bin\n1|Normal|A,1|Normal|B\n2|Normal|A,2|Normal|B\n")))
+	fmt.Println(fs.WriteObject("jobs", "JobData/scan1/job1/node2.pmcs", []byte("dataset.bin\nroi1:1|Normal|A,2|Normal|A")))
+
+	fmt.Println(readPMCListSpectraCounts(fs, "jobs", "JobData/scan1/job1", []string{"node1.pmcs", "node2.pmcs", "node3.pmcs"}, 7))
+
+	// Output:
+	// <nil>
+	// <nil>
+	// [2 1 7]
+}
@@ -29,11 +29,10 @@ func EstimateNodeCount(spectraCount uint, elementCount uint, desiredRunTimeSec u
 	// 23 elements 21.8sec
 	// A fitting curve is T=0.015E^2 + 0.6E + 2.9
 	// where T is sec per spectra, E is number of elements
-	// So calculating how much time per spectrum:
-	secPerSpectrum := 0.015*float64(elementCount)*float64(elementCount) + 0.6*float64(elementCount) + 2.9
+	// See EstimateSecPerSpectrum
 
 	// Within the desired runtime, how many ways do we need to farm this out?
-	estRuntimeSec := float64(spectraCount) * secPerSpectrum
+	estRuntimeSec := float64(spectraCount) * EstimateSecPerSpectrum(elementCount)
 
 	nodeCount := uint(estRuntimeSec / float64(desiredRunTimeSec))
 
@@ -47,6 +46,11 @@ func EstimateNodeCount(spectraCount uint, elementCount uint, desiredRunTimeSec u
 	return uint(nodeCount)
 }
 
+// How long one PIQUANT node takes to process one spectrum, based on the real world testing in EstimateNodeCount
+func EstimateSecPerSpectrum(elementCount uint) float64 {
+	return 0.015*float64(elementCount)*float64(elementCount) + 0.6*float64(elementCount) + 2.9
+}
+
 func FilesPerNode(spectraCount uint, nodeCount uint) uint {
 	// NOTE: this may result in some extra if the spectra don't divide exactly per node. Even for a single
 	// node it'll generate+1, but that's ok, this is a limit, when generating PMC files, this will be ok
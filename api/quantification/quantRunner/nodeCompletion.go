@@ -0,0 +1,132 @@
+// Licensed to NASA JPL under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. NASA JPL licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package quantRunner
+
+import (
+	"path"
+	"strings"
+	"time"
+
+	"github.com/pixlise/core/v4/api/filepaths"
+	"github.com/pixlise/core/v4/core/fileaccess"
+)
+
+///////////////////////////////////////////////////////////////////////////////////////////
+// Node completion from the job bucket
+
+// The docker and kubernetes runners can't see inside a running node, but PiquantRunner uploads the node's result CSV
+// to the job's output directory and its logs to the log directory when it's done. So we watch the job bucket for
+// those, and report each node as finished once either shows up. A node that uploads logs but no result has failed,
+// but it's still finished as far as progress goes
+
+// How often we look in the job bucket for nodes that have finished
+const nodeCompletionPollIntervalSec = 10
+
+type nodeCompletionPoller struct {
+	fs           fileaccess.FileAccess
+	bucket       string
+	jobPath      string
+	pmcListNames []string
+	onProgress   NodeProgressFunc
+	reported     map[string]bool
+}
+
+func makeNodeCompletionPoller(fs fileaccess.FileAccess, params PiquantParams, pmcListNames []string, onProgress NodeProgressFunc) *nodeCompletionPoller {
+	return &nodeCompletionPoller{
+		fs:           fs,
+		bucket:       params.PiquantJobsBucket,
+		jobPath:      path.Join(params.JobsPath, params.JobID),
+		pmcListNames: pmcListNames,
+		onProgress:   onProgress,
+		reported:     map[string]bool{},
+	}
+}
+
+// Starts polling the job bucket for nodes that have finished. The returned function stops polling, after one last
+// check so nodes that finished since the previous one are still reported, and returns once that's done
+func startNodeCompletionPoller(fs fileaccess.FileAccess, params PiquantParams, pmcListNames []string, onProgress NodeProgressFunc) func() {
+	stop := make(chan struct{})
+	done := make(chan struct{})
+
+	go func() {
+		makeNodeCompletionPoller(fs, params, pmcListNames, onProgress).poll(stop)
+		close(done)
+	}()
+
+	return func() {
+		close(stop)
+		<-done
+	}
+}
+
+// Checks the job bucket every nodeCompletionPollIntervalSec until stop is closed, then checks once more
+func (p *nodeCompletionPoller) poll(stop <-chan struct{}) {
+	ticker := time.NewTicker(nodeCompletionPollIntervalSec * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			p.check()
+			return
+		case <-ticker.C:
+			p.check()
+		}
+	}
+}
+
+// Reports nodes whose result or logs have appeared since the last check
+func (p *nodeCompletionPoller) check() {
+	if p.onProgress == nil || len(p.reported) >= len(p.pmcListNames) {
+		return
+	}
+
+	// If we fail to list, we try again next time
+	outputs, _ := p.fs.ListObjects(p.bucket, path.Join(p.jobPath, "output"))
+	logs, _ := p.fs.ListObjects(p.bucket, path.Join(p.jobPath, filepaths.PiquantLogSubdir))
+
+	outputNames := map[string]bool{}
+	for _, item := range outputs {
+		outputNames[path.Base(item)] = true
+	}
+
+	for _, name := range p.pmcListNames {
+		if p.reported[name] {
+			continue
+		}
+
+		if outputNames[name+"_result.csv"] {
+			rows := uint32(0)
+			if csvBytes, err := p.fs.ReadObject(p.bucket, path.Join(p.jobPath, "output", name+"_result.csv")); err == nil {
+				rows = countPiquantOutputRows(csvBytes)
+			}
+
+			p.reported[name] = true
+			p.onProgress(name, rows, true)
+			continue
+		}
+
+		for _, item := range logs {
+			if strings.HasPrefix(path.Base(item), name) {
+				p.reported[name] = true
+				p.onProgress(name, 0, true)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package quantRunner
+
+import (
+	"fmt"
+
+	"github.com/pixlise/core/v4/core/fileaccess"
+)
+
+func Example_nodeCompletionPoller_check() {
+	fs := fileaccess.MakeMemoryAccess()
+	params := PiquantParams{JobID: "job1", JobsPath: "JobData/scan1", PiquantJobsBucket: "jobs"}
+
+	p := makeNodeCompletionPoller(fs, params, []string{"node00001.pmcs", "node00002.pmcs", "node00003.pmcs"}, func(pmcListName string, spectraComplete uint32, finished bool) {
+		fmt.Printf("%v: %v %v\n", pmcListName, spectraComplete, finished)
+	})
+
+	fmt.Println("Nothing done:")
+	p.check()
+
+	fmt.Println("Node 2 done:")
+	fmt.Println(fs.WriteObject("jobs", "JobData/scan1/job1/output/node00002.pmcs_result.csv", []byte("title\nPMC, Fe_%\n1, 3.2\n2, 3.3\n")))
+	p.check()
+
+	fmt.Println("Node 3 failed, only wrote logs, and nothing reported twice:")
+	fmt.Println(fs.WriteObject("jobs", "JobData/scan1/job1/piquant-logs/node00003.pmcs_piquant.log", []byte("log")))
+	p.check()
+
+	fmt.Println("Node 1 done:")
+	fmt.Println(fs.WriteObject("jobs", "JobData/scan1/job1/output/node00001.pmcs_result.csv", []byte("title\nPMC, Fe_%\n3, 3.2\n")))
+	p.check()
+
+	// Output:
+	// Nothing done:
+	// Node 2 done:
+	// <nil>
+	// node00002.pmcs: 2 true
+	// Node 3 failed, only wrote logs, and nothing reported twice:
+	// <nil>
+	// node00003.pmcs: 0 true
+	// Node 1 done:
+	// <nil>
+	// node00001.pmcs: 1 true
+}
@@ -23,6 +23,7 @@ import (
 	"fmt"
 
 	"github.com/pixlise/core/v4/api/config"
+	"github.com/pixlise/core/v4/core/fileaccess"
 	"github.com/pixlise/core/v4/core/logger"
 )
 
@@ -45,17 +46,28 @@ type PiquantParams struct {
 	Command           string   `json:"command"`
 }
 
+// Called by runners as nodes make progress. spectraComplete is how many rows of the node's PMC list have been processed
+// so far. Only the local runner can see PIQUANT's output while it runs, so it's the only one that reports progress per
+// spectrum. The docker and kubernetes runners report each node once it has finished (see nodeCompletionPoller), and the
+// null runner reports each node as it writes its output
+type NodeProgressFunc func(pmcListName string, spectraComplete uint32, finished bool)
+
 type QuantRunner interface {
-	RunPiquant(piquantDockerImage string, params PiquantParams, pmcListNames []string, cfg config.APIConfig, requestorUserId string, log logger.ILogger) error
+	// onProgress can be nil if nobody is interested in progress
+	RunPiquant(piquantDockerImage string, params PiquantParams, pmcListNames []string, cfg config.APIConfig, requestorUserId string, log logger.ILogger, onProgress NodeProgressFunc) error
 }
 
-func GetQuantRunner(name string) (QuantRunner, error) {
+// Gets the named runner. The file access is used to read and write job files: null writes PIQUANT-like output, local
+// stages files for PIQUANT on the local file system, and docker and kubernetes watch for nodes finishing
+func GetQuantRunner(name string, fs fileaccess.FileAccess) (QuantRunner, error) {
 	if name == "docker" {
-		return &dockerRunner{}, nil
+		return &dockerRunner{fs: fs}, nil
 	} else if name == "kubernetes" {
-		return &kubernetesRunner{}, nil
+		return &kubernetesRunner{fs: fs}, nil
+	} else if name == "local" {
+		return &localRunner{fs: fs}, nil
 	} else if name == "null" {
-		return &nullRunner{}, nil
+		return &nullRunner{fs: fs}, nil
 	}
 	return nil, fmt.Errorf("Unknown quant runner: %v", name)
 }
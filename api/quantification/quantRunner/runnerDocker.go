@@ -26,6 +26,7 @@ import (
 	"sync"
 
 	"github.com/pixlise/core/v4/api/config"
+	"github.com/pixlise/core/v4/core/fileaccess"
 	"github.com/pixlise/core/v4/core/logger"
 )
 
@@ -33,6 +34,7 @@ import (
 // PIQUANT locally in Docker
 
 type dockerRunner struct {
+	fs fileaccess.FileAccess
 }
 
 func runDockerInstance(wg *sync.WaitGroup, params PiquantParams, dockerImage string, log logger.ILogger) {
@@ -70,7 +72,7 @@ func runDockerInstance(wg *sync.WaitGroup, params PiquantParams, dockerImage str
 	log.Infof(string(out))
 }
 
-func (r *dockerRunner) RunPiquant(piquantDockerImage string, params PiquantParams, pmcListNames []string, cfg config.APIConfig, requestorUserId string, log logger.ILogger) error {
+func (r *dockerRunner) RunPiquant(piquantDockerImage string, params PiquantParams, pmcListNames []string, cfg config.APIConfig, requestorUserId string, log logger.ILogger, onProgress NodeProgressFunc) error {
 	// Here we start multiple instances of docker and wait for them all to finish using the WaitGroup
 	var wg sync.WaitGroup
 
@@ -81,6 +83,9 @@ func (r *dockerRunner) RunPiquant(piquantDockerImage string, params PiquantParam
 		return errors.New(txt)
 	}
 
+	// We can't see inside the containers while they run, so report nodes as their outputs appear in the job bucket
+	stopPolling := startNodeCompletionPoller(r.fs, params, pmcListNames, onProgress)
+
 	for _, name := range pmcListNames {
 		wg.Add(1)
 
@@ -93,5 +98,7 @@ func (r *dockerRunner) RunPiquant(piquantDockerImage string, params PiquantParam
 	// Wait for all piquant instances to finish
 	wg.Wait()
 
+	stopPolling()
+
 	return nil
 }
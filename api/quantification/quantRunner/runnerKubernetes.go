@@ -26,6 +26,7 @@ import (
 	"time"
 
 	"github.com/pixlise/core/v4/api/config"
+	"github.com/pixlise/core/v4/core/fileaccess"
 	"github.com/pixlise/core/v4/core/kubernetes"
 	"github.com/pixlise/core/v4/core/logger"
 	"github.com/pixlise/core/v4/core/utils"
@@ -39,13 +40,15 @@ import (
 // PIQUANT in Kubernetes
 
 type kubernetesRunner struct {
+	fs          fileaccess.FileAccess
 	fatalErrors chan error
 	kubeHelper  kubernetes.KubeHelper
 }
 
 // RunPiquant executes the piquant command in a Kubernetes cluster, creating and
-// monitoring a Kubernetes Job resource as the parallel piquant workers progress
-func (r *kubernetesRunner) RunPiquant(piquantDockerImage string, params PiquantParams, pmcListNames []string, cfg config.APIConfig, requestorUserId string, log logger.ILogger) error {
+// monitoring a Kubernetes Job resource as the parallel piquant workers progress. NOTE: the job status only tells us how
+// many pods succeeded, not which ones, so nodes are reported to onProgress as their outputs appear in the job bucket
+func (r *kubernetesRunner) RunPiquant(piquantDockerImage string, params PiquantParams, pmcListNames []string, cfg config.APIConfig, requestorUserId string, log logger.ILogger, onProgress NodeProgressFunc) error {
 	jobId := fmt.Sprintf("job-%v", params.JobID)
 
 	// Make sure that the kubernetes client is set up
@@ -75,6 +78,9 @@ func (r *kubernetesRunner) RunPiquant(piquantDockerImage string, params PiquantP
 	// Dispatch the piquant run as a Kubernetes Job
 	go r.runQuantJob(params, jobId, kubeNamespace, svcAcctName, piquantDockerImage, requestorUserId, cpu, uint(len(pmcListNames)), status, uint(cfg.Jobs.MaxNodeRunTimeSec))
 
+	stopPolling := startNodeCompletionPoller(r.fs, params, pmcListNames, onProgress)
+	defer stopPolling()
+
 	// Wait for all piquant instances to finish
 	log.Infof("Waiting for %v pods...", len(pmcListNames))
 
@@ -0,0 +1,339 @@
+// Licensed to NASA JPL under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. NASA JPL licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package quantRunner
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pixlise/core/v4/api/config"
+	"github.com/pixlise/core/v4/api/filepaths"
+	"github.com/pixlise/core/v4/core/fileaccess"
+	"github.com/pixlise/core/v4/core/logger"
+)
+
+///////////////////////////////////////////////////////////////////////////////////////////
+// PIQUANT run directly as a local process
+
+// Runs the PIQUANT executable at LocalPiquantPath for each PMC list, with at most LocalPiquantWorkers running at once.
+// Each node gets its own working directory containing the files PIQUANT needs (dataset, detector config files and
+// its PMC list), and its output CSV and logs are written back to the job bucket where the other runners leave them
+type localRunner struct {
+	fs fileaccess.FileAccess
+}
+
+// Names of the PIQUANT output files in each node's working directory
+const localPiquantOutputFile = "map.csv"
+const localPiquantLogFile = "map.csv_log.txt"
+
+// How often we look at how much of its output CSV a running node has written
+const localPiquantProgressIntervalSec = 5
+
+// The files a node needs, read once and written to each node's working directory
+type localPiquantFiles struct {
+	configFile      string
+	calibrationFile string
+	files           map[string][]byte
+}
+
+func (r *localRunner) RunPiquant(piquantDockerImage string, params PiquantParams, pmcListNames []string, cfg config.APIConfig, requestorUserId string, log logger.ILogger, onProgress NodeProgressFunc) error {
+	if len(cfg.LocalPiquantPath) <= 0 {
+		return errors.New("LocalPiquantPath not configured, can't run PIQUANT locally")
+	}
+
+	piquantPath, err := filepath.Abs(cfg.LocalPiquantPath)
+	if err != nil {
+		return err
+	}
+
+	files, err := r.readPiquantFiles(params)
+	if err != nil {
+		return err
+	}
+
+	workRoot, err := os.MkdirTemp("", "piquant-"+params.JobID+"-")
+	if err != nil {
+		return fmt.Errorf("Failed to create local PIQUANT working directory: %v", err)
+	}
+	defer os.RemoveAll(workRoot)
+
+	workerCount := getLocalPiquantWorkerCount(cfg, len(pmcListNames))
+	log.Infof("Running %v PIQUANT nodes locally with %v workers: %v", len(pmcListNames), workerCount, piquantPath)
+
+	// Feed the PMC list names to the workers, collecting any errors
+	names := make(chan string)
+	nodeErrors := []error{}
+	completeCount := 0
+	var lock sync.Mutex
+	var wg sync.WaitGroup
+
+	for c := 0; c < workerCount; c++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for name := range names {
+				nodeParams := params
+				nodeParams.PMCListName = name
+
+				err := r.runNode(piquantPath, filepath.Join(workRoot, name), nodeParams, files, cfg, log, onProgress)
+
+				lock.Lock()
+				completeCount++
+				if err != nil {
+					nodeErrors = append(nodeErrors, err)
+					log.Errorf("Local PIQUANT node %v failed (%v of %v nodes complete): %v", name, completeCount, len(pmcListNames), err)
+				} else {
+					log.Infof("Local PIQUANT node %v finished (%v of %v nodes complete)", name, completeCount, len(pmcListNames))
+				}
+				lock.Unlock()
+			}
+		}()
+	}
+
+	for _, name := range pmcListNames {
+		names <- name
+	}
+	close(names)
+
+	// Wait for all piquant instances to finish
+	wg.Wait()
+
+	if len(nodeErrors) > 0 {
+		return fmt.Errorf("%v of %v local PIQUANT nodes failed. First error: %v", len(nodeErrors), len(pmcListNames), nodeErrors[0])
+	}
+	return nil
+}
+
+// How many PIQUANT processes to run at once. If not configured, we fit as many as we can on this machine given each
+// one is told to use Jobs.CoresPerNode threads
+func getLocalPiquantWorkerCount(cfg config.APIConfig, nodeCount int) int {
+	workers := int(cfg.LocalPiquantWorkers)
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+		if cfg.Jobs.CoresPerNode > 0 {
+			workers /= int(cfg.Jobs.CoresPerNode)
+		}
+	}
+
+	if workers > nodeCount {
+		workers = nodeCount
+	}
+	if workers < 1 {
+		workers = 1
+	}
+	return workers
+}
+
+// Reads the dataset and detector config files. These are the same for all nodes
+func (r *localRunner) readPiquantFiles(params PiquantParams) (localPiquantFiles, error) {
+	result := localPiquantFiles{files: map[string][]byte{}}
+
+	datasetPath := path.Join(params.DatasetPath, filepaths.DatasetFileName)
+	datasetBytes, err := r.fs.ReadObject(params.DatasetsBucket, datasetPath)
+	if err != nil {
+		return result, fmt.Errorf("Failed to read dataset for local PIQUANT: %v. Error: %v", datasetPath, err)
+	}
+	result.files[filepaths.DatasetFileName] = datasetBytes
+
+	// Read the config file which names the other detector config files. NOTE: this has legacy dashed JSON var names
+	piquantCfg := struct {
+		ConfigFile          string `json:"config-file"`
+		OpticEfficiencyFile string `json:"optic-efficiency"`
+		CalibrationFile     string `json:"calibration-file"`
+	}{}
+
+	cfgPath := path.Join(params.DetectorConfig, filepaths.PiquantConfigFileName)
+	cfgBytes, err := r.fs.ReadObject(params.ConfigBucket, cfgPath)
+	if err != nil {
+		return result, fmt.Errorf("Failed to read PIQUANT config: %v. Error: %v", cfgPath, err)
+	}
+	err = json.Unmarshal(cfgBytes, &piquantCfg)
+	if err != nil {
+		return result, fmt.Errorf("Failed to parse PIQUANT config: %v. Error: %v", cfgPath, err)
+	}
+
+	result.configFile = piquantCfg.ConfigFile
+	result.calibrationFile = piquantCfg.CalibrationFile
+
+	for _, name := range []string{piquantCfg.ConfigFile, piquantCfg.CalibrationFile, piquantCfg.OpticEfficiencyFile} {
+		if len(name) <= 0 {
+			continue
+		}
+
+		filePath := path.Join(params.DetectorConfig, name)
+		fileBytes, err := r.fs.ReadObject(params.ConfigBucket, filePath)
+		if err != nil {
+			return result, fmt.Errorf("Failed to read PIQUANT config file: %v. Error: %v", filePath, err)
+		}
+		result.files[name] = fileBytes
+	}
+
+	return result, nil
+}
+
+// Stages the files for one node in workDir, runs PIQUANT there and writes its output CSV and logs to the job bucket
+func (r *localRunner) runNode(piquantPath string, workDir string, params PiquantParams, files localPiquantFiles, cfg config.APIConfig, log logger.ILogger, onProgress NodeProgressFunc) error {
+	err := os.MkdirAll(workDir, 0777)
+	if err != nil {
+		return err
+	}
+
+	for name, fileBytes := range files.files {
+		err = os.WriteFile(filepath.Join(workDir, name), fileBytes, 0666)
+		if err != nil {
+			return err
+		}
+	}
+
+	jobPath := path.Join(params.JobsPath, params.JobID)
+	pmcListPath := path.Join(jobPath, params.PMCListName)
+	pmcList, err := r.fs.ReadObject(params.PiquantJobsBucket, pmcListPath)
+	if err != nil {
+		return fmt.Errorf("Failed to read PMC list: %v. Error: %v", pmcListPath, err)
+	}
+
+	err = os.WriteFile(filepath.Join(workDir, params.PMCListName), pmcList, 0666)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	if cfg.Jobs.MaxNodeRunTimeSec > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(cfg.Jobs.MaxNodeRunTimeSec)*time.Second)
+		defer cancel()
+	}
+
+	var out bytes.Buffer
+	cmd := exec.CommandContext(ctx, piquantPath, makeLocalPiquantArgs(params, files)...)
+	cmd.Dir = workDir
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+
+	runErr := cmd.Start()
+	if runErr == nil {
+		runErr = waitForLocalPiquant(cmd, filepath.Join(workDir, localPiquantOutputFile), params.PMCListName, onProgress)
+	}
+
+	// Save the logs whether it worked or not, they're what we'll need to look at if it failed
+	logPath := path.Join(jobPath, filepaths.PiquantLogSubdir)
+	err = r.fs.WriteObject(params.PiquantJobsBucket, path.Join(logPath, params.PMCListName+"_stdout.log"), out.Bytes())
+	if err != nil {
+		log.Errorf("Failed to save local PIQUANT stdout for %v: %v", params.PMCListName, err)
+	}
+
+	if logBytes, err := os.ReadFile(filepath.Join(workDir, localPiquantLogFile)); err == nil {
+		err = r.fs.WriteObject(params.PiquantJobsBucket, path.Join(logPath, params.PMCListName+"_piquant.log"), logBytes)
+		if err != nil {
+			log.Errorf("Failed to save local PIQUANT log for %v: %v", params.PMCListName, err)
+		}
+	}
+
+	if runErr != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return fmt.Errorf("Timed out after %v sec", cfg.Jobs.MaxNodeRunTimeSec)
+		}
+		return runErr
+	}
+
+	csvBytes, err := os.ReadFile(filepath.Join(workDir, localPiquantOutputFile))
+	if err != nil {
+		return fmt.Errorf("PIQUANT did not write output: %v", err)
+	}
+
+	err = r.fs.WriteObject(params.PiquantJobsBucket, path.Join(jobPath, "output", params.PMCListName+"_result.csv"), csvBytes)
+	if err != nil {
+		return err
+	}
+
+	if onProgress != nil {
+		onProgress(params.PMCListName, countPiquantOutputRows(csvBytes), true)
+	}
+	return nil
+}
+
+// Waits for PIQUANT to exit. While it runs, PIQUANT appends a row to its output CSV for each line of the PMC list, so
+// we report how many rows it has written so far
+func waitForLocalPiquant(cmd *exec.Cmd, outputPath string, pmcListName string, onProgress NodeProgressFunc) error {
+	done := make(chan error)
+	go func() {
+		done <- cmd.Wait()
+	}()
+
+	ticker := time.NewTicker(localPiquantProgressIntervalSec * time.Second)
+	defer ticker.Stop()
+
+	lastRows := uint32(0)
+	for {
+		select {
+		case err := <-done:
+			return err
+		case <-ticker.C:
+			if onProgress == nil {
+				continue
+			}
+
+			csvBytes, err := os.ReadFile(outputPath)
+			if err != nil {
+				// Not written anything yet
+				continue
+			}
+
+			if rows := countPiquantOutputRows(csvBytes); rows != lastRows {
+				lastRows = rows
+				onProgress(pmcListName, rows, false)
+			}
+		}
+	}
+}
+
+// PIQUANT output CSVs have a title row and a header row, followed by a row per line of the PMC list
+func countPiquantOutputRows(csvBytes []byte) uint32 {
+	lines := strings.Count(strings.TrimSpace(string(csvBytes)), "\n") + 1
+	if lines <= 2 {
+		return 0
+	}
+	return uint32(lines - 2)
+}
+
+// Forms the PIQUANT command line: command, config file, calibration file, PMC list, elements, output CSV, then any
+// other parameters
+func makeLocalPiquantArgs(params PiquantParams, files localPiquantFiles) []string {
+	args := []string{
+		params.Command,
+		files.configFile,
+		files.calibrationFile,
+		params.PMCListName,
+		strings.Join(params.Elements, ","),
+		localPiquantOutputFile,
+	}
+
+	return append(args, strings.Fields(params.Parameters)...)
+}
@@ -0,0 +1,106 @@
+//go:build !windows
+
+package quantRunner
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/pixlise/core/v4/api/config"
+	"github.com/pixlise/core/v4/core/fileaccess"
+	"github.com/pixlise/core/v4/core/logger"
+)
+
+func Example_localRunner_RunPiquant() {
+	fs := fileaccess.MakeMemoryAccess()
+
+	fmt.Println(fs.WriteObject("datasets", "Scans/scan1/dataset.bin", []byte("dataset")))
+	fmt.Println(fs.WriteObject("config", "DetectorConfig/PIXL/PiquantConfigs/v7/config.json", []byte(`{"config-file": "cfg.msa", "calibration-file": "calib.csv"}`)))
+	fmt.Println(fs.WriteObject("config", "DetectorConfig/PIXL/PiquantConfigs/v7/cfg.msa", []byte("cfg")))
+	fmt.Println(fs.WriteObject("config", "DetectorConfig/PIXL/PiquantConfigs/v7/calib.csv", []byte("calib")))
+	fmt.Println(fs.WriteObject("jobs", "JobData/scan1/job1/node00001.pmcs", []byte("dataset.bin\n1|Normal|A,1|Normal|B\n")))
+	fmt.Println(fs.WriteObject("jobs", "JobData/scan1/job1/node00002.pmcs", []byte("dataset.bin\n2|Normal|A,2|Normal|B\n")))
+
+	// A stand-in for PIQUANT which checks its files are there, and outputs its args and PMC list
+	binDir, err := os.MkdirTemp("", "fake-piquant-")
+	fmt.Println(err)
+	defer os.RemoveAll(binDir)
+
+	piquantPath := filepath.Join(binDir, "fake-piquant.sh")
+	fmt.Println(os.WriteFile(piquantPath, []byte(`#!/bin/sh
+cat dataset.bin $2 $3 > /dev/null || exit 1
+echo "$@" > $6
+echo "PMC, filename" >> $6
+tail -n 1 $4 >> $6
+echo "piquant log" > $6_log.txt
+`), 0777))
+
+	params := PiquantParams{
+		JobID:             "job1",
+		JobsPath:          "JobData/scan1",
+		DatasetPath:       "Scans/scan1",
+		DetectorConfig:    "DetectorConfig/PIXL/PiquantConfigs/v7/",
+		Elements:          []string{"Fe", "Ca"},
+		Parameters:        " -t,4",
+		DatasetsBucket:    "datasets",
+		ConfigBucket:      "config",
+		PiquantJobsBucket: "jobs",
+		Command:           "map",
+	}
+
+	cfg := config.APIConfig{LocalPiquantWorkers: 2}
+
+	runner, err := GetQuantRunner("local", fs)
+	fmt.Println(err)
+
+	// Nodes run in parallel, so we sort what they report
+	progress := []string{}
+	var lock sync.Mutex
+	onProgress := func(pmcListName string, spectraComplete uint32, finished bool) {
+		lock.Lock()
+		defer lock.Unlock()
+		progress = append(progress, fmt.Sprintf("%v: %v %v", pmcListName, spectraComplete, finished))
+	}
+
+	// Not configured
+	fmt.Println(runner.RunPiquant("", params, []string{"node00001.pmcs", "node00002.pmcs"}, cfg, "user1", &logger.NullLogger{}, onProgress))
+
+	cfg.LocalPiquantPath = piquantPath
+	fmt.Println(runner.RunPiquant("", params, []string{"node00001.pmcs", "node00002.pmcs"}, cfg, "user1", &logger.NullLogger{}, onProgress))
+
+	sort.Strings(progress)
+	fmt.Println(progress)
+
+	for _, name := range []string{"output/node00001.pmcs_result.csv", "output/node00002.pmcs_result.csv", "piquant-logs/node00002.pmcs_piquant.log"} {
+		out, err := fs.ReadObject("jobs", "JobData/scan1/job1/"+name)
+		fmt.Printf("%v: %v|%v", name, err, string(out))
+	}
+
+	// Missing PMC list
+	fmt.Println(runner.RunPiquant("", params, []string{"node00003.pmcs"}, cfg, "user1", &logger.NullLogger{}, nil))
+
+	// Output:
+	// <nil>
+	// <nil>
+	// <nil>
+	// <nil>
+	// <nil>
+	// <nil>
+	// <nil>
+	// <nil>
+	// <nil>
+	// LocalPiquantPath not configured, can't run PIQUANT locally
+	// <nil>
+	// [node00001.pmcs: 1 true node00002.pmcs: 1 true]
+	// output/node00001.pmcs_result.csv: <nil>|map cfg.msa calib.csv node00001.pmcs Fe,Ca map.csv -t,4
+	// PMC, filename
+	// 1|Normal|A,1|Normal|B
+	// output/node00002.pmcs_result.csv: <nil>|map cfg.msa calib.csv node00002.pmcs Fe,Ca map.csv -t,4
+	// PMC, filename
+	// 2|Normal|A,2|Normal|B
+	// piquant-logs/node00002.pmcs_piquant.log: <nil>|piquant log
+	// 1 of 1 local PIQUANT nodes failed. First error: Failed to read PMC list: JobData/scan1/job1/node00003.pmcs. Error: jobs/JobData/scan1/job1/node00003.pmcs: not found
+}
@@ -19,56 +19,127 @@ package quantRunner
 
 import (
 	"fmt"
-	"sync"
-	"time"
-
-	"github.com/pixlise/core/v4/core/logger"
+	"hash/fnv"
+	"path"
+	"strings"
 
 	"github.com/pixlise/core/v4/api/config"
+	"github.com/pixlise/core/v4/core/fileaccess"
+	"github.com/pixlise/core/v4/core/logger"
 )
 
 ///////////////////////////////////////////////////////////////////////////////////////////
 // NullPiquant for testing
 
+// Doesn't run PIQUANT, but writes a result CSV for each PMC list like PIQUANT would, so everything after running
+// PIQUANT can be tested offline. Values are made up, but are always the same for a given element, docker image and
+// detector config, so 2 runs with a different image or config give slightly different results
 type nullRunner struct {
+	fs fileaccess.FileAccess
 }
 
-func (r *nullRunner) RunPiquant(piquantDockerImage string, params PiquantParams, pmcListNames []string, cfg config.APIConfig, requestorUserId string, log logger.ILogger) error {
-	namespace := fmt.Sprintf("job-%v", params.JobID)
-
-	// Start each container in the namespace
-	var wg sync.WaitGroup
+func (r *nullRunner) RunPiquant(piquantDockerImage string, params PiquantParams, pmcListNames []string, cfg config.APIConfig, requestorUserId string, log logger.ILogger, onProgress NodeProgressFunc) error {
 	for _, name := range pmcListNames {
-		wg.Add(1)
+		pmcListPath := path.Join(params.JobsPath, params.JobID, name)
+		pmcList, err := r.fs.ReadObject(params.PiquantJobsBucket, pmcListPath)
+		if err != nil {
+			return fmt.Errorf("Null runner failed to read PMC list: %v. Error: %v", pmcListPath, err)
+		}
 
-		// Set the pmc name so it gets sent to the container
-		params.PMCListName = name
+		csv, err := makeNullPiquantResult(string(pmcList), piquantDockerImage, params)
+		if err != nil {
+			return fmt.Errorf("Null runner failed to process PMC list: %v. Error: %v", pmcListPath, err)
+		}
 
-		go runNullQuantJob(&wg, params, namespace, piquantDockerImage, log)
-	}
+		outPath := path.Join(params.JobsPath, params.JobID, "output", name+"_result.csv")
+		err = r.fs.WriteObject(params.PiquantJobsBucket, outPath, []byte(csv))
+		if err != nil {
+			return err
+		}
+
+		log.Infof("Null runner wrote: %v", outPath)
 
-	// Wait for all piquant instances to finish
-	wg.Wait()
+		if onProgress != nil {
+			onProgress(name, uint32(strings.Count(csv, "\n")-2), true)
+		}
+	}
 
 	return nil
 }
 
-// This is currently very dumb, we should extend it like the mock s3 backend to mock different failures
-// to allow us to test failure modes.
-func runNullQuantJob(wg *sync.WaitGroup, params PiquantParams, namespace string, dockerImage string, log logger.ILogger) {
-	defer wg.Done()
+// Makes a CSV with a row for each line in the PMC list. Lines are either a PMC's spectra, eg:
+// 123|Normal|A,123|Normal|B
+// or the spectra to sum for an ROI:
+// roiId:123|Normal|A,124|Normal|A
+func makeNullPiquantResult(pmcList string, piquantDockerImage string, params PiquantParams) (string, error) {
+	var sb strings.Builder
+	sb.WriteString("Null runner output\n")
+	sb.WriteString("PMC, SCLK, RTT, filename")
+	for _, elem := range params.Elements {
+		sb.WriteString(fmt.Sprintf(", %v_%%, %v_int", elem, elem))
+	}
+	sb.WriteString("\n")
 
-	fmt.Println("Creating pod...")
+	lines := strings.Split(pmcList, "\n")
 
-	// Now wait for it to finish
-	startUnix := time.Now().Unix()
-	maxEndUnix := startUnix + config.KubernetesMaxTimeoutSec
-	for currUnix := time.Now().Unix(); currUnix < maxEndUnix; currUnix = time.Now().Unix() {
-		// Check kubernetes pod status
+	// First line is the dataset file name
+	for c := 1; c < len(lines); c++ {
+		line := strings.TrimSpace(lines[c])
+		if len(line) <= 0 {
+			continue
+		}
 
-		for i := 1; i < 5; i++ {
-			log.Infof("NullQuantJob Loop: " + string(rune(i)))
+		roiId, spectra, isROI := strings.Cut(line, ":")
+		if !isROI {
+			spectra = line
 		}
-		time.Sleep(5 * time.Second)
+
+		// Each spectrum is PMC|READTYPE|DETECTOR
+		pmc := ""
+		detector := ""
+		for _, spectrum := range strings.Split(spectra, ",") {
+			bits := strings.Split(spectrum, "|")
+			if len(bits) != 3 {
+				return "", fmt.Errorf("Invalid spectrum \"%v\" on line %v", spectrum, c+1)
+			}
+
+			if len(pmc) <= 0 {
+				pmc = bits[0]
+			}
+
+			if len(detector) <= 0 {
+				detector = bits[2]
+			} else if detector != bits[2] {
+				detector = "Combined"
+			}
+		}
+
+		fileName := "Normal_" + detector
+		if isROI {
+			fileName += "_" + roiId
+		}
+
+		sb.WriteString(fmt.Sprintf("%v, 0, 0, %v", pmc, fileName))
+		for _, elem := range params.Elements {
+			sb.WriteString(fmt.Sprintf(", %.4f, 1000", nullPiquantWeightPercent(elem, piquantDockerImage, params.DetectorConfig)))
+		}
+		sb.WriteString("\n")
 	}
+
+	return sb.String(), nil
+}
+
+func nullPiquantWeightPercent(elem string, piquantDockerImage string, detectorConfig string) float32 {
+	// Somewhere between 1 and 21%
+	base := 1 + float32(hashString(elem)%2000)/100
+
+	// Varies by up to 1% for the image and config
+	variation := float32(int(hashString(elem+piquantDockerImage+detectorConfig)%21)-10) / 1000
+	return base * (1 + variation)
+}
+
+func hashString(s string) uint32 {
+	h := fnv.New32a()
+	h.Write([]byte(s))
+	return h.Sum32()
 }
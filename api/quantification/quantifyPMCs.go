@@ -122,6 +122,36 @@ func makeIndividualPMCListFileContents(PMCs []int32, combinedDetectors bool, inc
 	return sb.String(), nil
 }
 
+// Reads the PMCs back out of a PMC list file, as written by makeIndividualPMCListFileContents. Each PMC is only
+// returned once, even if it's listed on multiple lines (eg if detectors were quantified separately)
+func ReadPMCListFilePMCs(contents string) ([]int32, error) {
+	result := []int32{}
+	seen := map[int32]bool{}
+
+	lines := strings.Split(contents, "\n")
+
+	// First line is the dataset file name
+	for c := 1; c < len(lines); c++ {
+		line := strings.TrimSpace(lines[c])
+		if len(line) <= 0 {
+			continue
+		}
+
+		pmcStr, _, _ := strings.Cut(line, "|")
+		pmc, err := strconv.Atoi(pmcStr)
+		if err != nil {
+			return []int32{}, fmt.Errorf("Failed to read PMC on line %v: %v", c+1, line)
+		}
+
+		if !seen[int32(pmc)] {
+			seen[int32(pmc)] = true
+			result = append(result, int32(pmc))
+		}
+	}
+
+	return result, nil
+}
+
 func makeQuantJobPMCLists(PMCs []int32, pmcsPerNode int) [][]int32 {
 	var result [][]int32 = make([][]int32, 1)
 
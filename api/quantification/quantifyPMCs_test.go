@@ -59,6 +59,23 @@ func Example_makeIndividualPMCListFileContents_AB() {
 	//  <nil>
 }
 
+func Example_readPMCListFilePMCs() {
+	contents, _ := makeIndividualPMCListFileContents([]int32{15, 7, 388}, false, true, map[int32]bool{7: true})
+	fmt.Println(ReadPMCListFilePMCs(contents))
+
+	contents, _ = makeIndividualPMCListFileContents([]int32{15, 7, 388}, true, false, map[int32]bool{})
+	fmt.Println(ReadPMCListFilePMCs(contents))
+
+	fmt.Println(ReadPMCListFilePMCs("dataset.bin\n"))
+	fmt.Println(ReadPMCListFilePMCs("dataset.bin\n12|Normal|A\nabc|Normal|B"))
+
+	// Output:
+	// [15 7 388] <nil>
+	// [15 7 388] <nil>
+	// [] <nil>
+	// [] Failed to read PMC on line 3: abc|Normal|B
+}
+
 func Example_makeIndividualPMCListFileContents_AB_Dwell() {
 	fmt.Println(makeIndividualPMCListFileContents([]int32{15, 7, 388}, false, true, map[int32]bool{15: true}))
 
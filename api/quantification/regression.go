@@ -0,0 +1,285 @@
+package quantification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"path"
+	"strings"
+
+	"github.com/pixlise/core/v4/api/dbCollections"
+	"github.com/pixlise/core/v4/api/filepaths"
+	"github.com/pixlise/core/v4/api/job"
+	"github.com/pixlise/core/v4/api/piquant"
+	"github.com/pixlise/core/v4/api/quantification/quantRunner"
+	"github.com/pixlise/core/v4/api/services"
+	"github.com/pixlise/core/v4/core/errorwithstatus"
+	protos "github.com/pixlise/core/v4/generated-protos"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/protobuf/proto"
+)
+
+// PIQUANT regression runs quantify a fixed set of scans/ROIs with a baseline and a candidate PIQUANT version or config
+// version, and compare the results. This is to check what a new PIQUANT or config does before making it the default
+
+// CreateRegressionJob - starts a job that runs each case with the baseline and candidate, and compares the results.
+// The summary is saved to the DB when the job completes
+func CreateRegressionJob(params *protos.PiquantRegressionParams, requestorUserId string, svcs *services.APIServices, sendUpdate func(*protos.JobStatus)) (*protos.JobStatus, error) {
+	if err := validateRegressionParams(params); err != nil {
+		return nil, errorwithstatus.MakeBadRequestError(err)
+	}
+
+	// Fill in defaults now, so the summary shows what was actually run
+	for _, target := range []*protos.PiquantRegressionTarget{params.Baseline, params.Candidate} {
+		if len(target.PiquantVersion) <= 0 {
+			piquantVersion, err := piquant.GetPiquantVersion(svcs)
+			if err != nil || len(piquantVersion.Version) <= 0 {
+				return nil, fmt.Errorf("Failed to get PIQUANT version configuration. Error: %v", err)
+			}
+			target.PiquantVersion = piquantVersion.Version
+		}
+	}
+
+	for _, regCase := range params.Cases {
+		if len(regCase.QuantMode) <= 0 {
+			regCase.QuantMode = quantModeCombinedABBulk
+		}
+	}
+
+	runner, err := quantRunner.GetQuantRunner(svcs.Config.QuantExecutor, svcs.FS)
+	if err != nil {
+		return nil, err
+	}
+
+	// Each case runs PIQUANT twice, one after the other, and each run can take as long as a job node is allowed to run
+	timeoutSec := svcs.Config.Jobs.MaxNodeRunTimeSec * uint32(len(params.Cases)) * 2
+	name := fmt.Sprintf("PIQUANT regression: %v %v vs %v %v", params.Baseline.PiquantVersion, params.Baseline.DetectorConfig, params.Candidate.PiquantVersion, params.Candidate.DetectorConfig)
+
+	jobStatus, err := job.AddJob("piquant-regression", requestorUserId, protos.JobType_JT_RUN_QUANT_REGRESSION, "", name, []string{}, timeoutSec, svcs.MongoDB, svcs.IDGen, svcs.TimeStamper, svcs.Log, sendUpdate)
+	if err != nil || jobStatus == nil {
+		returnErr := fmt.Errorf("Failed to add job watcher for PIQUANT regression. Error was: %v", err)
+		svcs.Log.Errorf("%v", returnErr)
+		return nil, returnErr
+	}
+
+	jobId := jobStatus.JobId
+	go func() {
+		summary, failedCaseCount := runRegression(jobId, params, requestorUserId, runner, svcs, func(message string) {
+			job.UpdateJob(jobId, protos.JobStatus_RUNNING, message, jobId, svcs.MongoDB, svcs.TimeStamper, svcs.Log)
+		})
+		summary.CompleteUnixTimeSec = uint32(svcs.TimeStamper.GetTimeNowSec())
+
+		_, err := svcs.MongoDB.Collection(dbCollections.PiquantRegressionsName).InsertOne(context.TODO(), summary)
+		if err != nil {
+			job.CompleteJob(jobId, false, fmt.Sprintf("Failed to save PIQUANT regression summary: %v", err), "", []string{}, svcs.MongoDB, svcs.TimeStamper, svcs.Log)
+			return
+		}
+
+		message := fmt.Sprintf("%v regressions in %v comparisons. %v of %v cases failed to run", summary.RegressionCount, len(summary.Results), failedCaseCount, len(params.Cases))
+		success := failedCaseCount < len(params.Cases)
+		job.CompleteJob(jobId, success, message, "", []string{}, svcs.MongoDB, svcs.TimeStamper, svcs.Log)
+	}()
+
+	return jobStatus, nil
+}
+
+func validateRegressionParams(params *protos.PiquantRegressionParams) error {
+	if len(params.Cases) <= 0 {
+		return errors.New("No cases supplied")
+	}
+
+	for c, regCase := range params.Cases {
+		if len(regCase.ScanId) <= 0 {
+			return fmt.Errorf("Case %v has no scan ID", c+1)
+		}
+		if len(regCase.RoiIds) <= 0 {
+			return fmt.Errorf("Case %v has no ROI IDs", c+1)
+		}
+		if len(regCase.Elements) <= 0 {
+			return fmt.Errorf("Case %v has no elements", c+1)
+		}
+		if len(regCase.QuantMode) > 0 && regCase.QuantMode != quantModeCombinedABBulk && regCase.QuantMode != quantModeSeparateABBulk {
+			return fmt.Errorf("Case %v has invalid quant mode: %v", c+1, regCase.QuantMode)
+		}
+		if len(regCase.Parameters) > 0 {
+			if err := validateParameters(regCase.Parameters); err != nil {
+				return fmt.Errorf("Case %v: %v", c+1, err)
+			}
+		}
+	}
+
+	if params.Baseline == nil || params.Candidate == nil {
+		return errors.New("Baseline and candidate must be supplied")
+	}
+
+	for _, target := range []*protos.PiquantRegressionTarget{params.Baseline, params.Candidate} {
+		configBits := strings.Split(target.DetectorConfig, "/")
+		if len(configBits) != 2 || len(configBits[0]) <= 0 || len(configBits[1]) <= 0 {
+			return fmt.Errorf("DetectorConfig not in expected format: %v", target.DetectorConfig)
+		}
+	}
+
+	if params.Baseline.PiquantVersion == params.Candidate.PiquantVersion && params.Baseline.DetectorConfig == params.Candidate.DetectorConfig {
+		return errors.New("Baseline and candidate are the same")
+	}
+
+	if params.RelativeTolerance <= 0 {
+		return errors.New("RelativeTolerance must be greater than 0")
+	}
+
+	return nil
+}
+
+// Runs each case with the baseline and candidate and compares them. Cases that fail are listed in the summary errors,
+// along with anything else worth noting, so we also return how many cases failed to run
+func runRegression(jobId string, params *protos.PiquantRegressionParams, requestorUserId string, runner quantRunner.QuantRunner, svcs *services.APIServices, onProgress func(string)) (*protos.PiquantRegressionSummary, int) {
+	summary := &protos.PiquantRegressionSummary{
+		Id:              jobId,
+		Params:          params,
+		Results:         []*protos.PiquantRegressionElementResult{},
+		Errors:          []string{},
+		RequestorUserId: requestorUserId,
+	}
+	failedCaseCount := 0
+
+	for c, regCase := range params.Cases {
+		onProgress(fmt.Sprintf("Running case %v of %v (scan %v)", c+1, len(params.Cases), regCase.ScanId))
+
+		baseline, rois, err := runRegressionQuant(fmt.Sprintf("%v-%v-baseline", jobId, c), regCase, params.Baseline, requestorUserId, runner, svcs)
+		if err != nil {
+			summary.Errors = append(summary.Errors, fmt.Sprintf("Case %v (scan %v) baseline failed: %v", c+1, regCase.ScanId, err))
+			failedCaseCount++
+			continue
+		}
+
+		candidate, _, err := runRegressionQuant(fmt.Sprintf("%v-%v-candidate", jobId, c), regCase, params.Candidate, requestorUserId, runner, svcs)
+		if err != nil {
+			summary.Errors = append(summary.Errors, fmt.Sprintf("Case %v (scan %v) candidate failed: %v", c+1, regCase.ScanId, err))
+			failedCaseCount++
+			continue
+		}
+
+		// If the candidate doesn't output something the baseline did, that's worth knowing, but we can't compare it
+		for _, column := range getWeightPercentColumnsInQuant(baseline) {
+			if getQuantColumnIndex(candidate, column) < 0 {
+				summary.Errors = append(summary.Errors, fmt.Sprintf("Case %v (scan %v) candidate did not output: %v", c+1, regCase.ScanId, column))
+			}
+		}
+
+		results := compareRegressionQuants(regCase.ScanId, rois, baseline, candidate, params.RelativeTolerance)
+		for _, result := range results {
+			if result.Regression {
+				summary.RegressionCount++
+			}
+		}
+		summary.Results = append(summary.Results, results...)
+	}
+
+	return summary, failedCaseCount
+}
+
+// Runs PIQUANT for one case and returns the quantification, like a regular quant job would save it, along with the
+// ROIs that were quantified. Nothing is written to the DB, the output only stays in the jobs bucket
+func runRegressionQuant(runId string, regCase *protos.PiquantRegressionCase, target *protos.PiquantRegressionTarget, requestorUserId string, runner quantRunner.QuantRunner, svcs *services.APIServices) (*protos.Quantification, []ROIItemWithPMCs, error) {
+	// Detector config needs to be the path of the config, see legacyHandleQuantCreateReq
+	configBits := strings.Split(target.DetectorConfig, "/")
+
+	userParams := &protos.QuantCreateParams{
+		Command:        "map",
+		ScanId:         regCase.ScanId,
+		Name:           runId,
+		Elements:       regCase.Elements,
+		DetectorConfig: path.Join(configBits[0], filepaths.PiquantConfigSubDir, configBits[1]),
+		Parameters:     regCase.Parameters,
+		QuantMode:      regCase.QuantMode,
+		RoiIDs:         regCase.RoiIds,
+	}
+
+	jobDataPath := filepaths.GetJobDataPath(regCase.ScanId, runId, "")
+
+	pmcFiles, _, rois, combined, _, err := PreparePMCLists(userParams, nil, "node.pmcs", jobDataPath, svcs, false)
+	if err != nil {
+		return nil, rois, err
+	}
+
+	piquantParams := makePiquantParams(runId, userParams, uint32(svcs.Config.Jobs.CoresPerNode), svcs.Config.PiquantJobsBucket, svcs)
+
+	err = runner.RunPiquant(target.PiquantVersion, piquantParams, pmcFiles, svcs.Config, requestorUserId, svcs.Log, nil)
+	if err != nil {
+		return nil, rois, err
+	}
+
+	csvTitleRow := fmt.Sprintf("PIQUANT version: %v DetectorConfig: %v", target.PiquantVersion, target.DetectorConfig)
+	outputCSV, err := ProcessQuantROIsToPMCs(svcs.FS, svcs.Config.PiquantJobsBucket, jobDataPath, csvTitleRow, pmcFiles[0], combined, rois)
+	if err != nil {
+		return nil, rois, err
+	}
+
+	binFileBytes, _, err := ConvertQuantificationCSV(svcs.Log, outputCSV, []string{"PMC", "SCLK", "RTT", "filename"}, nil, false, "", false)
+	if err != nil {
+		return nil, rois, err
+	}
+
+	quant := &protos.Quantification{}
+	err = proto.Unmarshal(binFileBytes, quant)
+	if err != nil {
+		return nil, rois, err
+	}
+
+	return quant, rois, nil
+}
+
+// Compares each element in each ROI. As ROIs are quantified as one summed spectrum, every PMC in an ROI has the same
+// values, so we're comparing the ROI's value
+func compareRegressionQuants(scanId string, rois []ROIItemWithPMCs, baseline *protos.Quantification, candidate *protos.Quantification, relativeTolerance float32) []*protos.PiquantRegressionElementResult {
+	results := []*protos.PiquantRegressionElementResult{}
+
+	for _, roi := range rois {
+		roiPMCs := map[int32]bool{}
+		for _, pmc := range roi.PMCs {
+			roiPMCs[int32(pmc)] = true
+		}
+
+		for _, comparison := range compareQuants(roi.Id, roiPMCs, baseline, "", candidate, 0, false) {
+			if comparison.PmcCount <= 0 {
+				continue
+			}
+
+			relativeDifference := float32(0)
+			if comparison.ReferenceMean != 0 {
+				relativeDifference = (comparison.Mean - comparison.ReferenceMean) / float32(math.Abs(float64(comparison.ReferenceMean)))
+			} else if comparison.Mean != 0 {
+				relativeDifference = 1
+			}
+
+			results = append(results, &protos.PiquantRegressionElementResult{
+				ScanId:             scanId,
+				RoiId:              roi.Id,
+				Detector:           comparison.Detector,
+				Element:            comparison.Element,
+				BaselineValue:      comparison.ReferenceMean,
+				CandidateValue:     comparison.Mean,
+				RelativeDifference: relativeDifference,
+				Regression:         float32(math.Abs(float64(relativeDifference))) > relativeTolerance,
+			})
+		}
+	}
+
+	return results
+}
+
+func ReadRegressionSummary(jobId string, db *mongo.Database) (*protos.PiquantRegressionSummary, error) {
+	result := db.Collection(dbCollections.PiquantRegressionsName).FindOne(context.TODO(), bson.M{"_id": jobId})
+	if result.Err() != nil {
+		if result.Err() == mongo.ErrNoDocuments {
+			return nil, errorwithstatus.MakeNotFoundError(jobId)
+		}
+		return nil, result.Err()
+	}
+
+	summary := &protos.PiquantRegressionSummary{}
+	err := result.Decode(summary)
+	return summary, err
+}
@@ -0,0 +1,95 @@
+package quantification
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/pixlise/core/v4/api/filepaths"
+	"github.com/pixlise/core/v4/api/quantification/quantRunner"
+	"github.com/pixlise/core/v4/api/services/servicesMock"
+	"github.com/pixlise/core/v4/core/idgen"
+	protos "github.com/pixlise/core/v4/generated-protos"
+)
+
+func Example_quantification_validateRegressionParams() {
+	makeParams := func() *protos.PiquantRegressionParams {
+		return &protos.PiquantRegressionParams{
+			Cases:             []*protos.PiquantRegressionCase{{ScanId: "scan1", RoiIds: []string{"AllPoints"}, Elements: []string{"Fe"}}},
+			Baseline:          &protos.PiquantRegressionTarget{PiquantVersion: "piquant:1", DetectorConfig: "PIXL/v7"},
+			Candidate:         &protos.PiquantRegressionTarget{PiquantVersion: "piquant:1", DetectorConfig: "PIXL/v8"},
+			RelativeTolerance: 0.01,
+		}
+	}
+
+	fmt.Println(validateRegressionParams(makeParams()))
+
+	p := makeParams()
+	p.Cases[0].QuantMode = quantModeCombinedAB
+	fmt.Println(validateRegressionParams(p))
+
+	p = makeParams()
+	p.Candidate.DetectorConfig = "PIXL"
+	fmt.Println(validateRegressionParams(p))
+
+	p = makeParams()
+	p.Candidate.DetectorConfig = "PIXL/v7"
+	fmt.Println(validateRegressionParams(p))
+
+	p = makeParams()
+	p.RelativeTolerance = 0
+	fmt.Println(validateRegressionParams(p))
+
+	// Output:
+	// <nil>
+	// Case 1 has invalid quant mode: Combined
+	// DetectorConfig not in expected format: PIXL
+	// Baseline and candidate are the same
+	// RelativeTolerance must be greater than 0
+}
+
+func Example_quantification_runRegression() {
+	svcs := servicesMock.MakeMockSvcsWithMemoryFS(&idgen.MockIDGenerator{}, nil)
+
+	datasetBytes, err := os.ReadFile("./testdata/Naltsosdataset.bin")
+	fmt.Println(err)
+	fmt.Println(svcs.FS.WriteObject(svcs.Config.DatasetsBucket, filepaths.GetScanFilePath("scan1", filepaths.DatasetFileName), datasetBytes))
+
+	runner, err := quantRunner.GetQuantRunner("null", svcs.FS)
+	fmt.Println(err)
+
+	params := &protos.PiquantRegressionParams{
+		Cases: []*protos.PiquantRegressionCase{
+			{ScanId: "scan1", RoiIds: []string{"AllPoints"}, Elements: []string{"Fe", "Ca", "Si"}, QuantMode: quantModeCombinedABBulk},
+			{ScanId: "scan1", RoiIds: []string{"AllPoints"}, Elements: []string{"Fe"}, QuantMode: quantModeSeparateABBulk},
+			{ScanId: "missing-scan", RoiIds: []string{"AllPoints"}, Elements: []string{"Fe"}, QuantMode: quantModeCombinedABBulk},
+		},
+		Baseline:          &protos.PiquantRegressionTarget{PiquantVersion: "piquant:1.0", DetectorConfig: "PIXL/v7"},
+		Candidate:         &protos.PiquantRegressionTarget{PiquantVersion: "piquant:1.1", DetectorConfig: "PIXL/v7"},
+		RelativeTolerance: 0.005,
+	}
+
+	summary, failedCaseCount := runRegression("regr123", params, "user123", runner, &svcs, func(message string) { fmt.Println(message) })
+
+	fmt.Printf("Regressions: %v, failed cases: %v\n", summary.RegressionCount, failedCaseCount)
+	for _, r := range summary.Results {
+		fmt.Printf(" %v %v %v %v: %.3f -> %.3f (%.4f) %v\n", r.ScanId, r.RoiId, r.Detector, r.Element, r.BaselineValue, r.CandidateValue, r.RelativeDifference, r.Regression)
+	}
+	for _, e := range summary.Errors {
+		fmt.Println(e)
+	}
+
+	// Output:
+	// <nil>
+	// <nil>
+	// <nil>
+	// Running case 1 of 3 (scan scan1)
+	// Running case 2 of 3 (scan scan1)
+	// Running case 3 of 3 (scan missing-scan)
+	// Regressions: 4, failed cases: 1
+	//  scan1 AllPoints Combined Fe_%: 18.018 -> 17.804 (-0.0119) true
+	//  scan1 AllPoints Combined Ca_%: 11.580 -> 11.615 (0.0030) false
+	//  scan1 AllPoints Combined Si_%: 13.303 -> 13.397 (0.0070) true
+	//  scan1 AllPoints A Fe_%: 18.018 -> 17.804 (-0.0119) true
+	//  scan1 AllPoints B Fe_%: 18.018 -> 17.804 (-0.0119) true
+	// Case 3 (scan missing-scan) baseline failed: missing-scan not found
+}
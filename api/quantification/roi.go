@@ -32,7 +32,6 @@ func getROIs(
 	locIdxToPMCLookup map[int32]int32,
 	dataset *protos.Experiment) ([]ROIItemWithPMCs, error) {
 	result := []ROIItemWithPMCs{}
-	var err error
 
 	if len(roiIds) <= 0 {
 		// If we're in a map command, this is bad, as we want to have a list of ROIs to generate for
@@ -69,26 +68,29 @@ func getROIs(
 	} else {
 		// Not requesting from the POV of a user, so we're just reading these...
 		for _, roiId := range roiIds {
-			queryROIs = append(queryROIs, roiId)
-
 			if roiId == allPointsROIId {
 				needAllPoints = true
+			} else {
+				queryROIs = append(queryROIs, roiId)
 			}
 		}
 	}
 
-	filter := bson.M{"_id": bson.M{"$in": queryROIs}}
+	// If we only want AllPoints, there's nothing to read
+	items := []*protos.ROIItem{}
+	if len(queryROIs) > 0 {
+		filter := bson.M{"_id": bson.M{"$in": queryROIs}}
 
-	coll := svcs.MongoDB.Collection(dbCollections.RegionsOfInterestName)
-	cursor, err := coll.Find(context.TODO(), filter, options.Find())
-	if err != nil {
-		return nil, err
-	}
+		coll := svcs.MongoDB.Collection(dbCollections.RegionsOfInterestName)
+		cursor, err := coll.Find(context.TODO(), filter, options.Find())
+		if err != nil {
+			return nil, err
+		}
 
-	items := []*protos.ROIItem{}
-	err = cursor.All(context.TODO(), &items)
-	if err != nil {
-		return nil, err
+		err = cursor.All(context.TODO(), &items)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	// Run through them and form output list
@@ -57,6 +57,9 @@ var GitHash string
 
 type JobManagerInterface interface {
 	SubmitQuantJob(createParams *protos.QuantCreateParams, requestorUserSess *sessionuser.SessionUser, requestorSession *melody.Session) (*protos.JobStatus, error)
+	SubmitQuantJobWithPriority(createParams *protos.QuantCreateParams, requestorUserSess *sessionuser.SessionUser, requestorSession *melody.Session, priority int32) (*protos.JobStatus, error)
+	SubmitExpressionBatchJob(requests []*protos.DataSourceParams, requestorUserSess *sessionuser.SessionUser, requestorSession *melody.Session) (*protos.JobStatus, error)
+	CancelJob(jobId string) error
 	// ListJobs() ([]jobmanager.JobGroupConfig, error)
 	// GetJob(JobId string) (jobmanager.JobGroupConfig, error)
 }
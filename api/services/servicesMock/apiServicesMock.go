@@ -22,6 +22,11 @@ func MakeMockSvcsWithFS(bucketRootPath string, idGen idgen.IDGenerator, logLevel
 	return makeMockSvcs(fileaccess.MakeFSAccessS3Simulator(bucketRootPath), idGen, logLevel)
 }
 
+// Uses an in-memory file store, so tests can write files and read them back without mocking each S3 call
+func MakeMockSvcsWithMemoryFS(idGen idgen.IDGenerator, logLevel *logger.LogLevel) services.APIServices {
+	return makeMockSvcs(fileaccess.MakeMemoryAccess(), idGen, logLevel)
+}
+
 func makeMockSvcs(fs fileaccess.FileAccess, idGen idgen.IDGenerator, logLevel *logger.LogLevel) services.APIServices {
 	logging := logger.LogDebug
 	if logLevel != nil {
@@ -28,3 +28,7 @@ func (m MockJWTReader) GetUserInfo(*http.Request) (jwtparser.JWTUserInfo, error)
 func (m MockJWTReader) GetValidator() jwtparser.JWTInterface {
 	return nil
 }
+
+func (m MockJWTReader) GetClaimNames() jwtparser.ClaimNames {
+	return jwtparser.Auth0ClaimNames("")
+}
@@ -0,0 +1,93 @@
+package wsHandler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/pixlise/core/v4/api/dbCollections"
+	"github.com/pixlise/core/v4/api/ws/wsHelpers"
+	"github.com/pixlise/core/v4/core/errorwithstatus"
+	protos "github.com/pixlise/core/v4/generated-protos"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// How many entries we return if the request doesn't say
+const defaultAuditLogMaxEntries = 1000
+
+func HandleAuditLogListReq(req *protos.AuditLogListReq, hctx wsHelpers.HandlerContext) (*protos.AuditLogListResp, error) {
+	if err := wsHelpers.CheckStringField(&req.ObjectId, "ObjectId", 0, wsHelpers.IdFieldMaxLength); err != nil {
+		return nil, err
+	}
+	if err := wsHelpers.CheckStringField(&req.UserId, "UserId", 0, wsHelpers.IdFieldMaxLength); err != nil {
+		return nil, err
+	}
+
+	filter := bson.M{}
+
+	isAdmin := wsHelpers.HasPermission(hctx.SessUser.Permissions, protos.Permission_PERM_PIXLISE_ADMIN)
+
+	// History of an object can be seen by anyone who can see the object, but listing across objects is for admins only
+	if len(req.ObjectId) > 0 {
+		_, err := wsHelpers.CheckObjectAccess(false, req.ObjectId, req.ObjectType, hctx)
+		if err != nil {
+			// If the object was deleted there's nothing to check access against, but its history is still there. Admins
+			// can see all of it, anyone else only the entries they made
+			if e, ok := err.(errorwithstatus.Error); !ok || e.Status() != http.StatusNotFound {
+				return nil, err
+			}
+
+			if !isAdmin {
+				if len(req.UserId) > 0 && req.UserId != hctx.SessUser.User.Id {
+					return nil, errorwithstatus.MakeUnauthorisedError(errors.New("Listing other users' audit log entries for a deleted object requires admin permission"))
+				}
+				req.UserId = hctx.SessUser.User.Id
+			}
+		}
+
+		filter["objectid"] = req.ObjectId
+		filter["objecttype"] = req.ObjectType
+	} else if !isAdmin {
+		return nil, errorwithstatus.MakeUnauthorisedError(errors.New("Listing the audit log without an object id requires admin permission"))
+	} else if req.ObjectType != protos.ObjectType_OT_UNKNOWN {
+		filter["objecttype"] = req.ObjectType
+	}
+
+	if len(req.UserId) > 0 {
+		filter["userid"] = req.UserId
+	}
+
+	if req.FromUnixSec > 0 || req.ToUnixSec > 0 {
+		timeFilter := bson.M{}
+		if req.FromUnixSec > 0 {
+			timeFilter["$gte"] = req.FromUnixSec
+		}
+		if req.ToUnixSec > 0 {
+			timeFilter["$lte"] = req.ToUnixSec
+		}
+		filter["timestampunixsec"] = timeFilter
+	}
+
+	maxEntries := int64(req.MaxEntries)
+	if maxEntries <= 0 {
+		maxEntries = defaultAuditLogMaxEntries
+	}
+
+	ctx := context.TODO()
+	opts := options.Find().SetSort(bson.D{{Key: "timestampunixsec", Value: -1}}).SetLimit(maxEntries)
+	cursor, err := hctx.Svcs.MongoDB.Collection(dbCollections.AuditLogName).Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := []*protos.AuditLogEntry{}
+	err = cursor.All(ctx, &entries)
+	if err != nil {
+		return nil, err
+	}
+
+	return &protos.AuditLogListResp{
+		Entries: entries,
+	}, nil
+}
@@ -0,0 +1,95 @@
+package wsHandler
+
+import (
+	"context"
+	"errors"
+
+	"github.com/pixlise/core/v4/api/dbCollections"
+	"github.com/pixlise/core/v4/api/quantification"
+	"github.com/pixlise/core/v4/api/ws/wsHelpers"
+	"github.com/pixlise/core/v4/core/errorwithstatus"
+	protos "github.com/pixlise/core/v4/generated-protos"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func HandleAutoQuantProfileListReq(req *protos.AutoQuantProfileListReq, hctx wsHelpers.HandlerContext) (*protos.AutoQuantProfileListResp, error) {
+	profiles, err := quantification.ReadAutoQuantProfiles(hctx.Svcs.MongoDB)
+	if err != nil {
+		return nil, err
+	}
+
+	return &protos.AutoQuantProfileListResp{
+		Profiles: profiles,
+	}, nil
+}
+
+func HandleAutoQuantProfileWriteReq(req *protos.AutoQuantProfileWriteReq, hctx wsHelpers.HandlerContext) (*protos.AutoQuantProfileWriteResp, error) {
+	if req.Profile == nil {
+		return nil, errorwithstatus.MakeBadRequestError(errors.New("Profile must be specified"))
+	}
+
+	if err := quantification.IsValidAutoQuantProfile(req.Profile); err != nil {
+		return nil, errorwithstatus.MakeBadRequestError(err)
+	}
+
+	// If we're still running the defaults, they're stored first, so writing this doesn't make them disappear
+	if err := quantification.StoreDefaultAutoQuantProfilesIfNone(hctx.Svcs.MongoDB); err != nil {
+		return nil, err
+	}
+
+	ctx := context.TODO()
+	coll := hctx.Svcs.MongoDB.Collection(dbCollections.AutoQuantProfilesName)
+
+	req.Profile.ModifiedUnixSec = uint32(hctx.Svcs.TimeStamper.GetTimeNowSec())
+	req.Profile.ModifierUserId = hctx.SessUser.User.Id
+
+	if len(req.Profile.Id) > 0 {
+		if err := wsHelpers.CheckStringField(&req.Profile.Id, "Id", 1, wsHelpers.IdFieldMaxLength); err != nil {
+			return nil, err
+		}
+
+		result, err := coll.ReplaceOne(ctx, bson.M{"_id": req.Profile.Id}, req.Profile)
+		if err != nil {
+			return nil, err
+		}
+
+		if result.MatchedCount != 1 {
+			return nil, errorwithstatus.MakeNotFoundError(req.Profile.Id)
+		}
+	} else {
+		req.Profile.Id = hctx.Svcs.IDGen.GenObjectID()
+
+		_, err := coll.InsertOne(ctx, req.Profile)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &protos.AutoQuantProfileWriteResp{
+		Profile: req.Profile,
+	}, nil
+}
+
+func HandleAutoQuantProfileDeleteReq(req *protos.AutoQuantProfileDeleteReq, hctx wsHelpers.HandlerContext) (*protos.AutoQuantProfileDeleteResp, error) {
+	if err := wsHelpers.CheckStringField(&req.Id, "Id", 1, wsHelpers.IdFieldMaxLength); err != nil {
+		return nil, err
+	}
+
+	if err := quantification.StoreDefaultAutoQuantProfilesIfNone(hctx.Svcs.MongoDB); err != nil {
+		return nil, err
+	}
+
+	ctx := context.TODO()
+	coll := hctx.Svcs.MongoDB.Collection(dbCollections.AutoQuantProfilesName)
+
+	result, err := coll.DeleteOne(ctx, bson.M{"_id": req.Id})
+	if err != nil {
+		return nil, err
+	}
+
+	if result.DeletedCount != 1 {
+		return nil, errorwithstatus.MakeNotFoundError(req.Id)
+	}
+
+	return &protos.AutoQuantProfileDeleteResp{}, nil
+}
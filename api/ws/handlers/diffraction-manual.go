@@ -29,11 +29,33 @@ func HandleDiffractionPeakManualListReq(req *protos.DiffractionPeakManualListReq
 		return nil, err
 	}
 
+	resultMap, err = filterDiffractionPeakManualList(resultMap, hctx)
+	if err != nil {
+		return nil, err
+	}
+
 	return &protos.DiffractionPeakManualListResp{
 		Peaks: resultMap,
 	}, nil
 }
 
+// Removes peaks which have sharing set that doesn't include the user
+func filterDiffractionPeakManualList(peaks map[string]*protos.ManualDiffractionPeak, hctx wsHelpers.HandlerContext) (map[string]*protos.ManualDiffractionPeak, error) {
+	visibleIds, err := wsHelpers.FilterOptionalOwnershipIDs(utils.GetMapKeys(peaks), protos.ObjectType_OT_DIFFRACTION_PEAK, hctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := map[string]*protos.ManualDiffractionPeak{}
+	for id, peak := range peaks {
+		if visibleIds[id] {
+			result[id] = peak
+		}
+	}
+
+	return result, nil
+}
+
 // NOTE: ScanId isn't checked to see if it's a real scan upon insertion!
 // NOTE2: Insert ONLY! We generate an ID and insert into DB
 
@@ -69,6 +91,11 @@ func HandleDiffractionPeakManualInsertReq(req *protos.DiffractionPeakManualInser
 		hctx.Svcs.Log.Errorf("Manual diffraction insertion expected InsertedID of %v, got %v", id, result.InsertedID)
 	}
 
+	_, err = wsHelpers.WriteOptionalOwnership(id, protos.ObjectType_OT_DIFFRACTION_PEAK, req.ScanId, hctx)
+	if err != nil {
+		return nil, err
+	}
+
 	return &protos.DiffractionPeakManualInsertResp{CreatedId: id}, nil
 }
 
@@ -77,6 +104,11 @@ func HandleDiffractionPeakManualDeleteReq(req *protos.DiffractionPeakManualDelet
 		return nil, err
 	}
 
+	_, err := wsHelpers.CheckOptionalObjectAccess(true, req.Id, protos.ObjectType_OT_DIFFRACTION_PEAK, hctx)
+	if err != nil {
+		return nil, err
+	}
+
 	ctx := context.TODO()
 	coll := hctx.Svcs.MongoDB.Collection(dbCollections.DiffractionManualPeaksName)
 
@@ -92,5 +124,10 @@ func HandleDiffractionPeakManualDeleteReq(req *protos.DiffractionPeakManualDelet
 		return nil, errorwithstatus.MakeNotFoundError(req.Id)
 	}
 
+	err = wsHelpers.DeleteOptionalOwnership(req.Id, hctx)
+	if err != nil {
+		return nil, err
+	}
+
 	return &protos.DiffractionPeakManualDeleteResp{}, nil
 }
@@ -14,6 +14,7 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.mongodb.org/mongo-driver/mongo/readconcern"
 	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+	"google.golang.org/protobuf/proto"
 )
 
 func HandleElementSetDeleteReq(req *protos.ElementSetDeleteReq, hctx wsHelpers.HandlerContext) (*protos.ElementSetDeleteResp, error) {
@@ -134,6 +135,9 @@ func createElementSet(elementSet *protos.ElementSet, hctx wsHelpers.HandlerConte
 		return nil, err
 	}
 
+	wsHelpers.WriteAuditEntry(elementSet.Id, protos.ObjectType_OT_ELEMENT_SET, protos.AuditAction_AA_CREATE, nil, elementSet, hctx)
+	wsHelpers.SaveObjectRevision(elementSet.Id, protos.ObjectType_OT_ELEMENT_SET, 0, hctx)
+
 	elementSet.Owner = wsHelpers.MakeOwnerSummary(ownerItem, hctx.SessUser, hctx.Svcs.MongoDB, hctx.Svcs.TimeStamper)
 	return elementSet, nil
 }
@@ -146,6 +150,9 @@ func updateElementSet(elementSet *protos.ElementSet, hctx wsHelpers.HandlerConte
 		return nil, err
 	}
 
+	// Keep what it looked like for the audit log
+	dbItemBefore := proto.Clone(dbItem)
+
 	// Update fields
 	update := bson.D{}
 	if len(elementSet.Name) > 0 {
@@ -178,6 +185,9 @@ func updateElementSet(elementSet *protos.ElementSet, hctx wsHelpers.HandlerConte
 		hctx.Svcs.Log.Errorf("Element Set UpdateByID result had unexpected counts %+v id: %v", result, elementSet.Id)
 	}
 
+	wsHelpers.AuditObjectUpdate(elementSet.Id, protos.ObjectType_OT_ELEMENT_SET, dbCollections.ElementSetsName, dbItemBefore, hctx)
+	wsHelpers.SaveObjectRevision(elementSet.Id, protos.ObjectType_OT_ELEMENT_SET, 0, hctx)
+
 	// Return the merged item we validated, which in theory is in the DB now
 	dbItem.Owner = wsHelpers.MakeOwnerSummary(owner, hctx.SessUser, hctx.Svcs.MongoDB, hctx.Svcs.TimeStamper)
 	return dbItem, nil
@@ -1,15 +1,22 @@
 package wsHandler
 
 import (
+	"archive/zip"
+	"bytes"
+	"context"
 	"errors"
+	"fmt"
 	"os"
 	"path"
 
 	"github.com/pixlise/core/v4/api/dbCollections"
+	"github.com/pixlise/core/v4/api/filepaths"
 	"github.com/pixlise/core/v4/api/ws/wsHelpers"
 	"github.com/pixlise/core/v4/core/errorwithstatus"
 	"github.com/pixlise/core/v4/core/utils"
 	protos "github.com/pixlise/core/v4/generated-protos"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
 func HandleExportFilesReq(req *protos.ExportFilesReq, hctx wsHelpers.HandlerContext) (*protos.ExportFilesResp, error) {
@@ -17,11 +24,6 @@ func HandleExportFilesReq(req *protos.ExportFilesReq, hctx wsHelpers.HandlerCont
 		return nil, errors.New("no export types specified")
 	}
 
-	// For now we only allow exporting one thing...
-	if len(req.ExportTypes) != 1 || req.ExportTypes[0] != protos.ExportDataType_EDT_QUANT_CSV {
-		return nil, errors.New("Only one export type allowed: QUANT_CSV")
-	}
-
 	zipRoot := path.Join(os.TempDir(), "export-"+utils.RandStringBytesMaskImpr(8))
 	err := os.MkdirAll(zipRoot, os.ModePerm)
 
@@ -29,8 +31,30 @@ func HandleExportFilesReq(req *protos.ExportFilesReq, hctx wsHelpers.HandlerCont
 		return nil, err
 	}
 
+	defer os.RemoveAll(zipRoot)
+
+	// Anything scan-related needs the scan file, but we only want to read it once
+	var exprPB *protos.Experiment
+	getScan := func() (*protos.Experiment, error) {
+		if exprPB != nil {
+			return exprPB, nil
+		}
+
+		scan, err := beginDatasetFileReq(req.ScanId, hctx)
+		if err != nil {
+			return nil, err
+		}
+
+		exprPB = scan
+		return exprPB, nil
+	}
+
 	files := make([]*protos.ExportFile, 0)
+	zipFileCount := 0
 	for _, expType := range req.ExportTypes {
+		var zipFiles map[string][]byte
+		zipSubDir := ""
+
 		if expType == protos.ExportDataType_EDT_QUANT_CSV {
 			// Read from DB
 			dbItem, _, err := wsHelpers.GetUserObjectById[protos.QuantificationSummary](false, req.QuantId, protos.ObjectType_OT_QUANTIFICATION, dbCollections.QuantificationsName, hctx)
@@ -57,10 +81,223 @@ func HandleExportFilesReq(req *protos.ExportFilesReq, hctx wsHelpers.HandlerCont
 				Extension: "csv",
 				Content:   fileBytes,
 			})
+		} else if expType == protos.ExportDataType_EDT_SCAN_SPECTRA_MSA {
+			scan, err := getScan()
+			if err != nil {
+				return nil, err
+			}
+
+			zipSubDir = "spectra"
+			zipFiles, err = wsHelpers.MakeScanMSAFiles(scan)
+			if err != nil {
+				return nil, err
+			}
+		} else if expType == protos.ExportDataType_EDT_SCAN_BEAM_LOCATIONS {
+			scan, err := getScan()
+			if err != nil {
+				return nil, err
+			}
+
+			zipFiles = map[string][]byte{"beam-locations.csv": wsHelpers.MakeScanBeamLocationCSV(scan)}
+		} else if expType == protos.ExportDataType_EDT_SCAN_HOUSEKEEPING_CSV {
+			scan, err := getScan()
+			if err != nil {
+				return nil, err
+			}
+
+			csv, err := wsHelpers.MakeScanHousekeepingCSV(scan)
+			if err != nil {
+				return nil, err
+			}
+
+			zipFiles = map[string][]byte{"housekeeping.csv": csv}
+		} else if expType == protos.ExportDataType_EDT_ROI_PMCS {
+			scan, err := getScan()
+			if err != nil {
+				return nil, err
+			}
+
+			zipFiles, err = makeROIExportFiles(req, scan, hctx)
+			if err != nil {
+				return nil, err
+			}
+		} else if expType == protos.ExportDataType_EDT_DIFFRACTION_PEAKS {
+			scan, err := getScan()
+			if err != nil {
+				return nil, err
+			}
+
+			zipFiles, err = makeDiffractionExportFiles(req.ScanId, scan, hctx)
+			if err != nil {
+				return nil, err
+			}
+		} else if expType == protos.ExportDataType_EDT_CONTEXT_IMAGES {
+			// Check scan access, images are exported relative to this scan's beam locations
+			if _, err := getScan(); err != nil {
+				return nil, err
+			}
+
+			zipSubDir = "images"
+			zipFiles, err = makeContextImageExportFiles(req, hctx)
+			if err != nil {
+				return nil, err
+			}
 		} else {
 			return nil, errors.New("Unsupported export type")
 		}
+
+		// Write any files generated to our zip directory
+		if len(zipFiles) > 0 {
+			writeDir := path.Join(zipRoot, zipSubDir)
+			if err := os.MkdirAll(writeDir, os.ModePerm); err != nil {
+				return nil, err
+			}
+
+			for name, content := range zipFiles {
+				if err := os.WriteFile(path.Join(writeDir, name), content, 0644); err != nil {
+					return nil, err
+				}
+			}
+
+			zipFileCount += len(zipFiles)
+		}
+	}
+
+	// If we generated scan data files, send them back as one zip
+	if zipFileCount > 0 {
+		buf := new(bytes.Buffer)
+		w := zip.NewWriter(buf)
+
+		if err := utils.AddFilesToZip(w, zipRoot, ""); err != nil {
+			return nil, err
+		}
+		if err := w.Close(); err != nil {
+			return nil, err
+		}
+
+		files = append(files, &protos.ExportFile{
+			Name:      req.ScanId + "-export.zip",
+			Extension: "zip",
+			Content:   buf.Bytes(),
+		})
 	}
 
 	return &protos.ExportFilesResp{Files: files}, nil
 }
+
+func makeROIExportFiles(req *protos.ExportFilesReq, exprPB *protos.Experiment, hctx wsHelpers.HandlerContext) (map[string][]byte, error) {
+	if len(req.RoiIds) <= 0 {
+		return nil, errorwithstatus.MakeBadRequestError(errors.New("no ROI ids specified for ROI export"))
+	}
+
+	rois := []*protos.ROIItem{}
+	for _, roiId := range req.RoiIds {
+		roi, _, err := wsHelpers.GetUserObjectById[protos.ROIItem](false, roiId, protos.ObjectType_OT_ROI, dbCollections.RegionsOfInterestName, hctx)
+		if err != nil {
+			return nil, err
+		}
+
+		if roi.ScanId != req.ScanId {
+			return nil, errorwithstatus.MakeBadRequestError(fmt.Errorf("ROI %v does not belong to scan %v", roiId, req.ScanId))
+		}
+
+		rois = append(rois, roi)
+	}
+
+	csv, err := wsHelpers.MakeROIPMCsCSV(exprPB, rois)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string][]byte{"roi-pmcs.csv": csv}, nil
+}
+
+func makeDiffractionExportFiles(scanId string, exprPB *protos.Experiment, hctx wsHelpers.HandlerContext) (map[string][]byte, error) {
+	diffRawData, err := wsHelpers.ReadDiffractionFile(scanId, hctx.Svcs)
+	if err != nil {
+		return nil, err
+	}
+
+	// No indexes specified means we get all of them
+	detected, err := wsHelpers.GetDetectedDiffractionPeaks([]int32{}, exprPB, diffRawData)
+	if err != nil {
+		return nil, err
+	}
+
+	manual, err := wsHelpers.GetDiffractionPeakManualList(scanId, hctx.Svcs)
+	if err != nil {
+		return nil, err
+	}
+
+	manual, err = filterDiffractionPeakManualList(manual, hctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return wsHelpers.MakeDiffractionPeakCSVs(detected, manual), nil
+}
+
+func makeContextImageExportFiles(req *protos.ExportFilesReq, hctx wsHelpers.HandlerContext) (map[string][]byte, error) {
+	if len(req.ImageFileNames) <= 0 {
+		return nil, errorwithstatus.MakeBadRequestError(errors.New("no images specified for context image export"))
+	}
+
+	result := map[string][]byte{}
+	coll := hctx.Svcs.MongoDB.Collection(dbCollections.ImagesName)
+
+	for _, imageName := range req.ImageFileNames {
+		dbResult := coll.FindOne(context.TODO(), bson.M{"_id": imageName})
+		if dbResult.Err() != nil {
+			if dbResult.Err() == mongo.ErrNoDocuments {
+				return nil, errorwithstatus.MakeNotFoundError(imageName)
+			}
+			return nil, dbResult.Err()
+		}
+
+		img := &protos.ScanImage{}
+		if err := dbResult.Decode(img); err != nil {
+			return nil, err
+		}
+
+		if !utils.ItemInSlice(req.ScanId, img.AssociatedScanIds) {
+			return nil, errorwithstatus.MakeBadRequestError(fmt.Errorf("Image %v is not associated with scan %v", imageName, req.ScanId))
+		}
+
+		if _, err := wsHelpers.CheckOptionalObjectAccess(false, img.ImagePath, protos.ObjectType_OT_IMAGE, hctx); err != nil {
+			return nil, err
+		}
+
+		imgBytes, err := hctx.Svcs.FS.ReadObject(hctx.Svcs.Config.DatasetsBucket, filepaths.GetImageFilePath(img.ImagePath))
+		if err != nil {
+			return nil, fmt.Errorf("Failed to read image %v: %v", imageName, err)
+		}
+
+		// Mark only the beam locations of the scan being exported
+		locs, err := wsHelpers.GetImageBeamLocations(hctx, imageName, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		coords := []*protos.Coordinate2D{}
+		for _, locsForScan := range locs.LocationPerScan {
+			if locsForScan.ScanId == req.ScanId {
+				coords = append(coords, locsForScan.Locations...)
+			}
+		}
+
+		markedBytes, imgFormat, err := wsHelpers.MakeBeamMarkedImage(imgBytes, coords, img.MatchInfo)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to mark beam locations on image %v: %v", imageName, err)
+		}
+
+		ext := "png"
+		if imgFormat == "jpeg" {
+			ext = "jpg"
+		}
+
+		fileName := path.Base(img.ImagePath)
+		result[fileName[0:len(fileName)-len(path.Ext(fileName))]+"-beams."+ext] = markedBytes
+	}
+
+	return result, nil
+}
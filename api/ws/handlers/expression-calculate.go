@@ -14,7 +14,6 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
-	"google.golang.org/protobuf/proto"
 )
 
 func HandleExpressionCalculateReq(req *protos.ExpressionCalculateReq, hctx wsHelpers.HandlerContext) (*protos.ExpressionCalculateResp, error) {
@@ -29,20 +28,11 @@ func HandleExpressionCalculateReq(req *protos.ExpressionCalculateReq, hctx wsHel
 	resultItems := []*protos.RegionDataResultItem{}
 
 	for c, reqItem := range req.Requests {
-		if len(reqItem.ScanId) <= 0 {
-			return nil, errorwithstatus.MakeBadRequestError(fmt.Errorf("Request item %v must have a scan ID", c))
-		}
-		if len(reqItem.QuantId) <= 0 {
-			return nil, errorwithstatus.MakeBadRequestError(fmt.Errorf("Request item %v must have a quant ID", c))
-		}
-		if len(reqItem.ExpressionId) <= 0 {
-			return nil, errorwithstatus.MakeBadRequestError(fmt.Errorf("Request item %v must have an expression ID", c))
-		}
-		if len(reqItem.RoiId) <= 0 {
-			return nil, errorwithstatus.MakeBadRequestError(fmt.Errorf("Request item %v must have a region of interest ID", c))
+		if err := checkExpressionRequestItem(c, reqItem); err != nil {
+			return nil, err
 		}
 
-		cacheKey, err := makeCacheKey(c, reqItem.ScanId, reqItem.QuantId, reqItem.ExpressionId, reqItem.RoiId, reqItem.Units, hctx)
+		cacheKey, exprItem, err := makeCacheKey(c, reqItem.ScanId, reqItem.QuantId, reqItem.ExpressionId, reqItem.RoiId, reqItem.Units, hctx)
 		if err != nil {
 			return nil, err
 		}
@@ -59,13 +49,26 @@ func HandleExpressionCalculateReq(req *protos.ExpressionCalculateReq, hctx wsHel
 			m, totalMs, goMs, err = expressionrunner.RunExpression(reqItem.ExpressionId, reqItem.ScanId, reqItem.QuantId, hctx.Svcs, false, false)
 
 			if err != nil {
+				// If it was stopped for going over one of our limits, report that for this item and carry on with the rest
+				if limit, ok := expressionrunner.GetExpressionLimitExceeded(err); ok {
+					hctx.Svcs.Log.Infof("Expression \"%v\" stopped: %v", reqItem.ExpressionId, err)
+
+					resultItems = append(resultItems, &protos.RegionDataResultItem{
+						Error:         err.Error(),
+						Expression:    exprItem,
+						Query:         reqItem,
+						LimitExceeded: limit,
+					})
+					continue
+				}
+
 				return nil, errorwithstatus.MakeBadRequestError(fmt.Errorf("Failed to run expression %v: %v", reqItem.ExpressionId, err))
 			}
 
 			hctx.Svcs.Log.Infof("Expression \"%v\" took total %vms (%vms in Go runtime)", reqItem.ExpressionId, totalMs, goMs)
 
 			// Memoise it!
-			_, memData, err := memoise(cacheKey, reqItem.ScanId, reqItem.QuantId, reqItem.ExpressionId, reqItem.RoiId, hctx.SessUser.User.Id, m, hctx)
+			_, memData, err := expressionrunner.Memoise(cacheKey, reqItem.ScanId, reqItem.QuantId, exprItem, hctx.SessUser.User.Id, m, hctx.Svcs)
 
 			if err != nil {
 				return nil, errorwithstatus.MakeBadRequestError(fmt.Errorf("Failed to memoise expression result for %v: %v", reqItem.ExpressionId, err))
@@ -110,99 +113,65 @@ func HandleExpressionCalculateReq(req *protos.ExpressionCalculateReq, hctx wsHel
 	}, nil
 }
 
-// Written to match fromMemoised() in client code
-func fromMemoised(data []byte) (*protos.MemDataQueryResult, error) {
-	memResult := &protos.MemDataQueryResult{}
-	err := proto.Unmarshal(data, memResult)
+// Runs the expressions as a job, so the client doesn't have to wait on a request while possibly slow expressions run.
+// Results are memoised as they're calculated, so once the job completes, they can be read with ExpressionCalculateReq
+func HandleExpressionBatchCalculateReq(req *protos.ExpressionBatchCalculateReq, hctx wsHelpers.HandlerContext) (*protos.ExpressionBatchCalculateResp, error) {
+	if len(req.Requests) <= 0 {
+		return nil, errorwithstatus.MakeBadRequestError(errors.New("Expected at least one request item"))
+	}
+
+	if hctx.SessUser.User.Id == "" {
+		return nil, errorwithstatus.MakeBadRequestError(errors.New("User must be logged in"))
+	}
+
+	for c, reqItem := range req.Requests {
+		if err := checkExpressionRequestItem(c, reqItem); err != nil {
+			return nil, err
+		}
+
+		// Make sure the user has access to what they're asking us to run. Job nodes don't check this
+		_, _, err := makeCacheKey(c, reqItem.ScanId, reqItem.QuantId, reqItem.ExpressionId, reqItem.RoiId, reqItem.Units, hctx)
+		if err != nil {
+			return nil, err
+		}
+	}
 
+	status, err := hctx.Svcs.JobManager.SubmitExpressionBatchJob(req.Requests, &hctx.SessUser, hctx.Session)
 	if err != nil {
 		return nil, err
 	}
 
-	// NOTE: we read the same MemDataQueryResult structure as we return
-	return memResult, nil
+	return &protos.ExpressionBatchCalculateResp{Status: status}, nil
 }
 
-// Written to match toMemoised() in client code
-func toMemoised(m *expressionrunner.PMCDataValues, expr *protos.DataExpression) (*protos.MemDataQueryResult, []byte, error) {
-	// We copy to protobuf structs which then serialise to binary
-	// NOTE: This is an experiment, if it works, maybe we'll switch all code to use these structs!
-	memValues := []*protos.MemPMCDataValue{}
-
-	for _, val := range m.Values {
-		memValues = append(memValues, &protos.MemPMCDataValue{
-			Pmc:         uint32(val.PMC),
-			Value:       float32(val.Value),
-			IsUndefined: val.IsUndefined,
-			Label:       val.Label,
-		})
+func checkExpressionRequestItem(resultIdx int, reqItem *protos.DataSourceParams) error {
+	if len(reqItem.ScanId) <= 0 {
+		return errorwithstatus.MakeBadRequestError(fmt.Errorf("Request item %v must have a scan ID", resultIdx))
 	}
-
-	//memPixelIndexSet := result.region ? Array.from(result.region.pixelIndexSet) : [];
-
-	memResult := &protos.MemDataQueryResult{
-		ResultValues: &protos.MemPMCDataValues{
-			MinValue: float32(*m.ValueRange.Min),
-			MaxValue: float32(*m.ValueRange.Max),
-			Values:   memValues,
-			IsBinary: m.IsBinary,
-			Warning:  m.Warning,
-		},
-		IsPMCTable: true,
+	if len(reqItem.QuantId) <= 0 {
+		return errorwithstatus.MakeBadRequestError(fmt.Errorf("Request item %v must have a quant ID", resultIdx))
 	}
-
-	if expr != nil {
-		memResult.Expression = expr
+	if len(reqItem.ExpressionId) <= 0 {
+		return errorwithstatus.MakeBadRequestError(fmt.Errorf("Request item %v must have an expression ID", resultIdx))
+	}
+	if len(reqItem.RoiId) <= 0 {
+		return errorwithstatus.MakeBadRequestError(fmt.Errorf("Request item %v must have a region of interest ID", resultIdx))
 	}
-	/*
-		if (result.region) {
-			memResult.region = MemRegionSettings.create({
-			region: result.region.region,
-			displaySettings: ROIItemDisplaySettings.create({
-				colour: result.region.displaySettings.colour.asString(),
-				shape: result.region.displaySettings.shape,
-			}),
-			pixelIndexSet: memPixelIndexSet,
-			});
-		}*/
-
-	b, err := proto.Marshal(memResult)
-	return memResult, b, err
+	return nil
 }
 
-func makeCacheKey(resultIdx int, scanId, quantId, expressionId, roiId string, units protos.DataUnit, hctx wsHelpers.HandlerContext) (string, error) {
-	// Keys are of the form:
-	// {"scanId":"602735105","exprId":"q2ns80oc4452eldt","quantId":"quant-aqpxxfk6i05gcsy3","roiId":"AllPoints-602735105","units":0},Resp:false,exprMod:1772129285,spectra:3298,90,0
-	// So we need scan summary details and the expression last modified time
+func makeCacheKey(resultIdx int, scanId, quantId, expressionId, roiId string, units protos.DataUnit, hctx wsHelpers.HandlerContext) (string, *protos.DataExpression, error) {
 	scanItem, _, err := wsHelpers.GetUserObjectById[protos.ScanItem](true, scanId, protos.ObjectType_OT_SCAN, dbCollections.ScansName, hctx)
 	if err != nil {
-		return "", fmt.Errorf("Failed to read scan item for reqItem %v (%v): %v", resultIdx, scanId, err)
+		return "", nil, fmt.Errorf("Failed to read scan item for reqItem %v (%v): %v", resultIdx, scanId, err)
 	}
 
 	exprItem, _, err := wsHelpers.GetUserObjectById[protos.DataExpression](false, expressionId, protos.ObjectType_OT_EXPRESSION, dbCollections.ExpressionsName, hctx)
 	if err != nil {
-		return "", fmt.Errorf("Failed to read expression for reqItem %v (%v): %v", resultIdx, expressionId, err)
+		return "", nil, fmt.Errorf("Failed to read expression for reqItem %v (%v): %v", resultIdx, expressionId, err)
 	}
 
-	normalSpectraCount := scanItem.ContentCounts["NormalSpectra"]
-	dwellSpectraCount := scanItem.ContentCounts["DwellSpectra"]
-
-	spectrumTimeStamp := 0 // Comes from SpectrumResp.timeStampUnixSec, seems to always be 0 for now??
-
-	memCacheKey := fmt.Sprintf(
-		`{"scanId":"%v","exprId":"%v","quantId":"%v","roiId":"%v","units":%v},Resp:false,exprMod:%v,spectra:%v,%v,%v`,
-		scanId,
-		expressionId,
-		quantId,
-		roiId,
-		units.Number(),
-		exprItem.ModifiedUnixSec,
-		normalSpectraCount,
-		dwellSpectraCount,
-		spectrumTimeStamp,
-	)
-
-	return memCacheKey, nil
+	return expressionrunner.MakeMemoisationKey(scanItem, exprItem, quantId, roiId, units), exprItem, nil
 }
 
 func memoItemAgePastMax(item *protos.MemoisedItem, svcs *services.APIServices) int64 {
@@ -220,46 +189,6 @@ func memoItemAgePastMax(item *protos.MemoisedItem, svcs *services.APIServices) i
 	return ageTooOldSec
 }
 
-func memoise(memCacheKey string, scanId, quantId, expressionId, roiId, requestorUserId string, m *expressionrunner.PMCDataValues, hctx wsHelpers.HandlerContext) (*protos.MemoisedItem, *protos.MemDataQueryResult, error) {
-	exprItem, _, err := wsHelpers.GetUserObjectById[protos.DataExpression](false, expressionId, protos.ObjectType_OT_EXPRESSION, dbCollections.ExpressionsName, hctx)
-	if err != nil {
-		return nil, nil, fmt.Errorf("Failed to read expression: %v. Error: %v", expressionId, err)
-	}
-
-	memResult, data, err := toMemoised(m, exprItem)
-	if err != nil {
-		return nil, nil, fmt.Errorf("Failed to create memoise item for expression: %v. Error: %v", expressionId, err)
-	}
-
-	ctx := context.TODO()
-	coll := hctx.Svcs.MongoDB.Collection(dbCollections.MemoisedItemsName)
-	opt := options.Update().SetUpsert(true)
-
-	timestamp := uint32(hctx.Svcs.TimeStamper.GetTimeNowSec())
-	item := &protos.MemoisedItem{
-		Key:                 memCacheKey,
-		MemoTimeUnixSec:     timestamp,
-		Data:                data,
-		ScanId:              scanId,
-		QuantId:             quantId,
-		ExprId:              expressionId,
-		DataSize:            uint32(len(data)),
-		LastReadTimeUnixSec: timestamp, // Right now this is the last time it was accessed. To be updated in future get calls
-		MemoWriterUserId:    requestorUserId,
-	}
-
-	result, err := coll.UpdateByID(ctx, memCacheKey, bson.D{{Key: "$set", Value: item}}, opt)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	if result.UpsertedCount == 0 && (result.MatchedCount != result.ModifiedCount) {
-		hctx.Svcs.Log.Errorf("memoise expression result for: %v got unexpected DB write result: %+v", memCacheKey, result)
-	}
-
-	return item, memResult, nil
-}
-
 func readExpressionResult(resultIdx int, memCacheKey string, hctx wsHelpers.HandlerContext) (*protos.RegionDataResultItem, error) {
 	// NOTE: We just find the memoised key of the latest expression version and looking it up. If it's not pre-computed we return an error
 
@@ -286,7 +215,7 @@ func readExpressionResult(resultIdx int, memCacheKey string, hctx wsHelpers.Hand
 	}
 
 	// Decode its embedded data
-	memResult, err := fromMemoised(memItem.Data)
+	memResult, err := expressionrunner.FromMemoised(memItem.Data)
 	if err != nil {
 		return nil, fmt.Errorf("Failed to read memoised data for reqItem %v (%v): %v", resultIdx, memCacheKey, err)
 	}
@@ -0,0 +1,74 @@
+package wsHandler
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/pixlise/core/v4/api/dbCollections"
+	expressionrunner "github.com/pixlise/core/v4/api/job/jobrunner/expression-runner"
+	"github.com/pixlise/core/v4/api/ws/wsHelpers"
+	"github.com/pixlise/core/v4/core/errorwithstatus"
+	protos "github.com/pixlise/core/v4/generated-protos"
+)
+
+// Starts running an expression in a debug session. The expression runs in the background, and the client is sent
+// ExpressionDebugUpd messages as it pauses and when it ends
+func HandleExpressionDebugStartReq(req *protos.ExpressionDebugStartReq, hctx wsHelpers.HandlerContext) (*protos.ExpressionDebugStartResp, error) {
+	if len(req.ExpressionId) <= 0 {
+		return nil, errorwithstatus.MakeBadRequestError(errors.New("Expression ID must be specified"))
+	}
+	if len(req.ScanId) <= 0 {
+		return nil, errorwithstatus.MakeBadRequestError(errors.New("Scan ID must be specified"))
+	}
+
+	if hctx.SessUser.User.Id == "" {
+		return nil, errorwithstatus.MakeBadRequestError(errors.New("User must be logged in"))
+	}
+
+	// Make sure the user has access to what they're asking us to run
+	_, _, err := wsHelpers.GetUserObjectById[protos.ScanItem](false, req.ScanId, protos.ObjectType_OT_SCAN, dbCollections.ScansName, hctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(req.QuantId) > 0 {
+		_, _, err = wsHelpers.GetUserObjectById[protos.QuantificationSummary](false, req.QuantId, protos.ObjectType_OT_QUANTIFICATION, dbCollections.QuantificationsName, hctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	exprItem, _, err := wsHelpers.GetUserObjectById[protos.DataExpression](false, req.ExpressionId, protos.ObjectType_OT_EXPRESSION, dbCollections.ExpressionsName, hctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if exprItem.SourceLanguage != "LUA" {
+		return nil, errorwithstatus.MakeBadRequestError(fmt.Errorf("Expression %v is not Lua", req.ExpressionId))
+	}
+
+	session := hctx.Session
+	sessionId, err := expressionrunner.StartDebugSession(req.ExpressionId, req.ScanId, req.QuantId, hctx.SessUser.User.Id, hctx.SessUser.SessionId, req.Breakpoints, req.PauseOnStart, hctx.Svcs,
+		func(upd *protos.ExpressionDebugUpd) {
+			wsHelpers.SendForSession(session, &protos.WSMessage{Contents: &protos.WSMessage_ExpressionDebugUpd{ExpressionDebugUpd: upd}})
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &protos.ExpressionDebugStartResp{SessionId: sessionId}, nil
+}
+
+func HandleExpressionDebugCommandReq(req *protos.ExpressionDebugCommandReq, hctx wsHelpers.HandlerContext) (*protos.ExpressionDebugCommandResp, error) {
+	if len(req.SessionId) <= 0 {
+		return nil, errorwithstatus.MakeBadRequestError(errors.New("Session ID must be specified"))
+	}
+
+	err := expressionrunner.SendDebugCommand(req.SessionId, hctx.SessUser.User.Id, req.Command, req.SetBreakpoints, req.Breakpoints)
+	if err != nil {
+		return nil, err
+	}
+
+	return &protos.ExpressionDebugCommandResp{}, nil
+}
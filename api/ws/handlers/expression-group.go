@@ -14,6 +14,7 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.mongodb.org/mongo-driver/mongo/readconcern"
 	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+	"google.golang.org/protobuf/proto"
 )
 
 func HandleExpressionGroupDeleteReq(req *protos.ExpressionGroupDeleteReq, hctx wsHelpers.HandlerContext) (*protos.ExpressionGroupDeleteResp, error) {
@@ -141,6 +142,9 @@ func createExpressionGroup(egroup *protos.ExpressionGroup, hctx wsHelpers.Handle
 		return nil, err
 	}
 
+	wsHelpers.WriteAuditEntry(egroup.Id, protos.ObjectType_OT_EXPRESSION_GROUP, protos.AuditAction_AA_CREATE, nil, egroup, hctx)
+	wsHelpers.SaveObjectRevision(egroup.Id, protos.ObjectType_OT_EXPRESSION_GROUP, 0, hctx)
+
 	egroup.Owner = wsHelpers.MakeOwnerSummary(ownerItem, hctx.SessUser, hctx.Svcs.MongoDB, hctx.Svcs.TimeStamper)
 	return egroup, nil
 }
@@ -153,6 +157,9 @@ func updateExpressionGroup(egroup *protos.ExpressionGroup, hctx wsHelpers.Handle
 		return nil, err
 	}
 
+	// Keep what it looked like for the audit log
+	dbItemBefore := proto.Clone(dbItem)
+
 	// Update fields
 	update := bson.D{}
 	if len(egroup.Name) > 0 {
@@ -195,6 +202,9 @@ func updateExpressionGroup(egroup *protos.ExpressionGroup, hctx wsHelpers.Handle
 		hctx.Svcs.Log.Errorf("ExpresssionGroup UpdateByID result had unexpected counts %+v id: %v", result, egroup.Id)
 	}
 
+	wsHelpers.AuditObjectUpdate(egroup.Id, protos.ObjectType_OT_EXPRESSION_GROUP, dbCollections.ExpressionGroupsName, dbItemBefore, hctx)
+	wsHelpers.SaveObjectRevision(egroup.Id, protos.ObjectType_OT_EXPRESSION_GROUP, 0, hctx)
+
 	// Return the merged item we validated, which in theory is in the DB now
 	dbItem.Owner = wsHelpers.MakeOwnerSummary(owner, hctx.SessUser, hctx.Svcs.MongoDB, hctx.Svcs.TimeStamper)
 	return dbItem, nil
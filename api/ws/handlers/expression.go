@@ -3,17 +3,21 @@ package wsHandler
 import (
 	"context"
 	"errors"
+	"fmt"
 	"sort"
 
 	"github.com/pixlise/core/v4/api/dbCollections"
+	expressionrunner "github.com/pixlise/core/v4/api/job/jobrunner/expression-runner"
 	"github.com/pixlise/core/v4/api/ws/wsHelpers"
 	"github.com/pixlise/core/v4/core/errorwithstatus"
+	"github.com/pixlise/core/v4/core/utils"
 	protos "github.com/pixlise/core/v4/generated-protos"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.mongodb.org/mongo-driver/mongo/readconcern"
 	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+	"google.golang.org/protobuf/proto"
 )
 
 func HandleExpressionGetReq(req *protos.ExpressionGetReq, hctx wsHelpers.HandlerContext) (*protos.ExpressionGetResp, error) {
@@ -149,6 +153,10 @@ func createExpression(expr *protos.DataExpression, hctx wsHelpers.HandlerContext
 	if err != nil {
 		return nil, err
 	}
+
+	wsHelpers.WriteAuditEntry(expr.Id, protos.ObjectType_OT_EXPRESSION, protos.AuditAction_AA_CREATE, nil, expr, hctx)
+	wsHelpers.SaveObjectRevision(expr.Id, protos.ObjectType_OT_EXPRESSION, 0, hctx)
+
 	expr.Owner = wsHelpers.MakeOwnerSummary(ownerItem, hctx.SessUser, hctx.Svcs.MongoDB, hctx.Svcs.TimeStamper)
 	return expr, nil
 }
@@ -161,6 +169,9 @@ func updateExpression(expr *protos.DataExpression, hctx wsHelpers.HandlerContext
 		return nil, err
 	}
 
+	// Keep what it looked like for the audit log
+	dbItemBefore := proto.Clone(dbItem)
+
 	// Update fields
 	update := bson.D{}
 	if len(expr.Name) > 0 {
@@ -213,6 +224,9 @@ func updateExpression(expr *protos.DataExpression, hctx wsHelpers.HandlerContext
 		hctx.Svcs.Log.Errorf("DataExpression UpdateByID result had unexpected counts %+v id: %v", result, expr.Id)
 	}
 
+	wsHelpers.AuditObjectUpdate(expr.Id, protos.ObjectType_OT_EXPRESSION, dbCollections.ExpressionsName, dbItemBefore, hctx)
+	wsHelpers.SaveObjectRevision(expr.Id, protos.ObjectType_OT_EXPRESSION, 0, hctx)
+
 	// Return the merged item we validated, which in theory is in the DB now
 	dbItem.Owner = wsHelpers.MakeOwnerSummary(owner, hctx.SessUser, hctx.Svcs.MongoDB, hctx.Svcs.TimeStamper)
 	return dbItem, nil
@@ -224,6 +238,14 @@ func HandleExpressionWriteReq(req *protos.ExpressionWriteReq, hctx wsHelpers.Han
 		return nil, errorwithstatus.MakeBadRequestError(errors.New("Owner must be empty for write messages"))
 	}
 
+	// Check the scan we're asked to check against before saving anything, so a bad scan ID doesn't fail the request
+	// after the expression was already written
+	if len(req.CheckScanId) > 0 {
+		if _, err := wsHelpers.CheckObjectAccess(false, req.CheckScanId, protos.ObjectType_OT_SCAN, hctx); err != nil {
+			return nil, err
+		}
+	}
+
 	var item *protos.DataExpression
 	var err error
 
@@ -236,11 +258,84 @@ func HandleExpressionWriteReq(req *protos.ExpressionWriteReq, hctx wsHelpers.Han
 		return nil, err
 	}
 
+	// The expression is saved by now, so failing to work out warnings mustn't fail the request
+	warnings, err := getExpressionWarnings(item, req.CheckScanId, hctx)
+	if err != nil {
+		hctx.Svcs.Log.Errorf("Failed to get warnings for expression %v, scan %v: %v", item.Id, req.CheckScanId, err)
+		warnings = []string{}
+	}
+
 	return &protos.ExpressionWriteResp{
 		Expression: item,
+		Warnings:   warnings,
 	}, nil
 }
 
+// Analyses the expression to find problems that would stop it running, optionally checking that the given scan has
+// everything it reads. Problems with the expression itself are returned as warnings, because it's saved regardless
+func getExpressionWarnings(expr *protos.DataExpression, checkScanId string, hctx wsHelpers.HandlerContext) ([]string, error) {
+	warnings := []string{}
+
+	// We can only analyse Lua
+	if expr.SourceLanguage != "LUA" {
+		return warnings, nil
+	}
+
+	deps, err := expressionrunner.AnalyseExpression(expr, hctx.Svcs)
+	if err != nil {
+		return append(warnings, fmt.Sprintf("Failed to analyse expression: %v", err)), nil
+	}
+
+	for _, name := range deps.UndefinedGlobals {
+		warnings = append(warnings, fmt.Sprintf("Global \"%v\" is read but never defined", name))
+	}
+
+	if len(checkScanId) <= 0 {
+		return warnings, nil
+	}
+
+	scanItem, _, err := wsHelpers.GetUserObjectById[protos.ScanItem](false, checkScanId, protos.ObjectType_OT_SCAN, dbCollections.ScansName, hctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if deps.UsesSpectra && scanItem.ContentCounts["NormalSpectra"] <= 0 {
+		warnings = append(warnings, fmt.Sprintf("Expression reads spectra but scan %v has none", checkScanId))
+	}
+
+	if len(deps.HousekeepingColumns) > 0 || len(deps.PseudoIntensities) > 0 {
+		dataset, err := wsHelpers.ReadDatasetFile(checkScanId, hctx.Svcs, true)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, col := range deps.HousekeepingColumns {
+			if !utils.ItemInSlice(col, dataset.MetaLabels) {
+				warnings = append(warnings, fmt.Sprintf("Expression reads housekeeping column \"%v\" which scan %v doesn't have", col, checkScanId))
+			}
+		}
+
+		pseudoNames := []string{}
+		for _, item := range dataset.PseudoIntensityRanges {
+			pseudoNames = append(pseudoNames, item.Name)
+		}
+
+		for _, name := range deps.PseudoIntensities {
+			if !utils.ItemInSlice(name, pseudoNames) {
+				warnings = append(warnings, fmt.Sprintf("Expression reads pseudo-intensity \"%v\" which scan %v doesn't have", name, checkScanId))
+			}
+		}
+	}
+
+	if deps.UsesDiffraction {
+		if _, err := wsHelpers.ReadDiffractionFile(checkScanId, hctx.Svcs); err != nil {
+			warnings = append(warnings, fmt.Sprintf("Expression reads diffraction data but it couldn't be read for scan %v", checkScanId))
+		}
+	}
+
+	return warnings, nil
+}
+
 func HandleExpressionWriteExecStatReq(req *protos.ExpressionWriteExecStatReq, hctx wsHelpers.HandlerContext) (*protos.ExpressionWriteExecStatResp, error) {
 	// Validate request
 	if err := wsHelpers.CheckStringField(&req.Id, "Id", 0, wsHelpers.IdFieldMaxLength); err != nil {
@@ -60,6 +60,11 @@ func HandleImageBeamLocationsReq(req *protos.ImageBeamLocationsReq, hctx wsHelpe
 			return nil, err
 		}
 
+		_, err = wsHelpers.CheckOptionalObjectAccess(false, req.ImageName, protos.ObjectType_OT_IMAGE, hctx)
+		if err != nil {
+			return nil, err
+		}
+
 		locs, err = wsHelpers.GetImageBeamLocations(hctx, req.ImageName, req.ScanBeamVersions)
 		if err != nil {
 			return nil, err
@@ -93,6 +98,11 @@ func HandleImageBeamLocationVersionsReq(req *protos.ImageBeamLocationVersionsReq
 	}
 	wsHelpers.FixScanImageFileSize(&img)
 
+	_, err = wsHelpers.CheckOptionalObjectAccess(false, img.ImagePath, protos.ObjectType_OT_IMAGE, hctx)
+	if err != nil {
+		return nil, err
+	}
+
 	// Read the image, and follow the matched image link if there is one
 	imageForBeamRead := req.ImageName
 	if img.MatchInfo != nil && len(img.MatchInfo.BeamImageFileName) > 0 {
@@ -169,6 +179,11 @@ func HandleImageBeamLocationUploadReq(req *protos.ImageBeamLocationUploadReq, hc
 	}
 	wsHelpers.FixScanImageFileSize(&img)
 
+	_, err = wsHelpers.CheckOptionalObjectAccess(true, img.ImagePath, protos.ObjectType_OT_IMAGE, hctx)
+	if err != nil {
+		return nil, err
+	}
+
 	// NOTE: Once beam location saving works, we will update the "associated scans" list for the image!!
 
 	// Check that the scan ID is valid too
@@ -39,6 +39,32 @@ func HandleImagePyramidGetReq(req *protos.ImagePyramidGetReq, hctx wsHelpers.Han
 		hctx.Svcs.Log.Errorf("Unexpected image pyramid id: %v in pyramid %v", pyramid.Id, req.Id)
 	}
 
+	// The user needs access to the images the pyramid is for, and the scans those are associated with
+	cursor, err := hctx.Svcs.MongoDB.Collection(dbCollections.ImagesName).Find(ctx, bson.M{"pyramidid": req.Id})
+	if err != nil {
+		return nil, err
+	}
+
+	images := []*protos.ScanImage{}
+	err = cursor.All(ctx, &images)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, img := range images {
+		_, err = wsHelpers.CheckOptionalObjectAccess(false, img.ImagePath, protos.ObjectType_OT_IMAGE, hctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, scanId := range img.AssociatedScanIds {
+			_, err = wsHelpers.CheckObjectAccess(false, scanId, protos.ObjectType_OT_SCAN, hctx)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	return &protos.ImagePyramidGetResp{Image: pyramid.Pyramid}, nil
 }
 
@@ -61,8 +61,26 @@ func HandleImageListReq(req *protos.ImageListReq, hctx wsHelpers.HandlerContext)
 		return nil, err
 	}
 
+	// Images with sharing set are only listed if they're shared with the user
+	imagePaths := []string{}
+	for _, item := range latestItems {
+		imagePaths = append(imagePaths, item.ImagePath)
+	}
+
+	visibleIds, err := wsHelpers.FilterOptionalOwnershipIDs(imagePaths, protos.ObjectType_OT_IMAGE, hctx)
+	if err != nil {
+		return nil, err
+	}
+
+	visibleItems := []*protos.ScanImage{}
+	for _, item := range latestItems {
+		if visibleIds[item.ImagePath] {
+			visibleItems = append(visibleItems, item)
+		}
+	}
+
 	return &protos.ImageListResp{
-		Images: latestItems,
+		Images: visibleItems,
 	}, nil
 }
 
@@ -100,6 +118,11 @@ func HandleImageGetReq(req *protos.ImageGetReq, hctx wsHelpers.HandlerContext) (
 
 	latestItem := latestItems[0]
 
+	_, err = wsHelpers.CheckOptionalObjectAccess(false, latestItem.ImagePath, protos.ObjectType_OT_IMAGE, hctx)
+	if err != nil {
+		return nil, err
+	}
+
 	// Now look up any associated ids
 	if len(latestItem.AssociatedScanIds) <= 0 {
 		return nil, fmt.Errorf("Failed to find scan associated with image: %v", req.ImageName)
@@ -227,6 +250,11 @@ func HandleImageDeleteReq(req *protos.ImageDeleteReq, hctx wsHelpers.HandlerCont
 		return nil, err
 	}
 
+	_, err := wsHelpers.CheckOptionalObjectAccess(true, req.Name, protos.ObjectType_OT_IMAGE, hctx)
+	if err != nil {
+		return nil, err
+	}
+
 	ctx := context.TODO()
 
 	// Get image meta so we have all info we need
@@ -241,7 +269,7 @@ func HandleImageDeleteReq(req *protos.ImageDeleteReq, hctx wsHelpers.HandlerCont
 	}
 
 	img := protos.ScanImage{}
-	err := result.Decode(&img)
+	err = result.Decode(&img)
 	if err != nil {
 		return nil, err
 	}
@@ -306,6 +334,11 @@ func HandleImageDeleteReq(req *protos.ImageDeleteReq, hctx wsHelpers.HandlerCont
 
 	//Verify delImgResult.DeletedCount == 1 ???
 
+	err = wsHelpers.DeleteOptionalOwnership(req.Name, hctx)
+	if err != nil {
+		hctx.Svcs.Log.Errorf("Delete image %v - failed to delete ownership item: %v", req.Name, err)
+	}
+
 	// Finally, update the scan if needed
 	err = wsHelpers.UpdateScanImageDataTypes(img.OriginScanId, hctx.Svcs.MongoDB, hctx.Svcs.Log)
 	if err != nil {
@@ -357,6 +390,11 @@ func HandleImageSetMatchTransformReq(req *protos.ImageSetMatchTransformReq, hctx
 	}
 	wsHelpers.FixScanImageFileSize(&img)
 
+	_, err = wsHelpers.CheckOptionalObjectAccess(true, img.ImagePath, protos.ObjectType_OT_IMAGE, hctx)
+	if err != nil {
+		return nil, err
+	}
+
 	// Now look up any associated ids
 	if len(img.AssociatedScanIds) <= 0 {
 		return nil, fmt.Errorf("Failed to find scan associated with image: %v", req.ImageName)
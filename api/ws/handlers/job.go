@@ -2,11 +2,14 @@ package wsHandler
 
 import (
 	"context"
+	"errors"
 
 	"github.com/pixlise/core/v4/api/dbCollections"
 	"github.com/pixlise/core/v4/api/ws/wsHelpers"
+	"github.com/pixlise/core/v4/core/errorwithstatus"
 	protos "github.com/pixlise/core/v4/generated-protos"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
@@ -51,3 +54,37 @@ func HandleJobListReq(req *protos.JobListReq, hctx wsHelpers.HandlerContext) (*p
 		Jobs: itemsToSend,
 	}, nil
 }
+
+func HandleJobCancelReq(req *protos.JobCancelReq, hctx wsHelpers.HandlerContext) (*protos.JobCancelResp, error) {
+	if err := wsHelpers.CheckStringField(&req.JobId, "JobId", 1, wsHelpers.IdFieldMaxLength*2); err != nil {
+		return nil, err
+	}
+
+	// Only admins or whoever started the job can cancel it
+	ctx := context.TODO()
+	coll := hctx.Svcs.MongoDB.Collection(dbCollections.JobStatusName)
+
+	result := coll.FindOne(ctx, bson.M{"_id": req.JobId})
+	if result.Err() != nil {
+		if result.Err() == mongo.ErrNoDocuments {
+			return nil, errorwithstatus.MakeNotFoundError(req.JobId)
+		}
+		return nil, result.Err()
+	}
+
+	status := &protos.JobStatus{}
+	if err := result.Decode(status); err != nil {
+		return nil, err
+	}
+
+	isAdmin := hctx.SessUser.Permissions["PIXLISE_ADMIN"]
+	if !isAdmin && status.RequestorUserId != hctx.SessUser.User.Id {
+		return nil, errorwithstatus.MakeUnauthorisedError(errors.New("Only the user who started this job can cancel it"))
+	}
+
+	if err := hctx.Svcs.JobManager.CancelJob(req.JobId); err != nil {
+		return nil, err
+	}
+
+	return &protos.JobCancelResp{}, nil
+}
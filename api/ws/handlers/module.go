@@ -17,6 +17,7 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.mongodb.org/mongo-driver/mongo/readconcern"
 	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+	"google.golang.org/protobuf/proto"
 )
 
 func HandleDataModuleGetReq(req *protos.DataModuleGetReq, hctx wsHelpers.HandlerContext) (*protos.DataModuleGetResp, error) {
@@ -293,6 +294,8 @@ func createModule(name string, comments string, intialSourceCode string, tags []
 		return nil, err
 	}
 
+	wsHelpers.WriteAuditEntry(module.Id, protos.ObjectType_OT_DATA_MODULE, protos.AuditAction_AA_CREATE, nil, module, hctx)
+
 	// Make the return struct
 	moduleWire := &protos.DataModule{
 		Id:              module.Id,
@@ -322,6 +325,9 @@ func updateModule(id string, name string, comments string, hctx wsHelpers.Handle
 		return nil, err
 	}
 
+	// Keep what it looked like for the audit log
+	dbItemBefore := proto.Clone(dbItem)
+
 	// Update fields
 	update := bson.D{}
 	if len(name) > 0 {
@@ -354,6 +360,8 @@ func updateModule(id string, name string, comments string, hctx wsHelpers.Handle
 		hctx.Svcs.Log.Errorf("DataModule UpdateByID result had unexpected counts %+v id: %v", dbResult, id)
 	}
 
+	wsHelpers.AuditObjectUpdate(id, protos.ObjectType_OT_DATA_MODULE, dbCollections.ModulesName, dbItemBefore, hctx)
+
 	// Return the merged item we validated, which in theory is in the DB now
 	result := &protos.DataModule{
 		Id:              dbItem.Id,
@@ -480,6 +488,9 @@ func HandleDataModuleAddVersionReq(req *protos.DataModuleAddVersionReq, hctx wsH
 		hctx.Svcs.Log.Errorf("CreateModule (version): Expected Mongo insert to return ID %v, got %v", verId, insertResult.InsertedID)
 	}
 
+	// New versions are logged as an update of the module, with the version record as what changed
+	wsHelpers.WriteAuditEntry(req.ModuleId, protos.ObjectType_OT_DATA_MODULE, protos.AuditAction_AA_UPDATE, nil, verRec, hctx)
+
 	// Add all previous versions
 	versions, err := getModuleVersions(req.ModuleId, hctx.Svcs.MongoDB)
 	if err != nil {
@@ -2,6 +2,7 @@ package wsHandler
 
 import (
 	"context"
+	"net/http"
 
 	"github.com/pixlise/core/v4/api/dbCollections"
 	"github.com/pixlise/core/v4/api/sessionuser"
@@ -12,6 +13,7 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/protobuf/proto"
 )
 
 func HandleGetOwnershipReq(req *protos.GetOwnershipReq, hctx wsHelpers.HandlerContext) (*protos.GetOwnershipResp, error) {
@@ -60,6 +62,15 @@ func HandleObjectEditAccessReq(req *protos.ObjectEditAccessReq, hctx wsHelpers.H
 
 	// Determine if we have edit access to the object
 	owner, err := wsHelpers.CheckObjectAccess(true, req.ObjectId, req.ObjectType, hctx)
+	if err != nil && wsHelpers.IsOptionalOwnershipType(req.ObjectType) {
+		if e, ok := err.(errorwithstatus.Error); ok && e.Status() == http.StatusNotFound {
+			// First time sharing is set on this object, create its ownership item
+			owner, err = wsHelpers.ClaimOptionalOwnership(req.ObjectId, req.ObjectType, hctx)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
 	if err != nil {
 		owner, err = wsHelpers.CheckObjectAccess(false, req.ObjectId, req.ObjectType, hctx)
 		if err != nil {
@@ -133,6 +144,8 @@ func HandleObjectEditAccessReq(req *protos.ObjectEditAccessReq, hctx wsHelpers.H
 		hctx.Svcs.Log.Errorf("Ownership UpdateByID result had unexpected counts %+v id: %v, type: %v", result, req.ObjectId, req.ObjectType.String())
 	}
 
+	ownerBefore := proto.Clone(owner)
+
 	if owner.Editors == nil {
 		owner.Editors = &protos.UserGroupList{}
 	}
@@ -146,6 +159,8 @@ func HandleObjectEditAccessReq(req *protos.ObjectEditAccessReq, hctx wsHelpers.H
 	owner.Viewers.UserIds = viewerUserIds
 	owner.Viewers.GroupIds = viewerGroupsIds
 
+	wsHelpers.WriteAuditEntry(req.ObjectId, req.ObjectType, protos.AuditAction_AA_SHARE, ownerBefore, owner, hctx)
+
 	return &protos.ObjectEditAccessResp{
 		Ownership: owner,
 	}, nil
@@ -0,0 +1,51 @@
+package wsHandler
+
+import (
+	"errors"
+
+	"github.com/pixlise/core/v4/api/quantification"
+	"github.com/pixlise/core/v4/api/ws/wsHelpers"
+	"github.com/pixlise/core/v4/core/errorwithstatus"
+	protos "github.com/pixlise/core/v4/generated-protos"
+)
+
+func HandlePiquantRegressionReq(req *protos.PiquantRegressionReq, hctx wsHelpers.HandlerContext) (*protos.PiquantRegressionResp, error) {
+	if req.Params == nil {
+		return nil, errorwithstatus.MakeBadRequestError(errors.New("Params not supplied"))
+	}
+
+	session := hctx.Session
+	status, err := quantification.CreateRegressionJob(req.Params, hctx.SessUser.User.Id, hctx.Svcs, func(status *protos.JobStatus) {
+		if session == nil {
+			return
+		}
+
+		wsUpd := protos.WSMessage{
+			Contents: &protos.WSMessage_PiquantRegressionUpd{
+				PiquantRegressionUpd: &protos.PiquantRegressionUpd{
+					Status: status,
+				},
+			},
+		}
+
+		wsHelpers.SendForSession(session, &wsUpd)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &protos.PiquantRegressionResp{Status: status}, nil
+}
+
+func HandlePiquantRegressionResultReq(req *protos.PiquantRegressionResultReq, hctx wsHelpers.HandlerContext) (*protos.PiquantRegressionResultResp, error) {
+	if err := wsHelpers.CheckStringField(&req.JobId, "JobId", 1, wsHelpers.IdFieldMaxLength); err != nil {
+		return nil, err
+	}
+
+	summary, err := quantification.ReadRegressionSummary(req.JobId, hctx.Svcs.MongoDB)
+	if err != nil {
+		return nil, err
+	}
+
+	return &protos.PiquantRegressionResultResp{Summary: summary}, nil
+}
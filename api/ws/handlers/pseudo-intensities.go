@@ -5,11 +5,12 @@ import (
 	"strconv"
 
 	"github.com/pixlise/core/v4/api/ws/wsHelpers"
+	"github.com/pixlise/core/v4/core/scan"
 	protos "github.com/pixlise/core/v4/generated-protos"
 )
 
 func HandlePseudoIntensityReq(req *protos.PseudoIntensityReq, hctx wsHelpers.HandlerContext) (*protos.PseudoIntensityResp, error) {
-	exprPB, indexes, err := beginDatasetFileReqForRange(req.ScanId, req.Entries, hctx)
+	exprPB, indexes, err := beginDatasetFileReqForRange(req.ScanId, req.Entries, scan.DataKindPseudoIntensities, hctx)
 	if err != nil {
 		return nil, err
 	}
@@ -52,10 +52,10 @@ func legacyHandleQuantCreateReq(req *protos.QuantCreateReq, hctx wsHelpers.Handl
 
 	updater := i.SendQuantJobUpdate
 	if req.Params.Command != "map" {
-		updater = i.SendEphemeralQuantJobUpdate
+		updater = func(status *protos.JobStatus) { i.SendEphemeralQuantJobUpdate(status, nil) }
 	}
 
-	status, err := quantification.CreateJob(req.Params, hctx.SessUser.User.Id, hctx.Svcs, &hctx.SessUser, updater)
+	status, err := quantification.CreateJob(req.Params, hctx.SessUser.User.Id, hctx.Svcs, &hctx.SessUser, updater, i.SendEphemeralQuantJobUpdate)
 
 	if err != nil {
 		return nil, err
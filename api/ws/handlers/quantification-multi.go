@@ -110,6 +110,31 @@ func HandleMultiQuantCompareReq(req *protos.MultiQuantCompareReq, hctx wsHelpers
 	}, nil
 }
 
+func HandleQuantDiffReq(req *protos.QuantDiffReq, hctx wsHelpers.HandlerContext) (*protos.QuantDiffResp, error) {
+	// req.ScanId is checked in beginDatasetFileReqForRange
+
+	// We only need the location PMCs to look up ROI PMCs, so don't read any of the per-location data
+	exprPB, _, err := beginDatasetFileReqForRange(req.ScanId, nil, 0, hctx)
+	if err != nil {
+		return nil, err
+	}
+
+	comparisons, err := quantification.QuantDiff(req.ScanId, req.QuantIds, req.RoiIds, req.OutlierThreshold, req.IncludePMCDeltas, exprPB, hctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &protos.QuantDiffResp{Comparisons: comparisons}
+	if req.IncludeCSV {
+		resp.Csv = quantification.FormQuantDiffCSV(comparisons)
+		if req.IncludePMCDeltas {
+			resp.PmcDeltasCSV = quantification.FormQuantDiffPMCDeltasCSV(comparisons)
+		}
+	}
+
+	return resp, nil
+}
+
 func HandleQuantCombineReq(req *protos.QuantCombineReq, hctx wsHelpers.HandlerContext) (*protos.QuantCombineResp, error) {
 	// Simple validation
 
@@ -62,8 +62,25 @@ func HandleReferenceDataListReq(req *protos.ReferenceDataListReq, hctx wsHelpers
 		return nil, err
 	}
 
+	ids := []string{}
+	for _, item := range items {
+		ids = append(ids, item.Id)
+	}
+
+	visibleIds, err := wsHelpers.FilterOptionalOwnershipIDs(ids, protos.ObjectType_OT_REFERENCE_DATA, hctx)
+	if err != nil {
+		return nil, err
+	}
+
+	visibleItems := []*protos.ReferenceData{}
+	for _, item := range items {
+		if visibleIds[item.Id] {
+			visibleItems = append(visibleItems, item)
+		}
+	}
+
 	return &protos.ReferenceDataListResp{
-		ReferenceData: items,
+		ReferenceData: visibleItems,
 	}, nil
 }
 
@@ -72,11 +89,16 @@ func HandleReferenceDataGetReq(req *protos.ReferenceDataGetReq, hctx wsHelpers.H
 		return nil, err
 	}
 
+	_, err := wsHelpers.CheckOptionalObjectAccess(false, req.Id, protos.ObjectType_OT_REFERENCE_DATA, hctx)
+	if err != nil {
+		return nil, err
+	}
+
 	ctx := context.TODO()
 	coll := hctx.Svcs.MongoDB.Collection(dbCollections.ReferencesName)
 
 	item := &protos.ReferenceData{}
-	err := coll.FindOne(ctx, bson.D{{Key: "_id", Value: req.Id}}).Decode(item)
+	err = coll.FindOne(ctx, bson.D{{Key: "_id", Value: req.Id}}).Decode(item)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
 			return nil, errorwithstatus.MakeNotFoundError(req.Id)
@@ -94,6 +116,11 @@ func HandleReferenceDataDeleteReq(req *protos.ReferenceDataDeleteReq, hctx wsHel
 		return nil, err
 	}
 
+	_, err := wsHelpers.CheckOptionalObjectAccess(true, req.Id, protos.ObjectType_OT_REFERENCE_DATA, hctx)
+	if err != nil {
+		return nil, err
+	}
+
 	ctx := context.TODO()
 	coll := hctx.Svcs.MongoDB.Collection(dbCollections.ReferencesName)
 
@@ -106,6 +133,11 @@ func HandleReferenceDataDeleteReq(req *protos.ReferenceDataDeleteReq, hctx wsHel
 		return nil, errorwithstatus.MakeNotFoundError(req.Id)
 	}
 
+	err = wsHelpers.DeleteOptionalOwnership(req.Id, hctx)
+	if err != nil {
+		return nil, err
+	}
+
 	return &protos.ReferenceDataDeleteResp{}, nil
 }
 
@@ -132,6 +164,11 @@ func HandleReferenceDataWriteReq(req *protos.ReferenceDataWriteReq, hctx wsHelpe
 			return nil, err
 		}
 
+		_, err := wsHelpers.CheckOptionalObjectAccess(true, req.ReferenceData.Id, protos.ObjectType_OT_REFERENCE_DATA, hctx)
+		if err != nil {
+			return nil, err
+		}
+
 		// Check if the item exists
 		existsResult := coll.FindOne(ctx, bson.D{{Key: "_id", Value: req.ReferenceData.Id}})
 		if existsResult.Err() != nil {
@@ -166,6 +203,8 @@ func HandleReferenceDataWriteReq(req *protos.ReferenceDataWriteReq, hctx wsHelpe
 		id := hctx.Svcs.IDGen.GenObjectID()
 		req.ReferenceData.Id = id
 
+		// NOTE: Reference data isn't for a scan, so like existing items it has no ownership item and is visible to
+		// everyone until someone sets sharing on it
 		_, err := coll.InsertOne(ctx, req.ReferenceData)
 		if err != nil {
 			return nil, err
@@ -207,6 +246,16 @@ func HandleReferenceDataBulkWriteReq(req *protos.ReferenceDataBulkWriteReq, hctx
 		}
 	}
 
+	// Bulk writes are imports of the shared reference library, so new items aren't given an owner, but existing ones can
+	// only be overwritten if the user can edit them
+	for _, item := range req.ReferenceData {
+		if len(item.Id) > 0 {
+			if _, err := wsHelpers.CheckOptionalObjectAccess(true, item.Id, protos.ObjectType_OT_REFERENCE_DATA, hctx); err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	// Insert or update the items
 	for _, item := range req.ReferenceData {
 		if _, err := coll.UpdateOne(ctx, bson.D{{Key: "_id", Value: item.Id}}, bson.D{{Key: "$set", Value: item}}, options.Update().SetUpsert(true)); err != nil {
@@ -0,0 +1,109 @@
+package wsHandler
+
+import (
+	"context"
+	"errors"
+
+	"github.com/pixlise/core/v4/api/ws/wsHelpers"
+	"github.com/pixlise/core/v4/core/errorwithstatus"
+	protos "github.com/pixlise/core/v4/generated-protos"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func HandleObjectRevisionListReq(req *protos.ObjectRevisionListReq, hctx wsHelpers.HandlerContext) (*protos.ObjectRevisionListResp, error) {
+	_, err := checkRevisionAccess(req.ObjectId, req.ObjectType, false, hctx)
+	if err != nil {
+		return nil, err
+	}
+
+	revs, err := wsHelpers.ListObjectRevisions(req.ObjectId, req.ObjectType, hctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &protos.ObjectRevisionListResp{
+		Revisions: revs,
+	}, nil
+}
+
+func HandleObjectRevisionGetReq(req *protos.ObjectRevisionGetReq, hctx wsHelpers.HandlerContext) (*protos.ObjectRevisionGetResp, error) {
+	_, err := checkRevisionAccess(req.ObjectId, req.ObjectType, false, hctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rev, err := wsHelpers.GetObjectRevision(req.ObjectId, req.ObjectType, req.Revision, hctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &protos.ObjectRevisionGetResp{
+		Revision: rev,
+	}, nil
+}
+
+// Restoring overwrites the whole object with the revision, including fields the regular update functions don't allow to
+// be cleared. Things stored outside the object aren't restored: data of widgets in a screen configuration, and the MIST
+// info of an ROI
+func HandleObjectRevisionRestoreReq(req *protos.ObjectRevisionRestoreReq, hctx wsHelpers.HandlerContext) (*protos.ObjectRevisionRestoreResp, error) {
+	collectionName, err := checkRevisionAccess(req.ObjectId, req.ObjectType, true, hctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rev, err := wsHelpers.GetObjectRevision(req.ObjectId, req.ObjectType, req.Revision, hctx)
+	if err != nil {
+		return nil, err
+	}
+
+	item, err := wsHelpers.GetRevisionItem(rev, uint32(hctx.Svcs.TimeStamper.GetTimeNowSec()))
+	if err != nil {
+		return nil, err
+	}
+
+	before := wsHelpers.ReadItemsForAudit(collectionName, []string{req.ObjectId}, hctx)[req.ObjectId]
+
+	result, err := hctx.Svcs.MongoDB.Collection(collectionName).ReplaceOne(context.TODO(), bson.M{"_id": req.ObjectId}, item)
+	if err != nil {
+		return nil, err
+	}
+
+	if result.MatchedCount != 1 {
+		return nil, errorwithstatus.MakeNotFoundError(req.ObjectId)
+	}
+
+	wsHelpers.AuditObjectUpdate(req.ObjectId, req.ObjectType, collectionName, before, hctx)
+
+	// Restoring is a save like any other, so it can be undone by restoring the revision before it
+	newRev := wsHelpers.SaveObjectRevision(req.ObjectId, req.ObjectType, rev.Revision, hctx)
+	if newRev == nil {
+		return nil, errors.New("Revision was restored, but failed to save it as a new revision")
+	}
+
+	if req.ObjectType == protos.ObjectType_OT_ROI {
+		hctx.Svcs.Notifier.SysNotifyROIChanged(req.ObjectId)
+	}
+
+	return &protos.ObjectRevisionRestoreResp{
+		Revision: newRev,
+	}, nil
+}
+
+// Checks the user can access the object and that we keep revisions of it. Returns the collection the object is in
+func checkRevisionAccess(objectId string, objectType protos.ObjectType, forEditing bool, hctx wsHelpers.HandlerContext) (string, error) {
+	if err := wsHelpers.CheckStringField(&objectId, "ObjectId", 1, wsHelpers.IdFieldMaxLength*2 /* Screen config ids include the scan id */); err != nil {
+		return "", err
+	}
+
+	collectionName, err := wsHelpers.GetRevisionCollection(objectType)
+	if err != nil {
+		return "", err
+	}
+
+	_, err = wsHelpers.CheckObjectAccess(forEditing, objectId, objectType, hctx)
+	if err != nil {
+		return "", err
+	}
+
+	return collectionName, nil
+}
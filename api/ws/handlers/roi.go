@@ -16,6 +16,7 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.mongodb.org/mongo-driver/mongo/readconcern"
 	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+	"google.golang.org/protobuf/proto"
 )
 
 type IdOnly struct {
@@ -286,6 +287,9 @@ func createROI(roi *protos.ROIItem, id string, hctx wsHelpers.HandlerContext, ne
 		return nil, err
 	}
 
+	wsHelpers.WriteAuditEntry(roi.Id, protos.ObjectType_OT_ROI, protos.AuditAction_AA_CREATE, nil, roi, hctx)
+	wsHelpers.SaveObjectRevision(roi.Id, protos.ObjectType_OT_ROI, 0, hctx)
+
 	roi.Owner = wsHelpers.MakeOwnerSummary(ownerItem, hctx.SessUser, hctx.Svcs.MongoDB, hctx.Svcs.TimeStamper)
 
 	return roi, nil
@@ -299,8 +303,13 @@ func updateROI(roi *protos.ROIItem, hctx wsHelpers.HandlerContext, editors *prot
 		return nil, err
 	}
 
+	// Keep what it looked like for the audit log, as we edit dbItem below
+	dbItemBefore := proto.Clone(dbItem)
+
 	// Check if we need to update the ownership
 	if editors != nil || viewers != nil {
+		ownerBefore := proto.Clone(owner)
+
 		if editors != nil {
 			if owner.Editors == nil {
 				owner.Editors = &protos.UserGroupList{}
@@ -324,6 +333,7 @@ func updateROI(roi *protos.ROIItem, hctx wsHelpers.HandlerContext, editors *prot
 			return nil, err
 		}
 
+		wsHelpers.WriteAuditEntry(roi.Id, protos.ObjectType_OT_ROI, protos.AuditAction_AA_SHARE, ownerBefore, owner, hctx)
 	}
 
 	// Some fields can't change
@@ -386,6 +396,9 @@ func updateROI(roi *protos.ROIItem, hctx wsHelpers.HandlerContext, editors *prot
 		hctx.Svcs.Log.Errorf("ROI UpdateByID result had unexpected counts %v id: %v", result, roi.Id)
 	}
 
+	wsHelpers.AuditObjectUpdate(roi.Id, protos.ObjectType_OT_ROI, dbCollections.RegionsOfInterestName, dbItemBefore, hctx)
+	wsHelpers.SaveObjectRevision(roi.Id, protos.ObjectType_OT_ROI, 0, hctx)
+
 	// Return the merged item we validated, which in theory is in the DB now
 	dbItem.Owner = wsHelpers.MakeOwnerSummary(owner, hctx.SessUser, hctx.Svcs.MongoDB, hctx.Svcs.TimeStamper)
 
@@ -452,6 +465,8 @@ func HandleRegionOfInterestBulkWriteReq(req *protos.RegionOfInterestBulkWriteReq
 			mistIdList = append(mistIdList, item.Id)
 		}
 
+		deletedROIs := wsHelpers.ReadItemsForAudit(dbCollections.RegionsOfInterestName, mistIdList, hctx)
+
 		// Delete all the MIST ROIs for this scan
 		_, err = hctx.Svcs.MongoDB.Collection(dbCollections.MistROIsName).DeleteMany(ctx, bson.M{"_id": bson.M{"$in": mistIdList}})
 		if err != nil {
@@ -475,6 +490,11 @@ func HandleRegionOfInterestBulkWriteReq(req *protos.RegionOfInterestBulkWriteReq
 		if err != nil {
 			return nil, err
 		}
+
+		for id, item := range deletedROIs {
+			wsHelpers.WriteAuditEntry(id, protos.ObjectType_OT_ROI, protos.AuditAction_AA_DELETE, item, nil, hctx)
+		}
+		wsHelpers.DeleteObjectRevisions(mistIdList, protos.ObjectType_OT_ROI, hctx)
 	}
 
 	editors := &protos.UserGroupList{
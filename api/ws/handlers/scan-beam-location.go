@@ -7,7 +7,7 @@ import (
 )
 
 func HandleScanBeamLocationsReq(req *protos.ScanBeamLocationsReq, hctx wsHelpers.HandlerContext) (*protos.ScanBeamLocationsResp, error) {
-	exprPB, indexes, err := beginDatasetFileReqForRange(req.ScanId, req.Entries, hctx)
+	exprPB, indexes, err := beginDatasetFileReqForRange(req.ScanId, req.Entries, scan.DataKindBeams, hctx)
 	if err != nil {
 		return nil, err
 	}
@@ -18,7 +18,7 @@ func HandleImageScanEntryDisplayElementsGetReq(req *protos.ImageScanEntryDisplay
 	// Look up all the data required to calculate these polygons
 	// NOTE: we should probably store these longer term as we start dealing with larger data, but for now generation is fine as it was fine for years done client-side!
 
-	exprPB, indexes, err := beginDatasetFileReqForRange(req.ScanId, nil, hctx)
+	exprPB, indexes, err := beginDatasetFileReqForRange(req.ScanId, nil, scan.DataKindAll, hctx)
 	if err != nil {
 		return nil, err
 	}
@@ -7,7 +7,7 @@ import (
 )
 
 func HandleScanEntryReq(req *protos.ScanEntryReq, hctx wsHelpers.HandlerContext) (*protos.ScanEntryResp, error) {
-	exprPB, indexes, err := beginDatasetFileReqForRange(req.ScanId, req.Entries, hctx)
+	exprPB, indexes, err := beginDatasetFileReqForRange(req.ScanId, req.Entries, scan.DataKindAll, hctx)
 	if err != nil {
 		return nil, err
 	}
@@ -166,13 +166,29 @@ func HandleScanMetaLabelsAndTypesReq(req *protos.ScanMetaLabelsAndTypesReq, hctx
 }
 
 // Utility to call for any Req message that involves serving data out of a dataset.bin file
-// scanId is mandatory, but startIdx and locCount may not exist in all requests, can be set to 0 if unused/not relevant
-func beginDatasetFileReqForRange(scanId string, entryRange *protos.ScanEntryRange, hctx wsHelpers.HandlerContext) (*protos.Experiment, []uint32, error) {
-	exprPB, err := beginDatasetFileReq(scanId, hctx)
+// scanId is mandatory, entryRange can be nil to read all locations. Only the kinds of data asked for are guaranteed to be
+// read for the locations requested, as we read only the chunks of the dataset we need if they're available
+func beginDatasetFileReqForRange(scanId string, entryRange *protos.ScanEntryRange, kinds scan.DataKind, hctx wsHelpers.HandlerContext) (*protos.Experiment, []uint32, error) {
+	if err := checkDatasetFileAccess(scanId, hctx); err != nil {
+		return nil, []uint32{}, err
+	}
+
+	chunkIndex, err := wsHelpers.ReadDatasetChunkIndex(scanId, hctx.Svcs)
 	if err != nil {
 		return nil, []uint32{}, err
 	}
 
+	var exprPB *protos.Experiment
+	if chunkIndex != nil {
+		exprPB = chunkIndex.Header
+	} else {
+		// Scan was imported before we stored chunks, so we have to read the whole thing
+		exprPB, err = wsHelpers.ReadDatasetFile(scanId, hctx.Svcs, true)
+		if err != nil {
+			return nil, []uint32{}, err
+		}
+	}
+
 	indexes := []uint32{}
 	if entryRange == nil {
 		// Use all indexes available in the file
@@ -187,16 +203,18 @@ func beginDatasetFileReqForRange(scanId string, entryRange *protos.ScanEntryRang
 		}
 	}
 
+	if chunkIndex != nil {
+		exprPB, err = wsHelpers.ReadDatasetChunks(scanId, chunkIndex, kinds, indexes, hctx.Svcs)
+		if err != nil {
+			return nil, []uint32{}, err
+		}
+	}
+
 	return exprPB, indexes, nil
 }
 
 func beginDatasetFileReq(scanId string, hctx wsHelpers.HandlerContext) (*protos.Experiment, error) {
-	if err := wsHelpers.CheckStringField(&scanId, "ScanId", 1, 50); err != nil {
-		return nil, err
-	}
-
-	_, err := wsHelpers.CheckObjectAccess(false, scanId, protos.ObjectType_OT_SCAN, hctx)
-	if err != nil {
+	if err := checkDatasetFileAccess(scanId, hctx); err != nil {
 		return nil, err
 	}
 
@@ -209,6 +227,15 @@ func beginDatasetFileReq(scanId string, hctx wsHelpers.HandlerContext) (*protos.
 	return exprPB, nil
 }
 
+func checkDatasetFileAccess(scanId string, hctx wsHelpers.HandlerContext) error {
+	if err := wsHelpers.CheckStringField(&scanId, "ScanId", 1, 50); err != nil {
+		return err
+	}
+
+	_, err := wsHelpers.CheckObjectAccess(false, scanId, protos.ObjectType_OT_SCAN, hctx)
+	return err
+}
+
 func HandleScanDeleteReq(req *protos.ScanDeleteReq, hctx wsHelpers.HandlerContext) (*protos.ScanDeleteResp, error) {
 	// Check user has access
 	dbItem, _, err := wsHelpers.GetUserObjectById[protos.ScanItem](true, req.ScanId, protos.ObjectType_OT_SCAN, dbCollections.ScansName, hctx)
@@ -249,6 +276,8 @@ func HandleScanDeleteReq(req *protos.ScanDeleteReq, hctx wsHelpers.HandlerContex
 		hctx.Svcs.Log.Errorf("ScanDelete %v - Unexpected DeletedCount %v, expected 1", req.ScanId, delResult.DeletedCount)
 	}
 
+	wsHelpers.WriteAuditEntry(req.ScanId, protos.ObjectType_OT_SCAN, protos.AuditAction_AA_DELETE, dbItem, nil, hctx)
+
 	// Delete scan data from S3
 	err = hctx.Svcs.FS.DeleteObject(hctx.Svcs.Config.DatasetsBucket, filepaths.GetScanFilePath(req.ScanId, filepaths.DatasetFileName))
 	if err != nil {
@@ -260,6 +289,19 @@ func HandleScanDeleteReq(req *protos.ScanDeleteReq, hctx wsHelpers.HandlerContex
 		return nil, fmt.Errorf("ScanDelete %v - partially succeeded, as some files failed to delete: %v", req.ScanId, err)
 	}
 
+	// Older scans don't have chunk files, so there may be nothing to delete here
+	chunkFiles, err := hctx.Svcs.FS.ListObjects(hctx.Svcs.Config.DatasetsBucket, filepaths.GetScanFilePath(req.ScanId, "dataset-"))
+	if err != nil {
+		return nil, fmt.Errorf("ScanDelete %v - partially succeeded, failed to list chunk files: %v", req.ScanId, err)
+	}
+
+	for _, chunkFile := range chunkFiles {
+		err = hctx.Svcs.FS.DeleteObject(hctx.Svcs.Config.DatasetsBucket, chunkFile)
+		if err != nil {
+			return nil, fmt.Errorf("ScanDelete %v - partially succeeded, as some files failed to delete: %v", req.ScanId, err)
+		}
+	}
+
 	// Notify of our scan change
 	hctx.Svcs.Notifier.SysNotifyScanChanged(req.ScanId)
 
@@ -285,6 +327,7 @@ func HandleScanMetaWriteReq(req *protos.ScanMetaWriteReq, hctx wsHelpers.Handler
 	// Overwrites some metadata fields to allow them to be more descriptive to users. Requires permission EDIT_SCAN
 	// so only admins can do this
 	ctx := context.TODO()
+	scanBefore := wsHelpers.ReadItemsForAudit(dbCollections.ScansName, []string{req.ScanId}, hctx)[req.ScanId]
 	coll := hctx.Svcs.MongoDB.Collection(dbCollections.ScansName)
 
 	update := bson.D{
@@ -302,6 +345,8 @@ func HandleScanMetaWriteReq(req *protos.ScanMetaWriteReq, hctx wsHelpers.Handler
 		return nil, errorwithstatus.MakeNotFoundError(req.ScanId)
 	}
 
+	wsHelpers.AuditObjectUpdate(req.ScanId, protos.ObjectType_OT_SCAN, dbCollections.ScansName, scanBefore, hctx)
+
 	// Notify of our scan change
 	hctx.Svcs.Notifier.SysNotifyScanChanged(req.ScanId)
 
@@ -405,6 +450,15 @@ func HandleScanUploadReq(req *protos.ScanUploadReq, hctx wsHelpers.HandlerContex
 		return nil, err
 	}
 
+	// A dry run processes the upload into memory instead of the upload bucket, so nothing is written
+	if req.DryRun {
+		fs = fileaccess.MakeMemoryAccess()
+		err = fs.WriteObject(destBucket, zipPath, zippedData)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Validate contents - detector dependent
 	if req.Format == "pixl-em" || req.Format == "pixl-fm" {
 		err = dataimport.ProcessSDF(datasetID, zipReader, zippedData, destBucket, s3PathStart, fs, logger)
@@ -429,6 +483,15 @@ func HandleScanUploadReq(req *protos.ScanUploadReq, hctx wsHelpers.HandlerContex
 	}
 	logger.Infof("  Uploaded: s3://%v/%v", destBucket, savePath)
 
+	if req.DryRun {
+		report, err := dataimport.DryRunUpload(&fileaccess.FSAccess{}, fs, hctx.Svcs.FS, hctx.Svcs.Config.ConfigBucket, destBucket, hctx.Svcs.Config.DatasetsBucket, datasetID, logger)
+		if err != nil {
+			return nil, errorwithstatus.MakeBadRequestError(fmt.Errorf("Dry run import failed: %v", err))
+		}
+
+		return &protos.ScanUploadResp{Report: report}, nil
+	}
+
 	// Now save creator info
 	savePath = path.Join(s3PathStart, "creator.json")
 	err = fs.WriteJSON(destBucket, savePath, hctx.SessUser.User)
@@ -242,6 +242,8 @@ func writeScreenConfiguration(screenConfig *protos.ScreenConfiguration, hctx wsH
 		return nil, err
 	}
 
+	wsHelpers.SaveObjectRevision(configuration.Id, protos.ObjectType_OT_SCREEN_CONFIG, 0, hctx)
+
 	configuration.Owner = wsHelpers.MakeOwnerSummary(owner, hctx.SessUser, hctx.Svcs.MongoDB, hctx.Svcs.TimeStamper)
 
 	return configuration, nil
@@ -436,6 +438,8 @@ func HandleScreenConfigurationDeleteReq(req *protos.ScreenConfigurationDeleteReq
 		return nil, err
 	}
 
+	wsHelpers.DeleteObjectRevisions([]string{req.Id}, protos.ObjectType_OT_SCREEN_CONFIG, hctx)
+
 	return &protos.ScreenConfigurationDeleteResp{
 		Id: req.Id,
 	}, nil
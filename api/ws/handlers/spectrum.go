@@ -5,12 +5,18 @@ import (
 	"fmt"
 
 	"github.com/pixlise/core/v4/api/ws/wsHelpers"
+	"github.com/pixlise/core/v4/core/scan"
 	"github.com/pixlise/core/v4/core/utils"
 	protos "github.com/pixlise/core/v4/generated-protos"
 )
 
 func HandleSpectrumReq(req *protos.SpectrumReq, hctx wsHelpers.HandlerContext) (*protos.SpectrumResp, error) {
-	exprPB, indexes, err := beginDatasetFileReqForRange(req.ScanId, req.Entries, hctx)
+	kinds := scan.DataKindSpectra
+	if req.BulkSum || req.MaxValue {
+		kinds |= scan.DataKindBulkMaxSpectra
+	}
+
+	exprPB, indexes, err := beginDatasetFileReqForRange(req.ScanId, req.Entries, kinds, hctx)
 	if err != nil {
 		return nil, err
 	}
@@ -8,9 +8,10 @@ import (
 	"sync"
 
 	"github.com/mongodb/mongo-tools/mongorestore"
+	"github.com/pixlise/core/v4/api/filepaths"
 	"github.com/pixlise/core/v4/api/services"
 	"github.com/pixlise/core/v4/api/ws/wsHelpers"
-	"github.com/pixlise/core/v4/core/fileaccess"
+	"github.com/pixlise/core/v4/core/errorwithstatus"
 	"github.com/pixlise/core/v4/core/mongoDBConnection"
 	"github.com/pixlise/core/v4/core/mongobackup"
 	protos "github.com/pixlise/core/v4/generated-protos"
@@ -43,6 +44,7 @@ func HandleBackupDBReq(req *protos.BackupDBReq, hctx wsHelpers.HandlerContext) (
 	return &protos.BackupDBResp{}, nil
 }
 
+// Where backups were written before we had backup sets. We can still restore from here if there are no backup sets
 var envS3Path = "WholeEnvBackup"
 
 func runBackup(dbName string, startTimestamp int64, svcs *services.APIServices) {
@@ -52,25 +54,39 @@ func runBackup(dbName string, startTimestamp int64, svcs *services.APIServices)
 	var errImageSync error
 	var errQuantSync error
 
-	svcs.Log.Infof("Clearing PIXLISE backup bucket: %v", svcs.Config.DataBackupBucket)
+	var scanFiles map[string]wsHelpers.BackupFile
+	var imageFiles map[string]wsHelpers.BackupFile
+	var quantFiles map[string]wsHelpers.BackupFile
 
-	err := fileaccess.ClearBucketDir(svcs.Config.DataBackupBucket, envS3Path, svcs.FS, svcs.Log)
+	backupBucket := svcs.Config.DataBackupBucket
+
+	sets, err := wsHelpers.ListBackupSets(backupBucket, svcs.FS)
 	if err != nil {
-		svcs.Log.Errorf("PIXLISE Backup bucket clear failed: %v", err)
+		svcs.Log.Errorf("PIXLISE Backup failed to list existing backup sets: %v", err)
 		return
 	}
 
+	// Only files changed since the last good backup are copied
+	prev := wsHelpers.GetLatestCompleteBackupSet(sets)
+	setId := wsHelpers.MakeBackupSetId(startTimestamp)
+
+	if prev != nil {
+		svcs.Log.Infof("PIXLISE Backup writing set %v, copying files changed since set %v", setId, prev.SetId)
+	} else {
+		svcs.Log.Infof("PIXLISE Backup writing set %v, no previous complete set found so copying all files", setId)
+	}
+
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		errDBDump = mongobackup.BackupDB(dbName, svcs.Config.DataBackupBucket, path.Join(envS3Path, "DB"), false, svcs)
+		errDBDump = mongobackup.BackupDB(dbName, backupBucket, path.Join(wsHelpers.GetBackupSetPath(setId), "DB"), false, svcs)
 	}()
 
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
 		svcs.Log.Infof("Syncing scans to bucket")
-		errScanSync = wsHelpers.SyncScans(envS3Path, svcs)
+		scanFiles, errScanSync = wsHelpers.SyncScans(setId, prev, svcs)
 		svcs.Log.Infof("Syncing scans to bucket COMPLETE")
 	}()
 
@@ -78,7 +94,7 @@ func runBackup(dbName string, startTimestamp int64, svcs *services.APIServices)
 	go func() {
 		defer wg.Done()
 		svcs.Log.Infof("Syncing quants to bucket")
-		errQuantSync = wsHelpers.SyncQuants(envS3Path, svcs)
+		quantFiles, errQuantSync = wsHelpers.SyncQuants(setId, prev, svcs)
 		svcs.Log.Infof("Syncing quants to bucket COMPLETE")
 	}()
 
@@ -86,7 +102,7 @@ func runBackup(dbName string, startTimestamp int64, svcs *services.APIServices)
 	go func() {
 		defer wg.Done()
 		svcs.Log.Infof("Syncing images to bucket")
-		errImageSync = wsHelpers.SyncImages(envS3Path, svcs)
+		imageFiles, errImageSync = wsHelpers.SyncImages(setId, prev, svcs)
 		svcs.Log.Infof("Syncing images to bucket COMPLETE")
 	}()
 
@@ -109,17 +125,73 @@ func runBackup(dbName string, startTimestamp int64, svcs *services.APIServices)
 		err = fmt.Errorf("PIXLISE Backup error syncing quants: %v", errQuantSync)
 	}
 
+	// Write the manifest even if we failed, so the set can be inspected. It's only restorable if complete though
+	manifest := &wsHelpers.BackupManifest{
+		SetId:            setId,
+		TimeStampUnixSec: startTimestamp,
+		Complete:         err == nil,
+		DBName:           dbName,
+		Files: map[string]map[string]wsHelpers.BackupFile{
+			filepaths.DatasetScansRoot:       scanFiles,
+			filepaths.DatasetImagesRoot:      imageFiles,
+			filepaths.RootQuantificationPath: quantFiles,
+		},
+	}
+
+	errManifest := wsHelpers.WriteBackupManifest(backupBucket, manifest, svcs.FS)
+	if errManifest != nil {
+		svcs.Log.Errorf("PIXLISE Backup failed to write manifest for set %v: %v", setId, errManifest)
+		return
+	}
+
 	if err != nil {
 		svcs.Log.Errorf("%v", err)
 		return
 	}
 
 	endTimestamp := svcs.TimeStamper.GetTimeNowSec()
-	svcs.Log.Infof("PIXLISE Backup complete in %v sec", endTimestamp-startTimestamp)
+	fileCount, copiedCount := manifest.FileCounts()
+	svcs.Log.Infof("PIXLISE Backup set %v complete in %v sec, %v files, %v copied", setId, endTimestamp-startTimestamp, fileCount, copiedCount)
+
+	// Now that we have a good backup, we can delete old ones
+	deletedSets, err := wsHelpers.ApplyBackupRetention(backupBucket, svcs.Config.BackupKeepCount, svcs.Config.BackupKeepDays, endTimestamp, svcs.FS, svcs.Log)
+	if err != nil {
+		svcs.Log.Errorf("PIXLISE Backup failed to apply retention policy: %v", err)
+		return
+	}
+
+	if len(deletedSets) > 0 {
+		svcs.Log.Infof("PIXLISE Backup retention deleted sets: %v", strings.Join(deletedSets, ", "))
+	}
 
 	// TODO: send an update message to notify anything listening that we're done!
 }
 
+func HandleBackupSetListReq(req *protos.BackupSetListReq, hctx wsHelpers.HandlerContext) (*protos.BackupSetListResp, error) {
+	if len(hctx.Svcs.Config.DataBackupBucket) <= 0 {
+		return nil, errors.New("PIXLISE Backup bucket not configured")
+	}
+
+	sets, err := wsHelpers.ListBackupSets(hctx.Svcs.Config.DataBackupBucket, hctx.Svcs.FS)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &protos.BackupSetListResp{Sets: []*protos.BackupSetSummary{}}
+	for _, set := range sets {
+		fileCount, copiedCount := set.FileCounts()
+		resp.Sets = append(resp.Sets, &protos.BackupSetSummary{
+			SetId:            set.SetId,
+			TimeStampUnixSec: uint32(set.TimeStampUnixSec),
+			Complete:         set.Complete,
+			FileCount:        fileCount,
+			CopiedFileCount:  copiedCount,
+		})
+	}
+
+	return resp, nil
+}
+
 func HandleRestoreDBReq(req *protos.RestoreDBReq, hctx wsHelpers.HandlerContext) (*protos.RestoreDBResp, error) {
 	// Only allow restore if enabled and we're NOT prod
 	if !hctx.Svcs.Config.RestoreEnabled {
@@ -134,6 +206,27 @@ func HandleRestoreDBReq(req *protos.RestoreDBReq, hctx wsHelpers.HandlerContext)
 		return nil, errors.New(err)
 	}
 
+	sets, err := wsHelpers.ListBackupSets(hctx.Svcs.Config.DataBackupBucket, hctx.Svcs.FS)
+	if err != nil {
+		return nil, err
+	}
+
+	// If there are no backup sets and none was asked for, we restore from the old single backup location
+	var manifest *wsHelpers.BackupManifest
+	if len(sets) > 0 || len(req.BackupSetId) > 0 || len(req.ScanId) > 0 {
+		manifest, err = wsHelpers.FindBackupSet(sets, req.BackupSetId)
+		if err != nil {
+			return nil, errorwithstatus.MakeNotFoundError(err.Error())
+		}
+	}
+
+	startTimestamp := hctx.Svcs.TimeStamper.GetTimeNowSec()
+
+	if len(req.ScanId) > 0 {
+		go runScanRestore(req.ScanId, manifest, startTimestamp, hctx.Svcs)
+		return &protos.RestoreDBResp{}, nil
+	}
+
 	deleteLocal := true
 
 	if deleteLocal {
@@ -144,14 +237,26 @@ func HandleRestoreDBReq(req *protos.RestoreDBReq, hctx wsHelpers.HandlerContext)
 		}
 	}
 
-	startTimestamp := hctx.Svcs.TimeStamper.GetTimeNowSec()
-
-	go runRestore(startTimestamp, hctx.Svcs, deleteLocal)
+	go runRestore(startTimestamp, manifest, hctx.Svcs, deleteLocal)
 
 	return &protos.RestoreDBResp{}, nil
 }
 
-func runRestore(startTimestamp int64, svcs *services.APIServices, downloadRemoteFiles bool) {
+func runScanRestore(scanId string, manifest *wsHelpers.BackupManifest, startTimestamp int64, svcs *services.APIServices) {
+	svcs.Log.Infof("Restoring scan %v from backup set %v...", scanId, manifest.SetId)
+
+	err := wsHelpers.RestoreScanFromBackupSet(scanId, manifest, svcs)
+	if err != nil {
+		svcs.Log.Errorf("PIXLISE Restore of scan %v failed: %v", scanId, err)
+		return
+	}
+
+	endTimestamp := svcs.TimeStamper.GetTimeNowSec()
+	svcs.Log.Infof("PIXLISE Restore of scan %v complete in %v sec", scanId, endTimestamp-startTimestamp)
+}
+
+// Restores from the given backup set, or the old style single backup if manifest is nil
+func runRestore(startTimestamp int64, manifest *wsHelpers.BackupManifest, svcs *services.APIServices, downloadRemoteFiles bool) {
 	var wg sync.WaitGroup
 	var errDBRestore error
 	var errScanSync error
@@ -159,6 +264,9 @@ func runRestore(startTimestamp int64, svcs *services.APIServices, downloadRemote
 	var errQuantSync error
 
 	envReadPath := envS3Path
+	if manifest != nil {
+		envReadPath = wsHelpers.GetBackupSetPath(manifest.SetId)
+	}
 
 	var err error
 	/*
@@ -175,7 +283,7 @@ func runRestore(startTimestamp int64, svcs *services.APIServices, downloadRemote
 			envReadPath = ""
 		}*/
 
-	svcs.Log.Infof("RunRestore from \"s3://%v/%v\"...", svcs.Config.DataBackupBucket, envS3Path)
+	svcs.Log.Infof("RunRestore from \"s3://%v/%v\"...", svcs.Config.DataBackupBucket, envReadPath)
 
 	wg.Add(1)
 	go func() {
@@ -211,7 +319,11 @@ func runRestore(startTimestamp int64, svcs *services.APIServices, downloadRemote
 	go func() {
 		defer wg.Done()
 		svcs.Log.Infof("Restoring scans to bucket")
-		errScanSync = wsHelpers.RestoreScans(envReadPath, svcs)
+		if manifest != nil {
+			errScanSync = wsHelpers.RestoreBackupSetFiles(manifest, filepaths.DatasetScansRoot, svcs.Config.DatasetsBucket, svcs)
+		} else {
+			errScanSync = wsHelpers.RestoreScans(envReadPath, svcs)
+		}
 		svcs.Log.Infof("Restoring scans to bucket COMPLETE")
 	}()
 
@@ -219,7 +331,11 @@ func runRestore(startTimestamp int64, svcs *services.APIServices, downloadRemote
 	go func() {
 		defer wg.Done()
 		svcs.Log.Infof("Restoring quants to bucket")
-		errQuantSync = wsHelpers.RestoreQuants(envReadPath, svcs)
+		if manifest != nil {
+			errQuantSync = wsHelpers.RestoreBackupSetFiles(manifest, filepaths.RootQuantificationPath, svcs.Config.UsersBucket, svcs)
+		} else {
+			errQuantSync = wsHelpers.RestoreQuants(envReadPath, svcs)
+		}
 		svcs.Log.Infof("Restoring quants to bucket COMPLETE")
 	}()
 
@@ -227,7 +343,11 @@ func runRestore(startTimestamp int64, svcs *services.APIServices, downloadRemote
 	go func() {
 		defer wg.Done()
 		svcs.Log.Infof("Restoring images to bucket")
-		errImageSync = wsHelpers.RestoreImages(envReadPath, svcs)
+		if manifest != nil {
+			errImageSync = wsHelpers.RestoreBackupSetFiles(manifest, filepaths.DatasetImagesRoot, svcs.Config.DatasetsBucket, svcs)
+		} else {
+			errImageSync = wsHelpers.RestoreImages(envReadPath, svcs)
+		}
 		svcs.Log.Infof("Restoring images to bucket COMPLETE")
 	}()
 
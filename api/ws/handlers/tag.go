@@ -94,6 +94,11 @@ func HandleTagCreateReq(req *protos.TagCreateReq, hctx wsHelpers.HandlerContext)
 		return nil, _err
 	}
 
+	_, err = wsHelpers.WriteOptionalOwnership(tagId, protos.ObjectType_OT_TAG, req.ScanId, hctx)
+	if err != nil {
+		return nil, err
+	}
+
 	resolvedTag, err := decorateTag(tag, hctx.Svcs.MongoDB, hctx.Svcs.Log)
 	if err != nil {
 		return nil, err
@@ -106,19 +111,27 @@ func HandleTagDeleteReq(req *protos.TagDeleteReq, hctx wsHelpers.HandlerContext)
 	ctx := context.TODO()
 	coll := hctx.Svcs.MongoDB.Collection(dbCollections.TagsName)
 
-	// Check if tag exists and is owned by user
-	filter := bson.M{"$and": []interface{}{
-		bson.M{"_id": req.TagId},
-		bson.M{"ownerid": hctx.SessUser.User.Id},
-	}}
-	cursor, err := coll.Find(ctx, filter)
+	// Tags with sharing set can be deleted by their editors
+	owner, err := wsHelpers.CheckOptionalObjectAccess(true, req.TagId, protos.ObjectType_OT_TAG, hctx)
 	if err != nil {
 		return nil, err
 	}
 
-	// If user doesn't own tag, return error
-	if !cursor.Next(ctx) {
-		return nil, errorwithstatus.MakeUnauthorisedError(fmt.Errorf("User does not own tag: %v", req.TagId))
+	if owner == nil {
+		// Check if tag exists and is owned by user
+		filter := bson.M{"$and": []interface{}{
+			bson.M{"_id": req.TagId},
+			bson.M{"ownerid": hctx.SessUser.User.Id},
+		}}
+		cursor, err := coll.Find(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+
+		// If user doesn't own tag, return error
+		if !cursor.Next(ctx) {
+			return nil, errorwithstatus.MakeUnauthorisedError(fmt.Errorf("User does not own tag: %v", req.TagId))
+		}
 	}
 
 	// Delete tag
@@ -127,6 +140,11 @@ func HandleTagDeleteReq(req *protos.TagDeleteReq, hctx wsHelpers.HandlerContext)
 		return nil, err
 	}
 
+	err = wsHelpers.DeleteOptionalOwnership(req.TagId, hctx)
+	if err != nil {
+		return nil, err
+	}
+
 	return &protos.TagDeleteResp{}, nil
 }
 
@@ -147,8 +165,22 @@ func HandleTagListReq(req *protos.TagListReq, hctx wsHelpers.HandlerContext) (*p
 		return nil, err
 	}
 
+	tagIds := []string{}
+	for _, tag := range tags {
+		tagIds = append(tagIds, tag.Id)
+	}
+
+	visibleIds, err := wsHelpers.FilterOptionalOwnershipIDs(tagIds, protos.ObjectType_OT_TAG, hctx)
+	if err != nil {
+		return nil, err
+	}
+
 	decoratedTags := []*protos.Tag{}
 	for _, tag := range tags {
+		if !visibleIds[tag.Id] {
+			continue
+		}
+
 		decoratedTag, _ := decorateTag(tag, hctx.Svcs.MongoDB, hctx.Svcs.Log)
 		decoratedTags = append(decoratedTags, decoratedTag)
 	}
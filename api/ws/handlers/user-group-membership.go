@@ -60,6 +60,14 @@ func HandleUserGroupAddMemberReq(req *protos.UserGroupAddMemberReq, hctx wsHelpe
 }
 
 func assignDefaultRoles(targetUserId string, group *protos.UserGroup, hctx wsHelpers.HandlerContext) error {
+	// Roles live in Auth0, other identity providers have no management API we can assign them through
+	if idp := hctx.Svcs.Config.IdentityProvider; idp != "" && idp != "auth0" {
+		if len(group.Info.DefaultRoles) > 0 {
+			hctx.Svcs.Log.Infof("Not assigning default roles of group %v to user %v, identity provider \"%v\" doesn't support it", group.Info.Id, targetUserId, idp)
+		}
+		return nil
+	}
+
 	if len(group.Info.DefaultRoles) > 0 {
 		targetUser, err := wsHelpers.GetDBUser(targetUserId, hctx.Svcs.MongoDB)
 		if err != nil {
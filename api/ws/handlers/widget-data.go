@@ -11,6 +11,10 @@ import (
 )
 
 func HandleWidgetDataGetReq(req *protos.WidgetDataGetReq, hctx wsHelpers.HandlerContext) (*protos.WidgetDataGetResp, error) {
+	if _, err := wsHelpers.CheckOptionalObjectAccess(false, req.Id, protos.ObjectType_OT_WIDGET_DATA, hctx); err != nil {
+		return nil, err
+	}
+
 	result := hctx.Svcs.MongoDB.Collection(dbCollections.WidgetDataName).FindOne(context.TODO(), bson.M{
 		"_id": req.Id,
 	})
@@ -35,6 +39,10 @@ func HandleWidgetDataWriteReq(req *protos.WidgetDataWriteReq, hctx wsHelpers.Han
 		return nil, errors.New("widget data must have a predefined id to write to")
 	}
 
+	if _, err := wsHelpers.CheckOptionalObjectAccess(true, req.WidgetData.Id, protos.ObjectType_OT_WIDGET_DATA, hctx); err != nil {
+		return nil, err
+	}
+
 	// Check if exists
 	result := hctx.Svcs.MongoDB.Collection(dbCollections.WidgetDataName).FindOne(context.TODO(), bson.M{
 		"_id": req.WidgetData.Id,
@@ -66,6 +74,10 @@ func HandleWidgetMetadataGetReq(req *protos.WidgetMetadataGetReq, hctx wsHelpers
 		return nil, errors.New("widget metadata must have a predefined id to get")
 	}
 
+	if _, err := wsHelpers.CheckOptionalObjectAccess(false, req.Id, protos.ObjectType_OT_WIDGET_DATA, hctx); err != nil {
+		return nil, err
+	}
+
 	result := hctx.Svcs.MongoDB.Collection(dbCollections.WidgetDataName).FindOne(context.TODO(), bson.M{
 		"_id": req.Id,
 	})
@@ -92,6 +104,10 @@ func HandleWidgetMetadataWriteReq(req *protos.WidgetMetadataWriteReq, hctx wsHel
 		return nil, errors.New("widget metadata must have a predefined id to write to")
 	}
 
+	if _, err := wsHelpers.CheckOptionalObjectAccess(true, req.Id, protos.ObjectType_OT_WIDGET_DATA, hctx); err != nil {
+		return nil, err
+	}
+
 	// Check if exists
 	result := hctx.Svcs.MongoDB.Collection(dbCollections.WidgetDataName).FindOne(context.TODO(), bson.M{
 		"_id": req.Id,
@@ -6,6 +6,7 @@ import (
 
 	"github.com/olahol/melody"
 	"github.com/pixlise/core/v4/api/dbCollections"
+	expressionrunner "github.com/pixlise/core/v4/api/job/jobrunner/expression-runner"
 	apiRouter "github.com/pixlise/core/v4/api/router"
 	"github.com/pixlise/core/v4/api/services"
 	"github.com/pixlise/core/v4/api/ws/wsHelpers"
@@ -167,6 +168,9 @@ func (ws *WSHandler) HandleDisconnect(s *melody.Session) {
 	}
 
 	fmt.Printf("Disconnect user: %v, session: %v\n", connectingUser.User.Id, connectingUser.SessionId)
+
+	// Nobody is left to send commands to (or receive updates from) any debug sessions it started
+	expressionrunner.StopDebugSessionsForWSSession(connectingUser.SessionId)
 }
 
 func (ws *WSHandler) HandleMessage(s *melody.Session, msg []byte) {
@@ -0,0 +1,208 @@
+package wsHelpers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pixlise/core/v4/api/dbCollections"
+	"github.com/pixlise/core/v4/core/utils"
+	protos "github.com/pixlise/core/v4/generated-protos"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+)
+
+// Audit log values longer than this are truncated, so we don't store whole PMC lists, expressions etc for every edit
+const maxAuditValueLength = 500
+
+// WriteAuditEntry - Records a change to a user object in the audit log. before is nil for a created object, after is nil
+// for a deleted one, otherwise only the fields that differ are stored. These can be protos or anything else that can be
+// written to the DB. Failures are only logged, as by the time we're called the change has been made
+func WriteAuditEntry(objectId string, objectType protos.ObjectType, action protos.AuditAction, before any, after any, hctx HandlerContext) {
+	changes, err := makeAuditChanges(before, after)
+	if err != nil {
+		hctx.Svcs.Log.Errorf("Failed to form audit log changes for %v %v: %v", objectType.String(), objectId, err)
+	}
+
+	// Updates which didn't change anything aren't interesting
+	if action == protos.AuditAction_AA_UPDATE && len(changes) <= 0 {
+		return
+	}
+
+	entry := &protos.AuditLogEntry{
+		Id:               hctx.Svcs.IDGen.GenObjectID(),
+		ObjectId:         objectId,
+		ObjectType:       objectType,
+		Action:           action,
+		UserId:           hctx.SessUser.User.Id,
+		RealUserId:       getRealUserId(hctx),
+		TimeStampUnixSec: uint32(hctx.Svcs.TimeStamper.GetTimeNowSec()),
+		Changes:          changes,
+	}
+
+	_, err = hctx.Svcs.MongoDB.Collection(dbCollections.AuditLogName).InsertOne(context.TODO(), entry)
+	if err != nil {
+		hctx.Svcs.Log.Errorf("Failed to write audit log entry for %v %v: %v", objectType.String(), objectId, err)
+	}
+}
+
+// AuditObjectUpdate - Records an update to a user object, reading what it looks like now from the DB. before should be
+// the item as read before the update was made
+func AuditObjectUpdate(objectId string, objectType protos.ObjectType, collectionName string, before any, hctx HandlerContext) {
+	after, err := hctx.Svcs.MongoDB.Collection(collectionName).FindOne(context.TODO(), bson.M{"_id": objectId}).Raw()
+	if err != nil {
+		hctx.Svcs.Log.Errorf("Failed to read %v %v for audit log: %v", objectType.String(), objectId, err)
+		return
+	}
+
+	WriteAuditEntry(objectId, objectType, protos.AuditAction_AA_UPDATE, before, after, hctx)
+}
+
+// ReadItemsForAudit - For deletes which don't go through DeleteUserObject, reads the items so they can be written to the
+// audit log once deleted. Returns id->item
+func ReadItemsForAudit(collectionName string, ids []string, hctx HandlerContext) map[string]bson.Raw {
+	result := map[string]bson.Raw{}
+
+	cursor, err := hctx.Svcs.MongoDB.Collection(collectionName).Find(context.TODO(), bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		hctx.Svcs.Log.Errorf("Failed to read %v items for audit log: %v", collectionName, err)
+		return result
+	}
+
+	items := []bson.Raw{}
+	err = cursor.All(context.TODO(), &items)
+	if err != nil {
+		hctx.Svcs.Log.Errorf("Failed to read %v items for audit log: %v", collectionName, err)
+		return result
+	}
+
+	for _, item := range items {
+		result[lookupString(item, "_id")] = item
+	}
+
+	return result
+}
+
+// The user id of who's really connected, if they're impersonating someone, otherwise empty
+func getRealUserId(hctx HandlerContext) string {
+	if hctx.Session == nil {
+		return ""
+	}
+
+	realUserId, ok := hctx.Session.Get("realUserId")
+	if !ok {
+		return ""
+	}
+
+	return fmt.Sprintf("%v", realUserId)
+}
+
+func makeAuditChanges(before any, after any) ([]*protos.AuditFieldChange, error) {
+	beforeFields := map[string]string{}
+	afterFields := map[string]string{}
+
+	for _, item := range []struct {
+		doc    any
+		fields map[string]string
+	}{{before, beforeFields}, {after, afterFields}} {
+		// Items we failed to read from the DB come through as empty raw documents
+		if raw, ok := item.doc.(bson.Raw); item.doc == nil || (ok && len(raw) <= 0) {
+			continue
+		}
+
+		raw, err := bson.Marshal(item.doc)
+		if err != nil {
+			return []*protos.AuditFieldChange{}, err
+		}
+
+		err = flattenAuditFields("", raw, item.fields)
+		if err != nil {
+			return []*protos.AuditFieldChange{}, err
+		}
+	}
+
+	fields := utils.GetMapKeys(beforeFields)
+	for field := range afterFields {
+		if _, ok := beforeFields[field]; !ok {
+			fields = append(fields, field)
+		}
+	}
+	sort.Strings(fields)
+
+	changes := []*protos.AuditFieldChange{}
+	for _, field := range fields {
+		if beforeFields[field] != afterFields[field] {
+			changes = append(changes, &protos.AuditFieldChange{Field: field, Before: beforeFields[field], After: afterFields[field]})
+		}
+	}
+
+	return changes, nil
+}
+
+// Reads the fields of a document into a map of path->value, where nested documents have their fields listed with a
+// path separated by . and anything else (including arrays) is one value
+func flattenAuditFields(prefix string, doc bson.Raw, fields map[string]string) error {
+	elems, err := doc.Elements()
+	if err != nil {
+		return err
+	}
+
+	for _, elem := range elems {
+		key := elem.Key()
+		if len(prefix) <= 0 && key == "_id" {
+			continue
+		}
+		if len(prefix) > 0 {
+			key = prefix + "." + key
+		}
+
+		value := elem.Value()
+		if value.Type == bsontype.EmbeddedDocument {
+			err = flattenAuditFields(key, value.Document(), fields)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		str := formatAuditValue(value)
+		if len(str) > maxAuditValueLength {
+			str = fmt.Sprintf("%v... (%v chars)", str[0:maxAuditValueLength], len(str))
+		}
+		fields[key] = str
+	}
+
+	return nil
+}
+
+// Values are stored as something readable, rather than the extended JSON the driver gives us
+func formatAuditValue(value bson.RawValue) string {
+	switch value.Type {
+	case bsontype.String:
+		return value.StringValue()
+	case bsontype.Null, bsontype.Undefined:
+		return ""
+	case bsontype.Boolean:
+		return fmt.Sprintf("%v", value.Boolean())
+	case bsontype.Int32:
+		return fmt.Sprintf("%v", value.Int32())
+	case bsontype.Int64:
+		return fmt.Sprintf("%v", value.Int64())
+	case bsontype.Double:
+		return fmt.Sprintf("%v", value.Double())
+	case bsontype.Array:
+		values, err := value.Array().Values()
+		if err != nil {
+			break
+		}
+
+		items := []string{}
+		for _, item := range values {
+			items = append(items, formatAuditValue(item))
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	}
+
+	return value.String()
+}
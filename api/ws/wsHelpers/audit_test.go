@@ -0,0 +1,100 @@
+package wsHelpers
+
+import (
+	"fmt"
+	"strings"
+
+	protos "github.com/pixlise/core/v4/generated-protos"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func printAuditChanges(changes []*protos.AuditFieldChange, err error) {
+	fmt.Printf("err: %v\n", err)
+	for _, change := range changes {
+		fmt.Printf("  %v: [%v] -> [%v]\n", change.Field, change.Before, change.After)
+	}
+}
+
+func Example_makeAuditChanges() {
+	before := &protos.ROIItem{
+		Id:                      "roi123",
+		ScanId:                  "scan456",
+		Name:                    "Dark spots",
+		Description:             "Darker area",
+		ScanEntryIndexesEncoded: []int32{1, 2, 3},
+		ModifiedUnixSec:         1000,
+	}
+	after := &protos.ROIItem{
+		Id:                      "roi123",
+		ScanId:                  "scan456",
+		Name:                    "Dark spots",
+		Description:             "Darker area near the vein",
+		ScanEntryIndexesEncoded: []int32{1, 2, 3, 4},
+		ModifiedUnixSec:         1010,
+	}
+
+	fmt.Println("Created:")
+	printAuditChanges(makeAuditChanges(nil, &protos.ExpressionGroup{Id: "grp1", Name: "Group", ModifiedUnixSec: 1000}))
+
+	fmt.Println("Updated:")
+	printAuditChanges(makeAuditChanges(before, after))
+
+	fmt.Println("Unchanged:")
+	printAuditChanges(makeAuditChanges(before, before))
+
+	fmt.Println("Shared:")
+	printAuditChanges(makeAuditChanges(
+		&protos.OwnershipItem{Id: "roi123", Viewers: &protos.UserGroupList{UserIds: []string{"user1"}}},
+		&protos.OwnershipItem{Id: "roi123", Viewers: &protos.UserGroupList{UserIds: []string{"user1", "user2"}, GroupIds: []string{"group1"}}},
+	))
+
+	fmt.Println("Deleted, failed to read:")
+	printAuditChanges(makeAuditChanges(bson.Raw(nil), nil))
+
+	// Output:
+	// Created:
+	// err: <nil>
+	//   modifiedunixsec: [] -> [1000]
+	//   name: [] -> [Group]
+	// Updated:
+	// err: <nil>
+	//   description: [Darker area] -> [Darker area near the vein]
+	//   modifiedunixsec: [1000] -> [1010]
+	//   scanentryindexesencoded: [[1, 2, 3]] -> [[1, 2, 3, 4]]
+	// Unchanged:
+	// err: <nil>
+	// Shared:
+	// err: <nil>
+	//   viewers.groupids: [] -> [[group1]]
+	//   viewers.userids: [[user1]] -> [[user1, user2]]
+	// Deleted, failed to read:
+	// err: <nil>
+}
+
+func Example_flattenAuditFields() {
+	doc, err := bson.Marshal(bson.D{
+		{Key: "_id", Value: "abc"},
+		{Key: "name", Value: "Item"},
+		{Key: "source", Value: strings.Repeat("x", maxAuditValueLength+10)},
+		{Key: "nested", Value: bson.D{{Key: "_id", Value: "nested-id"}, {Key: "count", Value: 3}}},
+	})
+	fmt.Printf("%v\n", err)
+
+	fields := map[string]string{}
+	fmt.Printf("%v\n", flattenAuditFields("", doc, fields))
+
+	fmt.Println(len(fields))
+	fmt.Println(fields["name"])
+	fmt.Println(fields["nested._id"])
+	fmt.Println(fields["nested.count"])
+	fmt.Println(len(fields["source"]), strings.HasSuffix(fields["source"], fmt.Sprintf("... (%v chars)", maxAuditValueLength+10)))
+
+	// Output:
+	// <nil>
+	// <nil>
+	// 4
+	// Item
+	// nested-id
+	// 3
+	// 515 true
+}
@@ -0,0 +1,277 @@
+package wsHelpers
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	dataImportHelpers "github.com/pixlise/core/v4/api/dataimport/dataimportHelpers"
+	"github.com/pixlise/core/v4/api/dbCollections"
+	"github.com/pixlise/core/v4/api/filepaths"
+	"github.com/pixlise/core/v4/api/services"
+	"github.com/pixlise/core/v4/core/fileaccess"
+	"github.com/pixlise/core/v4/core/logger"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Collections we restore documents from when restoring a single scan. Order matters, later ones are matched by ids found
+// in earlier ones (beam locations by image, ownership by scan/ROI/quant)
+var scanRestoreCollections = []string{
+	dbCollections.ScansName,
+	dbCollections.ScanDefaultImagesName,
+	dbCollections.ImagesName,
+	dbCollections.ImageBeamLocationsName,
+	dbCollections.RegionsOfInterestName,
+	dbCollections.QuantificationsName,
+	dbCollections.OwnershipName,
+}
+
+// What we found while reading the DB dump for a scan, so we know which files to restore with it
+type scanRestoreItems struct {
+	scanId     string
+	imageIds   []string
+	quantFiles []string
+	ownedIds   map[string]bool
+}
+
+func makeScanRestoreItems(scanId string) *scanRestoreItems {
+	return &scanRestoreItems{scanId: scanId, imageIds: []string{}, quantFiles: []string{}, ownedIds: map[string]bool{}}
+}
+
+// Returns true if the document from the given collection belongs to the scan being restored
+func (s *scanRestoreItems) match(collection string, doc bson.Raw) (bool, error) {
+	id, _ := doc.Lookup("_id").StringValueOK()
+
+	switch collection {
+	case dbCollections.ScansName:
+		if id == s.scanId {
+			s.ownedIds[id] = true
+			return true, nil
+		}
+	case dbCollections.ScanDefaultImagesName:
+		return id == s.scanId, nil
+	case dbCollections.ImagesName:
+		isForScan := lookupString(doc, "originscanid") == s.scanId
+		if !isForScan {
+			if scanIds, ok := doc.Lookup("associatedscanids").ArrayOK(); ok {
+				values, _ := scanIds.Values()
+				for _, v := range values {
+					if scanId, _ := v.StringValueOK(); scanId == s.scanId {
+						isForScan = true
+						break
+					}
+				}
+			}
+		}
+		if isForScan {
+			s.imageIds = append(s.imageIds, id)
+			return true, nil
+		}
+	case dbCollections.ImageBeamLocationsName:
+		for _, imageId := range s.imageIds {
+			if id == imageId || id == dataImportHelpers.GetImageNameSansVersion(imageId) {
+				return true, nil
+			}
+		}
+	case dbCollections.RegionsOfInterestName:
+		if lookupString(doc, "scanid") == s.scanId {
+			s.ownedIds[id] = true
+			return true, nil
+		}
+	case dbCollections.QuantificationsName:
+		if lookupString(doc, "scanid") == s.scanId {
+			s.ownedIds[id] = true
+
+			item := quantFileItem{}
+			err := bson.Unmarshal(doc, &item)
+			if err != nil {
+				return false, err
+			}
+
+			quantFiles, err := makeRelativePaths(item.filePaths(), filepaths.RootQuantificationPath)
+			if err != nil {
+				return false, err
+			}
+			s.quantFiles = append(s.quantFiles, quantFiles...)
+			return true, nil
+		}
+	case dbCollections.OwnershipName:
+		return s.ownedIds[id], nil
+	}
+
+	return false, nil
+}
+
+func lookupString(doc bson.Raw, key string) string {
+	value, _ := doc.Lookup(key).StringValueOK()
+	return value
+}
+
+// Reads a gzipped BSON file as written by mongodump, calling onDoc for each document in it
+func readBSONDump(stream io.Reader, onDoc func(doc bson.Raw) error) error {
+	reader, err := gzip.NewReader(stream)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	// File is just a sequence of documents, each starting with its length
+	for {
+		lenBytes := make([]byte, 4)
+		_, err = io.ReadFull(reader, lenBytes)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		docLen := binary.LittleEndian.Uint32(lenBytes)
+		if docLen < 5 {
+			return fmt.Errorf("Invalid BSON document length: %v", docLen)
+		}
+
+		doc := make([]byte, docLen)
+		copy(doc, lenBytes)
+		_, err = io.ReadFull(reader, doc[4:])
+		if err != nil {
+			return err
+		}
+
+		err = onDoc(doc)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// Reads the documents of each collection in scanRestoreCollections which belong to the scan, from the backup set's DB dump
+func readScanBackupDocs(backupBucket string, manifest *BackupManifest, items *scanRestoreItems, fs fileaccess.FileAccess, jobLog logger.ILogger) (map[string][]bson.Raw, error) {
+	result := map[string][]bson.Raw{}
+	for _, collection := range scanRestoreCollections {
+		dumpPath := path.Join(GetBackupSetPath(manifest.SetId), "DB", collection+".bson.gz")
+		stream, err := fs.ReadObjectStream(backupBucket, dumpPath)
+		if err != nil {
+			if fs.IsNotFoundError(err) {
+				jobLog.Infof(" Restore scan: no DB dump found for collection %v", collection)
+				continue
+			}
+			return result, err
+		}
+
+		docs := []bson.Raw{}
+		err = readBSONDump(stream, func(doc bson.Raw) error {
+			matched, err := items.match(collection, doc)
+			if matched {
+				docs = append(docs, doc)
+			}
+			return err
+		})
+		stream.Close()
+
+		if err != nil {
+			return result, fmt.Errorf("Failed to read DB dump %v: %v", dumpPath, err)
+		}
+
+		result[collection] = docs
+	}
+
+	return result, nil
+}
+
+// RestoreScanFromBackupSet - Restores a single scan from a backup set: its DB items, images, quants and ROIs, and the
+// files for the scan, images and quants. DB items are overwritten with the backed up version, others are left alone
+func RestoreScanFromBackupSet(scanId string, manifest *BackupManifest, svcs *services.APIServices) error {
+	ctx := context.TODO()
+	backupBucket := svcs.Config.DataBackupBucket
+
+	items := makeScanRestoreItems(scanId)
+	docs, err := readScanBackupDocs(backupBucket, manifest, items, svcs.FS, svcs.Log)
+	if err != nil {
+		return err
+	}
+
+	if len(docs[dbCollections.ScansName]) <= 0 {
+		return fmt.Errorf("Scan %v not found in backup set %v", scanId, manifest.SetId)
+	}
+
+	for _, collection := range scanRestoreCollections {
+		coll := svcs.MongoDB.Collection(collection)
+		for _, doc := range docs[collection] {
+			_, err = coll.ReplaceOne(ctx, bson.M{"_id": doc.Lookup("_id")}, doc, options.Replace().SetUpsert(true))
+			if err != nil {
+				return fmt.Errorf("Failed to restore %v item %v: %v", collection, doc.Lookup("_id"), err)
+			}
+		}
+
+		svcs.Log.Infof(" Restore scan %v: %v %v items restored", scanId, len(docs[collection]), collection)
+	}
+
+	scanFiles := []string{}
+	for relPath := range manifest.Files[filepaths.DatasetScansRoot] {
+		if strings.HasPrefix(relPath, scanId+"/") {
+			scanFiles = append(scanFiles, relPath)
+		}
+	}
+
+	err = deleteDatasetChunksNotInBackupSet(scanId, manifest, svcs.Config.DatasetsBucket, svcs.FS, svcs.Log)
+	if err != nil {
+		return err
+	}
+
+	err = restoreFilesFromBackupSet(backupBucket, manifest, filepaths.DatasetScansRoot, scanFiles, svcs.Config.DatasetsBucket, svcs.FS, svcs.Log)
+	if err != nil {
+		return err
+	}
+
+	// So we don't serve the files we had before the restore
+	ClearCacheForScanId(scanId, svcs.TimeStamper, svcs.Log)
+
+	err = restoreFilesFromBackupSet(backupBucket, manifest, filepaths.DatasetImagesRoot, items.imageIds, svcs.Config.DatasetsBucket, svcs.FS, svcs.Log)
+	if err != nil {
+		return err
+	}
+
+	return restoreFilesFromBackupSet(backupBucket, manifest, filepaths.RootQuantificationPath, items.quantFiles, svcs.Config.UsersBucket, svcs.FS, svcs.Log)
+}
+
+// Chunks of the dataset file are read instead of it if there's a chunk index, so any chunk files the backup set doesn't
+// have (eg it was made before the scan was re-imported, or before we backed up chunks) are deleted. Otherwise they'd be
+// served instead of the restored dataset file
+func deleteDatasetChunksNotInBackupSet(scanId string, manifest *BackupManifest, datasetsBucket string, fs fileaccess.FileAccess, jobLog logger.ILogger) error {
+	scanRoot := path.Join(filepaths.DatasetScansRoot, scanId)
+	files, err := fs.ListObjects(datasetsBucket, scanRoot+"/")
+	if err != nil {
+		return err
+	}
+
+	backedUp := manifest.Files[filepaths.DatasetScansRoot]
+	for _, f := range files {
+		if !filepaths.IsDatasetChunkFileName(path.Base(f)) {
+			continue
+		}
+
+		if _, ok := backedUp[path.Join(scanId, path.Base(f))]; ok {
+			continue
+		}
+
+		jobLog.Infof(" Restore scan %v: deleting dataset chunk file not in backup set: %v", scanId, f)
+		err = fs.DeleteObject(datasetsBucket, f)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RestoreBackupSetFiles - Restores all files of root from the backup set, to the given bucket
+func RestoreBackupSetFiles(manifest *BackupManifest, root string, destBucket string, svcs *services.APIServices) error {
+	return restoreFilesFromBackupSet(svcs.Config.DataBackupBucket, manifest, root, nil, destBucket, svcs.FS, svcs.Log)
+}
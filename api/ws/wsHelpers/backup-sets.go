@@ -0,0 +1,310 @@
+package wsHelpers
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pixlise/core/v4/core/fileaccess"
+	"github.com/pixlise/core/v4/core/logger"
+)
+
+// Backups are written as sets, each in its own directory under BackupSetsRoot in the backup bucket, named by the time
+// the backup started. Each set has its own DB dump, and a manifest listing all scan, image and quant files in the backup.
+// Files that haven't changed since the previous backup are not copied again, the manifest refers to the earlier set that
+// stores them instead. The manifest is written last, and is only marked complete if everything succeeded, so a failed
+// backup never replaces a good one.
+const BackupSetsRoot = "Backups"
+
+const backupManifestFileName = "manifest.json"
+
+// BackupFile - A file in a backup set, and which set it is actually stored in
+type BackupFile struct {
+	fileaccess.ObjectInfo
+	SetId string `json:"setId"`
+}
+
+type BackupManifest struct {
+	SetId            string `json:"setId"`
+	TimeStampUnixSec int64  `json:"timeStampUnixSec"`
+	Complete         bool   `json:"complete"`
+	DBName           string `json:"dbName"`
+
+	// Root dir files were backed up from (eg filepaths.DatasetScansRoot) -> path relative to root -> file
+	Files map[string]map[string]BackupFile `json:"files"`
+}
+
+// Returns how many files the set contains, and how many of those are stored in this set (as opposed to an earlier one)
+func (m *BackupManifest) FileCounts() (uint32, uint32) {
+	total := uint32(0)
+	copied := uint32(0)
+	for _, files := range m.Files {
+		for _, file := range files {
+			total++
+			if file.SetId == m.SetId {
+				copied++
+			}
+		}
+	}
+	return total, copied
+}
+
+// MakeBackupSetId - Set ids are the UTC start time of the backup, so they sort in time order
+func MakeBackupSetId(unixSec int64) string {
+	return time.Unix(unixSec, 0).UTC().Format("2006-01-02T15-04-05Z")
+}
+
+func GetBackupSetPath(setId string) string {
+	return path.Join(BackupSetsRoot, setId)
+}
+
+// Where a file from root with the given relative path is stored in the backup bucket
+func getBackupFilePath(file BackupFile, root string, relPath string) string {
+	return path.Join(GetBackupSetPath(file.SetId), root, relPath)
+}
+
+func WriteBackupManifest(bucket string, manifest *BackupManifest, fs fileaccess.FileAccess) error {
+	return fs.WriteJSON(bucket, path.Join(GetBackupSetPath(manifest.SetId), backupManifestFileName), manifest)
+}
+
+// ListBackupSets - Reads the manifest of each backup set in the bucket, returning them newest first. Sets without a
+// manifest (backups still running, or ones which failed before writing it) are not returned
+func ListBackupSets(bucket string, fs fileaccess.FileAccess) ([]*BackupManifest, error) {
+	files, err := fs.ListObjects(bucket, BackupSetsRoot+"/")
+	if err != nil {
+		return []*BackupManifest{}, err
+	}
+
+	sets := []*BackupManifest{}
+	for _, file := range files {
+		parts := strings.Split(file, "/")
+		if len(parts) != 3 || parts[2] != backupManifestFileName {
+			continue
+		}
+
+		manifest := &BackupManifest{}
+		err = fs.ReadJSON(bucket, file, manifest, false)
+		if err != nil {
+			return []*BackupManifest{}, fmt.Errorf("Failed to read backup manifest %v: %v", file, err)
+		}
+
+		if manifest.Files == nil {
+			manifest.Files = map[string]map[string]BackupFile{}
+		}
+
+		sets = append(sets, manifest)
+	}
+
+	sort.Slice(sets, func(i, j int) bool { return sets[i].SetId > sets[j].SetId })
+	return sets, nil
+}
+
+// GetLatestCompleteBackupSet - Returns the newest complete set, nil if none. Expects sets as returned by ListBackupSets
+func GetLatestCompleteBackupSet(sets []*BackupManifest) *BackupManifest {
+	for _, set := range sets {
+		if set.Complete {
+			return set
+		}
+	}
+	return nil
+}
+
+// FindBackupSet - Returns the complete set with the given id, or the latest complete one if setId is empty
+func FindBackupSet(sets []*BackupManifest, setId string) (*BackupManifest, error) {
+	if len(setId) <= 0 {
+		set := GetLatestCompleteBackupSet(sets)
+		if set == nil {
+			return nil, fmt.Errorf("No complete backup sets found")
+		}
+		return set, nil
+	}
+
+	for _, set := range sets {
+		if set.SetId == setId {
+			if !set.Complete {
+				return nil, fmt.Errorf("Backup set %v is not complete", setId)
+			}
+			return set, nil
+		}
+	}
+
+	return nil, fmt.Errorf("Backup set %v not found", setId)
+}
+
+// Copies files from source root into the backup set, unless their size and checksum are the same as in the previous set.
+// Returns the manifest entries for all files, so unchanged ones refer to the set they were copied to earlier. Like
+// syncFiles, files that fail to copy are logged and left out, so one missing file doesn't fail the whole backup
+func syncFilesToBackupSet(srcBucket string, srcRoot string, srcRelativePaths []string, backupBucket string, setId string, prev *BackupManifest, fs fileaccess.FileAccess, jobLog logger.ILogger) (map[string]BackupFile, error) {
+	jobLog.Infof(" Backup s3://%v/%v to set %v starting (%v files)...", srcBucket, srcRoot, setId, len(srcRelativePaths))
+
+	srcInfos, err := fileaccess.ListObjectInfo(fs, srcBucket, srcRoot)
+	if err != nil {
+		return map[string]BackupFile{}, err
+	}
+
+	prevFiles := map[string]BackupFile{}
+	if prev != nil && prev.Files[srcRoot] != nil {
+		prevFiles = prev.Files[srcRoot]
+	}
+
+	result := map[string]BackupFile{}
+	copied := 0
+	for _, relPath := range srcRelativePaths {
+		srcFullPath := path.Join(srcRoot, relPath)
+		info, ok := srcInfos[srcFullPath]
+		if !ok {
+			jobLog.Errorf(" Backup source file not found: s3://%v/%v", srcBucket, srcFullPath)
+			continue
+		}
+
+		if prevFile, ok := prevFiles[relPath]; ok && prevFile.ObjectInfo == info {
+			result[relPath] = prevFile
+			continue
+		}
+
+		if copied%100 == 0 {
+			jobLog.Infof(" Backup %v: %v copied...", srcRoot, copied)
+		}
+
+		file := BackupFile{ObjectInfo: info, SetId: setId}
+		err = fs.CopyObject(srcBucket, srcFullPath, backupBucket, getBackupFilePath(file, srcRoot, relPath))
+		if err != nil {
+			jobLog.Errorf(" Backup error copying s3://%v/%v: %v", srcBucket, srcFullPath, err)
+			continue
+		}
+
+		result[relPath] = file
+		copied++
+	}
+
+	jobLog.Infof(" Backup %v to set %v complete: %v copied, %v unchanged", srcRoot, setId, copied, len(result)-copied)
+	return result, nil
+}
+
+// Copies files of root from the backup set back to destBucket, skipping ones which are already the same there. If
+// relPaths is nil all files are restored
+func restoreFilesFromBackupSet(backupBucket string, manifest *BackupManifest, root string, relPaths []string, destBucket string, fs fileaccess.FileAccess, jobLog logger.ILogger) error {
+	files := manifest.Files[root]
+	if relPaths == nil {
+		relPaths = []string{}
+		for relPath := range files {
+			relPaths = append(relPaths, relPath)
+		}
+		sort.Strings(relPaths)
+	}
+
+	destInfos, err := fileaccess.ListObjectInfo(fs, destBucket, root)
+	if err != nil {
+		return err
+	}
+
+	jobLog.Infof(" Restore %v files of %v from backup set %v starting...", len(relPaths), root, manifest.SetId)
+
+	copied := 0
+	for _, relPath := range relPaths {
+		file, ok := files[relPath]
+		if !ok {
+			jobLog.Errorf(" Restore file %v not in backup set %v", path.Join(root, relPath), manifest.SetId)
+			continue
+		}
+
+		destPath := path.Join(root, relPath)
+		if destInfos[destPath] == file.ObjectInfo {
+			continue
+		}
+
+		srcPath := getBackupFilePath(file, root, relPath)
+		err = fs.CopyObject(backupBucket, srcPath, destBucket, destPath)
+		if err != nil {
+			jobLog.Errorf(" Restore error copying s3://%v/%v: %v", backupBucket, srcPath, err)
+			continue
+		}
+		copied++
+	}
+
+	jobLog.Infof(" Restore %v from backup set %v complete: %v copied, %v already up to date", root, manifest.SetId, copied, len(relPaths)-copied)
+	return nil
+}
+
+// ApplyBackupRetention - Deletes backup sets we no longer need. A complete set is kept if it's one of the newest keepCount
+// complete sets, or is less than keepDays old. If both are 0, all complete sets are kept. The latest complete set is
+// always kept, and incomplete sets are deleted once there's a newer complete one. Files stored in a deleted set which
+// are still referenced by a kept set are not deleted. Returns the ids of the sets deleted
+func ApplyBackupRetention(bucket string, keepCount uint, keepDays uint, nowUnixSec int64, fs fileaccess.FileAccess, jobLog logger.ILogger) ([]string, error) {
+	sets, err := ListBackupSets(bucket, fs)
+	if err != nil {
+		return []string{}, err
+	}
+
+	keep := []*BackupManifest{}
+	remove := []*BackupManifest{}
+	completeCount := uint(0)
+	for _, set := range sets {
+		keepSet := false
+		if set.Complete {
+			keepSet = completeCount == 0 ||
+				(keepCount == 0 && keepDays == 0) ||
+				completeCount < keepCount ||
+				nowUnixSec-set.TimeStampUnixSec < int64(keepDays)*24*60*60
+			completeCount++
+		} else {
+			// Failed backups are kept until we have a newer good one
+			keepSet = completeCount == 0
+		}
+
+		if keepSet {
+			keep = append(keep, set)
+		} else {
+			remove = append(remove, set)
+		}
+	}
+
+	// Work out what files kept sets still need
+	referenced := map[string]bool{}
+	for _, set := range keep {
+		for root, files := range set.Files {
+			for relPath, file := range files {
+				referenced[getBackupFilePath(file, root, relPath)] = true
+			}
+		}
+	}
+
+	deleted := []string{}
+	for _, set := range remove {
+		setPath := GetBackupSetPath(set.SetId)
+		files, err := fs.ListObjects(bucket, setPath+"/")
+		if err != nil {
+			return deleted, err
+		}
+
+		// Delete the manifest first, so if we fail part way, the set isn't seen as restorable
+		manifestPath := path.Join(setPath, backupManifestFileName)
+		err = fs.DeleteObject(bucket, manifestPath)
+		if err != nil {
+			return deleted, err
+		}
+
+		kept := 0
+		for _, file := range files {
+			if file == manifestPath {
+				continue
+			}
+			if referenced[file] {
+				kept++
+				continue
+			}
+
+			err = fs.DeleteObject(bucket, file)
+			if err != nil {
+				return deleted, err
+			}
+		}
+
+		jobLog.Infof("Deleted backup set %v, kept %v files still referenced by newer sets", set.SetId, kept)
+		deleted = append(deleted, set.SetId)
+	}
+
+	return deleted, nil
+}
@@ -0,0 +1,276 @@
+package wsHelpers
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/pixlise/core/v4/api/dbCollections"
+	"github.com/pixlise/core/v4/core/fileaccess"
+	"github.com/pixlise/core/v4/core/logger"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func printBackupFiles(files map[string]BackupFile) {
+	paths := []string{}
+	for p := range files {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	for _, p := range paths {
+		fmt.Printf("  %v: size %v, set %v\n", p, files[p].Size, files[p].SetId)
+	}
+}
+
+func printBucket(fs fileaccess.FileAccess, bucket string, prefix string) {
+	files, _ := fs.ListObjects(bucket, prefix)
+	sort.Strings(files)
+	fmt.Printf("%v files: %v\n", prefix, strings.Join(files, ", "))
+}
+
+func Example_syncFilesToBackupSet() {
+	fs := fileaccess.MakeMemoryAccess()
+	l := &logger.StdOutLoggerForTest{}
+
+	fs.WriteObject("datasets", "Scans/123/dataset.bin", []byte{1, 2, 3})
+	fs.WriteObject("datasets", "Scans/123/diffraction-db.bin", []byte{4})
+	fs.WriteObject("datasets", "Scans/456/dataset.bin", []byte{5, 6})
+
+	scanFiles := []string{"123/dataset.bin", "123/diffraction-db.bin", "456/dataset.bin", "456/diffraction-db.bin"}
+
+	// First backup copies everything it can find
+	first, err := syncFilesToBackupSet("datasets", "Scans", scanFiles, "backup", "set1", nil, fs, l)
+	fmt.Printf("First: %v\n", err)
+	printBackupFiles(first)
+	fmt.Printf("Missing file logged: %v\n", l.LogContains("Backup source file not found: s3://datasets/Scans/456/diffraction-db.bin"))
+
+	// Second only copies what changed
+	fs.WriteObject("datasets", "Scans/456/dataset.bin", []byte{5, 7})
+	fs.WriteObject("datasets", "Scans/456/diffraction-db.bin", []byte{8})
+
+	prev := &BackupManifest{SetId: "set1", Complete: true, Files: map[string]map[string]BackupFile{"Scans": first}}
+	second, err := syncFilesToBackupSet("datasets", "Scans", scanFiles, "backup", "set2", prev, fs, l)
+	fmt.Printf("Second: %v\n", err)
+	printBackupFiles(second)
+
+	printBucket(fs, "backup", "Backups")
+
+	// Output:
+	// First: <nil>
+	//   123/dataset.bin: size 3, set set1
+	//   123/diffraction-db.bin: size 1, set set1
+	//   456/dataset.bin: size 2, set set1
+	// Missing file logged: true
+	// Second: <nil>
+	//   123/dataset.bin: size 3, set set1
+	//   123/diffraction-db.bin: size 1, set set1
+	//   456/dataset.bin: size 2, set set2
+	//   456/diffraction-db.bin: size 1, set set2
+	// Backups files: Backups/set1/Scans/123/dataset.bin, Backups/set1/Scans/123/diffraction-db.bin, Backups/set1/Scans/456/dataset.bin, Backups/set2/Scans/456/dataset.bin, Backups/set2/Scans/456/diffraction-db.bin
+}
+
+func Example_restoreFilesFromBackupSet() {
+	fs := fileaccess.MakeMemoryAccess()
+	l := &logger.NullLogger{}
+
+	fs.WriteObject("datasets", "Images/123/context.png", []byte{1, 2, 3})
+	fs.WriteObject("datasets", "Images/123/other.png", []byte{4})
+	fs.WriteObject("datasets", "Images/456/context.png", []byte{5})
+
+	files, _ := syncFilesToBackupSet("datasets", "Images", []string{"123/context.png", "123/other.png", "456/context.png"}, "backup", "set1", nil, fs, l)
+	manifest := &BackupManifest{SetId: "set1", Complete: true, Files: map[string]map[string]BackupFile{"Images": files}}
+
+	// Break one, delete another, restoring one scan's images shouldn't touch the other
+	fs.WriteObject("datasets", "Images/123/context.png", []byte{9, 9, 9})
+	fs.DeleteObject("datasets", "Images/123/other.png")
+	fs.DeleteObject("datasets", "Images/456/context.png")
+
+	fmt.Printf("Restore: %v\n", restoreFilesFromBackupSet("backup", manifest, "Images", []string{"123/context.png", "123/other.png"}, "datasets", fs, l))
+	printBucket(fs, "datasets", "Images")
+
+	data, _ := fs.ReadObject("datasets", "Images/123/context.png")
+	fmt.Printf("Restored: %v\n", data)
+
+	// Restoring all
+	fmt.Printf("Restore all: %v\n", restoreFilesFromBackupSet("backup", manifest, "Images", nil, "datasets", fs, l))
+	printBucket(fs, "datasets", "Images")
+
+	// Output:
+	// Restore: <nil>
+	// Images files: Images/123/context.png, Images/123/other.png
+	// Restored: [1 2 3]
+	// Restore all: <nil>
+	// Images files: Images/123/context.png, Images/123/other.png, Images/456/context.png
+}
+
+func ExampleApplyBackupRetention() {
+	fs := fileaccess.MakeMemoryAccess()
+	l := &logger.NullLogger{}
+	day := int64(24 * 60 * 60)
+	now := 100 * day
+
+	// Each set has its own DB dump, and a file, the first set has one that is never changed
+	writeSet := func(setId string, timeStamp int64, complete bool) {
+		files := map[string]BackupFile{
+			"changing.bin": {SetId: setId},
+			"constant.bin": {SetId: "set1"},
+		}
+		for relPath, file := range files {
+			if file.SetId == setId {
+				fs.WriteObject("backup", path.Join(GetBackupSetPath(setId), "Scans", relPath), []byte{1})
+			}
+		}
+		fs.WriteObject("backup", path.Join(GetBackupSetPath(setId), "DB", "scans.bson.gz"), []byte{1})
+		WriteBackupManifest("backup", &BackupManifest{SetId: setId, TimeStampUnixSec: timeStamp, Complete: complete, Files: map[string]map[string]BackupFile{"Scans": files}}, fs)
+	}
+
+	writeSet("set1", now-40*day, true)
+	writeSet("set2", now-20*day, false)
+	writeSet("set3", now-10*day, true)
+	writeSet("set4", now-5*day, true)
+	writeSet("set5", now-1*day, false)
+
+	// No limits, only the failed set that's older than a good one is deleted
+	deleted, err := ApplyBackupRetention("backup", 0, 0, now, fs, l)
+	fmt.Printf("No limits: %v, %v\n", err, deleted)
+
+	// Keep 1 or anything newer than 7 days
+	deleted, err = ApplyBackupRetention("backup", 1, 7, now, fs, l)
+	fmt.Printf("Keep 1 or 7 days: %v, %v\n", err, deleted)
+
+	// Set 1's file is still needed
+	printBucket(fs, "backup", "Backups/set1")
+
+	sets, err := ListBackupSets("backup", fs)
+	fmt.Printf("Remaining: %v\n", err)
+	for _, set := range sets {
+		fmt.Printf("  %v complete: %v\n", set.SetId, set.Complete)
+	}
+
+	// Even with a 0 day limit, the latest good one is kept
+	deleted, err = ApplyBackupRetention("backup", 0, 1, now+10*day, fs, l)
+	fmt.Printf("1 day: %v, %v\n", err, deleted)
+
+	latest, err := FindBackupSet(sets, "")
+	fmt.Printf("Latest: %v, %v\n", latest.SetId, err)
+	_, err = FindBackupSet(sets, "set5")
+	fmt.Printf("Incomplete: %v\n", err)
+	_, err = FindBackupSet(sets, "set3")
+	fmt.Printf("Deleted: %v\n", err)
+
+	// Output:
+	// No limits: <nil>, [set2]
+	// Keep 1 or 7 days: <nil>, [set3 set1]
+	// Backups/set1 files: Backups/set1/Scans/constant.bin
+	// Remaining: <nil>
+	//   set5 complete: false
+	//   set4 complete: true
+	// 1 day: <nil>, []
+	// Latest: set4, <nil>
+	// Incomplete: Backup set set5 is not complete
+	// Deleted: Backup set set3 not found
+}
+
+func Example_readScanBackupDocs() {
+	fs := fileaccess.MakeMemoryAccess()
+	l := &logger.NullLogger{}
+
+	writeDump := func(collection string, docs []bson.M) {
+		var buf bytes.Buffer
+		w := gzip.NewWriter(&buf)
+		for _, doc := range docs {
+			b, _ := bson.Marshal(doc)
+			w.Write(b)
+		}
+		w.Close()
+		fs.WriteObject("backup", path.Join(GetBackupSetPath("set1"), "DB", collection+".bson.gz"), buf.Bytes())
+	}
+
+	writeDump(dbCollections.ScansName, []bson.M{{"_id": "123", "title": "Mine"}, {"_id": "456", "title": "Other"}})
+	writeDump(dbCollections.ImagesName, []bson.M{
+		{"_id": "123/context.png", "originscanid": "123"},
+		{"_id": "456/context.png", "originscanid": "456", "associatedscanids": bson.A{"456", "123"}},
+		{"_id": "789/context.png", "originscanid": "789", "associatedscanids": bson.A{"789"}},
+	})
+	writeDump(dbCollections.ImageBeamLocationsName, []bson.M{{"_id": "123/context.png"}, {"_id": "789/context.png"}})
+	writeDump(dbCollections.RegionsOfInterestName, []bson.M{{"_id": "roi1", "scanid": "123"}, {"_id": "roi2", "scanid": "456"}})
+	writeDump(dbCollections.QuantificationsName, []bson.M{
+		{"_id": "quant1", "scanid": "123", "status": bson.M{"outputfilepath": "Quantifications/123/user1"}},
+		{"_id": "quant2", "scanid": "456", "status": bson.M{"outputfilepath": "Quantifications/456/user1"}},
+	})
+	writeDump(dbCollections.OwnershipName, []bson.M{{"_id": "123"}, {"_id": "roi1"}, {"_id": "roi2"}, {"_id": "quant1"}})
+
+	items := makeScanRestoreItems("123")
+	docs, err := readScanBackupDocs("backup", &BackupManifest{SetId: "set1"}, items, fs, l)
+	fmt.Printf("%v\n", err)
+	for _, collection := range scanRestoreCollections {
+		ids := []string{}
+		for _, doc := range docs[collection] {
+			ids = append(ids, lookupString(doc, "_id"))
+		}
+		fmt.Printf("%v: %v\n", collection, ids)
+	}
+	fmt.Printf("Images: %v\nQuant files: %v\n", items.imageIds, items.quantFiles)
+
+	// Output:
+	// <nil>
+	// scans: [123]
+	// scanDefaultImages: []
+	// images: [123/context.png 456/context.png]
+	// imageBeamLocations: [123/context.png]
+	// regionsOfInterest: [roi1]
+	// quantifications: [quant1]
+	// ownership: [123 roi1 quant1]
+	// Images: [123/context.png 456/context.png]
+	// Quant files: [123/user1/quant1.bin 123/user1/quant1.csv]
+}
+
+func Example_makeScanFileList() {
+	existing := []string{"123/dataset.bin", "123/dataset-chunks.bin", "123/dataset-spectra-0.bin", "123/dataset-spectra-1.bin", "123/context.png", "456/dataset.bin", "789/dataset-chunks.bin"}
+	fmt.Println(strings.Join(makeScanFileList([]string{"123", "456"}, existing), "\n"))
+
+	// Output:
+	// 123/dataset.bin
+	// 123/diffraction-db.bin
+	// 123/dataset-chunks.bin
+	// 123/dataset-spectra-0.bin
+	// 123/dataset-spectra-1.bin
+	// 456/dataset.bin
+	// 456/diffraction-db.bin
+}
+
+func Example_deleteDatasetChunksNotInBackupSet() {
+	fs := fileaccess.MakeMemoryAccess()
+	l := &logger.NullLogger{}
+
+	// Backed up before the scan was chunked
+	fs.WriteObject("datasets", "Scans/123/dataset.bin", []byte{1})
+	files, _ := syncFilesToBackupSet("datasets", "Scans", []string{"123/dataset.bin"}, "backup", "set1", nil, fs, l)
+	manifest := &BackupManifest{SetId: "set1", Complete: true, Files: map[string]map[string]BackupFile{"Scans": files}}
+
+	// Re-imported since, so now has chunks, which would be read instead of the restored dataset file
+	fs.WriteObject("datasets", "Scans/123/dataset-chunks.bin", []byte{2})
+	fs.WriteObject("datasets", "Scans/123/dataset-spectra-0.bin", []byte{3})
+	fs.WriteObject("datasets", "Scans/123/context.png", []byte{4})
+	fs.WriteObject("datasets", "Scans/456/dataset-chunks.bin", []byte{5})
+
+	fmt.Printf("Delete: %v\n", deleteDatasetChunksNotInBackupSet("123", manifest, "datasets", fs, l))
+	printBucket(fs, "datasets", "Scans")
+
+	// Chunks that are in the backup set are left to be restored
+	fs.WriteObject("datasets", "Scans/123/dataset-chunks.bin", []byte{2})
+	files, _ = syncFilesToBackupSet("datasets", "Scans", []string{"123/dataset.bin", "123/dataset-chunks.bin"}, "backup", "set2", nil, fs, l)
+	manifest = &BackupManifest{SetId: "set2", Complete: true, Files: map[string]map[string]BackupFile{"Scans": files}}
+	fmt.Printf("Delete: %v\n", deleteDatasetChunksNotInBackupSet("123", manifest, "datasets", fs, l))
+	printBucket(fs, "datasets", "Scans")
+
+	// Output:
+	// Delete: <nil>
+	// Scans files: Scans/123/context.png, Scans/123/dataset.bin, Scans/456/dataset-chunks.bin
+	// Delete: <nil>
+	// Scans files: Scans/123/context.png, Scans/123/dataset-chunks.bin, Scans/123/dataset.bin, Scans/456/dataset-chunks.bin
+}
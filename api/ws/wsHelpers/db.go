@@ -50,9 +50,19 @@ func DeleteUserObjectByIdField(idField string, objectId string, objectType proto
 	}
 	defer sess.EndSession(ctx)
 
+	// What we deleted, so it can be written to the audit log. Reset in each callback in case the transaction is retried
+	deletedItems := map[string]bson.Raw{}
+
 	// Deleting in a single transaction - we have 2 copies of this, one calls DeleteOne, the other DeleteMany
 	callbackDeleteOne := func(sessCtx mongo.SessionContext) (interface{}, error) {
 		txResult := []string{}
+		deletedItems = map[string]bson.Raw{}
+
+		item, err := hctx.Svcs.MongoDB.Collection(collectionName).FindOne(context.TODO(), bson.M{idField: objectId}).Raw()
+		if err != nil && err != mongo.ErrNoDocuments {
+			return txResult, errorwithstatus.MakeBadRequestError(err)
+		}
+
 		result, err := hctx.Svcs.MongoDB.Collection(collectionName).DeleteOne(context.TODO(), bson.M{idField: objectId})
 		if err != nil {
 			return txResult, errorwithstatus.MakeBadRequestError(err)
@@ -71,22 +81,39 @@ func DeleteUserObjectByIdField(idField string, objectId string, objectType proto
 			return txResult, errorwithstatus.MakeNotFoundError(objectId)
 		}
 
+		deletedItems[objectId] = item
 		txResult = append(txResult, objectId)
 		return txResult, nil
 	}
 
 	callbackDeleteMany := func(sessCtx mongo.SessionContext) (interface{}, error) {
 		txResult := []string{}
+		deletedItems = map[string]bson.Raw{}
 
-		// First, lets get the ids of the individual ROI items here
+		// First, lets get the individual ROI items here
 		filter := bson.M{idField: objectId}
-		cursor, err := hctx.Svcs.MongoDB.Collection(collectionName).Find(context.TODO(), filter, options.Find().SetProjection(bson.D{{Key: "_id", Value: true}}))
-		ids := []*ItemWithId{}
-		err = cursor.All(context.TODO(), &ids)
+		cursor, err := hctx.Svcs.MongoDB.Collection(collectionName).Find(context.TODO(), filter)
+		if err != nil {
+			return txResult, errorwithstatus.MakeBadRequestError(err)
+		}
+
+		items := []bson.Raw{}
+		err = cursor.All(context.TODO(), &items)
 		if err != nil {
 			return txResult, errorwithstatus.MakeBadRequestError(err)
 		}
 
+		ids := []*ItemWithId{}
+		for _, item := range items {
+			id := &ItemWithId{}
+			err = bson.Unmarshal(item, id)
+			if err != nil {
+				return txResult, errorwithstatus.MakeBadRequestError(err)
+			}
+			ids = append(ids, id)
+			deletedItems[id.Id] = item
+		}
+
 		delResult, err := hctx.Svcs.MongoDB.Collection(collectionName).DeleteMany(context.TODO(), bson.M{idField: objectId})
 		if err != nil {
 			return txResult, errorwithstatus.MakeBadRequestError(err)
@@ -125,6 +152,11 @@ func DeleteUserObjectByIdField(idField string, objectId string, objectType proto
 		return []string{}, nil
 	}
 
+	for _, id := range delIds {
+		WriteAuditEntry(id, objectType, protos.AuditAction_AA_DELETE, deletedItems[id], nil, hctx)
+	}
+	DeleteObjectRevisions(delIds, objectType, hctx)
+
 	return delIds, nil
 }
 
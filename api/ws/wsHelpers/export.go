@@ -0,0 +1,312 @@
+package wsHelpers
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pixlise/core/v4/core/client"
+	"github.com/pixlise/core/v4/core/imageedit"
+	"github.com/pixlise/core/v4/core/indexcompression"
+	protos "github.com/pixlise/core/v4/generated-protos"
+)
+
+// Functions to generate the individual files that go into a scan export zip. These work purely on data that
+// has already been read (and access checked) by the caller, so they don't touch the DB or file system. Each
+// returns file contents keyed by file name, which the caller can write wherever it needs.
+
+type exportDetectorSpectrum struct {
+	detectorId string
+	counts     []int32
+	meta       map[string]string
+}
+
+// Fields we write into the MSA header if they're stored for each detector. These are written comma separated
+// in detector order like the multi-detector MSA files we import
+var msaPerDetectorFields = []string{"XPERCHAN", "OFFSET", "LIVETIME", "REALTIME"}
+
+// Generates an MSA file for each PMC and spectrum read type (Normal, Dwell, BulkSum, MaxValue) in the scan. All detectors
+// for the same read type are written as columns of the same file
+func MakeScanMSAFiles(exprPB *protos.Experiment) (map[string][]byte, error) {
+	detectorIdIdx := getMetaLabelIdx(exprPB, "DETECTOR_ID")
+	readTypeIdx := getMetaLabelIdx(exprPB, "READTYPE")
+
+	if detectorIdIdx < 0 || readTypeIdx < 0 {
+		return nil, fmt.Errorf("Scan %v does not contain DETECTOR_ID or READTYPE metadata, can't export spectra", exprPB.TargetId)
+	}
+
+	result := map[string][]byte{}
+	for locIdx, loc := range exprPB.Locations {
+		spectraByReadType := map[string][]exportDetectorSpectrum{}
+
+		for _, det := range loc.Detectors {
+			spectrum := exportDetectorSpectrum{meta: map[string]string{}}
+			readType := ""
+
+			for _, m := range det.Meta {
+				if m.LabelIdx < 0 || int(m.LabelIdx) >= len(exprPB.MetaLabels) || int(m.LabelIdx) >= len(exprPB.MetaTypes) {
+					return nil, fmt.Errorf("LabelIdx %v out of range when reading spectrum meta for location idx: %v", m.LabelIdx, locIdx)
+				}
+
+				value := metaValueString(m, exprPB.MetaTypes[m.LabelIdx])
+				if int(m.LabelIdx) == detectorIdIdx {
+					spectrum.detectorId = value
+				} else if int(m.LabelIdx) == readTypeIdx {
+					readType = value
+				} else {
+					spectrum.meta[exprPB.MetaLabels[m.LabelIdx]] = value
+				}
+			}
+
+			if len(spectrum.detectorId) <= 0 || len(readType) <= 0 {
+				return nil, fmt.Errorf("Failed to read detector id or read type for spectrum at location idx: %v", locIdx)
+			}
+
+			spectrum.counts = client.ZeroRunDecode(det.Spectrum)
+			spectraByReadType[readType] = append(spectraByReadType[readType], spectrum)
+		}
+
+		for readType, spectra := range spectraByReadType {
+			sort.Slice(spectra, func(i, j int) bool { return spectra[i].detectorId < spectra[j].detectorId })
+
+			msa, err := makeMSA(loc, spectra)
+			if err != nil {
+				return nil, fmt.Errorf("%v for PMC %v", err, loc.Id)
+			}
+
+			result[fmt.Sprintf("%v-%v.msa", loc.Id, readType)] = msa
+		}
+	}
+
+	return result, nil
+}
+
+func makeMSA(loc *protos.Experiment_Location, spectra []exportDetectorSpectrum) ([]byte, error) {
+	channelCount := len(spectra[0].counts)
+	for _, s := range spectra {
+		if len(s.counts) != channelCount {
+			return nil, fmt.Errorf("Detector %v has %v channels, expected %v", s.detectorId, len(s.counts), channelCount)
+		}
+	}
+
+	var b bytes.Buffer
+	b.WriteString("#FORMAT      : EMSA/MAS spectral data file\n")
+	b.WriteString("#VERSION     : TC202v2.0 PIXL\n")
+	b.WriteString("#TITLE       : PIXLISE export\n")
+	b.WriteString(fmt.Sprintf("#NPOINTS     : %v\n", channelCount))
+	b.WriteString(fmt.Sprintf("#NCOLUMNS    : %v\n", len(spectra)))
+	b.WriteString("#XUNITS      : eV\n")
+	b.WriteString("#YUNITS      : COUNTS\n")
+	if len(spectra) == 1 {
+		b.WriteString("#DATATYPE    : Y\n")
+		b.WriteString(fmt.Sprintf("#DETECTOR_ID : %v\n", spectra[0].detectorId))
+	} else {
+		b.WriteString("#DATATYPE    : YY\n")
+	}
+
+	for _, field := range msaPerDetectorFields {
+		values := []string{}
+		for _, s := range spectra {
+			if v, ok := s.meta[field]; ok {
+				values = append(values, v)
+			}
+		}
+
+		// Only write it if every detector had the value
+		if len(values) == len(spectra) {
+			b.WriteString(fmt.Sprintf("#%-11v : %v\n", field, strings.Join(values, ", ")))
+		}
+	}
+
+	b.WriteString("#SIGNALTYPE  : XRF\n")
+	if loc.Beam != nil {
+		b.WriteString(fmt.Sprintf("#XPOSITION   : %v\n", loc.Beam.X))
+		b.WriteString(fmt.Sprintf("#YPOSITION   : %v\n", loc.Beam.Y))
+		b.WriteString(fmt.Sprintf("#ZPOSITION   : %v\n", loc.Beam.Z))
+	}
+	b.WriteString(fmt.Sprintf("#PMC         : %v\n", loc.Id))
+	b.WriteString("#SPECTRUM    :\n")
+
+	for ch := 0; ch < channelCount; ch++ {
+		values := []string{}
+		for _, s := range spectra {
+			values = append(values, strconv.Itoa(int(s.counts[ch])))
+		}
+		b.WriteString(strings.Join(values, ", ") + "\n")
+	}
+
+	b.WriteString("#ENDOFDATA   :\n")
+	return b.Bytes(), nil
+}
+
+// Generates a CSV of beam locations for every PMC that has one
+func MakeScanBeamLocationCSV(exprPB *protos.Experiment) []byte {
+	var b bytes.Buffer
+	b.WriteString("PMC,X,Y,Z,GeomCorr,image_i,image_j\n")
+
+	for _, loc := range exprPB.Locations {
+		if loc.Beam != nil {
+			b.WriteString(fmt.Sprintf("%v,%v,%v,%v,%v,%v,%v\n", loc.Id, loc.Beam.X, loc.Beam.Y, loc.Beam.Z, loc.Beam.GeomCorr, loc.Beam.ImageI, loc.Beam.ImageJ))
+		}
+	}
+
+	return b.Bytes()
+}
+
+// Generates a CSV of all housekeeping (per-location, not per-detector) metadata. Columns are only output if at least
+// one location has a value for them
+func MakeScanHousekeepingCSV(exprPB *protos.Experiment) ([]byte, error) {
+	// Find all label indexes used by housekeeping data
+	usedLabelIdxs := map[int32]bool{}
+	for _, loc := range exprPB.Locations {
+		for _, m := range loc.Meta {
+			if m.LabelIdx < 0 || int(m.LabelIdx) >= len(exprPB.MetaLabels) || int(m.LabelIdx) >= len(exprPB.MetaTypes) {
+				return nil, fmt.Errorf("LabelIdx %v out of range when reading housekeeping for PMC: %v", m.LabelIdx, loc.Id)
+			}
+			if exprPB.MetaLabels[m.LabelIdx] != "PMC" {
+				usedLabelIdxs[m.LabelIdx] = true
+			}
+		}
+	}
+
+	labelIdxs := []int32{}
+	for idx := range usedLabelIdxs {
+		labelIdxs = append(labelIdxs, idx)
+	}
+	sort.Slice(labelIdxs, func(i, j int) bool { return labelIdxs[i] < labelIdxs[j] })
+
+	var b bytes.Buffer
+	b.WriteString("PMC")
+	for _, idx := range labelIdxs {
+		b.WriteString("," + exprPB.MetaLabels[idx])
+	}
+	b.WriteString("\n")
+
+	for _, loc := range exprPB.Locations {
+		if len(loc.Meta) <= 0 {
+			continue
+		}
+
+		values := map[int32]string{}
+		for _, m := range loc.Meta {
+			values[m.LabelIdx] = metaValueString(m, exprPB.MetaTypes[m.LabelIdx])
+		}
+
+		b.WriteString(loc.Id)
+		for _, idx := range labelIdxs {
+			b.WriteString("," + csvEscape(values[idx]))
+		}
+		b.WriteString("\n")
+	}
+
+	return b.Bytes(), nil
+}
+
+// Generates a CSV listing the PMCs in each ROI. ROIs store location indexes, these are converted to PMCs using the scan
+func MakeROIPMCsCSV(exprPB *protos.Experiment, rois []*protos.ROIItem) ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteString("ROIID,ROIName,PMC\n")
+
+	for _, roi := range rois {
+		locIdxs, err := indexcompression.DecodeIndexList(roi.ScanEntryIndexesEncoded, len(exprPB.Locations))
+		if err != nil {
+			return nil, fmt.Errorf("Failed to decode ROI %v: %v", roi.Id, err)
+		}
+
+		for _, locIdx := range locIdxs {
+			b.WriteString(fmt.Sprintf("%v,%v,%v\n", roi.Id, csvEscape(roi.Name), exprPB.Locations[locIdx].Id))
+		}
+	}
+
+	return b.Bytes(), nil
+}
+
+// Generates CSVs of the detected diffraction peaks (as found by the diffraction peak detector at import time) and the
+// peaks users have added manually
+func MakeDiffractionPeakCSVs(detected []*protos.DetectedDiffractionPerLocation, manual map[string]*protos.ManualDiffractionPeak) map[string][]byte {
+	var b bytes.Buffer
+	b.WriteString("PMC,Detector,PeakChannel,EffectSize,BaselineVariation,GlobalDifference,DifferenceSigma,PeakHeight\n")
+
+	sort.Slice(detected, func(i, j int) bool { return comparePMCStrings(detected[i].Id, detected[j].Id) })
+	for _, loc := range detected {
+		for _, peak := range loc.Peaks {
+			b.WriteString(fmt.Sprintf("%v,%v,%v,%v,%v,%v,%v,%v\n", loc.Id, peak.Detector, peak.PeakChannel, peak.EffectSize, peak.BaselineVariation, peak.GlobalDifference, peak.DifferenceSigma, peak.PeakHeight))
+		}
+	}
+
+	result := map[string][]byte{"diffraction-peaks-detected.csv": b.Bytes()}
+
+	b = bytes.Buffer{}
+	b.WriteString("Id,PMC,EnergykeV,CreatorUserId,CreatedUnixSec\n")
+
+	manualIds := []string{}
+	for id := range manual {
+		manualIds = append(manualIds, id)
+	}
+	sort.Strings(manualIds)
+
+	for _, id := range manualIds {
+		peak := manual[id]
+		b.WriteString(fmt.Sprintf("%v,%v,%v,%v,%v\n", id, peak.Pmc, peak.EnergykeV, peak.CreatorUserId, peak.CreatedUnixSec))
+	}
+
+	result["diffraction-peaks-manual.csv"] = b.Bytes()
+	return result
+}
+
+// Marks the beam locations on a context image, returning the image bytes in the same format as the source (or PNG
+// if the source format can't be written back out, eg TIF)
+func MakeBeamMarkedImage(imgBytes []byte, locations []*protos.Coordinate2D, matchInfo *protos.ImageMatchTransform) ([]byte, string, error) {
+	img, imgFormat, err := image.Decode(bytes.NewReader(imgBytes))
+	if err != nil {
+		return nil, "", err
+	}
+
+	if imgFormat != "png" && imgFormat != "jpeg" {
+		imgFormat = "png"
+	}
+
+	img = imageedit.MarkLocations(img, locations, color.RGBA{R: 255, G: 255, B: 0, A: 255}, matchInfo)
+
+	outBytes, err := imageedit.GetImageBytes(img, imgFormat)
+	return outBytes, imgFormat, err
+}
+
+func getMetaLabelIdx(exprPB *protos.Experiment, label string) int {
+	for c, l := range exprPB.MetaLabels {
+		if l == label {
+			return c
+		}
+	}
+	return -1
+}
+
+func metaValueString(m *protos.Experiment_Location_MetaDataItem, metaType protos.Experiment_MetaDataType) string {
+	switch metaType {
+	case protos.Experiment_MT_INT:
+		return strconv.Itoa(int(m.Ivalue))
+	case protos.Experiment_MT_FLOAT:
+		return strconv.FormatFloat(float64(m.Fvalue), 'f', -1, 32)
+	}
+	return m.Svalue
+}
+
+func csvEscape(value string) string {
+	if strings.ContainsAny(value, ",\"\n") {
+		return "\"" + strings.ReplaceAll(value, "\"", "\"\"") + "\""
+	}
+	return value
+}
+
+func comparePMCStrings(a string, b string) bool {
+	aPMC, errA := strconv.Atoi(a)
+	bPMC, errB := strconv.Atoi(b)
+	if errA != nil || errB != nil {
+		return a < b
+	}
+	return aPMC < bPMC
+}
@@ -0,0 +1,158 @@
+package wsHelpers
+
+import (
+	"fmt"
+	"sort"
+
+	protos "github.com/pixlise/core/v4/generated-protos"
+)
+
+func makeExportTestScan() *protos.Experiment {
+	return &protos.Experiment{
+		TargetId:   "123",
+		MetaLabels: []string{"PMC", "DETECTOR_ID", "READTYPE", "LIVETIME", "XPERCHAN", "SCLK", "hk_temp"},
+		MetaTypes: []protos.Experiment_MetaDataType{
+			protos.Experiment_MT_INT,
+			protos.Experiment_MT_STRING,
+			protos.Experiment_MT_STRING,
+			protos.Experiment_MT_FLOAT,
+			protos.Experiment_MT_FLOAT,
+			protos.Experiment_MT_INT,
+			protos.Experiment_MT_FLOAT,
+		},
+		Locations: []*protos.Experiment_Location{
+			{
+				Id: "7",
+				Meta: []*protos.Experiment_Location_MetaDataItem{
+					{LabelIdx: 5, Ivalue: 12345},
+					{LabelIdx: 6, Fvalue: 20.5},
+				},
+			},
+			{
+				Id:   "8",
+				Beam: &protos.Experiment_Location_BeamLocation{X: 1.5, Y: 2.25, Z: -3, ImageI: 100, ImageJ: 200},
+				Meta: []*protos.Experiment_Location_MetaDataItem{
+					{LabelIdx: 5, Ivalue: 12346},
+				},
+				Detectors: []*protos.Experiment_Location_DetectorSpectrum{
+					{
+						Meta: []*protos.Experiment_Location_MetaDataItem{
+							{LabelIdx: 1, Svalue: "B"},
+							{LabelIdx: 2, Svalue: "Normal"},
+							{LabelIdx: 3, Fvalue: 9.5},
+							{LabelIdx: 4, Fvalue: 10},
+						},
+						Spectrum: []int32{0, 2, 5, 7},
+					},
+					{
+						Meta: []*protos.Experiment_Location_MetaDataItem{
+							{LabelIdx: 1, Svalue: "A"},
+							{LabelIdx: 2, Svalue: "Normal"},
+							{LabelIdx: 3, Fvalue: 9.25},
+							{LabelIdx: 4, Fvalue: 10},
+						},
+						Spectrum: []int32{3, 0, 3},
+					},
+				},
+			},
+		},
+	}
+}
+
+func Example_makeScanMSAFiles() {
+	files, err := MakeScanMSAFiles(makeExportTestScan())
+	fmt.Printf("%v|%v\n", len(files), err)
+	fmt.Printf("%v", string(files["8-Normal.msa"]))
+
+	// Output:
+	// 1|<nil>
+	// #FORMAT      : EMSA/MAS spectral data file
+	// #VERSION     : TC202v2.0 PIXL
+	// #TITLE       : PIXLISE export
+	// #NPOINTS     : 4
+	// #NCOLUMNS    : 2
+	// #XUNITS      : eV
+	// #YUNITS      : COUNTS
+	// #DATATYPE    : YY
+	// #XPERCHAN    : 10, 10
+	// #LIVETIME    : 9.25, 9.5
+	// #SIGNALTYPE  : XRF
+	// #XPOSITION   : 1.5
+	// #YPOSITION   : 2.25
+	// #ZPOSITION   : -3
+	// #PMC         : 8
+	// #SPECTRUM    :
+	// 3, 0
+	// 0, 0
+	// 0, 5
+	// 0, 7
+	// #ENDOFDATA   :
+}
+
+func Example_makeScanBeamLocationCSV() {
+	fmt.Printf("%v", string(MakeScanBeamLocationCSV(makeExportTestScan())))
+
+	// Output:
+	// PMC,X,Y,Z,GeomCorr,image_i,image_j
+	// 8,1.5,2.25,-3,0,100,200
+}
+
+func Example_makeScanHousekeepingCSV() {
+	csv, err := MakeScanHousekeepingCSV(makeExportTestScan())
+	fmt.Printf("%v|%v", err, string(csv))
+
+	// Output:
+	// <nil>|PMC,SCLK,hk_temp
+	// 7,12345,20.5
+	// 8,12346,
+}
+
+func Example_makeROIPMCsCSV() {
+	rois := []*protos.ROIItem{
+		{Id: "roi1", Name: "Rock, dark", ScanEntryIndexesEncoded: []int32{0, 1}},
+		{Id: "roi2", Name: "Bad", ScanEntryIndexesEncoded: []int32{5}},
+	}
+
+	csv, err := MakeROIPMCsCSV(makeExportTestScan(), rois[0:1])
+	fmt.Printf("%v|%v", err, string(csv))
+
+	_, err = MakeROIPMCsCSV(makeExportTestScan(), rois)
+	fmt.Printf("%v\n", err)
+
+	// Output:
+	// <nil>|ROIID,ROIName,PMC
+	// roi1,"Rock, dark",7
+	// roi1,"Rock, dark",8
+	// Failed to decode ROI roi2: index 5 out of bounds: 2
+}
+
+func Example_makeDiffractionPeakCSVs() {
+	detected := []*protos.DetectedDiffractionPerLocation{
+		{Id: "12", Peaks: []*protos.DetectedDiffractionPerLocation_DetectedDiffractionPeak{{PeakChannel: 500, EffectSize: 6.5, Detector: "A"}}},
+		{Id: "9", Peaks: []*protos.DetectedDiffractionPerLocation_DetectedDiffractionPeak{{PeakChannel: 1200, EffectSize: 4, PeakHeight: 0.5, Detector: "B"}}},
+	}
+	manual := map[string]*protos.ManualDiffractionPeak{
+		"peak2": {Pmc: 33, EnergykeV: 5.5, CreatorUserId: "user1", CreatedUnixSec: 1234567890},
+	}
+
+	files := MakeDiffractionPeakCSVs(detected, manual)
+
+	names := []string{}
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		fmt.Printf("%v:\n%v", name, string(files[name]))
+	}
+
+	// Output:
+	// diffraction-peaks-detected.csv:
+	// PMC,Detector,PeakChannel,EffectSize,BaselineVariation,GlobalDifference,DifferenceSigma,PeakHeight
+	// 9,B,1200,4,0,0,0,0.5
+	// 12,A,500,6.5,0,0,0,0
+	// diffraction-peaks-manual.csv:
+	// Id,PMC,EnergykeV,CreatorUserId,CreatedUnixSec
+	// peak2,33,5.5,user1,1234567890
+}
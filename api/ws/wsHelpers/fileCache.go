@@ -5,12 +5,14 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"strings"
 
 	"github.com/pixlise/core/v4/api/filepaths"
 	"github.com/pixlise/core/v4/api/services"
 	"github.com/pixlise/core/v4/core/errorwithstatus"
 	"github.com/pixlise/core/v4/core/fileaccess"
 	"github.com/pixlise/core/v4/core/logger"
+	"github.com/pixlise/core/v4/core/scan"
 	"github.com/pixlise/core/v4/core/timestamper"
 	protos "github.com/pixlise/core/v4/generated-protos"
 	"google.golang.org/protobuf/proto"
@@ -31,22 +33,95 @@ var MaxFileCacheAgeSec = int64(60 * 5)
 var MaxFileCacheSizeBytes = uint64(200 * 1024 * 1024)
 
 func ReadDatasetFile(scanId string, svcs *services.APIServices, useFileCache bool) (*protos.Experiment, error) {
-	cacheId := "scan-" + scanId
+	fileBytes, err := readDatasetFileBytes(scanId, filepaths.DatasetFileName, "scan-"+scanId, "-dataset.bin", svcs, useFileCache)
+	if err != nil {
+		if svcs.FS.IsNotFoundError(err) {
+			return nil, errorwithstatus.MakeNotFoundError(scanId)
+		}
+		return nil, err
+	}
+
+	// Now decode the data & return it
+	datasetPB := &protos.Experiment{}
+	err = proto.Unmarshal(fileBytes, datasetPB)
+	if err != nil {
+		svcs.Log.Errorf("Failed to decode scan data for scan: %v. Error: %v", scanId, err)
+		return nil, err
+	}
+
+	return datasetPB, nil
+}
+
+// Reads the index of the scan's chunk files. Returns nil if the scan doesn't have any, because it was imported before
+// we started writing them
+func ReadDatasetChunkIndex(scanId string, svcs *services.APIServices) (*protos.ExperimentChunkIndex, error) {
+	fileBytes, err := readDatasetFileBytes(scanId, filepaths.DatasetChunkIndexFileName, "scan-"+scanId+"-chunks", "-"+filepaths.DatasetChunkIndexFileName, svcs, true)
+	if err != nil {
+		if svcs.FS.IsNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	index := &protos.ExperimentChunkIndex{}
+	err = proto.Unmarshal(fileBytes, index)
+	if err != nil {
+		svcs.Log.Errorf("Failed to decode scan chunk index for scan: %v. Error: %v", scanId, err)
+		return nil, err
+	}
+
+	if index.Header == nil {
+		return nil, fmt.Errorf("Scan chunk index for scan: %v has no header", scanId)
+	}
+
+	return index, nil
+}
+
+// Reads the kinds of data requested for the given locations out of the scan's chunk files. The returned experiment has
+// all locations, but other locations only have what's stored in the chunk index
+func ReadDatasetChunks(scanId string, index *protos.ExperimentChunkIndex, kinds scan.DataKind, indexes []uint32, svcs *services.APIServices) (*protos.Experiment, error) {
+	datasetPB := proto.Clone(index.Header).(*protos.Experiment)
+
+	for _, file := range scan.GetChunkFiles(index, kinds, indexes) {
+		fileBytes, err := readDatasetFileBytes(scanId, file.Name, "scan-"+scanId+"-"+file.Name, "", svcs, true)
+		if err != nil {
+			return nil, err
+		}
+
+		chunk := &protos.Experiment{}
+		err = proto.Unmarshal(fileBytes, chunk)
+		if err != nil {
+			svcs.Log.Errorf("Failed to decode scan chunk %v for scan: %v. Error: %v", file.Name, scanId, err)
+			return nil, err
+		}
+
+		err = scan.MergeChunk(datasetPB, index, file, chunk)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return datasetPB, nil
+}
+
+// Reads one of the files stored for a scan, from the file cache if we have it. Not found errors are returned as-is
+// so callers can check for them with IsNotFoundError
+func readDatasetFileBytes(scanId string, fileName string, cacheId string, cacheFileSuffix string, svcs *services.APIServices, useFileCache bool) ([]byte, error) {
 	var fileBytes []byte
 	if useFileCache {
 		fileBytes = checkCache(cacheId, "scan", svcs)
 	}
 
 	// If we don't have data by now, download it and add to our cache
-	var err error
 	if fileBytes == nil {
-		s3Path := filepaths.GetScanFilePath(scanId, filepaths.DatasetFileName)
+		var err error
+		s3Path := filepaths.GetScanFilePath(scanId, fileName)
 		svcs.Log.Debugf("Downloading file: s3://%v/%v", svcs.Config.DatasetsBucket, s3Path)
 		fileBytes, err = svcs.FS.ReadObject(svcs.Config.DatasetsBucket, s3Path)
 		if err != nil {
-			// Doesn't seem to exist?
+			// Doesn't seem to exist? Let the caller decide if that's an error
 			if svcs.FS.IsNotFoundError(err) {
-				return nil, errorwithstatus.MakeNotFoundError(scanId)
+				return nil, err
 			}
 
 			svcs.Log.Errorf("Failed to load scan data for %v, from: s3://%v/%v, error was: %v.", scanId, svcs.Config.DatasetsBucket, s3Path, err)
@@ -55,19 +130,11 @@ func ReadDatasetFile(scanId string, svcs *services.APIServices, useFileCache boo
 
 		if useFileCache {
 			// Write locally
-			addToCache(cacheId, "-dataset.bin", fmt.Sprintf("s3://%v/%v", svcs.Config.DatasetsBucket, s3Path), fileBytes, svcs)
+			addToCache(cacheId, cacheFileSuffix, fmt.Sprintf("s3://%v/%v", svcs.Config.DatasetsBucket, s3Path), fileBytes, svcs)
 		}
 	}
 
-	// Now decode the data & return it
-	datasetPB := &protos.Experiment{}
-	err = proto.Unmarshal(fileBytes, datasetPB)
-	if err != nil {
-		svcs.Log.Errorf("Failed to decode scan data for scan: %v. Error: %v", scanId, err)
-		return nil, err
-	}
-
-	return datasetPB, nil
+	return fileBytes, nil
 }
 
 func ReadQuantificationFile(quantId string, quantPath string, svcs *services.APIServices) (*protos.Quantification, error) {
@@ -149,6 +216,13 @@ func ClearCacheForScanId(scanId string, ts timestamper.ITimeStamper, l logger.IL
 
 	itemsToClear := []string{"scan-" + scanId, "diffraction-" + scanId}
 
+	// Scan chunk files are cached separately too
+	for cacheItem := range fileCache {
+		if strings.HasPrefix(cacheItem, "scan-"+scanId+"-") {
+			itemsToClear = append(itemsToClear, cacheItem)
+		}
+	}
+
 	for _, cacheItem := range itemsToClear {
 		if item, ok := fileCache[cacheItem]; ok {
 			l.Infof("Setting cached file %v time stamp to be too old, subsequent access should re-download it", item.localPath)
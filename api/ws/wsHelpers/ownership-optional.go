@@ -0,0 +1,207 @@
+package wsHelpers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/pixlise/core/v4/api/dbCollections"
+	"github.com/pixlise/core/v4/core/errorwithstatus"
+	"github.com/pixlise/core/v4/core/utils"
+	protos "github.com/pixlise/core/v4/generated-protos"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Object types which were added to the ownership system after they already existed. These only have ownership items if
+// they were created since for a scan (uploaded images, tags and manual diffraction peaks get one then), or if someone has
+// set sharing on them. Those without an ownership item are accessible as they were before, so existing data doesn't disappear from
+// view: to everyone, or for images, anyone with access to their scans
+var optionalOwnershipCollections = map[protos.ObjectType]string{
+	protos.ObjectType_OT_IMAGE:            dbCollections.ImagesName,
+	protos.ObjectType_OT_TAG:              dbCollections.TagsName,
+	protos.ObjectType_OT_REFERENCE_DATA:   dbCollections.ReferencesName,
+	protos.ObjectType_OT_DIFFRACTION_PEAK: dbCollections.DiffractionManualPeaksName,
+	protos.ObjectType_OT_WIDGET_DATA:      dbCollections.WidgetDataName,
+}
+
+func IsOptionalOwnershipType(objectType protos.ObjectType) bool {
+	_, ok := optionalOwnershipCollections[objectType]
+	return ok
+}
+
+// CheckOptionalObjectAccess - Like CheckObjectAccess, for object types where ownership is optional. If the object has no
+// ownership item, returns nil, nil - it's then up to the caller to apply whatever checks were done before
+func CheckOptionalObjectAccess(requireEdit bool, objectId string, objectType protos.ObjectType, hctx HandlerContext) (*protos.OwnershipItem, error) {
+	return CheckOptionalObjectAccessForUser(requireEdit, objectId, objectType, hctx.SessUser.User.Id, hctx.SessUser.MemberOfGroupIds, hctx.SessUser.ViewerOfGroupIds, hctx.Svcs.MongoDB)
+}
+
+// CheckOptionalObjectAccessForUser - Like CheckOptionalObjectAccess, for callers that don't have a HandlerContext
+func CheckOptionalObjectAccessForUser(requireEdit bool, objectId string, objectType protos.ObjectType, userId string, memberOfGroupIds []string, viewerOfGroupIds []string, db *mongo.Database) (*protos.OwnershipItem, error) {
+	owner, err := CheckObjectAccessForUser(requireEdit, objectId, objectType, userId, memberOfGroupIds, viewerOfGroupIds, db)
+	if err != nil {
+		if e, ok := err.(errorwithstatus.Error); ok && e.Status() == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return owner, nil
+}
+
+// FilterOptionalOwnershipIDs - Returns the ids the user can view: ones which have no ownership item, and ones which
+// ListAccessibleIDs says are visible to the user
+func FilterOptionalOwnershipIDs(ids []string, objectType protos.ObjectType, hctx HandlerContext) (map[string]bool, error) {
+	result := map[string]bool{}
+	if len(ids) <= 0 {
+		return result, nil
+	}
+
+	accessible, err := ListAccessibleIDs(false, objectType, hctx.Svcs, hctx.SessUser)
+	if err != nil {
+		return result, err
+	}
+
+	// Find which of the ids have an ownership item at all
+	filter := bson.M{"_id": bson.M{"$in": ids}, "objecttype": objectType}
+	opts := options.Find().SetProjection(bson.D{{Key: "_id", Value: true}})
+	cursor, err := hctx.Svcs.MongoDB.Collection(dbCollections.OwnershipName).Find(context.TODO(), filter, opts)
+	if err != nil {
+		return result, err
+	}
+
+	owned := []*ItemWithId{}
+	err = cursor.All(context.TODO(), &owned)
+	if err != nil {
+		return result, err
+	}
+
+	ownedIds := map[string]bool{}
+	for _, item := range owned {
+		ownedIds[item.Id] = true
+	}
+
+	for _, id := range ids {
+		if _, ok := accessible[id]; ok || !ownedIds[id] {
+			result[id] = true
+		}
+	}
+
+	return result, nil
+}
+
+// WriteOptionalOwnership - Creates the ownership item for an object newly created for a scan. The creating user becomes
+// its editor, and whoever can view or edit the scan can view it, so it's visible to the same users as the scan it was made
+// for. If there's no scan (or the scan has no ownership item), none is written and the object stays visible to everyone
+// like it did before, until someone sets sharing on it
+func WriteOptionalOwnership(objectId string, objectType protos.ObjectType, scanId string, hctx HandlerContext) (*protos.OwnershipItem, error) {
+	return WriteOptionalOwnershipForUser(objectId, objectType, scanId, hctx.SessUser.User.Id, hctx.Svcs.TimeStamper.GetTimeNowSec(), hctx.Svcs.MongoDB)
+}
+
+// WriteOptionalOwnershipForUser - Like WriteOptionalOwnership, for callers that don't have a HandlerContext
+func WriteOptionalOwnershipForUser(objectId string, objectType protos.ObjectType, scanId string, creatorUserId string, createTimeUnixSec int64, db *mongo.Database) (*protos.OwnershipItem, error) {
+	if len(scanId) <= 0 {
+		return nil, nil
+	}
+
+	scanOwner := &protos.OwnershipItem{}
+	err := db.Collection(dbCollections.OwnershipName).FindOne(context.TODO(), bson.M{"_id": scanId}).Decode(scanOwner)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	owner := makeOwnerInheritingViewers(objectId, objectType, creatorUserId, createTimeUnixSec, scanOwner)
+	_, err = db.Collection(dbCollections.OwnershipName).InsertOne(context.TODO(), owner)
+	if err != nil {
+		return nil, err
+	}
+
+	return owner, nil
+}
+
+// Makes an ownership item with the creator as editor, and the viewers and editors of parentOwner as viewers
+func makeOwnerInheritingViewers(objectId string, objectType protos.ObjectType, creatorUserId string, createTimeUnixSec int64, parentOwner *protos.OwnershipItem) *protos.OwnershipItem {
+	owner := MakeOwnerForWrite(objectId, objectType, creatorUserId, createTimeUnixSec)
+	owner.Viewers = &protos.UserGroupList{UserIds: []string{}, GroupIds: []string{}}
+
+	for _, list := range []*protos.UserGroupList{parentOwner.Viewers, parentOwner.Editors} {
+		if list == nil {
+			continue
+		}
+
+		for _, userId := range list.UserIds {
+			if userId != creatorUserId && !utils.ItemInSlice(userId, owner.Viewers.UserIds) {
+				owner.Viewers.UserIds = append(owner.Viewers.UserIds, userId)
+			}
+		}
+
+		for _, groupId := range list.GroupIds {
+			if !utils.ItemInSlice(groupId, owner.Viewers.GroupIds) {
+				owner.Viewers.GroupIds = append(owner.Viewers.GroupIds, groupId)
+			}
+		}
+	}
+
+	return owner
+}
+
+// ClaimOptionalOwnership - Creates the ownership item for an object that doesn't have one yet, so its sharing can be
+// set. Until now it has been visible to everyone, so only the user recorded as its creator (if the object records one)
+// or an admin can do this. The creator (or otherwise the admin) becomes its editor. Images and widget data don't record
+// who created them, so only admins can set sharing on ones that have no ownership item: images uploaded before they
+// got ownership items, images imported with their scan and all widget data
+func ClaimOptionalOwnership(objectId string, objectType protos.ObjectType, hctx HandlerContext) (*protos.OwnershipItem, error) {
+	collectionName, ok := optionalOwnershipCollections[objectType]
+	if !ok {
+		return nil, errorwithstatus.MakeBadRequestError(fmt.Errorf("%v objects always have ownership", objectType.String()))
+	}
+
+	// Tags store their owner, diffraction peaks their creator
+	type creatorFields struct {
+		OwnerId       string
+		CreatorUserId string
+	}
+
+	creator := creatorFields{}
+	err := hctx.Svcs.MongoDB.Collection(collectionName).FindOne(context.TODO(), bson.M{"_id": objectId}).Decode(&creator)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errorwithstatus.MakeNotFoundError(objectId)
+		}
+		return nil, err
+	}
+
+	creatorUserId := creator.OwnerId
+	if len(creatorUserId) <= 0 {
+		creatorUserId = creator.CreatorUserId
+	}
+
+	if creatorUserId != hctx.SessUser.User.Id {
+		if !HasPermission(hctx.SessUser.Permissions, protos.Permission_PERM_PIXLISE_ADMIN) {
+			return nil, errorwithstatus.MakeUnauthorisedError(errors.New("Only the creator of the object or an admin can set its sharing"))
+		}
+
+		if len(creatorUserId) <= 0 {
+			creatorUserId = hctx.SessUser.User.Id
+		}
+	}
+
+	owner := MakeOwnerForWrite(objectId, objectType, creatorUserId, hctx.Svcs.TimeStamper.GetTimeNowSec())
+	_, err = hctx.Svcs.MongoDB.Collection(dbCollections.OwnershipName).InsertOne(context.TODO(), owner)
+	if err != nil {
+		return nil, err
+	}
+
+	return owner, nil
+}
+
+// DeleteOptionalOwnership - Deletes the ownership item of an object being deleted, if it has one
+func DeleteOptionalOwnership(objectId string, hctx HandlerContext) error {
+	_, err := hctx.Svcs.MongoDB.Collection(dbCollections.OwnershipName).DeleteOne(context.TODO(), bson.M{"_id": objectId})
+	return err
+}
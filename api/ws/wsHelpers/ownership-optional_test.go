@@ -0,0 +1,41 @@
+package wsHelpers
+
+import (
+	"fmt"
+
+	protos "github.com/pixlise/core/v4/generated-protos"
+)
+
+func Example_makeOwnerInheritingViewers() {
+	scanOwner := &protos.OwnershipItem{
+		Id:         "scan123",
+		ObjectType: protos.ObjectType_OT_SCAN,
+		Editors:    &protos.UserGroupList{UserIds: []string{"scanowner"}},
+		Viewers:    &protos.UserGroupList{UserIds: []string{"creator"}, GroupIds: []string{"group1"}},
+	}
+
+	owner := makeOwnerInheritingViewers("tag123", protos.ObjectType_OT_TAG, "creator", 1000, scanOwner)
+	fmt.Printf("%v|%v|%v|%v\n", owner.CreatorUserId, owner.CreatedUnixSec, owner.Editors.UserIds, owner.Editors.GroupIds)
+	fmt.Printf("%v|%v\n", owner.Viewers.UserIds, owner.Viewers.GroupIds)
+
+	// The creator can edit it, the scan owner and members of the scan's viewer group can only view it
+	for _, user := range []struct {
+		id       string
+		memberOf []string
+	}{
+		{"creator", []string{}},
+		{"scanowner", []string{}},
+		{"other", []string{"group1"}},
+		{"other", []string{"group2"}},
+	} {
+		fmt.Printf("%v in %v: view=%v, edit=%v\n", user.id, user.memberOf, HasObjectAccess(false, owner, user.id, user.memberOf, []string{}), HasObjectAccess(true, owner, user.id, user.memberOf, []string{}))
+	}
+
+	// Output:
+	// creator|1000|[creator]|[]
+	// [scanowner]|[group1]
+	// creator in []: view=true, edit=true
+	// scanowner in []: view=true, edit=false
+	// other in [group1]: view=true, edit=false
+	// other in [group2]: view=false, edit=false
+}
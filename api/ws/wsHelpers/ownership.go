@@ -64,14 +64,41 @@ func CheckObjectAccessForUser(requireEdit bool, objectId string, objectType prot
 	}
 
 	// Now check permissions
-	// For editing, we only look at the editor list
+	if HasObjectAccess(requireEdit, ownership, userId, memberOfGroupIds, viewerOfGroupIds) {
+		return ownership, nil
+	}
+
+	// Access denied
 	accessType := "Edit"
+	if !requireEdit {
+		accessType = "View"
+	}
+
+	name, err := DescribeObject(objectId, objectType, db)
+	if err != nil {
+		name = "" // just in case
+		// NOTE: at this point we don't log errors, we just show it as an id/type anyway
+	}
+
+	var resultErr error
+	if len(name) <= 0 {
+		resultErr = fmt.Errorf("%v access denied for: %v (id: %v)", accessType, objectType.String()[3:], objectId)
+	} else {
+		resultErr = fmt.Errorf("%v access denied for: %v named \"%v\" (id: %v)", accessType, objectType.String()[3:], name, objectId)
+	}
+
+	return nil, errorwithstatus.MakeUnauthorisedError(resultErr)
+}
+
+// HasObjectAccess - Checks if the ownership item gives the user (directly, or via groups they're a member or viewer of)
+// edit access, or if requireEdit is false, view access
+func HasObjectAccess(requireEdit bool, ownership *protos.OwnershipItem, userId string, memberOfGroupIds []string, viewerOfGroupIds []string) bool {
+	// For editing, we only look at the editor list
 	toCheck := []*protos.UserGroupList{ownership.Editors}
 	if !requireEdit {
 		// If we're interested in view permissions, editors have implicit view permissions
 		// so we just add the viewer list here
 		toCheck = append(toCheck, ownership.Viewers)
-		accessType = "View"
 	}
 
 	for _, toCheckItem := range toCheck {
@@ -81,13 +108,13 @@ func CheckObjectAccessForUser(requireEdit bool, objectId string, objectType prot
 
 		// First check user id
 		if toCheckItem.UserIds != nil && utils.ItemInSlice(userId, toCheckItem.UserIds) {
-			return ownership, nil // User has access
+			return true // User has access
 		} else {
 			// Check groups
 			if toCheckItem.GroupIds != nil {
 				for _, groupId := range memberOfGroupIds {
 					if utils.ItemInSlice(groupId, toCheckItem.GroupIds) {
-						return ownership, nil // User has access via group it belongs to
+						return true // User has access via group it belongs to
 					}
 				}
 
@@ -95,7 +122,7 @@ func CheckObjectAccessForUser(requireEdit bool, objectId string, objectType prot
 					// If we don't require editing, check if the user is a viewer of any of the groups too
 					for _, groupId := range viewerOfGroupIds {
 						if utils.ItemInSlice(groupId, toCheckItem.GroupIds) {
-							return ownership, nil // User has access via group it belongs to
+							return true // User has access via group it belongs to
 						}
 					}
 				}
@@ -103,21 +130,7 @@ func CheckObjectAccessForUser(requireEdit bool, objectId string, objectType prot
 		}
 	}
 
-	// Access denied
-	name, err := DescribeObject(objectId, objectType, db)
-	if err != nil {
-		name = "" // just in case
-		// NOTE: at this point we don't log errors, we just show it as an id/type anyway
-	}
-
-	var resultErr error
-	if len(name) <= 0 {
-		resultErr = fmt.Errorf("%v access denied for: %v (id: %v)", accessType, objectType.String()[3:], objectId)
-	} else {
-		resultErr = fmt.Errorf("%v access denied for: %v named \"%v\" (id: %v)", accessType, objectType.String()[3:], name, objectId)
-	}
-
-	return nil, errorwithstatus.MakeUnauthorisedError(resultErr)
+	return false
 }
 
 func DescribeObject(objectId string, objType protos.ObjectType, db *mongo.Database) (string, error) {
@@ -145,6 +158,20 @@ func DescribeObject(objectId string, objType protos.ObjectType, db *mongo.Databa
 		nameField = "title"
 	case protos.ObjectType_OT_SCREEN_CONFIG:
 		collection = dbCollections.ScreenConfigurationName
+	case protos.ObjectType_OT_IMAGE:
+		collection = dbCollections.ImagesName
+		nameField = "_id"
+	case protos.ObjectType_OT_TAG:
+		collection = dbCollections.TagsName
+	case protos.ObjectType_OT_REFERENCE_DATA:
+		collection = dbCollections.ReferencesName
+		nameField = "mineralsamplename"
+	case protos.ObjectType_OT_DIFFRACTION_PEAK:
+		collection = dbCollections.DiffractionManualPeaksName
+		nameField = "_id"
+	case protos.ObjectType_OT_WIDGET_DATA:
+		collection = dbCollections.WidgetDataName
+		nameField = "widgetName"
 	}
 
 	if len(collection) <= 0 {
@@ -170,9 +197,12 @@ func DescribeObject(objectId string, objType protos.ObjectType, db *mongo.Databa
 	}
 
 	type ProjReturn struct {
-		Name   string
-		Title  string
-		Params QParams
+		Id                string `bson:"_id"`
+		Name              string
+		Title             string
+		Params            QParams
+		MineralSampleName string
+		WidgetName        string `bson:"widgetName"`
 	}
 
 	n := &ProjReturn{}
@@ -186,6 +216,12 @@ func DescribeObject(objectId string, objType protos.ObjectType, db *mongo.Databa
 		name = n.Title
 	} else if objType == protos.ObjectType_OT_QUANTIFICATION {
 		name = n.Params.UserParams.Name
+	} else if objType == protos.ObjectType_OT_IMAGE || objType == protos.ObjectType_OT_DIFFRACTION_PEAK {
+		name = n.Id
+	} else if objType == protos.ObjectType_OT_REFERENCE_DATA {
+		name = n.MineralSampleName
+	} else if objType == protos.ObjectType_OT_WIDGET_DATA {
+		name = n.WidgetName
 	}
 
 	return name, nil
@@ -0,0 +1,213 @@
+package wsHelpers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pixlise/core/v4/api/dbCollections"
+	"github.com/pixlise/core/v4/core/errorwithstatus"
+	protos "github.com/pixlise/core/v4/generated-protos"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Object types we keep revisions of, and the collections they're stored in
+var revisionCollections = map[protos.ObjectType]string{
+	protos.ObjectType_OT_ROI:              dbCollections.RegionsOfInterestName,
+	protos.ObjectType_OT_EXPRESSION:       dbCollections.ExpressionsName,
+	protos.ObjectType_OT_EXPRESSION_GROUP: dbCollections.ExpressionGroupsName,
+	protos.ObjectType_OT_ELEMENT_SET:      dbCollections.ElementSetsName,
+	protos.ObjectType_OT_SCREEN_CONFIG:    dbCollections.ScreenConfigurationName,
+}
+
+// If 2 saves of the same object race, one will fail to insert its revision number, so we retry a few times
+const maxRevisionInsertAttempts = 3
+
+// GetRevisionCollection - Returns the collection objects of the given type are stored in, or an error if we don't keep
+// revisions of that type
+func GetRevisionCollection(objectType protos.ObjectType) (string, error) {
+	collectionName, ok := revisionCollections[objectType]
+	if !ok {
+		return "", errorwithstatus.MakeBadRequestError(fmt.Errorf("Revisions are not kept for %v", objectType.String()))
+	}
+	return collectionName, nil
+}
+
+// SaveObjectRevision - Saves the object as it now is in the DB as its next revision. Called after each create or update
+// of an object, so every save can be viewed or restored later. restoredFromRevision is 0 unless this save was a restore.
+// Failures are only logged, as by the time we're called the change has been made. Returns the revision, nil on failure
+func SaveObjectRevision(objectId string, objectType protos.ObjectType, restoredFromRevision uint32, hctx HandlerContext) *protos.ObjectRevision {
+	rev, err := saveObjectRevision(objectId, objectType, restoredFromRevision, hctx)
+	if err != nil {
+		hctx.Svcs.Log.Errorf("Failed to save revision of %v %v: %v", objectType.String(), objectId, err)
+		return nil
+	}
+	return rev
+}
+
+func saveObjectRevision(objectId string, objectType protos.ObjectType, restoredFromRevision uint32, hctx HandlerContext) (*protos.ObjectRevision, error) {
+	collectionName, err := GetRevisionCollection(objectType)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := context.TODO()
+	result := hctx.Svcs.MongoDB.Collection(collectionName).FindOne(ctx, bson.M{"_id": objectId})
+	if result.Err() != nil {
+		return nil, result.Err()
+	}
+
+	rev := &protos.ObjectRevision{
+		ObjectId:             objectId,
+		ObjectType:           objectType,
+		AuthorUserId:         hctx.SessUser.User.Id,
+		TimeStampUnixSec:     uint32(hctx.Svcs.TimeStamper.GetTimeNowSec()),
+		RestoredFromRevision: restoredFromRevision,
+	}
+
+	err = decodeRevisionItem(rev, result)
+	if err != nil {
+		return nil, err
+	}
+
+	coll := hctx.Svcs.MongoDB.Collection(dbCollections.ObjectRevisionsName)
+	for attempt := 1; ; attempt++ {
+		latest, err := getLatestRevisionNumber(objectId, objectType, hctx)
+		if err != nil {
+			return nil, err
+		}
+
+		rev.Revision = latest + 1
+		rev.Id = fmt.Sprintf("%v-r%v", objectId, rev.Revision)
+
+		_, err = coll.InsertOne(ctx, rev)
+		if err == nil {
+			return rev, nil
+		}
+		if !mongo.IsDuplicateKeyError(err) || attempt >= maxRevisionInsertAttempts {
+			return nil, err
+		}
+	}
+}
+
+func getLatestRevisionNumber(objectId string, objectType protos.ObjectType, hctx HandlerContext) (uint32, error) {
+	opts := options.FindOne().SetSort(bson.D{{Key: "revision", Value: -1}}).SetProjection(bson.D{{Key: "revision", Value: true}})
+	result := hctx.Svcs.MongoDB.Collection(dbCollections.ObjectRevisionsName).FindOne(context.TODO(), bson.M{"objectid": objectId, "objecttype": objectType}, opts)
+	if result.Err() != nil {
+		if result.Err() == mongo.ErrNoDocuments {
+			return 0, nil
+		}
+		return 0, result.Err()
+	}
+
+	rev := &protos.ObjectRevision{}
+	err := result.Decode(rev)
+	return rev.Revision, err
+}
+
+// Reads the object from the DB into the field of the revision matching its type
+func decodeRevisionItem(rev *protos.ObjectRevision, result *mongo.SingleResult) error {
+	switch rev.ObjectType {
+	case protos.ObjectType_OT_ROI:
+		rev.Roi = &protos.ROIItem{}
+		return result.Decode(rev.Roi)
+	case protos.ObjectType_OT_EXPRESSION:
+		rev.Expression = &protos.DataExpression{}
+		return result.Decode(rev.Expression)
+	case protos.ObjectType_OT_EXPRESSION_GROUP:
+		rev.ExpressionGroup = &protos.ExpressionGroup{}
+		return result.Decode(rev.ExpressionGroup)
+	case protos.ObjectType_OT_ELEMENT_SET:
+		rev.ElementSet = &protos.ElementSet{}
+		return result.Decode(rev.ElementSet)
+	case protos.ObjectType_OT_SCREEN_CONFIG:
+		rev.ScreenConfiguration = &protos.ScreenConfiguration{}
+		return result.Decode(rev.ScreenConfiguration)
+	}
+
+	return fmt.Errorf("Revisions are not kept for %v", rev.ObjectType.String())
+}
+
+// GetRevisionItem - Returns the object stored in the revision, ready to be written back to the DB with the given
+// modified time
+func GetRevisionItem(rev *protos.ObjectRevision, modifiedUnixSec uint32) (any, error) {
+	switch rev.ObjectType {
+	case protos.ObjectType_OT_ROI:
+		if rev.Roi != nil {
+			rev.Roi.ModifiedUnixSec = modifiedUnixSec
+			return rev.Roi, nil
+		}
+	case protos.ObjectType_OT_EXPRESSION:
+		if rev.Expression != nil {
+			rev.Expression.ModifiedUnixSec = modifiedUnixSec
+			return rev.Expression, nil
+		}
+	case protos.ObjectType_OT_EXPRESSION_GROUP:
+		if rev.ExpressionGroup != nil {
+			rev.ExpressionGroup.ModifiedUnixSec = modifiedUnixSec
+			return rev.ExpressionGroup, nil
+		}
+	case protos.ObjectType_OT_ELEMENT_SET:
+		if rev.ElementSet != nil {
+			rev.ElementSet.ModifiedUnixSec = modifiedUnixSec
+			return rev.ElementSet, nil
+		}
+	case protos.ObjectType_OT_SCREEN_CONFIG:
+		if rev.ScreenConfiguration != nil {
+			rev.ScreenConfiguration.ModifiedUnixSec = modifiedUnixSec
+			return rev.ScreenConfiguration, nil
+		}
+	}
+
+	return nil, fmt.Errorf("Revision %v of %v %v has no stored item", rev.Revision, rev.ObjectType.String(), rev.ObjectId)
+}
+
+// ListObjectRevisions - Lists revisions of the object newest first, without the stored items
+func ListObjectRevisions(objectId string, objectType protos.ObjectType, hctx HandlerContext) ([]*protos.ObjectRevision, error) {
+	ctx := context.TODO()
+	opts := options.Find().SetSort(bson.D{{Key: "revision", Value: -1}}).SetProjection(bson.D{
+		{Key: "roi", Value: false},
+		{Key: "expression", Value: false},
+		{Key: "expressiongroup", Value: false},
+		{Key: "elementset", Value: false},
+		{Key: "screenconfiguration", Value: false},
+	})
+
+	cursor, err := hctx.Svcs.MongoDB.Collection(dbCollections.ObjectRevisionsName).Find(ctx, bson.M{"objectid": objectId, "objecttype": objectType}, opts)
+	if err != nil {
+		return []*protos.ObjectRevision{}, err
+	}
+
+	revs := []*protos.ObjectRevision{}
+	err = cursor.All(ctx, &revs)
+	return revs, err
+}
+
+// GetObjectRevision - Reads a revision of the object, including the stored item
+func GetObjectRevision(objectId string, objectType protos.ObjectType, revision uint32, hctx HandlerContext) (*protos.ObjectRevision, error) {
+	filter := bson.M{"objectid": objectId, "objecttype": objectType, "revision": revision}
+	result := hctx.Svcs.MongoDB.Collection(dbCollections.ObjectRevisionsName).FindOne(context.TODO(), filter)
+	if result.Err() != nil {
+		if result.Err() == mongo.ErrNoDocuments {
+			return nil, errorwithstatus.MakeNotFoundError(fmt.Sprintf("%v revision %v", objectId, revision))
+		}
+		return nil, result.Err()
+	}
+
+	rev := &protos.ObjectRevision{}
+	err := result.Decode(rev)
+	return rev, err
+}
+
+// DeleteObjectRevisions - Deletes all revisions of the given objects, for when they're deleted. The audit log still has what they last were
+func DeleteObjectRevisions(objectIds []string, objectType protos.ObjectType, hctx HandlerContext) {
+	if _, ok := revisionCollections[objectType]; !ok || len(objectIds) <= 0 {
+		return
+	}
+
+	_, err := hctx.Svcs.MongoDB.Collection(dbCollections.ObjectRevisionsName).DeleteMany(context.TODO(), bson.M{"objectid": bson.M{"$in": objectIds}, "objecttype": objectType})
+	if err != nil {
+		hctx.Svcs.Log.Errorf("Failed to delete revisions of %v %v: %v", objectType.String(), objectIds, err)
+	}
+}
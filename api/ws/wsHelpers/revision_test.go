@@ -0,0 +1,41 @@
+package wsHelpers
+
+import (
+	"fmt"
+
+	protos "github.com/pixlise/core/v4/generated-protos"
+)
+
+func ExampleGetRevisionCollection() {
+	for _, t := range []protos.ObjectType{protos.ObjectType_OT_EXPRESSION, protos.ObjectType_OT_SCREEN_CONFIG, protos.ObjectType_OT_SCAN} {
+		coll, err := GetRevisionCollection(t)
+		fmt.Printf("%v: %v|%v\n", t.String(), coll, err)
+	}
+
+	// Output:
+	// OT_EXPRESSION: expressions|<nil>
+	// OT_SCREEN_CONFIG: screenConfigurations|<nil>
+	// OT_SCAN: |Revisions are not kept for OT_SCAN
+}
+
+func ExampleGetRevisionItem() {
+	rev := &protos.ObjectRevision{
+		ObjectId:   "expr123",
+		ObjectType: protos.ObjectType_OT_EXPRESSION,
+		Revision:   3,
+		Expression: &protos.DataExpression{Id: "expr123", Name: "Fe ratio", ModifiedUnixSec: 1000},
+	}
+
+	item, err := GetRevisionItem(rev, 2000)
+	expr, ok := item.(*protos.DataExpression)
+	fmt.Printf("%v|%v|%v|%v\n", err, ok, expr.Name, expr.ModifiedUnixSec)
+
+	// Wrong field set for the type
+	rev.ObjectType = protos.ObjectType_OT_ROI
+	item, err = GetRevisionItem(rev, 2000)
+	fmt.Printf("%v|%v\n", item, err)
+
+	// Output:
+	// <nil>|true|Fe ratio|2000
+	// <nil>|Revision 3 of OT_ROI expr123 has no stored item
+}
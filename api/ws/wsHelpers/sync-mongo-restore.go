@@ -112,11 +112,11 @@ func DownloadArchive(envS3Path string, svcs *services.APIServices) (string, erro
 			return "", fmt.Errorf("Failed to write local DB dump file: %v. Error: %v", dbFilePathLocal, err)
 		}
 
-		// Save the first dir as the db name
+		// Save the first dir under envS3Path as the db name. NOTE: envS3Path may itself contain several dirs, eg for backup sets
 		if len(dbName) <= 0 {
-			parts := strings.Split(dbFile, "/")
+			parts := strings.Split(dbFilePathLocal, "/")
 			if len(parts) > 1 {
-				dbName = parts[1]
+				dbName = parts[0]
 			}
 		}
 	}
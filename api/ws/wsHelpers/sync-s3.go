@@ -15,7 +15,62 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
-func SyncScans(destS3Path string, svcs *services.APIServices) error {
+// SyncScans - Copies scan files to the given backup set, except those unchanged since the previous set (if any)
+func SyncScans(setId string, prev *BackupManifest, svcs *services.APIServices) (map[string]BackupFile, error) {
+	scanPaths, err := listScanFiles(svcs)
+	if err != nil {
+		return map[string]BackupFile{}, err
+	}
+
+	return syncFilesToBackupSet(
+		svcs.Config.DatasetsBucket,
+		filepaths.DatasetScansRoot,
+		scanPaths,
+		svcs.Config.DataBackupBucket,
+		setId,
+		prev,
+		svcs.FS,
+		svcs.Log)
+}
+
+// SyncImages - Copies image files to the given backup set, except those unchanged since the previous set (if any)
+func SyncImages(setId string, prev *BackupManifest, svcs *services.APIServices) (map[string]BackupFile, error) {
+	imagePaths, err := listImageFiles(svcs)
+	if err != nil {
+		return map[string]BackupFile{}, err
+	}
+
+	return syncFilesToBackupSet(
+		svcs.Config.DatasetsBucket,
+		filepaths.DatasetImagesRoot,
+		imagePaths,
+		svcs.Config.DataBackupBucket,
+		setId,
+		prev,
+		svcs.FS,
+		svcs.Log)
+}
+
+// SyncQuants - Copies quant files to the given backup set, except those unchanged since the previous set (if any)
+func SyncQuants(setId string, prev *BackupManifest, svcs *services.APIServices) (map[string]BackupFile, error) {
+	quantPaths, err := listQuantFiles(svcs)
+	if err != nil {
+		return map[string]BackupFile{}, err
+	}
+
+	return syncFilesToBackupSet(
+		svcs.Config.UsersBucket,
+		filepaths.RootQuantificationPath,
+		quantPaths,
+		svcs.Config.DataBackupBucket,
+		setId,
+		prev,
+		svcs.FS,
+		svcs.Log)
+}
+
+// Returns the scan files for all scans in the DB, relative to filepaths.DatasetScansRoot
+func listScanFiles(svcs *services.APIServices) ([]string, error) {
 	ctx := context.TODO()
 	coll := svcs.MongoDB.Collection(dbCollections.ScansName)
 
@@ -24,33 +79,57 @@ func SyncScans(destS3Path string, svcs *services.APIServices) error {
 
 	cursor, err := coll.Find(ctx, filter, opts)
 	if err != nil {
-		return err
+		return []string{}, err
 	}
 
 	scanIds := []bson.M{}
 	err = cursor.All(context.TODO(), &scanIds)
 	if err != nil {
-		return err
+		return []string{}, err
 	}
 
-	scanPaths := []string{}
+	// The dataset file is also stored in chunks, which we only know the names of by listing them
+	allFiles, err := svcs.FS.ListObjects(svcs.Config.DatasetsBucket, filepaths.DatasetScansRoot+"/")
+	if err != nil {
+		return []string{}, err
+	}
+
+	relativeFiles, err := makeRelativePaths(allFiles, filepaths.DatasetScansRoot)
+	if err != nil {
+		return []string{}, err
+	}
+
+	ids := []string{}
 	for _, item := range scanIds {
-		id := item["_id"].(string)
-		scanPaths = append(scanPaths, path.Join(id, "dataset.bin"))
-		scanPaths = append(scanPaths, path.Join(id, "diffraction-db.bin"))
+		ids = append(ids, item["_id"].(string))
 	}
 
-	return syncFiles(
-		svcs.Config.DatasetsBucket,
-		filepaths.DatasetScansRoot,
-		scanPaths,
-		svcs.Config.DataBackupBucket,
-		path.Join(destS3Path, filepaths.DatasetScansRoot),
-		svcs.FS,
-		svcs.Log)
+	return makeScanFileList(ids, relativeFiles), nil
 }
 
-func SyncImages(destS3Path string, svcs *services.APIServices) error {
+// Returns the dataset and diffraction files for each scan, and any dataset chunk files of theirs in existingFiles
+func makeScanFileList(scanIds []string, existingFiles []string) []string {
+	chunkFiles := map[string][]string{}
+	for _, f := range existingFiles {
+		scanId, fileName := path.Split(f)
+		if filepaths.IsDatasetChunkFileName(fileName) {
+			scanId = strings.TrimSuffix(scanId, "/")
+			chunkFiles[scanId] = append(chunkFiles[scanId], f)
+		}
+	}
+
+	scanPaths := []string{}
+	for _, id := range scanIds {
+		scanPaths = append(scanPaths, path.Join(id, filepaths.DatasetFileName))
+		scanPaths = append(scanPaths, path.Join(id, filepaths.DiffractionDBFileName))
+		scanPaths = append(scanPaths, chunkFiles[id]...)
+	}
+
+	return scanPaths
+}
+
+// Returns the image files for all images in the DB, relative to filepaths.DatasetImagesRoot
+func listImageFiles(svcs *services.APIServices) ([]string, error) {
 	ctx := context.TODO()
 	coll := svcs.MongoDB.Collection(dbCollections.ImagesName)
 
@@ -59,13 +138,13 @@ func SyncImages(destS3Path string, svcs *services.APIServices) error {
 
 	cursor, err := coll.Find(ctx, filter, opts)
 	if err != nil {
-		return err
+		return []string{}, err
 	}
 
 	items := []bson.M{}
 	err = cursor.All(context.TODO(), &items)
 	if err != nil {
-		return err
+		return []string{}, err
 	}
 
 	imagePaths := []string{}
@@ -73,17 +152,11 @@ func SyncImages(destS3Path string, svcs *services.APIServices) error {
 		imagePaths = append(imagePaths, item["_id"].(string))
 	}
 
-	return syncFiles(
-		svcs.Config.DatasetsBucket,
-		filepaths.DatasetImagesRoot,
-		imagePaths,
-		svcs.Config.DataBackupBucket,
-		path.Join(destS3Path, filepaths.DatasetImagesRoot),
-		svcs.FS,
-		svcs.Log)
+	return imagePaths, nil
 }
 
-func SyncQuants(destS3Path string, svcs *services.APIServices) error {
+// Returns the quant files for all quants in the DB, relative to filepaths.RootQuantificationPath
+func listQuantFiles(svcs *services.APIServices) ([]string, error) {
 	ctx := context.TODO()
 	coll := svcs.MongoDB.Collection(dbCollections.QuantificationsName)
 
@@ -92,46 +165,36 @@ func SyncQuants(destS3Path string, svcs *services.APIServices) error {
 
 	cursor, err := coll.Find(ctx, filter, opts)
 	if err != nil {
-		return err
+		return []string{}, err
 	}
 
 	quantPaths := []string{}
 
 	for cursor.Next(ctx) {
-		type QuantStatus struct {
-			OutputFilePath string
-		}
-		type QuantItem struct {
-			Id     string `bson:"_id"`
-			Status QuantStatus
-		}
-
-		item := QuantItem{}
+		item := quantFileItem{}
 		err = cursor.Decode(&item)
 		if err != nil {
-			return err
+			return []string{}, err
 		}
 
-		// Copying bin and csv files
-		p := path.Join(item.Status.OutputFilePath, item.Id)
-
-		quantPaths = append(quantPaths, p+".bin")
-		quantPaths = append(quantPaths, p+".csv")
+		quantPaths = append(quantPaths, item.filePaths()...)
 	}
 
-	relativeQuantFiles, err := makeRelativePaths(quantPaths, filepaths.RootQuantificationPath)
-	if err != nil {
-		return err
+	return makeRelativePaths(quantPaths, filepaths.RootQuantificationPath)
+}
+
+// Just the parts of a quant DB item we need to find its files
+type quantFileItem struct {
+	Id     string `bson:"_id"`
+	Status struct {
+		OutputFilePath string
 	}
+}
 
-	return syncFiles(
-		svcs.Config.UsersBucket,
-		filepaths.RootQuantificationPath,
-		relativeQuantFiles,
-		svcs.Config.DataBackupBucket,
-		path.Join(destS3Path, filepaths.RootQuantificationPath),
-		svcs.FS,
-		svcs.Log)
+func (q quantFileItem) filePaths() []string {
+	// Copying bin and csv files
+	p := path.Join(q.Status.OutputFilePath, q.Id)
+	return []string{p + ".bin", p + ".csv"}
 }
 
 func RestoreScans(envS3Path string, svcs *services.APIServices) error {
@@ -30,6 +30,7 @@ import (
 	"strings"
 
 	"github.com/pixlise/core/v4/api/config"
+	"github.com/pixlise/core/v4/core/errorwithstatus"
 	"gopkg.in/auth0.v4/management"
 )
 
@@ -72,7 +73,13 @@ func GetJWT(username string, password string, clientID string /*clientSecret str
 	return bodyData.AccessToken, nil
 }
 
+// InitAuth0ManagementAPI - For managing users and their roles. This is only possible if Auth0 is where users log in,
+// with other identity providers users are managed with the provider's own tools
 func InitAuth0ManagementAPI(cfg config.APIConfig) (*management.Management, error) {
+	if len(cfg.IdentityProvider) > 0 && cfg.IdentityProvider != "auth0" {
+		return nil, errorwithstatus.MakeBadRequestError(fmt.Errorf("User management is not available when the identity provider is %v, manage users there instead", cfg.IdentityProvider))
+	}
+
 	api, err := management.New(cfg.Auth0Domain, cfg.Auth0ManagementClientID, cfg.Auth0ManagementSecret)
 	return api, err
 }
@@ -60,6 +60,20 @@ func GetSessionWithRegion(region string) (*session.Session, error) {
 	return sess, nil
 }
 
+// GetSessionWithS3Endpoint - returns an AWS session which talks to an S3-compatible store (eg MinIO) at the given endpoint.
+// These generally don't support bucket names as subdomains, so path style addressing is forced
+func GetSessionWithS3Endpoint(region string, endpoint string) (*session.Session, error) {
+	sess, err := session.NewSession(&aws.Config{
+		Region:           aws.String(region),
+		Endpoint:         aws.String(endpoint),
+		S3ForcePathStyle: aws.Bool(true),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return sess, nil
+}
+
 // GetS3 - returns an S3 session
 func GetS3(sess *session.Session) (s3iface.S3API, error) {
 	svc := s3.New(sess)
@@ -19,9 +19,11 @@ package fileaccess
 
 import (
 	"fmt"
+	"io"
 	"log"
 	"math/rand"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -36,7 +38,9 @@ type testData struct {
 	Description string `json:"description"`
 }
 
-func runTest(fs FileAccess, bucket string) {
+// Conformance test that every FileAccess implementation must pass with the same output. The other bucket is
+// used to check copying between buckets, and must be different from bucket
+func runTest(fs FileAccess, bucket string, otherBucket string) {
 	// Write pretty printed JSON
 	fmt.Printf("JSON: %v\n", fs.WriteJSON(bucket, "the-files/pretty.json", testData{Name: "Hello", Value: 778, Description: "World"}))
 
@@ -98,6 +102,39 @@ func runTest(fs FileAccess, bucket string) {
 	listing, err = fs.ListObjects(bucket, "the-files/non-existant-path/ug")
 	fmt.Printf("Listing bad path: %v, %v\n", err, listing)
 
+	// Write and read back as streams
+	fmt.Printf("Write stream: %v\n", fs.WriteObjectStream(otherBucket, "streamed/data.txt", strings.NewReader("Streamed content")))
+
+	stream, err := fs.ReadObjectStream(otherBucket, "streamed/data.txt")
+	fmt.Printf("Read stream: %v\n", err)
+	if err == nil {
+		streamed, err := io.ReadAll(stream)
+		fmt.Printf("Stream contents: %v, %v\n", err, string(streamed))
+		fmt.Printf("Close stream: %v\n", stream.Close())
+	}
+
+	_, err = fs.ReadObjectStream(otherBucket, "streamed/datazzz.txt")
+	fmt.Printf("Read stream bad path, got not found error: %v\n", fs.IsNotFoundError(err))
+
+	// Copy between buckets
+	fmt.Printf("Copy to other bucket: %v\n", fs.CopyObject(bucket, "the-files/data.bin", otherBucket, "copied/data.bin"))
+
+	data, err = fs.ReadObject(otherBucket, "copied/data.bin")
+	fmt.Printf("Read other bucket copy: %v, %v\n", err, data)
+
+	listing, err = fs.ListObjects(otherBucket, "")
+	fmt.Printf("Listing other bucket: %v, %v\n", err, listing)
+
+	// Copying must not have affected the source bucket
+	exists, err = fs.ObjectExists(bucket, "copied/data.bin")
+	fmt.Printf("Exists in source bucket: %v|%v\n", exists, err)
+
+	// Emptying the other bucket
+	fmt.Printf("Empty other bucket: %v\n", fs.EmptyObjects(otherBucket))
+
+	listing, err = fs.ListObjects(otherBucket, "")
+	fmt.Printf("Listing emptied other bucket: %v, %v\n", err, listing)
+
 	// Delete the copy
 	fmt.Printf("Delete copy: %v\n", fs.DeleteObject(bucket, "the-files/subdir/copied.json"))
 
@@ -122,14 +159,16 @@ func runTest(fs FileAccess, bucket string) {
 func Example_localFileSystem() {
 	// First, clear any files we may have there already
 	fmt.Printf("Setup: %v\n", os.RemoveAll("./test-output/"))
+	fmt.Printf("Setup other: %v\n", os.RemoveAll("./test-output-other/"))
 
 	// Now run the tests
-	runTest(&FSAccess{}, "./test-output")
+	runTest(&FSAccess{}, "./test-output", "./test-output-other")
 
 	// NOTE: test output must match the output from S3 (except cleanup steps)
 
 	// Output:
 	// Setup: <nil>
+	// Setup other: <nil>
 	// JSON: <nil>
 	// JSON no-indent: <nil>
 	// Exists1: false|<nil>
@@ -147,6 +186,17 @@ func Example_localFileSystem() {
 	// Listing subdir: <nil>, [the-files/subdir/copied.json the-files/subdir/ugly.json]
 	// Listing with prefix: <nil>, [the-files/subdir/ugly.json]
 	// Listing bad path: <nil>, []
+	// Write stream: <nil>
+	// Read stream: <nil>
+	// Stream contents: <nil>, Streamed content
+	// Close stream: <nil>
+	// Read stream bad path, got not found error: true
+	// Copy to other bucket: <nil>
+	// Read other bucket copy: <nil>, [250 130 10 0 33]
+	// Listing other bucket: <nil>, [copied/data.bin streamed/data.txt]
+	// Exists in source bucket: false|<nil>
+	// Empty other bucket: <nil>
+	// Listing emptied other bucket: <nil>, []
 	// Delete copy: <nil>
 	// Delete bin: <nil>
 	// Listing2: <nil>, [the-files/pretty.json the-files/subdir/ugly.json]
@@ -161,7 +211,7 @@ func Example_localFileSystemS3Sim() {
 
 	// Now run the tests
 	fs := MakeFSAccessS3Simulator("./test-bucket-root")
-	runTest(fs, "my-bucket")
+	runTest(fs, "my-bucket", "my-other-bucket")
 
 	// NOTE: test output must match the output from S3 (except cleanup steps)
 
@@ -184,6 +234,64 @@ func Example_localFileSystemS3Sim() {
 	// Listing subdir: <nil>, [the-files/subdir/copied.json the-files/subdir/ugly.json]
 	// Listing with prefix: <nil>, [the-files/subdir/ugly.json]
 	// Listing bad path: <nil>, []
+	// Write stream: <nil>
+	// Read stream: <nil>
+	// Stream contents: <nil>, Streamed content
+	// Close stream: <nil>
+	// Read stream bad path, got not found error: true
+	// Copy to other bucket: <nil>
+	// Read other bucket copy: <nil>, [250 130 10 0 33]
+	// Listing other bucket: <nil>, [copied/data.bin streamed/data.txt]
+	// Exists in source bucket: false|<nil>
+	// Empty other bucket: <nil>
+	// Listing emptied other bucket: <nil>, []
+	// Delete copy: <nil>
+	// Delete bin: <nil>
+	// Listing2: <nil>, [the-files/pretty.json the-files/subdir/ugly.json]
+	// Listing subdir2: <nil>, [the-files/subdir/ugly.json]
+	// Empty dir: <nil>
+	// Listing subdir3: <nil>, []
+}
+
+func Example_memory() {
+	fs := MakeMemoryAccess()
+	fmt.Printf("Setup: %v\n", fs.EmptyObjects("my-bucket"))
+
+	// Now run the tests
+	runTest(fs, "my-bucket", "my-other-bucket")
+
+	// NOTE: test output must match the output from S3 (except cleanup steps)
+
+	// Output:
+	// Setup: <nil>
+	// JSON: <nil>
+	// JSON no-indent: <nil>
+	// Exists1: false|<nil>
+	// Binary: <nil>
+	// Exists2: true|<nil>
+	// Copy: <nil>
+	// Copy bad path, got not found error: true
+	// Read JSON: <nil>, {Hello 778 World}
+	// Read JSON no-indent: <nil>, {Hello 778 World}
+	// Read Binary: <nil>, [250 130 10 0 33]
+	// Read bad path, got not found error: true
+	// Read bad JSON: invalid character 'ú' looking for beginning of value
+	// Not a "not found" error: true
+	// Listing: <nil>, [the-files/data.bin the-files/pretty.json the-files/subdir/copied.json the-files/subdir/ugly.json]
+	// Listing subdir: <nil>, [the-files/subdir/copied.json the-files/subdir/ugly.json]
+	// Listing with prefix: <nil>, [the-files/subdir/ugly.json]
+	// Listing bad path: <nil>, []
+	// Write stream: <nil>
+	// Read stream: <nil>
+	// Stream contents: <nil>, Streamed content
+	// Close stream: <nil>
+	// Read stream bad path, got not found error: true
+	// Copy to other bucket: <nil>
+	// Read other bucket copy: <nil>, [250 130 10 0 33]
+	// Listing other bucket: <nil>, [copied/data.bin streamed/data.txt]
+	// Exists in source bucket: false|<nil>
+	// Empty other bucket: <nil>
+	// Listing emptied other bucket: <nil>, []
 	// Delete copy: <nil>
 	// Delete bin: <nil>
 	// Listing2: <nil>, [the-files/pretty.json the-files/subdir/ugly.json]
@@ -225,8 +333,21 @@ func Example_s3() {
 		fmt.Printf("Delete bucket errors: %v\n", err)
 	}()
 
+	// And another one for testing copying between buckets
+	otherTestBucket := testBucket + "-other"
+	_, err = s3svc.CreateBucket(&s3.CreateBucketInput{Bucket: aws.String(otherTestBucket)})
+	if err != nil {
+		fmt.Printf("Failed to create other test S3 bucket: %v\n", err)
+		return
+	}
+
+	defer func() {
+		_, err := s3svc.DeleteBucket(&s3.DeleteBucketInput{Bucket: aws.String(otherTestBucket)})
+		fmt.Printf("Delete other bucket errors: %v\n", err)
+	}()
+
 	// Now run the tests
-	runTest(fs, testBucket)
+	runTest(fs, testBucket, otherTestBucket)
 
 	// NOTE: test output must match the output from local file system (except cleanup steps)
 
@@ -249,12 +370,24 @@ func Example_s3() {
 	// Listing subdir: <nil>, [the-files/subdir/copied.json the-files/subdir/ugly.json]
 	// Listing with prefix: <nil>, [the-files/subdir/ugly.json]
 	// Listing bad path: <nil>, []
+	// Write stream: <nil>
+	// Read stream: <nil>
+	// Stream contents: <nil>, Streamed content
+	// Close stream: <nil>
+	// Read stream bad path, got not found error: true
+	// Copy to other bucket: <nil>
+	// Read other bucket copy: <nil>, [250 130 10 0 33]
+	// Listing other bucket: <nil>, [copied/data.bin streamed/data.txt]
+	// Exists in source bucket: false|<nil>
+	// Empty other bucket: <nil>
+	// Listing emptied other bucket: <nil>, []
 	// Delete copy: <nil>
 	// Delete bin: <nil>
 	// Listing2: <nil>, [the-files/pretty.json the-files/subdir/ugly.json]
 	// Listing subdir2: <nil>, [the-files/subdir/ugly.json]
 	// Empty dir: <nil>
 	// Listing subdir3: <nil>, []
+	// Delete other bucket errors: <nil>
 	// Delete bucket errors: <nil>
 }
 
@@ -0,0 +1,200 @@
+// Licensed to NASA JPL under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. NASA JPL licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package fileaccess
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pixlise/core/v4/core/utils"
+)
+
+// Implementation of file access that keeps everything in memory. Useful for unit tests that need a working
+// file store without mocking S3 calls or leaving files on disk. Buckets are created as they are first written to
+type MemoryAccess struct {
+	mutex   sync.Mutex
+	buckets map[string]map[string][]byte
+}
+
+func MakeMemoryAccess() *MemoryAccess {
+	return &MemoryAccess{buckets: map[string]map[string][]byte{}}
+}
+
+// Returned when a bucket/path combination doesn't exist, so IsNotFoundError can recognise it
+type memoryNotFoundError struct {
+	bucket string
+	path   string
+}
+
+func (e memoryNotFoundError) Error() string {
+	return fmt.Sprintf("%v/%v: not found", e.bucket, e.path)
+}
+
+func (m *MemoryAccess) ListObjects(bucket string, prefix string) ([]string, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	result := []string{}
+	for path := range m.buckets[bucket] {
+		if strings.HasPrefix(path, prefix) {
+			result = append(result, path)
+		}
+	}
+
+	// Return in the same order S3 lists things in
+	sort.Strings(result)
+	return result, nil
+}
+
+func (m *MemoryAccess) ObjectExists(bucket string, path string) (bool, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	_, ok := m.buckets[bucket][path]
+	return ok, nil
+}
+
+func (m *MemoryAccess) ReadObject(bucket string, path string) ([]byte, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	data, ok := m.buckets[bucket][path]
+	if !ok {
+		return nil, memoryNotFoundError{bucket: bucket, path: path}
+	}
+
+	// Return a copy so callers can't modify what we store
+	return bytes.Clone(data), nil
+}
+
+func (m *MemoryAccess) WriteObject(bucket string, path string, data []byte) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.writeObject(bucket, path, bytes.Clone(data))
+	return nil
+}
+
+func (m *MemoryAccess) ReadObjectStream(bucket string, path string) (io.ReadCloser, error) {
+	data, err := m.ReadObject(bucket, path)
+	if err != nil {
+		return nil, err
+	}
+
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (m *MemoryAccess) WriteObjectStream(bucket string, path string, stream io.Reader) error {
+	data, err := io.ReadAll(stream)
+	if err != nil {
+		return err
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.writeObject(bucket, path, data)
+	return nil
+}
+
+func (m *MemoryAccess) ReadJSON(bucket string, s3Path string, itemsPtr interface{}, emptyIfNotFound bool) error {
+	fileData, err := m.ReadObject(bucket, s3Path)
+
+	// If we got an error, and it's a not found, and we're told to ignore these and return empty data, then do so
+	if err != nil {
+		if emptyIfNotFound && m.IsNotFoundError(err) {
+			return nil
+		}
+		return err
+	}
+
+	return json.Unmarshal(fileData, itemsPtr)
+}
+
+func (m *MemoryAccess) WriteJSON(bucket string, s3Path string, itemsPtr interface{}) error {
+	fileData, err := json.MarshalIndent(itemsPtr, "", utils.PrettyPrintIndentForJSON)
+	if err != nil {
+		return err
+	}
+
+	return m.WriteObject(bucket, s3Path, fileData)
+}
+
+func (m *MemoryAccess) WriteJSONNoIndent(bucket string, s3Path string, itemsPtr interface{}) error {
+	fileData, err := json.Marshal(itemsPtr)
+	if err != nil {
+		return err
+	}
+
+	return m.WriteObject(bucket, s3Path, fileData)
+}
+
+func (m *MemoryAccess) DeleteObject(bucket string, path string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if _, ok := m.buckets[bucket][path]; !ok {
+		return memoryNotFoundError{bucket: bucket, path: path}
+	}
+
+	delete(m.buckets[bucket], path)
+	return nil
+}
+
+func (m *MemoryAccess) CopyObject(srcBucket string, srcPath string, dstBucket string, dstPath string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	data, ok := m.buckets[srcBucket][srcPath]
+	if !ok {
+		return memoryNotFoundError{bucket: srcBucket, path: srcPath}
+	}
+
+	m.writeObject(dstBucket, dstPath, bytes.Clone(data))
+	return nil
+}
+
+func (m *MemoryAccess) EmptyObjects(targetBucket string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	// Like S3, the bucket itself is left in place, just without any files
+	m.buckets[targetBucket] = map[string][]byte{}
+	return nil
+}
+
+func (m *MemoryAccess) IsNotFoundError(err error) bool {
+	_, ok := err.(memoryNotFoundError)
+	return ok
+}
+
+// Assumes mutex is already locked
+func (m *MemoryAccess) writeObject(bucket string, path string, data []byte) {
+	files, ok := m.buckets[bucket]
+	if !ok {
+		files = map[string][]byte{}
+		m.buckets[bucket] = files
+	}
+
+	files[path] = data
+}
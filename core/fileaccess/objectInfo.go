@@ -0,0 +1,108 @@
+// Licensed to NASA JPL under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. NASA JPL licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package fileaccess
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+// ObjectInfo - What we know about a stored object without reading it, so callers can tell if it has changed
+type ObjectInfo struct {
+	Size     int64  `json:"size"`
+	Checksum string `json:"checksum"`
+}
+
+// Implemented by FileAccess implementations which can list sizes and checksums cheaply
+type objectInfoLister interface {
+	ListObjectInfo(bucket string, prefix string) (map[string]ObjectInfo, error)
+}
+
+// ListObjectInfo - Lists the objects under prefix like ListObjects, returning the size and checksum of each. S3 returns
+// these in the listing (the checksum being the ETag), for anything else we read each object and checksum it with MD5.
+// Checksums should therefore only be compared to others from the same FileAccess implementation
+func ListObjectInfo(fs FileAccess, bucket string, prefix string) (map[string]ObjectInfo, error) {
+	if lister, ok := fs.(objectInfoLister); ok {
+		return lister.ListObjectInfo(bucket, prefix)
+	}
+
+	paths, err := fs.ListObjects(bucket, prefix)
+	if err != nil {
+		return map[string]ObjectInfo{}, err
+	}
+
+	result := map[string]ObjectInfo{}
+	for _, p := range paths {
+		data, err := fs.ReadObject(bucket, p)
+		if err != nil {
+			return map[string]ObjectInfo{}, err
+		}
+
+		sum := md5.Sum(data)
+		result[p] = ObjectInfo{Size: int64(len(data)), Checksum: hex.EncodeToString(sum[:])}
+	}
+
+	return result, nil
+}
+
+// ListObjectInfo - Same listing as ListObjects, but also returns the size and ETag of each object
+func (s3Access S3Access) ListObjectInfo(bucket string, prefix string) (map[string]ObjectInfo, error) {
+	continuationToken := ""
+	result := map[string]ObjectInfo{}
+
+	params := s3.ListObjectsV2Input{
+		Bucket: aws.String(bucket),
+		Prefix: aws.String(prefix),
+	}
+
+	for {
+		if len(continuationToken) > 0 {
+			params.ContinuationToken = aws.String(continuationToken)
+		}
+
+		listing, err := s3Access.s3Api.ListObjectsV2(&params)
+		if err != nil {
+			return map[string]ObjectInfo{}, err
+		}
+
+		for _, item := range listing.Contents {
+			if strings.HasSuffix(*item.Key, "/") {
+				continue
+			}
+
+			info := ObjectInfo{Size: aws.Int64Value(item.Size)}
+
+			// S3 returns the ETag in quotes
+			info.Checksum = strings.Trim(aws.StringValue(item.ETag), "\"")
+
+			result[*item.Key] = info
+		}
+
+		if listing.IsTruncated != nil && *listing.IsTruncated && listing.NextContinuationToken != nil {
+			continuationToken = *listing.NextContinuationToken
+		} else {
+			break
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,76 @@
+// Licensed to NASA JPL under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. NASA JPL licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package fileaccess
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/pixlise/core/v4/core/awsutil"
+)
+
+func ExampleListObjectInfo() {
+	fs := MakeMemoryAccess()
+	fs.WriteObject("bucket", "Scans/123/dataset.bin", []byte{1, 2, 3})
+	fs.WriteObject("bucket", "Scans/123/diffraction-db.bin", []byte{})
+	fs.WriteObject("bucket", "Images/123/context.png", []byte{4, 5})
+
+	infos, err := ListObjectInfo(fs, "bucket", "Scans")
+	fmt.Printf("%v, %v\n", err, infos)
+
+	// Output:
+	// <nil>, map[Scans/123/dataset.bin:{3 5289df737df57326fcdd22597afb1fac} Scans/123/diffraction-db.bin:{0 d41d8cd98f00b204e9800998ecf8427e}]
+}
+
+func Example_s3ListObjectInfo() {
+	var mockS3 awsutil.MockS3Client
+	defer mockS3.FinishTest()
+
+	mockS3.ExpListObjectsV2Input = []s3.ListObjectsV2Input{
+		{
+			Bucket: aws.String("bucket"), Prefix: aws.String("Scans"),
+		},
+		{
+			Bucket: aws.String("bucket"), Prefix: aws.String("Scans"), ContinuationToken: aws.String("cont-1"),
+		},
+	}
+	mockS3.QueuedListObjectsV2Output = []*s3.ListObjectsV2Output{
+		{
+			IsTruncated:           aws.Bool(true),
+			NextContinuationToken: aws.String("cont-1"),
+			Contents: []*s3.Object{
+				{Key: aws.String("Scans/"), Size: aws.Int64(0), ETag: aws.String("\"d41d8cd98f00b204e9800998ecf8427e\"")},
+				{Key: aws.String("Scans/123/dataset.bin"), Size: aws.Int64(3), ETag: aws.String("\"5289df737df57326fcdd22597afb1fac\"")},
+			},
+		},
+		{
+			IsTruncated: aws.Bool(false),
+			Contents: []*s3.Object{
+				{Key: aws.String("Scans/456/dataset.bin"), Size: aws.Int64(10485760), ETag: aws.String("\"a3b1c2d3e4f5a6b7c8d9e0f1a2b3c4d5-2\"")},
+			},
+		},
+	}
+
+	fs := MakeS3Access(&mockS3)
+	infos, err := ListObjectInfo(fs, "bucket", "Scans")
+	fmt.Printf("%v, %v\n", err, infos)
+
+	// Output:
+	// <nil>, map[Scans/123/dataset.bin:{3 5289df737df57326fcdd22597afb1fac} Scans/456/dataset.bin:{10485760 a3b1c2d3e4f5a6b7c8d9e0f1a2b3c4d5-2}]
+}
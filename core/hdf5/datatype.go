@@ -0,0 +1,427 @@
+// Licensed to NASA JPL under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. NASA JPL licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package hdf5
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/bits"
+	"strings"
+)
+
+// Datatype classes
+const (
+	ClassFixedPoint = 0
+	ClassFloat      = 1
+	ClassTime       = 2
+	ClassString     = 3
+	ClassBitfield   = 4
+	ClassOpaque     = 5
+	ClassCompound   = 6
+	ClassReference  = 7
+	ClassEnum       = 8
+	ClassVarLen     = 9
+	ClassArray      = 10
+)
+
+// Datatype - the type of each element of a dataset or attribute
+type Datatype struct {
+	Class     int
+	Size      int // Size of each element in bytes
+	Signed    bool
+	BigEndian bool
+
+	// Set for variable length strings, which are stored in the global heap
+	VarLenString bool
+}
+
+// IsNumeric - true for integer and floating point types
+func (t *Datatype) IsNumeric() bool {
+	return (t.Class == ClassFixedPoint && (t.Size == 1 || t.Size == 2 || t.Size == 4 || t.Size == 8)) ||
+		(t.Class == ClassFloat && (t.Size == 4 || t.Size == 8))
+}
+
+// IsString - true for fixed and variable length strings
+func (t *Datatype) IsString() bool {
+	return t.Class == ClassString || t.VarLenString
+}
+
+func (t *Datatype) String() string {
+	switch {
+	case t.Class == ClassFixedPoint && t.Signed:
+		return fmt.Sprintf("int%v", t.Size*8)
+	case t.Class == ClassFixedPoint:
+		return fmt.Sprintf("uint%v", t.Size*8)
+	case t.Class == ClassFloat:
+		return fmt.Sprintf("float%v", t.Size*8)
+	case t.VarLenString:
+		return "string"
+	case t.Class == ClassString:
+		return fmt.Sprintf("string%v", t.Size)
+	}
+	return fmt.Sprintf("class%v(%v bytes)", t.Class, t.Size)
+}
+
+// Returns the datatype and how many bytes of msg it was read from
+func (f *File) readDatatype(msg []byte) (*Datatype, int, error) {
+	p := f.makeParser(msg)
+	classAndVersion := p.u8()
+	bits := p.bytes(3)
+	size := p.u32()
+	if p.err != nil {
+		return nil, 0, p.err
+	}
+
+	t := &Datatype{Class: int(classAndVersion & 0x0F), Size: int(size)}
+
+	switch t.Class {
+	case ClassFixedPoint:
+		t.BigEndian = bits[0]&0x01 != 0
+		t.Signed = bits[0]&0x08 != 0
+		p.skip(4) // Bit offset and precision
+	case ClassFloat:
+		t.BigEndian = bits[0]&0x01 != 0
+		if bits[0]&0x40 != 0 {
+			return nil, 0, fmt.Errorf("VAX floating point data is not supported")
+		}
+		p.skip(12) // Bit offset/precision, exponent and mantissa layout and bias
+	case ClassString:
+	case ClassVarLen:
+		// Strings are the only variable length type we read, otherwise it's a sequence
+		_, baseSize, err := f.readDatatype(msg[p.pos:])
+		if err != nil {
+			return nil, 0, err
+		}
+		p.skip(baseSize)
+		t.VarLenString = bits[0]&0x0F == 1
+	default:
+		// Something we don't read the data of, so the rest of the message doesn't matter
+		p.pos = len(msg)
+	}
+
+	return t, p.pos, p.err
+}
+
+func (f *File) readDataspace(msg []byte) ([]uint64, error) {
+	p := f.makeParser(msg)
+	version := p.u8()
+	rank := int(p.u8())
+	p.u8() // Flags, we don't care about max dimensions
+
+	switch version {
+	case 1:
+		p.skip(5)
+	case 2:
+		if dsType := p.u8(); dsType == 2 {
+			// Null dataspace, has no elements
+			return []uint64{0}, p.err
+		}
+	default:
+		return nil, fmt.Errorf("Unsupported dataspace version: %v", version)
+	}
+
+	dims := []uint64{}
+	for c := 0; c < rank; c++ {
+		dims = append(dims, p.length())
+	}
+
+	// Every element takes at least a byte, so we could never read more elements than this. Checking here means
+	// element counts can safely be converted to int everywhere else
+	if count := elementCount(dims); count > maxDatasetBytes {
+		return nil, fmt.Errorf("Dataspace too large: %v elements", count)
+	}
+
+	return dims, p.err
+}
+
+// Multiplies out the dimensions. A corrupt file could have dimensions whose product overflows, so this saturates at the
+// largest uint64 instead, which fails any size check
+func elementCount(dims []uint64) uint64 {
+	count := uint64(1)
+	for _, d := range dims {
+		count = mulSaturating(count, d)
+	}
+	return count
+}
+
+func mulSaturating(a uint64, b uint64) uint64 {
+	hi, lo := bits.Mul64(a, b)
+	if hi != 0 {
+		return math.MaxUint64
+	}
+	return lo
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////
+// Attributes
+
+// Attribute - a named value stored in the header of a group or dataset
+type Attribute struct {
+	Name  string
+	Value *Data
+}
+
+func (f *File) readAttribute(msg []byte) (Attribute, error) {
+	result := Attribute{}
+
+	p := f.makeParser(msg)
+	version := p.u8()
+	p.u8() // Reserved in v1, flags otherwise
+	nameSize := int(p.u16())
+	datatypeSize := int(p.u16())
+	dataspaceSize := int(p.u16())
+
+	// Version 1 pads each field to 8 bytes
+	padTo := 1
+	switch version {
+	case 1:
+		padTo = 8
+	case 2:
+	case 3:
+		p.u8() // Name character set
+	default:
+		return result, fmt.Errorf("Unsupported attribute message version: %v", version)
+	}
+
+	result.Name = readNullTerminated(p.bytes(nameSize))
+	p.align(padTo)
+	datatypeMsg := p.bytes(datatypeSize)
+	p.align(padTo)
+	dataspaceMsg := p.bytes(dataspaceSize)
+	p.align(padTo)
+	if p.err != nil {
+		return result, p.err
+	}
+
+	datatype, _, err := f.readDatatype(datatypeMsg)
+	if err != nil {
+		return result, fmt.Errorf("Attribute %v: %v", result.Name, err)
+	}
+
+	dims, err := f.readDataspace(dataspaceMsg)
+	if err != nil {
+		return result, fmt.Errorf("Attribute %v: %v", result.Name, err)
+	}
+
+	size := mulSaturating(elementCount(dims), uint64(f.storedElementSize(datatype)))
+	if size > uint64(p.remaining()) {
+		return result, fmt.Errorf("Attribute %v: expected %v bytes of data, got %v", result.Name, size, p.remaining())
+	}
+
+	raw := p.bytes(int(size))
+	if p.err != nil {
+		return result, fmt.Errorf("Attribute %v: %v", result.Name, p.err)
+	}
+
+	result.Value, err = f.makeData(dims, datatype, raw)
+	if err != nil {
+		return result, fmt.Errorf("Attribute %v: %v", result.Name, err)
+	}
+	return result, nil
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////
+// Values
+
+// Data - values read from a dataset or attribute
+type Data struct {
+	Dims []uint64
+	Type *Datatype
+
+	raw     []byte
+	strings []string
+}
+
+// How many bytes each element takes in the file. Variable length data is stored as a length followed by a global heap ID
+func (f *File) storedElementSize(t *Datatype) int {
+	if t.Class == ClassVarLen {
+		return 4 + f.offsetSize + 4
+	}
+	return t.Size
+}
+
+func (f *File) makeData(dims []uint64, t *Datatype, raw []byte) (*Data, error) {
+	result := &Data{Dims: dims, Type: t, raw: raw}
+
+	count := int(elementCount(dims))
+	elemSize := f.storedElementSize(t)
+	if size := mulSaturating(uint64(count), uint64(elemSize)); uint64(len(raw)) < size {
+		return nil, fmt.Errorf("Expected %v bytes of data, got %v", size, len(raw))
+	}
+
+	if t.Class == ClassString {
+		for c := 0; c < count; c++ {
+			result.strings = append(result.strings, strings.TrimRight(readNullTerminated(raw[c*elemSize:(c+1)*elemSize]), " "))
+		}
+	} else if t.VarLenString {
+		heaps := map[uint64][]byte{}
+		for c := 0; c < count; c++ {
+			p := f.makeParser(raw[c*elemSize : (c+1)*elemSize])
+			length := p.u32()
+			heapAddr := p.offset()
+			index := p.u32()
+			if length == 0 {
+				result.strings = append(result.strings, "")
+				continue
+			}
+
+			s, err := f.readGlobalHeapObject(heaps, heapAddr, index)
+			if err != nil {
+				return nil, err
+			}
+			result.strings = append(result.strings, s)
+		}
+	}
+
+	return result, nil
+}
+
+// Len - how many values there are
+func (d *Data) Len() int {
+	return int(elementCount(d.Dims))
+}
+
+// IsNumeric - true if the values are integers or floats
+func (d *Data) IsNumeric() bool {
+	return d.Type.IsNumeric()
+}
+
+// IsString - true if the values are strings
+func (d *Data) IsString() bool {
+	return d.Type.IsString()
+}
+
+// String - returns the value at index i as a string. Returns "" if it's not a string type
+func (d *Data) String(i int) string {
+	if i < 0 || i >= len(d.strings) {
+		return ""
+	}
+	return d.strings[i]
+}
+
+// Strings - returns all values as strings, or nil if it's not a string type
+func (d *Data) Strings() []string {
+	return d.strings
+}
+
+// Float64 - returns the value at index i as a float64. Returns 0 if it's not a numeric type
+func (d *Data) Float64(i int) float64 {
+	if !d.IsNumeric() || i < 0 || i >= d.Len() {
+		return 0
+	}
+
+	b := d.raw[i*d.Type.Size : (i+1)*d.Type.Size]
+	var order binary.ByteOrder = binary.LittleEndian
+	if d.Type.BigEndian {
+		order = binary.BigEndian
+	}
+
+	if d.Type.Class == ClassFloat {
+		if d.Type.Size == 4 {
+			return float64(math.Float32frombits(order.Uint32(b)))
+		}
+		return math.Float64frombits(order.Uint64(b))
+	}
+
+	switch d.Type.Size {
+	case 1:
+		if d.Type.Signed {
+			return float64(int8(b[0]))
+		}
+		return float64(b[0])
+	case 2:
+		if d.Type.Signed {
+			return float64(int16(order.Uint16(b)))
+		}
+		return float64(order.Uint16(b))
+	case 4:
+		if d.Type.Signed {
+			return float64(int32(order.Uint32(b)))
+		}
+		return float64(order.Uint32(b))
+	}
+
+	if d.Type.Signed {
+		return float64(int64(order.Uint64(b)))
+	}
+	return float64(order.Uint64(b))
+}
+
+// Float64s - returns all values as float64s, or nil if it's not a numeric type
+func (d *Data) Float64s() []float64 {
+	if !d.IsNumeric() {
+		return nil
+	}
+
+	result := make([]float64, d.Len())
+	for c := range result {
+		result[c] = d.Float64(c)
+	}
+	return result
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////
+// Global heap, where variable length data lives
+
+func (f *File) readGlobalHeapObject(heaps map[uint64][]byte, addr uint64, index uint32) (string, error) {
+	heap, ok := heaps[addr]
+	if !ok {
+		hdr, err := f.readSignedBlock(addr, 8+f.lengthSize, "GCOL")
+		if err != nil {
+			return "", err
+		}
+
+		p := f.makeParser(hdr)
+		p.skip(8)
+		size := p.length()
+		if size > maxObjectHeaderBytes {
+			return "", fmt.Errorf("Global heap collection too large: %v bytes", size)
+		}
+
+		heap, err = f.readAt(addr, int(size))
+		if err != nil {
+			return "", err
+		}
+		heaps[addr] = heap
+	}
+
+	// Objects follow the collection header. Each is padded to 8 bytes, and object 0 is the free space at the end
+	p := f.makeParser(heap)
+	p.skip(8 + f.lengthSize)
+	for p.remaining() >= 8+f.lengthSize {
+		objIndex := p.u16()
+		p.skip(6) // Reference count and reserved
+		size := p.length()
+		if objIndex == 0 {
+			break
+		}
+
+		data := p.bytes(int(size))
+		p.align(8)
+		if p.err != nil {
+			return "", p.err
+		}
+
+		if uint32(objIndex) == index {
+			return readNullTerminated(data), nil
+		}
+	}
+
+	return "", fmt.Errorf("Global heap object %v not found in collection at %v", index, addr)
+}
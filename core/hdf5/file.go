@@ -0,0 +1,438 @@
+// Licensed to NASA JPL under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. NASA JPL licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package hdf5 is a read-only, pure Go (no cgo) reader for HDF5 files.
+// We read a subset of what's possible, enough for the files written by h5py and the HDF5 library with
+// its default settings, which is what instruments and beamlines generally produce:
+//   - Superblock versions 0-3
+//   - Version 1 and 2 object headers
+//   - Groups stored as symbol tables or compact link messages (dense link storage is not supported)
+//   - Fixed-point, floating point and string (fixed and variable length) data types
+//   - Compact, contiguous and chunked data. Chunks can be indexed by a version 1 B-tree, or
+//     (version 4 layouts) single chunk, implicit or unpaged fixed array indexes
+//   - Deflate, shuffle and fletcher32 filters
+//
+// References:
+// https://docs.hdfgroup.org/hdf5/develop/_f_m_t3.html
+// https://support.hdfgroup.org/documentation/hdf5/latest/_f_m_t3.html
+package hdf5
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+)
+
+// Signature is the 8 bytes at the start of an HDF5 superblock
+var Signature = []byte{0x89, 'H', 'D', 'F', '\r', '\n', 0x1a, '\n'}
+
+// The superblock can be at 0, 512, 1024, 2048... if the file has a user block. We don't search too far
+const maxSuperblockSearchOffset = 1 << 20
+
+// How many soft links we follow when looking up a path before deciding it's a loop
+const maxSoftLinkDepth = 16
+
+// File - an open HDF5 file
+type File struct {
+	r          io.ReaderAt
+	size       int64 // -1 if we can't tell from the reader
+	closer     io.Closer
+	offsetSize int
+	lengthSize int
+	baseAddr   uint64
+	rootAddr   uint64
+}
+
+// IsHDF5 - Checks if the file at the given path starts with an HDF5 superblock
+func IsHDF5(filePath string) bool {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return false
+	}
+	defer file.Close()
+
+	_, err = findSuperblock(file)
+	return err == nil
+}
+
+// Open - Opens an HDF5 file from disk. Caller must Close() it
+func Open(filePath string) (*File, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := NewFile(file)
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("Failed to read HDF5 file: %v. Error: %v", filePath, err)
+	}
+
+	f.closer = file
+	return f, nil
+}
+
+// NewFile - Reads an HDF5 file from the given reader
+func NewFile(r io.ReaderAt) (*File, error) {
+	sbAddr, err := findSuperblock(r)
+	if err != nil {
+		return nil, err
+	}
+
+	f := &File{r: r, size: readerSize(r)}
+	err = f.readSuperblock(sbAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+// Works out the size of the file from the reader, so we can check addresses and sizes read from the file before
+// allocating anything. Files and the readers in bytes and io all have a way to tell us
+func readerSize(r io.ReaderAt) int64 {
+	switch sized := r.(type) {
+	case interface{ Size() int64 }:
+		return sized.Size()
+	case interface{ Stat() (os.FileInfo, error) }:
+		if info, err := sized.Stat(); err == nil {
+			return info.Size()
+		}
+	}
+	return -1
+}
+
+// Close - Closes the underlying file, if we opened it
+func (f *File) Close() error {
+	if f.closer != nil {
+		return f.closer.Close()
+	}
+	return nil
+}
+
+// Root - Returns the root group
+func (f *File) Root() (*Object, error) {
+	return f.readObject("/", f.rootAddr)
+}
+
+// Get - Returns the group or dataset at the given absolute path, eg /entry/data/counts
+func (f *File) Get(objPath string) (*Object, error) {
+	return f.get(objPath, 0)
+}
+
+func (f *File) get(objPath string, depth int) (*Object, error) {
+	if depth > maxSoftLinkDepth {
+		return nil, fmt.Errorf("Too many soft links when looking up: %v", objPath)
+	}
+
+	obj, err := f.Root()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range strings.Split(strings.Trim(path.Clean("/"+objPath), "/"), "/") {
+		if len(name) <= 0 {
+			continue
+		}
+
+		obj, err = obj.child(name, depth)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return obj, nil
+}
+
+// Walk - Calls fn for every group and dataset in the file, parents before children. Objects reachable by more than one
+// hard link are only visited once, and soft and external links are not followed
+func (f *File) Walk(fn func(obj *Object) error) error {
+	root, err := f.Root()
+	if err != nil {
+		return err
+	}
+
+	visited := map[uint64]bool{}
+	return f.walk(root, visited, fn)
+}
+
+func (f *File) walk(obj *Object, visited map[uint64]bool, fn func(obj *Object) error) error {
+	visited[obj.addr] = true
+
+	err := fn(obj)
+	if err != nil {
+		return err
+	}
+
+	for _, l := range obj.links {
+		if l.linkType != linkTypeHard || visited[l.addr] {
+			continue
+		}
+
+		child, err := f.readObject(path.Join(obj.Path, l.name), l.addr)
+		if err != nil {
+			return err
+		}
+
+		err = f.walk(child, visited, fn)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func findSuperblock(r io.ReaderAt) (uint64, error) {
+	sig := make([]byte, len(Signature))
+	for addr := int64(0); addr <= maxSuperblockSearchOffset; addr = max(addr*2, 512) {
+		_, err := r.ReadAt(sig, addr)
+		if err != nil {
+			break
+		}
+
+		if bytes.Equal(sig, Signature) {
+			return uint64(addr), nil
+		}
+	}
+
+	return 0, errors.New("HDF5 signature not found")
+}
+
+func (f *File) readSuperblock(sbAddr uint64) error {
+	// Enough to get us to the sizes, which are in the same place in all versions
+	hdr, err := f.readAt(sbAddr, 16)
+	if err != nil {
+		return err
+	}
+
+	version := hdr[8]
+
+	var p *parser
+	switch version {
+	case 0, 1:
+		f.offsetSize = int(hdr[13])
+		f.lengthSize = int(hdr[14])
+		err = f.checkSizes()
+		if err != nil {
+			return err
+		}
+
+		// Fixed part, 4 addresses, then the root group symbol table entry
+		fixedSize := 24
+		if version == 1 {
+			fixedSize += 4
+		}
+
+		sb, err := f.readAt(sbAddr, fixedSize+4*f.offsetSize+f.symbolTableEntrySize())
+		if err != nil {
+			return err
+		}
+
+		p = f.makeParser(sb)
+		p.skip(fixedSize)
+		f.baseAddr = p.offset()
+		p.skip(3 * f.offsetSize) // Free-space info, end of file and driver info addresses
+
+		entry := f.readSymbolTableEntry(p)
+		f.rootAddr = entry.addr
+	case 2, 3:
+		f.offsetSize = int(hdr[9])
+		f.lengthSize = int(hdr[10])
+		err = f.checkSizes()
+		if err != nil {
+			return err
+		}
+
+		sb, err := f.readAt(sbAddr, 12+4*f.offsetSize)
+		if err != nil {
+			return err
+		}
+
+		p = f.makeParser(sb)
+		p.skip(12)
+		f.baseAddr = p.offset()
+		p.skip(2 * f.offsetSize) // Superblock extension and end of file addresses
+		f.rootAddr = p.offset()
+	default:
+		return fmt.Errorf("Unsupported HDF5 superblock version: %v", version)
+	}
+
+	if p.err != nil {
+		return p.err
+	}
+
+	// Files with a user block have their base address set to where the superblock is, otherwise it's 0
+	if f.baseAddr == 0 {
+		f.baseAddr = sbAddr
+	}
+
+	return nil
+}
+
+func (f *File) checkSizes() error {
+	for _, size := range []int{f.offsetSize, f.lengthSize} {
+		if size != 2 && size != 4 && size != 8 {
+			return fmt.Errorf("Unsupported HDF5 offset/length size: %v", size)
+		}
+	}
+	return nil
+}
+
+// Reads n bytes at the given address, which is relative to the base address like all addresses in the file
+func (f *File) readAt(addr uint64, n int) ([]byte, error) {
+	if n < 0 {
+		return nil, fmt.Errorf("Invalid HDF5 read size %v at address %v", n, addr)
+	}
+
+	// Sizes come from the file, so if it's corrupt we could be asked for far more than it contains
+	if f.size >= 0 {
+		start := f.baseAddr + addr
+		if start < addr || start > uint64(f.size) || uint64(n) > uint64(f.size)-start {
+			return nil, fmt.Errorf("Failed to read %v bytes at HDF5 address %v: beyond end of file (%v bytes)", n, addr, f.size)
+		}
+	}
+
+	buf := make([]byte, n)
+	_, err := f.r.ReadAt(buf, int64(f.baseAddr+addr))
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read %v bytes at HDF5 address %v: %v", n, addr, err)
+	}
+	return buf, nil
+}
+
+// Reads up to n bytes at the given address, for when we don't know how big a structure is until we've read its start
+func (f *File) readAtMost(addr uint64, n int) ([]byte, error) {
+	buf := make([]byte, n)
+	read, err := f.r.ReadAt(buf, int64(f.baseAddr+addr))
+	if read <= 0 && err != nil {
+		return nil, fmt.Errorf("Failed to read at HDF5 address %v: %v", addr, err)
+	}
+	return buf[:read], nil
+}
+
+// Reads a block which starts with the given 4 character signature
+func (f *File) readSignedBlock(addr uint64, n int, signature string) ([]byte, error) {
+	buf, err := f.readAt(addr, n)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(buf) < 4 || string(buf[0:4]) != signature {
+		return nil, fmt.Errorf("Expected %v signature at HDF5 address %v", signature, addr)
+	}
+	return buf, nil
+}
+
+// Addresses that aren't set are stored as all 1's
+func (f *File) isUndefinedAddr(addr uint64) bool {
+	if f.offsetSize >= 8 {
+		return addr == ^uint64(0)
+	}
+	return addr == (uint64(1)<<(8*f.offsetSize))-1
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////
+// Little-endian reading of the various sized fields in the file
+
+type parser struct {
+	buf        []byte
+	pos        int
+	offsetSize int
+	lengthSize int
+	err        error
+}
+
+func (f *File) makeParser(buf []byte) *parser {
+	return &parser{buf: buf, offsetSize: f.offsetSize, lengthSize: f.lengthSize}
+}
+
+func (p *parser) remaining() int {
+	return len(p.buf) - p.pos
+}
+
+func (p *parser) bytes(n int) []byte {
+	if p.err != nil {
+		return nil
+	}
+
+	if n < 0 || p.pos+n > len(p.buf) {
+		p.err = errors.New("Unexpected end of HDF5 structure")
+		p.pos = len(p.buf)
+		return nil
+	}
+
+	b := p.buf[p.pos : p.pos+n]
+	p.pos += n
+	return b
+}
+
+func (p *parser) skip(n int) {
+	p.bytes(n)
+}
+
+// Skips to the next multiple of align bytes from the start of the buffer
+func (p *parser) align(align int) {
+	if rem := p.pos % align; rem != 0 {
+		p.skip(align - rem)
+	}
+}
+
+func (p *parser) u8() uint8 {
+	b := p.bytes(1)
+	if b == nil {
+		return 0
+	}
+	return b[0]
+}
+
+func (p *parser) u16() uint16 {
+	return uint16(p.uint(2))
+}
+
+func (p *parser) u32() uint32 {
+	return uint32(p.uint(4))
+}
+
+func (p *parser) u64() uint64 {
+	return p.uint(8)
+}
+
+// Reads an unsigned little endian value of n bytes
+func (p *parser) uint(n int) uint64 {
+	b := p.bytes(n)
+	if b == nil {
+		return 0
+	}
+
+	var padded [8]byte
+	copy(padded[:], b)
+	return binary.LittleEndian.Uint64(padded[:])
+}
+
+func (p *parser) offset() uint64 {
+	return p.uint(p.offsetSize)
+}
+
+func (p *parser) length() uint64 {
+	return p.uint(p.lengthSize)
+}
@@ -0,0 +1,196 @@
+package hdf5
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"strings"
+)
+
+func printHDF5Tree(f *File) {
+	err := f.Walk(func(obj *Object) error {
+		attrs := []string{}
+		for _, attr := range obj.Attributes {
+			if attr.Value.IsString() {
+				attrs = append(attrs, fmt.Sprintf("%v=%v", attr.Name, attr.Value.Strings()))
+			} else {
+				attrs = append(attrs, fmt.Sprintf("%v=%v", attr.Name, attr.Value.Float64s()))
+			}
+		}
+
+		attrsStr := ""
+		if len(attrs) > 0 {
+			attrsStr = " attrs: " + strings.Join(attrs, ", ")
+		}
+
+		if obj.IsGroup() {
+			fmt.Printf("%v group %v%v\n", obj.Path, obj.Children(), attrsStr)
+			return nil
+		}
+
+		data, err := obj.Read()
+		if err != nil {
+			fmt.Printf("%v %v %v: %v\n", obj.Path, obj.Type(), obj.Dims(), err)
+			return nil
+		}
+
+		if data.IsString() {
+			fmt.Printf("%v %v %v%v values: %q\n", obj.Path, obj.Type(), obj.Dims(), attrsStr, data.Strings())
+		} else {
+			fmt.Printf("%v %v %v%v values: %v\n", obj.Path, obj.Type(), obj.Dims(), attrsStr, data.Float64s())
+		}
+		return nil
+	})
+
+	fmt.Printf("Walk: %v\n", err)
+}
+
+func Example_isHDF5() {
+	fmt.Println(IsHDF5("./test-data/v0.h5"))
+	fmt.Println(IsHDF5("./test-data/v2.h5"))
+	fmt.Println(IsHDF5("./file.go"))
+	fmt.Println(IsHDF5("./test-data/missing.h5"))
+
+	// Output:
+	// true
+	// true
+	// false
+	// false
+}
+
+// Written the way the HDF5 library does by default: version 0 superblock, version 1 object headers, symbol table groups
+// and chunks indexed by a version 1 B-tree
+func Example_readV0File() {
+	f, err := Open("./test-data/v0.h5")
+	fmt.Printf("Open: %v\n", err)
+	defer f.Close()
+
+	printHDF5Tree(f)
+
+	obj, err := f.Get("/group/floats")
+	fmt.Printf("%v %v\n", obj.AttrString("description"), err)
+	fmt.Printf("%v|%v\n", obj.AttrString("scale"), obj.AttrString("missing"))
+
+	_, err = f.Get("/group/nothing")
+	fmt.Println(err)
+
+	// Output:
+	// Open: <nil>
+	// / group [group ints] attrs: title=[HDF5 test file], version=[3]
+	// /group group [bigendian compact empty floats names sub] attrs: NX_class=[NXentry]
+	// /group/bigendian float64 [3] values: [1.5 -2.25 1e+10]
+	// /group/compact uint16 [4] values: [10 20 30 40]
+	// /group/empty uint8 [4] values: [0 0 0 0]
+	// /group/floats float32 [5 4] attrs: description=[Some floats], scale=[0.25 4] values: [0 0.5 1 1.5 2 2.5 3 3.5 4 4.5 5 5.5 6 6.5 7 7.5 8 8.5 9 9.5]
+	// /group/names string [3] values: ["alpha" "beta" ""]
+	// /group/sub group []
+	// /ints int32 [2 3] attrs: units=[counts] values: [1 -2 3 4 5 -6]
+	// Walk: <nil>
+	// Some floats <nil>
+	// |
+	// nothing not found in /group
+}
+
+// Written the way the HDF5 library does when asked for the latest format: version 2 superblock and object headers,
+// groups stored as link messages and version 4 data layouts
+func Example_readV2File() {
+	f, err := Open("./test-data/v2.h5")
+	fmt.Printf("Open: %v\n", err)
+	defer f.Close()
+
+	printHDF5Tree(f)
+
+	for _, p := range []string{"/link_to_single", "/also_data/relative", "/data/contig"} {
+		obj, err := f.Get(p)
+		if err != nil {
+			fmt.Println(err)
+			continue
+		}
+
+		data, err := obj.Read()
+		fmt.Printf("%v: %v %v %v\n", p, obj.Path, data.Float64s(), err)
+	}
+
+	// Output:
+	// Open: <nil>
+	// / group [also_data data link_to_single]
+	// /data group [contig fixed fixedfiltered implicit relative single] attrs: NX_class=[NXdata], axes=[y x]
+	// /data/single uint32 [3 4] values: [0 1 2 3 4 5 6 7 8 9 10 11]
+	// /data/implicit int16 [5] values: [-3 -2 -1 0 1]
+	// /data/fixed float64 [4 3] values: [0.5 1.5 2.5 3.5 4.5 5.5 6.5 7.5 8.5 9.5 10.5 11.5]
+	// /data/fixedfiltered uint16 [6] values: [100 200 300 400 0 0]
+	// /data/contig int8 [4] attrs: units=[mm] values: [-128 -1 0 127]
+	// Walk: <nil>
+	// /link_to_single: /data/single [0 1 2 3 4 5 6 7 8 9 10 11] <nil>
+	// /also_data/relative: /also_data/fixed [0.5 1.5 2.5 3.5 4.5 5.5 6.5 7.5 8.5 9.5 10.5 11.5] <nil>
+	// /data/contig: /data/contig [-128 -1 0 127] <nil>
+}
+
+// Corrupt files shouldn't be able to make us loop forever or allocate huge amounts of memory
+func Example_corruptFileLimits() {
+	// A version 1 object header whose only message is a continuation pointing back at its own message block
+	buf := []byte{1, 0, 1, 0, 1, 0, 0, 0, 24, 0, 0, 0, 0, 0, 0, 0}
+	buf = binary.LittleEndian.AppendUint16(buf, msgContinuation)
+	buf = binary.LittleEndian.AppendUint16(buf, 16)
+	buf = append(buf, 0, 0, 0, 0)
+	buf = binary.LittleEndian.AppendUint64(buf, 16)
+	buf = binary.LittleEndian.AppendUint64(buf, 24)
+
+	f := &File{r: bytes.NewReader(buf), size: readerSize(bytes.NewReader(buf)), offsetSize: 8, lengthSize: 8}
+	_, err := f.readObjectHeader(0)
+	fmt.Println(err)
+
+	tracker := makeHeaderBlockTracker(0, maxObjectHeaderBytes-10)
+	fmt.Println(tracker.add(100, 10))
+	fmt.Println(tracker.add(200, 1))
+
+	// Reading past the end of the file fails before allocating anything
+	_, err = f.readAt(16, 1024*1024*1024)
+	fmt.Println(err)
+	_, err = f.readAt(1<<63, 8)
+	fmt.Println(err)
+
+	// Element counts that overflow saturate, so fail any size check
+	fmt.Println(elementCount([]uint64{1 << 40, 1 << 40}) == ^uint64(0), elementCount([]uint64{1 << 40, 1 << 40, 0}))
+
+	_, err = makeFillOnlyData(maxFillOnlyDatasetBytes + 1)
+	fmt.Println(err)
+
+	// v0.h5 with /group/floats claiming to be [5 400000000], which used to allocate 8GB for its one written chunk
+	huge, err := Open("./test-data/v0-huge-chunked.h5")
+	fmt.Printf("Open: %v\n", err)
+	defer huge.Close()
+
+	obj, err := huge.Get("/group/floats")
+	fmt.Printf("%v %v\n", obj.Dims(), err)
+	_, err = obj.Read()
+	fmt.Println(err)
+
+	// Shuffle filter element sizes come from the file, so are checked against the datatype and chunk
+	shuffled := &Object{file: f, datatype: &Datatype{Class: ClassFixedPoint, Size: 4}, filters: []filter{{id: filterShuffle, clientData: []uint32{0x7fffffff}}}}
+	_, err = shuffled.applyFilters([]byte{1, 2, 3, 4}, 0)
+	fmt.Println(err)
+	shuffled.filters[0].clientData[0] = 0
+	_, err = shuffled.applyFilters([]byte{1, 2, 3, 4}, 0)
+	fmt.Println(err)
+	shuffled.filters[0].clientData[0] = 4
+	unshuffledBytes, err := shuffled.applyFilters([]byte{1, 2, 3, 4, 5, 6, 7, 8}, 0)
+	fmt.Println(unshuffledBytes, err)
+	fmt.Println(unshuffle([]byte{1, 2, 3}, 1<<30))
+
+	// Output:
+	// Object header continuation at address 16 was already read
+	// <nil>
+	// Object header too large: over 67108864 bytes
+	// Failed to read 1073741824 bytes at HDF5 address 16: beyond end of file (40 bytes)
+	// Failed to read 8 bytes at HDF5 address 9223372036854775808: beyond end of file (40 bytes)
+	// true 0
+	// Dataset was never written and is too large to fill: 268435457 bytes
+	// Open: <nil>
+	// [5 400000000] <nil>
+	// Failed to read /group/floats: Dataset is 8000000000 bytes but its written chunks can only hold 144
+	// Invalid shuffle filter element size: 2147483647
+	// Invalid shuffle filter element size: 0
+	// [1 3 5 7 2 4 6 8] <nil>
+	// [1 2 3]
+}
@@ -0,0 +1,643 @@
+// Licensed to NASA JPL under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. NASA JPL licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package hdf5
+
+import (
+	"fmt"
+	"path"
+	"sort"
+)
+
+// Object header message types we care about
+const (
+	msgDataspace         = 0x0001
+	msgLinkInfo          = 0x0002
+	msgDatatype          = 0x0003
+	msgLink              = 0x0006
+	msgDataLayout        = 0x0008
+	msgFilterPipeline    = 0x000B
+	msgAttribute         = 0x000C
+	msgContinuation      = 0x0010
+	msgSymbolTable       = 0x0011
+	msgAttributeInfo     = 0x0015
+	msgFlagShared        = 0x02
+	maxObjectHeaderBytes = 64 * 1024 * 1024
+)
+
+const (
+	linkTypeHard     = 0
+	linkTypeSoft     = 1
+	linkTypeExternal = 64
+)
+
+type link struct {
+	name     string
+	linkType int
+	addr     uint64 // hard links
+	target   string // soft links
+}
+
+type headerMessage struct {
+	msgType uint16
+	flags   uint8
+	data    []byte
+}
+
+// Object - a group or dataset in the file
+type Object struct {
+	file *File
+	addr uint64
+
+	// Path - absolute path of the object in the file, eg /entry/data
+	Path string
+
+	// Attributes - any attributes stored in the object header
+	Attributes []Attribute
+
+	// Set for groups
+	isGroup bool
+	links   []link
+
+	// Set for datasets
+	dims     []uint64
+	datatype *Datatype
+	layout   *dataLayout
+	filters  []filter
+}
+
+// Name - the last part of the path
+func (o *Object) Name() string {
+	return path.Base(o.Path)
+}
+
+// IsGroup - true if this is a group
+func (o *Object) IsGroup() bool {
+	return o.isGroup
+}
+
+// IsDataset - true if this is a dataset
+func (o *Object) IsDataset() bool {
+	return o.datatype != nil
+}
+
+// Dims - Dimensions of the dataset, slowest changing first. Scalar datasets have no dimensions
+func (o *Object) Dims() []uint64 {
+	return o.dims
+}
+
+// ElementCount - How many values the dataset holds
+func (o *Object) ElementCount() uint64 {
+	return elementCount(o.dims)
+}
+
+// Type - Data type of the dataset
+func (o *Object) Type() *Datatype {
+	return o.datatype
+}
+
+// Children - Names of the links in a group, sorted
+func (o *Object) Children() []string {
+	result := []string{}
+	for _, l := range o.links {
+		result = append(result, l.name)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// Child - Returns the group or dataset linked from this group with the given name
+func (o *Object) Child(name string) (*Object, error) {
+	return o.child(name, 0)
+}
+
+func (o *Object) child(name string, depth int) (*Object, error) {
+	for _, l := range o.links {
+		if l.name != name {
+			continue
+		}
+
+		switch l.linkType {
+		case linkTypeHard:
+			return o.file.readObject(path.Join(o.Path, name), l.addr)
+		case linkTypeSoft:
+			target := l.target
+			if !path.IsAbs(target) {
+				target = path.Join(o.Path, target)
+			}
+			return o.file.get(target, depth+1)
+		default:
+			return nil, fmt.Errorf("Link %v in %v is an external link, which is not supported", name, o.Path)
+		}
+	}
+
+	return nil, fmt.Errorf("%v not found in %v", name, o.Path)
+}
+
+// Attr - Returns the attribute with the given name, or nil if it doesn't exist
+func (o *Object) Attr(name string) *Attribute {
+	for c := range o.Attributes {
+		if o.Attributes[c].Name == name {
+			return &o.Attributes[c]
+		}
+	}
+	return nil
+}
+
+// AttrString - Returns the value of a string attribute (or the first string if it's an array), or "" if it doesn't
+// exist or is not a string
+func (o *Object) AttrString(name string) string {
+	attr := o.Attr(name)
+	if attr == nil || !attr.Value.IsString() || attr.Value.Len() < 1 {
+		return ""
+	}
+	return attr.Value.String(0)
+}
+
+// Read - Reads all values of the dataset
+func (o *Object) Read() (*Data, error) {
+	if !o.IsDataset() {
+		return nil, fmt.Errorf("%v is not a dataset", o.Path)
+	}
+
+	raw, err := o.readRaw()
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read %v: %v", o.Path, err)
+	}
+
+	data, err := o.file.makeData(o.dims, o.datatype, raw)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read %v: %v", o.Path, err)
+	}
+	return data, nil
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////
+// Reading object headers
+
+func (f *File) readObject(objPath string, addr uint64) (*Object, error) {
+	msgs, err := f.readObjectHeader(addr)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read object header for %v: %v", objPath, err)
+	}
+
+	obj := &Object{file: f, addr: addr, Path: objPath, Attributes: []Attribute{}}
+
+	var layoutMsg []byte
+	for _, msg := range msgs {
+		if msg.flags&msgFlagShared != 0 && (msg.msgType == msgDatatype || msg.msgType == msgDataspace || msg.msgType == msgFilterPipeline) {
+			return nil, fmt.Errorf("%v uses a shared message (type %v), which is not supported", objPath, msg.msgType)
+		}
+
+		switch msg.msgType {
+		case msgDataspace:
+			obj.dims, err = f.readDataspace(msg.data)
+		case msgDatatype:
+			obj.datatype, _, err = f.readDatatype(msg.data)
+		case msgDataLayout:
+			layoutMsg = msg.data
+		case msgFilterPipeline:
+			obj.filters, err = f.readFilterPipeline(msg.data)
+		case msgAttribute:
+			var attr Attribute
+			attr, err = f.readAttribute(msg.data)
+			if err == nil {
+				obj.Attributes = append(obj.Attributes, attr)
+			}
+		case msgSymbolTable:
+			obj.isGroup = true
+			var links []link
+			links, err = f.readSymbolTableGroup(msg.data)
+			obj.links = append(obj.links, links...)
+		case msgLinkInfo:
+			obj.isGroup = true
+			err = f.checkLinkInfo(msg.data)
+		case msgLink:
+			obj.isGroup = true
+			var l link
+			l, err = f.readLink(msg.data)
+			obj.links = append(obj.links, l)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("Failed to read %v: %v", objPath, err)
+		}
+	}
+
+	if layoutMsg != nil && obj.datatype != nil {
+		obj.layout, err = f.readDataLayout(layoutMsg, len(obj.dims))
+		if err != nil {
+			return nil, fmt.Errorf("Failed to read %v: %v", objPath, err)
+		}
+	}
+
+	return obj, nil
+}
+
+// Object headers can be split into blocks, linked by continuation messages. A corrupt file could link them in a loop,
+// or link to so many that we run out of memory, so we remember which blocks we've read and how big they were
+type headerBlockTracker struct {
+	visited    map[uint64]bool
+	totalBytes uint64
+}
+
+func makeHeaderBlockTracker(addr uint64, size uint64) *headerBlockTracker {
+	return &headerBlockTracker{visited: map[uint64]bool{addr: true}, totalBytes: size}
+}
+
+func (t *headerBlockTracker) add(addr uint64, size uint64) error {
+	if t.visited[addr] {
+		return fmt.Errorf("Object header continuation at address %v was already read", addr)
+	}
+	t.visited[addr] = true
+
+	t.totalBytes += size
+	if t.totalBytes > maxObjectHeaderBytes {
+		return fmt.Errorf("Object header too large: over %v bytes", maxObjectHeaderBytes)
+	}
+	return nil
+}
+
+// Reads all messages in the object header at addr, following continuation messages
+func (f *File) readObjectHeader(addr uint64) ([]headerMessage, error) {
+	prefix, err := f.readAtMost(addr, 16)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(prefix) >= 4 && string(prefix[0:4]) == "OHDR" {
+		return f.readObjectHeaderV2(addr)
+	}
+
+	if len(prefix) < 16 {
+		return nil, fmt.Errorf("Object header truncated at address %v", addr)
+	}
+
+	if prefix[0] != 1 {
+		return nil, fmt.Errorf("Unsupported object header version: %v", prefix[0])
+	}
+
+	// Version 1: version, reserved, message count, reference count, header size, then padding to 8 bytes
+	p := f.makeParser(prefix)
+	p.skip(8)
+	headerSize := p.u32()
+	if headerSize > maxObjectHeaderBytes {
+		return nil, fmt.Errorf("Object header too large: %v bytes", headerSize)
+	}
+
+	block, err := f.readAt(addr+16, int(headerSize))
+	if err != nil {
+		return nil, err
+	}
+
+	tracker := makeHeaderBlockTracker(addr+16, uint64(headerSize))
+	result := []headerMessage{}
+	blocks := [][]byte{block}
+	for len(blocks) > 0 {
+		p := f.makeParser(blocks[0])
+		blocks = blocks[1:]
+
+		for p.remaining() >= 8 {
+			msgType := p.u16()
+			size := p.u16()
+			flags := p.u8()
+			p.skip(3)
+			data := p.bytes(int(size))
+			if p.err != nil {
+				return nil, p.err
+			}
+
+			if msgType == msgContinuation {
+				contBlock, err := f.readContinuation(data, "", tracker)
+				if err != nil {
+					return nil, err
+				}
+				blocks = append(blocks, contBlock)
+			} else {
+				result = append(result, headerMessage{msgType: msgType, flags: flags, data: data})
+			}
+		}
+	}
+
+	return result, nil
+}
+
+func (f *File) readObjectHeaderV2(addr uint64) ([]headerMessage, error) {
+	prefix, err := f.readAtMost(addr, 6+16+4+8)
+	if err != nil {
+		return nil, err
+	}
+
+	p := f.makeParser(prefix)
+	p.skip(4)
+	version := p.u8()
+	if version != 2 {
+		return nil, fmt.Errorf("Unsupported object header version: %v", version)
+	}
+
+	flags := p.u8()
+	if flags&0x20 != 0 {
+		p.skip(16) // Access, modification, change and birth times
+	}
+	if flags&0x10 != 0 {
+		p.skip(4) // Attribute storage phase change values
+	}
+
+	chunkSize := p.uint(1 << (flags & 0x03))
+	if p.err != nil {
+		return nil, p.err
+	}
+	if chunkSize > maxObjectHeaderBytes {
+		return nil, fmt.Errorf("Object header too large: %v bytes", chunkSize)
+	}
+
+	block, err := f.readAt(addr+uint64(p.pos), int(chunkSize))
+	if err != nil {
+		return nil, err
+	}
+
+	tracker := makeHeaderBlockTracker(addr, chunkSize)
+
+	trackCreationOrder := flags&0x04 != 0
+
+	result := []headerMessage{}
+	blocks := [][]byte{block}
+	for len(blocks) > 0 {
+		p := f.makeParser(blocks[0])
+		blocks = blocks[1:]
+
+		msgHeaderSize := 4
+		if trackCreationOrder {
+			msgHeaderSize += 2
+		}
+
+		// Anything left over that's too small to be a message is a gap
+		for p.remaining() >= msgHeaderSize {
+			msgType := uint16(p.u8())
+			size := p.u16()
+			msgFlags := p.u8()
+			if trackCreationOrder {
+				p.skip(2)
+			}
+			data := p.bytes(int(size))
+			if p.err != nil {
+				return nil, p.err
+			}
+
+			if msgType == msgContinuation {
+				contBlock, err := f.readContinuation(data, "OCHK", tracker)
+				if err != nil {
+					return nil, err
+				}
+				blocks = append(blocks, contBlock)
+			} else {
+				result = append(result, headerMessage{msgType: msgType, flags: msgFlags, data: data})
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// Reads the block a continuation message points to. Version 2 blocks have a signature and checksum which we strip
+func (f *File) readContinuation(msg []byte, signature string, tracker *headerBlockTracker) ([]byte, error) {
+	p := f.makeParser(msg)
+	addr := p.offset()
+	length := p.length()
+	if p.err != nil {
+		return nil, p.err
+	}
+	if length > maxObjectHeaderBytes {
+		return nil, fmt.Errorf("Object header continuation too large: %v bytes", length)
+	}
+	if err := tracker.add(addr, length); err != nil {
+		return nil, err
+	}
+
+	if len(signature) <= 0 {
+		return f.readAt(addr, int(length))
+	}
+
+	block, err := f.readSignedBlock(addr, int(length), signature)
+	if err != nil {
+		return nil, err
+	}
+	if len(block) < 8 {
+		return nil, fmt.Errorf("Object header continuation too small: %v bytes", length)
+	}
+	return block[4 : len(block)-4], nil
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////
+// Groups
+
+type symbolTableEntry struct {
+	nameOffset uint64
+	addr       uint64
+}
+
+func (f *File) symbolTableEntrySize() int {
+	return 2*f.offsetSize + 24
+}
+
+func (f *File) readSymbolTableEntry(p *parser) symbolTableEntry {
+	entry := symbolTableEntry{nameOffset: p.offset(), addr: p.offset()}
+	p.skip(24) // Cache type, reserved and scratch-pad
+	return entry
+}
+
+// Old-style groups: a B-tree of symbol table nodes, with the names in a local heap
+func (f *File) readSymbolTableGroup(msg []byte) ([]link, error) {
+	p := f.makeParser(msg)
+	btreeAddr := p.offset()
+	heapAddr := p.offset()
+	if p.err != nil {
+		return nil, p.err
+	}
+
+	heap, err := f.readLocalHeap(heapAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []link{}
+	err = f.readBTreeV1(btreeAddr, 0, f.lengthSize, func(key []byte, childAddr uint64) error {
+		entries, err := f.readSymbolTableNode(childAddr)
+		if err != nil {
+			return err
+		}
+
+		for _, entry := range entries {
+			if entry.nameOffset >= uint64(len(heap)) {
+				return fmt.Errorf("Symbol name offset %v outside of local heap", entry.nameOffset)
+			}
+			result = append(result, link{name: readNullTerminated(heap[entry.nameOffset:]), linkType: linkTypeHard, addr: entry.addr})
+		}
+		return nil
+	})
+
+	return result, err
+}
+
+func (f *File) readLocalHeap(addr uint64) ([]byte, error) {
+	hdr, err := f.readSignedBlock(addr, 8+2*f.lengthSize+f.offsetSize, "HEAP")
+	if err != nil {
+		return nil, err
+	}
+
+	p := f.makeParser(hdr)
+	p.skip(8)
+	dataSize := p.length()
+	p.length() // Free list offset
+	dataAddr := p.offset()
+	if p.err != nil {
+		return nil, p.err
+	}
+	if dataSize > maxObjectHeaderBytes {
+		return nil, fmt.Errorf("Local heap too large: %v bytes", dataSize)
+	}
+
+	return f.readAt(dataAddr, int(dataSize))
+}
+
+func (f *File) readSymbolTableNode(addr uint64) ([]symbolTableEntry, error) {
+	hdr, err := f.readSignedBlock(addr, 8, "SNOD")
+	if err != nil {
+		return nil, err
+	}
+
+	count := int(hdr[6]) | int(hdr[7])<<8
+	node, err := f.readAt(addr+8, count*f.symbolTableEntrySize())
+	if err != nil {
+		return nil, err
+	}
+
+	p := f.makeParser(node)
+	result := []symbolTableEntry{}
+	for c := 0; c < count; c++ {
+		result = append(result, f.readSymbolTableEntry(p))
+	}
+	return result, p.err
+}
+
+// Walks a version 1 B-tree of the given node type, calling fn for each leaf entry with its key and child address. The
+// key is the one before the child, which describes it
+func (f *File) readBTreeV1(addr uint64, nodeType uint8, keySize int, fn func(key []byte, childAddr uint64) error) error {
+	hdrSize := 8 + 2*f.offsetSize
+	hdr, err := f.readSignedBlock(addr, hdrSize, "TREE")
+	if err != nil {
+		return err
+	}
+
+	if hdr[4] != nodeType {
+		return fmt.Errorf("Expected B-tree node type %v, got %v", nodeType, hdr[4])
+	}
+
+	level := hdr[5]
+	entries := int(hdr[6]) | int(hdr[7])<<8
+
+	// Keys and children alternate, with one more key than children
+	body, err := f.readAt(addr+uint64(hdrSize), entries*(keySize+f.offsetSize)+keySize)
+	if err != nil {
+		return err
+	}
+
+	p := f.makeParser(body)
+	for c := 0; c < entries; c++ {
+		key := p.bytes(keySize)
+		childAddr := p.offset()
+		if p.err != nil {
+			return p.err
+		}
+
+		if level > 0 {
+			err = f.readBTreeV1(childAddr, nodeType, keySize, fn)
+		} else {
+			err = fn(key, childAddr)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// New-style groups store links in the object header if there are few of them, otherwise in a fractal heap ("dense"
+// storage) which we don't support
+func (f *File) checkLinkInfo(msg []byte) error {
+	p := f.makeParser(msg)
+	p.u8() // Version
+	flags := p.u8()
+	if flags&0x01 != 0 {
+		p.skip(8) // Maximum creation index
+	}
+
+	heapAddr := p.offset()
+	if p.err != nil {
+		return p.err
+	}
+
+	if !f.isUndefinedAddr(heapAddr) {
+		return fmt.Errorf("Group uses dense link storage, which is not supported")
+	}
+	return nil
+}
+
+func (f *File) readLink(msg []byte) (link, error) {
+	result := link{}
+
+	p := f.makeParser(msg)
+	version := p.u8()
+	if version != 1 {
+		return result, fmt.Errorf("Unsupported link message version: %v", version)
+	}
+
+	flags := p.u8()
+	if flags&0x08 != 0 {
+		result.linkType = int(p.u8())
+	}
+	if flags&0x04 != 0 {
+		p.skip(8) // Creation order
+	}
+	if flags&0x10 != 0 {
+		p.skip(1) // Character set
+	}
+
+	nameLength := p.uint(1 << (flags & 0x03))
+	result.name = string(p.bytes(int(nameLength)))
+
+	switch result.linkType {
+	case linkTypeHard:
+		result.addr = p.offset()
+	case linkTypeSoft:
+		targetLength := p.u16()
+		result.target = string(p.bytes(int(targetLength)))
+	}
+
+	return result, p.err
+}
+
+func readNullTerminated(b []byte) string {
+	for c, ch := range b {
+		if ch == 0 {
+			return string(b[:c])
+		}
+	}
+	return string(b)
+}
@@ -0,0 +1,653 @@
+// Licensed to NASA JPL under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. NASA JPL licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package hdf5
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math/bits"
+)
+
+// Layout classes
+const (
+	layoutCompact    = 0
+	layoutContiguous = 1
+	layoutChunked    = 2
+)
+
+// Chunk index types in version 4 layout messages
+const (
+	chunkIndexBTreeV1    = 0 // Not an actual value in the file, it's what version 3 layouts use
+	chunkIndexSingle     = 1
+	chunkIndexImplicit   = 2
+	chunkIndexFixedArray = 3
+	chunkIndexExtArray   = 4
+	chunkIndexBTreeV2    = 5
+)
+
+// Filters
+const (
+	filterDeflate    = 1
+	filterShuffle    = 2
+	filterFletcher32 = 3
+	filterSZip       = 4
+)
+
+// The most we'll allocate to read a dataset, to protect against corrupt files
+const maxDatasetBytes = 16 * 1024 * 1024 * 1024
+
+// Datasets that were never written are all fill value, so take up no space in the file. A tiny file could claim a huge
+// one, so we refuse to allocate more than this for them
+const maxFillOnlyDatasetBytes = 256 * 1024 * 1024
+
+type dataLayout struct {
+	class int
+
+	compactData []byte
+
+	addr uint64 // Contiguous data, or the chunk index
+	size uint64 // Contiguous data size
+
+	chunkDims      []uint64 // In elements, not including the element size dimension
+	chunkIndex     int
+	singleChunkLen uint64 // Single chunk index with filters: size of the chunk in the file
+	singleMask     uint32 // Single chunk index with filters: which filters were skipped
+}
+
+type filter struct {
+	id         uint16
+	clientData []uint32
+}
+
+type chunk struct {
+	offsets    []uint64 // In elements, for each dimension
+	addr       uint64
+	size       uint64 // In the file, after filters
+	filterMask uint32
+}
+
+func (f *File) readDataLayout(msg []byte, rank int) (*dataLayout, error) {
+	result := &dataLayout{}
+
+	p := f.makeParser(msg)
+	version := p.u8()
+
+	if version < 3 {
+		// Old layouts are rarely seen, we only read simple ones
+		ndims := int(p.u8())
+		result.class = int(p.u8())
+		p.skip(5)
+
+		switch result.class {
+		case layoutContiguous:
+			// Size isn't stored, we work it out from the dataspace
+			result.addr = p.offset()
+			p.skip(4 * ndims)
+		case layoutCompact:
+			p.skip(4 * ndims)
+			size := p.u32()
+			result.compactData = p.bytes(int(size))
+		default:
+			return nil, fmt.Errorf("Unsupported data layout version %v, class %v", version, result.class)
+		}
+
+		return result, p.err
+	}
+
+	if version > 4 {
+		return nil, fmt.Errorf("Unsupported data layout version: %v", version)
+	}
+
+	result.class = int(p.u8())
+	switch result.class {
+	case layoutCompact:
+		size := p.u16()
+		result.compactData = p.bytes(int(size))
+	case layoutContiguous:
+		result.addr = p.offset()
+		result.size = p.length()
+	case layoutChunked:
+		flags := uint8(0)
+		if version == 4 {
+			flags = p.u8()
+		}
+
+		// Chunk dimensions include an extra one at the end, the element size
+		ndims := int(p.u8())
+		if ndims != rank+1 {
+			return nil, fmt.Errorf("Chunk has %v dimensions, expected %v", ndims-1, rank)
+		}
+
+		if version == 3 {
+			result.chunkIndex = chunkIndexBTreeV1
+			result.addr = p.offset()
+			for c := 0; c < ndims; c++ {
+				result.chunkDims = append(result.chunkDims, uint64(p.u32()))
+			}
+		} else {
+			dimSize := int(p.u8())
+			for c := 0; c < ndims; c++ {
+				result.chunkDims = append(result.chunkDims, p.uint(dimSize))
+			}
+
+			result.chunkIndex = int(p.u8())
+			switch result.chunkIndex {
+			case chunkIndexSingle:
+				if flags&0x02 != 0 {
+					result.singleChunkLen = p.length()
+					result.singleMask = p.u32()
+				}
+			case chunkIndexImplicit:
+			case chunkIndexFixedArray:
+				p.skip(1) // Page bits, we read this from the fixed array header
+			default:
+				return nil, fmt.Errorf("Unsupported chunk index type: %v", result.chunkIndex)
+			}
+
+			result.addr = p.offset()
+		}
+
+		result.chunkDims = result.chunkDims[0:rank]
+		for _, d := range result.chunkDims {
+			if d == 0 {
+				return nil, fmt.Errorf("Invalid chunk dimensions: %v", result.chunkDims)
+			}
+		}
+		if elementCount(result.chunkDims) > maxDatasetBytes {
+			return nil, fmt.Errorf("Chunk too large: %v", result.chunkDims)
+		}
+	default:
+		return nil, fmt.Errorf("Unsupported data layout class: %v", result.class)
+	}
+
+	return result, p.err
+}
+
+func (f *File) readFilterPipeline(msg []byte) ([]filter, error) {
+	p := f.makeParser(msg)
+	version := p.u8()
+	count := int(p.u8())
+	if version == 1 {
+		p.skip(6)
+	} else if version != 2 {
+		return nil, fmt.Errorf("Unsupported filter pipeline version: %v", version)
+	}
+
+	result := []filter{}
+	for c := 0; c < count; c++ {
+		flt := filter{id: p.u16()}
+
+		nameLength := 0
+		if version == 1 || flt.id >= 256 {
+			nameLength = int(p.u16())
+		}
+		p.u16() // Flags
+		valueCount := int(p.u16())
+		p.skip(nameLength)
+
+		for v := 0; v < valueCount; v++ {
+			flt.clientData = append(flt.clientData, p.u32())
+		}
+
+		if version == 1 && valueCount%2 != 0 {
+			p.skip(4)
+		}
+
+		switch flt.id {
+		case filterDeflate, filterShuffle, filterFletcher32:
+		case filterSZip:
+			return nil, fmt.Errorf("SZIP compressed data is not supported")
+		default:
+			return nil, fmt.Errorf("Unsupported filter: %v", flt.id)
+		}
+
+		result = append(result, flt)
+	}
+
+	return result, p.err
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////
+// Reading data
+
+func (o *Object) readRaw() ([]byte, error) {
+	f := o.file
+	layout := o.layout
+	if layout == nil {
+		return nil, fmt.Errorf("No data layout")
+	}
+
+	elemSize := uint64(f.storedElementSize(o.datatype))
+	if elemSize == 0 {
+		return nil, fmt.Errorf("Invalid element size: 0")
+	}
+
+	totalSize := mulSaturating(o.ElementCount(), elemSize)
+	if totalSize > maxDatasetBytes {
+		return nil, fmt.Errorf("Dataset too large: %v bytes", totalSize)
+	}
+
+	switch layout.class {
+	case layoutCompact:
+		return layout.compactData, nil
+	case layoutContiguous:
+		if f.isUndefinedAddr(layout.addr) {
+			// Never written, so it's all fill value, which we take to be 0
+			return makeFillOnlyData(totalSize)
+		}
+		if layout.size > 0 && layout.size < totalSize {
+			return nil, fmt.Errorf("Contiguous data is %v bytes, expected %v", layout.size, totalSize)
+		}
+		if f.size >= 0 && totalSize > uint64(f.size) {
+			return nil, fmt.Errorf("Contiguous data is %v bytes, larger than the file", totalSize)
+		}
+		return f.readAt(layout.addr, int(totalSize))
+	}
+
+	chunks, err := o.readChunkIndex()
+	if err != nil {
+		return nil, err
+	}
+
+	chunkSize := mulSaturating(elementCount(layout.chunkDims), elemSize)
+	if chunkSize > maxDatasetBytes {
+		return nil, fmt.Errorf("Chunk too large: %v bytes", chunkSize)
+	}
+
+	// Written chunks can't hold more than their size in the file allows (after decompression), the rest of the dataset
+	// is fill value, which we cap like we do for datasets that were never written. Checked before we allocate, so a
+	// small file can't claim a huge dataset by writing one chunk of it
+	writtenSize := uint64(0)
+	for _, ch := range chunks {
+		if f.isUndefinedAddr(ch.addr) {
+			continue
+		}
+
+		if f.size >= 0 && ch.size > uint64(f.size) {
+			return nil, fmt.Errorf("Chunk at %v is %v bytes, larger than the file", ch.addr, ch.size)
+		}
+
+		writtenSize += min(chunkSize, o.maxUnfilteredSize(ch))
+	}
+
+	if writtenSize == 0 {
+		return makeFillOnlyData(totalSize)
+	}
+
+	if totalSize > writtenSize && totalSize-writtenSize > maxFillOnlyDatasetBytes {
+		return nil, fmt.Errorf("Dataset is %v bytes but its written chunks can only hold %v", totalSize, writtenSize)
+	}
+
+	result := make([]byte, totalSize)
+
+	for _, ch := range chunks {
+		if f.isUndefinedAddr(ch.addr) {
+			continue
+		}
+
+		if ch.size > chunkSize*2+1024*1024 {
+			return nil, fmt.Errorf("Chunk at %v too large: %v bytes", ch.addr, ch.size)
+		}
+
+		chunkBytes, err := f.readAt(ch.addr, int(ch.size))
+		if err != nil {
+			return nil, err
+		}
+
+		chunkBytes, err = o.applyFilters(chunkBytes, ch.filterMask)
+		if err != nil {
+			return nil, fmt.Errorf("Chunk at %v: %v", ch.addr, err)
+		}
+
+		if uint64(len(chunkBytes)) < chunkSize {
+			return nil, fmt.Errorf("Chunk at %v has %v bytes, expected %v", ch.addr, len(chunkBytes), chunkSize)
+		}
+
+		copyChunk(result, o.dims, chunkBytes, layout.chunkDims, ch.offsets, elemSize)
+	}
+
+	return result, nil
+}
+
+// Deflate can't compress better than this, so it's the most a chunk can grow by when decompressed
+const maxDeflateRatio = 1032
+
+// Returns the most bytes a chunk could hold once its filters are undone
+func (o *Object) maxUnfilteredSize(ch chunk) uint64 {
+	for c, flt := range o.filters {
+		if flt.id == filterDeflate && ch.filterMask&(1<<c) == 0 {
+			return mulSaturating(ch.size, maxDeflateRatio)
+		}
+	}
+	return ch.size
+}
+
+func makeFillOnlyData(size uint64) ([]byte, error) {
+	if size > maxFillOnlyDatasetBytes {
+		return nil, fmt.Errorf("Dataset was never written and is too large to fill: %v bytes", size)
+	}
+	return make([]byte, size), nil
+}
+
+// Returns all the chunks that have been written
+func (o *Object) readChunkIndex() ([]chunk, error) {
+	f := o.file
+	layout := o.layout
+	rank := len(o.dims)
+	chunkSize := mulSaturating(elementCount(layout.chunkDims), uint64(f.storedElementSize(o.datatype)))
+
+	if f.isUndefinedAddr(layout.addr) {
+		return []chunk{}, nil
+	}
+
+	switch layout.chunkIndex {
+	case chunkIndexBTreeV1:
+		result := []chunk{}
+		keySize := 8 + 8*(rank+1)
+		err := f.readBTreeV1(layout.addr, 1, keySize, func(key []byte, childAddr uint64) error {
+			p := f.makeParser(key)
+			ch := chunk{addr: childAddr}
+			ch.size = uint64(p.u32())
+			ch.filterMask = p.u32()
+			for c := 0; c < rank; c++ {
+				ch.offsets = append(ch.offsets, p.u64())
+			}
+			result = append(result, ch)
+			return p.err
+		})
+		return result, err
+	case chunkIndexSingle:
+		ch := chunk{addr: layout.addr, size: chunkSize, offsets: make([]uint64, rank)}
+		if len(o.filters) > 0 {
+			ch.size = layout.singleChunkLen
+			ch.filterMask = layout.singleMask
+		}
+		return []chunk{ch}, nil
+	case chunkIndexImplicit:
+		// All chunks are stored one after the other, so they have to fit in the file
+		if f.size >= 0 && mulSaturating(chunkCount(o.dims, layout.chunkDims), chunkSize) > uint64(f.size) {
+			return nil, fmt.Errorf("Implicit chunk index for %v chunks of %v bytes is larger than the file", chunkCount(o.dims, layout.chunkDims), chunkSize)
+		}
+
+		result := []chunk{}
+		for c, offsets := range chunkGridOffsets(o.dims, layout.chunkDims) {
+			result = append(result, chunk{addr: layout.addr + uint64(c)*chunkSize, size: chunkSize, offsets: offsets})
+		}
+		return result, nil
+	case chunkIndexFixedArray:
+		return o.readFixedArrayIndex(chunkSize)
+	}
+
+	return nil, fmt.Errorf("Unsupported chunk index type: %v", layout.chunkIndex)
+}
+
+// Fixed array chunk index: a header pointing to a data block which lists each chunk in order. We don't support paged
+// data blocks, which are only used for datasets with more than 2^pageBits chunks (usually 1024)
+func (o *Object) readFixedArrayIndex(chunkSize uint64) ([]chunk, error) {
+	f := o.file
+
+	hdr, err := f.readSignedBlock(o.layout.addr, 8+f.lengthSize+f.offsetSize, "FAHD")
+	if err != nil {
+		return nil, err
+	}
+
+	p := f.makeParser(hdr)
+	p.skip(5)
+	clientID := p.u8()
+	entrySize := int(p.u8())
+	pageBits := p.u8()
+	entryCount := p.length()
+	dataBlockAddr := p.offset()
+	if p.err != nil {
+		return nil, p.err
+	}
+
+	if pageBits >= 64 || entryCount > uint64(1)<<pageBits {
+		return nil, fmt.Errorf("Paged fixed array chunk index is not supported")
+	}
+
+	if expected := chunkCount(o.dims, o.layout.chunkDims); entryCount != expected {
+		return nil, fmt.Errorf("Fixed array has %v entries, expected %v chunks", entryCount, expected)
+	}
+
+	// Read the block before we make the grid, so a corrupt entry count fails here rather than allocating a huge grid
+	blockHdrSize := 6 + f.offsetSize
+	entriesSize := mulSaturating(entryCount, uint64(entrySize))
+	if entriesSize > maxObjectHeaderBytes {
+		return nil, fmt.Errorf("Fixed array data block too large: %v bytes", entriesSize)
+	}
+
+	block, err := f.readSignedBlock(dataBlockAddr, blockHdrSize+int(entriesSize), "FADB")
+	if err != nil {
+		return nil, err
+	}
+
+	p = f.makeParser(block)
+	p.skip(blockHdrSize)
+
+	grid := chunkGridOffsets(o.dims, o.layout.chunkDims)
+	result := []chunk{}
+	for _, offsets := range grid {
+		ch := chunk{offsets: offsets, size: chunkSize}
+		ch.addr = p.offset()
+		if clientID == 1 {
+			// Filtered chunks have their size (in whatever bytes are left) and filter mask
+			ch.size = p.uint(entrySize - f.offsetSize - 4)
+			ch.filterMask = p.u32()
+		}
+		result = append(result, ch)
+	}
+
+	return result, p.err
+}
+
+// Returns how many chunks it takes to cover the dataset
+func chunkCount(dims []uint64, chunkDims []uint64) uint64 {
+	count := uint64(1)
+	for c, d := range dims {
+		perDim := d / chunkDims[c]
+		if d%chunkDims[c] != 0 {
+			perDim++
+		}
+		count = mulSaturating(count, perDim)
+	}
+	return count
+}
+
+// Returns the offset of each chunk in a dataset, in row-major order
+func chunkGridOffsets(dims []uint64, chunkDims []uint64) [][]uint64 {
+	result := [][]uint64{}
+
+	offsets := make([]uint64, len(dims))
+	for {
+		result = append(result, append([]uint64{}, offsets...))
+
+		// Increment, fastest changing dimension last
+		d := len(dims) - 1
+		for ; d >= 0; d-- {
+			offsets[d] += chunkDims[d]
+			if offsets[d] < dims[d] {
+				break
+			}
+			offsets[d] = 0
+		}
+
+		if d < 0 {
+			return result
+		}
+	}
+}
+
+// Copies a chunk (which is always full sized, even if it hangs off the edge of the dataset) into the dataset
+func copyChunk(dst []byte, dims []uint64, src []byte, chunkDims []uint64, offsets []uint64, elemSize uint64) {
+	rank := len(dims)
+	if rank == 0 {
+		copy(dst, src[:elemSize])
+		return
+	}
+
+	// Copy a row of the fastest changing dimension at a time
+	last := rank - 1
+	if offsets[last] >= dims[last] {
+		return
+	}
+	rowLen := min(chunkDims[last], dims[last]-offsets[last]) * elemSize
+
+	pos := make([]uint64, rank) // Position within the chunk
+	for {
+		inBounds := true
+		srcIdx := uint64(0)
+		dstIdx := uint64(0)
+		for d := 0; d < rank; d++ {
+			if offsets[d]+pos[d] >= dims[d] {
+				inBounds = false
+				break
+			}
+			srcIdx = srcIdx*chunkDims[d] + pos[d]
+			dstIdx = dstIdx*dims[d] + offsets[d] + pos[d]
+		}
+
+		if inBounds {
+			copy(dst[dstIdx*elemSize:dstIdx*elemSize+rowLen], src[srcIdx*elemSize:])
+		}
+
+		// Next row
+		d := last - 1
+		for ; d >= 0; d-- {
+			pos[d]++
+			if pos[d] < chunkDims[d] {
+				break
+			}
+			pos[d] = 0
+		}
+
+		if d < 0 {
+			return
+		}
+	}
+}
+
+// Filters are applied in order when writing, so we undo them in reverse order. Bit n of the mask being set means filter
+// n was skipped for this chunk
+func (o *Object) applyFilters(data []byte, mask uint32) ([]byte, error) {
+	for c := len(o.filters) - 1; c >= 0; c-- {
+		if mask&(1<<c) != 0 {
+			continue
+		}
+
+		flt := o.filters[c]
+		switch flt.id {
+		case filterDeflate:
+			r, err := zlib.NewReader(bytes.NewReader(data))
+			if err != nil {
+				return nil, fmt.Errorf("Failed to decompress: %v", err)
+			}
+
+			data, err = io.ReadAll(r)
+			if err != nil {
+				return nil, fmt.Errorf("Failed to decompress: %v", err)
+			}
+		case filterShuffle:
+			typeSize := o.file.storedElementSize(o.datatype)
+			elemSize := typeSize
+			if len(flt.clientData) > 0 {
+				// From the file, so check it's sane. Huge ones would make unshuffle loop for ages
+				if uint64(flt.clientData[0]) == 0 || uint64(flt.clientData[0]) > uint64(typeSize) || uint64(flt.clientData[0]) > uint64(len(data)) {
+					return nil, fmt.Errorf("Invalid shuffle filter element size: %v", flt.clientData[0])
+				}
+				elemSize = int(flt.clientData[0])
+			}
+			data = unshuffle(data, elemSize)
+		case filterFletcher32:
+			if len(data) < 4 {
+				return nil, fmt.Errorf("Chunk too small for fletcher32 checksum")
+			}
+
+			// Checksum is the last 4 bytes. Like the HDF5 library, we accept it byte swapped, as some old versions wrote it
+			stored := binary.LittleEndian.Uint32(data[len(data)-4:])
+			data = data[:len(data)-4]
+			sum := fletcher32(data)
+			if stored != sum && stored != bits.ReverseBytes32(sum) {
+				return nil, fmt.Errorf("Fletcher32 checksum mismatch")
+			}
+		}
+	}
+
+	return data, nil
+}
+
+// The shuffle filter stores the first byte of each element, then the second byte of each element, and so on
+func unshuffle(data []byte, elemSize int) []byte {
+	if elemSize <= 1 {
+		return data
+	}
+
+	count := len(data) / elemSize
+	if count == 0 {
+		return data
+	}
+
+	result := make([]byte, len(data))
+	for b := 0; b < elemSize; b++ {
+		for c := 0; c < count; c++ {
+			result[c*elemSize+b] = data[b*count+c]
+		}
+	}
+
+	// Anything that doesn't make up a whole element is left as is
+	copy(result[count*elemSize:], data[count*elemSize:])
+	return result
+}
+
+// As implemented by the HDF5 library: the data is read as big-endian 16-bit words, with an odd last byte in the high
+// half of the last word. Sums are folded back into 16 bits every 360 words so they don't overflow
+func fletcher32(data []byte) uint32 {
+	sum1 := uint32(0)
+	sum2 := uint32(0)
+
+	words := len(data) / 2
+	pos := 0
+	for words > 0 {
+		batch := min(words, 360)
+		words -= batch
+
+		for ; batch > 0; batch-- {
+			sum1 += uint32(data[pos])<<8 | uint32(data[pos+1])
+			sum2 += sum1
+			pos += 2
+		}
+
+		sum1 = (sum1 & 0xffff) + (sum1 >> 16)
+		sum2 = (sum2 & 0xffff) + (sum2 >> 16)
+	}
+
+	if len(data)%2 != 0 {
+		sum1 += uint32(data[pos]) << 8
+		sum2 += sum1
+		sum1 = (sum1 & 0xffff) + (sum1 >> 16)
+		sum2 = (sum2 & 0xffff) + (sum2 >> 16)
+	}
+
+	sum1 = (sum1 & 0xffff) + (sum1 >> 16)
+	sum2 = (sum2 & 0xffff) + (sum2 >> 16)
+
+	return sum2<<16 | sum1
+}
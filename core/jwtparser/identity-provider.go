@@ -0,0 +1,93 @@
+// Licensed to NASA JPL under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. NASA JPL licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package jwtparser
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/auth0-community/go-auth0"
+	"github.com/pixlise/core/v4/core/fileaccess"
+)
+
+// IdentityProvider - Where users log in. Validates the JWTs users send us, and knows which claims in them hold the
+// user's details and permissions
+type IdentityProvider interface {
+	GetValidator() JWTInterface
+	GetClaimNames() ClaimNames
+}
+
+// ClaimNames - Names of the JWT claims we read user details from. Nested claims (eg Keycloak's realm_access.roles) are
+// named with their path separated by .
+type ClaimNames struct {
+	UserId   string
+	Username string
+	Email    string
+
+	// Claims listing permissions, the user gets all permissions found in any of them. Each can be an array of strings
+	// or a space separated string. Permission names must match PIXLISE's, eg PIXLISE_ADMIN
+	Permissions []string
+}
+
+// Auth0IdentityProvider - Validates JWTs issued by our Auth0 tenant
+type Auth0IdentityProvider struct {
+	validator *auth0.JWTValidator
+	namespace string
+}
+
+func InitAuth0IdentityProvider(auth0Domain string, auth0Namespace string, configBucket string, pemPath string, fs fileaccess.FileAccess) (*Auth0IdentityProvider, error) {
+	validator, err := InitJWTValidator(auth0Domain, auth0Namespace, configBucket, pemPath, fs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Auth0IdentityProvider{validator: validator, namespace: auth0Namespace}, nil
+}
+
+func (p *Auth0IdentityProvider) GetValidator() JWTInterface {
+	return p.validator
+}
+
+func (p *Auth0IdentityProvider) GetClaimNames() ClaimNames {
+	return Auth0ClaimNames(p.namespace)
+}
+
+// Auth0ClaimNames - Claims our Auth0 tenant puts user details in. Auth0 only allows custom claims to be named with a
+// namespace, which we're configured with
+func Auth0ClaimNames(auth0Namespace string) ClaimNames {
+	return ClaimNames{
+		UserId:      "sub",
+		Username:    getAuth0ClaimName(auth0Namespace, "username"),
+		Email:       getAuth0ClaimName(auth0Namespace, "email"),
+		Permissions: []string{"permissions"},
+	}
+}
+
+func getAuth0ClaimName(auth0Namespace string, suffix string) string {
+	// If we're configured with a namespace, just use this... otherwise we have a fallback for PIXLISE v4 prod
+	if len(auth0Namespace) > 0 {
+		name, err := url.JoinPath(auth0Namespace, suffix)
+		if err != nil {
+			return ""
+		}
+		return name
+	}
+
+	// PIXLISE v4 prod was a bit more wild... Eventually we can deprecate this too
+	return fmt.Sprintf("https://pixlise.org/%v", suffix)
+}
@@ -17,23 +17,85 @@
 
 package jwtparser
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
-func ReadPermissions(claims map[string]interface{}) (map[string]bool, error) {
+// ReadPermissions - Reads the permissions listed in any of the given claims. Errors if none of them are in the JWT
+func ReadPermissions(claims map[string]interface{}, permissionClaims []string) (map[string]bool, error) {
 	result := map[string]bool{}
+	found := false
 
-	claimPermissions, ok := claims["permissions"].([]interface{}) // example of casting interface to something concrete
-	if !ok {
+	for _, claimName := range permissionClaims {
+		claim, ok := getClaim(claims, claimName)
+		if !ok {
+			continue
+		}
+
+		found = true
+
+		switch claimPermissions := claim.(type) {
+		case []interface{}:
+			for _, claimPerm := range claimPermissions {
+				// Get it as a string
+				claimPermStr, ok := claimPerm.(string)
+				if ok {
+					result[claimPermStr] = true
+				}
+			}
+		case string:
+			// Scope style, eg "openid PIXLISE_ADMIN"
+			for _, claimPerm := range strings.Fields(claimPermissions) {
+				result[claimPerm] = true
+			}
+		default:
+			return result, fmt.Errorf("Unexpected type of %v claim in request JWT", claimName)
+		}
+	}
+
+	if !found {
 		return result, fmt.Errorf("Failed to get permissions from request JWT")
 	}
 
-	for _, claimPerm := range claimPermissions {
-		// Get it as a string
-		claimPermStr, ok := claimPerm.(string)
-		if ok {
-			result[claimPermStr] = true
+	return result, nil
+}
+
+// Gets a claim, which can be nested in other claims, named by its path separated by . (eg realm_access.roles). Claims
+// which have a . in their name (eg Auth0's namespaced ones, which are URLs) are found directly first
+func getClaim(claims map[string]interface{}, name string) (interface{}, bool) {
+	if claim, ok := claims[name]; ok {
+		return claim, true
+	}
+
+	parts := strings.Split(name, ".")
+	if len(parts) < 2 {
+		return nil, false
+	}
+
+	var claim interface{} = claims
+	for _, part := range parts {
+		obj, ok := claim.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+
+		claim, ok = obj[part]
+		if !ok {
+			return nil, false
 		}
 	}
 
-	return result, nil
+	return claim, true
+}
+
+// Gets a claim that should be a string
+func getStringClaim(claims map[string]interface{}, name string) (string, bool) {
+	claim, ok := getClaim(claims, name)
+	if !ok {
+		return "", false
+	}
+
+	str, ok := claim.(string)
+	return str, ok
 }
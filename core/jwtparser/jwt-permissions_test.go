@@ -0,0 +1,61 @@
+// Licensed to NASA JPL under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. NASA JPL licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package jwtparser
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/pixlise/core/v4/core/utils"
+)
+
+func printPermissions(perms map[string]bool, err error) {
+	keys := utils.GetMapKeys(perms)
+	sort.Strings(keys)
+	fmt.Printf("%v|%v\n", keys, err)
+}
+
+func ExampleReadPermissions() {
+	claims := map[string]interface{}{
+		"permissions": []interface{}{"QUANTIFY", "EXPORT"},
+		"scope":       "openid EDIT_EXPRESSION",
+		"realm_access": map[string]interface{}{
+			"roles": []interface{}{"PIXLISE_ADMIN", "offline_access"},
+		},
+		"https://pixlise.org/username": "Niko",
+		"sub":                          123,
+	}
+
+	printPermissions(ReadPermissions(claims, []string{"permissions"}))
+	printPermissions(ReadPermissions(claims, []string{"realm_access.roles", "scope"}))
+	printPermissions(ReadPermissions(claims, []string{"groups"}))
+	printPermissions(ReadPermissions(claims, []string{"realm_access.groups"}))
+	printPermissions(ReadPermissions(claims, []string{"sub"}))
+
+	fmt.Println(getStringClaim(claims, "https://pixlise.org/username"))
+	fmt.Println(getStringClaim(claims, "sub"))
+
+	// Output:
+	// [EXPORT QUANTIFY]|<nil>
+	// [EDIT_EXPRESSION PIXLISE_ADMIN offline_access openid]|<nil>
+	// []|Failed to get permissions from request JWT
+	// []|Failed to get permissions from request JWT
+	// []|Unexpected type of sub claim in request JWT
+	// Niko true
+	//  false
+}
@@ -0,0 +1,111 @@
+// Licensed to NASA JPL under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. NASA JPL licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package jwtparser
+
+import (
+	"errors"
+	"time"
+
+	"github.com/auth0-community/go-auth0"
+	"gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+)
+
+// LocalIdentityProvider - Issues and validates HMAC signed JWTs with a shared secret, so the API can be run offline
+// for development and tests without an external identity provider. Not for use in any deployed environment, as
+// anyone with the secret can make a token with any permissions!
+type LocalIdentityProvider struct {
+	secret    []byte
+	validator *auth0.JWTValidator
+}
+
+const LocalIssuer = "pixlise-local"
+const LocalAudience = "pixlise-backend"
+
+// So a short or empty secret isn't configured by accident
+const localSecretMinLength = 32
+
+// So a placeholder like "changeme" or "0" repeated to the minimum length isn't accepted either
+const localSecretMinDistinctChars = 10
+
+func InitLocalIdentityProvider(secret string) (*LocalIdentityProvider, error) {
+	if len(secret) < localSecretMinLength {
+		return nil, errors.New("Local JWT secret must be at least 32 characters")
+	}
+
+	distinct := map[rune]bool{}
+	for _, c := range secret {
+		distinct[c] = true
+	}
+
+	if len(distinct) < localSecretMinDistinctChars {
+		return nil, errors.New("Local JWT secret looks like a placeholder, it must have at least 10 different characters")
+	}
+
+	configuration := auth0.NewConfiguration(auth0.NewKeyProvider([]byte(secret)), []string{LocalAudience}, LocalIssuer, jose.HS256)
+
+	return &LocalIdentityProvider{
+		secret:    []byte(secret),
+		validator: auth0.NewValidator(configuration, nil),
+	}, nil
+}
+
+func (p *LocalIdentityProvider) GetValidator() JWTInterface {
+	return p.validator
+}
+
+func (p *LocalIdentityProvider) GetClaimNames() ClaimNames {
+	return ClaimNames{
+		UserId:      "sub",
+		Username:    "name",
+		Email:       "email",
+		Permissions: []string{"permissions"},
+	}
+}
+
+// localClaims - Claims of the tokens we issue
+type localClaims struct {
+	jwt.Claims
+	Name        string   `json:"name"`
+	Email       string   `json:"email"`
+	Permissions []string `json:"permissions"`
+}
+
+// MakeToken - Issues a JWT for the given user, valid from now for validFor
+func (p *LocalIdentityProvider) MakeToken(user JWTUserInfo, permissions []string, now time.Time, validFor time.Duration) (string, error) {
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.HS256, Key: p.secret}, (&jose.SignerOptions{}).WithType("JWT"))
+	if err != nil {
+		return "", err
+	}
+
+	claims := localClaims{
+		Claims: jwt.Claims{
+			Issuer:    LocalIssuer,
+			Subject:   user.UserID,
+			Audience:  jwt.Audience{LocalAudience},
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			Expiry:    jwt.NewNumericDate(now.Add(validFor)),
+		},
+		Name:        user.Name,
+		Email:       user.Email,
+		Permissions: permissions,
+	}
+
+	return jwt.Signed(signer).Claims(claims).CompactSerialize()
+}
@@ -0,0 +1,72 @@
+// Licensed to NASA JPL under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. NASA JPL licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package jwtparser
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"time"
+)
+
+func ExampleLocalIdentityProvider_MakeToken() {
+	_, err := InitLocalIdentityProvider("too short")
+	fmt.Println(err)
+
+	_, err = InitLocalIdentityProvider("changemechangemechangemechangeme")
+	fmt.Println(err)
+
+	provider, err := InitLocalIdentityProvider("a-secret-for-local-development-only")
+	fmt.Println(err)
+
+	other, err := InitLocalIdentityProvider("some-other-secret-for-local-development")
+	fmt.Println(err)
+
+	user := JWTUserInfo{UserID: "user123", Name: "Niko Bellic", Email: "niko@pixlise.org"}
+	token, err := provider.MakeToken(user, []string{"PIXLISE_ADMIN"}, time.Now(), time.Hour)
+	fmt.Println(err)
+
+	expired, err := provider.MakeToken(user, []string{"PIXLISE_ADMIN"}, time.Now().Add(-2*time.Hour), time.Hour)
+	fmt.Println(err)
+
+	for _, item := range []struct {
+		label    string
+		provider *LocalIdentityProvider
+		token    string
+	}{
+		{"Valid", provider, token},
+		{"Expired", provider, expired},
+		{"Wrong secret", other, token},
+	} {
+		req := httptest.NewRequest("GET", "http://example.com/foo", nil)
+		req.Header.Set("Authorization", "Bearer "+item.token)
+
+		info, err := MakeJWTReader(item.provider).GetUserInfo(req)
+		fmt.Printf("%v: %v|%v|%v|%v|%v\n", item.label, info.UserID, info.Name, info.Email, info.Permissions, err)
+	}
+
+	// Output:
+	// Local JWT secret must be at least 32 characters
+	// Local JWT secret looks like a placeholder, it must have at least 10 different characters
+	// <nil>
+	// <nil>
+	// <nil>
+	// <nil>
+	// Valid: user123|Niko Bellic|niko@pixlise.org|map[PIXLISE_ADMIN:true]|<nil>
+	// Expired: |||map[]|square/go-jose/jwt: validation failed, token is expired (exp)
+	// Wrong secret: |||map[]|square/go-jose: error in cryptographic primitive
+}
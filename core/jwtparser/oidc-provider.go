@@ -0,0 +1,127 @@
+// Licensed to NASA JPL under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. NASA JPL licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package jwtparser
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/auth0-community/go-auth0"
+	"gopkg.in/square/go-jose.v2"
+)
+
+// OIDCIdentityProvider - Validates JWTs issued by an OpenID Connect provider, eg a Keycloak realm. The provider's signing
+// keys are found through its discovery document, and are refreshed when a token signed with an unknown key arrives
+type OIDCIdentityProvider struct {
+	validator *auth0.JWTValidator
+	claims    ClaimNames
+}
+
+// What we need from the provider's /.well-known/openid-configuration
+type oidcDiscovery struct {
+	Issuer  string `json:"issuer"`
+	JWKSURI string `json:"jwks_uri"`
+}
+
+const oidcDiscoveryTimeout = 30 * time.Second
+
+// InitOIDCIdentityProvider - Reads the discovery document of the provider at issuerURL. Tokens must be RS256 signed and
+// issued for audience. Any claim names left empty are set to what OIDC providers generally use
+func InitOIDCIdentityProvider(issuerURL string, audience string, claims ClaimNames) (*OIDCIdentityProvider, error) {
+	if len(issuerURL) <= 0 {
+		return nil, errors.New("OIDC issuer URL must be set")
+	}
+	if len(audience) <= 0 {
+		return nil, errors.New("OIDC audience must be set")
+	}
+
+	discovery, err := readOIDCDiscovery(issuerURL)
+	if err != nil {
+		return nil, err
+	}
+
+	keyProvider := auth0.NewJWKClient(auth0.JWKClientOptions{URI: discovery.JWKSURI}, nil)
+	configuration := auth0.NewConfiguration(keyProvider, []string{audience}, discovery.Issuer, jose.RS256)
+
+	return &OIDCIdentityProvider{
+		validator: auth0.NewValidator(configuration, nil),
+		claims:    withDefaultOIDCClaimNames(claims),
+	}, nil
+}
+
+func (p *OIDCIdentityProvider) GetValidator() JWTInterface {
+	return p.validator
+}
+
+func (p *OIDCIdentityProvider) GetClaimNames() ClaimNames {
+	return p.claims
+}
+
+func readOIDCDiscovery(issuerURL string) (oidcDiscovery, error) {
+	result := oidcDiscovery{}
+	discoveryURL := strings.TrimSuffix(issuerURL, "/") + "/.well-known/openid-configuration"
+
+	client := http.Client{Timeout: oidcDiscoveryTimeout}
+	resp, err := client.Get(discoveryURL)
+	if err != nil {
+		return result, fmt.Errorf("Failed to read OIDC discovery document %v: %v", discoveryURL, err)
+	}
+
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return result, fmt.Errorf("Failed to read OIDC discovery document %v: %v", discoveryURL, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return result, fmt.Errorf("Failed to read OIDC discovery document %v, status: %v", discoveryURL, resp.Status)
+	}
+
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return result, fmt.Errorf("Failed to parse OIDC discovery document %v: %v", discoveryURL, err)
+	}
+
+	if len(result.Issuer) <= 0 || len(result.JWKSURI) <= 0 {
+		return result, fmt.Errorf("OIDC discovery document %v did not contain issuer and jwks_uri", discoveryURL)
+	}
+
+	return result, nil
+}
+
+func withDefaultOIDCClaimNames(claims ClaimNames) ClaimNames {
+	if len(claims.UserId) <= 0 {
+		claims.UserId = "sub"
+	}
+	if len(claims.Username) <= 0 {
+		claims.Username = "preferred_username"
+	}
+	if len(claims.Email) <= 0 {
+		claims.Email = "email"
+	}
+	if len(claims.Permissions) <= 0 {
+		claims.Permissions = []string{"permissions"}
+	}
+	return claims
+}
@@ -20,13 +20,12 @@ package jwtparser
 import (
 	"fmt"
 	"net/http"
-	"net/url"
 	"strings"
 )
 
 // IJWTReader - User ID getter from HTTP request
 type IJWTReader interface {
-	GetValidator() JWTInterface
+	IdentityProvider
 	GetUserInfo(*http.Request) (JWTUserInfo, error)
 }
 
@@ -39,14 +38,23 @@ type JWTUserInfo struct {
 
 // RealJWTReader - Reader
 type RealJWTReader struct {
-	Validator      JWTInterface
-	Auth0Namespace string
+	Validator JWTInterface
+	Claims    ClaimNames
+}
+
+// MakeJWTReader - Makes a reader of JWTs from the given identity provider
+func MakeJWTReader(provider IdentityProvider) RealJWTReader {
+	return RealJWTReader{Validator: provider.GetValidator(), Claims: provider.GetClaimNames()}
 }
 
 func (j RealJWTReader) GetValidator() JWTInterface {
 	return j.Validator
 }
 
+func (j RealJWTReader) GetClaimNames() ClaimNames {
+	return j.Claims
+}
+
 // GetSimpleUserInfo - Get Simple User Info
 // TODO: See note for GetUserInfo about user impersonation
 func (j RealJWTReader) GetSimpleUserInfo(r *http.Request) (JWTUserInfo, error) {
@@ -65,18 +73,18 @@ func (j RealJWTReader) GetSimpleUserInfo(r *http.Request) (JWTUserInfo, error) {
 		return result, err
 	}
 
-	userNameObj, ok := claims[j.getJWTFieldName("username")]
+	userName, ok := getStringClaim(claims, j.Claims.Username)
 	if !ok {
 		return result, fmt.Errorf("Failed to get user name from request JWT")
 	}
-	result.Name = userNameObj.(string)
+	result.Name = userName
 
-	userIDObj, ok := claims["sub"]
+	userID, ok := getStringClaim(claims, j.Claims.UserId)
 	if !ok {
 		return result, fmt.Errorf("Failed to get user ID from request JWT")
 	}
 
-	result.UserID = userIDObj.(string)
+	result.UserID = userID
 	pipePos := strings.Index(result.UserID, "|")
 	if pipePos > -1 {
 		result.UserID = result.UserID[pipePos+1:]
@@ -109,55 +117,29 @@ func (j RealJWTReader) GetUserInfo(r *http.Request) (JWTUserInfo, error) {
 		return result, err
 	}
 
-	userNameObj, ok := claims[j.getJWTFieldName("username")]
+	userName, ok := getStringClaim(claims, j.Claims.Username)
 	if !ok {
 		return result, fmt.Errorf("Failed to get user name from request JWT")
 	}
-	result.Name = userNameObj.(string)
+	result.Name = userName
 
-	userEmailObj, ok := claims[j.getJWTFieldName("email")]
+	userEmail, ok := getStringClaim(claims, j.Claims.Email)
 	if !ok {
 		return result, fmt.Errorf("Failed to get email address from JWT")
 	}
 
-	result.Email = userEmailObj.(string)
+	result.Email = userEmail
 
-	userIDObj, ok := claims["sub"]
+	userID, ok := getStringClaim(claims, j.Claims.UserId)
 	if !ok {
 		return result, fmt.Errorf("Failed to get user ID from request JWT")
 	}
 
-	result.UserID = userIDObj.(string)
-
-	// The permissions field is only in the JWT if RBAC is enabled on Auth0 API and Add Permissions in the Access Token is also enabled
-	_, ok = claims["permissions"]
-	if !ok {
-		return result, fmt.Errorf("Failed to get permissions object from JWT")
-	}
+	result.UserID = userID
 
-	result.Permissions, err = ReadPermissions(claims)
+	// With Auth0, the permissions field is only in the JWT if RBAC is enabled on Auth0 API and Add Permissions in the
+	// Access Token is also enabled. Other providers need a mapper configured to put roles in the claims we read
+	result.Permissions, err = ReadPermissions(claims, j.Claims.Permissions)
 
 	return result, err
 }
-
-func (j RealJWTReader) getJWTFieldName(suffix string) string {
-	// If we're configured with a namespace, just use this... otherwise we have a fallback for PIXLISE v4 prod
-	if len(j.Auth0Namespace) > 0 {
-		name, err := url.JoinPath(j.Auth0Namespace, suffix)
-		if err != nil {
-			return ""
-		}
-		return name
-	}
-
-	// PIXLISE v4 prod was a bit more wild... Eventually we can deprecate this too
-	switch suffix {
-	case "username":
-		return "https://pixlise.org/username"
-	case "email":
-		return "https://pixlise.org/email"
-	}
-
-	// Don't know what else this could be... probably should print an error
-	return ""
-}
@@ -0,0 +1,185 @@
+package scan
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/pixlise/core/v4/api/filepaths"
+	protos "github.com/pixlise/core/v4/generated-protos"
+	"google.golang.org/protobuf/proto"
+)
+
+// The kinds of per-location data in a scan. Each is stored in its own chunk files, so requests only read what they need
+type DataKind uint
+
+const (
+	DataKindSpectra DataKind = 1 << iota
+	DataKindHousekeeping
+	DataKindBeams
+	DataKindPseudoIntensities
+
+	// Not stored separately, but when reading spectra, also reads the locations with bulk sum/max value spectra
+	DataKindBulkMaxSpectra
+)
+
+const DataKindAll = DataKindSpectra | DataKindHousekeeping | DataKindBeams | DataKindPseudoIntensities
+
+var chunkedDataKinds = []DataKind{DataKindSpectra, DataKindHousekeeping, DataKindBeams, DataKindPseudoIntensities}
+
+// PIXL scans are a few thousand locations, SEM/WDS maps can be far larger. This keeps a chunk of spectra to a few MB
+const DefaultLocationsPerChunk = 500
+
+func (k DataKind) chunkFileKindName() string {
+	switch k {
+	case DataKindSpectra:
+		return "spectra"
+	case DataKindHousekeeping:
+		return "housekeeping"
+	case DataKindBeams:
+		return "beams"
+	case DataKindPseudoIntensities:
+		return "pseudo"
+	}
+	return ""
+}
+
+// Splits a scan into the chunk index and chunk files, returned as a map of file name->contents
+func MakeChunks(exprPB *protos.Experiment, locationsPerChunk int) (*protos.ExperimentChunkIndex, map[string]*protos.Experiment) {
+	// Clone everything but the locations, which we copy only the small fields of
+	locations := exprPB.Locations
+	exprPB.Locations = nil
+	header := proto.Clone(exprPB).(*protos.Experiment)
+	exprPB.Locations = locations
+
+	readtypeIdx := int32(-1)
+	for c, label := range exprPB.MetaLabels {
+		if label == "READTYPE" {
+			readtypeIdx = int32(c)
+			break
+		}
+	}
+
+	index := &protos.ExperimentChunkIndex{
+		Header:                 header,
+		LocationsPerChunk:      uint32(locationsPerChunk),
+		BulkMaxLocationIndexes: []uint32{},
+	}
+
+	chunks := map[string]*protos.Experiment{}
+
+	for c, loc := range locations {
+		header.Locations = append(header.Locations, &protos.Experiment_Location{
+			Id:                  loc.Id,
+			ScanSource:          loc.ScanSource,
+			SpectrumCompression: loc.SpectrumCompression,
+			ContextImage:        loc.ContextImage,
+		})
+
+		if isBulkMaxLocation(loc, readtypeIdx) {
+			index.BulkMaxLocationIndexes = append(index.BulkMaxLocationIndexes, uint32(c))
+		}
+
+		chunkIdx := c / locationsPerChunk
+		for _, kind := range chunkedDataKinds {
+			name := filepaths.MakeDatasetChunkFileName(kind.chunkFileKindName(), chunkIdx)
+			chunk, ok := chunks[name]
+			if !ok {
+				chunk = &protos.Experiment{}
+				chunks[name] = chunk
+			}
+
+			chunkLoc := &protos.Experiment_Location{}
+			switch kind {
+			case DataKindSpectra:
+				chunkLoc.Detectors = loc.Detectors
+			case DataKindHousekeeping:
+				chunkLoc.Meta = loc.Meta
+			case DataKindBeams:
+				chunkLoc.Beam = loc.Beam
+			case DataKindPseudoIntensities:
+				chunkLoc.PseudoIntensities = loc.PseudoIntensities
+			}
+			chunk.Locations = append(chunk.Locations, chunkLoc)
+		}
+	}
+
+	return index, chunks
+}
+
+func isBulkMaxLocation(loc *protos.Experiment_Location, readtypeIdx int32) bool {
+	for _, detector := range loc.Detectors {
+		for _, m := range detector.Meta {
+			if m.LabelIdx == readtypeIdx && (m.Svalue == "BulkSum" || m.Svalue == "MaxValue") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// A chunk file to read, and what it contains
+type ChunkFile struct {
+	Name     string
+	kind     DataKind
+	chunkIdx int
+}
+
+// Returns the chunk files to read to get the given kinds of data for the location indexes
+func GetChunkFiles(index *protos.ExperimentChunkIndex, kinds DataKind, indexes []uint32) []ChunkFile {
+	files := []ChunkFile{}
+	if index.LocationsPerChunk <= 0 {
+		return files
+	}
+
+	for _, kind := range chunkedDataKinds {
+		if kinds&kind == 0 {
+			continue
+		}
+
+		kindIndexes := indexes
+		if kind == DataKindSpectra && kinds&DataKindBulkMaxSpectra != 0 {
+			kindIndexes = append(append([]uint32{}, indexes...), index.BulkMaxLocationIndexes...)
+		}
+
+		chunkIdxs := map[int]bool{}
+		for _, idx := range kindIndexes {
+			chunkIdxs[int(idx/index.LocationsPerChunk)] = true
+		}
+
+		sortedIdxs := []int{}
+		for idx := range chunkIdxs {
+			sortedIdxs = append(sortedIdxs, idx)
+		}
+		sort.Ints(sortedIdxs)
+
+		for _, idx := range sortedIdxs {
+			files = append(files, ChunkFile{Name: filepaths.MakeDatasetChunkFileName(kind.chunkFileKindName(), idx), kind: kind, chunkIdx: idx})
+		}
+	}
+
+	return files
+}
+
+// Copies the data in a chunk file into the locations of the experiment, which must have been made from the index header
+func MergeChunk(exprPB *protos.Experiment, index *protos.ExperimentChunkIndex, file ChunkFile, chunk *protos.Experiment) error {
+	firstIdx := file.chunkIdx * int(index.LocationsPerChunk)
+	if firstIdx+len(chunk.Locations) > len(exprPB.Locations) {
+		return fmt.Errorf("Chunk %v has %v locations, scan only has %v", file.Name, len(chunk.Locations), len(exprPB.Locations))
+	}
+
+	for c, chunkLoc := range chunk.Locations {
+		loc := exprPB.Locations[firstIdx+c]
+		switch file.kind {
+		case DataKindSpectra:
+			loc.Detectors = chunkLoc.Detectors
+		case DataKindHousekeeping:
+			loc.Meta = chunkLoc.Meta
+		case DataKindBeams:
+			loc.Beam = chunkLoc.Beam
+		case DataKindPseudoIntensities:
+			loc.PseudoIntensities = chunkLoc.PseudoIntensities
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,130 @@
+package scan
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	protos "github.com/pixlise/core/v4/generated-protos"
+	"google.golang.org/protobuf/proto"
+)
+
+func makeChunkTestExperiment() *protos.Experiment {
+	exprPB := &protos.Experiment{
+		Title:      "Chunk test",
+		MetaLabels: []string{"READTYPE", "SCLK"},
+		MetaTypes:  []protos.Experiment_MetaDataType{protos.Experiment_MT_STRING, protos.Experiment_MT_INT},
+	}
+
+	for c := 0; c < 7; c++ {
+		readType := "Normal"
+		if c == 5 {
+			readType = "BulkSum"
+		}
+
+		exprPB.Locations = append(exprPB.Locations, &protos.Experiment_Location{
+			Id:           strconv.Itoa(100 + c),
+			ContextImage: "context.png",
+			Meta:         []*protos.Experiment_Location_MetaDataItem{{LabelIdx: 1, Ivalue: int32(1000 + c)}},
+			Beam:         &protos.Experiment_Location_BeamLocation{X: float32(c), Y: 1, Z: 2},
+			Detectors: []*protos.Experiment_Location_DetectorSpectrum{
+				{Meta: []*protos.Experiment_Location_MetaDataItem{{LabelIdx: 0, Svalue: readType}}, Spectrum: []int32{int32(c), 3}},
+			},
+			PseudoIntensities: []*protos.Experiment_Location_PseudoIntensityData{{ElementIntensities: []float32{float32(c)}}},
+		})
+	}
+
+	return exprPB
+}
+
+func printChunkTestLocations(exprPB *protos.Experiment) {
+	for _, loc := range exprPB.Locations {
+		spectrum := []int32{}
+		if len(loc.Detectors) > 0 {
+			spectrum = loc.Detectors[0].Spectrum
+		}
+		fmt.Printf(" %v: meta=%v beam=%v spectrum=%v pseudo=%v\n", loc.Id, len(loc.Meta), loc.Beam != nil, spectrum, len(loc.PseudoIntensities))
+	}
+}
+
+func Example_scan_MakeChunks() {
+	exprPB := makeChunkTestExperiment()
+	index, chunks := MakeChunks(exprPB, 3)
+
+	names := []string{}
+	for name := range chunks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Printf("Source locations: %v\n", len(exprPB.Locations))
+	fmt.Printf("Header: %v, locations: %v, per chunk: %v, bulk/max: %v\n", index.Header.Title, len(index.Header.Locations), index.LocationsPerChunk, index.BulkMaxLocationIndexes)
+	fmt.Printf("Chunks: %v\n", names)
+	fmt.Printf("Last spectra chunk: %v locations\n", len(chunks["dataset-spectra-2.bin"].Locations))
+
+	// Make sure they survive being written and read back like they would be from S3
+	readIndex := &protos.ExperimentChunkIndex{}
+	b, _ := proto.Marshal(index)
+	fmt.Println(proto.Unmarshal(b, readIndex))
+
+	read := func(kinds DataKind, indexes []uint32) {
+		result := proto.Clone(readIndex.Header).(*protos.Experiment)
+		files := GetChunkFiles(readIndex, kinds, indexes)
+
+		fileNames := []string{}
+		for _, file := range files {
+			fileNames = append(fileNames, file.Name)
+
+			chunk := &protos.Experiment{}
+			b, _ := proto.Marshal(chunks[file.Name])
+			if err := proto.Unmarshal(b, chunk); err != nil {
+				fmt.Println(err)
+			}
+
+			if err := MergeChunk(result, readIndex, file, chunk); err != nil {
+				fmt.Println(err)
+			}
+		}
+
+		fmt.Printf("Read: %v\n", fileNames)
+		printChunkTestLocations(result)
+	}
+
+	read(DataKindBeams|DataKindHousekeeping, []uint32{1})
+	read(DataKindSpectra|DataKindBulkMaxSpectra, []uint32{6})
+	read(DataKindPseudoIntensities, []uint32{})
+
+	fmt.Println(MergeChunk(proto.Clone(readIndex.Header).(*protos.Experiment), readIndex, ChunkFile{Name: "bad", kind: DataKindBeams, chunkIdx: 2}, chunks["dataset-beams-0.bin"]))
+
+	// Output:
+	// Source locations: 7
+	// Header: Chunk test, locations: 7, per chunk: 3, bulk/max: [5]
+	// Chunks: [dataset-beams-0.bin dataset-beams-1.bin dataset-beams-2.bin dataset-housekeeping-0.bin dataset-housekeeping-1.bin dataset-housekeeping-2.bin dataset-pseudo-0.bin dataset-pseudo-1.bin dataset-pseudo-2.bin dataset-spectra-0.bin dataset-spectra-1.bin dataset-spectra-2.bin]
+	// Last spectra chunk: 1 locations
+	// <nil>
+	// Read: [dataset-housekeeping-0.bin dataset-beams-0.bin]
+	//  100: meta=1 beam=true spectrum=[] pseudo=0
+	//  101: meta=1 beam=true spectrum=[] pseudo=0
+	//  102: meta=1 beam=true spectrum=[] pseudo=0
+	//  103: meta=0 beam=false spectrum=[] pseudo=0
+	//  104: meta=0 beam=false spectrum=[] pseudo=0
+	//  105: meta=0 beam=false spectrum=[] pseudo=0
+	//  106: meta=0 beam=false spectrum=[] pseudo=0
+	// Read: [dataset-spectra-1.bin dataset-spectra-2.bin]
+	//  100: meta=0 beam=false spectrum=[] pseudo=0
+	//  101: meta=0 beam=false spectrum=[] pseudo=0
+	//  102: meta=0 beam=false spectrum=[] pseudo=0
+	//  103: meta=0 beam=false spectrum=[3 3] pseudo=0
+	//  104: meta=0 beam=false spectrum=[4 3] pseudo=0
+	//  105: meta=0 beam=false spectrum=[5 3] pseudo=0
+	//  106: meta=0 beam=false spectrum=[6 3] pseudo=0
+	// Read: []
+	//  100: meta=0 beam=false spectrum=[] pseudo=0
+	//  101: meta=0 beam=false spectrum=[] pseudo=0
+	//  102: meta=0 beam=false spectrum=[] pseudo=0
+	//  103: meta=0 beam=false spectrum=[] pseudo=0
+	//  104: meta=0 beam=false spectrum=[] pseudo=0
+	//  105: meta=0 beam=false spectrum=[] pseudo=0
+	//  106: meta=0 beam=false spectrum=[] pseudo=0
+	// Chunk bad has 3 locations, scan only has 7
+}
@@ -0,0 +1,231 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v3.21.12
+// source: audit-msgs.proto
+
+package protos
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Lists audit log entries, newest first. Entries for an object can be listed by anyone with access to the object, listing
+// without specifying an object requires PIXLISE_ADMIN. Once an object is deleted, admins can still list all its entries,
+// and other users the entries they made
+// requires(NONE)
+type AuditLogListReq struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	ObjectId   string                 `protobuf:"bytes,1,opt,name=objectId,proto3" json:"objectId,omitempty"`
+	ObjectType ObjectType             `protobuf:"varint,2,opt,name=objectType,proto3,enum=ObjectType" json:"objectType,omitempty"`
+	// Optional filters
+	UserId      string `protobuf:"bytes,3,opt,name=userId,proto3" json:"userId,omitempty"`
+	FromUnixSec uint32 `protobuf:"varint,4,opt,name=fromUnixSec,proto3" json:"fromUnixSec,omitempty"`
+	ToUnixSec   uint32 `protobuf:"varint,5,opt,name=toUnixSec,proto3" json:"toUnixSec,omitempty"`
+	// If 0, a default limit applies
+	MaxEntries    uint32 `protobuf:"varint,6,opt,name=maxEntries,proto3" json:"maxEntries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditLogListReq) Reset() {
+	*x = AuditLogListReq{}
+	mi := &file_audit_msgs_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditLogListReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditLogListReq) ProtoMessage() {}
+
+func (x *AuditLogListReq) ProtoReflect() protoreflect.Message {
+	mi := &file_audit_msgs_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditLogListReq.ProtoReflect.Descriptor instead.
+func (*AuditLogListReq) Descriptor() ([]byte, []int) {
+	return file_audit_msgs_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *AuditLogListReq) GetObjectId() string {
+	if x != nil {
+		return x.ObjectId
+	}
+	return ""
+}
+
+func (x *AuditLogListReq) GetObjectType() ObjectType {
+	if x != nil {
+		return x.ObjectType
+	}
+	return ObjectType_OT_UNKNOWN
+}
+
+func (x *AuditLogListReq) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *AuditLogListReq) GetFromUnixSec() uint32 {
+	if x != nil {
+		return x.FromUnixSec
+	}
+	return 0
+}
+
+func (x *AuditLogListReq) GetToUnixSec() uint32 {
+	if x != nil {
+		return x.ToUnixSec
+	}
+	return 0
+}
+
+func (x *AuditLogListReq) GetMaxEntries() uint32 {
+	if x != nil {
+		return x.MaxEntries
+	}
+	return 0
+}
+
+type AuditLogListResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*AuditLogEntry       `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditLogListResp) Reset() {
+	*x = AuditLogListResp{}
+	mi := &file_audit_msgs_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditLogListResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditLogListResp) ProtoMessage() {}
+
+func (x *AuditLogListResp) ProtoReflect() protoreflect.Message {
+	mi := &file_audit_msgs_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditLogListResp.ProtoReflect.Descriptor instead.
+func (*AuditLogListResp) Descriptor() ([]byte, []int) {
+	return file_audit_msgs_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *AuditLogListResp) GetEntries() []*AuditLogEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+var File_audit_msgs_proto protoreflect.FileDescriptor
+
+const file_audit_msgs_proto_rawDesc = "" +
+	"\n" +
+	"\x10audit-msgs.proto\x1a\vaudit.proto\x1a\x16ownership-access.proto\"\xd2\x01\n" +
+	"\x0fAuditLogListReq\x12\x1a\n" +
+	"\bobjectId\x18\x01 \x01(\tR\bobjectId\x12+\n" +
+	"\n" +
+	"objectType\x18\x02 \x01(\x0e2\v.ObjectTypeR\n" +
+	"objectType\x12\x16\n" +
+	"\x06userId\x18\x03 \x01(\tR\x06userId\x12 \n" +
+	"\vfromUnixSec\x18\x04 \x01(\rR\vfromUnixSec\x12\x1c\n" +
+	"\ttoUnixSec\x18\x05 \x01(\rR\ttoUnixSec\x12\x1e\n" +
+	"\n" +
+	"maxEntries\x18\x06 \x01(\rR\n" +
+	"maxEntries\"<\n" +
+	"\x10AuditLogListResp\x12(\n" +
+	"\aentries\x18\x01 \x03(\v2\x0e.AuditLogEntryR\aentriesB\n" +
+	"Z\b.;protosb\x06proto3"
+
+var (
+	file_audit_msgs_proto_rawDescOnce sync.Once
+	file_audit_msgs_proto_rawDescData []byte
+)
+
+func file_audit_msgs_proto_rawDescGZIP() []byte {
+	file_audit_msgs_proto_rawDescOnce.Do(func() {
+		file_audit_msgs_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_audit_msgs_proto_rawDesc), len(file_audit_msgs_proto_rawDesc)))
+	})
+	return file_audit_msgs_proto_rawDescData
+}
+
+var file_audit_msgs_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_audit_msgs_proto_goTypes = []any{
+	(*AuditLogListReq)(nil),  // 0: AuditLogListReq
+	(*AuditLogListResp)(nil), // 1: AuditLogListResp
+	(ObjectType)(0),          // 2: ObjectType
+	(*AuditLogEntry)(nil),    // 3: AuditLogEntry
+}
+var file_audit_msgs_proto_depIdxs = []int32{
+	2, // 0: AuditLogListReq.objectType:type_name -> ObjectType
+	3, // 1: AuditLogListResp.entries:type_name -> AuditLogEntry
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_audit_msgs_proto_init() }
+func file_audit_msgs_proto_init() {
+	if File_audit_msgs_proto != nil {
+		return
+	}
+	file_audit_proto_init()
+	file_ownership_access_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_audit_msgs_proto_rawDesc), len(file_audit_msgs_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_audit_msgs_proto_goTypes,
+		DependencyIndexes: file_audit_msgs_proto_depIdxs,
+		MessageInfos:      file_audit_msgs_proto_msgTypes,
+	}.Build()
+	File_audit_msgs_proto = out.File
+	file_audit_msgs_proto_goTypes = nil
+	file_audit_msgs_proto_depIdxs = nil
+}
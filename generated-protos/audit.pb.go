@@ -0,0 +1,329 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v3.21.12
+// source: audit.proto
+
+package protos
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type AuditAction int32
+
+const (
+	AuditAction_AA_UNKNOWN AuditAction = 0
+	AuditAction_AA_CREATE  AuditAction = 1
+	AuditAction_AA_UPDATE  AuditAction = 2
+	AuditAction_AA_DELETE  AuditAction = 3
+	AuditAction_AA_SHARE   AuditAction = 4
+)
+
+// Enum value maps for AuditAction.
+var (
+	AuditAction_name = map[int32]string{
+		0: "AA_UNKNOWN",
+		1: "AA_CREATE",
+		2: "AA_UPDATE",
+		3: "AA_DELETE",
+		4: "AA_SHARE",
+	}
+	AuditAction_value = map[string]int32{
+		"AA_UNKNOWN": 0,
+		"AA_CREATE":  1,
+		"AA_UPDATE":  2,
+		"AA_DELETE":  3,
+		"AA_SHARE":   4,
+	}
+)
+
+func (x AuditAction) Enum() *AuditAction {
+	p := new(AuditAction)
+	*p = x
+	return p
+}
+
+func (x AuditAction) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AuditAction) Descriptor() protoreflect.EnumDescriptor {
+	return file_audit_proto_enumTypes[0].Descriptor()
+}
+
+func (AuditAction) Type() protoreflect.EnumType {
+	return &file_audit_proto_enumTypes[0]
+}
+
+func (x AuditAction) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AuditAction.Descriptor instead.
+func (AuditAction) EnumDescriptor() ([]byte, []int) {
+	return file_audit_proto_rawDescGZIP(), []int{0}
+}
+
+type AuditFieldChange struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Path of the field in the DB item, with . separating nested fields, eg "viewers.userids"
+	Field string `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	// Values as readable text (arrays as [a, b]), empty if the field wasn't there. Long values are truncated
+	Before        string `protobuf:"bytes,2,opt,name=before,proto3" json:"before,omitempty"`
+	After         string `protobuf:"bytes,3,opt,name=after,proto3" json:"after,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditFieldChange) Reset() {
+	*x = AuditFieldChange{}
+	mi := &file_audit_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditFieldChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditFieldChange) ProtoMessage() {}
+
+func (x *AuditFieldChange) ProtoReflect() protoreflect.Message {
+	mi := &file_audit_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditFieldChange.ProtoReflect.Descriptor instead.
+func (*AuditFieldChange) Descriptor() ([]byte, []int) {
+	return file_audit_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *AuditFieldChange) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *AuditFieldChange) GetBefore() string {
+	if x != nil {
+		return x.Before
+	}
+	return ""
+}
+
+func (x *AuditFieldChange) GetAfter() string {
+	if x != nil {
+		return x.After
+	}
+	return ""
+}
+
+// Written for every change to a user object. These are never modified or deleted
+type AuditLogEntry struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Id         string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty" bson:"_id,omitempty"`  
+	ObjectId   string                 `protobuf:"bytes,2,opt,name=objectId,proto3" json:"objectId,omitempty"`
+	ObjectType ObjectType             `protobuf:"varint,3,opt,name=objectType,proto3,enum=ObjectType" json:"objectType,omitempty"`
+	Action     AuditAction            `protobuf:"varint,4,opt,name=action,proto3,enum=AuditAction" json:"action,omitempty"`
+	// User who made the change, and if they were impersonating that user, who they really are
+	UserId           string              `protobuf:"bytes,5,opt,name=userId,proto3" json:"userId,omitempty"`
+	RealUserId       string              `protobuf:"bytes,6,opt,name=realUserId,proto3" json:"realUserId,omitempty"`
+	TimeStampUnixSec uint32              `protobuf:"varint,7,opt,name=timeStampUnixSec,proto3" json:"timeStampUnixSec,omitempty"`
+	Changes          []*AuditFieldChange `protobuf:"bytes,8,rep,name=changes,proto3" json:"changes,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *AuditLogEntry) Reset() {
+	*x = AuditLogEntry{}
+	mi := &file_audit_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditLogEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditLogEntry) ProtoMessage() {}
+
+func (x *AuditLogEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_audit_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditLogEntry.ProtoReflect.Descriptor instead.
+func (*AuditLogEntry) Descriptor() ([]byte, []int) {
+	return file_audit_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *AuditLogEntry) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AuditLogEntry) GetObjectId() string {
+	if x != nil {
+		return x.ObjectId
+	}
+	return ""
+}
+
+func (x *AuditLogEntry) GetObjectType() ObjectType {
+	if x != nil {
+		return x.ObjectType
+	}
+	return ObjectType_OT_UNKNOWN
+}
+
+func (x *AuditLogEntry) GetAction() AuditAction {
+	if x != nil {
+		return x.Action
+	}
+	return AuditAction_AA_UNKNOWN
+}
+
+func (x *AuditLogEntry) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *AuditLogEntry) GetRealUserId() string {
+	if x != nil {
+		return x.RealUserId
+	}
+	return ""
+}
+
+func (x *AuditLogEntry) GetTimeStampUnixSec() uint32 {
+	if x != nil {
+		return x.TimeStampUnixSec
+	}
+	return 0
+}
+
+func (x *AuditLogEntry) GetChanges() []*AuditFieldChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+var File_audit_proto protoreflect.FileDescriptor
+
+const file_audit_proto_rawDesc = "" +
+	"\n" +
+	"\vaudit.proto\x1a\x16ownership-access.proto\"V\n" +
+	"\x10AuditFieldChange\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12\x16\n" +
+	"\x06before\x18\x02 \x01(\tR\x06before\x12\x14\n" +
+	"\x05after\x18\x03 \x01(\tR\x05after\"\x9f\x02\n" +
+	"\rAuditLogEntry\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
+	"\bobjectId\x18\x02 \x01(\tR\bobjectId\x12+\n" +
+	"\n" +
+	"objectType\x18\x03 \x01(\x0e2\v.ObjectTypeR\n" +
+	"objectType\x12$\n" +
+	"\x06action\x18\x04 \x01(\x0e2\f.AuditActionR\x06action\x12\x16\n" +
+	"\x06userId\x18\x05 \x01(\tR\x06userId\x12\x1e\n" +
+	"\n" +
+	"realUserId\x18\x06 \x01(\tR\n" +
+	"realUserId\x12*\n" +
+	"\x10timeStampUnixSec\x18\a \x01(\rR\x10timeStampUnixSec\x12+\n" +
+	"\achanges\x18\b \x03(\v2\x11.AuditFieldChangeR\achanges*X\n" +
+	"\vAuditAction\x12\x0e\n" +
+	"\n" +
+	"AA_UNKNOWN\x10\x00\x12\r\n" +
+	"\tAA_CREATE\x10\x01\x12\r\n" +
+	"\tAA_UPDATE\x10\x02\x12\r\n" +
+	"\tAA_DELETE\x10\x03\x12\f\n" +
+	"\bAA_SHARE\x10\x04B\n" +
+	"Z\b.;protosb\x06proto3"
+
+var (
+	file_audit_proto_rawDescOnce sync.Once
+	file_audit_proto_rawDescData []byte
+)
+
+func file_audit_proto_rawDescGZIP() []byte {
+	file_audit_proto_rawDescOnce.Do(func() {
+		file_audit_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_audit_proto_rawDesc), len(file_audit_proto_rawDesc)))
+	})
+	return file_audit_proto_rawDescData
+}
+
+var file_audit_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_audit_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_audit_proto_goTypes = []any{
+	(AuditAction)(0),         // 0: AuditAction
+	(*AuditFieldChange)(nil), // 1: AuditFieldChange
+	(*AuditLogEntry)(nil),    // 2: AuditLogEntry
+	(ObjectType)(0),          // 3: ObjectType
+}
+var file_audit_proto_depIdxs = []int32{
+	3, // 0: AuditLogEntry.objectType:type_name -> ObjectType
+	0, // 1: AuditLogEntry.action:type_name -> AuditAction
+	1, // 2: AuditLogEntry.changes:type_name -> AuditFieldChange
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_audit_proto_init() }
+func file_audit_proto_init() {
+	if File_audit_proto != nil {
+		return
+	}
+	file_ownership_access_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_audit_proto_rawDesc), len(file_audit_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_audit_proto_goTypes,
+		DependencyIndexes: file_audit_proto_depIdxs,
+		EnumInfos:         file_audit_proto_enumTypes,
+		MessageInfos:      file_audit_proto_msgTypes,
+	}.Build()
+	File_audit_proto = out.File
+	file_audit_proto_goTypes = nil
+	file_audit_proto_depIdxs = nil
+}
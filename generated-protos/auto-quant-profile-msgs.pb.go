@@ -0,0 +1,349 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v3.21.12
+// source: auto-quant-profile-msgs.proto
+
+package protos
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// requires(READ_PIQUANT_SETTINGS)
+type AutoQuantProfileListReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AutoQuantProfileListReq) Reset() {
+	*x = AutoQuantProfileListReq{}
+	mi := &file_auto_quant_profile_msgs_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AutoQuantProfileListReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AutoQuantProfileListReq) ProtoMessage() {}
+
+func (x *AutoQuantProfileListReq) ProtoReflect() protoreflect.Message {
+	mi := &file_auto_quant_profile_msgs_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AutoQuantProfileListReq.ProtoReflect.Descriptor instead.
+func (*AutoQuantProfileListReq) Descriptor() ([]byte, []int) {
+	return file_auto_quant_profile_msgs_proto_rawDescGZIP(), []int{0}
+}
+
+type AutoQuantProfileListResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Profiles      []*AutoQuantProfile    `protobuf:"bytes,1,rep,name=profiles,proto3" json:"profiles,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AutoQuantProfileListResp) Reset() {
+	*x = AutoQuantProfileListResp{}
+	mi := &file_auto_quant_profile_msgs_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AutoQuantProfileListResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AutoQuantProfileListResp) ProtoMessage() {}
+
+func (x *AutoQuantProfileListResp) ProtoReflect() protoreflect.Message {
+	mi := &file_auto_quant_profile_msgs_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AutoQuantProfileListResp.ProtoReflect.Descriptor instead.
+func (*AutoQuantProfileListResp) Descriptor() ([]byte, []int) {
+	return file_auto_quant_profile_msgs_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *AutoQuantProfileListResp) GetProfiles() []*AutoQuantProfile {
+	if x != nil {
+		return x.Profiles
+	}
+	return nil
+}
+
+// Creates a profile if id is blank, otherwise overwrites it
+// requires(EDIT_PIQUANT_SETTINGS)
+type AutoQuantProfileWriteReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Profile       *AutoQuantProfile      `protobuf:"bytes,1,opt,name=profile,proto3" json:"profile,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AutoQuantProfileWriteReq) Reset() {
+	*x = AutoQuantProfileWriteReq{}
+	mi := &file_auto_quant_profile_msgs_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AutoQuantProfileWriteReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AutoQuantProfileWriteReq) ProtoMessage() {}
+
+func (x *AutoQuantProfileWriteReq) ProtoReflect() protoreflect.Message {
+	mi := &file_auto_quant_profile_msgs_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AutoQuantProfileWriteReq.ProtoReflect.Descriptor instead.
+func (*AutoQuantProfileWriteReq) Descriptor() ([]byte, []int) {
+	return file_auto_quant_profile_msgs_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *AutoQuantProfileWriteReq) GetProfile() *AutoQuantProfile {
+	if x != nil {
+		return x.Profile
+	}
+	return nil
+}
+
+type AutoQuantProfileWriteResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Profile       *AutoQuantProfile      `protobuf:"bytes,1,opt,name=profile,proto3" json:"profile,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AutoQuantProfileWriteResp) Reset() {
+	*x = AutoQuantProfileWriteResp{}
+	mi := &file_auto_quant_profile_msgs_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AutoQuantProfileWriteResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AutoQuantProfileWriteResp) ProtoMessage() {}
+
+func (x *AutoQuantProfileWriteResp) ProtoReflect() protoreflect.Message {
+	mi := &file_auto_quant_profile_msgs_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AutoQuantProfileWriteResp.ProtoReflect.Descriptor instead.
+func (*AutoQuantProfileWriteResp) Descriptor() ([]byte, []int) {
+	return file_auto_quant_profile_msgs_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *AutoQuantProfileWriteResp) GetProfile() *AutoQuantProfile {
+	if x != nil {
+		return x.Profile
+	}
+	return nil
+}
+
+// requires(EDIT_PIQUANT_SETTINGS)
+type AutoQuantProfileDeleteReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AutoQuantProfileDeleteReq) Reset() {
+	*x = AutoQuantProfileDeleteReq{}
+	mi := &file_auto_quant_profile_msgs_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AutoQuantProfileDeleteReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AutoQuantProfileDeleteReq) ProtoMessage() {}
+
+func (x *AutoQuantProfileDeleteReq) ProtoReflect() protoreflect.Message {
+	mi := &file_auto_quant_profile_msgs_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AutoQuantProfileDeleteReq.ProtoReflect.Descriptor instead.
+func (*AutoQuantProfileDeleteReq) Descriptor() ([]byte, []int) {
+	return file_auto_quant_profile_msgs_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *AutoQuantProfileDeleteReq) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type AutoQuantProfileDeleteResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AutoQuantProfileDeleteResp) Reset() {
+	*x = AutoQuantProfileDeleteResp{}
+	mi := &file_auto_quant_profile_msgs_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AutoQuantProfileDeleteResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AutoQuantProfileDeleteResp) ProtoMessage() {}
+
+func (x *AutoQuantProfileDeleteResp) ProtoReflect() protoreflect.Message {
+	mi := &file_auto_quant_profile_msgs_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AutoQuantProfileDeleteResp.ProtoReflect.Descriptor instead.
+func (*AutoQuantProfileDeleteResp) Descriptor() ([]byte, []int) {
+	return file_auto_quant_profile_msgs_proto_rawDescGZIP(), []int{5}
+}
+
+var File_auto_quant_profile_msgs_proto protoreflect.FileDescriptor
+
+const file_auto_quant_profile_msgs_proto_rawDesc = "" +
+	"\n" +
+	"\x1dauto-quant-profile-msgs.proto\x1a\x18auto-quant-profile.proto\"\x19\n" +
+	"\x17AutoQuantProfileListReq\"I\n" +
+	"\x18AutoQuantProfileListResp\x12-\n" +
+	"\bprofiles\x18\x01 \x03(\v2\x11.AutoQuantProfileR\bprofiles\"G\n" +
+	"\x18AutoQuantProfileWriteReq\x12+\n" +
+	"\aprofile\x18\x01 \x01(\v2\x11.AutoQuantProfileR\aprofile\"H\n" +
+	"\x19AutoQuantProfileWriteResp\x12+\n" +
+	"\aprofile\x18\x01 \x01(\v2\x11.AutoQuantProfileR\aprofile\"+\n" +
+	"\x19AutoQuantProfileDeleteReq\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x1c\n" +
+	"\x1aAutoQuantProfileDeleteRespB\n" +
+	"Z\b.;protosb\x06proto3"
+
+var (
+	file_auto_quant_profile_msgs_proto_rawDescOnce sync.Once
+	file_auto_quant_profile_msgs_proto_rawDescData []byte
+)
+
+func file_auto_quant_profile_msgs_proto_rawDescGZIP() []byte {
+	file_auto_quant_profile_msgs_proto_rawDescOnce.Do(func() {
+		file_auto_quant_profile_msgs_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_auto_quant_profile_msgs_proto_rawDesc), len(file_auto_quant_profile_msgs_proto_rawDesc)))
+	})
+	return file_auto_quant_profile_msgs_proto_rawDescData
+}
+
+var file_auto_quant_profile_msgs_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_auto_quant_profile_msgs_proto_goTypes = []any{
+	(*AutoQuantProfileListReq)(nil),    // 0: AutoQuantProfileListReq
+	(*AutoQuantProfileListResp)(nil),   // 1: AutoQuantProfileListResp
+	(*AutoQuantProfileWriteReq)(nil),   // 2: AutoQuantProfileWriteReq
+	(*AutoQuantProfileWriteResp)(nil),  // 3: AutoQuantProfileWriteResp
+	(*AutoQuantProfileDeleteReq)(nil),  // 4: AutoQuantProfileDeleteReq
+	(*AutoQuantProfileDeleteResp)(nil), // 5: AutoQuantProfileDeleteResp
+	(*AutoQuantProfile)(nil),           // 6: AutoQuantProfile
+}
+var file_auto_quant_profile_msgs_proto_depIdxs = []int32{
+	6, // 0: AutoQuantProfileListResp.profiles:type_name -> AutoQuantProfile
+	6, // 1: AutoQuantProfileWriteReq.profile:type_name -> AutoQuantProfile
+	6, // 2: AutoQuantProfileWriteResp.profile:type_name -> AutoQuantProfile
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_auto_quant_profile_msgs_proto_init() }
+func file_auto_quant_profile_msgs_proto_init() {
+	if File_auto_quant_profile_msgs_proto != nil {
+		return
+	}
+	file_auto_quant_profile_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_auto_quant_profile_msgs_proto_rawDesc), len(file_auto_quant_profile_msgs_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_auto_quant_profile_msgs_proto_goTypes,
+		DependencyIndexes: file_auto_quant_profile_msgs_proto_depIdxs,
+		MessageInfos:      file_auto_quant_profile_msgs_proto_msgTypes,
+	}.Build()
+	File_auto_quant_profile_msgs_proto = out.File
+	file_auto_quant_profile_msgs_proto_goTypes = nil
+	file_auto_quant_profile_msgs_proto_depIdxs = nil
+}
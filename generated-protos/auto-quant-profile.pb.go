@@ -0,0 +1,255 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v3.21.12
+// source: auto-quant-profile.proto
+
+package protos
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Defines a set of quantifications that run automatically when a scan is imported, if the scan matches
+type AutoQuantProfile struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty" bson:"_id,omitempty"`  
+	// Quant names are made from this and the quant mode, eg "AutoQuant-PIXL (AB)"
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Elements only, PIQUANT determines the oxide states to write
+	Elements []string `protobuf:"bytes,3,rep,name=elements,proto3" json:"elements,omitempty"`
+	// Detector config and the PIQUANT config version to use, eg "PIXL" and "v7"
+	DetectorConfig        string `protobuf:"bytes,4,opt,name=detectorConfig,proto3" json:"detectorConfig,omitempty"`
+	DetectorConfigVersion string `protobuf:"bytes,5,opt,name=detectorConfigVersion,proto3" json:"detectorConfigVersion,omitempty"`
+	// One quant is run per mode, eg "Combined", "AB"
+	QuantModes []string `protobuf:"bytes,6,rep,name=quantModes,proto3" json:"quantModes,omitempty"`
+	Parameters string   `protobuf:"bytes,7,opt,name=parameters,proto3" json:"parameters,omitempty"`
+	RunTimeSec uint32   `protobuf:"varint,8,opt,name=runTimeSec,proto3" json:"runTimeSec,omitempty"`
+	// Which scans this runs for. If no instruments are listed, it matches any instrument. Each scan meta
+	// value must match the corresponding regular expression
+	Instruments   []ScanInstrument  `protobuf:"varint,9,rep,packed,name=instruments,proto3,enum=ScanInstrument" json:"instruments,omitempty"`
+	ScanMetaMatch map[string]string `protobuf:"bytes,10,rep,name=scanMetaMatch,proto3" json:"scanMetaMatch,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Allows a profile to be kept without it running
+	Disabled        bool   `protobuf:"varint,11,opt,name=disabled,proto3" json:"disabled,omitempty"`
+	ModifiedUnixSec uint32 `protobuf:"varint,12,opt,name=modifiedUnixSec,proto3" json:"modifiedUnixSec,omitempty"`
+	ModifierUserId  string `protobuf:"bytes,13,opt,name=modifierUserId,proto3" json:"modifierUserId,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *AutoQuantProfile) Reset() {
+	*x = AutoQuantProfile{}
+	mi := &file_auto_quant_profile_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AutoQuantProfile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AutoQuantProfile) ProtoMessage() {}
+
+func (x *AutoQuantProfile) ProtoReflect() protoreflect.Message {
+	mi := &file_auto_quant_profile_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AutoQuantProfile.ProtoReflect.Descriptor instead.
+func (*AutoQuantProfile) Descriptor() ([]byte, []int) {
+	return file_auto_quant_profile_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *AutoQuantProfile) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AutoQuantProfile) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AutoQuantProfile) GetElements() []string {
+	if x != nil {
+		return x.Elements
+	}
+	return nil
+}
+
+func (x *AutoQuantProfile) GetDetectorConfig() string {
+	if x != nil {
+		return x.DetectorConfig
+	}
+	return ""
+}
+
+func (x *AutoQuantProfile) GetDetectorConfigVersion() string {
+	if x != nil {
+		return x.DetectorConfigVersion
+	}
+	return ""
+}
+
+func (x *AutoQuantProfile) GetQuantModes() []string {
+	if x != nil {
+		return x.QuantModes
+	}
+	return nil
+}
+
+func (x *AutoQuantProfile) GetParameters() string {
+	if x != nil {
+		return x.Parameters
+	}
+	return ""
+}
+
+func (x *AutoQuantProfile) GetRunTimeSec() uint32 {
+	if x != nil {
+		return x.RunTimeSec
+	}
+	return 0
+}
+
+func (x *AutoQuantProfile) GetInstruments() []ScanInstrument {
+	if x != nil {
+		return x.Instruments
+	}
+	return nil
+}
+
+func (x *AutoQuantProfile) GetScanMetaMatch() map[string]string {
+	if x != nil {
+		return x.ScanMetaMatch
+	}
+	return nil
+}
+
+func (x *AutoQuantProfile) GetDisabled() bool {
+	if x != nil {
+		return x.Disabled
+	}
+	return false
+}
+
+func (x *AutoQuantProfile) GetModifiedUnixSec() uint32 {
+	if x != nil {
+		return x.ModifiedUnixSec
+	}
+	return 0
+}
+
+func (x *AutoQuantProfile) GetModifierUserId() string {
+	if x != nil {
+		return x.ModifierUserId
+	}
+	return ""
+}
+
+var File_auto_quant_profile_proto protoreflect.FileDescriptor
+
+const file_auto_quant_profile_proto_rawDesc = "" +
+	"\n" +
+	"\x18auto-quant-profile.proto\x1a\n" +
+	"scan.proto\"\xbf\x04\n" +
+	"\x10AutoQuantProfile\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1a\n" +
+	"\belements\x18\x03 \x03(\tR\belements\x12&\n" +
+	"\x0edetectorConfig\x18\x04 \x01(\tR\x0edetectorConfig\x124\n" +
+	"\x15detectorConfigVersion\x18\x05 \x01(\tR\x15detectorConfigVersion\x12\x1e\n" +
+	"\n" +
+	"quantModes\x18\x06 \x03(\tR\n" +
+	"quantModes\x12\x1e\n" +
+	"\n" +
+	"parameters\x18\a \x01(\tR\n" +
+	"parameters\x12\x1e\n" +
+	"\n" +
+	"runTimeSec\x18\b \x01(\rR\n" +
+	"runTimeSec\x121\n" +
+	"\vinstruments\x18\t \x03(\x0e2\x0f.ScanInstrumentR\vinstruments\x12J\n" +
+	"\rscanMetaMatch\x18\n" +
+	" \x03(\v2$.AutoQuantProfile.ScanMetaMatchEntryR\rscanMetaMatch\x12\x1a\n" +
+	"\bdisabled\x18\v \x01(\bR\bdisabled\x12(\n" +
+	"\x0fmodifiedUnixSec\x18\f \x01(\rR\x0fmodifiedUnixSec\x12&\n" +
+	"\x0emodifierUserId\x18\r \x01(\tR\x0emodifierUserId\x1a@\n" +
+	"\x12ScanMetaMatchEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\n" +
+	"Z\b.;protosb\x06proto3"
+
+var (
+	file_auto_quant_profile_proto_rawDescOnce sync.Once
+	file_auto_quant_profile_proto_rawDescData []byte
+)
+
+func file_auto_quant_profile_proto_rawDescGZIP() []byte {
+	file_auto_quant_profile_proto_rawDescOnce.Do(func() {
+		file_auto_quant_profile_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_auto_quant_profile_proto_rawDesc), len(file_auto_quant_profile_proto_rawDesc)))
+	})
+	return file_auto_quant_profile_proto_rawDescData
+}
+
+var file_auto_quant_profile_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_auto_quant_profile_proto_goTypes = []any{
+	(*AutoQuantProfile)(nil), // 0: AutoQuantProfile
+	nil,                      // 1: AutoQuantProfile.ScanMetaMatchEntry
+	(ScanInstrument)(0),      // 2: ScanInstrument
+}
+var file_auto_quant_profile_proto_depIdxs = []int32{
+	2, // 0: AutoQuantProfile.instruments:type_name -> ScanInstrument
+	1, // 1: AutoQuantProfile.scanMetaMatch:type_name -> AutoQuantProfile.ScanMetaMatchEntry
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_auto_quant_profile_proto_init() }
+func file_auto_quant_profile_proto_init() {
+	if File_auto_quant_profile_proto != nil {
+		return
+	}
+	file_scan_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_auto_quant_profile_proto_rawDesc), len(file_auto_quant_profile_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_auto_quant_profile_proto_goTypes,
+		DependencyIndexes: file_auto_quant_profile_proto_depIdxs,
+		MessageInfos:      file_auto_quant_profile_proto_msgTypes,
+	}.Build()
+	File_auto_quant_profile_proto = out.File
+	file_auto_quant_profile_proto_goTypes = nil
+	file_auto_quant_profile_proto_depIdxs = nil
+}
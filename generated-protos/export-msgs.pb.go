@@ -24,8 +24,14 @@ const (
 type ExportDataType int32
 
 const (
-	ExportDataType_EDT_UNKNOWN   ExportDataType = 0 // https://protobuf.dev/programming-guides/dos-donts/ says specify an unknown as 0
-	ExportDataType_EDT_QUANT_CSV ExportDataType = 1
+	ExportDataType_EDT_UNKNOWN               ExportDataType = 0 // https://protobuf.dev/programming-guides/dos-donts/ says specify an unknown as 0
+	ExportDataType_EDT_QUANT_CSV             ExportDataType = 1
+	ExportDataType_EDT_SCAN_SPECTRA_MSA      ExportDataType = 2
+	ExportDataType_EDT_SCAN_BEAM_LOCATIONS   ExportDataType = 3
+	ExportDataType_EDT_SCAN_HOUSEKEEPING_CSV ExportDataType = 4
+	ExportDataType_EDT_ROI_PMCS              ExportDataType = 5
+	ExportDataType_EDT_DIFFRACTION_PEAKS     ExportDataType = 6
+	ExportDataType_EDT_CONTEXT_IMAGES        ExportDataType = 7
 )
 
 // Enum value maps for ExportDataType.
@@ -33,10 +39,22 @@ var (
 	ExportDataType_name = map[int32]string{
 		0: "EDT_UNKNOWN",
 		1: "EDT_QUANT_CSV",
+		2: "EDT_SCAN_SPECTRA_MSA",
+		3: "EDT_SCAN_BEAM_LOCATIONS",
+		4: "EDT_SCAN_HOUSEKEEPING_CSV",
+		5: "EDT_ROI_PMCS",
+		6: "EDT_DIFFRACTION_PEAKS",
+		7: "EDT_CONTEXT_IMAGES",
 	}
 	ExportDataType_value = map[string]int32{
-		"EDT_UNKNOWN":   0,
-		"EDT_QUANT_CSV": 1,
+		"EDT_UNKNOWN":               0,
+		"EDT_QUANT_CSV":             1,
+		"EDT_SCAN_SPECTRA_MSA":      2,
+		"EDT_SCAN_BEAM_LOCATIONS":   3,
+		"EDT_SCAN_HOUSEKEEPING_CSV": 4,
+		"EDT_ROI_PMCS":              5,
+		"EDT_DIFFRACTION_PEAKS":     6,
+		"EDT_CONTEXT_IMAGES":        7,
 	}
 )
 
@@ -203,10 +221,16 @@ const file_export_msgs_proto_rawDesc = "" +
 	"\x06roiIds\x18\x04 \x03(\tR\x06roiIds\x12&\n" +
 	"\x0eimageFileNames\x18\x05 \x03(\tR\x0eimageFileNames\"4\n" +
 	"\x0fExportFilesResp\x12!\n" +
-	"\x05files\x18\x01 \x03(\v2\v.ExportFileR\x05files*4\n" +
+	"\x05files\x18\x01 \x03(\v2\v.ExportFileR\x05files*\xcf\x01\n" +
 	"\x0eExportDataType\x12\x0f\n" +
 	"\vEDT_UNKNOWN\x10\x00\x12\x11\n" +
-	"\rEDT_QUANT_CSV\x10\x01B\n" +
+	"\rEDT_QUANT_CSV\x10\x01\x12\x18\n" +
+	"\x14EDT_SCAN_SPECTRA_MSA\x10\x02\x12\x1b\n" +
+	"\x17EDT_SCAN_BEAM_LOCATIONS\x10\x03\x12\x1d\n" +
+	"\x19EDT_SCAN_HOUSEKEEPING_CSV\x10\x04\x12\x10\n" +
+	"\fEDT_ROI_PMCS\x10\x05\x12\x19\n" +
+	"\x15EDT_DIFFRACTION_PEAKS\x10\x06\x12\x16\n" +
+	"\x12EDT_CONTEXT_IMAGES\x10\aB\n" +
 	"Z\b.;protosb\x06proto3"
 
 var (
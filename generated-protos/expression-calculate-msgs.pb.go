@@ -110,15 +110,109 @@ func (x *ExpressionCalculateResp) GetResult() *RegionDataResults {
 	return nil
 }
 
+// requires(NONE)
+type ExpressionBatchCalculateReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Requests      []*DataSourceParams    `protobuf:"bytes,1,rep,name=requests,proto3" json:"requests,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExpressionBatchCalculateReq) Reset() {
+	*x = ExpressionBatchCalculateReq{}
+	mi := &file_expression_calculate_msgs_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExpressionBatchCalculateReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExpressionBatchCalculateReq) ProtoMessage() {}
+
+func (x *ExpressionBatchCalculateReq) ProtoReflect() protoreflect.Message {
+	mi := &file_expression_calculate_msgs_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExpressionBatchCalculateReq.ProtoReflect.Descriptor instead.
+func (*ExpressionBatchCalculateReq) Descriptor() ([]byte, []int) {
+	return file_expression_calculate_msgs_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ExpressionBatchCalculateReq) GetRequests() []*DataSourceParams {
+	if x != nil {
+		return x.Requests
+	}
+	return nil
+}
+
+type ExpressionBatchCalculateResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        *JobStatus             `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExpressionBatchCalculateResp) Reset() {
+	*x = ExpressionBatchCalculateResp{}
+	mi := &file_expression_calculate_msgs_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExpressionBatchCalculateResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExpressionBatchCalculateResp) ProtoMessage() {}
+
+func (x *ExpressionBatchCalculateResp) ProtoReflect() protoreflect.Message {
+	mi := &file_expression_calculate_msgs_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExpressionBatchCalculateResp.ProtoReflect.Descriptor instead.
+func (*ExpressionBatchCalculateResp) Descriptor() ([]byte, []int) {
+	return file_expression_calculate_msgs_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ExpressionBatchCalculateResp) GetStatus() *JobStatus {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
 var File_expression_calculate_msgs_proto protoreflect.FileDescriptor
 
 const file_expression_calculate_msgs_proto_rawDesc = "" +
 	"\n" +
-	"\x1fexpression-calculate-msgs.proto\x1a\x1aexpression-calculate.proto\"G\n" +
+	"\x1fexpression-calculate-msgs.proto\x1a\x1aexpression-calculate.proto\x1a\tjob.proto\"G\n" +
 	"\x16ExpressionCalculateReq\x12-\n" +
 	"\brequests\x18\x01 \x03(\v2\x11.DataSourceParamsR\brequests\"E\n" +
 	"\x17ExpressionCalculateResp\x12*\n" +
-	"\x06result\x18\x01 \x01(\v2\x12.RegionDataResultsR\x06resultB\n" +
+	"\x06result\x18\x01 \x01(\v2\x12.RegionDataResultsR\x06result\"L\n" +
+	"\x1bExpressionBatchCalculateReq\x12-\n" +
+	"\brequests\x18\x01 \x03(\v2\x11.DataSourceParamsR\brequests\"B\n" +
+	"\x1cExpressionBatchCalculateResp\x12\"\n" +
+	"\x06status\x18\x01 \x01(\v2\n" +
+	".JobStatusR\x06statusB\n" +
 	"Z\b.;protosb\x06proto3"
 
 var (
@@ -133,21 +227,26 @@ func file_expression_calculate_msgs_proto_rawDescGZIP() []byte {
 	return file_expression_calculate_msgs_proto_rawDescData
 }
 
-var file_expression_calculate_msgs_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_expression_calculate_msgs_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
 var file_expression_calculate_msgs_proto_goTypes = []any{
-	(*ExpressionCalculateReq)(nil),  // 0: ExpressionCalculateReq
-	(*ExpressionCalculateResp)(nil), // 1: ExpressionCalculateResp
-	(*DataSourceParams)(nil),        // 2: DataSourceParams
-	(*RegionDataResults)(nil),       // 3: RegionDataResults
+	(*ExpressionCalculateReq)(nil),       // 0: ExpressionCalculateReq
+	(*ExpressionCalculateResp)(nil),      // 1: ExpressionCalculateResp
+	(*ExpressionBatchCalculateReq)(nil),  // 2: ExpressionBatchCalculateReq
+	(*ExpressionBatchCalculateResp)(nil), // 3: ExpressionBatchCalculateResp
+	(*DataSourceParams)(nil),             // 4: DataSourceParams
+	(*RegionDataResults)(nil),            // 5: RegionDataResults
+	(*JobStatus)(nil),                    // 6: JobStatus
 }
 var file_expression_calculate_msgs_proto_depIdxs = []int32{
-	2, // 0: ExpressionCalculateReq.requests:type_name -> DataSourceParams
-	3, // 1: ExpressionCalculateResp.result:type_name -> RegionDataResults
-	2, // [2:2] is the sub-list for method output_type
-	2, // [2:2] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	4, // 0: ExpressionCalculateReq.requests:type_name -> DataSourceParams
+	5, // 1: ExpressionCalculateResp.result:type_name -> RegionDataResults
+	4, // 2: ExpressionBatchCalculateReq.requests:type_name -> DataSourceParams
+	6, // 3: ExpressionBatchCalculateResp.status:type_name -> JobStatus
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
 }
 
 func init() { file_expression_calculate_msgs_proto_init() }
@@ -156,13 +255,14 @@ func file_expression_calculate_msgs_proto_init() {
 		return
 	}
 	file_expression_calculate_proto_init()
+	file_job_proto_init()
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_expression_calculate_msgs_proto_rawDesc), len(file_expression_calculate_msgs_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   4,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -70,6 +70,61 @@ func (DataUnit) EnumDescriptor() ([]byte, []int) {
 	return file_expression_calculate_proto_rawDescGZIP(), []int{0}
 }
 
+type ExpressionLimit int32
+
+const (
+	ExpressionLimit_EL_NONE         ExpressionLimit = 0 // https://protobuf.dev/programming-guides/dos-donts/ says specify an unknown as 0
+	ExpressionLimit_EL_RUN_TIME     ExpressionLimit = 1
+	ExpressionLimit_EL_INSTRUCTIONS ExpressionLimit = 2
+	ExpressionLimit_EL_MAP_SIZE     ExpressionLimit = 3
+	ExpressionLimit_EL_CACHE_SIZE   ExpressionLimit = 4
+)
+
+// Enum value maps for ExpressionLimit.
+var (
+	ExpressionLimit_name = map[int32]string{
+		0: "EL_NONE",
+		1: "EL_RUN_TIME",
+		2: "EL_INSTRUCTIONS",
+		3: "EL_MAP_SIZE",
+		4: "EL_CACHE_SIZE",
+	}
+	ExpressionLimit_value = map[string]int32{
+		"EL_NONE":         0,
+		"EL_RUN_TIME":     1,
+		"EL_INSTRUCTIONS": 2,
+		"EL_MAP_SIZE":     3,
+		"EL_CACHE_SIZE":   4,
+	}
+)
+
+func (x ExpressionLimit) Enum() *ExpressionLimit {
+	p := new(ExpressionLimit)
+	*p = x
+	return p
+}
+
+func (x ExpressionLimit) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ExpressionLimit) Descriptor() protoreflect.EnumDescriptor {
+	return file_expression_calculate_proto_enumTypes[1].Descriptor()
+}
+
+func (ExpressionLimit) Type() protoreflect.EnumType {
+	return &file_expression_calculate_proto_enumTypes[1]
+}
+
+func (x ExpressionLimit) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ExpressionLimit.Descriptor instead.
+func (ExpressionLimit) EnumDescriptor() ([]byte, []int) {
+	return file_expression_calculate_proto_rawDescGZIP(), []int{1}
+}
+
 // Requesting a single expression to be run
 type DataSourceParams struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -209,8 +264,10 @@ type RegionDataResultItem struct {
 	Warning    string                 `protobuf:"bytes,3,opt,name=warning,proto3" json:"warning,omitempty"`
 	Expression *DataExpression        `protobuf:"bytes,4,opt,name=expression,proto3" json:"expression,omitempty"` // NOTE: Is this redundant??? There's also the same field in DataQueryResult
 	// RegionSettings region = 5; // NOTE: Is this redundant??? There's also the same field in DataQueryResult
-	Query         *DataSourceParams `protobuf:"bytes,6,opt,name=query,proto3" json:"query,omitempty"`
-	IsPMCTable    bool              `protobuf:"varint,7,opt,name=isPMCTable,proto3" json:"isPMCTable,omitempty"` // NOTE: Is this redundant??? There's also the same field in DataQueryResult
+	Query      *DataSourceParams `protobuf:"bytes,6,opt,name=query,proto3" json:"query,omitempty"`
+	IsPMCTable bool              `protobuf:"varint,7,opt,name=isPMCTable,proto3" json:"isPMCTable,omitempty"` // NOTE: Is this redundant??? There's also the same field in DataQueryResult
+	// If the expression was stopped because it went over one of the limits we run expressions with, this says which
+	LimitExceeded ExpressionLimit `protobuf:"varint,8,opt,name=limitExceeded,proto3,enum=ExpressionLimit" json:"limitExceeded,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -287,6 +344,13 @@ func (x *RegionDataResultItem) GetIsPMCTable() bool {
 	return false
 }
 
+func (x *RegionDataResultItem) GetLimitExceeded() ExpressionLimit {
+	if x != nil {
+		return x.LimitExceeded
+	}
+	return ExpressionLimit_EL_NONE
+}
+
 var File_expression_calculate_proto protoreflect.FileDescriptor
 
 const file_expression_calculate_proto_rawDesc = "" +
@@ -300,7 +364,7 @@ const file_expression_calculate_proto_rawDesc = "" +
 	"\x05units\x18\x05 \x01(\x0e2\t.DataUnitR\x05units\"d\n" +
 	"\x11RegionDataResults\x129\n" +
 	"\fqueryResults\x18\x01 \x03(\v2\x15.RegionDataResultItemR\fqueryResults\x12\x14\n" +
-	"\x05error\x18\x02 \x01(\tR\x05error\"\xf5\x01\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"\xad\x02\n" +
 	"\x14RegionDataResultItem\x123\n" +
 	"\n" +
 	"exprResult\x18\x01 \x01(\v2\x13.MemDataQueryResultR\n" +
@@ -313,11 +377,18 @@ const file_expression_calculate_proto_rawDesc = "" +
 	"\x05query\x18\x06 \x01(\v2\x11.DataSourceParamsR\x05query\x12\x1e\n" +
 	"\n" +
 	"isPMCTable\x18\a \x01(\bR\n" +
-	"isPMCTable*9\n" +
+	"isPMCTable\x126\n" +
+	"\rlimitExceeded\x18\b \x01(\x0e2\x10.ExpressionLimitR\rlimitExceeded*9\n" +
 	"\bDataUnit\x12\x10\n" +
 	"\fUNIT_DEFAULT\x10\x00\x12\r\n" +
 	"\tUNIT_MMOL\x10\x01\x12\f\n" +
-	"\bUNIT_PPM\x10\x02B\n" +
+	"\bUNIT_PPM\x10\x02*h\n" +
+	"\x0fExpressionLimit\x12\v\n" +
+	"\aEL_NONE\x10\x00\x12\x0f\n" +
+	"\vEL_RUN_TIME\x10\x01\x12\x13\n" +
+	"\x0fEL_INSTRUCTIONS\x10\x02\x12\x0f\n" +
+	"\vEL_MAP_SIZE\x10\x03\x12\x11\n" +
+	"\rEL_CACHE_SIZE\x10\x04B\n" +
 	"Z\b.;protosb\x06proto3"
 
 var (
@@ -332,27 +403,29 @@ func file_expression_calculate_proto_rawDescGZIP() []byte {
 	return file_expression_calculate_proto_rawDescData
 }
 
-var file_expression_calculate_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_expression_calculate_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
 var file_expression_calculate_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
 var file_expression_calculate_proto_goTypes = []any{
 	(DataUnit)(0),                // 0: DataUnit
-	(*DataSourceParams)(nil),     // 1: DataSourceParams
-	(*RegionDataResults)(nil),    // 2: RegionDataResults
-	(*RegionDataResultItem)(nil), // 3: RegionDataResultItem
-	(*MemDataQueryResult)(nil),   // 4: MemDataQueryResult
-	(*DataExpression)(nil),       // 5: DataExpression
+	(ExpressionLimit)(0),         // 1: ExpressionLimit
+	(*DataSourceParams)(nil),     // 2: DataSourceParams
+	(*RegionDataResults)(nil),    // 3: RegionDataResults
+	(*RegionDataResultItem)(nil), // 4: RegionDataResultItem
+	(*MemDataQueryResult)(nil),   // 5: MemDataQueryResult
+	(*DataExpression)(nil),       // 6: DataExpression
 }
 var file_expression_calculate_proto_depIdxs = []int32{
 	0, // 0: DataSourceParams.units:type_name -> DataUnit
-	3, // 1: RegionDataResults.queryResults:type_name -> RegionDataResultItem
-	4, // 2: RegionDataResultItem.exprResult:type_name -> MemDataQueryResult
-	5, // 3: RegionDataResultItem.expression:type_name -> DataExpression
-	1, // 4: RegionDataResultItem.query:type_name -> DataSourceParams
-	5, // [5:5] is the sub-list for method output_type
-	5, // [5:5] is the sub-list for method input_type
-	5, // [5:5] is the sub-list for extension type_name
-	5, // [5:5] is the sub-list for extension extendee
-	0, // [0:5] is the sub-list for field type_name
+	4, // 1: RegionDataResults.queryResults:type_name -> RegionDataResultItem
+	5, // 2: RegionDataResultItem.exprResult:type_name -> MemDataQueryResult
+	6, // 3: RegionDataResultItem.expression:type_name -> DataExpression
+	2, // 4: RegionDataResultItem.query:type_name -> DataSourceParams
+	1, // 5: RegionDataResultItem.limitExceeded:type_name -> ExpressionLimit
+	6, // [6:6] is the sub-list for method output_type
+	6, // [6:6] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
 }
 
 func init() { file_expression_calculate_proto_init() }
@@ -367,7 +440,7 @@ func file_expression_calculate_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_expression_calculate_proto_rawDesc), len(file_expression_calculate_proto_rawDesc)),
-			NumEnums:      1,
+			NumEnums:      2,
 			NumMessages:   3,
 			NumExtensions: 0,
 			NumServices:   0,
@@ -0,0 +1,775 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v3.21.12
+// source: expression-debug-msgs.proto
+
+package protos
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ExpressionDebugState int32
+
+const (
+	// https://protobuf.dev/programming-guides/dos-donts/ says specify an unknown as 0
+	ExpressionDebugState_EDS_UNKNOWN  ExpressionDebugState = 0
+	ExpressionDebugState_EDS_PAUSED   ExpressionDebugState = 1
+	ExpressionDebugState_EDS_FINISHED ExpressionDebugState = 2
+	ExpressionDebugState_EDS_ERROR    ExpressionDebugState = 3
+	ExpressionDebugState_EDS_STOPPED  ExpressionDebugState = 4
+)
+
+// Enum value maps for ExpressionDebugState.
+var (
+	ExpressionDebugState_name = map[int32]string{
+		0: "EDS_UNKNOWN",
+		1: "EDS_PAUSED",
+		2: "EDS_FINISHED",
+		3: "EDS_ERROR",
+		4: "EDS_STOPPED",
+	}
+	ExpressionDebugState_value = map[string]int32{
+		"EDS_UNKNOWN":  0,
+		"EDS_PAUSED":   1,
+		"EDS_FINISHED": 2,
+		"EDS_ERROR":    3,
+		"EDS_STOPPED":  4,
+	}
+)
+
+func (x ExpressionDebugState) Enum() *ExpressionDebugState {
+	p := new(ExpressionDebugState)
+	*p = x
+	return p
+}
+
+func (x ExpressionDebugState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ExpressionDebugState) Descriptor() protoreflect.EnumDescriptor {
+	return file_expression_debug_msgs_proto_enumTypes[0].Descriptor()
+}
+
+func (ExpressionDebugState) Type() protoreflect.EnumType {
+	return &file_expression_debug_msgs_proto_enumTypes[0]
+}
+
+func (x ExpressionDebugState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ExpressionDebugState.Descriptor instead.
+func (ExpressionDebugState) EnumDescriptor() ([]byte, []int) {
+	return file_expression_debug_msgs_proto_rawDescGZIP(), []int{0}
+}
+
+type ExpressionDebugCommand int32
+
+const (
+	// https://protobuf.dev/programming-guides/dos-donts/ says specify an unknown as 0
+	ExpressionDebugCommand_EDC_NONE      ExpressionDebugCommand = 0
+	ExpressionDebugCommand_EDC_CONTINUE  ExpressionDebugCommand = 1
+	ExpressionDebugCommand_EDC_STEP_IN   ExpressionDebugCommand = 2
+	ExpressionDebugCommand_EDC_STEP_OVER ExpressionDebugCommand = 3
+	ExpressionDebugCommand_EDC_STEP_OUT  ExpressionDebugCommand = 4
+	ExpressionDebugCommand_EDC_STOP      ExpressionDebugCommand = 5
+)
+
+// Enum value maps for ExpressionDebugCommand.
+var (
+	ExpressionDebugCommand_name = map[int32]string{
+		0: "EDC_NONE",
+		1: "EDC_CONTINUE",
+		2: "EDC_STEP_IN",
+		3: "EDC_STEP_OVER",
+		4: "EDC_STEP_OUT",
+		5: "EDC_STOP",
+	}
+	ExpressionDebugCommand_value = map[string]int32{
+		"EDC_NONE":      0,
+		"EDC_CONTINUE":  1,
+		"EDC_STEP_IN":   2,
+		"EDC_STEP_OVER": 3,
+		"EDC_STEP_OUT":  4,
+		"EDC_STOP":      5,
+	}
+)
+
+func (x ExpressionDebugCommand) Enum() *ExpressionDebugCommand {
+	p := new(ExpressionDebugCommand)
+	*p = x
+	return p
+}
+
+func (x ExpressionDebugCommand) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ExpressionDebugCommand) Descriptor() protoreflect.EnumDescriptor {
+	return file_expression_debug_msgs_proto_enumTypes[1].Descriptor()
+}
+
+func (ExpressionDebugCommand) Type() protoreflect.EnumType {
+	return &file_expression_debug_msgs_proto_enumTypes[1]
+}
+
+func (x ExpressionDebugCommand) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ExpressionDebugCommand.Descriptor instead.
+func (ExpressionDebugCommand) EnumDescriptor() ([]byte, []int) {
+	return file_expression_debug_msgs_proto_rawDescGZIP(), []int{1}
+}
+
+// A position in an expression or one of the modules it references
+type ExpressionDebugLocation struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Blank for the expression itself, otherwise the module the line is in
+	ModuleId string `protobuf:"bytes,1,opt,name=moduleId,proto3" json:"moduleId,omitempty"`
+	Line     uint32 `protobuf:"varint,2,opt,name=line,proto3" json:"line,omitempty"`
+	// Only set for stack frames
+	FunctionName  string `protobuf:"bytes,3,opt,name=functionName,proto3" json:"functionName,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExpressionDebugLocation) Reset() {
+	*x = ExpressionDebugLocation{}
+	mi := &file_expression_debug_msgs_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExpressionDebugLocation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExpressionDebugLocation) ProtoMessage() {}
+
+func (x *ExpressionDebugLocation) ProtoReflect() protoreflect.Message {
+	mi := &file_expression_debug_msgs_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExpressionDebugLocation.ProtoReflect.Descriptor instead.
+func (*ExpressionDebugLocation) Descriptor() ([]byte, []int) {
+	return file_expression_debug_msgs_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ExpressionDebugLocation) GetModuleId() string {
+	if x != nil {
+		return x.ModuleId
+	}
+	return ""
+}
+
+func (x *ExpressionDebugLocation) GetLine() uint32 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
+
+func (x *ExpressionDebugLocation) GetFunctionName() string {
+	if x != nil {
+		return x.FunctionName
+	}
+	return ""
+}
+
+type ExpressionDebugVariable struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Tables are written as JSON
+	Value         string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExpressionDebugVariable) Reset() {
+	*x = ExpressionDebugVariable{}
+	mi := &file_expression_debug_msgs_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExpressionDebugVariable) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExpressionDebugVariable) ProtoMessage() {}
+
+func (x *ExpressionDebugVariable) ProtoReflect() protoreflect.Message {
+	mi := &file_expression_debug_msgs_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExpressionDebugVariable.ProtoReflect.Descriptor instead.
+func (*ExpressionDebugVariable) Descriptor() ([]byte, []int) {
+	return file_expression_debug_msgs_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ExpressionDebugVariable) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ExpressionDebugVariable) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+// A call to one of the runtime functions we provide to Lua, eg element() or housekeeping()
+type ExpressionDebugTraceItem struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Function   string                 `protobuf:"bytes,1,opt,name=function,proto3" json:"function,omitempty"`
+	Args       []string               `protobuf:"bytes,2,rep,name=args,proto3" json:"args,omitempty"`
+	DurationMs float32                `protobuf:"fixed32,3,opt,name=durationMs,proto3" json:"durationMs,omitempty"`
+	// Not set if called from a built-in module
+	CalledFrom    *ExpressionDebugLocation `protobuf:"bytes,4,opt,name=calledFrom,proto3" json:"calledFrom,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExpressionDebugTraceItem) Reset() {
+	*x = ExpressionDebugTraceItem{}
+	mi := &file_expression_debug_msgs_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExpressionDebugTraceItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExpressionDebugTraceItem) ProtoMessage() {}
+
+func (x *ExpressionDebugTraceItem) ProtoReflect() protoreflect.Message {
+	mi := &file_expression_debug_msgs_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExpressionDebugTraceItem.ProtoReflect.Descriptor instead.
+func (*ExpressionDebugTraceItem) Descriptor() ([]byte, []int) {
+	return file_expression_debug_msgs_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ExpressionDebugTraceItem) GetFunction() string {
+	if x != nil {
+		return x.Function
+	}
+	return ""
+}
+
+func (x *ExpressionDebugTraceItem) GetArgs() []string {
+	if x != nil {
+		return x.Args
+	}
+	return nil
+}
+
+func (x *ExpressionDebugTraceItem) GetDurationMs() float32 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+func (x *ExpressionDebugTraceItem) GetCalledFrom() *ExpressionDebugLocation {
+	if x != nil {
+		return x.CalledFrom
+	}
+	return nil
+}
+
+// requires(EDIT_EXPRESSION)
+type ExpressionDebugStartReq struct {
+	state        protoimpl.MessageState     `protogen:"open.v1"`
+	ExpressionId string                     `protobuf:"bytes,1,opt,name=expressionId,proto3" json:"expressionId,omitempty"`
+	ScanId       string                     `protobuf:"bytes,2,opt,name=scanId,proto3" json:"scanId,omitempty"`
+	QuantId      string                     `protobuf:"bytes,3,opt,name=quantId,proto3" json:"quantId,omitempty"`
+	Breakpoints  []*ExpressionDebugLocation `protobuf:"bytes,4,rep,name=breakpoints,proto3" json:"breakpoints,omitempty"`
+	// Pause on the first line of module or expression code, instead of running until a breakpoint
+	PauseOnStart  bool `protobuf:"varint,5,opt,name=pauseOnStart,proto3" json:"pauseOnStart,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExpressionDebugStartReq) Reset() {
+	*x = ExpressionDebugStartReq{}
+	mi := &file_expression_debug_msgs_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExpressionDebugStartReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExpressionDebugStartReq) ProtoMessage() {}
+
+func (x *ExpressionDebugStartReq) ProtoReflect() protoreflect.Message {
+	mi := &file_expression_debug_msgs_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExpressionDebugStartReq.ProtoReflect.Descriptor instead.
+func (*ExpressionDebugStartReq) Descriptor() ([]byte, []int) {
+	return file_expression_debug_msgs_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ExpressionDebugStartReq) GetExpressionId() string {
+	if x != nil {
+		return x.ExpressionId
+	}
+	return ""
+}
+
+func (x *ExpressionDebugStartReq) GetScanId() string {
+	if x != nil {
+		return x.ScanId
+	}
+	return ""
+}
+
+func (x *ExpressionDebugStartReq) GetQuantId() string {
+	if x != nil {
+		return x.QuantId
+	}
+	return ""
+}
+
+func (x *ExpressionDebugStartReq) GetBreakpoints() []*ExpressionDebugLocation {
+	if x != nil {
+		return x.Breakpoints
+	}
+	return nil
+}
+
+func (x *ExpressionDebugStartReq) GetPauseOnStart() bool {
+	if x != nil {
+		return x.PauseOnStart
+	}
+	return false
+}
+
+type ExpressionDebugStartResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SessionId     string                 `protobuf:"bytes,1,opt,name=sessionId,proto3" json:"sessionId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExpressionDebugStartResp) Reset() {
+	*x = ExpressionDebugStartResp{}
+	mi := &file_expression_debug_msgs_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExpressionDebugStartResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExpressionDebugStartResp) ProtoMessage() {}
+
+func (x *ExpressionDebugStartResp) ProtoReflect() protoreflect.Message {
+	mi := &file_expression_debug_msgs_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExpressionDebugStartResp.ProtoReflect.Descriptor instead.
+func (*ExpressionDebugStartResp) Descriptor() ([]byte, []int) {
+	return file_expression_debug_msgs_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ExpressionDebugStartResp) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+// requires(EDIT_EXPRESSION)
+type ExpressionDebugCommandReq struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	SessionId string                 `protobuf:"bytes,1,opt,name=sessionId,proto3" json:"sessionId,omitempty"`
+	// Stepping or continuing is only allowed while paused, stopping can be done any time. Can be EDC_NONE if
+	// just changing breakpoints
+	Command ExpressionDebugCommand `protobuf:"varint,2,opt,name=command,proto3,enum=ExpressionDebugCommand" json:"command,omitempty"`
+	// If set, breakpoints are replaced with the list given
+	SetBreakpoints bool                       `protobuf:"varint,3,opt,name=setBreakpoints,proto3" json:"setBreakpoints,omitempty"`
+	Breakpoints    []*ExpressionDebugLocation `protobuf:"bytes,4,rep,name=breakpoints,proto3" json:"breakpoints,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ExpressionDebugCommandReq) Reset() {
+	*x = ExpressionDebugCommandReq{}
+	mi := &file_expression_debug_msgs_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExpressionDebugCommandReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExpressionDebugCommandReq) ProtoMessage() {}
+
+func (x *ExpressionDebugCommandReq) ProtoReflect() protoreflect.Message {
+	mi := &file_expression_debug_msgs_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExpressionDebugCommandReq.ProtoReflect.Descriptor instead.
+func (*ExpressionDebugCommandReq) Descriptor() ([]byte, []int) {
+	return file_expression_debug_msgs_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ExpressionDebugCommandReq) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *ExpressionDebugCommandReq) GetCommand() ExpressionDebugCommand {
+	if x != nil {
+		return x.Command
+	}
+	return ExpressionDebugCommand_EDC_NONE
+}
+
+func (x *ExpressionDebugCommandReq) GetSetBreakpoints() bool {
+	if x != nil {
+		return x.SetBreakpoints
+	}
+	return false
+}
+
+func (x *ExpressionDebugCommandReq) GetBreakpoints() []*ExpressionDebugLocation {
+	if x != nil {
+		return x.Breakpoints
+	}
+	return nil
+}
+
+type ExpressionDebugCommandResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExpressionDebugCommandResp) Reset() {
+	*x = ExpressionDebugCommandResp{}
+	mi := &file_expression_debug_msgs_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExpressionDebugCommandResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExpressionDebugCommandResp) ProtoMessage() {}
+
+func (x *ExpressionDebugCommandResp) ProtoReflect() protoreflect.Message {
+	mi := &file_expression_debug_msgs_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExpressionDebugCommandResp.ProtoReflect.Descriptor instead.
+func (*ExpressionDebugCommandResp) Descriptor() ([]byte, []int) {
+	return file_expression_debug_msgs_proto_rawDescGZIP(), []int{6}
+}
+
+type ExpressionDebugUpd struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	SessionId string                 `protobuf:"bytes,1,opt,name=sessionId,proto3" json:"sessionId,omitempty"`
+	State     ExpressionDebugState   `protobuf:"varint,2,opt,name=state,proto3,enum=ExpressionDebugState" json:"state,omitempty"`
+	// When paused, the call stack (innermost first) and the locals of the innermost function
+	Stack  []*ExpressionDebugLocation `protobuf:"bytes,3,rep,name=stack,proto3" json:"stack,omitempty"`
+	Locals []*ExpressionDebugVariable `protobuf:"bytes,4,rep,name=locals,proto3" json:"locals,omitempty"`
+	// Runtime function calls made since the last update
+	Trace []*ExpressionDebugTraceItem `protobuf:"bytes,5,rep,name=trace,proto3" json:"trace,omitempty"`
+	// How many trace items weren't sent because there were too many
+	TraceItemsDropped uint32 `protobuf:"varint,6,opt,name=traceItemsDropped,proto3" json:"traceItemsDropped,omitempty"`
+	// Set if state is EDS_ERROR
+	Error         string `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExpressionDebugUpd) Reset() {
+	*x = ExpressionDebugUpd{}
+	mi := &file_expression_debug_msgs_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExpressionDebugUpd) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExpressionDebugUpd) ProtoMessage() {}
+
+func (x *ExpressionDebugUpd) ProtoReflect() protoreflect.Message {
+	mi := &file_expression_debug_msgs_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExpressionDebugUpd.ProtoReflect.Descriptor instead.
+func (*ExpressionDebugUpd) Descriptor() ([]byte, []int) {
+	return file_expression_debug_msgs_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ExpressionDebugUpd) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *ExpressionDebugUpd) GetState() ExpressionDebugState {
+	if x != nil {
+		return x.State
+	}
+	return ExpressionDebugState_EDS_UNKNOWN
+}
+
+func (x *ExpressionDebugUpd) GetStack() []*ExpressionDebugLocation {
+	if x != nil {
+		return x.Stack
+	}
+	return nil
+}
+
+func (x *ExpressionDebugUpd) GetLocals() []*ExpressionDebugVariable {
+	if x != nil {
+		return x.Locals
+	}
+	return nil
+}
+
+func (x *ExpressionDebugUpd) GetTrace() []*ExpressionDebugTraceItem {
+	if x != nil {
+		return x.Trace
+	}
+	return nil
+}
+
+func (x *ExpressionDebugUpd) GetTraceItemsDropped() uint32 {
+	if x != nil {
+		return x.TraceItemsDropped
+	}
+	return 0
+}
+
+func (x *ExpressionDebugUpd) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+var File_expression_debug_msgs_proto protoreflect.FileDescriptor
+
+const file_expression_debug_msgs_proto_rawDesc = "" +
+	"\n" +
+	"\x1bexpression-debug-msgs.proto\"m\n" +
+	"\x17ExpressionDebugLocation\x12\x1a\n" +
+	"\bmoduleId\x18\x01 \x01(\tR\bmoduleId\x12\x12\n" +
+	"\x04line\x18\x02 \x01(\rR\x04line\x12\"\n" +
+	"\ffunctionName\x18\x03 \x01(\tR\ffunctionName\"C\n" +
+	"\x17ExpressionDebugVariable\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\"\xa4\x01\n" +
+	"\x18ExpressionDebugTraceItem\x12\x1a\n" +
+	"\bfunction\x18\x01 \x01(\tR\bfunction\x12\x12\n" +
+	"\x04args\x18\x02 \x03(\tR\x04args\x12\x1e\n" +
+	"\n" +
+	"durationMs\x18\x03 \x01(\x02R\n" +
+	"durationMs\x128\n" +
+	"\n" +
+	"calledFrom\x18\x04 \x01(\v2\x18.ExpressionDebugLocationR\n" +
+	"calledFrom\"\xcf\x01\n" +
+	"\x17ExpressionDebugStartReq\x12\"\n" +
+	"\fexpressionId\x18\x01 \x01(\tR\fexpressionId\x12\x16\n" +
+	"\x06scanId\x18\x02 \x01(\tR\x06scanId\x12\x18\n" +
+	"\aquantId\x18\x03 \x01(\tR\aquantId\x12:\n" +
+	"\vbreakpoints\x18\x04 \x03(\v2\x18.ExpressionDebugLocationR\vbreakpoints\x12\"\n" +
+	"\fpauseOnStart\x18\x05 \x01(\bR\fpauseOnStart\"8\n" +
+	"\x18ExpressionDebugStartResp\x12\x1c\n" +
+	"\tsessionId\x18\x01 \x01(\tR\tsessionId\"\xd0\x01\n" +
+	"\x19ExpressionDebugCommandReq\x12\x1c\n" +
+	"\tsessionId\x18\x01 \x01(\tR\tsessionId\x121\n" +
+	"\acommand\x18\x02 \x01(\x0e2\x17.ExpressionDebugCommandR\acommand\x12&\n" +
+	"\x0esetBreakpoints\x18\x03 \x01(\bR\x0esetBreakpoints\x12:\n" +
+	"\vbreakpoints\x18\x04 \x03(\v2\x18.ExpressionDebugLocationR\vbreakpoints\"\x1c\n" +
+	"\x1aExpressionDebugCommandResp\"\xb6\x02\n" +
+	"\x12ExpressionDebugUpd\x12\x1c\n" +
+	"\tsessionId\x18\x01 \x01(\tR\tsessionId\x12+\n" +
+	"\x05state\x18\x02 \x01(\x0e2\x15.ExpressionDebugStateR\x05state\x12.\n" +
+	"\x05stack\x18\x03 \x03(\v2\x18.ExpressionDebugLocationR\x05stack\x120\n" +
+	"\x06locals\x18\x04 \x03(\v2\x18.ExpressionDebugVariableR\x06locals\x12/\n" +
+	"\x05trace\x18\x05 \x03(\v2\x19.ExpressionDebugTraceItemR\x05trace\x12,\n" +
+	"\x11traceItemsDropped\x18\x06 \x01(\rR\x11traceItemsDropped\x12\x14\n" +
+	"\x05error\x18\a \x01(\tR\x05error*i\n" +
+	"\x14ExpressionDebugState\x12\x0f\n" +
+	"\vEDS_UNKNOWN\x10\x00\x12\x0e\n" +
+	"\n" +
+	"EDS_PAUSED\x10\x01\x12\x10\n" +
+	"\fEDS_FINISHED\x10\x02\x12\r\n" +
+	"\tEDS_ERROR\x10\x03\x12\x0f\n" +
+	"\vEDS_STOPPED\x10\x04*|\n" +
+	"\x16ExpressionDebugCommand\x12\f\n" +
+	"\bEDC_NONE\x10\x00\x12\x10\n" +
+	"\fEDC_CONTINUE\x10\x01\x12\x0f\n" +
+	"\vEDC_STEP_IN\x10\x02\x12\x11\n" +
+	"\rEDC_STEP_OVER\x10\x03\x12\x10\n" +
+	"\fEDC_STEP_OUT\x10\x04\x12\f\n" +
+	"\bEDC_STOP\x10\x05B\n" +
+	"Z\b.;protosb\x06proto3"
+
+var (
+	file_expression_debug_msgs_proto_rawDescOnce sync.Once
+	file_expression_debug_msgs_proto_rawDescData []byte
+)
+
+func file_expression_debug_msgs_proto_rawDescGZIP() []byte {
+	file_expression_debug_msgs_proto_rawDescOnce.Do(func() {
+		file_expression_debug_msgs_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_expression_debug_msgs_proto_rawDesc), len(file_expression_debug_msgs_proto_rawDesc)))
+	})
+	return file_expression_debug_msgs_proto_rawDescData
+}
+
+var file_expression_debug_msgs_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_expression_debug_msgs_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_expression_debug_msgs_proto_goTypes = []any{
+	(ExpressionDebugState)(0),          // 0: ExpressionDebugState
+	(ExpressionDebugCommand)(0),        // 1: ExpressionDebugCommand
+	(*ExpressionDebugLocation)(nil),    // 2: ExpressionDebugLocation
+	(*ExpressionDebugVariable)(nil),    // 3: ExpressionDebugVariable
+	(*ExpressionDebugTraceItem)(nil),   // 4: ExpressionDebugTraceItem
+	(*ExpressionDebugStartReq)(nil),    // 5: ExpressionDebugStartReq
+	(*ExpressionDebugStartResp)(nil),   // 6: ExpressionDebugStartResp
+	(*ExpressionDebugCommandReq)(nil),  // 7: ExpressionDebugCommandReq
+	(*ExpressionDebugCommandResp)(nil), // 8: ExpressionDebugCommandResp
+	(*ExpressionDebugUpd)(nil),         // 9: ExpressionDebugUpd
+}
+var file_expression_debug_msgs_proto_depIdxs = []int32{
+	2, // 0: ExpressionDebugTraceItem.calledFrom:type_name -> ExpressionDebugLocation
+	2, // 1: ExpressionDebugStartReq.breakpoints:type_name -> ExpressionDebugLocation
+	1, // 2: ExpressionDebugCommandReq.command:type_name -> ExpressionDebugCommand
+	2, // 3: ExpressionDebugCommandReq.breakpoints:type_name -> ExpressionDebugLocation
+	0, // 4: ExpressionDebugUpd.state:type_name -> ExpressionDebugState
+	2, // 5: ExpressionDebugUpd.stack:type_name -> ExpressionDebugLocation
+	3, // 6: ExpressionDebugUpd.locals:type_name -> ExpressionDebugVariable
+	4, // 7: ExpressionDebugUpd.trace:type_name -> ExpressionDebugTraceItem
+	8, // [8:8] is the sub-list for method output_type
+	8, // [8:8] is the sub-list for method input_type
+	8, // [8:8] is the sub-list for extension type_name
+	8, // [8:8] is the sub-list for extension extendee
+	0, // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_expression_debug_msgs_proto_init() }
+func file_expression_debug_msgs_proto_init() {
+	if File_expression_debug_msgs_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_expression_debug_msgs_proto_rawDesc), len(file_expression_debug_msgs_proto_rawDesc)),
+			NumEnums:      2,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_expression_debug_msgs_proto_goTypes,
+		DependencyIndexes: file_expression_debug_msgs_proto_depIdxs,
+		EnumInfos:         file_expression_debug_msgs_proto_enumTypes,
+		MessageInfos:      file_expression_debug_msgs_proto_msgTypes,
+	}.Build()
+	File_expression_debug_msgs_proto = out.File
+	file_expression_debug_msgs_proto_goTypes = nil
+	file_expression_debug_msgs_proto_depIdxs = nil
+}
@@ -202,8 +202,10 @@ func (x *ExpressionGetResp) GetExpression() *DataExpression {
 // If id is blank, assume its new and generate an ID to return, otherwise update & return same one
 // requires(EDIT_EXPRESSION)
 type ExpressionWriteReq struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Expression    *DataExpression        `protobuf:"bytes,1,opt,name=expression,proto3" json:"expression,omitempty"`
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Expression *DataExpression        `protobuf:"bytes,1,opt,name=expression,proto3" json:"expression,omitempty"`
+	// Optional, if set, the response warns about anything the expression reads that this scan doesn't have
+	CheckScanId   string `protobuf:"bytes,2,opt,name=checkScanId,proto3" json:"checkScanId,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -245,9 +247,18 @@ func (x *ExpressionWriteReq) GetExpression() *DataExpression {
 	return nil
 }
 
+func (x *ExpressionWriteReq) GetCheckScanId() string {
+	if x != nil {
+		return x.CheckScanId
+	}
+	return ""
+}
+
 type ExpressionWriteResp struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Expression    *DataExpression        `protobuf:"bytes,1,opt,name=expression,proto3" json:"expression,omitempty"`
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Expression *DataExpression        `protobuf:"bytes,1,opt,name=expression,proto3" json:"expression,omitempty"`
+	// Problems found by analysing the expression, eg globals that aren't defined, or data missing from checkScanId
+	Warnings      []string `protobuf:"bytes,2,rep,name=warnings,proto3" json:"warnings,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -289,6 +300,13 @@ func (x *ExpressionWriteResp) GetExpression() *DataExpression {
 	return nil
 }
 
+func (x *ExpressionWriteResp) GetWarnings() []string {
+	if x != nil {
+		return x.Warnings
+	}
+	return nil
+}
+
 // requires(EDIT_EXPRESSION)
 type ExpressionDeleteReq struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -663,15 +681,17 @@ const file_expression_msgs_proto_rawDesc = "" +
 	"\x11ExpressionGetResp\x12/\n" +
 	"\n" +
 	"expression\x18\x01 \x01(\v2\x0f.DataExpressionR\n" +
-	"expression\"E\n" +
+	"expression\"g\n" +
 	"\x12ExpressionWriteReq\x12/\n" +
 	"\n" +
 	"expression\x18\x01 \x01(\v2\x0f.DataExpressionR\n" +
-	"expression\"F\n" +
+	"expression\x12 \n" +
+	"\vcheckScanId\x18\x02 \x01(\tR\vcheckScanId\"b\n" +
 	"\x13ExpressionWriteResp\x12/\n" +
 	"\n" +
 	"expression\x18\x01 \x01(\v2\x0f.DataExpressionR\n" +
-	"expression\"%\n" +
+	"expression\x12\x1a\n" +
+	"\bwarnings\x18\x02 \x03(\tR\bwarnings\"%\n" +
 	"\x13ExpressionDeleteReq\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"\x16\n" +
 	"\x14ExpressionDeleteResp\"\\\n" +
@@ -146,6 +146,87 @@ func (x *JobListUpd) GetJob() *JobStatus {
 	return nil
 }
 
+// requires(QUANTIFY)
+type JobCancelReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobId         string                 `protobuf:"bytes,1,opt,name=jobId,proto3" json:"jobId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JobCancelReq) Reset() {
+	*x = JobCancelReq{}
+	mi := &file_job_msgs_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JobCancelReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobCancelReq) ProtoMessage() {}
+
+func (x *JobCancelReq) ProtoReflect() protoreflect.Message {
+	mi := &file_job_msgs_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobCancelReq.ProtoReflect.Descriptor instead.
+func (*JobCancelReq) Descriptor() ([]byte, []int) {
+	return file_job_msgs_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *JobCancelReq) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+type JobCancelResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JobCancelResp) Reset() {
+	*x = JobCancelResp{}
+	mi := &file_job_msgs_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JobCancelResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobCancelResp) ProtoMessage() {}
+
+func (x *JobCancelResp) ProtoReflect() protoreflect.Message {
+	mi := &file_job_msgs_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobCancelResp.ProtoReflect.Descriptor instead.
+func (*JobCancelResp) Descriptor() ([]byte, []int) {
+	return file_job_msgs_proto_rawDescGZIP(), []int{4}
+}
+
 var File_job_msgs_proto protoreflect.FileDescriptor
 
 const file_job_msgs_proto_rawDesc = "" +
@@ -159,7 +240,10 @@ const file_job_msgs_proto_rawDesc = "" +
 	"\n" +
 	"JobListUpd\x12\x1c\n" +
 	"\x03job\x18\x01 \x01(\v2\n" +
-	".JobStatusR\x03jobB\n" +
+	".JobStatusR\x03job\"$\n" +
+	"\fJobCancelReq\x12\x14\n" +
+	"\x05jobId\x18\x01 \x01(\tR\x05jobId\"\x0f\n" +
+	"\rJobCancelRespB\n" +
 	"Z\b.;protosb\x06proto3"
 
 var (
@@ -174,16 +258,18 @@ func file_job_msgs_proto_rawDescGZIP() []byte {
 	return file_job_msgs_proto_rawDescData
 }
 
-var file_job_msgs_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_job_msgs_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
 var file_job_msgs_proto_goTypes = []any{
-	(*JobListReq)(nil),  // 0: JobListReq
-	(*JobListResp)(nil), // 1: JobListResp
-	(*JobListUpd)(nil),  // 2: JobListUpd
-	(*JobStatus)(nil),   // 3: JobStatus
+	(*JobListReq)(nil),    // 0: JobListReq
+	(*JobListResp)(nil),   // 1: JobListResp
+	(*JobListUpd)(nil),    // 2: JobListUpd
+	(*JobCancelReq)(nil),  // 3: JobCancelReq
+	(*JobCancelResp)(nil), // 4: JobCancelResp
+	(*JobStatus)(nil),     // 5: JobStatus
 }
 var file_job_msgs_proto_depIdxs = []int32{
-	3, // 0: JobListResp.jobs:type_name -> JobStatus
-	3, // 1: JobListUpd.job:type_name -> JobStatus
+	5, // 0: JobListResp.jobs:type_name -> JobStatus
+	5, // 1: JobListUpd.job:type_name -> JobStatus
 	2, // [2:2] is the sub-list for method output_type
 	2, // [2:2] is the sub-list for method input_type
 	2, // [2:2] is the sub-list for extension type_name
@@ -203,7 +289,7 @@ func file_job_msgs_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_job_msgs_proto_rawDesc), len(file_job_msgs_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   3,
+			NumMessages:   5,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -24,12 +24,14 @@ const (
 type JobType int32
 
 const (
-	JobType_JT_UNKNOWN       JobType = 0 // https://protobuf.dev/programming-guides/dos-donts/ says specify an unknown as 0
-	JobType_JT_IMPORT_SCAN   JobType = 1
-	JobType_JT_REIMPORT_SCAN JobType = 2
-	JobType_JT_IMPORT_IMAGE  JobType = 3
-	JobType_JT_RUN_QUANT     JobType = 4
-	JobType_JT_RUN_FIT       JobType = 5
+	JobType_JT_UNKNOWN              JobType = 0 // https://protobuf.dev/programming-guides/dos-donts/ says specify an unknown as 0
+	JobType_JT_IMPORT_SCAN          JobType = 1
+	JobType_JT_REIMPORT_SCAN        JobType = 2
+	JobType_JT_IMPORT_IMAGE         JobType = 3
+	JobType_JT_RUN_QUANT            JobType = 4
+	JobType_JT_RUN_FIT              JobType = 5
+	JobType_JT_RUN_EXPRESSION_BATCH JobType = 6
+	JobType_JT_RUN_QUANT_REGRESSION JobType = 7
 )
 
 // Enum value maps for JobType.
@@ -41,14 +43,18 @@ var (
 		3: "JT_IMPORT_IMAGE",
 		4: "JT_RUN_QUANT",
 		5: "JT_RUN_FIT",
+		6: "JT_RUN_EXPRESSION_BATCH",
+		7: "JT_RUN_QUANT_REGRESSION",
 	}
 	JobType_value = map[string]int32{
-		"JT_UNKNOWN":       0,
-		"JT_IMPORT_SCAN":   1,
-		"JT_REIMPORT_SCAN": 2,
-		"JT_IMPORT_IMAGE":  3,
-		"JT_RUN_QUANT":     4,
-		"JT_RUN_FIT":       5,
+		"JT_UNKNOWN":              0,
+		"JT_IMPORT_SCAN":          1,
+		"JT_REIMPORT_SCAN":        2,
+		"JT_IMPORT_IMAGE":         3,
+		"JT_RUN_QUANT":            4,
+		"JT_RUN_FIT":              5,
+		"JT_RUN_EXPRESSION_BATCH": 6,
+		"JT_RUN_QUANT_REGRESSION": 7,
 	}
 )
 
@@ -89,6 +95,7 @@ const (
 	JobStatus_GATHERING_RESULTS JobStatus_Status = 4
 	JobStatus_COMPLETE          JobStatus_Status = 5
 	JobStatus_ERROR             JobStatus_Status = 6
+	JobStatus_CANCELLED         JobStatus_Status = 7
 )
 
 // Enum value maps for JobStatus_Status.
@@ -101,6 +108,7 @@ var (
 		4: "GATHERING_RESULTS",
 		5: "COMPLETE",
 		6: "ERROR",
+		7: "CANCELLED",
 	}
 	JobStatus_Status_value = map[string]int32{
 		"UNKNOWN":           0,
@@ -110,6 +118,7 @@ var (
 		"GATHERING_RESULTS": 4,
 		"COMPLETE":          5,
 		"ERROR":             6,
+		"CANCELLED":         7,
 	}
 )
 
@@ -143,11 +152,12 @@ func (JobStatus_Status) EnumDescriptor() ([]byte, []int) {
 type JobQueueItem_State int32
 
 const (
-	JobQueueItem_UNKNOWN  JobQueueItem_State = 0 // https://protobuf.dev/programming-guides/dos-donts/ says specify an unknown as 0
-	JobQueueItem_ASSIGNED JobQueueItem_State = 1
-	JobQueueItem_RUNNING  JobQueueItem_State = 2
-	JobQueueItem_COMPLETE JobQueueItem_State = 3
-	JobQueueItem_FAILED   JobQueueItem_State = 4
+	JobQueueItem_UNKNOWN   JobQueueItem_State = 0 // https://protobuf.dev/programming-guides/dos-donts/ says specify an unknown as 0
+	JobQueueItem_ASSIGNED  JobQueueItem_State = 1
+	JobQueueItem_RUNNING   JobQueueItem_State = 2
+	JobQueueItem_COMPLETE  JobQueueItem_State = 3
+	JobQueueItem_FAILED    JobQueueItem_State = 4
+	JobQueueItem_CANCELLED JobQueueItem_State = 5
 )
 
 // Enum value maps for JobQueueItem_State.
@@ -158,13 +168,15 @@ var (
 		2: "RUNNING",
 		3: "COMPLETE",
 		4: "FAILED",
+		5: "CANCELLED",
 	}
 	JobQueueItem_State_value = map[string]int32{
-		"UNKNOWN":  0,
-		"ASSIGNED": 1,
-		"RUNNING":  2,
-		"COMPLETE": 3,
-		"FAILED":   4,
+		"UNKNOWN":   0,
+		"ASSIGNED":  1,
+		"RUNNING":   2,
+		"COMPLETE":  3,
+		"FAILED":    4,
+		"CANCELLED": 5,
 	}
 )
 
@@ -442,8 +454,16 @@ type JobQueueItem struct {
 	State                       JobQueueItem_State `protobuf:"varint,5,opt,name=state,proto3,enum=JobQueueItem_State" json:"state,omitempty"`
 	Message                     string             `protobuf:"bytes,6,opt,name=message,proto3" json:"message,omitempty"`
 	InstanceId                  string             `protobuf:"bytes,9,opt,name=instanceId,proto3" json:"instanceId,omitempty"`
-	unknownFields               protoimpl.UnknownFields
-	sizeCache                   protoimpl.SizeCache
+	// How many times this job has been re-queued after failing, and when it can next be started
+	RetryCount        uint32 `protobuf:"varint,10,opt,name=retryCount,proto3" json:"retryCount,omitempty"`
+	RetryAfterUnixSec int64  `protobuf:"varint,11,opt,name=retryAfterUnixSec,proto3" json:"retryAfterUnixSec,omitempty"`
+	// Jobs with a higher priority are started first
+	Priority int32 `protobuf:"varint,12,opt,name=priority,proto3" json:"priority,omitempty"`
+	// For jobs that work through a list of items, how far through the list they are
+	ItemsComplete uint32 `protobuf:"varint,13,opt,name=itemsComplete,proto3" json:"itemsComplete,omitempty"`
+	ItemsTotal    uint32 `protobuf:"varint,14,opt,name=itemsTotal,proto3" json:"itemsTotal,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *JobQueueItem) Reset() {
@@ -539,11 +559,46 @@ func (x *JobQueueItem) GetInstanceId() string {
 	return ""
 }
 
+func (x *JobQueueItem) GetRetryCount() uint32 {
+	if x != nil {
+		return x.RetryCount
+	}
+	return 0
+}
+
+func (x *JobQueueItem) GetRetryAfterUnixSec() int64 {
+	if x != nil {
+		return x.RetryAfterUnixSec
+	}
+	return 0
+}
+
+func (x *JobQueueItem) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *JobQueueItem) GetItemsComplete() uint32 {
+	if x != nil {
+		return x.ItemsComplete
+	}
+	return 0
+}
+
+func (x *JobQueueItem) GetItemsTotal() uint32 {
+	if x != nil {
+		return x.ItemsTotal
+	}
+	return 0
+}
+
 var File_job_proto protoreflect.FileDescriptor
 
 const file_job_proto_rawDesc = "" +
 	"\n" +
-	"\tjob.proto\"\xe7\x04\n" +
+	"\tjob.proto\"\xf7\x04\n" +
 	"\tJobStatus\x12\x14\n" +
 	"\x05jobId\x18\x01 \x01(\tR\x05jobId\x12)\n" +
 	"\x06status\x18\x02 \x01(\x0e2\x11.JobStatus.StatusR\x06status\x12\x18\n" +
@@ -559,7 +614,7 @@ const file_job_proto_rawDesc = "" +
 	"\rotherLogFiles\x18\t \x03(\tR\rotherLogFiles\x12(\n" +
 	"\x0frequestorUserId\x18\f \x01(\tR\x0frequestorUserId\x12\x12\n" +
 	"\x04name\x18\r \x01(\tR\x04name\x12\x1a\n" +
-	"\belements\x18\x0e \x03(\tR\belements\"u\n" +
+	"\belements\x18\x0e \x03(\tR\belements\"\x84\x01\n" +
 	"\x06Status\x12\v\n" +
 	"\aUNKNOWN\x10\x00\x12\f\n" +
 	"\bSTARTING\x10\x01\x12\x13\n" +
@@ -567,12 +622,13 @@ const file_job_proto_rawDesc = "" +
 	"\aRUNNING\x10\x03\x12\x15\n" +
 	"\x11GATHERING_RESULTS\x10\x04\x12\f\n" +
 	"\bCOMPLETE\x10\x05\x12\t\n" +
-	"\x05ERROR\x10\x06\"\x92\x01\n" +
+	"\x05ERROR\x10\x06\x12\r\n" +
+	"\tCANCELLED\x10\a\"\x92\x01\n" +
 	"\x10JobHandlerDBItem\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
 	"\x05jobId\x18\x02 \x01(\tR\x05jobId\x12,\n" +
 	"\x11handlerInstanceId\x18\x03 \x01(\tR\x11handlerInstanceId\x12*\n" +
-	"\x10timeStampUnixSec\x18\x04 \x01(\rR\x10timeStampUnixSec\"\xba\x03\n" +
+	"\x10timeStampUnixSec\x18\x04 \x01(\rR\x10timeStampUnixSec\"\xf9\x04\n" +
 	"\fJobQueueItem\x12\x14\n" +
 	"\x05jobId\x18\x01 \x01(\tR\x05jobId\x12\x1e\n" +
 	"\n" +
@@ -586,14 +642,25 @@ const file_job_proto_rawDesc = "" +
 	"\amessage\x18\x06 \x01(\tR\amessage\x12\x1e\n" +
 	"\n" +
 	"instanceId\x18\t \x01(\tR\n" +
-	"instanceId\"I\n" +
+	"instanceId\x12\x1e\n" +
+	"\n" +
+	"retryCount\x18\n" +
+	" \x01(\rR\n" +
+	"retryCount\x12,\n" +
+	"\x11retryAfterUnixSec\x18\v \x01(\x03R\x11retryAfterUnixSec\x12\x1a\n" +
+	"\bpriority\x18\f \x01(\x05R\bpriority\x12$\n" +
+	"\ritemsComplete\x18\r \x01(\rR\ritemsComplete\x12\x1e\n" +
+	"\n" +
+	"itemsTotal\x18\x0e \x01(\rR\n" +
+	"itemsTotal\"X\n" +
 	"\x05State\x12\v\n" +
 	"\aUNKNOWN\x10\x00\x12\f\n" +
 	"\bASSIGNED\x10\x01\x12\v\n" +
 	"\aRUNNING\x10\x02\x12\f\n" +
 	"\bCOMPLETE\x10\x03\x12\n" +
 	"\n" +
-	"\x06FAILED\x10\x04*z\n" +
+	"\x06FAILED\x10\x04\x12\r\n" +
+	"\tCANCELLED\x10\x05*\xb4\x01\n" +
 	"\aJobType\x12\x0e\n" +
 	"\n" +
 	"JT_UNKNOWN\x10\x00\x12\x12\n" +
@@ -602,7 +669,9 @@ const file_job_proto_rawDesc = "" +
 	"\x0fJT_IMPORT_IMAGE\x10\x03\x12\x10\n" +
 	"\fJT_RUN_QUANT\x10\x04\x12\x0e\n" +
 	"\n" +
-	"JT_RUN_FIT\x10\x05B\n" +
+	"JT_RUN_FIT\x10\x05\x12\x1b\n" +
+	"\x17JT_RUN_EXPRESSION_BATCH\x10\x06\x12\x1b\n" +
+	"\x17JT_RUN_QUANT_REGRESSION\x10\aB\n" +
 	"Z\b.;protosb\x06proto3"
 
 var (
@@ -33,20 +33,32 @@ const (
 	ObjectType_OT_SCAN             ObjectType = 6
 	ObjectType_OT_QUANTIFICATION   ObjectType = 7
 	ObjectType_OT_SCREEN_CONFIG    ObjectType = 8
+	// The following only have ownership items if created since they were added here, or shared since. Those without one
+	// are accessible as they were before: to everyone, or for images, anyone with access to their scans
+	ObjectType_OT_IMAGE            ObjectType = 9
+	ObjectType_OT_TAG              ObjectType = 10
+	ObjectType_OT_REFERENCE_DATA   ObjectType = 11
+	ObjectType_OT_DIFFRACTION_PEAK ObjectType = 12
+	ObjectType_OT_WIDGET_DATA      ObjectType = 13
 )
 
 // Enum value maps for ObjectType.
 var (
 	ObjectType_name = map[int32]string{
-		0: "OT_UNKNOWN",
-		1: "OT_ROI",
-		2: "OT_ELEMENT_SET",
-		3: "OT_EXPRESSION",
-		4: "OT_EXPRESSION_GROUP",
-		5: "OT_DATA_MODULE",
-		6: "OT_SCAN",
-		7: "OT_QUANTIFICATION",
-		8: "OT_SCREEN_CONFIG",
+		0:  "OT_UNKNOWN",
+		1:  "OT_ROI",
+		2:  "OT_ELEMENT_SET",
+		3:  "OT_EXPRESSION",
+		4:  "OT_EXPRESSION_GROUP",
+		5:  "OT_DATA_MODULE",
+		6:  "OT_SCAN",
+		7:  "OT_QUANTIFICATION",
+		8:  "OT_SCREEN_CONFIG",
+		9:  "OT_IMAGE",
+		10: "OT_TAG",
+		11: "OT_REFERENCE_DATA",
+		12: "OT_DIFFRACTION_PEAK",
+		13: "OT_WIDGET_DATA",
 	}
 	ObjectType_value = map[string]int32{
 		"OT_UNKNOWN":          0,
@@ -58,6 +70,11 @@ var (
 		"OT_SCAN":             6,
 		"OT_QUANTIFICATION":   7,
 		"OT_SCREEN_CONFIG":    8,
+		"OT_IMAGE":            9,
+		"OT_TAG":              10,
+		"OT_REFERENCE_DATA":   11,
+		"OT_DIFFRACTION_PEAK": 12,
+		"OT_WIDGET_DATA":      13,
 	}
 )
 
@@ -353,7 +370,7 @@ const file_ownership_access_proto_rawDesc = "" +
 	"\x0feditorUserCount\x18\x05 \x01(\rR\x0feditorUserCount\x12*\n" +
 	"\x10editorGroupCount\x18\x06 \x01(\rR\x10editorGroupCount\x12*\n" +
 	"\x10sharedWithOthers\x18\a \x01(\bR\x10sharedWithOthers\x12\x18\n" +
-	"\acanEdit\x18\b \x01(\bR\acanEdit*\xb6\x01\n" +
+	"\acanEdit\x18\b \x01(\bR\acanEdit*\x94\x02\n" +
 	"\n" +
 	"ObjectType\x12\x0e\n" +
 	"\n" +
@@ -366,7 +383,14 @@ const file_ownership_access_proto_rawDesc = "" +
 	"\x0eOT_DATA_MODULE\x10\x05\x12\v\n" +
 	"\aOT_SCAN\x10\x06\x12\x15\n" +
 	"\x11OT_QUANTIFICATION\x10\a\x12\x14\n" +
-	"\x10OT_SCREEN_CONFIG\x10\bB\n" +
+	"\x10OT_SCREEN_CONFIG\x10\b\x12\f\n" +
+	"\bOT_IMAGE\x10\t\x12\n" +
+	"\n" +
+	"\x06OT_TAG\x10\n" +
+	"\x12\x15\n" +
+	"\x11OT_REFERENCE_DATA\x10\v\x12\x17\n" +
+	"\x13OT_DIFFRACTION_PEAK\x10\f\x12\x12\n" +
+	"\x0eOT_WIDGET_DATA\x10\rB\n" +
 	"Z\b.;protosb\x06proto3"
 
 var (
@@ -0,0 +1,325 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v3.21.12
+// source: piquant-regression-msgs.proto
+
+package protos
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Runs the cases with both the baseline and candidate PIQUANT version/config and compares the results
+// requires(EDIT_PIQUANT_SETTINGS)
+type PiquantRegressionReq struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	Params        *PiquantRegressionParams `protobuf:"bytes,1,opt,name=params,proto3" json:"params,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PiquantRegressionReq) Reset() {
+	*x = PiquantRegressionReq{}
+	mi := &file_piquant_regression_msgs_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PiquantRegressionReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PiquantRegressionReq) ProtoMessage() {}
+
+func (x *PiquantRegressionReq) ProtoReflect() protoreflect.Message {
+	mi := &file_piquant_regression_msgs_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PiquantRegressionReq.ProtoReflect.Descriptor instead.
+func (*PiquantRegressionReq) Descriptor() ([]byte, []int) {
+	return file_piquant_regression_msgs_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PiquantRegressionReq) GetParams() *PiquantRegressionParams {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
+type PiquantRegressionResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        *JobStatus             `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PiquantRegressionResp) Reset() {
+	*x = PiquantRegressionResp{}
+	mi := &file_piquant_regression_msgs_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PiquantRegressionResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PiquantRegressionResp) ProtoMessage() {}
+
+func (x *PiquantRegressionResp) ProtoReflect() protoreflect.Message {
+	mi := &file_piquant_regression_msgs_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PiquantRegressionResp.ProtoReflect.Descriptor instead.
+func (*PiquantRegressionResp) Descriptor() ([]byte, []int) {
+	return file_piquant_regression_msgs_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PiquantRegressionResp) GetStatus() *JobStatus {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+type PiquantRegressionUpd struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        *JobStatus             `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PiquantRegressionUpd) Reset() {
+	*x = PiquantRegressionUpd{}
+	mi := &file_piquant_regression_msgs_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PiquantRegressionUpd) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PiquantRegressionUpd) ProtoMessage() {}
+
+func (x *PiquantRegressionUpd) ProtoReflect() protoreflect.Message {
+	mi := &file_piquant_regression_msgs_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PiquantRegressionUpd.ProtoReflect.Descriptor instead.
+func (*PiquantRegressionUpd) Descriptor() ([]byte, []int) {
+	return file_piquant_regression_msgs_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PiquantRegressionUpd) GetStatus() *JobStatus {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+// requires(READ_PIQUANT_SETTINGS)
+type PiquantRegressionResultReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobId         string                 `protobuf:"bytes,1,opt,name=jobId,proto3" json:"jobId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PiquantRegressionResultReq) Reset() {
+	*x = PiquantRegressionResultReq{}
+	mi := &file_piquant_regression_msgs_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PiquantRegressionResultReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PiquantRegressionResultReq) ProtoMessage() {}
+
+func (x *PiquantRegressionResultReq) ProtoReflect() protoreflect.Message {
+	mi := &file_piquant_regression_msgs_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PiquantRegressionResultReq.ProtoReflect.Descriptor instead.
+func (*PiquantRegressionResultReq) Descriptor() ([]byte, []int) {
+	return file_piquant_regression_msgs_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *PiquantRegressionResultReq) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+type PiquantRegressionResultResp struct {
+	state         protoimpl.MessageState    `protogen:"open.v1"`
+	Summary       *PiquantRegressionSummary `protobuf:"bytes,1,opt,name=summary,proto3" json:"summary,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PiquantRegressionResultResp) Reset() {
+	*x = PiquantRegressionResultResp{}
+	mi := &file_piquant_regression_msgs_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PiquantRegressionResultResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PiquantRegressionResultResp) ProtoMessage() {}
+
+func (x *PiquantRegressionResultResp) ProtoReflect() protoreflect.Message {
+	mi := &file_piquant_regression_msgs_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PiquantRegressionResultResp.ProtoReflect.Descriptor instead.
+func (*PiquantRegressionResultResp) Descriptor() ([]byte, []int) {
+	return file_piquant_regression_msgs_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *PiquantRegressionResultResp) GetSummary() *PiquantRegressionSummary {
+	if x != nil {
+		return x.Summary
+	}
+	return nil
+}
+
+var File_piquant_regression_msgs_proto protoreflect.FileDescriptor
+
+const file_piquant_regression_msgs_proto_rawDesc = "" +
+	"\n" +
+	"\x1dpiquant-regression-msgs.proto\x1a\tjob.proto\x1a\x18piquant-regression.proto\"H\n" +
+	"\x14PiquantRegressionReq\x120\n" +
+	"\x06params\x18\x01 \x01(\v2\x18.PiquantRegressionParamsR\x06params\";\n" +
+	"\x15PiquantRegressionResp\x12\"\n" +
+	"\x06status\x18\x01 \x01(\v2\n" +
+	".JobStatusR\x06status\":\n" +
+	"\x14PiquantRegressionUpd\x12\"\n" +
+	"\x06status\x18\x01 \x01(\v2\n" +
+	".JobStatusR\x06status\"2\n" +
+	"\x1aPiquantRegressionResultReq\x12\x14\n" +
+	"\x05jobId\x18\x01 \x01(\tR\x05jobId\"R\n" +
+	"\x1bPiquantRegressionResultResp\x123\n" +
+	"\asummary\x18\x01 \x01(\v2\x19.PiquantRegressionSummaryR\asummaryB\n" +
+	"Z\b.;protosb\x06proto3"
+
+var (
+	file_piquant_regression_msgs_proto_rawDescOnce sync.Once
+	file_piquant_regression_msgs_proto_rawDescData []byte
+)
+
+func file_piquant_regression_msgs_proto_rawDescGZIP() []byte {
+	file_piquant_regression_msgs_proto_rawDescOnce.Do(func() {
+		file_piquant_regression_msgs_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_piquant_regression_msgs_proto_rawDesc), len(file_piquant_regression_msgs_proto_rawDesc)))
+	})
+	return file_piquant_regression_msgs_proto_rawDescData
+}
+
+var file_piquant_regression_msgs_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_piquant_regression_msgs_proto_goTypes = []any{
+	(*PiquantRegressionReq)(nil),        // 0: PiquantRegressionReq
+	(*PiquantRegressionResp)(nil),       // 1: PiquantRegressionResp
+	(*PiquantRegressionUpd)(nil),        // 2: PiquantRegressionUpd
+	(*PiquantRegressionResultReq)(nil),  // 3: PiquantRegressionResultReq
+	(*PiquantRegressionResultResp)(nil), // 4: PiquantRegressionResultResp
+	(*PiquantRegressionParams)(nil),     // 5: PiquantRegressionParams
+	(*JobStatus)(nil),                   // 6: JobStatus
+	(*PiquantRegressionSummary)(nil),    // 7: PiquantRegressionSummary
+}
+var file_piquant_regression_msgs_proto_depIdxs = []int32{
+	5, // 0: PiquantRegressionReq.params:type_name -> PiquantRegressionParams
+	6, // 1: PiquantRegressionResp.status:type_name -> JobStatus
+	6, // 2: PiquantRegressionUpd.status:type_name -> JobStatus
+	7, // 3: PiquantRegressionResultResp.summary:type_name -> PiquantRegressionSummary
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_piquant_regression_msgs_proto_init() }
+func file_piquant_regression_msgs_proto_init() {
+	if File_piquant_regression_msgs_proto != nil {
+		return
+	}
+	file_job_proto_init()
+	file_piquant_regression_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_piquant_regression_msgs_proto_rawDesc), len(file_piquant_regression_msgs_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_piquant_regression_msgs_proto_goTypes,
+		DependencyIndexes: file_piquant_regression_msgs_proto_depIdxs,
+		MessageInfos:      file_piquant_regression_msgs_proto_msgTypes,
+	}.Build()
+	File_piquant_regression_msgs_proto = out.File
+	file_piquant_regression_msgs_proto_goTypes = nil
+	file_piquant_regression_msgs_proto_depIdxs = nil
+}
@@ -0,0 +1,521 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v3.21.12
+// source: piquant-regression.proto
+
+package protos
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// A scan and the ROIs and elements to quantify in it. Each ROI is summed and quantified as one spectrum
+type PiquantRegressionCase struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	ScanId string                 `protobuf:"bytes,1,opt,name=scanId,proto3" json:"scanId,omitempty"`
+	// Can include AllPoints
+	RoiIds     []string `protobuf:"bytes,2,rep,name=roiIds,proto3" json:"roiIds,omitempty"`
+	Elements   []string `protobuf:"bytes,3,rep,name=elements,proto3" json:"elements,omitempty"`
+	Parameters string   `protobuf:"bytes,4,opt,name=parameters,proto3" json:"parameters,omitempty"`
+	// CombinedBulk or ABBulk, defaults to CombinedBulk
+	QuantMode     string `protobuf:"bytes,5,opt,name=quantMode,proto3" json:"quantMode,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PiquantRegressionCase) Reset() {
+	*x = PiquantRegressionCase{}
+	mi := &file_piquant_regression_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PiquantRegressionCase) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PiquantRegressionCase) ProtoMessage() {}
+
+func (x *PiquantRegressionCase) ProtoReflect() protoreflect.Message {
+	mi := &file_piquant_regression_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PiquantRegressionCase.ProtoReflect.Descriptor instead.
+func (*PiquantRegressionCase) Descriptor() ([]byte, []int) {
+	return file_piquant_regression_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PiquantRegressionCase) GetScanId() string {
+	if x != nil {
+		return x.ScanId
+	}
+	return ""
+}
+
+func (x *PiquantRegressionCase) GetRoiIds() []string {
+	if x != nil {
+		return x.RoiIds
+	}
+	return nil
+}
+
+func (x *PiquantRegressionCase) GetElements() []string {
+	if x != nil {
+		return x.Elements
+	}
+	return nil
+}
+
+func (x *PiquantRegressionCase) GetParameters() string {
+	if x != nil {
+		return x.Parameters
+	}
+	return ""
+}
+
+func (x *PiquantRegressionCase) GetQuantMode() string {
+	if x != nil {
+		return x.QuantMode
+	}
+	return ""
+}
+
+// What to run PIQUANT with
+type PiquantRegressionTarget struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Docker image to run. If blank, the current PIQUANT version is used
+	PiquantVersion string `protobuf:"bytes,1,opt,name=piquantVersion,proto3" json:"piquantVersion,omitempty"`
+	// Config name and version, eg PIXL/v7
+	DetectorConfig string `protobuf:"bytes,2,opt,name=detectorConfig,proto3" json:"detectorConfig,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *PiquantRegressionTarget) Reset() {
+	*x = PiquantRegressionTarget{}
+	mi := &file_piquant_regression_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PiquantRegressionTarget) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PiquantRegressionTarget) ProtoMessage() {}
+
+func (x *PiquantRegressionTarget) ProtoReflect() protoreflect.Message {
+	mi := &file_piquant_regression_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PiquantRegressionTarget.ProtoReflect.Descriptor instead.
+func (*PiquantRegressionTarget) Descriptor() ([]byte, []int) {
+	return file_piquant_regression_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PiquantRegressionTarget) GetPiquantVersion() string {
+	if x != nil {
+		return x.PiquantVersion
+	}
+	return ""
+}
+
+func (x *PiquantRegressionTarget) GetDetectorConfig() string {
+	if x != nil {
+		return x.DetectorConfig
+	}
+	return ""
+}
+
+type PiquantRegressionParams struct {
+	state     protoimpl.MessageState   `protogen:"open.v1"`
+	Cases     []*PiquantRegressionCase `protobuf:"bytes,1,rep,name=cases,proto3" json:"cases,omitempty"`
+	Baseline  *PiquantRegressionTarget `protobuf:"bytes,2,opt,name=baseline,proto3" json:"baseline,omitempty"`
+	Candidate *PiquantRegressionTarget `protobuf:"bytes,3,opt,name=candidate,proto3" json:"candidate,omitempty"`
+	// Elements whose candidate value differs from the baseline by more than this fraction of the baseline value are
+	// flagged as regressions, eg 0.05 for 5%
+	RelativeTolerance float32 `protobuf:"fixed32,4,opt,name=relativeTolerance,proto3" json:"relativeTolerance,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *PiquantRegressionParams) Reset() {
+	*x = PiquantRegressionParams{}
+	mi := &file_piquant_regression_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PiquantRegressionParams) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PiquantRegressionParams) ProtoMessage() {}
+
+func (x *PiquantRegressionParams) ProtoReflect() protoreflect.Message {
+	mi := &file_piquant_regression_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PiquantRegressionParams.ProtoReflect.Descriptor instead.
+func (*PiquantRegressionParams) Descriptor() ([]byte, []int) {
+	return file_piquant_regression_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PiquantRegressionParams) GetCases() []*PiquantRegressionCase {
+	if x != nil {
+		return x.Cases
+	}
+	return nil
+}
+
+func (x *PiquantRegressionParams) GetBaseline() *PiquantRegressionTarget {
+	if x != nil {
+		return x.Baseline
+	}
+	return nil
+}
+
+func (x *PiquantRegressionParams) GetCandidate() *PiquantRegressionTarget {
+	if x != nil {
+		return x.Candidate
+	}
+	return nil
+}
+
+func (x *PiquantRegressionParams) GetRelativeTolerance() float32 {
+	if x != nil {
+		return x.RelativeTolerance
+	}
+	return 0
+}
+
+type PiquantRegressionElementResult struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	ScanId   string                 `protobuf:"bytes,1,opt,name=scanId,proto3" json:"scanId,omitempty"`
+	RoiId    string                 `protobuf:"bytes,2,opt,name=roiId,proto3" json:"roiId,omitempty"`
+	Detector string                 `protobuf:"bytes,3,opt,name=detector,proto3" json:"detector,omitempty"`
+	// Weight % column, eg "FeO-T_%"
+	Element        string  `protobuf:"bytes,4,opt,name=element,proto3" json:"element,omitempty"`
+	BaselineValue  float32 `protobuf:"fixed32,5,opt,name=baselineValue,proto3" json:"baselineValue,omitempty"`
+	CandidateValue float32 `protobuf:"fixed32,6,opt,name=candidateValue,proto3" json:"candidateValue,omitempty"`
+	// (candidate - baseline) / baseline. If the baseline is 0, any non-zero candidate value is a difference of 1
+	RelativeDifference float32 `protobuf:"fixed32,7,opt,name=relativeDifference,proto3" json:"relativeDifference,omitempty"`
+	Regression         bool    `protobuf:"varint,8,opt,name=regression,proto3" json:"regression,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *PiquantRegressionElementResult) Reset() {
+	*x = PiquantRegressionElementResult{}
+	mi := &file_piquant_regression_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PiquantRegressionElementResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PiquantRegressionElementResult) ProtoMessage() {}
+
+func (x *PiquantRegressionElementResult) ProtoReflect() protoreflect.Message {
+	mi := &file_piquant_regression_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PiquantRegressionElementResult.ProtoReflect.Descriptor instead.
+func (*PiquantRegressionElementResult) Descriptor() ([]byte, []int) {
+	return file_piquant_regression_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *PiquantRegressionElementResult) GetScanId() string {
+	if x != nil {
+		return x.ScanId
+	}
+	return ""
+}
+
+func (x *PiquantRegressionElementResult) GetRoiId() string {
+	if x != nil {
+		return x.RoiId
+	}
+	return ""
+}
+
+func (x *PiquantRegressionElementResult) GetDetector() string {
+	if x != nil {
+		return x.Detector
+	}
+	return ""
+}
+
+func (x *PiquantRegressionElementResult) GetElement() string {
+	if x != nil {
+		return x.Element
+	}
+	return ""
+}
+
+func (x *PiquantRegressionElementResult) GetBaselineValue() float32 {
+	if x != nil {
+		return x.BaselineValue
+	}
+	return 0
+}
+
+func (x *PiquantRegressionElementResult) GetCandidateValue() float32 {
+	if x != nil {
+		return x.CandidateValue
+	}
+	return 0
+}
+
+func (x *PiquantRegressionElementResult) GetRelativeDifference() float32 {
+	if x != nil {
+		return x.RelativeDifference
+	}
+	return 0
+}
+
+func (x *PiquantRegressionElementResult) GetRegression() bool {
+	if x != nil {
+		return x.Regression
+	}
+	return false
+}
+
+type PiquantRegressionSummary struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The job ID
+	Id              string                            `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty" bson:"_id,omitempty"`  
+	Params          *PiquantRegressionParams          `protobuf:"bytes,2,opt,name=params,proto3" json:"params,omitempty"`
+	Results         []*PiquantRegressionElementResult `protobuf:"bytes,3,rep,name=results,proto3" json:"results,omitempty"`
+	RegressionCount uint32                            `protobuf:"varint,4,opt,name=regressionCount,proto3" json:"regressionCount,omitempty"`
+	// Cases that failed to run, and so have no results
+	Errors              []string `protobuf:"bytes,5,rep,name=errors,proto3" json:"errors,omitempty"`
+	RequestorUserId     string   `protobuf:"bytes,6,opt,name=requestorUserId,proto3" json:"requestorUserId,omitempty"`
+	CompleteUnixTimeSec uint32   `protobuf:"varint,7,opt,name=completeUnixTimeSec,proto3" json:"completeUnixTimeSec,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *PiquantRegressionSummary) Reset() {
+	*x = PiquantRegressionSummary{}
+	mi := &file_piquant_regression_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PiquantRegressionSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PiquantRegressionSummary) ProtoMessage() {}
+
+func (x *PiquantRegressionSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_piquant_regression_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PiquantRegressionSummary.ProtoReflect.Descriptor instead.
+func (*PiquantRegressionSummary) Descriptor() ([]byte, []int) {
+	return file_piquant_regression_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *PiquantRegressionSummary) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PiquantRegressionSummary) GetParams() *PiquantRegressionParams {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
+func (x *PiquantRegressionSummary) GetResults() []*PiquantRegressionElementResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *PiquantRegressionSummary) GetRegressionCount() uint32 {
+	if x != nil {
+		return x.RegressionCount
+	}
+	return 0
+}
+
+func (x *PiquantRegressionSummary) GetErrors() []string {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+func (x *PiquantRegressionSummary) GetRequestorUserId() string {
+	if x != nil {
+		return x.RequestorUserId
+	}
+	return ""
+}
+
+func (x *PiquantRegressionSummary) GetCompleteUnixTimeSec() uint32 {
+	if x != nil {
+		return x.CompleteUnixTimeSec
+	}
+	return 0
+}
+
+var File_piquant_regression_proto protoreflect.FileDescriptor
+
+const file_piquant_regression_proto_rawDesc = "" +
+	"\n" +
+	"\x18piquant-regression.proto\"\xa1\x01\n" +
+	"\x15PiquantRegressionCase\x12\x16\n" +
+	"\x06scanId\x18\x01 \x01(\tR\x06scanId\x12\x16\n" +
+	"\x06roiIds\x18\x02 \x03(\tR\x06roiIds\x12\x1a\n" +
+	"\belements\x18\x03 \x03(\tR\belements\x12\x1e\n" +
+	"\n" +
+	"parameters\x18\x04 \x01(\tR\n" +
+	"parameters\x12\x1c\n" +
+	"\tquantMode\x18\x05 \x01(\tR\tquantMode\"i\n" +
+	"\x17PiquantRegressionTarget\x12&\n" +
+	"\x0epiquantVersion\x18\x01 \x01(\tR\x0epiquantVersion\x12&\n" +
+	"\x0edetectorConfig\x18\x02 \x01(\tR\x0edetectorConfig\"\xe3\x01\n" +
+	"\x17PiquantRegressionParams\x12,\n" +
+	"\x05cases\x18\x01 \x03(\v2\x16.PiquantRegressionCaseR\x05cases\x124\n" +
+	"\bbaseline\x18\x02 \x01(\v2\x18.PiquantRegressionTargetR\bbaseline\x126\n" +
+	"\tcandidate\x18\x03 \x01(\v2\x18.PiquantRegressionTargetR\tcandidate\x12,\n" +
+	"\x11relativeTolerance\x18\x04 \x01(\x02R\x11relativeTolerance\"\xa2\x02\n" +
+	"\x1ePiquantRegressionElementResult\x12\x16\n" +
+	"\x06scanId\x18\x01 \x01(\tR\x06scanId\x12\x14\n" +
+	"\x05roiId\x18\x02 \x01(\tR\x05roiId\x12\x1a\n" +
+	"\bdetector\x18\x03 \x01(\tR\bdetector\x12\x18\n" +
+	"\aelement\x18\x04 \x01(\tR\aelement\x12$\n" +
+	"\rbaselineValue\x18\x05 \x01(\x02R\rbaselineValue\x12&\n" +
+	"\x0ecandidateValue\x18\x06 \x01(\x02R\x0ecandidateValue\x12.\n" +
+	"\x12relativeDifference\x18\a \x01(\x02R\x12relativeDifference\x12\x1e\n" +
+	"\n" +
+	"regression\x18\b \x01(\bR\n" +
+	"regression\"\xb5\x02\n" +
+	"\x18PiquantRegressionSummary\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x120\n" +
+	"\x06params\x18\x02 \x01(\v2\x18.PiquantRegressionParamsR\x06params\x129\n" +
+	"\aresults\x18\x03 \x03(\v2\x1f.PiquantRegressionElementResultR\aresults\x12(\n" +
+	"\x0fregressionCount\x18\x04 \x01(\rR\x0fregressionCount\x12\x16\n" +
+	"\x06errors\x18\x05 \x03(\tR\x06errors\x12(\n" +
+	"\x0frequestorUserId\x18\x06 \x01(\tR\x0frequestorUserId\x120\n" +
+	"\x13completeUnixTimeSec\x18\a \x01(\rR\x13completeUnixTimeSecB\n" +
+	"Z\b.;protosb\x06proto3"
+
+var (
+	file_piquant_regression_proto_rawDescOnce sync.Once
+	file_piquant_regression_proto_rawDescData []byte
+)
+
+func file_piquant_regression_proto_rawDescGZIP() []byte {
+	file_piquant_regression_proto_rawDescOnce.Do(func() {
+		file_piquant_regression_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_piquant_regression_proto_rawDesc), len(file_piquant_regression_proto_rawDesc)))
+	})
+	return file_piquant_regression_proto_rawDescData
+}
+
+var file_piquant_regression_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_piquant_regression_proto_goTypes = []any{
+	(*PiquantRegressionCase)(nil),          // 0: PiquantRegressionCase
+	(*PiquantRegressionTarget)(nil),        // 1: PiquantRegressionTarget
+	(*PiquantRegressionParams)(nil),        // 2: PiquantRegressionParams
+	(*PiquantRegressionElementResult)(nil), // 3: PiquantRegressionElementResult
+	(*PiquantRegressionSummary)(nil),       // 4: PiquantRegressionSummary
+}
+var file_piquant_regression_proto_depIdxs = []int32{
+	0, // 0: PiquantRegressionParams.cases:type_name -> PiquantRegressionCase
+	1, // 1: PiquantRegressionParams.baseline:type_name -> PiquantRegressionTarget
+	1, // 2: PiquantRegressionParams.candidate:type_name -> PiquantRegressionTarget
+	2, // 3: PiquantRegressionSummary.params:type_name -> PiquantRegressionParams
+	3, // 4: PiquantRegressionSummary.results:type_name -> PiquantRegressionElementResult
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_piquant_regression_proto_init() }
+func file_piquant_regression_proto_init() {
+	if File_piquant_regression_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_piquant_regression_proto_rawDesc), len(file_piquant_regression_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_piquant_regression_proto_goTypes,
+		DependencyIndexes: file_piquant_regression_proto_depIdxs,
+		MessageInfos:      file_piquant_regression_proto_msgTypes,
+	}.Build()
+	File_piquant_regression_proto = out.File
+	file_piquant_regression_proto_goTypes = nil
+	file_piquant_regression_proto_depIdxs = nil
+}
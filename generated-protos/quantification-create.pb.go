@@ -111,20 +111,161 @@ func (x *QuantCreateResp) GetStatus() *JobStatus {
 	return nil
 }
 
+// How far each node running PIQUANT for a quant has got
+type QuantNodeProgress struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	PmcListName     string                 `protobuf:"bytes,1,opt,name=pmcListName,proto3" json:"pmcListName,omitempty"`
+	SpectraComplete uint32                 `protobuf:"varint,2,opt,name=spectraComplete,proto3" json:"spectraComplete,omitempty"`
+	SpectraTotal    uint32                 `protobuf:"varint,3,opt,name=spectraTotal,proto3" json:"spectraTotal,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *QuantNodeProgress) Reset() {
+	*x = QuantNodeProgress{}
+	mi := &file_quantification_create_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuantNodeProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuantNodeProgress) ProtoMessage() {}
+
+func (x *QuantNodeProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_quantification_create_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuantNodeProgress.ProtoReflect.Descriptor instead.
+func (*QuantNodeProgress) Descriptor() ([]byte, []int) {
+	return file_quantification_create_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *QuantNodeProgress) GetPmcListName() string {
+	if x != nil {
+		return x.PmcListName
+	}
+	return ""
+}
+
+func (x *QuantNodeProgress) GetSpectraComplete() uint32 {
+	if x != nil {
+		return x.SpectraComplete
+	}
+	return 0
+}
+
+func (x *QuantNodeProgress) GetSpectraTotal() uint32 {
+	if x != nil {
+		return x.SpectraTotal
+	}
+	return 0
+}
+
+// Progress of a running map quant, combined from what each node has reported
+type QuantProgress struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	SpectraComplete uint32                 `protobuf:"varint,1,opt,name=spectraComplete,proto3" json:"spectraComplete,omitempty"`
+	SpectraTotal    uint32                 `protobuf:"varint,2,opt,name=spectraTotal,proto3" json:"spectraTotal,omitempty"`
+	PercentComplete float32                `protobuf:"fixed32,3,opt,name=percentComplete,proto3" json:"percentComplete,omitempty"`
+	// Estimated seconds until all nodes have finished
+	EtaSec        uint32               `protobuf:"varint,4,opt,name=etaSec,proto3" json:"etaSec,omitempty"`
+	Nodes         []*QuantNodeProgress `protobuf:"bytes,5,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QuantProgress) Reset() {
+	*x = QuantProgress{}
+	mi := &file_quantification_create_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuantProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuantProgress) ProtoMessage() {}
+
+func (x *QuantProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_quantification_create_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuantProgress.ProtoReflect.Descriptor instead.
+func (*QuantProgress) Descriptor() ([]byte, []int) {
+	return file_quantification_create_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *QuantProgress) GetSpectraComplete() uint32 {
+	if x != nil {
+		return x.SpectraComplete
+	}
+	return 0
+}
+
+func (x *QuantProgress) GetSpectraTotal() uint32 {
+	if x != nil {
+		return x.SpectraTotal
+	}
+	return 0
+}
+
+func (x *QuantProgress) GetPercentComplete() float32 {
+	if x != nil {
+		return x.PercentComplete
+	}
+	return 0
+}
+
+func (x *QuantProgress) GetEtaSec() uint32 {
+	if x != nil {
+		return x.EtaSec
+	}
+	return 0
+}
+
+func (x *QuantProgress) GetNodes() []*QuantNodeProgress {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
 // Updates are sent until the job timeout is reached, at which point a final update will
 // be sent saying the job has timed out. Hopefully it completes well before that though
 type QuantCreateUpd struct {
 	state  protoimpl.MessageState `protogen:"open.v1"`
 	Status *JobStatus             `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
 	// If the req just ran a one-shot quick command, the result is returned here
-	ResultData    []byte `protobuf:"bytes,2,opt,name=resultData,proto3" json:"resultData,omitempty"`
+	ResultData []byte `protobuf:"bytes,2,opt,name=resultData,proto3" json:"resultData,omitempty"`
+	// Only set for progress updates sent while PIQUANT is running
+	Progress      *QuantProgress `protobuf:"bytes,3,opt,name=progress,proto3" json:"progress,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *QuantCreateUpd) Reset() {
 	*x = QuantCreateUpd{}
-	mi := &file_quantification_create_proto_msgTypes[2]
+	mi := &file_quantification_create_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -136,7 +277,7 @@ func (x *QuantCreateUpd) String() string {
 func (*QuantCreateUpd) ProtoMessage() {}
 
 func (x *QuantCreateUpd) ProtoReflect() protoreflect.Message {
-	mi := &file_quantification_create_proto_msgTypes[2]
+	mi := &file_quantification_create_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -149,7 +290,7 @@ func (x *QuantCreateUpd) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use QuantCreateUpd.ProtoReflect.Descriptor instead.
 func (*QuantCreateUpd) Descriptor() ([]byte, []int) {
-	return file_quantification_create_proto_rawDescGZIP(), []int{2}
+	return file_quantification_create_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *QuantCreateUpd) GetStatus() *JobStatus {
@@ -166,6 +307,13 @@ func (x *QuantCreateUpd) GetResultData() []byte {
 	return nil
 }
 
+func (x *QuantCreateUpd) GetProgress() *QuantProgress {
+	if x != nil {
+		return x.Progress
+	}
+	return nil
+}
+
 var File_quantification_create_proto protoreflect.FileDescriptor
 
 const file_quantification_create_proto_rawDesc = "" +
@@ -175,13 +323,24 @@ const file_quantification_create_proto_rawDesc = "" +
 	"\x06params\x18\x01 \x01(\v2\x12.QuantCreateParamsR\x06params\"5\n" +
 	"\x0fQuantCreateResp\x12\"\n" +
 	"\x06status\x18\x01 \x01(\v2\n" +
-	".JobStatusR\x06status\"T\n" +
+	".JobStatusR\x06status\"\x83\x01\n" +
+	"\x11QuantNodeProgress\x12 \n" +
+	"\vpmcListName\x18\x01 \x01(\tR\vpmcListName\x12(\n" +
+	"\x0fspectraComplete\x18\x02 \x01(\rR\x0fspectraComplete\x12\"\n" +
+	"\fspectraTotal\x18\x03 \x01(\rR\fspectraTotal\"\xc9\x01\n" +
+	"\rQuantProgress\x12(\n" +
+	"\x0fspectraComplete\x18\x01 \x01(\rR\x0fspectraComplete\x12\"\n" +
+	"\fspectraTotal\x18\x02 \x01(\rR\fspectraTotal\x12(\n" +
+	"\x0fpercentComplete\x18\x03 \x01(\x02R\x0fpercentComplete\x12\x16\n" +
+	"\x06etaSec\x18\x04 \x01(\rR\x06etaSec\x12(\n" +
+	"\x05nodes\x18\x05 \x03(\v2\x12.QuantNodeProgressR\x05nodes\"\x80\x01\n" +
 	"\x0eQuantCreateUpd\x12\"\n" +
 	"\x06status\x18\x01 \x01(\v2\n" +
 	".JobStatusR\x06status\x12\x1e\n" +
 	"\n" +
 	"resultData\x18\x02 \x01(\fR\n" +
-	"resultDataB\n" +
+	"resultData\x12*\n" +
+	"\bprogress\x18\x03 \x01(\v2\x0e.QuantProgressR\bprogressB\n" +
 	"Z\b.;protosb\x06proto3"
 
 var (
@@ -196,23 +355,27 @@ func file_quantification_create_proto_rawDescGZIP() []byte {
 	return file_quantification_create_proto_rawDescData
 }
 
-var file_quantification_create_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_quantification_create_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
 var file_quantification_create_proto_goTypes = []any{
 	(*QuantCreateReq)(nil),    // 0: QuantCreateReq
 	(*QuantCreateResp)(nil),   // 1: QuantCreateResp
-	(*QuantCreateUpd)(nil),    // 2: QuantCreateUpd
-	(*QuantCreateParams)(nil), // 3: QuantCreateParams
-	(*JobStatus)(nil),         // 4: JobStatus
+	(*QuantNodeProgress)(nil), // 2: QuantNodeProgress
+	(*QuantProgress)(nil),     // 3: QuantProgress
+	(*QuantCreateUpd)(nil),    // 4: QuantCreateUpd
+	(*QuantCreateParams)(nil), // 5: QuantCreateParams
+	(*JobStatus)(nil),         // 6: JobStatus
 }
 var file_quantification_create_proto_depIdxs = []int32{
-	3, // 0: QuantCreateReq.params:type_name -> QuantCreateParams
-	4, // 1: QuantCreateResp.status:type_name -> JobStatus
-	4, // 2: QuantCreateUpd.status:type_name -> JobStatus
-	3, // [3:3] is the sub-list for method output_type
-	3, // [3:3] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	5, // 0: QuantCreateReq.params:type_name -> QuantCreateParams
+	6, // 1: QuantCreateResp.status:type_name -> JobStatus
+	2, // 2: QuantProgress.nodes:type_name -> QuantNodeProgress
+	6, // 3: QuantCreateUpd.status:type_name -> JobStatus
+	3, // 4: QuantCreateUpd.progress:type_name -> QuantProgress
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
 }
 
 func init() { file_quantification_create_proto_init() }
@@ -228,7 +391,7 @@ func file_quantification_create_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_quantification_create_proto_rawDesc), len(file_quantification_create_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   3,
+			NumMessages:   5,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -479,6 +479,157 @@ func (x *MultiQuantCompareResp) GetQuantTables() []*QuantComparisonTable {
 	return nil
 }
 
+// Compares quantifications element by element, eg to validate a new PIQUANT config version against the previous one
+// requires(QUANTIFY)
+type QuantDiffReq struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	ScanId string                 `protobuf:"bytes,1,opt,name=scanId,proto3" json:"scanId,omitempty"`
+	// The first is the reference that the others are compared to
+	QuantIds []string `protobuf:"bytes,2,rep,name=quantIds,proto3" json:"quantIds,omitempty"`
+	// Can include AllPoints
+	RoiIds []string `protobuf:"bytes,3,rep,name=roiIds,proto3" json:"roiIds,omitempty"`
+	// PMCs where the values differ by more than this (in weight %) are outliers. If 0, outliers are PMCs whose difference
+	// is over 3 standard deviations from the mean difference
+	OutlierThreshold float32 `protobuf:"fixed32,4,opt,name=outlierThreshold,proto3" json:"outlierThreshold,omitempty"`
+	IncludePMCDeltas bool    `protobuf:"varint,5,opt,name=includePMCDeltas,proto3" json:"includePMCDeltas,omitempty"`
+	IncludeCSV       bool    `protobuf:"varint,6,opt,name=includeCSV,proto3" json:"includeCSV,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *QuantDiffReq) Reset() {
+	*x = QuantDiffReq{}
+	mi := &file_quantification_multi_msgs_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuantDiffReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuantDiffReq) ProtoMessage() {}
+
+func (x *QuantDiffReq) ProtoReflect() protoreflect.Message {
+	mi := &file_quantification_multi_msgs_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuantDiffReq.ProtoReflect.Descriptor instead.
+func (*QuantDiffReq) Descriptor() ([]byte, []int) {
+	return file_quantification_multi_msgs_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *QuantDiffReq) GetScanId() string {
+	if x != nil {
+		return x.ScanId
+	}
+	return ""
+}
+
+func (x *QuantDiffReq) GetQuantIds() []string {
+	if x != nil {
+		return x.QuantIds
+	}
+	return nil
+}
+
+func (x *QuantDiffReq) GetRoiIds() []string {
+	if x != nil {
+		return x.RoiIds
+	}
+	return nil
+}
+
+func (x *QuantDiffReq) GetOutlierThreshold() float32 {
+	if x != nil {
+		return x.OutlierThreshold
+	}
+	return 0
+}
+
+func (x *QuantDiffReq) GetIncludePMCDeltas() bool {
+	if x != nil {
+		return x.IncludePMCDeltas
+	}
+	return false
+}
+
+func (x *QuantDiffReq) GetIncludeCSV() bool {
+	if x != nil {
+		return x.IncludeCSV
+	}
+	return false
+}
+
+type QuantDiffResp struct {
+	state       protoimpl.MessageState    `protogen:"open.v1"`
+	Comparisons []*QuantElementComparison `protobuf:"bytes,1,rep,name=comparisons,proto3" json:"comparisons,omitempty"`
+	// Only set if requested. The statistics, and if PMC deltas were requested, a row per PMC
+	Csv           string `protobuf:"bytes,2,opt,name=csv,proto3" json:"csv,omitempty"`
+	PmcDeltasCSV  string `protobuf:"bytes,3,opt,name=pmcDeltasCSV,proto3" json:"pmcDeltasCSV,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QuantDiffResp) Reset() {
+	*x = QuantDiffResp{}
+	mi := &file_quantification_multi_msgs_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuantDiffResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuantDiffResp) ProtoMessage() {}
+
+func (x *QuantDiffResp) ProtoReflect() protoreflect.Message {
+	mi := &file_quantification_multi_msgs_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuantDiffResp.ProtoReflect.Descriptor instead.
+func (*QuantDiffResp) Descriptor() ([]byte, []int) {
+	return file_quantification_multi_msgs_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *QuantDiffResp) GetComparisons() []*QuantElementComparison {
+	if x != nil {
+		return x.Comparisons
+	}
+	return nil
+}
+
+func (x *QuantDiffResp) GetCsv() string {
+	if x != nil {
+		return x.Csv
+	}
+	return ""
+}
+
+func (x *QuantDiffResp) GetPmcDeltasCSV() string {
+	if x != nil {
+		return x.PmcDeltasCSV
+	}
+	return ""
+}
+
 var File_quantification_multi_msgs_proto protoreflect.FileDescriptor
 
 const file_quantification_multi_msgs_proto_rawDesc = "" +
@@ -509,7 +660,20 @@ const file_quantification_multi_msgs_proto_rawDesc = "" +
 	"\x13remainingPointsPMCs\x18\x04 \x03(\x05R\x13remainingPointsPMCs\"f\n" +
 	"\x15MultiQuantCompareResp\x12\x14\n" +
 	"\x05roiId\x18\x01 \x01(\tR\x05roiId\x127\n" +
-	"\vquantTables\x18\x02 \x03(\v2\x15.QuantComparisonTableR\vquantTablesB\n" +
+	"\vquantTables\x18\x02 \x03(\v2\x15.QuantComparisonTableR\vquantTables\"\xd2\x01\n" +
+	"\fQuantDiffReq\x12\x16\n" +
+	"\x06scanId\x18\x01 \x01(\tR\x06scanId\x12\x1a\n" +
+	"\bquantIds\x18\x02 \x03(\tR\bquantIds\x12\x16\n" +
+	"\x06roiIds\x18\x03 \x03(\tR\x06roiIds\x12*\n" +
+	"\x10outlierThreshold\x18\x04 \x01(\x02R\x10outlierThreshold\x12*\n" +
+	"\x10includePMCDeltas\x18\x05 \x01(\bR\x10includePMCDeltas\x12\x1e\n" +
+	"\n" +
+	"includeCSV\x18\x06 \x01(\bR\n" +
+	"includeCSV\"\x80\x01\n" +
+	"\rQuantDiffResp\x129\n" +
+	"\vcomparisons\x18\x01 \x03(\v2\x17.QuantElementComparisonR\vcomparisons\x12\x10\n" +
+	"\x03csv\x18\x02 \x01(\tR\x03csv\x12\"\n" +
+	"\fpmcDeltasCSV\x18\x03 \x01(\tR\fpmcDeltasCSVB\n" +
 	"Z\b.;protosb\x06proto3"
 
 var (
@@ -524,7 +688,7 @@ func file_quantification_multi_msgs_proto_rawDescGZIP() []byte {
 	return file_quantification_multi_msgs_proto_rawDescData
 }
 
-var file_quantification_multi_msgs_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_quantification_multi_msgs_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
 var file_quantification_multi_msgs_proto_goTypes = []any{
 	(*QuantCombineReq)(nil),           // 0: QuantCombineReq
 	(*QuantCombineResp)(nil),          // 1: QuantCombineResp
@@ -534,22 +698,26 @@ var file_quantification_multi_msgs_proto_goTypes = []any{
 	(*QuantCombineListWriteResp)(nil), // 5: QuantCombineListWriteResp
 	(*MultiQuantCompareReq)(nil),      // 6: MultiQuantCompareReq
 	(*MultiQuantCompareResp)(nil),     // 7: MultiQuantCompareResp
-	(*QuantCombineItem)(nil),          // 8: QuantCombineItem
-	(*QuantCombineSummary)(nil),       // 9: QuantCombineSummary
-	(*QuantCombineItemList)(nil),      // 10: QuantCombineItemList
-	(*QuantComparisonTable)(nil),      // 11: QuantComparisonTable
+	(*QuantDiffReq)(nil),              // 8: QuantDiffReq
+	(*QuantDiffResp)(nil),             // 9: QuantDiffResp
+	(*QuantCombineItem)(nil),          // 10: QuantCombineItem
+	(*QuantCombineSummary)(nil),       // 11: QuantCombineSummary
+	(*QuantCombineItemList)(nil),      // 12: QuantCombineItemList
+	(*QuantComparisonTable)(nil),      // 13: QuantComparisonTable
+	(*QuantElementComparison)(nil),    // 14: QuantElementComparison
 }
 var file_quantification_multi_msgs_proto_depIdxs = []int32{
-	8,  // 0: QuantCombineReq.roiZStack:type_name -> QuantCombineItem
-	9,  // 1: QuantCombineResp.summary:type_name -> QuantCombineSummary
-	10, // 2: QuantCombineListGetResp.list:type_name -> QuantCombineItemList
-	10, // 3: QuantCombineListWriteReq.list:type_name -> QuantCombineItemList
-	11, // 4: MultiQuantCompareResp.quantTables:type_name -> QuantComparisonTable
-	5,  // [5:5] is the sub-list for method output_type
-	5,  // [5:5] is the sub-list for method input_type
-	5,  // [5:5] is the sub-list for extension type_name
-	5,  // [5:5] is the sub-list for extension extendee
-	0,  // [0:5] is the sub-list for field type_name
+	10, // 0: QuantCombineReq.roiZStack:type_name -> QuantCombineItem
+	11, // 1: QuantCombineResp.summary:type_name -> QuantCombineSummary
+	12, // 2: QuantCombineListGetResp.list:type_name -> QuantCombineItemList
+	12, // 3: QuantCombineListWriteReq.list:type_name -> QuantCombineItemList
+	13, // 4: MultiQuantCompareResp.quantTables:type_name -> QuantComparisonTable
+	14, // 5: QuantDiffResp.comparisons:type_name -> QuantElementComparison
+	6,  // [6:6] is the sub-list for method output_type
+	6,  // [6:6] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
 }
 
 func init() { file_quantification_multi_msgs_proto_init() }
@@ -568,7 +736,7 @@ func file_quantification_multi_msgs_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_quantification_multi_msgs_proto_rawDesc), len(file_quantification_multi_msgs_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   8,
+			NumMessages:   10,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -357,6 +357,219 @@ func (x *QuantComparisonTable) GetElementWeights() map[string]float32 {
 	return nil
 }
 
+// Compares one quant's values for an element to the reference quant's, over the PMCs of an ROI that both have values for
+type QuantElementComparison struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	RoiId    string                 `protobuf:"bytes,1,opt,name=roiId,proto3" json:"roiId,omitempty"`
+	Detector string                 `protobuf:"bytes,2,opt,name=detector,proto3" json:"detector,omitempty"`
+	// Weight % column, eg "FeO-T_%"
+	Element         string  `protobuf:"bytes,3,opt,name=element,proto3" json:"element,omitempty"`
+	QuantId         string  `protobuf:"bytes,4,opt,name=quantId,proto3" json:"quantId,omitempty"`
+	PmcCount        uint32  `protobuf:"varint,5,opt,name=pmcCount,proto3" json:"pmcCount,omitempty"`
+	ReferenceMean   float32 `protobuf:"fixed32,6,opt,name=referenceMean,proto3" json:"referenceMean,omitempty"`
+	ReferenceStdDev float32 `protobuf:"fixed32,7,opt,name=referenceStdDev,proto3" json:"referenceStdDev,omitempty"`
+	Mean            float32 `protobuf:"fixed32,8,opt,name=mean,proto3" json:"mean,omitempty"`
+	StdDev          float32 `protobuf:"fixed32,9,opt,name=stdDev,proto3" json:"stdDev,omitempty"`
+	// Statistics of this quant's value minus the reference quant's value
+	MeanDelta   float32 `protobuf:"fixed32,10,opt,name=meanDelta,proto3" json:"meanDelta,omitempty"`
+	DeltaStdDev float32 `protobuf:"fixed32,11,opt,name=deltaStdDev,proto3" json:"deltaStdDev,omitempty"`
+	// Pearson correlation of the two quants' values. 0 if either has no variance
+	Correlation float32 `protobuf:"fixed32,12,opt,name=correlation,proto3" json:"correlation,omitempty"`
+	OutlierPMCs []int32 `protobuf:"varint,13,rep,packed,name=outlierPMCs,proto3" json:"outlierPMCs,omitempty"`
+	// Only set if requested
+	PmcDeltas     []*QuantPMCDelta `protobuf:"bytes,14,rep,name=pmcDeltas,proto3" json:"pmcDeltas,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QuantElementComparison) Reset() {
+	*x = QuantElementComparison{}
+	mi := &file_quantification_multi_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuantElementComparison) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuantElementComparison) ProtoMessage() {}
+
+func (x *QuantElementComparison) ProtoReflect() protoreflect.Message {
+	mi := &file_quantification_multi_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuantElementComparison.ProtoReflect.Descriptor instead.
+func (*QuantElementComparison) Descriptor() ([]byte, []int) {
+	return file_quantification_multi_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *QuantElementComparison) GetRoiId() string {
+	if x != nil {
+		return x.RoiId
+	}
+	return ""
+}
+
+func (x *QuantElementComparison) GetDetector() string {
+	if x != nil {
+		return x.Detector
+	}
+	return ""
+}
+
+func (x *QuantElementComparison) GetElement() string {
+	if x != nil {
+		return x.Element
+	}
+	return ""
+}
+
+func (x *QuantElementComparison) GetQuantId() string {
+	if x != nil {
+		return x.QuantId
+	}
+	return ""
+}
+
+func (x *QuantElementComparison) GetPmcCount() uint32 {
+	if x != nil {
+		return x.PmcCount
+	}
+	return 0
+}
+
+func (x *QuantElementComparison) GetReferenceMean() float32 {
+	if x != nil {
+		return x.ReferenceMean
+	}
+	return 0
+}
+
+func (x *QuantElementComparison) GetReferenceStdDev() float32 {
+	if x != nil {
+		return x.ReferenceStdDev
+	}
+	return 0
+}
+
+func (x *QuantElementComparison) GetMean() float32 {
+	if x != nil {
+		return x.Mean
+	}
+	return 0
+}
+
+func (x *QuantElementComparison) GetStdDev() float32 {
+	if x != nil {
+		return x.StdDev
+	}
+	return 0
+}
+
+func (x *QuantElementComparison) GetMeanDelta() float32 {
+	if x != nil {
+		return x.MeanDelta
+	}
+	return 0
+}
+
+func (x *QuantElementComparison) GetDeltaStdDev() float32 {
+	if x != nil {
+		return x.DeltaStdDev
+	}
+	return 0
+}
+
+func (x *QuantElementComparison) GetCorrelation() float32 {
+	if x != nil {
+		return x.Correlation
+	}
+	return 0
+}
+
+func (x *QuantElementComparison) GetOutlierPMCs() []int32 {
+	if x != nil {
+		return x.OutlierPMCs
+	}
+	return nil
+}
+
+func (x *QuantElementComparison) GetPmcDeltas() []*QuantPMCDelta {
+	if x != nil {
+		return x.PmcDeltas
+	}
+	return nil
+}
+
+type QuantPMCDelta struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Pmc            int32                  `protobuf:"varint,1,opt,name=pmc,proto3" json:"pmc,omitempty"`
+	ReferenceValue float32                `protobuf:"fixed32,2,opt,name=referenceValue,proto3" json:"referenceValue,omitempty"`
+	Value          float32                `protobuf:"fixed32,3,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *QuantPMCDelta) Reset() {
+	*x = QuantPMCDelta{}
+	mi := &file_quantification_multi_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuantPMCDelta) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuantPMCDelta) ProtoMessage() {}
+
+func (x *QuantPMCDelta) ProtoReflect() protoreflect.Message {
+	mi := &file_quantification_multi_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuantPMCDelta.ProtoReflect.Descriptor instead.
+func (*QuantPMCDelta) Descriptor() ([]byte, []int) {
+	return file_quantification_multi_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *QuantPMCDelta) GetPmc() int32 {
+	if x != nil {
+		return x.Pmc
+	}
+	return 0
+}
+
+func (x *QuantPMCDelta) GetReferenceValue() float32 {
+	if x != nil {
+		return x.ReferenceValue
+	}
+	return 0
+}
+
+func (x *QuantPMCDelta) GetValue() float32 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
 var File_quantification_multi_proto protoreflect.FileDescriptor
 
 const file_quantification_multi_proto_rawDesc = "" +
@@ -388,7 +601,27 @@ const file_quantification_multi_proto_rawDesc = "" +
 	"\x0eelementWeights\x18\x03 \x03(\v2).QuantComparisonTable.ElementWeightsEntryR\x0eelementWeights\x1aA\n" +
 	"\x13ElementWeightsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\x02R\x05value:\x028\x01B\n" +
+	"\x05value\x18\x02 \x01(\x02R\x05value:\x028\x01\"\xc8\x03\n" +
+	"\x16QuantElementComparison\x12\x14\n" +
+	"\x05roiId\x18\x01 \x01(\tR\x05roiId\x12\x1a\n" +
+	"\bdetector\x18\x02 \x01(\tR\bdetector\x12\x18\n" +
+	"\aelement\x18\x03 \x01(\tR\aelement\x12\x18\n" +
+	"\aquantId\x18\x04 \x01(\tR\aquantId\x12\x1a\n" +
+	"\bpmcCount\x18\x05 \x01(\rR\bpmcCount\x12$\n" +
+	"\rreferenceMean\x18\x06 \x01(\x02R\rreferenceMean\x12(\n" +
+	"\x0freferenceStdDev\x18\a \x01(\x02R\x0freferenceStdDev\x12\x12\n" +
+	"\x04mean\x18\b \x01(\x02R\x04mean\x12\x16\n" +
+	"\x06stdDev\x18\t \x01(\x02R\x06stdDev\x12\x1c\n" +
+	"\tmeanDelta\x18\n" +
+	" \x01(\x02R\tmeanDelta\x12 \n" +
+	"\vdeltaStdDev\x18\v \x01(\x02R\vdeltaStdDev\x12 \n" +
+	"\vcorrelation\x18\f \x01(\x02R\vcorrelation\x12 \n" +
+	"\voutlierPMCs\x18\r \x03(\x05R\voutlierPMCs\x12,\n" +
+	"\tpmcDeltas\x18\x0e \x03(\v2\x0e.QuantPMCDeltaR\tpmcDeltas\"_\n" +
+	"\rQuantPMCDelta\x12\x10\n" +
+	"\x03pmc\x18\x01 \x01(\x05R\x03pmc\x12&\n" +
+	"\x0ereferenceValue\x18\x02 \x01(\x02R\x0ereferenceValue\x12\x14\n" +
+	"\x05value\x18\x03 \x01(\x02R\x05valueB\n" +
 	"Z\b.;protosb\x06proto3"
 
 var (
@@ -403,7 +636,7 @@ func file_quantification_multi_proto_rawDescGZIP() []byte {
 	return file_quantification_multi_proto_rawDescData
 }
 
-var file_quantification_multi_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_quantification_multi_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
 var file_quantification_multi_proto_goTypes = []any{
 	(*QuantCombineItem)(nil),       // 0: QuantCombineItem
 	(*QuantCombineItemList)(nil),   // 1: QuantCombineItemList
@@ -411,20 +644,23 @@ var file_quantification_multi_proto_goTypes = []any{
 	(*QuantCombineSummaryRow)(nil), // 3: QuantCombineSummaryRow
 	(*QuantCombineSummary)(nil),    // 4: QuantCombineSummary
 	(*QuantComparisonTable)(nil),   // 5: QuantComparisonTable
-	nil,                            // 6: QuantCombineSummary.WeightPercentsEntry
-	nil,                            // 7: QuantComparisonTable.ElementWeightsEntry
+	(*QuantElementComparison)(nil), // 6: QuantElementComparison
+	(*QuantPMCDelta)(nil),          // 7: QuantPMCDelta
+	nil,                            // 8: QuantCombineSummary.WeightPercentsEntry
+	nil,                            // 9: QuantComparisonTable.ElementWeightsEntry
 }
 var file_quantification_multi_proto_depIdxs = []int32{
 	0, // 0: QuantCombineItemList.roiZStack:type_name -> QuantCombineItem
 	1, // 1: QuantCombineItemListDB.list:type_name -> QuantCombineItemList
-	6, // 2: QuantCombineSummary.weightPercents:type_name -> QuantCombineSummary.WeightPercentsEntry
-	7, // 3: QuantComparisonTable.elementWeights:type_name -> QuantComparisonTable.ElementWeightsEntry
-	3, // 4: QuantCombineSummary.WeightPercentsEntry.value:type_name -> QuantCombineSummaryRow
-	5, // [5:5] is the sub-list for method output_type
-	5, // [5:5] is the sub-list for method input_type
-	5, // [5:5] is the sub-list for extension type_name
-	5, // [5:5] is the sub-list for extension extendee
-	0, // [0:5] is the sub-list for field type_name
+	8, // 2: QuantCombineSummary.weightPercents:type_name -> QuantCombineSummary.WeightPercentsEntry
+	9, // 3: QuantComparisonTable.elementWeights:type_name -> QuantComparisonTable.ElementWeightsEntry
+	7, // 4: QuantElementComparison.pmcDeltas:type_name -> QuantPMCDelta
+	3, // 5: QuantCombineSummary.WeightPercentsEntry.value:type_name -> QuantCombineSummaryRow
+	6, // [6:6] is the sub-list for method output_type
+	6, // [6:6] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
 }
 
 func init() { file_quantification_multi_proto_init() }
@@ -438,7 +674,7 @@ func file_quantification_multi_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_quantification_multi_proto_rawDesc), len(file_quantification_multi_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   8,
+			NumMessages:   10,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -0,0 +1,426 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v3.21.12
+// source: revision-msgs.proto
+
+package protos
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Lists revisions of an object, newest first, without the object contents. Requires view access to the object
+// requires(NONE)
+type ObjectRevisionListReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ObjectId      string                 `protobuf:"bytes,1,opt,name=objectId,proto3" json:"objectId,omitempty"`
+	ObjectType    ObjectType             `protobuf:"varint,2,opt,name=objectType,proto3,enum=ObjectType" json:"objectType,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ObjectRevisionListReq) Reset() {
+	*x = ObjectRevisionListReq{}
+	mi := &file_revision_msgs_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ObjectRevisionListReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ObjectRevisionListReq) ProtoMessage() {}
+
+func (x *ObjectRevisionListReq) ProtoReflect() protoreflect.Message {
+	mi := &file_revision_msgs_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ObjectRevisionListReq.ProtoReflect.Descriptor instead.
+func (*ObjectRevisionListReq) Descriptor() ([]byte, []int) {
+	return file_revision_msgs_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ObjectRevisionListReq) GetObjectId() string {
+	if x != nil {
+		return x.ObjectId
+	}
+	return ""
+}
+
+func (x *ObjectRevisionListReq) GetObjectType() ObjectType {
+	if x != nil {
+		return x.ObjectType
+	}
+	return ObjectType_OT_UNKNOWN
+}
+
+type ObjectRevisionListResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Revisions     []*ObjectRevision      `protobuf:"bytes,1,rep,name=revisions,proto3" json:"revisions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ObjectRevisionListResp) Reset() {
+	*x = ObjectRevisionListResp{}
+	mi := &file_revision_msgs_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ObjectRevisionListResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ObjectRevisionListResp) ProtoMessage() {}
+
+func (x *ObjectRevisionListResp) ProtoReflect() protoreflect.Message {
+	mi := &file_revision_msgs_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ObjectRevisionListResp.ProtoReflect.Descriptor instead.
+func (*ObjectRevisionListResp) Descriptor() ([]byte, []int) {
+	return file_revision_msgs_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ObjectRevisionListResp) GetRevisions() []*ObjectRevision {
+	if x != nil {
+		return x.Revisions
+	}
+	return nil
+}
+
+// Gets a revision of an object, including what the object looked like at that point. Requires view access to the object
+// requires(NONE)
+type ObjectRevisionGetReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ObjectId      string                 `protobuf:"bytes,1,opt,name=objectId,proto3" json:"objectId,omitempty"`
+	ObjectType    ObjectType             `protobuf:"varint,2,opt,name=objectType,proto3,enum=ObjectType" json:"objectType,omitempty"`
+	Revision      uint32                 `protobuf:"varint,3,opt,name=revision,proto3" json:"revision,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ObjectRevisionGetReq) Reset() {
+	*x = ObjectRevisionGetReq{}
+	mi := &file_revision_msgs_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ObjectRevisionGetReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ObjectRevisionGetReq) ProtoMessage() {}
+
+func (x *ObjectRevisionGetReq) ProtoReflect() protoreflect.Message {
+	mi := &file_revision_msgs_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ObjectRevisionGetReq.ProtoReflect.Descriptor instead.
+func (*ObjectRevisionGetReq) Descriptor() ([]byte, []int) {
+	return file_revision_msgs_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ObjectRevisionGetReq) GetObjectId() string {
+	if x != nil {
+		return x.ObjectId
+	}
+	return ""
+}
+
+func (x *ObjectRevisionGetReq) GetObjectType() ObjectType {
+	if x != nil {
+		return x.ObjectType
+	}
+	return ObjectType_OT_UNKNOWN
+}
+
+func (x *ObjectRevisionGetReq) GetRevision() uint32 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+type ObjectRevisionGetResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Revision      *ObjectRevision        `protobuf:"bytes,1,opt,name=revision,proto3" json:"revision,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ObjectRevisionGetResp) Reset() {
+	*x = ObjectRevisionGetResp{}
+	mi := &file_revision_msgs_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ObjectRevisionGetResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ObjectRevisionGetResp) ProtoMessage() {}
+
+func (x *ObjectRevisionGetResp) ProtoReflect() protoreflect.Message {
+	mi := &file_revision_msgs_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ObjectRevisionGetResp.ProtoReflect.Descriptor instead.
+func (*ObjectRevisionGetResp) Descriptor() ([]byte, []int) {
+	return file_revision_msgs_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ObjectRevisionGetResp) GetRevision() *ObjectRevision {
+	if x != nil {
+		return x.Revision
+	}
+	return nil
+}
+
+// Overwrites the object with what it looked like at the given revision. This is saved as a new revision, so it can be
+// undone. Requires edit access to the object
+// requires(NONE)
+type ObjectRevisionRestoreReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ObjectId      string                 `protobuf:"bytes,1,opt,name=objectId,proto3" json:"objectId,omitempty"`
+	ObjectType    ObjectType             `protobuf:"varint,2,opt,name=objectType,proto3,enum=ObjectType" json:"objectType,omitempty"`
+	Revision      uint32                 `protobuf:"varint,3,opt,name=revision,proto3" json:"revision,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ObjectRevisionRestoreReq) Reset() {
+	*x = ObjectRevisionRestoreReq{}
+	mi := &file_revision_msgs_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ObjectRevisionRestoreReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ObjectRevisionRestoreReq) ProtoMessage() {}
+
+func (x *ObjectRevisionRestoreReq) ProtoReflect() protoreflect.Message {
+	mi := &file_revision_msgs_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ObjectRevisionRestoreReq.ProtoReflect.Descriptor instead.
+func (*ObjectRevisionRestoreReq) Descriptor() ([]byte, []int) {
+	return file_revision_msgs_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ObjectRevisionRestoreReq) GetObjectId() string {
+	if x != nil {
+		return x.ObjectId
+	}
+	return ""
+}
+
+func (x *ObjectRevisionRestoreReq) GetObjectType() ObjectType {
+	if x != nil {
+		return x.ObjectType
+	}
+	return ObjectType_OT_UNKNOWN
+}
+
+func (x *ObjectRevisionRestoreReq) GetRevision() uint32 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+type ObjectRevisionRestoreResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Revision      *ObjectRevision        `protobuf:"bytes,1,opt,name=revision,proto3" json:"revision,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ObjectRevisionRestoreResp) Reset() {
+	*x = ObjectRevisionRestoreResp{}
+	mi := &file_revision_msgs_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ObjectRevisionRestoreResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ObjectRevisionRestoreResp) ProtoMessage() {}
+
+func (x *ObjectRevisionRestoreResp) ProtoReflect() protoreflect.Message {
+	mi := &file_revision_msgs_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ObjectRevisionRestoreResp.ProtoReflect.Descriptor instead.
+func (*ObjectRevisionRestoreResp) Descriptor() ([]byte, []int) {
+	return file_revision_msgs_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ObjectRevisionRestoreResp) GetRevision() *ObjectRevision {
+	if x != nil {
+		return x.Revision
+	}
+	return nil
+}
+
+var File_revision_msgs_proto protoreflect.FileDescriptor
+
+const file_revision_msgs_proto_rawDesc = "" +
+	"\n" +
+	"\x13revision-msgs.proto\x1a\x16ownership-access.proto\x1a\x0erevision.proto\"`\n" +
+	"\x15ObjectRevisionListReq\x12\x1a\n" +
+	"\bobjectId\x18\x01 \x01(\tR\bobjectId\x12+\n" +
+	"\n" +
+	"objectType\x18\x02 \x01(\x0e2\v.ObjectTypeR\n" +
+	"objectType\"G\n" +
+	"\x16ObjectRevisionListResp\x12-\n" +
+	"\trevisions\x18\x01 \x03(\v2\x0f.ObjectRevisionR\trevisions\"{\n" +
+	"\x14ObjectRevisionGetReq\x12\x1a\n" +
+	"\bobjectId\x18\x01 \x01(\tR\bobjectId\x12+\n" +
+	"\n" +
+	"objectType\x18\x02 \x01(\x0e2\v.ObjectTypeR\n" +
+	"objectType\x12\x1a\n" +
+	"\brevision\x18\x03 \x01(\rR\brevision\"D\n" +
+	"\x15ObjectRevisionGetResp\x12+\n" +
+	"\brevision\x18\x01 \x01(\v2\x0f.ObjectRevisionR\brevision\"\x7f\n" +
+	"\x18ObjectRevisionRestoreReq\x12\x1a\n" +
+	"\bobjectId\x18\x01 \x01(\tR\bobjectId\x12+\n" +
+	"\n" +
+	"objectType\x18\x02 \x01(\x0e2\v.ObjectTypeR\n" +
+	"objectType\x12\x1a\n" +
+	"\brevision\x18\x03 \x01(\rR\brevision\"H\n" +
+	"\x19ObjectRevisionRestoreResp\x12+\n" +
+	"\brevision\x18\x01 \x01(\v2\x0f.ObjectRevisionR\brevisionB\n" +
+	"Z\b.;protosb\x06proto3"
+
+var (
+	file_revision_msgs_proto_rawDescOnce sync.Once
+	file_revision_msgs_proto_rawDescData []byte
+)
+
+func file_revision_msgs_proto_rawDescGZIP() []byte {
+	file_revision_msgs_proto_rawDescOnce.Do(func() {
+		file_revision_msgs_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_revision_msgs_proto_rawDesc), len(file_revision_msgs_proto_rawDesc)))
+	})
+	return file_revision_msgs_proto_rawDescData
+}
+
+var file_revision_msgs_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_revision_msgs_proto_goTypes = []any{
+	(*ObjectRevisionListReq)(nil),     // 0: ObjectRevisionListReq
+	(*ObjectRevisionListResp)(nil),    // 1: ObjectRevisionListResp
+	(*ObjectRevisionGetReq)(nil),      // 2: ObjectRevisionGetReq
+	(*ObjectRevisionGetResp)(nil),     // 3: ObjectRevisionGetResp
+	(*ObjectRevisionRestoreReq)(nil),  // 4: ObjectRevisionRestoreReq
+	(*ObjectRevisionRestoreResp)(nil), // 5: ObjectRevisionRestoreResp
+	(ObjectType)(0),                   // 6: ObjectType
+	(*ObjectRevision)(nil),            // 7: ObjectRevision
+}
+var file_revision_msgs_proto_depIdxs = []int32{
+	6, // 0: ObjectRevisionListReq.objectType:type_name -> ObjectType
+	7, // 1: ObjectRevisionListResp.revisions:type_name -> ObjectRevision
+	6, // 2: ObjectRevisionGetReq.objectType:type_name -> ObjectType
+	7, // 3: ObjectRevisionGetResp.revision:type_name -> ObjectRevision
+	6, // 4: ObjectRevisionRestoreReq.objectType:type_name -> ObjectType
+	7, // 5: ObjectRevisionRestoreResp.revision:type_name -> ObjectRevision
+	6, // [6:6] is the sub-list for method output_type
+	6, // [6:6] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_revision_msgs_proto_init() }
+func file_revision_msgs_proto_init() {
+	if File_revision_msgs_proto != nil {
+		return
+	}
+	file_ownership_access_proto_init()
+	file_revision_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_revision_msgs_proto_rawDesc), len(file_revision_msgs_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_revision_msgs_proto_goTypes,
+		DependencyIndexes: file_revision_msgs_proto_depIdxs,
+		MessageInfos:      file_revision_msgs_proto_msgTypes,
+	}.Build()
+	File_revision_msgs_proto = out.File
+	file_revision_msgs_proto_goTypes = nil
+	file_revision_msgs_proto_depIdxs = nil
+}
@@ -0,0 +1,252 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v3.21.12
+// source: revision.proto
+
+package protos
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// A saved version of a user object. One is written each time the object is created or updated, so older versions can be
+// viewed or restored. Only the field matching objectType is set. When listing revisions, none are set
+type ObjectRevision struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Id         string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty" bson:"_id,omitempty"`  
+	ObjectId   string                 `protobuf:"bytes,2,opt,name=objectId,proto3" json:"objectId,omitempty"`
+	ObjectType ObjectType             `protobuf:"varint,3,opt,name=objectType,proto3,enum=ObjectType" json:"objectType,omitempty"`
+	// Starts at 1 when the object is created
+	Revision uint32 `protobuf:"varint,4,opt,name=revision,proto3" json:"revision,omitempty"`
+	// Who saved it and when
+	AuthorUserId     string `protobuf:"bytes,5,opt,name=authorUserId,proto3" json:"authorUserId,omitempty"`
+	TimeStampUnixSec uint32 `protobuf:"varint,6,opt,name=timeStampUnixSec,proto3" json:"timeStampUnixSec,omitempty"`
+	// If this revision was written by restoring an older one, the revision that was restored
+	RestoredFromRevision uint32               `protobuf:"varint,7,opt,name=restoredFromRevision,proto3" json:"restoredFromRevision,omitempty"`
+	Roi                  *ROIItem             `protobuf:"bytes,8,opt,name=roi,proto3" json:"roi,omitempty"`
+	Expression           *DataExpression      `protobuf:"bytes,9,opt,name=expression,proto3" json:"expression,omitempty"`
+	ExpressionGroup      *ExpressionGroup     `protobuf:"bytes,10,opt,name=expressionGroup,proto3" json:"expressionGroup,omitempty"`
+	ElementSet           *ElementSet          `protobuf:"bytes,11,opt,name=elementSet,proto3" json:"elementSet,omitempty"`
+	ScreenConfiguration  *ScreenConfiguration `protobuf:"bytes,12,opt,name=screenConfiguration,proto3" json:"screenConfiguration,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *ObjectRevision) Reset() {
+	*x = ObjectRevision{}
+	mi := &file_revision_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ObjectRevision) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ObjectRevision) ProtoMessage() {}
+
+func (x *ObjectRevision) ProtoReflect() protoreflect.Message {
+	mi := &file_revision_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ObjectRevision.ProtoReflect.Descriptor instead.
+func (*ObjectRevision) Descriptor() ([]byte, []int) {
+	return file_revision_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ObjectRevision) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ObjectRevision) GetObjectId() string {
+	if x != nil {
+		return x.ObjectId
+	}
+	return ""
+}
+
+func (x *ObjectRevision) GetObjectType() ObjectType {
+	if x != nil {
+		return x.ObjectType
+	}
+	return ObjectType_OT_UNKNOWN
+}
+
+func (x *ObjectRevision) GetRevision() uint32 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+func (x *ObjectRevision) GetAuthorUserId() string {
+	if x != nil {
+		return x.AuthorUserId
+	}
+	return ""
+}
+
+func (x *ObjectRevision) GetTimeStampUnixSec() uint32 {
+	if x != nil {
+		return x.TimeStampUnixSec
+	}
+	return 0
+}
+
+func (x *ObjectRevision) GetRestoredFromRevision() uint32 {
+	if x != nil {
+		return x.RestoredFromRevision
+	}
+	return 0
+}
+
+func (x *ObjectRevision) GetRoi() *ROIItem {
+	if x != nil {
+		return x.Roi
+	}
+	return nil
+}
+
+func (x *ObjectRevision) GetExpression() *DataExpression {
+	if x != nil {
+		return x.Expression
+	}
+	return nil
+}
+
+func (x *ObjectRevision) GetExpressionGroup() *ExpressionGroup {
+	if x != nil {
+		return x.ExpressionGroup
+	}
+	return nil
+}
+
+func (x *ObjectRevision) GetElementSet() *ElementSet {
+	if x != nil {
+		return x.ElementSet
+	}
+	return nil
+}
+
+func (x *ObjectRevision) GetScreenConfiguration() *ScreenConfiguration {
+	if x != nil {
+		return x.ScreenConfiguration
+	}
+	return nil
+}
+
+var File_revision_proto protoreflect.FileDescriptor
+
+const file_revision_proto_rawDesc = "" +
+	"\n" +
+	"\x0erevision.proto\x1a\x16ownership-access.proto\x1a\troi.proto\x1a\x11expressions.proto\x1a\x16expression-group.proto\x1a\x11element-set.proto\x1a\x1ascreen-configuration.proto\"\x87\x04\n" +
+	"\x0eObjectRevision\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
+	"\bobjectId\x18\x02 \x01(\tR\bobjectId\x12+\n" +
+	"\n" +
+	"objectType\x18\x03 \x01(\x0e2\v.ObjectTypeR\n" +
+	"objectType\x12\x1a\n" +
+	"\brevision\x18\x04 \x01(\rR\brevision\x12\"\n" +
+	"\fauthorUserId\x18\x05 \x01(\tR\fauthorUserId\x12*\n" +
+	"\x10timeStampUnixSec\x18\x06 \x01(\rR\x10timeStampUnixSec\x122\n" +
+	"\x14restoredFromRevision\x18\a \x01(\rR\x14restoredFromRevision\x12\x1a\n" +
+	"\x03roi\x18\b \x01(\v2\b.ROIItemR\x03roi\x12/\n" +
+	"\n" +
+	"expression\x18\t \x01(\v2\x0f.DataExpressionR\n" +
+	"expression\x12:\n" +
+	"\x0fexpressionGroup\x18\n" +
+	" \x01(\v2\x10.ExpressionGroupR\x0fexpressionGroup\x12+\n" +
+	"\n" +
+	"elementSet\x18\v \x01(\v2\v.ElementSetR\n" +
+	"elementSet\x12F\n" +
+	"\x13screenConfiguration\x18\f \x01(\v2\x14.ScreenConfigurationR\x13screenConfigurationB\n" +
+	"Z\b.;protosb\x06proto3"
+
+var (
+	file_revision_proto_rawDescOnce sync.Once
+	file_revision_proto_rawDescData []byte
+)
+
+func file_revision_proto_rawDescGZIP() []byte {
+	file_revision_proto_rawDescOnce.Do(func() {
+		file_revision_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_revision_proto_rawDesc), len(file_revision_proto_rawDesc)))
+	})
+	return file_revision_proto_rawDescData
+}
+
+var file_revision_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_revision_proto_goTypes = []any{
+	(*ObjectRevision)(nil),      // 0: ObjectRevision
+	(ObjectType)(0),             // 1: ObjectType
+	(*ROIItem)(nil),             // 2: ROIItem
+	(*DataExpression)(nil),      // 3: DataExpression
+	(*ExpressionGroup)(nil),     // 4: ExpressionGroup
+	(*ElementSet)(nil),          // 5: ElementSet
+	(*ScreenConfiguration)(nil), // 6: ScreenConfiguration
+}
+var file_revision_proto_depIdxs = []int32{
+	1, // 0: ObjectRevision.objectType:type_name -> ObjectType
+	2, // 1: ObjectRevision.roi:type_name -> ROIItem
+	3, // 2: ObjectRevision.expression:type_name -> DataExpression
+	4, // 3: ObjectRevision.expressionGroup:type_name -> ExpressionGroup
+	5, // 4: ObjectRevision.elementSet:type_name -> ElementSet
+	6, // 5: ObjectRevision.screenConfiguration:type_name -> ScreenConfiguration
+	6, // [6:6] is the sub-list for method output_type
+	6, // [6:6] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_revision_proto_init() }
+func file_revision_proto_init() {
+	if File_revision_proto != nil {
+		return
+	}
+	file_ownership_access_proto_init()
+	file_roi_proto_init()
+	file_expressions_proto_init()
+	file_expression_group_proto_init()
+	file_element_set_proto_init()
+	file_screen_configuration_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_revision_proto_rawDesc), len(file_revision_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_revision_proto_goTypes,
+		DependencyIndexes: file_revision_proto_depIdxs,
+		MessageInfos:      file_revision_proto_msgTypes,
+	}.Build()
+	File_revision_proto = out.File
+	file_revision_proto_goTypes = nil
+	file_revision_proto_depIdxs = nil
+}
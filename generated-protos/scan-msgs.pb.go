@@ -268,10 +268,12 @@ type ScanUploadReq struct {
 	//	the zip first, then this can be called, passing the same scan id & file name
 	//
 	// bytes zippedData = 3; // jpl-breadboard implies this is a zip file of MSA files
-	ZipFileName   string `protobuf:"bytes,4,opt,name=zipFileName,proto3" json:"zipFileName,omitempty"`
-	SkipRows      uint32 `protobuf:"varint,5,opt,name=skipRows,proto3" json:"skipRows,omitempty"`
-	SkipColumns   uint32 `protobuf:"varint,6,opt,name=skipColumns,proto3" json:"skipColumns,omitempty"`
-	MaxMapPoints  uint32 `protobuf:"varint,7,opt,name=maxMapPoints,proto3" json:"maxMapPoints,omitempty"`
+	ZipFileName  string `protobuf:"bytes,4,opt,name=zipFileName,proto3" json:"zipFileName,omitempty"`
+	SkipRows     uint32 `protobuf:"varint,5,opt,name=skipRows,proto3" json:"skipRows,omitempty"`
+	SkipColumns  uint32 `protobuf:"varint,6,opt,name=skipColumns,proto3" json:"skipColumns,omitempty"`
+	MaxMapPoints uint32 `protobuf:"varint,7,opt,name=maxMapPoints,proto3" json:"maxMapPoints,omitempty"`
+	// If set, runs the importer without saving anything and returns a report of what it found instead of starting a job
+	DryRun        bool `protobuf:"varint,8,opt,name=dryRun,proto3" json:"dryRun,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -348,9 +350,18 @@ func (x *ScanUploadReq) GetMaxMapPoints() uint32 {
 	return 0
 }
 
+func (x *ScanUploadReq) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
 type ScanUploadResp struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	JobId         string                 `protobuf:"bytes,1,opt,name=jobId,proto3" json:"jobId,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	JobId string                 `protobuf:"bytes,1,opt,name=jobId,proto3" json:"jobId,omitempty"`
+	// Only set for dry runs
+	Report        *ScanImportReport `protobuf:"bytes,2,opt,name=report,proto3" json:"report,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -392,6 +403,141 @@ func (x *ScanUploadResp) GetJobId() string {
 	return ""
 }
 
+func (x *ScanUploadResp) GetReport() *ScanImportReport {
+	if x != nil {
+		return x.Report
+	}
+	return nil
+}
+
+// What an importer found in an uploaded scan, returned by a dry run so problems can be seen before importing
+type ScanImportReport struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Importer that was selected for the files
+	Format         string                            `protobuf:"bytes,1,opt,name=format,proto3" json:"format,omitempty"`
+	Title          string                            `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Instrument     ScanInstrument                    `protobuf:"varint,3,opt,name=instrument,proto3,enum=ScanInstrument" json:"instrument,omitempty"`
+	PmcCount       uint32                            `protobuf:"varint,4,opt,name=pmcCount,proto3" json:"pmcCount,omitempty"`
+	SpectrumCounts []*ScanImportReport_SpectrumCount `protobuf:"bytes,5,rep,name=spectrumCounts,proto3" json:"spectrumCounts,omitempty"`
+	// PMCs which have Normal spectra but no beam location
+	PmcsWithoutBeam     []int32                                `protobuf:"varint,6,rep,packed,name=pmcsWithoutBeam,proto3" json:"pmcsWithoutBeam,omitempty"`
+	HousekeepingColumns []*ScanImportReport_HousekeepingColumn `protobuf:"bytes,7,rep,name=housekeepingColumns,proto3" json:"housekeepingColumns,omitempty"`
+	// Context images referenced by PMCs, and those which were referenced but not found in the import
+	ContextImages []string `protobuf:"bytes,8,rep,name=contextImages,proto3" json:"contextImages,omitempty"`
+	MissingImages []string `protobuf:"bytes,9,rep,name=missingImages,proto3" json:"missingImages,omitempty"`
+	MatchedImages []string `protobuf:"bytes,10,rep,name=matchedImages,proto3" json:"matchedImages,omitempty"`
+	Warnings      []string `protobuf:"bytes,11,rep,name=warnings,proto3" json:"warnings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScanImportReport) Reset() {
+	*x = ScanImportReport{}
+	mi := &file_scan_msgs_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScanImportReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScanImportReport) ProtoMessage() {}
+
+func (x *ScanImportReport) ProtoReflect() protoreflect.Message {
+	mi := &file_scan_msgs_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScanImportReport.ProtoReflect.Descriptor instead.
+func (*ScanImportReport) Descriptor() ([]byte, []int) {
+	return file_scan_msgs_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ScanImportReport) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *ScanImportReport) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *ScanImportReport) GetInstrument() ScanInstrument {
+	if x != nil {
+		return x.Instrument
+	}
+	return ScanInstrument_UNKNOWN_INSTRUMENT
+}
+
+func (x *ScanImportReport) GetPmcCount() uint32 {
+	if x != nil {
+		return x.PmcCount
+	}
+	return 0
+}
+
+func (x *ScanImportReport) GetSpectrumCounts() []*ScanImportReport_SpectrumCount {
+	if x != nil {
+		return x.SpectrumCounts
+	}
+	return nil
+}
+
+func (x *ScanImportReport) GetPmcsWithoutBeam() []int32 {
+	if x != nil {
+		return x.PmcsWithoutBeam
+	}
+	return nil
+}
+
+func (x *ScanImportReport) GetHousekeepingColumns() []*ScanImportReport_HousekeepingColumn {
+	if x != nil {
+		return x.HousekeepingColumns
+	}
+	return nil
+}
+
+func (x *ScanImportReport) GetContextImages() []string {
+	if x != nil {
+		return x.ContextImages
+	}
+	return nil
+}
+
+func (x *ScanImportReport) GetMissingImages() []string {
+	if x != nil {
+		return x.MissingImages
+	}
+	return nil
+}
+
+func (x *ScanImportReport) GetMatchedImages() []string {
+	if x != nil {
+		return x.MatchedImages
+	}
+	return nil
+}
+
+func (x *ScanImportReport) GetWarnings() []string {
+	if x != nil {
+		return x.Warnings
+	}
+	return nil
+}
+
 type ScanUploadUpd struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Status        *JobStatus             `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
@@ -401,7 +547,7 @@ type ScanUploadUpd struct {
 
 func (x *ScanUploadUpd) Reset() {
 	*x = ScanUploadUpd{}
-	mi := &file_scan_msgs_proto_msgTypes[7]
+	mi := &file_scan_msgs_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -413,7 +559,7 @@ func (x *ScanUploadUpd) String() string {
 func (*ScanUploadUpd) ProtoMessage() {}
 
 func (x *ScanUploadUpd) ProtoReflect() protoreflect.Message {
-	mi := &file_scan_msgs_proto_msgTypes[7]
+	mi := &file_scan_msgs_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -426,7 +572,7 @@ func (x *ScanUploadUpd) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ScanUploadUpd.ProtoReflect.Descriptor instead.
 func (*ScanUploadUpd) Descriptor() ([]byte, []int) {
-	return file_scan_msgs_proto_rawDescGZIP(), []int{7}
+	return file_scan_msgs_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *ScanUploadUpd) GetStatus() *JobStatus {
@@ -446,7 +592,7 @@ type ScanCreateUserDefinedReq struct {
 
 func (x *ScanCreateUserDefinedReq) Reset() {
 	*x = ScanCreateUserDefinedReq{}
-	mi := &file_scan_msgs_proto_msgTypes[8]
+	mi := &file_scan_msgs_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -458,7 +604,7 @@ func (x *ScanCreateUserDefinedReq) String() string {
 func (*ScanCreateUserDefinedReq) ProtoMessage() {}
 
 func (x *ScanCreateUserDefinedReq) ProtoReflect() protoreflect.Message {
-	mi := &file_scan_msgs_proto_msgTypes[8]
+	mi := &file_scan_msgs_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -471,7 +617,7 @@ func (x *ScanCreateUserDefinedReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ScanCreateUserDefinedReq.ProtoReflect.Descriptor instead.
 func (*ScanCreateUserDefinedReq) Descriptor() ([]byte, []int) {
-	return file_scan_msgs_proto_rawDescGZIP(), []int{8}
+	return file_scan_msgs_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *ScanCreateUserDefinedReq) GetId() string {
@@ -489,7 +635,7 @@ type ScanCreateUserDefinedResp struct {
 
 func (x *ScanCreateUserDefinedResp) Reset() {
 	*x = ScanCreateUserDefinedResp{}
-	mi := &file_scan_msgs_proto_msgTypes[9]
+	mi := &file_scan_msgs_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -501,7 +647,7 @@ func (x *ScanCreateUserDefinedResp) String() string {
 func (*ScanCreateUserDefinedResp) ProtoMessage() {}
 
 func (x *ScanCreateUserDefinedResp) ProtoReflect() protoreflect.Message {
-	mi := &file_scan_msgs_proto_msgTypes[9]
+	mi := &file_scan_msgs_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -514,7 +660,7 @@ func (x *ScanCreateUserDefinedResp) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ScanCreateUserDefinedResp.ProtoReflect.Descriptor instead.
 func (*ScanCreateUserDefinedResp) Descriptor() ([]byte, []int) {
-	return file_scan_msgs_proto_rawDescGZIP(), []int{9}
+	return file_scan_msgs_proto_rawDescGZIP(), []int{10}
 }
 
 // Editing who to auto-share a scan with
@@ -528,7 +674,7 @@ type ScanAutoShareReq struct {
 
 func (x *ScanAutoShareReq) Reset() {
 	*x = ScanAutoShareReq{}
-	mi := &file_scan_msgs_proto_msgTypes[10]
+	mi := &file_scan_msgs_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -540,7 +686,7 @@ func (x *ScanAutoShareReq) String() string {
 func (*ScanAutoShareReq) ProtoMessage() {}
 
 func (x *ScanAutoShareReq) ProtoReflect() protoreflect.Message {
-	mi := &file_scan_msgs_proto_msgTypes[10]
+	mi := &file_scan_msgs_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -553,7 +699,7 @@ func (x *ScanAutoShareReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ScanAutoShareReq.ProtoReflect.Descriptor instead.
 func (*ScanAutoShareReq) Descriptor() ([]byte, []int) {
-	return file_scan_msgs_proto_rawDescGZIP(), []int{10}
+	return file_scan_msgs_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *ScanAutoShareReq) GetId() string {
@@ -572,7 +718,7 @@ type ScanAutoShareResp struct {
 
 func (x *ScanAutoShareResp) Reset() {
 	*x = ScanAutoShareResp{}
-	mi := &file_scan_msgs_proto_msgTypes[11]
+	mi := &file_scan_msgs_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -584,7 +730,7 @@ func (x *ScanAutoShareResp) String() string {
 func (*ScanAutoShareResp) ProtoMessage() {}
 
 func (x *ScanAutoShareResp) ProtoReflect() protoreflect.Message {
-	mi := &file_scan_msgs_proto_msgTypes[11]
+	mi := &file_scan_msgs_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -597,7 +743,7 @@ func (x *ScanAutoShareResp) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ScanAutoShareResp.ProtoReflect.Descriptor instead.
 func (*ScanAutoShareResp) Descriptor() ([]byte, []int) {
-	return file_scan_msgs_proto_rawDescGZIP(), []int{11}
+	return file_scan_msgs_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *ScanAutoShareResp) GetEntry() *ScanAutoShareEntry {
@@ -617,7 +763,7 @@ type ScanAutoShareWriteReq struct {
 
 func (x *ScanAutoShareWriteReq) Reset() {
 	*x = ScanAutoShareWriteReq{}
-	mi := &file_scan_msgs_proto_msgTypes[12]
+	mi := &file_scan_msgs_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -629,7 +775,7 @@ func (x *ScanAutoShareWriteReq) String() string {
 func (*ScanAutoShareWriteReq) ProtoMessage() {}
 
 func (x *ScanAutoShareWriteReq) ProtoReflect() protoreflect.Message {
-	mi := &file_scan_msgs_proto_msgTypes[12]
+	mi := &file_scan_msgs_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -642,7 +788,7 @@ func (x *ScanAutoShareWriteReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ScanAutoShareWriteReq.ProtoReflect.Descriptor instead.
 func (*ScanAutoShareWriteReq) Descriptor() ([]byte, []int) {
-	return file_scan_msgs_proto_rawDescGZIP(), []int{12}
+	return file_scan_msgs_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *ScanAutoShareWriteReq) GetEntry() *ScanAutoShareEntry {
@@ -660,7 +806,7 @@ type ScanAutoShareWriteResp struct {
 
 func (x *ScanAutoShareWriteResp) Reset() {
 	*x = ScanAutoShareWriteResp{}
-	mi := &file_scan_msgs_proto_msgTypes[13]
+	mi := &file_scan_msgs_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -672,7 +818,7 @@ func (x *ScanAutoShareWriteResp) String() string {
 func (*ScanAutoShareWriteResp) ProtoMessage() {}
 
 func (x *ScanAutoShareWriteResp) ProtoReflect() protoreflect.Message {
-	mi := &file_scan_msgs_proto_msgTypes[13]
+	mi := &file_scan_msgs_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -685,7 +831,7 @@ func (x *ScanAutoShareWriteResp) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ScanAutoShareWriteResp.ProtoReflect.Descriptor instead.
 func (*ScanAutoShareWriteResp) Descriptor() ([]byte, []int) {
-	return file_scan_msgs_proto_rawDescGZIP(), []int{13}
+	return file_scan_msgs_proto_rawDescGZIP(), []int{14}
 }
 
 // This should trigger a ScanListUpd to go out
@@ -703,7 +849,7 @@ type ScanMetaWriteReq struct {
 
 func (x *ScanMetaWriteReq) Reset() {
 	*x = ScanMetaWriteReq{}
-	mi := &file_scan_msgs_proto_msgTypes[14]
+	mi := &file_scan_msgs_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -715,7 +861,7 @@ func (x *ScanMetaWriteReq) String() string {
 func (*ScanMetaWriteReq) ProtoMessage() {}
 
 func (x *ScanMetaWriteReq) ProtoReflect() protoreflect.Message {
-	mi := &file_scan_msgs_proto_msgTypes[14]
+	mi := &file_scan_msgs_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -728,7 +874,7 @@ func (x *ScanMetaWriteReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ScanMetaWriteReq.ProtoReflect.Descriptor instead.
 func (*ScanMetaWriteReq) Descriptor() ([]byte, []int) {
-	return file_scan_msgs_proto_rawDescGZIP(), []int{14}
+	return file_scan_msgs_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *ScanMetaWriteReq) GetScanId() string {
@@ -767,7 +913,7 @@ type ScanMetaWriteResp struct {
 
 func (x *ScanMetaWriteResp) Reset() {
 	*x = ScanMetaWriteResp{}
-	mi := &file_scan_msgs_proto_msgTypes[15]
+	mi := &file_scan_msgs_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -779,7 +925,7 @@ func (x *ScanMetaWriteResp) String() string {
 func (*ScanMetaWriteResp) ProtoMessage() {}
 
 func (x *ScanMetaWriteResp) ProtoReflect() protoreflect.Message {
-	mi := &file_scan_msgs_proto_msgTypes[15]
+	mi := &file_scan_msgs_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -792,7 +938,7 @@ func (x *ScanMetaWriteResp) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ScanMetaWriteResp.ProtoReflect.Descriptor instead.
 func (*ScanMetaWriteResp) Descriptor() ([]byte, []int) {
-	return file_scan_msgs_proto_rawDescGZIP(), []int{15}
+	return file_scan_msgs_proto_rawDescGZIP(), []int{16}
 }
 
 // Triggering a re-import, should publish a ScanListUpd to go out
@@ -808,7 +954,7 @@ type ScanTriggerReImportReq struct {
 
 func (x *ScanTriggerReImportReq) Reset() {
 	*x = ScanTriggerReImportReq{}
-	mi := &file_scan_msgs_proto_msgTypes[16]
+	mi := &file_scan_msgs_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -820,7 +966,7 @@ func (x *ScanTriggerReImportReq) String() string {
 func (*ScanTriggerReImportReq) ProtoMessage() {}
 
 func (x *ScanTriggerReImportReq) ProtoReflect() protoreflect.Message {
-	mi := &file_scan_msgs_proto_msgTypes[16]
+	mi := &file_scan_msgs_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -833,7 +979,7 @@ func (x *ScanTriggerReImportReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ScanTriggerReImportReq.ProtoReflect.Descriptor instead.
 func (*ScanTriggerReImportReq) Descriptor() ([]byte, []int) {
-	return file_scan_msgs_proto_rawDescGZIP(), []int{16}
+	return file_scan_msgs_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *ScanTriggerReImportReq) GetScanId() string {
@@ -852,7 +998,7 @@ type ScanTriggerReImportResp struct {
 
 func (x *ScanTriggerReImportResp) Reset() {
 	*x = ScanTriggerReImportResp{}
-	mi := &file_scan_msgs_proto_msgTypes[17]
+	mi := &file_scan_msgs_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -864,7 +1010,7 @@ func (x *ScanTriggerReImportResp) String() string {
 func (*ScanTriggerReImportResp) ProtoMessage() {}
 
 func (x *ScanTriggerReImportResp) ProtoReflect() protoreflect.Message {
-	mi := &file_scan_msgs_proto_msgTypes[17]
+	mi := &file_scan_msgs_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -877,7 +1023,7 @@ func (x *ScanTriggerReImportResp) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ScanTriggerReImportResp.ProtoReflect.Descriptor instead.
 func (*ScanTriggerReImportResp) Descriptor() ([]byte, []int) {
-	return file_scan_msgs_proto_rawDescGZIP(), []int{17}
+	return file_scan_msgs_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *ScanTriggerReImportResp) GetJobId() string {
@@ -896,7 +1042,7 @@ type ScanTriggerReImportUpd struct {
 
 func (x *ScanTriggerReImportUpd) Reset() {
 	*x = ScanTriggerReImportUpd{}
-	mi := &file_scan_msgs_proto_msgTypes[18]
+	mi := &file_scan_msgs_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -908,7 +1054,7 @@ func (x *ScanTriggerReImportUpd) String() string {
 func (*ScanTriggerReImportUpd) ProtoMessage() {}
 
 func (x *ScanTriggerReImportUpd) ProtoReflect() protoreflect.Message {
-	mi := &file_scan_msgs_proto_msgTypes[18]
+	mi := &file_scan_msgs_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -921,7 +1067,7 @@ func (x *ScanTriggerReImportUpd) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ScanTriggerReImportUpd.ProtoReflect.Descriptor instead.
 func (*ScanTriggerReImportUpd) Descriptor() ([]byte, []int) {
-	return file_scan_msgs_proto_rawDescGZIP(), []int{18}
+	return file_scan_msgs_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *ScanTriggerReImportUpd) GetStatus() *JobStatus {
@@ -941,7 +1087,7 @@ type ScanMetaLabelsAndTypesReq struct {
 
 func (x *ScanMetaLabelsAndTypesReq) Reset() {
 	*x = ScanMetaLabelsAndTypesReq{}
-	mi := &file_scan_msgs_proto_msgTypes[19]
+	mi := &file_scan_msgs_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -953,7 +1099,7 @@ func (x *ScanMetaLabelsAndTypesReq) String() string {
 func (*ScanMetaLabelsAndTypesReq) ProtoMessage() {}
 
 func (x *ScanMetaLabelsAndTypesReq) ProtoReflect() protoreflect.Message {
-	mi := &file_scan_msgs_proto_msgTypes[19]
+	mi := &file_scan_msgs_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -966,7 +1112,7 @@ func (x *ScanMetaLabelsAndTypesReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ScanMetaLabelsAndTypesReq.ProtoReflect.Descriptor instead.
 func (*ScanMetaLabelsAndTypesReq) Descriptor() ([]byte, []int) {
-	return file_scan_msgs_proto_rawDescGZIP(), []int{19}
+	return file_scan_msgs_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *ScanMetaLabelsAndTypesReq) GetScanId() string {
@@ -986,7 +1132,7 @@ type ScanMetaLabelsAndTypesResp struct {
 
 func (x *ScanMetaLabelsAndTypesResp) Reset() {
 	*x = ScanMetaLabelsAndTypesResp{}
-	mi := &file_scan_msgs_proto_msgTypes[20]
+	mi := &file_scan_msgs_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -998,7 +1144,7 @@ func (x *ScanMetaLabelsAndTypesResp) String() string {
 func (*ScanMetaLabelsAndTypesResp) ProtoMessage() {}
 
 func (x *ScanMetaLabelsAndTypesResp) ProtoReflect() protoreflect.Message {
-	mi := &file_scan_msgs_proto_msgTypes[20]
+	mi := &file_scan_msgs_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1011,7 +1157,7 @@ func (x *ScanMetaLabelsAndTypesResp) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ScanMetaLabelsAndTypesResp.ProtoReflect.Descriptor instead.
 func (*ScanMetaLabelsAndTypesResp) Descriptor() ([]byte, []int) {
-	return file_scan_msgs_proto_rawDescGZIP(), []int{20}
+	return file_scan_msgs_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *ScanMetaLabelsAndTypesResp) GetMetaLabels() []string {
@@ -1039,7 +1185,7 @@ type ScanDeleteReq struct {
 
 func (x *ScanDeleteReq) Reset() {
 	*x = ScanDeleteReq{}
-	mi := &file_scan_msgs_proto_msgTypes[21]
+	mi := &file_scan_msgs_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1051,7 +1197,7 @@ func (x *ScanDeleteReq) String() string {
 func (*ScanDeleteReq) ProtoMessage() {}
 
 func (x *ScanDeleteReq) ProtoReflect() protoreflect.Message {
-	mi := &file_scan_msgs_proto_msgTypes[21]
+	mi := &file_scan_msgs_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1064,7 +1210,7 @@ func (x *ScanDeleteReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ScanDeleteReq.ProtoReflect.Descriptor instead.
 func (*ScanDeleteReq) Descriptor() ([]byte, []int) {
-	return file_scan_msgs_proto_rawDescGZIP(), []int{21}
+	return file_scan_msgs_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *ScanDeleteReq) GetScanId() string {
@@ -1089,7 +1235,7 @@ type ScanDeleteResp struct {
 
 func (x *ScanDeleteResp) Reset() {
 	*x = ScanDeleteResp{}
-	mi := &file_scan_msgs_proto_msgTypes[22]
+	mi := &file_scan_msgs_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1101,7 +1247,7 @@ func (x *ScanDeleteResp) String() string {
 func (*ScanDeleteResp) ProtoMessage() {}
 
 func (x *ScanDeleteResp) ProtoReflect() protoreflect.Message {
-	mi := &file_scan_msgs_proto_msgTypes[22]
+	mi := &file_scan_msgs_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1114,7 +1260,7 @@ func (x *ScanDeleteResp) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ScanDeleteResp.ProtoReflect.Descriptor instead.
 func (*ScanDeleteResp) Descriptor() ([]byte, []int) {
-	return file_scan_msgs_proto_rawDescGZIP(), []int{22}
+	return file_scan_msgs_proto_rawDescGZIP(), []int{23}
 }
 
 // requires(EDIT_SCAN)
@@ -1128,7 +1274,7 @@ type ScanTriggerJobReq struct {
 
 func (x *ScanTriggerJobReq) Reset() {
 	*x = ScanTriggerJobReq{}
-	mi := &file_scan_msgs_proto_msgTypes[23]
+	mi := &file_scan_msgs_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1140,7 +1286,7 @@ func (x *ScanTriggerJobReq) String() string {
 func (*ScanTriggerJobReq) ProtoMessage() {}
 
 func (x *ScanTriggerJobReq) ProtoReflect() protoreflect.Message {
-	mi := &file_scan_msgs_proto_msgTypes[23]
+	mi := &file_scan_msgs_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1153,7 +1299,7 @@ func (x *ScanTriggerJobReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ScanTriggerJobReq.ProtoReflect.Descriptor instead.
 func (*ScanTriggerJobReq) Descriptor() ([]byte, []int) {
-	return file_scan_msgs_proto_rawDescGZIP(), []int{23}
+	return file_scan_msgs_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *ScanTriggerJobReq) GetScanId() string {
@@ -1178,7 +1324,7 @@ type ScanTriggerJobResp struct {
 
 func (x *ScanTriggerJobResp) Reset() {
 	*x = ScanTriggerJobResp{}
-	mi := &file_scan_msgs_proto_msgTypes[24]
+	mi := &file_scan_msgs_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1190,7 +1336,7 @@ func (x *ScanTriggerJobResp) String() string {
 func (*ScanTriggerJobResp) ProtoMessage() {}
 
 func (x *ScanTriggerJobResp) ProtoReflect() protoreflect.Message {
-	mi := &file_scan_msgs_proto_msgTypes[24]
+	mi := &file_scan_msgs_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1203,7 +1349,7 @@ func (x *ScanTriggerJobResp) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ScanTriggerJobResp.ProtoReflect.Descriptor instead.
 func (*ScanTriggerJobResp) Descriptor() ([]byte, []int) {
-	return file_scan_msgs_proto_rawDescGZIP(), []int{24}
+	return file_scan_msgs_proto_rawDescGZIP(), []int{25}
 }
 
 // requires(NONE)
@@ -1215,7 +1361,7 @@ type ScanListJobsReq struct {
 
 func (x *ScanListJobsReq) Reset() {
 	*x = ScanListJobsReq{}
-	mi := &file_scan_msgs_proto_msgTypes[25]
+	mi := &file_scan_msgs_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1227,7 +1373,7 @@ func (x *ScanListJobsReq) String() string {
 func (*ScanListJobsReq) ProtoMessage() {}
 
 func (x *ScanListJobsReq) ProtoReflect() protoreflect.Message {
-	mi := &file_scan_msgs_proto_msgTypes[25]
+	mi := &file_scan_msgs_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1240,7 +1386,7 @@ func (x *ScanListJobsReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ScanListJobsReq.ProtoReflect.Descriptor instead.
 func (*ScanListJobsReq) Descriptor() ([]byte, []int) {
-	return file_scan_msgs_proto_rawDescGZIP(), []int{25}
+	return file_scan_msgs_proto_rawDescGZIP(), []int{26}
 }
 
 type ScanListJobsResp struct {
@@ -1252,7 +1398,7 @@ type ScanListJobsResp struct {
 
 func (x *ScanListJobsResp) Reset() {
 	*x = ScanListJobsResp{}
-	mi := &file_scan_msgs_proto_msgTypes[26]
+	mi := &file_scan_msgs_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1264,7 +1410,7 @@ func (x *ScanListJobsResp) String() string {
 func (*ScanListJobsResp) ProtoMessage() {}
 
 func (x *ScanListJobsResp) ProtoReflect() protoreflect.Message {
-	mi := &file_scan_msgs_proto_msgTypes[26]
+	mi := &file_scan_msgs_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1277,7 +1423,7 @@ func (x *ScanListJobsResp) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ScanListJobsResp.ProtoReflect.Descriptor instead.
 func (*ScanListJobsResp) Descriptor() ([]byte, []int) {
-	return file_scan_msgs_proto_rawDescGZIP(), []int{26}
+	return file_scan_msgs_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *ScanListJobsResp) GetJobs() []*JobGroupConfig {
@@ -1297,7 +1443,7 @@ type ScanWriteJobReq struct {
 
 func (x *ScanWriteJobReq) Reset() {
 	*x = ScanWriteJobReq{}
-	mi := &file_scan_msgs_proto_msgTypes[27]
+	mi := &file_scan_msgs_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1309,7 +1455,7 @@ func (x *ScanWriteJobReq) String() string {
 func (*ScanWriteJobReq) ProtoMessage() {}
 
 func (x *ScanWriteJobReq) ProtoReflect() protoreflect.Message {
-	mi := &file_scan_msgs_proto_msgTypes[27]
+	mi := &file_scan_msgs_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1322,7 +1468,7 @@ func (x *ScanWriteJobReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ScanWriteJobReq.ProtoReflect.Descriptor instead.
 func (*ScanWriteJobReq) Descriptor() ([]byte, []int) {
-	return file_scan_msgs_proto_rawDescGZIP(), []int{27}
+	return file_scan_msgs_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *ScanWriteJobReq) GetJob() *JobGroupConfig {
@@ -1340,7 +1486,7 @@ type ScanWriteJobResp struct {
 
 func (x *ScanWriteJobResp) Reset() {
 	*x = ScanWriteJobResp{}
-	mi := &file_scan_msgs_proto_msgTypes[28]
+	mi := &file_scan_msgs_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1352,7 +1498,7 @@ func (x *ScanWriteJobResp) String() string {
 func (*ScanWriteJobResp) ProtoMessage() {}
 
 func (x *ScanWriteJobResp) ProtoReflect() protoreflect.Message {
-	mi := &file_scan_msgs_proto_msgTypes[28]
+	mi := &file_scan_msgs_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1365,7 +1511,7 @@ func (x *ScanWriteJobResp) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ScanWriteJobResp.ProtoReflect.Descriptor instead.
 func (*ScanWriteJobResp) Descriptor() ([]byte, []int) {
-	return file_scan_msgs_proto_rawDescGZIP(), []int{28}
+	return file_scan_msgs_proto_rawDescGZIP(), []int{29}
 }
 
 type ScanListReq_MinMaxInt struct {
@@ -1378,7 +1524,7 @@ type ScanListReq_MinMaxInt struct {
 
 func (x *ScanListReq_MinMaxInt) Reset() {
 	*x = ScanListReq_MinMaxInt{}
-	mi := &file_scan_msgs_proto_msgTypes[30]
+	mi := &file_scan_msgs_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1390,7 +1536,7 @@ func (x *ScanListReq_MinMaxInt) String() string {
 func (*ScanListReq_MinMaxInt) ProtoMessage() {}
 
 func (x *ScanListReq_MinMaxInt) ProtoReflect() protoreflect.Message {
-	mi := &file_scan_msgs_proto_msgTypes[30]
+	mi := &file_scan_msgs_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1420,6 +1566,128 @@ func (x *ScanListReq_MinMaxInt) GetMax() int64 {
 	return 0
 }
 
+// Spectra found of a given read type (Normal, BulkSum, MaxValue, Dwell) and detector
+type ScanImportReport_SpectrumCount struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReadType      string                 `protobuf:"bytes,1,opt,name=readType,proto3" json:"readType,omitempty"`
+	Detector      string                 `protobuf:"bytes,2,opt,name=detector,proto3" json:"detector,omitempty"`
+	Count         uint32                 `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScanImportReport_SpectrumCount) Reset() {
+	*x = ScanImportReport_SpectrumCount{}
+	mi := &file_scan_msgs_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScanImportReport_SpectrumCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScanImportReport_SpectrumCount) ProtoMessage() {}
+
+func (x *ScanImportReport_SpectrumCount) ProtoReflect() protoreflect.Message {
+	mi := &file_scan_msgs_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScanImportReport_SpectrumCount.ProtoReflect.Descriptor instead.
+func (*ScanImportReport_SpectrumCount) Descriptor() ([]byte, []int) {
+	return file_scan_msgs_proto_rawDescGZIP(), []int{7, 0}
+}
+
+func (x *ScanImportReport_SpectrumCount) GetReadType() string {
+	if x != nil {
+		return x.ReadType
+	}
+	return ""
+}
+
+func (x *ScanImportReport_SpectrumCount) GetDetector() string {
+	if x != nil {
+		return x.Detector
+	}
+	return ""
+}
+
+func (x *ScanImportReport_SpectrumCount) GetCount() uint32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type ScanImportReport_HousekeepingColumn struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Name     string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	DataType ScanMetaDataType       `protobuf:"varint,2,opt,name=dataType,proto3,enum=ScanMetaDataType" json:"dataType,omitempty"`
+	// How many PMCs have a value for this column
+	PmcCount      uint32 `protobuf:"varint,3,opt,name=pmcCount,proto3" json:"pmcCount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScanImportReport_HousekeepingColumn) Reset() {
+	*x = ScanImportReport_HousekeepingColumn{}
+	mi := &file_scan_msgs_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScanImportReport_HousekeepingColumn) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScanImportReport_HousekeepingColumn) ProtoMessage() {}
+
+func (x *ScanImportReport_HousekeepingColumn) ProtoReflect() protoreflect.Message {
+	mi := &file_scan_msgs_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScanImportReport_HousekeepingColumn.ProtoReflect.Descriptor instead.
+func (*ScanImportReport_HousekeepingColumn) Descriptor() ([]byte, []int) {
+	return file_scan_msgs_proto_rawDescGZIP(), []int{7, 1}
+}
+
+func (x *ScanImportReport_HousekeepingColumn) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ScanImportReport_HousekeepingColumn) GetDataType() ScanMetaDataType {
+	if x != nil {
+		return x.DataType
+	}
+	return ScanMetaDataType_MT_FLOAT
+}
+
+func (x *ScanImportReport_HousekeepingColumn) GetPmcCount() uint32 {
+	if x != nil {
+		return x.PmcCount
+	}
+	return 0
+}
+
 var File_scan_msgs_proto protoreflect.FileDescriptor
 
 const file_scan_msgs_proto_rawDesc = "" +
@@ -1441,16 +1709,41 @@ const file_scan_msgs_proto_rawDesc = "" +
 	"ScanGetReq\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\",\n" +
 	"\vScanGetResp\x12\x1d\n" +
-	"\x04scan\x18\x01 \x01(\v2\t.ScanItemR\x04scan\"\xbb\x01\n" +
+	"\x04scan\x18\x01 \x01(\v2\t.ScanItemR\x04scan\"\xd3\x01\n" +
 	"\rScanUploadReq\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
 	"\x06format\x18\x02 \x01(\tR\x06format\x12 \n" +
 	"\vzipFileName\x18\x04 \x01(\tR\vzipFileName\x12\x1a\n" +
 	"\bskipRows\x18\x05 \x01(\rR\bskipRows\x12 \n" +
 	"\vskipColumns\x18\x06 \x01(\rR\vskipColumns\x12\"\n" +
-	"\fmaxMapPoints\x18\a \x01(\rR\fmaxMapPoints\"&\n" +
+	"\fmaxMapPoints\x18\a \x01(\rR\fmaxMapPoints\x12\x16\n" +
+	"\x06dryRun\x18\b \x01(\bR\x06dryRun\"Q\n" +
 	"\x0eScanUploadResp\x12\x14\n" +
-	"\x05jobId\x18\x01 \x01(\tR\x05jobId\"3\n" +
+	"\x05jobId\x18\x01 \x01(\tR\x05jobId\x12)\n" +
+	"\x06report\x18\x02 \x01(\v2\x11.ScanImportReportR\x06report\"\xba\x05\n" +
+	"\x10ScanImportReport\x12\x16\n" +
+	"\x06format\x18\x01 \x01(\tR\x06format\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12/\n" +
+	"\n" +
+	"instrument\x18\x03 \x01(\x0e2\x0f.ScanInstrumentR\n" +
+	"instrument\x12\x1a\n" +
+	"\bpmcCount\x18\x04 \x01(\rR\bpmcCount\x12G\n" +
+	"\x0espectrumCounts\x18\x05 \x03(\v2\x1f.ScanImportReport.SpectrumCountR\x0espectrumCounts\x12(\n" +
+	"\x0fpmcsWithoutBeam\x18\x06 \x03(\x05R\x0fpmcsWithoutBeam\x12V\n" +
+	"\x13housekeepingColumns\x18\a \x03(\v2$.ScanImportReport.HousekeepingColumnR\x13housekeepingColumns\x12$\n" +
+	"\rcontextImages\x18\b \x03(\tR\rcontextImages\x12$\n" +
+	"\rmissingImages\x18\t \x03(\tR\rmissingImages\x12$\n" +
+	"\rmatchedImages\x18\n" +
+	" \x03(\tR\rmatchedImages\x12\x1a\n" +
+	"\bwarnings\x18\v \x03(\tR\bwarnings\x1a]\n" +
+	"\rSpectrumCount\x12\x1a\n" +
+	"\breadType\x18\x01 \x01(\tR\breadType\x12\x1a\n" +
+	"\bdetector\x18\x02 \x01(\tR\bdetector\x12\x14\n" +
+	"\x05count\x18\x03 \x01(\rR\x05count\x1as\n" +
+	"\x12HousekeepingColumn\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12-\n" +
+	"\bdataType\x18\x02 \x01(\x0e2\x11.ScanMetaDataTypeR\bdataType\x12\x1a\n" +
+	"\bpmcCount\x18\x03 \x01(\rR\bpmcCount\"3\n" +
 	"\rScanUploadUpd\x12\"\n" +
 	"\x06status\x18\x01 \x01(\v2\n" +
 	".JobStatusR\x06status\"*\n" +
@@ -1512,61 +1805,70 @@ func file_scan_msgs_proto_rawDescGZIP() []byte {
 	return file_scan_msgs_proto_rawDescData
 }
 
-var file_scan_msgs_proto_msgTypes = make([]protoimpl.MessageInfo, 31)
+var file_scan_msgs_proto_msgTypes = make([]protoimpl.MessageInfo, 34)
 var file_scan_msgs_proto_goTypes = []any{
-	(*ScanListReq)(nil),                // 0: ScanListReq
-	(*ScanListResp)(nil),               // 1: ScanListResp
-	(*ScanListUpd)(nil),                // 2: ScanListUpd
-	(*ScanGetReq)(nil),                 // 3: ScanGetReq
-	(*ScanGetResp)(nil),                // 4: ScanGetResp
-	(*ScanUploadReq)(nil),              // 5: ScanUploadReq
-	(*ScanUploadResp)(nil),             // 6: ScanUploadResp
-	(*ScanUploadUpd)(nil),              // 7: ScanUploadUpd
-	(*ScanCreateUserDefinedReq)(nil),   // 8: ScanCreateUserDefinedReq
-	(*ScanCreateUserDefinedResp)(nil),  // 9: ScanCreateUserDefinedResp
-	(*ScanAutoShareReq)(nil),           // 10: ScanAutoShareReq
-	(*ScanAutoShareResp)(nil),          // 11: ScanAutoShareResp
-	(*ScanAutoShareWriteReq)(nil),      // 12: ScanAutoShareWriteReq
-	(*ScanAutoShareWriteResp)(nil),     // 13: ScanAutoShareWriteResp
-	(*ScanMetaWriteReq)(nil),           // 14: ScanMetaWriteReq
-	(*ScanMetaWriteResp)(nil),          // 15: ScanMetaWriteResp
-	(*ScanTriggerReImportReq)(nil),     // 16: ScanTriggerReImportReq
-	(*ScanTriggerReImportResp)(nil),    // 17: ScanTriggerReImportResp
-	(*ScanTriggerReImportUpd)(nil),     // 18: ScanTriggerReImportUpd
-	(*ScanMetaLabelsAndTypesReq)(nil),  // 19: ScanMetaLabelsAndTypesReq
-	(*ScanMetaLabelsAndTypesResp)(nil), // 20: ScanMetaLabelsAndTypesResp
-	(*ScanDeleteReq)(nil),              // 21: ScanDeleteReq
-	(*ScanDeleteResp)(nil),             // 22: ScanDeleteResp
-	(*ScanTriggerJobReq)(nil),          // 23: ScanTriggerJobReq
-	(*ScanTriggerJobResp)(nil),         // 24: ScanTriggerJobResp
-	(*ScanListJobsReq)(nil),            // 25: ScanListJobsReq
-	(*ScanListJobsResp)(nil),           // 26: ScanListJobsResp
-	(*ScanWriteJobReq)(nil),            // 27: ScanWriteJobReq
-	(*ScanWriteJobResp)(nil),           // 28: ScanWriteJobResp
-	nil,                                // 29: ScanListReq.SearchFiltersEntry
-	(*ScanListReq_MinMaxInt)(nil),      // 30: ScanListReq.MinMaxInt
-	(*ScanItem)(nil),                   // 31: ScanItem
-	(*JobStatus)(nil),                  // 32: JobStatus
-	(*ScanAutoShareEntry)(nil),         // 33: ScanAutoShareEntry
-	(ScanMetaDataType)(0),              // 34: ScanMetaDataType
-	(*JobGroupConfig)(nil),             // 35: JobGroupConfig
+	(*ScanListReq)(nil),                         // 0: ScanListReq
+	(*ScanListResp)(nil),                        // 1: ScanListResp
+	(*ScanListUpd)(nil),                         // 2: ScanListUpd
+	(*ScanGetReq)(nil),                          // 3: ScanGetReq
+	(*ScanGetResp)(nil),                         // 4: ScanGetResp
+	(*ScanUploadReq)(nil),                       // 5: ScanUploadReq
+	(*ScanUploadResp)(nil),                      // 6: ScanUploadResp
+	(*ScanImportReport)(nil),                    // 7: ScanImportReport
+	(*ScanUploadUpd)(nil),                       // 8: ScanUploadUpd
+	(*ScanCreateUserDefinedReq)(nil),            // 9: ScanCreateUserDefinedReq
+	(*ScanCreateUserDefinedResp)(nil),           // 10: ScanCreateUserDefinedResp
+	(*ScanAutoShareReq)(nil),                    // 11: ScanAutoShareReq
+	(*ScanAutoShareResp)(nil),                   // 12: ScanAutoShareResp
+	(*ScanAutoShareWriteReq)(nil),               // 13: ScanAutoShareWriteReq
+	(*ScanAutoShareWriteResp)(nil),              // 14: ScanAutoShareWriteResp
+	(*ScanMetaWriteReq)(nil),                    // 15: ScanMetaWriteReq
+	(*ScanMetaWriteResp)(nil),                   // 16: ScanMetaWriteResp
+	(*ScanTriggerReImportReq)(nil),              // 17: ScanTriggerReImportReq
+	(*ScanTriggerReImportResp)(nil),             // 18: ScanTriggerReImportResp
+	(*ScanTriggerReImportUpd)(nil),              // 19: ScanTriggerReImportUpd
+	(*ScanMetaLabelsAndTypesReq)(nil),           // 20: ScanMetaLabelsAndTypesReq
+	(*ScanMetaLabelsAndTypesResp)(nil),          // 21: ScanMetaLabelsAndTypesResp
+	(*ScanDeleteReq)(nil),                       // 22: ScanDeleteReq
+	(*ScanDeleteResp)(nil),                      // 23: ScanDeleteResp
+	(*ScanTriggerJobReq)(nil),                   // 24: ScanTriggerJobReq
+	(*ScanTriggerJobResp)(nil),                  // 25: ScanTriggerJobResp
+	(*ScanListJobsReq)(nil),                     // 26: ScanListJobsReq
+	(*ScanListJobsResp)(nil),                    // 27: ScanListJobsResp
+	(*ScanWriteJobReq)(nil),                     // 28: ScanWriteJobReq
+	(*ScanWriteJobResp)(nil),                    // 29: ScanWriteJobResp
+	nil,                                         // 30: ScanListReq.SearchFiltersEntry
+	(*ScanListReq_MinMaxInt)(nil),               // 31: ScanListReq.MinMaxInt
+	(*ScanImportReport_SpectrumCount)(nil),      // 32: ScanImportReport.SpectrumCount
+	(*ScanImportReport_HousekeepingColumn)(nil), // 33: ScanImportReport.HousekeepingColumn
+	(*ScanItem)(nil),                            // 34: ScanItem
+	(ScanInstrument)(0),                         // 35: ScanInstrument
+	(*JobStatus)(nil),                           // 36: JobStatus
+	(*ScanAutoShareEntry)(nil),                  // 37: ScanAutoShareEntry
+	(ScanMetaDataType)(0),                       // 38: ScanMetaDataType
+	(*JobGroupConfig)(nil),                      // 39: JobGroupConfig
 }
 var file_scan_msgs_proto_depIdxs = []int32{
-	29, // 0: ScanListReq.searchFilters:type_name -> ScanListReq.SearchFiltersEntry
-	31, // 1: ScanListResp.scans:type_name -> ScanItem
-	31, // 2: ScanGetResp.scan:type_name -> ScanItem
-	32, // 3: ScanUploadUpd.status:type_name -> JobStatus
-	33, // 4: ScanAutoShareResp.entry:type_name -> ScanAutoShareEntry
-	33, // 5: ScanAutoShareWriteReq.entry:type_name -> ScanAutoShareEntry
-	32, // 6: ScanTriggerReImportUpd.status:type_name -> JobStatus
-	34, // 7: ScanMetaLabelsAndTypesResp.metaTypes:type_name -> ScanMetaDataType
-	35, // 8: ScanListJobsResp.jobs:type_name -> JobGroupConfig
-	35, // 9: ScanWriteJobReq.job:type_name -> JobGroupConfig
-	10, // [10:10] is the sub-list for method output_type
-	10, // [10:10] is the sub-list for method input_type
-	10, // [10:10] is the sub-list for extension type_name
-	10, // [10:10] is the sub-list for extension extendee
-	0,  // [0:10] is the sub-list for field type_name
+	30, // 0: ScanListReq.searchFilters:type_name -> ScanListReq.SearchFiltersEntry
+	34, // 1: ScanListResp.scans:type_name -> ScanItem
+	34, // 2: ScanGetResp.scan:type_name -> ScanItem
+	7,  // 3: ScanUploadResp.report:type_name -> ScanImportReport
+	35, // 4: ScanImportReport.instrument:type_name -> ScanInstrument
+	32, // 5: ScanImportReport.spectrumCounts:type_name -> ScanImportReport.SpectrumCount
+	33, // 6: ScanImportReport.housekeepingColumns:type_name -> ScanImportReport.HousekeepingColumn
+	36, // 7: ScanUploadUpd.status:type_name -> JobStatus
+	37, // 8: ScanAutoShareResp.entry:type_name -> ScanAutoShareEntry
+	37, // 9: ScanAutoShareWriteReq.entry:type_name -> ScanAutoShareEntry
+	36, // 10: ScanTriggerReImportUpd.status:type_name -> JobStatus
+	38, // 11: ScanMetaLabelsAndTypesResp.metaTypes:type_name -> ScanMetaDataType
+	39, // 12: ScanListJobsResp.jobs:type_name -> JobGroupConfig
+	39, // 13: ScanWriteJobReq.job:type_name -> JobGroupConfig
+	38, // 14: ScanImportReport.HousekeepingColumn.dataType:type_name -> ScanMetaDataType
+	15, // [15:15] is the sub-list for method output_type
+	15, // [15:15] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
 }
 
 func init() { file_scan_msgs_proto_init() }
@@ -1582,7 +1884,7 @@ func file_scan_msgs_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_scan_msgs_proto_rawDesc), len(file_scan_msgs_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   31,
+			NumMessages:   34,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
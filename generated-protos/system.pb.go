@@ -132,7 +132,11 @@ func (*BackupDBUpd) Descriptor() ([]byte, []int) {
 
 // requires(PIXLISE_ADMIN)
 type RestoreDBReq struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Backup set to restore from, if empty, the latest complete one
+	BackupSetId string `protobuf:"bytes,1,opt,name=backupSetId,proto3" json:"backupSetId,omitempty"`
+	// If set, only this scan is restored along with its images, quants and ROIs, otherwise the whole DB and files
+	ScanId        string `protobuf:"bytes,2,opt,name=scanId,proto3" json:"scanId,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -167,6 +171,20 @@ func (*RestoreDBReq) Descriptor() ([]byte, []int) {
 	return file_system_proto_rawDescGZIP(), []int{3}
 }
 
+func (x *RestoreDBReq) GetBackupSetId() string {
+	if x != nil {
+		return x.BackupSetId
+	}
+	return ""
+}
+
+func (x *RestoreDBReq) GetScanId() string {
+	if x != nil {
+		return x.ScanId
+	}
+	return ""
+}
+
 type RestoreDBResp struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
@@ -316,6 +334,167 @@ func (x *DBAdminConfigGetResp) GetImpersonateEnabled() bool {
 	return false
 }
 
+type BackupSetSummary struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	SetId            string                 `protobuf:"bytes,1,opt,name=setId,proto3" json:"setId,omitempty"`
+	TimeStampUnixSec uint32                 `protobuf:"varint,2,opt,name=timeStampUnixSec,proto3" json:"timeStampUnixSec,omitempty"`
+	// False if the backup failed part way through, these can't be restored from
+	Complete bool `protobuf:"varint,3,opt,name=complete,proto3" json:"complete,omitempty"`
+	// How many scan, image and quant files the set contains, and how many of those were copied by this backup (the
+	// rest being unchanged since an earlier backup)
+	FileCount       uint32 `protobuf:"varint,4,opt,name=fileCount,proto3" json:"fileCount,omitempty"`
+	CopiedFileCount uint32 `protobuf:"varint,5,opt,name=copiedFileCount,proto3" json:"copiedFileCount,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *BackupSetSummary) Reset() {
+	*x = BackupSetSummary{}
+	mi := &file_system_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BackupSetSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BackupSetSummary) ProtoMessage() {}
+
+func (x *BackupSetSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_system_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BackupSetSummary.ProtoReflect.Descriptor instead.
+func (*BackupSetSummary) Descriptor() ([]byte, []int) {
+	return file_system_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *BackupSetSummary) GetSetId() string {
+	if x != nil {
+		return x.SetId
+	}
+	return ""
+}
+
+func (x *BackupSetSummary) GetTimeStampUnixSec() uint32 {
+	if x != nil {
+		return x.TimeStampUnixSec
+	}
+	return 0
+}
+
+func (x *BackupSetSummary) GetComplete() bool {
+	if x != nil {
+		return x.Complete
+	}
+	return false
+}
+
+func (x *BackupSetSummary) GetFileCount() uint32 {
+	if x != nil {
+		return x.FileCount
+	}
+	return 0
+}
+
+func (x *BackupSetSummary) GetCopiedFileCount() uint32 {
+	if x != nil {
+		return x.CopiedFileCount
+	}
+	return 0
+}
+
+// requires(PIXLISE_ADMIN)
+type BackupSetListReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BackupSetListReq) Reset() {
+	*x = BackupSetListReq{}
+	mi := &file_system_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BackupSetListReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BackupSetListReq) ProtoMessage() {}
+
+func (x *BackupSetListReq) ProtoReflect() protoreflect.Message {
+	mi := &file_system_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BackupSetListReq.ProtoReflect.Descriptor instead.
+func (*BackupSetListReq) Descriptor() ([]byte, []int) {
+	return file_system_proto_rawDescGZIP(), []int{8}
+}
+
+type BackupSetListResp struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Newest first
+	Sets          []*BackupSetSummary `protobuf:"bytes,1,rep,name=sets,proto3" json:"sets,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BackupSetListResp) Reset() {
+	*x = BackupSetListResp{}
+	mi := &file_system_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BackupSetListResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BackupSetListResp) ProtoMessage() {}
+
+func (x *BackupSetListResp) ProtoReflect() protoreflect.Message {
+	mi := &file_system_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BackupSetListResp.ProtoReflect.Descriptor instead.
+func (*BackupSetListResp) Descriptor() ([]byte, []int) {
+	return file_system_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *BackupSetListResp) GetSets() []*BackupSetSummary {
+	if x != nil {
+		return x.Sets
+	}
+	return nil
+}
+
 var File_system_proto protoreflect.FileDescriptor
 
 const file_system_proto_rawDesc = "" +
@@ -323,8 +502,10 @@ const file_system_proto_rawDesc = "" +
 	"\fsystem.proto\"\r\n" +
 	"\vBackupDBReq\"\x0e\n" +
 	"\fBackupDBResp\"\r\n" +
-	"\vBackupDBUpd\"\x0e\n" +
-	"\fRestoreDBReq\"\x0f\n" +
+	"\vBackupDBUpd\"H\n" +
+	"\fRestoreDBReq\x12 \n" +
+	"\vbackupSetId\x18\x01 \x01(\tR\vbackupSetId\x12\x16\n" +
+	"\x06scanId\x18\x02 \x01(\tR\x06scanId\"\x0f\n" +
 	"\rRestoreDBResp\"\x15\n" +
 	"\x13DBAdminConfigGetReq\"\xd4\x01\n" +
 	"\x14DBAdminConfigGetResp\x12\x1c\n" +
@@ -334,7 +515,16 @@ const file_system_proto_rawDesc = "" +
 	"canRestore\x18\x03 \x01(\bR\n" +
 	"canRestore\x12 \n" +
 	"\vrestoreFrom\x18\x04 \x01(\tR\vrestoreFrom\x12.\n" +
-	"\x12impersonateEnabled\x18\x05 \x01(\bR\x12impersonateEnabledB\n" +
+	"\x12impersonateEnabled\x18\x05 \x01(\bR\x12impersonateEnabled\"\xb8\x01\n" +
+	"\x10BackupSetSummary\x12\x14\n" +
+	"\x05setId\x18\x01 \x01(\tR\x05setId\x12*\n" +
+	"\x10timeStampUnixSec\x18\x02 \x01(\rR\x10timeStampUnixSec\x12\x1a\n" +
+	"\bcomplete\x18\x03 \x01(\bR\bcomplete\x12\x1c\n" +
+	"\tfileCount\x18\x04 \x01(\rR\tfileCount\x12(\n" +
+	"\x0fcopiedFileCount\x18\x05 \x01(\rR\x0fcopiedFileCount\"\x12\n" +
+	"\x10BackupSetListReq\":\n" +
+	"\x11BackupSetListResp\x12%\n" +
+	"\x04sets\x18\x01 \x03(\v2\x11.BackupSetSummaryR\x04setsB\n" +
 	"Z\b.;protosb\x06proto3"
 
 var (
@@ -349,7 +539,7 @@ func file_system_proto_rawDescGZIP() []byte {
 	return file_system_proto_rawDescData
 }
 
-var file_system_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_system_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
 var file_system_proto_goTypes = []any{
 	(*BackupDBReq)(nil),          // 0: BackupDBReq
 	(*BackupDBResp)(nil),         // 1: BackupDBResp
@@ -358,13 +548,17 @@ var file_system_proto_goTypes = []any{
 	(*RestoreDBResp)(nil),        // 4: RestoreDBResp
 	(*DBAdminConfigGetReq)(nil),  // 5: DBAdminConfigGetReq
 	(*DBAdminConfigGetResp)(nil), // 6: DBAdminConfigGetResp
+	(*BackupSetSummary)(nil),     // 7: BackupSetSummary
+	(*BackupSetListReq)(nil),     // 8: BackupSetListReq
+	(*BackupSetListResp)(nil),    // 9: BackupSetListResp
 }
 var file_system_proto_depIdxs = []int32{
-	0, // [0:0] is the sub-list for method output_type
-	0, // [0:0] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	7, // 0: BackupSetListResp.sets:type_name -> BackupSetSummary
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
 }
 
 func init() { file_system_proto_init() }
@@ -378,7 +572,7 @@ func file_system_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_system_proto_rawDesc), len(file_system_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   7,
+			NumMessages:   10,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
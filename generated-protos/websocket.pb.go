@@ -102,9 +102,19 @@ type WSMessage struct {
 	ErrorText string `protobuf:"bytes,3,opt,name=errorText,proto3" json:"errorText,omitempty"`
 	// Types that are valid to be assigned to Contents:
 	//
+	//	*WSMessage_AuditLogListReq
+	//	*WSMessage_AuditLogListResp
+	//	*WSMessage_AutoQuantProfileDeleteReq
+	//	*WSMessage_AutoQuantProfileDeleteResp
+	//	*WSMessage_AutoQuantProfileListReq
+	//	*WSMessage_AutoQuantProfileListResp
+	//	*WSMessage_AutoQuantProfileWriteReq
+	//	*WSMessage_AutoQuantProfileWriteResp
 	//	*WSMessage_BackupDBReq
 	//	*WSMessage_BackupDBResp
 	//	*WSMessage_BackupDBUpd
+	//	*WSMessage_BackupSetListReq
+	//	*WSMessage_BackupSetListResp
 	//	*WSMessage_DBAdminConfigGetReq
 	//	*WSMessage_DBAdminConfigGetResp
 	//	*WSMessage_DataModuleAddVersionReq
@@ -143,8 +153,15 @@ type WSMessage struct {
 	//	*WSMessage_ElementSetWriteResp
 	//	*WSMessage_ExportFilesReq
 	//	*WSMessage_ExportFilesResp
+	//	*WSMessage_ExpressionBatchCalculateReq
+	//	*WSMessage_ExpressionBatchCalculateResp
 	//	*WSMessage_ExpressionCalculateReq
 	//	*WSMessage_ExpressionCalculateResp
+	//	*WSMessage_ExpressionDebugCommandReq
+	//	*WSMessage_ExpressionDebugCommandResp
+	//	*WSMessage_ExpressionDebugStartReq
+	//	*WSMessage_ExpressionDebugStartResp
+	//	*WSMessage_ExpressionDebugUpd
 	//	*WSMessage_ExpressionDeleteReq
 	//	*WSMessage_ExpressionDeleteResp
 	//	*WSMessage_ExpressionDisplaySettingsGetReq
@@ -205,6 +222,8 @@ type WSMessage struct {
 	//	*WSMessage_ImportMarsViewerImageReq
 	//	*WSMessage_ImportMarsViewerImageResp
 	//	*WSMessage_ImportMarsViewerImageUpd
+	//	*WSMessage_JobCancelReq
+	//	*WSMessage_JobCancelResp
 	//	*WSMessage_JobListReq
 	//	*WSMessage_JobListResp
 	//	*WSMessage_JobListUpd
@@ -227,6 +246,12 @@ type WSMessage struct {
 	//	*WSMessage_NotificationUpd
 	//	*WSMessage_ObjectEditAccessReq
 	//	*WSMessage_ObjectEditAccessResp
+	//	*WSMessage_ObjectRevisionGetReq
+	//	*WSMessage_ObjectRevisionGetResp
+	//	*WSMessage_ObjectRevisionListReq
+	//	*WSMessage_ObjectRevisionListResp
+	//	*WSMessage_ObjectRevisionRestoreReq
+	//	*WSMessage_ObjectRevisionRestoreResp
 	//	*WSMessage_PiquantConfigFileReq
 	//	*WSMessage_PiquantConfigFileResp
 	//	*WSMessage_PiquantConfigListReq
@@ -237,6 +262,11 @@ type WSMessage struct {
 	//	*WSMessage_PiquantConfigVersionsListResp
 	//	*WSMessage_PiquantCurrentVersionReq
 	//	*WSMessage_PiquantCurrentVersionResp
+	//	*WSMessage_PiquantRegressionReq
+	//	*WSMessage_PiquantRegressionResp
+	//	*WSMessage_PiquantRegressionResultReq
+	//	*WSMessage_PiquantRegressionResultResp
+	//	*WSMessage_PiquantRegressionUpd
 	//	*WSMessage_PiquantVersionListReq
 	//	*WSMessage_PiquantVersionListResp
 	//	*WSMessage_PiquantWriteCurrentVersionReq
@@ -256,6 +286,8 @@ type WSMessage struct {
 	//	*WSMessage_QuantCreateReq
 	//	*WSMessage_QuantCreateResp
 	//	*WSMessage_QuantCreateUpd
+	//	*WSMessage_QuantDiffReq
+	//	*WSMessage_QuantDiffResp
 	//	*WSMessage_QuantDeleteReq
 	//	*WSMessage_QuantDeleteResp
 	//	*WSMessage_QuantGetReq
@@ -497,6 +529,78 @@ func (x *WSMessage) GetContents() isWSMessage_Contents {
 	return nil
 }
 
+func (x *WSMessage) GetAuditLogListReq() *AuditLogListReq {
+	if x != nil {
+		if x, ok := x.Contents.(*WSMessage_AuditLogListReq); ok {
+			return x.AuditLogListReq
+		}
+	}
+	return nil
+}
+
+func (x *WSMessage) GetAuditLogListResp() *AuditLogListResp {
+	if x != nil {
+		if x, ok := x.Contents.(*WSMessage_AuditLogListResp); ok {
+			return x.AuditLogListResp
+		}
+	}
+	return nil
+}
+
+func (x *WSMessage) GetAutoQuantProfileDeleteReq() *AutoQuantProfileDeleteReq {
+	if x != nil {
+		if x, ok := x.Contents.(*WSMessage_AutoQuantProfileDeleteReq); ok {
+			return x.AutoQuantProfileDeleteReq
+		}
+	}
+	return nil
+}
+
+func (x *WSMessage) GetAutoQuantProfileDeleteResp() *AutoQuantProfileDeleteResp {
+	if x != nil {
+		if x, ok := x.Contents.(*WSMessage_AutoQuantProfileDeleteResp); ok {
+			return x.AutoQuantProfileDeleteResp
+		}
+	}
+	return nil
+}
+
+func (x *WSMessage) GetAutoQuantProfileListReq() *AutoQuantProfileListReq {
+	if x != nil {
+		if x, ok := x.Contents.(*WSMessage_AutoQuantProfileListReq); ok {
+			return x.AutoQuantProfileListReq
+		}
+	}
+	return nil
+}
+
+func (x *WSMessage) GetAutoQuantProfileListResp() *AutoQuantProfileListResp {
+	if x != nil {
+		if x, ok := x.Contents.(*WSMessage_AutoQuantProfileListResp); ok {
+			return x.AutoQuantProfileListResp
+		}
+	}
+	return nil
+}
+
+func (x *WSMessage) GetAutoQuantProfileWriteReq() *AutoQuantProfileWriteReq {
+	if x != nil {
+		if x, ok := x.Contents.(*WSMessage_AutoQuantProfileWriteReq); ok {
+			return x.AutoQuantProfileWriteReq
+		}
+	}
+	return nil
+}
+
+func (x *WSMessage) GetAutoQuantProfileWriteResp() *AutoQuantProfileWriteResp {
+	if x != nil {
+		if x, ok := x.Contents.(*WSMessage_AutoQuantProfileWriteResp); ok {
+			return x.AutoQuantProfileWriteResp
+		}
+	}
+	return nil
+}
+
 func (x *WSMessage) GetBackupDBReq() *BackupDBReq {
 	if x != nil {
 		if x, ok := x.Contents.(*WSMessage_BackupDBReq); ok {
@@ -524,6 +628,24 @@ func (x *WSMessage) GetBackupDBUpd() *BackupDBUpd {
 	return nil
 }
 
+func (x *WSMessage) GetBackupSetListReq() *BackupSetListReq {
+	if x != nil {
+		if x, ok := x.Contents.(*WSMessage_BackupSetListReq); ok {
+			return x.BackupSetListReq
+		}
+	}
+	return nil
+}
+
+func (x *WSMessage) GetBackupSetListResp() *BackupSetListResp {
+	if x != nil {
+		if x, ok := x.Contents.(*WSMessage_BackupSetListResp); ok {
+			return x.BackupSetListResp
+		}
+	}
+	return nil
+}
+
 func (x *WSMessage) GetDBAdminConfigGetReq() *DBAdminConfigGetReq {
 	if x != nil {
 		if x, ok := x.Contents.(*WSMessage_DBAdminConfigGetReq); ok {
@@ -866,6 +988,24 @@ func (x *WSMessage) GetExportFilesResp() *ExportFilesResp {
 	return nil
 }
 
+func (x *WSMessage) GetExpressionBatchCalculateReq() *ExpressionBatchCalculateReq {
+	if x != nil {
+		if x, ok := x.Contents.(*WSMessage_ExpressionBatchCalculateReq); ok {
+			return x.ExpressionBatchCalculateReq
+		}
+	}
+	return nil
+}
+
+func (x *WSMessage) GetExpressionBatchCalculateResp() *ExpressionBatchCalculateResp {
+	if x != nil {
+		if x, ok := x.Contents.(*WSMessage_ExpressionBatchCalculateResp); ok {
+			return x.ExpressionBatchCalculateResp
+		}
+	}
+	return nil
+}
+
 func (x *WSMessage) GetExpressionCalculateReq() *ExpressionCalculateReq {
 	if x != nil {
 		if x, ok := x.Contents.(*WSMessage_ExpressionCalculateReq); ok {
@@ -884,6 +1024,51 @@ func (x *WSMessage) GetExpressionCalculateResp() *ExpressionCalculateResp {
 	return nil
 }
 
+func (x *WSMessage) GetExpressionDebugCommandReq() *ExpressionDebugCommandReq {
+	if x != nil {
+		if x, ok := x.Contents.(*WSMessage_ExpressionDebugCommandReq); ok {
+			return x.ExpressionDebugCommandReq
+		}
+	}
+	return nil
+}
+
+func (x *WSMessage) GetExpressionDebugCommandResp() *ExpressionDebugCommandResp {
+	if x != nil {
+		if x, ok := x.Contents.(*WSMessage_ExpressionDebugCommandResp); ok {
+			return x.ExpressionDebugCommandResp
+		}
+	}
+	return nil
+}
+
+func (x *WSMessage) GetExpressionDebugStartReq() *ExpressionDebugStartReq {
+	if x != nil {
+		if x, ok := x.Contents.(*WSMessage_ExpressionDebugStartReq); ok {
+			return x.ExpressionDebugStartReq
+		}
+	}
+	return nil
+}
+
+func (x *WSMessage) GetExpressionDebugStartResp() *ExpressionDebugStartResp {
+	if x != nil {
+		if x, ok := x.Contents.(*WSMessage_ExpressionDebugStartResp); ok {
+			return x.ExpressionDebugStartResp
+		}
+	}
+	return nil
+}
+
+func (x *WSMessage) GetExpressionDebugUpd() *ExpressionDebugUpd {
+	if x != nil {
+		if x, ok := x.Contents.(*WSMessage_ExpressionDebugUpd); ok {
+			return x.ExpressionDebugUpd
+		}
+	}
+	return nil
+}
+
 func (x *WSMessage) GetExpressionDeleteReq() *ExpressionDeleteReq {
 	if x != nil {
 		if x, ok := x.Contents.(*WSMessage_ExpressionDeleteReq); ok {
@@ -1424,6 +1609,24 @@ func (x *WSMessage) GetImportMarsViewerImageUpd() *ImportMarsViewerImageUpd {
 	return nil
 }
 
+func (x *WSMessage) GetJobCancelReq() *JobCancelReq {
+	if x != nil {
+		if x, ok := x.Contents.(*WSMessage_JobCancelReq); ok {
+			return x.JobCancelReq
+		}
+	}
+	return nil
+}
+
+func (x *WSMessage) GetJobCancelResp() *JobCancelResp {
+	if x != nil {
+		if x, ok := x.Contents.(*WSMessage_JobCancelResp); ok {
+			return x.JobCancelResp
+		}
+	}
+	return nil
+}
+
 func (x *WSMessage) GetJobListReq() *JobListReq {
 	if x != nil {
 		if x, ok := x.Contents.(*WSMessage_JobListReq); ok {
@@ -1622,6 +1825,60 @@ func (x *WSMessage) GetObjectEditAccessResp() *ObjectEditAccessResp {
 	return nil
 }
 
+func (x *WSMessage) GetObjectRevisionGetReq() *ObjectRevisionGetReq {
+	if x != nil {
+		if x, ok := x.Contents.(*WSMessage_ObjectRevisionGetReq); ok {
+			return x.ObjectRevisionGetReq
+		}
+	}
+	return nil
+}
+
+func (x *WSMessage) GetObjectRevisionGetResp() *ObjectRevisionGetResp {
+	if x != nil {
+		if x, ok := x.Contents.(*WSMessage_ObjectRevisionGetResp); ok {
+			return x.ObjectRevisionGetResp
+		}
+	}
+	return nil
+}
+
+func (x *WSMessage) GetObjectRevisionListReq() *ObjectRevisionListReq {
+	if x != nil {
+		if x, ok := x.Contents.(*WSMessage_ObjectRevisionListReq); ok {
+			return x.ObjectRevisionListReq
+		}
+	}
+	return nil
+}
+
+func (x *WSMessage) GetObjectRevisionListResp() *ObjectRevisionListResp {
+	if x != nil {
+		if x, ok := x.Contents.(*WSMessage_ObjectRevisionListResp); ok {
+			return x.ObjectRevisionListResp
+		}
+	}
+	return nil
+}
+
+func (x *WSMessage) GetObjectRevisionRestoreReq() *ObjectRevisionRestoreReq {
+	if x != nil {
+		if x, ok := x.Contents.(*WSMessage_ObjectRevisionRestoreReq); ok {
+			return x.ObjectRevisionRestoreReq
+		}
+	}
+	return nil
+}
+
+func (x *WSMessage) GetObjectRevisionRestoreResp() *ObjectRevisionRestoreResp {
+	if x != nil {
+		if x, ok := x.Contents.(*WSMessage_ObjectRevisionRestoreResp); ok {
+			return x.ObjectRevisionRestoreResp
+		}
+	}
+	return nil
+}
+
 func (x *WSMessage) GetPiquantConfigFileReq() *PiquantConfigFileReq {
 	if x != nil {
 		if x, ok := x.Contents.(*WSMessage_PiquantConfigFileReq); ok {
@@ -1712,6 +1969,51 @@ func (x *WSMessage) GetPiquantCurrentVersionResp() *PiquantCurrentVersionResp {
 	return nil
 }
 
+func (x *WSMessage) GetPiquantRegressionReq() *PiquantRegressionReq {
+	if x != nil {
+		if x, ok := x.Contents.(*WSMessage_PiquantRegressionReq); ok {
+			return x.PiquantRegressionReq
+		}
+	}
+	return nil
+}
+
+func (x *WSMessage) GetPiquantRegressionResp() *PiquantRegressionResp {
+	if x != nil {
+		if x, ok := x.Contents.(*WSMessage_PiquantRegressionResp); ok {
+			return x.PiquantRegressionResp
+		}
+	}
+	return nil
+}
+
+func (x *WSMessage) GetPiquantRegressionResultReq() *PiquantRegressionResultReq {
+	if x != nil {
+		if x, ok := x.Contents.(*WSMessage_PiquantRegressionResultReq); ok {
+			return x.PiquantRegressionResultReq
+		}
+	}
+	return nil
+}
+
+func (x *WSMessage) GetPiquantRegressionResultResp() *PiquantRegressionResultResp {
+	if x != nil {
+		if x, ok := x.Contents.(*WSMessage_PiquantRegressionResultResp); ok {
+			return x.PiquantRegressionResultResp
+		}
+	}
+	return nil
+}
+
+func (x *WSMessage) GetPiquantRegressionUpd() *PiquantRegressionUpd {
+	if x != nil {
+		if x, ok := x.Contents.(*WSMessage_PiquantRegressionUpd); ok {
+			return x.PiquantRegressionUpd
+		}
+	}
+	return nil
+}
+
 func (x *WSMessage) GetPiquantVersionListReq() *PiquantVersionListReq {
 	if x != nil {
 		if x, ok := x.Contents.(*WSMessage_PiquantVersionListReq); ok {
@@ -1883,6 +2185,24 @@ func (x *WSMessage) GetQuantCreateUpd() *QuantCreateUpd {
 	return nil
 }
 
+func (x *WSMessage) GetQuantDiffReq() *QuantDiffReq {
+	if x != nil {
+		if x, ok := x.Contents.(*WSMessage_QuantDiffReq); ok {
+			return x.QuantDiffReq
+		}
+	}
+	return nil
+}
+
+func (x *WSMessage) GetQuantDiffResp() *QuantDiffResp {
+	if x != nil {
+		if x, ok := x.Contents.(*WSMessage_QuantDiffResp); ok {
+			return x.QuantDiffResp
+		}
+	}
+	return nil
+}
+
 func (x *WSMessage) GetQuantDeleteReq() *QuantDeleteReq {
 	if x != nil {
 		if x, ok := x.Contents.(*WSMessage_QuantDeleteReq); ok {
@@ -3489,6 +3809,38 @@ type isWSMessage_Contents interface {
 	isWSMessage_Contents()
 }
 
+type WSMessage_AuditLogListReq struct {
+	AuditLogListReq *AuditLogListReq `protobuf:"bytes,390,opt,name=auditLogListReq,proto3,oneof"`
+}
+
+type WSMessage_AuditLogListResp struct {
+	AuditLogListResp *AuditLogListResp `protobuf:"bytes,391,opt,name=auditLogListResp,proto3,oneof"`
+}
+
+type WSMessage_AutoQuantProfileDeleteReq struct {
+	AutoQuantProfileDeleteReq *AutoQuantProfileDeleteReq `protobuf:"bytes,379,opt,name=autoQuantProfileDeleteReq,proto3,oneof"`
+}
+
+type WSMessage_AutoQuantProfileDeleteResp struct {
+	AutoQuantProfileDeleteResp *AutoQuantProfileDeleteResp `protobuf:"bytes,380,opt,name=autoQuantProfileDeleteResp,proto3,oneof"`
+}
+
+type WSMessage_AutoQuantProfileListReq struct {
+	AutoQuantProfileListReq *AutoQuantProfileListReq `protobuf:"bytes,375,opt,name=autoQuantProfileListReq,proto3,oneof"`
+}
+
+type WSMessage_AutoQuantProfileListResp struct {
+	AutoQuantProfileListResp *AutoQuantProfileListResp `protobuf:"bytes,376,opt,name=autoQuantProfileListResp,proto3,oneof"`
+}
+
+type WSMessage_AutoQuantProfileWriteReq struct {
+	AutoQuantProfileWriteReq *AutoQuantProfileWriteReq `protobuf:"bytes,377,opt,name=autoQuantProfileWriteReq,proto3,oneof"`
+}
+
+type WSMessage_AutoQuantProfileWriteResp struct {
+	AutoQuantProfileWriteResp *AutoQuantProfileWriteResp `protobuf:"bytes,378,opt,name=autoQuantProfileWriteResp,proto3,oneof"`
+}
+
 type WSMessage_BackupDBReq struct {
 	BackupDBReq *BackupDBReq `protobuf:"bytes,311,opt,name=backupDBReq,proto3,oneof"`
 }
@@ -3501,6 +3853,14 @@ type WSMessage_BackupDBUpd struct {
 	BackupDBUpd *BackupDBUpd `protobuf:"bytes,313,opt,name=backupDBUpd,proto3,oneof"`
 }
 
+type WSMessage_BackupSetListReq struct {
+	BackupSetListReq *BackupSetListReq `protobuf:"bytes,388,opt,name=backupSetListReq,proto3,oneof"`
+}
+
+type WSMessage_BackupSetListResp struct {
+	BackupSetListResp *BackupSetListResp `protobuf:"bytes,389,opt,name=backupSetListResp,proto3,oneof"`
+}
+
 type WSMessage_DBAdminConfigGetReq struct {
 	DBAdminConfigGetReq *DBAdminConfigGetReq `protobuf:"bytes,314,opt,name=dBAdminConfigGetReq,proto3,oneof"`
 }
@@ -3653,6 +4013,14 @@ type WSMessage_ExportFilesResp struct {
 	ExportFilesResp *ExportFilesResp `protobuf:"bytes,39,opt,name=exportFilesResp,proto3,oneof"`
 }
 
+type WSMessage_ExpressionBatchCalculateReq struct {
+	ExpressionBatchCalculateReq *ExpressionBatchCalculateReq `protobuf:"bytes,368,opt,name=expressionBatchCalculateReq,proto3,oneof"`
+}
+
+type WSMessage_ExpressionBatchCalculateResp struct {
+	ExpressionBatchCalculateResp *ExpressionBatchCalculateResp `protobuf:"bytes,369,opt,name=expressionBatchCalculateResp,proto3,oneof"`
+}
+
 type WSMessage_ExpressionCalculateReq struct {
 	ExpressionCalculateReq *ExpressionCalculateReq `protobuf:"bytes,362,opt,name=expressionCalculateReq,proto3,oneof"`
 }
@@ -3661,6 +4029,26 @@ type WSMessage_ExpressionCalculateResp struct {
 	ExpressionCalculateResp *ExpressionCalculateResp `protobuf:"bytes,363,opt,name=expressionCalculateResp,proto3,oneof"`
 }
 
+type WSMessage_ExpressionDebugCommandReq struct {
+	ExpressionDebugCommandReq *ExpressionDebugCommandReq `protobuf:"bytes,372,opt,name=expressionDebugCommandReq,proto3,oneof"`
+}
+
+type WSMessage_ExpressionDebugCommandResp struct {
+	ExpressionDebugCommandResp *ExpressionDebugCommandResp `protobuf:"bytes,373,opt,name=expressionDebugCommandResp,proto3,oneof"`
+}
+
+type WSMessage_ExpressionDebugStartReq struct {
+	ExpressionDebugStartReq *ExpressionDebugStartReq `protobuf:"bytes,370,opt,name=expressionDebugStartReq,proto3,oneof"`
+}
+
+type WSMessage_ExpressionDebugStartResp struct {
+	ExpressionDebugStartResp *ExpressionDebugStartResp `protobuf:"bytes,371,opt,name=expressionDebugStartResp,proto3,oneof"`
+}
+
+type WSMessage_ExpressionDebugUpd struct {
+	ExpressionDebugUpd *ExpressionDebugUpd `protobuf:"bytes,374,opt,name=expressionDebugUpd,proto3,oneof"`
+}
+
 type WSMessage_ExpressionDeleteReq struct {
 	ExpressionDeleteReq *ExpressionDeleteReq `protobuf:"bytes,40,opt,name=expressionDeleteReq,proto3,oneof"`
 }
@@ -3901,6 +4289,14 @@ type WSMessage_ImportMarsViewerImageUpd struct {
 	ImportMarsViewerImageUpd *ImportMarsViewerImageUpd `protobuf:"bytes,275,opt,name=importMarsViewerImageUpd,proto3,oneof"`
 }
 
+type WSMessage_JobCancelReq struct {
+	JobCancelReq *JobCancelReq `protobuf:"bytes,366,opt,name=jobCancelReq,proto3,oneof"`
+}
+
+type WSMessage_JobCancelResp struct {
+	JobCancelResp *JobCancelResp `protobuf:"bytes,367,opt,name=jobCancelResp,proto3,oneof"`
+}
+
 type WSMessage_JobListReq struct {
 	JobListReq *JobListReq `protobuf:"bytes,296,opt,name=jobListReq,proto3,oneof"`
 }
@@ -3989,6 +4385,30 @@ type WSMessage_ObjectEditAccessResp struct {
 	ObjectEditAccessResp *ObjectEditAccessResp `protobuf:"bytes,175,opt,name=objectEditAccessResp,proto3,oneof"`
 }
 
+type WSMessage_ObjectRevisionGetReq struct {
+	ObjectRevisionGetReq *ObjectRevisionGetReq `protobuf:"bytes,394,opt,name=objectRevisionGetReq,proto3,oneof"`
+}
+
+type WSMessage_ObjectRevisionGetResp struct {
+	ObjectRevisionGetResp *ObjectRevisionGetResp `protobuf:"bytes,395,opt,name=objectRevisionGetResp,proto3,oneof"`
+}
+
+type WSMessage_ObjectRevisionListReq struct {
+	ObjectRevisionListReq *ObjectRevisionListReq `protobuf:"bytes,392,opt,name=objectRevisionListReq,proto3,oneof"`
+}
+
+type WSMessage_ObjectRevisionListResp struct {
+	ObjectRevisionListResp *ObjectRevisionListResp `protobuf:"bytes,393,opt,name=objectRevisionListResp,proto3,oneof"`
+}
+
+type WSMessage_ObjectRevisionRestoreReq struct {
+	ObjectRevisionRestoreReq *ObjectRevisionRestoreReq `protobuf:"bytes,396,opt,name=objectRevisionRestoreReq,proto3,oneof"`
+}
+
+type WSMessage_ObjectRevisionRestoreResp struct {
+	ObjectRevisionRestoreResp *ObjectRevisionRestoreResp `protobuf:"bytes,397,opt,name=objectRevisionRestoreResp,proto3,oneof"`
+}
+
 type WSMessage_PiquantConfigFileReq struct {
 	PiquantConfigFileReq *PiquantConfigFileReq `protobuf:"bytes,350,opt,name=piquantConfigFileReq,proto3,oneof"`
 }
@@ -4029,6 +4449,26 @@ type WSMessage_PiquantCurrentVersionResp struct {
 	PiquantCurrentVersionResp *PiquantCurrentVersionResp `protobuf:"bytes,80,opt,name=piquantCurrentVersionResp,proto3,oneof"`
 }
 
+type WSMessage_PiquantRegressionReq struct {
+	PiquantRegressionReq *PiquantRegressionReq `protobuf:"bytes,383,opt,name=piquantRegressionReq,proto3,oneof"`
+}
+
+type WSMessage_PiquantRegressionResp struct {
+	PiquantRegressionResp *PiquantRegressionResp `protobuf:"bytes,384,opt,name=piquantRegressionResp,proto3,oneof"`
+}
+
+type WSMessage_PiquantRegressionResultReq struct {
+	PiquantRegressionResultReq *PiquantRegressionResultReq `protobuf:"bytes,385,opt,name=piquantRegressionResultReq,proto3,oneof"`
+}
+
+type WSMessage_PiquantRegressionResultResp struct {
+	PiquantRegressionResultResp *PiquantRegressionResultResp `protobuf:"bytes,386,opt,name=piquantRegressionResultResp,proto3,oneof"`
+}
+
+type WSMessage_PiquantRegressionUpd struct {
+	PiquantRegressionUpd *PiquantRegressionUpd `protobuf:"bytes,387,opt,name=piquantRegressionUpd,proto3,oneof"`
+}
+
 type WSMessage_PiquantVersionListReq struct {
 	PiquantVersionListReq *PiquantVersionListReq `protobuf:"bytes,81,opt,name=piquantVersionListReq,proto3,oneof"`
 }
@@ -4105,6 +4545,14 @@ type WSMessage_QuantCreateUpd struct {
 	QuantCreateUpd *QuantCreateUpd `protobuf:"bytes,272,opt,name=quantCreateUpd,proto3,oneof"`
 }
 
+type WSMessage_QuantDiffReq struct {
+	QuantDiffReq *QuantDiffReq `protobuf:"bytes,381,opt,name=quantDiffReq,proto3,oneof"`
+}
+
+type WSMessage_QuantDiffResp struct {
+	QuantDiffResp *QuantDiffResp `protobuf:"bytes,382,opt,name=quantDiffResp,proto3,oneof"`
+}
+
 type WSMessage_QuantDeleteReq struct {
 	QuantDeleteReq *QuantDeleteReq `protobuf:"bytes,210,opt,name=quantDeleteReq,proto3,oneof"`
 }
@@ -4817,12 +5265,32 @@ type WSMessage_ZenodoDOIGetResp struct {
 	ZenodoDOIGetResp *ZenodoDOIGetResp `protobuf:"bytes,241,opt,name=zenodoDOIGetResp,proto3,oneof"`
 }
 
+func (*WSMessage_AuditLogListReq) isWSMessage_Contents() {}
+
+func (*WSMessage_AuditLogListResp) isWSMessage_Contents() {}
+
+func (*WSMessage_AutoQuantProfileDeleteReq) isWSMessage_Contents() {}
+
+func (*WSMessage_AutoQuantProfileDeleteResp) isWSMessage_Contents() {}
+
+func (*WSMessage_AutoQuantProfileListReq) isWSMessage_Contents() {}
+
+func (*WSMessage_AutoQuantProfileListResp) isWSMessage_Contents() {}
+
+func (*WSMessage_AutoQuantProfileWriteReq) isWSMessage_Contents() {}
+
+func (*WSMessage_AutoQuantProfileWriteResp) isWSMessage_Contents() {}
+
 func (*WSMessage_BackupDBReq) isWSMessage_Contents() {}
 
 func (*WSMessage_BackupDBResp) isWSMessage_Contents() {}
 
 func (*WSMessage_BackupDBUpd) isWSMessage_Contents() {}
 
+func (*WSMessage_BackupSetListReq) isWSMessage_Contents() {}
+
+func (*WSMessage_BackupSetListResp) isWSMessage_Contents() {}
+
 func (*WSMessage_DBAdminConfigGetReq) isWSMessage_Contents() {}
 
 func (*WSMessage_DBAdminConfigGetResp) isWSMessage_Contents() {}
@@ -4899,10 +5367,24 @@ func (*WSMessage_ExportFilesReq) isWSMessage_Contents() {}
 
 func (*WSMessage_ExportFilesResp) isWSMessage_Contents() {}
 
+func (*WSMessage_ExpressionBatchCalculateReq) isWSMessage_Contents() {}
+
+func (*WSMessage_ExpressionBatchCalculateResp) isWSMessage_Contents() {}
+
 func (*WSMessage_ExpressionCalculateReq) isWSMessage_Contents() {}
 
 func (*WSMessage_ExpressionCalculateResp) isWSMessage_Contents() {}
 
+func (*WSMessage_ExpressionDebugCommandReq) isWSMessage_Contents() {}
+
+func (*WSMessage_ExpressionDebugCommandResp) isWSMessage_Contents() {}
+
+func (*WSMessage_ExpressionDebugStartReq) isWSMessage_Contents() {}
+
+func (*WSMessage_ExpressionDebugStartResp) isWSMessage_Contents() {}
+
+func (*WSMessage_ExpressionDebugUpd) isWSMessage_Contents() {}
+
 func (*WSMessage_ExpressionDeleteReq) isWSMessage_Contents() {}
 
 func (*WSMessage_ExpressionDeleteResp) isWSMessage_Contents() {}
@@ -5023,6 +5505,10 @@ func (*WSMessage_ImportMarsViewerImageResp) isWSMessage_Contents() {}
 
 func (*WSMessage_ImportMarsViewerImageUpd) isWSMessage_Contents() {}
 
+func (*WSMessage_JobCancelReq) isWSMessage_Contents() {}
+
+func (*WSMessage_JobCancelResp) isWSMessage_Contents() {}
+
 func (*WSMessage_JobListReq) isWSMessage_Contents() {}
 
 func (*WSMessage_JobListResp) isWSMessage_Contents() {}
@@ -5067,6 +5553,18 @@ func (*WSMessage_ObjectEditAccessReq) isWSMessage_Contents() {}
 
 func (*WSMessage_ObjectEditAccessResp) isWSMessage_Contents() {}
 
+func (*WSMessage_ObjectRevisionGetReq) isWSMessage_Contents() {}
+
+func (*WSMessage_ObjectRevisionGetResp) isWSMessage_Contents() {}
+
+func (*WSMessage_ObjectRevisionListReq) isWSMessage_Contents() {}
+
+func (*WSMessage_ObjectRevisionListResp) isWSMessage_Contents() {}
+
+func (*WSMessage_ObjectRevisionRestoreReq) isWSMessage_Contents() {}
+
+func (*WSMessage_ObjectRevisionRestoreResp) isWSMessage_Contents() {}
+
 func (*WSMessage_PiquantConfigFileReq) isWSMessage_Contents() {}
 
 func (*WSMessage_PiquantConfigFileResp) isWSMessage_Contents() {}
@@ -5087,6 +5585,16 @@ func (*WSMessage_PiquantCurrentVersionReq) isWSMessage_Contents() {}
 
 func (*WSMessage_PiquantCurrentVersionResp) isWSMessage_Contents() {}
 
+func (*WSMessage_PiquantRegressionReq) isWSMessage_Contents() {}
+
+func (*WSMessage_PiquantRegressionResp) isWSMessage_Contents() {}
+
+func (*WSMessage_PiquantRegressionResultReq) isWSMessage_Contents() {}
+
+func (*WSMessage_PiquantRegressionResultResp) isWSMessage_Contents() {}
+
+func (*WSMessage_PiquantRegressionUpd) isWSMessage_Contents() {}
+
 func (*WSMessage_PiquantVersionListReq) isWSMessage_Contents() {}
 
 func (*WSMessage_PiquantVersionListResp) isWSMessage_Contents() {}
@@ -5125,6 +5633,10 @@ func (*WSMessage_QuantCreateResp) isWSMessage_Contents() {}
 
 func (*WSMessage_QuantCreateUpd) isWSMessage_Contents() {}
 
+func (*WSMessage_QuantDiffReq) isWSMessage_Contents() {}
+
+func (*WSMessage_QuantDiffResp) isWSMessage_Contents() {}
+
 func (*WSMessage_QuantDeleteReq) isWSMessage_Contents() {}
 
 func (*WSMessage_QuantDeleteResp) isWSMessage_Contents() {}
@@ -5485,14 +5997,24 @@ var File_websocket_proto protoreflect.FileDescriptor
 
 const file_websocket_proto_rawDesc = "" +
 	"\n" +
-	"\x0fwebsocket.proto\x1a\x1adetector-config-msgs.proto\x1a$diffraction-detected-peak-msgs.proto\x1a\x1ddiffraction-manual-msgs.proto\x1a\x1ddiffraction-status-msgs.proto\x1a\x16element-set-msgs.proto\x1a\x11export-msgs.proto\x1a\x1bexpression-group-msgs.proto\x1a\x15expression-msgs.proto\x1a\x1fexpression-calculate-msgs.proto\x1a\x1fimage-3d-model-point-msgs.proto\x1a\x1eimage-beam-location-msgs.proto\x1a\x10image-msgs.proto\x1a\x16image-coreg-msgs.proto\x1a\x18image-pyramid-msgs.proto\x1a\x0ejob-msgs.proto\x1a\x0elog-msgs.proto\x1a\x16memoisation-msgs.proto\x1a\x11module-msgs.proto\x1a\x1bownership-access-msgs.proto\x1a\x12piquant-msgs.proto\x1a\x1dpseudo-intensities-msgs.proto\x1a\x1bquantification-create.proto\x1a$quantification-management-msgs.proto\x1a\x1fquantification-multi-msgs.proto\x1a#quantification-retrieval-msgs.proto\x1a quantification-upload-msgs.proto\x1a\x0eroi-msgs.proto\x1a\x1dscan-beam-location-msgs.proto\x1a\x1escan-entry-metadata-msgs.proto\x1a\x15scan-entry-msgs.proto\x1a\x1dscan-entry-polygon-msgs.proto\x1a\x0fscan-msgs.proto\x1a\x1aselection-pixel-msgs.proto\x1a\x1aselection-entry-msgs.proto\x1a\x13spectrum-msgs.proto\x1a\x17notification-msgs.proto\x1a\x0etag-msgs.proto\x1a\x0ftest-msgs.proto\x1a user-group-management-msgs.proto\x1a\x1cuser-group-admins-msgs.proto\x1a\x1duser-group-joining-msgs.proto\x1a user-group-membership-msgs.proto\x1a\x1fuser-group-retrieval-msgs.proto\x1a\x1auser-management-msgs.proto\x1a\x0fuser-msgs.proto\x1a$user-notification-setting-msgs.proto\x1a\x0edoi-msgs.proto\x1a\x1fscreen-configuration-msgs.proto\x1a\x16widget-data-msgs.proto\x1a\fsystem.proto\x1a\x15references-msgs.proto\"\x8c\xce\x01\n" +
+	"\x0fwebsocket.proto\x1a\x10audit-msgs.proto\x1a\x1dauto-quant-profile-msgs.proto\x1a\x1adetector-config-msgs.proto\x1a$diffraction-detected-peak-msgs.proto\x1a\x1ddiffraction-manual-msgs.proto\x1a\x1ddiffraction-status-msgs.proto\x1a\x16element-set-msgs.proto\x1a\x11export-msgs.proto\x1a\x1bexpression-group-msgs.proto\x1a\x15expression-msgs.proto\x1a\x1fexpression-calculate-msgs.proto\x1a\x1bexpression-debug-msgs.proto\x1a\x1fimage-3d-model-point-msgs.proto\x1a\x1eimage-beam-location-msgs.proto\x1a\x10image-msgs.proto\x1a\x16image-coreg-msgs.proto\x1a\x18image-pyramid-msgs.proto\x1a\x0ejob-msgs.proto\x1a\x0elog-msgs.proto\x1a\x16memoisation-msgs.proto\x1a\x11module-msgs.proto\x1a\x1bownership-access-msgs.proto\x1a\x12piquant-msgs.proto\x1a\x1dpiquant-regression-msgs.proto\x1a\x1dpseudo-intensities-msgs.proto\x1a\x1bquantification-create.proto\x1a$quantification-management-msgs.proto\x1a\x1fquantification-multi-msgs.proto\x1a#quantification-retrieval-msgs.proto\x1a quantification-upload-msgs.proto\x1a\x13revision-msgs.proto\x1a\x0eroi-msgs.proto\x1a\x1dscan-beam-location-msgs.proto\x1a\x1escan-entry-metadata-msgs.proto\x1a\x15scan-entry-msgs.proto\x1a\x1dscan-entry-polygon-msgs.proto\x1a\x0fscan-msgs.proto\x1a\x1aselection-pixel-msgs.proto\x1a\x1aselection-entry-msgs.proto\x1a\x13spectrum-msgs.proto\x1a\x17notification-msgs.proto\x1a\x0etag-msgs.proto\x1a\x0ftest-msgs.proto\x1a user-group-management-msgs.proto\x1a\x1cuser-group-admins-msgs.proto\x1a\x1duser-group-joining-msgs.proto\x1a user-group-membership-msgs.proto\x1a\x1fuser-group-retrieval-msgs.proto\x1a\x1auser-management-msgs.proto\x1a\x0fuser-msgs.proto\x1a$user-notification-setting-msgs.proto\x1a\x0edoi-msgs.proto\x1a\x1fscreen-configuration-msgs.proto\x1a\x16widget-data-msgs.proto\x1a\fsystem.proto\x1a\x15references-msgs.proto\"\xc1\xe2\x01\n" +
 	"\tWSMessage\x12\x14\n" +
 	"\x05msgId\x18\x01 \x01(\rR\x05msgId\x12'\n" +
 	"\x06status\x18\x02 \x01(\x0e2\x0f.ResponseStatusR\x06status\x12\x1c\n" +
-	"\terrorText\x18\x03 \x01(\tR\terrorText\x121\n" +
+	"\terrorText\x18\x03 \x01(\tR\terrorText\x12=\n" +
+	"\x0fauditLogListReq\x18\x86\x03 \x01(\v2\x10.AuditLogListReqH\x00R\x0fauditLogListReq\x12@\n" +
+	"\x10auditLogListResp\x18\x87\x03 \x01(\v2\x11.AuditLogListRespH\x00R\x10auditLogListResp\x12[\n" +
+	"\x19autoQuantProfileDeleteReq\x18\xfb\x02 \x01(\v2\x1a.AutoQuantProfileDeleteReqH\x00R\x19autoQuantProfileDeleteReq\x12^\n" +
+	"\x1aautoQuantProfileDeleteResp\x18\xfc\x02 \x01(\v2\x1b.AutoQuantProfileDeleteRespH\x00R\x1aautoQuantProfileDeleteResp\x12U\n" +
+	"\x17autoQuantProfileListReq\x18\xf7\x02 \x01(\v2\x18.AutoQuantProfileListReqH\x00R\x17autoQuantProfileListReq\x12X\n" +
+	"\x18autoQuantProfileListResp\x18\xf8\x02 \x01(\v2\x19.AutoQuantProfileListRespH\x00R\x18autoQuantProfileListResp\x12X\n" +
+	"\x18autoQuantProfileWriteReq\x18\xf9\x02 \x01(\v2\x19.AutoQuantProfileWriteReqH\x00R\x18autoQuantProfileWriteReq\x12[\n" +
+	"\x19autoQuantProfileWriteResp\x18\xfa\x02 \x01(\v2\x1a.AutoQuantProfileWriteRespH\x00R\x19autoQuantProfileWriteResp\x121\n" +
 	"\vbackupDBReq\x18\xb7\x02 \x01(\v2\f.BackupDBReqH\x00R\vbackupDBReq\x124\n" +
 	"\fbackupDBResp\x18\xb8\x02 \x01(\v2\r.BackupDBRespH\x00R\fbackupDBResp\x121\n" +
-	"\vbackupDBUpd\x18\xb9\x02 \x01(\v2\f.BackupDBUpdH\x00R\vbackupDBUpd\x12I\n" +
+	"\vbackupDBUpd\x18\xb9\x02 \x01(\v2\f.BackupDBUpdH\x00R\vbackupDBUpd\x12@\n" +
+	"\x10backupSetListReq\x18\x84\x03 \x01(\v2\x11.BackupSetListReqH\x00R\x10backupSetListReq\x12C\n" +
+	"\x11backupSetListResp\x18\x85\x03 \x01(\v2\x12.BackupSetListRespH\x00R\x11backupSetListResp\x12I\n" +
 	"\x13dBAdminConfigGetReq\x18\xba\x02 \x01(\v2\x14.DBAdminConfigGetReqH\x00R\x13dBAdminConfigGetReq\x12L\n" +
 	"\x14dBAdminConfigGetResp\x18\xbb\x02 \x01(\v2\x15.DBAdminConfigGetRespH\x00R\x14dBAdminConfigGetResp\x12U\n" +
 	"\x17dataModuleAddVersionReq\x18\xc6\x01 \x01(\v2\x18.DataModuleAddVersionReqH\x00R\x17dataModuleAddVersionReq\x12X\n" +
@@ -5531,9 +6053,16 @@ const file_websocket_proto_rawDesc = "" +
 	"\x12elementSetWriteReq\x18$ \x01(\v2\x13.ElementSetWriteReqH\x00R\x12elementSetWriteReq\x12H\n" +
 	"\x13elementSetWriteResp\x18% \x01(\v2\x14.ElementSetWriteRespH\x00R\x13elementSetWriteResp\x129\n" +
 	"\x0eexportFilesReq\x18& \x01(\v2\x0f.ExportFilesReqH\x00R\x0eexportFilesReq\x12<\n" +
-	"\x0fexportFilesResp\x18' \x01(\v2\x10.ExportFilesRespH\x00R\x0fexportFilesResp\x12R\n" +
+	"\x0fexportFilesResp\x18' \x01(\v2\x10.ExportFilesRespH\x00R\x0fexportFilesResp\x12a\n" +
+	"\x1bexpressionBatchCalculateReq\x18\xf0\x02 \x01(\v2\x1c.ExpressionBatchCalculateReqH\x00R\x1bexpressionBatchCalculateReq\x12d\n" +
+	"\x1cexpressionBatchCalculateResp\x18\xf1\x02 \x01(\v2\x1d.ExpressionBatchCalculateRespH\x00R\x1cexpressionBatchCalculateResp\x12R\n" +
 	"\x16expressionCalculateReq\x18\xea\x02 \x01(\v2\x17.ExpressionCalculateReqH\x00R\x16expressionCalculateReq\x12U\n" +
-	"\x17expressionCalculateResp\x18\xeb\x02 \x01(\v2\x18.ExpressionCalculateRespH\x00R\x17expressionCalculateResp\x12H\n" +
+	"\x17expressionCalculateResp\x18\xeb\x02 \x01(\v2\x18.ExpressionCalculateRespH\x00R\x17expressionCalculateResp\x12[\n" +
+	"\x19expressionDebugCommandReq\x18\xf4\x02 \x01(\v2\x1a.ExpressionDebugCommandReqH\x00R\x19expressionDebugCommandReq\x12^\n" +
+	"\x1aexpressionDebugCommandResp\x18\xf5\x02 \x01(\v2\x1b.ExpressionDebugCommandRespH\x00R\x1aexpressionDebugCommandResp\x12U\n" +
+	"\x17expressionDebugStartReq\x18\xf2\x02 \x01(\v2\x18.ExpressionDebugStartReqH\x00R\x17expressionDebugStartReq\x12X\n" +
+	"\x18expressionDebugStartResp\x18\xf3\x02 \x01(\v2\x19.ExpressionDebugStartRespH\x00R\x18expressionDebugStartResp\x12F\n" +
+	"\x12expressionDebugUpd\x18\xf6\x02 \x01(\v2\x13.ExpressionDebugUpdH\x00R\x12expressionDebugUpd\x12H\n" +
 	"\x13expressionDeleteReq\x18( \x01(\v2\x14.ExpressionDeleteReqH\x00R\x13expressionDeleteReq\x12K\n" +
 	"\x14expressionDeleteResp\x18) \x01(\v2\x15.ExpressionDeleteRespH\x00R\x14expressionDeleteResp\x12m\n" +
 	"\x1fexpressionDisplaySettingsGetReq\x18\xa0\x02 \x01(\v2 .ExpressionDisplaySettingsGetReqH\x00R\x1fexpressionDisplaySettingsGetReq\x12p\n" +
@@ -5593,7 +6122,9 @@ const file_websocket_proto_rawDesc = "" +
 	"\x19imageTileStructureGetResp\x18\xe5\x02 \x01(\v2\x1a.ImageTileStructureGetRespH\x00R\x19imageTileStructureGetResp\x12X\n" +
 	"\x18importMarsViewerImageReq\x18\x91\x02 \x01(\v2\x19.ImportMarsViewerImageReqH\x00R\x18importMarsViewerImageReq\x12[\n" +
 	"\x19importMarsViewerImageResp\x18\x92\x02 \x01(\v2\x1a.ImportMarsViewerImageRespH\x00R\x19importMarsViewerImageResp\x12X\n" +
-	"\x18importMarsViewerImageUpd\x18\x93\x02 \x01(\v2\x19.ImportMarsViewerImageUpdH\x00R\x18importMarsViewerImageUpd\x12.\n" +
+	"\x18importMarsViewerImageUpd\x18\x93\x02 \x01(\v2\x19.ImportMarsViewerImageUpdH\x00R\x18importMarsViewerImageUpd\x124\n" +
+	"\fjobCancelReq\x18\xee\x02 \x01(\v2\r.JobCancelReqH\x00R\fjobCancelReq\x127\n" +
+	"\rjobCancelResp\x18\xef\x02 \x01(\v2\x0e.JobCancelRespH\x00R\rjobCancelResp\x12.\n" +
 	"\n" +
 	"jobListReq\x18\xa8\x02 \x01(\v2\v.JobListReqH\x00R\n" +
 	"jobListReq\x121\n" +
@@ -5622,6 +6153,12 @@ const file_websocket_proto_rawDesc = "" +
 	"\x0fnotificationUpd\x18\x93\x01 \x01(\v2\x10.NotificationUpdH\x00R\x0fnotificationUpd\x12I\n" +
 	"\x13objectEditAccessReq\x18\xae\x01 \x01(\v2\x14.ObjectEditAccessReqH\x00R\x13objectEditAccessReq\x12L\n" +
 	"\x14objectEditAccessResp\x18\xaf\x01 \x01(\v2\x15.ObjectEditAccessRespH\x00R\x14objectEditAccessResp\x12L\n" +
+	"\x14objectRevisionGetReq\x18\x8a\x03 \x01(\v2\x15.ObjectRevisionGetReqH\x00R\x14objectRevisionGetReq\x12O\n" +
+	"\x15objectRevisionGetResp\x18\x8b\x03 \x01(\v2\x16.ObjectRevisionGetRespH\x00R\x15objectRevisionGetResp\x12O\n" +
+	"\x15objectRevisionListReq\x18\x88\x03 \x01(\v2\x16.ObjectRevisionListReqH\x00R\x15objectRevisionListReq\x12R\n" +
+	"\x16objectRevisionListResp\x18\x89\x03 \x01(\v2\x17.ObjectRevisionListRespH\x00R\x16objectRevisionListResp\x12X\n" +
+	"\x18objectRevisionRestoreReq\x18\x8c\x03 \x01(\v2\x19.ObjectRevisionRestoreReqH\x00R\x18objectRevisionRestoreReq\x12[\n" +
+	"\x19objectRevisionRestoreResp\x18\x8d\x03 \x01(\v2\x1a.ObjectRevisionRestoreRespH\x00R\x19objectRevisionRestoreResp\x12L\n" +
 	"\x14piquantConfigFileReq\x18\xde\x02 \x01(\v2\x15.PiquantConfigFileReqH\x00R\x14piquantConfigFileReq\x12O\n" +
 	"\x15piquantConfigFileResp\x18\xdf\x02 \x01(\v2\x16.PiquantConfigFileRespH\x00R\x15piquantConfigFileResp\x12K\n" +
 	"\x14piquantConfigListReq\x18I \x01(\v2\x15.PiquantConfigListReqH\x00R\x14piquantConfigListReq\x12N\n" +
@@ -5631,7 +6168,12 @@ const file_websocket_proto_rawDesc = "" +
 	"\x1cpiquantConfigVersionsListReq\x18M \x01(\v2\x1d.PiquantConfigVersionsListReqH\x00R\x1cpiquantConfigVersionsListReq\x12f\n" +
 	"\x1dpiquantConfigVersionsListResp\x18N \x01(\v2\x1e.PiquantConfigVersionsListRespH\x00R\x1dpiquantConfigVersionsListResp\x12W\n" +
 	"\x18piquantCurrentVersionReq\x18O \x01(\v2\x19.PiquantCurrentVersionReqH\x00R\x18piquantCurrentVersionReq\x12Z\n" +
-	"\x19piquantCurrentVersionResp\x18P \x01(\v2\x1a.PiquantCurrentVersionRespH\x00R\x19piquantCurrentVersionResp\x12N\n" +
+	"\x19piquantCurrentVersionResp\x18P \x01(\v2\x1a.PiquantCurrentVersionRespH\x00R\x19piquantCurrentVersionResp\x12L\n" +
+	"\x14piquantRegressionReq\x18\xff\x02 \x01(\v2\x15.PiquantRegressionReqH\x00R\x14piquantRegressionReq\x12O\n" +
+	"\x15piquantRegressionResp\x18\x80\x03 \x01(\v2\x16.PiquantRegressionRespH\x00R\x15piquantRegressionResp\x12^\n" +
+	"\x1apiquantRegressionResultReq\x18\x81\x03 \x01(\v2\x1b.PiquantRegressionResultReqH\x00R\x1apiquantRegressionResultReq\x12a\n" +
+	"\x1bpiquantRegressionResultResp\x18\x82\x03 \x01(\v2\x1c.PiquantRegressionResultRespH\x00R\x1bpiquantRegressionResultResp\x12L\n" +
+	"\x14piquantRegressionUpd\x18\x83\x03 \x01(\v2\x15.PiquantRegressionUpdH\x00R\x14piquantRegressionUpd\x12N\n" +
 	"\x15piquantVersionListReq\x18Q \x01(\v2\x16.PiquantVersionListReqH\x00R\x15piquantVersionListReq\x12Q\n" +
 	"\x16piquantVersionListResp\x18R \x01(\v2\x17.PiquantVersionListRespH\x00R\x16piquantVersionListResp\x12f\n" +
 	"\x1dpiquantWriteCurrentVersionReq\x18S \x01(\v2\x1e.PiquantWriteCurrentVersionReqH\x00R\x1dpiquantWriteCurrentVersionReq\x12i\n" +
@@ -5650,7 +6192,9 @@ const file_websocket_proto_rawDesc = "" +
 	"\x10quantCombineResp\x18\xd1\x01 \x01(\v2\x11.QuantCombineRespH\x00R\x10quantCombineResp\x12:\n" +
 	"\x0equantCreateReq\x18\x8e\x02 \x01(\v2\x0f.QuantCreateReqH\x00R\x0equantCreateReq\x12=\n" +
 	"\x0fquantCreateResp\x18\x8f\x02 \x01(\v2\x10.QuantCreateRespH\x00R\x0fquantCreateResp\x12:\n" +
-	"\x0equantCreateUpd\x18\x90\x02 \x01(\v2\x0f.QuantCreateUpdH\x00R\x0equantCreateUpd\x12:\n" +
+	"\x0equantCreateUpd\x18\x90\x02 \x01(\v2\x0f.QuantCreateUpdH\x00R\x0equantCreateUpd\x124\n" +
+	"\fquantDiffReq\x18\xfd\x02 \x01(\v2\r.QuantDiffReqH\x00R\fquantDiffReq\x127\n" +
+	"\rquantDiffResp\x18\xfe\x02 \x01(\v2\x0e.QuantDiffRespH\x00R\rquantDiffResp\x12:\n" +
 	"\x0equantDeleteReq\x18\xd2\x01 \x01(\v2\x0f.QuantDeleteReqH\x00R\x0equantDeleteReq\x12=\n" +
 	"\x0fquantDeleteResp\x18\xd3\x01 \x01(\v2\x10.QuantDeleteRespH\x00R\x0fquantDeleteResp\x121\n" +
 	"\vquantGetReq\x18\xd4\x01 \x01(\v2\f.QuantGetReqH\x00R\vquantGetReq\x124\n" +
@@ -5865,678 +6409,742 @@ var file_websocket_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
 var file_websocket_proto_goTypes = []any{
 	(ResponseStatus)(0),                              // 0: ResponseStatus
 	(*WSMessage)(nil),                                // 1: WSMessage
-	(*BackupDBReq)(nil),                              // 2: BackupDBReq
-	(*BackupDBResp)(nil),                             // 3: BackupDBResp
-	(*BackupDBUpd)(nil),                              // 4: BackupDBUpd
-	(*DBAdminConfigGetReq)(nil),                      // 5: DBAdminConfigGetReq
-	(*DBAdminConfigGetResp)(nil),                     // 6: DBAdminConfigGetResp
-	(*DataModuleAddVersionReq)(nil),                  // 7: DataModuleAddVersionReq
-	(*DataModuleAddVersionResp)(nil),                 // 8: DataModuleAddVersionResp
-	(*DataModuleGetReq)(nil),                         // 9: DataModuleGetReq
-	(*DataModuleGetResp)(nil),                        // 10: DataModuleGetResp
-	(*DataModuleListReq)(nil),                        // 11: DataModuleListReq
-	(*DataModuleListResp)(nil),                       // 12: DataModuleListResp
-	(*DataModuleWriteReq)(nil),                       // 13: DataModuleWriteReq
-	(*DataModuleWriteResp)(nil),                      // 14: DataModuleWriteResp
-	(*DetectedDiffractionPeaksReq)(nil),              // 15: DetectedDiffractionPeaksReq
-	(*DetectedDiffractionPeaksResp)(nil),             // 16: DetectedDiffractionPeaksResp
-	(*DetectorConfigListReq)(nil),                    // 17: DetectorConfigListReq
-	(*DetectorConfigListResp)(nil),                   // 18: DetectorConfigListResp
-	(*DetectorConfigReq)(nil),                        // 19: DetectorConfigReq
-	(*DetectorConfigResp)(nil),                       // 20: DetectorConfigResp
-	(*DiffractionPeakManualDeleteReq)(nil),           // 21: DiffractionPeakManualDeleteReq
-	(*DiffractionPeakManualDeleteResp)(nil),          // 22: DiffractionPeakManualDeleteResp
-	(*DiffractionPeakManualInsertReq)(nil),           // 23: DiffractionPeakManualInsertReq
-	(*DiffractionPeakManualInsertResp)(nil),          // 24: DiffractionPeakManualInsertResp
-	(*DiffractionPeakManualListReq)(nil),             // 25: DiffractionPeakManualListReq
-	(*DiffractionPeakManualListResp)(nil),            // 26: DiffractionPeakManualListResp
-	(*DiffractionPeakStatusDeleteReq)(nil),           // 27: DiffractionPeakStatusDeleteReq
-	(*DiffractionPeakStatusDeleteResp)(nil),          // 28: DiffractionPeakStatusDeleteResp
-	(*DiffractionPeakStatusListReq)(nil),             // 29: DiffractionPeakStatusListReq
-	(*DiffractionPeakStatusListResp)(nil),            // 30: DiffractionPeakStatusListResp
-	(*DiffractionPeakStatusWriteReq)(nil),            // 31: DiffractionPeakStatusWriteReq
-	(*DiffractionPeakStatusWriteResp)(nil),           // 32: DiffractionPeakStatusWriteResp
-	(*ElementSetDeleteReq)(nil),                      // 33: ElementSetDeleteReq
-	(*ElementSetDeleteResp)(nil),                     // 34: ElementSetDeleteResp
-	(*ElementSetGetReq)(nil),                         // 35: ElementSetGetReq
-	(*ElementSetGetResp)(nil),                        // 36: ElementSetGetResp
-	(*ElementSetListReq)(nil),                        // 37: ElementSetListReq
-	(*ElementSetListResp)(nil),                       // 38: ElementSetListResp
-	(*ElementSetWriteReq)(nil),                       // 39: ElementSetWriteReq
-	(*ElementSetWriteResp)(nil),                      // 40: ElementSetWriteResp
-	(*ExportFilesReq)(nil),                           // 41: ExportFilesReq
-	(*ExportFilesResp)(nil),                          // 42: ExportFilesResp
-	(*ExpressionCalculateReq)(nil),                   // 43: ExpressionCalculateReq
-	(*ExpressionCalculateResp)(nil),                  // 44: ExpressionCalculateResp
-	(*ExpressionDeleteReq)(nil),                      // 45: ExpressionDeleteReq
-	(*ExpressionDeleteResp)(nil),                     // 46: ExpressionDeleteResp
-	(*ExpressionDisplaySettingsGetReq)(nil),          // 47: ExpressionDisplaySettingsGetReq
-	(*ExpressionDisplaySettingsGetResp)(nil),         // 48: ExpressionDisplaySettingsGetResp
-	(*ExpressionDisplaySettingsWriteReq)(nil),        // 49: ExpressionDisplaySettingsWriteReq
-	(*ExpressionDisplaySettingsWriteResp)(nil),       // 50: ExpressionDisplaySettingsWriteResp
-	(*ExpressionGetReq)(nil),                         // 51: ExpressionGetReq
-	(*ExpressionGetResp)(nil),                        // 52: ExpressionGetResp
-	(*ExpressionGroupDeleteReq)(nil),                 // 53: ExpressionGroupDeleteReq
-	(*ExpressionGroupDeleteResp)(nil),                // 54: ExpressionGroupDeleteResp
-	(*ExpressionGroupGetReq)(nil),                    // 55: ExpressionGroupGetReq
-	(*ExpressionGroupGetResp)(nil),                   // 56: ExpressionGroupGetResp
-	(*ExpressionGroupListReq)(nil),                   // 57: ExpressionGroupListReq
-	(*ExpressionGroupListResp)(nil),                  // 58: ExpressionGroupListResp
-	(*ExpressionGroupWriteReq)(nil),                  // 59: ExpressionGroupWriteReq
-	(*ExpressionGroupWriteResp)(nil),                 // 60: ExpressionGroupWriteResp
-	(*ExpressionListReq)(nil),                        // 61: ExpressionListReq
-	(*ExpressionListResp)(nil),                       // 62: ExpressionListResp
-	(*ExpressionWriteExecStatReq)(nil),               // 63: ExpressionWriteExecStatReq
-	(*ExpressionWriteExecStatResp)(nil),              // 64: ExpressionWriteExecStatResp
-	(*ExpressionWriteReq)(nil),                       // 65: ExpressionWriteReq
-	(*ExpressionWriteResp)(nil),                      // 66: ExpressionWriteResp
-	(*GetOwnershipDescriptionReq)(nil),               // 67: GetOwnershipDescriptionReq
-	(*GetOwnershipDescriptionResp)(nil),              // 68: GetOwnershipDescriptionResp
-	(*GetOwnershipReq)(nil),                          // 69: GetOwnershipReq
-	(*GetOwnershipResp)(nil),                         // 70: GetOwnershipResp
-	(*Image3DModelPointUploadReq)(nil),               // 71: Image3DModelPointUploadReq
-	(*Image3DModelPointUploadResp)(nil),              // 72: Image3DModelPointUploadResp
-	(*Image3DModelPointsReq)(nil),                    // 73: Image3DModelPointsReq
-	(*Image3DModelPointsResp)(nil),                   // 74: Image3DModelPointsResp
-	(*ImageBeamLocationUploadReq)(nil),               // 75: ImageBeamLocationUploadReq
-	(*ImageBeamLocationUploadResp)(nil),              // 76: ImageBeamLocationUploadResp
-	(*ImageBeamLocationVersionsReq)(nil),             // 77: ImageBeamLocationVersionsReq
-	(*ImageBeamLocationVersionsResp)(nil),            // 78: ImageBeamLocationVersionsResp
-	(*ImageBeamLocationsReq)(nil),                    // 79: ImageBeamLocationsReq
-	(*ImageBeamLocationsResp)(nil),                   // 80: ImageBeamLocationsResp
-	(*ImageDeleteReq)(nil),                           // 81: ImageDeleteReq
-	(*ImageDeleteResp)(nil),                          // 82: ImageDeleteResp
-	(*ImageGetDefaultReq)(nil),                       // 83: ImageGetDefaultReq
-	(*ImageGetDefaultResp)(nil),                      // 84: ImageGetDefaultResp
-	(*ImageGetReq)(nil),                              // 85: ImageGetReq
-	(*ImageGetResp)(nil),                             // 86: ImageGetResp
-	(*ImageListReq)(nil),                             // 87: ImageListReq
-	(*ImageListResp)(nil),                            // 88: ImageListResp
-	(*ImageListUpd)(nil),                             // 89: ImageListUpd
-	(*ImagePyramidGetReq)(nil),                       // 90: ImagePyramidGetReq
-	(*ImagePyramidGetResp)(nil),                      // 91: ImagePyramidGetResp
-	(*ImageScanEntryDisplayElementsGetReq)(nil),      // 92: ImageScanEntryDisplayElementsGetReq
-	(*ImageScanEntryDisplayElementsGetResp)(nil),     // 93: ImageScanEntryDisplayElementsGetResp
-	(*ImageSetDefaultReq)(nil),                       // 94: ImageSetDefaultReq
-	(*ImageSetDefaultResp)(nil),                      // 95: ImageSetDefaultResp
-	(*ImageSetMatchTransformReq)(nil),                // 96: ImageSetMatchTransformReq
-	(*ImageSetMatchTransformResp)(nil),               // 97: ImageSetMatchTransformResp
-	(*ImageTileDataGetReq)(nil),                      // 98: ImageTileDataGetReq
-	(*ImageTileDataGetResp)(nil),                     // 99: ImageTileDataGetResp
-	(*ImageTileStructureGetReq)(nil),                 // 100: ImageTileStructureGetReq
-	(*ImageTileStructureGetResp)(nil),                // 101: ImageTileStructureGetResp
-	(*ImportMarsViewerImageReq)(nil),                 // 102: ImportMarsViewerImageReq
-	(*ImportMarsViewerImageResp)(nil),                // 103: ImportMarsViewerImageResp
-	(*ImportMarsViewerImageUpd)(nil),                 // 104: ImportMarsViewerImageUpd
-	(*JobListReq)(nil),                               // 105: JobListReq
-	(*JobListResp)(nil),                              // 106: JobListResp
-	(*JobListUpd)(nil),                               // 107: JobListUpd
-	(*LogGetLevelReq)(nil),                           // 108: LogGetLevelReq
-	(*LogGetLevelResp)(nil),                          // 109: LogGetLevelResp
-	(*LogReadReq)(nil),                               // 110: LogReadReq
-	(*LogReadResp)(nil),                              // 111: LogReadResp
-	(*LogSetLevelReq)(nil),                           // 112: LogSetLevelReq
-	(*LogSetLevelResp)(nil),                          // 113: LogSetLevelResp
-	(*MemoiseDeleteByRegexReq)(nil),                  // 114: MemoiseDeleteByRegexReq
-	(*MemoiseDeleteByRegexResp)(nil),                 // 115: MemoiseDeleteByRegexResp
-	(*MemoiseDeleteReq)(nil),                         // 116: MemoiseDeleteReq
-	(*MemoiseDeleteResp)(nil),                        // 117: MemoiseDeleteResp
-	(*MultiQuantCompareReq)(nil),                     // 118: MultiQuantCompareReq
-	(*MultiQuantCompareResp)(nil),                    // 119: MultiQuantCompareResp
-	(*NotificationDismissReq)(nil),                   // 120: NotificationDismissReq
-	(*NotificationDismissResp)(nil),                  // 121: NotificationDismissResp
-	(*NotificationReq)(nil),                          // 122: NotificationReq
-	(*NotificationResp)(nil),                         // 123: NotificationResp
-	(*NotificationUpd)(nil),                          // 124: NotificationUpd
-	(*ObjectEditAccessReq)(nil),                      // 125: ObjectEditAccessReq
-	(*ObjectEditAccessResp)(nil),                     // 126: ObjectEditAccessResp
-	(*PiquantConfigFileReq)(nil),                     // 127: PiquantConfigFileReq
-	(*PiquantConfigFileResp)(nil),                    // 128: PiquantConfigFileResp
-	(*PiquantConfigListReq)(nil),                     // 129: PiquantConfigListReq
-	(*PiquantConfigListResp)(nil),                    // 130: PiquantConfigListResp
-	(*PiquantConfigVersionReq)(nil),                  // 131: PiquantConfigVersionReq
-	(*PiquantConfigVersionResp)(nil),                 // 132: PiquantConfigVersionResp
-	(*PiquantConfigVersionsListReq)(nil),             // 133: PiquantConfigVersionsListReq
-	(*PiquantConfigVersionsListResp)(nil),            // 134: PiquantConfigVersionsListResp
-	(*PiquantCurrentVersionReq)(nil),                 // 135: PiquantCurrentVersionReq
-	(*PiquantCurrentVersionResp)(nil),                // 136: PiquantCurrentVersionResp
-	(*PiquantVersionListReq)(nil),                    // 137: PiquantVersionListReq
-	(*PiquantVersionListResp)(nil),                   // 138: PiquantVersionListResp
-	(*PiquantWriteCurrentVersionReq)(nil),            // 139: PiquantWriteCurrentVersionReq
-	(*PiquantWriteCurrentVersionResp)(nil),           // 140: PiquantWriteCurrentVersionResp
-	(*PseudoIntensityReq)(nil),                       // 141: PseudoIntensityReq
-	(*PseudoIntensityResp)(nil),                      // 142: PseudoIntensityResp
-	(*PublishExpressionToZenodoReq)(nil),             // 143: PublishExpressionToZenodoReq
-	(*PublishExpressionToZenodoResp)(nil),            // 144: PublishExpressionToZenodoResp
-	(*QuantBlessReq)(nil),                            // 145: QuantBlessReq
-	(*QuantBlessResp)(nil),                           // 146: QuantBlessResp
-	(*QuantCombineListGetReq)(nil),                   // 147: QuantCombineListGetReq
-	(*QuantCombineListGetResp)(nil),                  // 148: QuantCombineListGetResp
-	(*QuantCombineListWriteReq)(nil),                 // 149: QuantCombineListWriteReq
-	(*QuantCombineListWriteResp)(nil),                // 150: QuantCombineListWriteResp
-	(*QuantCombineReq)(nil),                          // 151: QuantCombineReq
-	(*QuantCombineResp)(nil),                         // 152: QuantCombineResp
-	(*QuantCreateReq)(nil),                           // 153: QuantCreateReq
-	(*QuantCreateResp)(nil),                          // 154: QuantCreateResp
-	(*QuantCreateUpd)(nil),                           // 155: QuantCreateUpd
-	(*QuantDeleteReq)(nil),                           // 156: QuantDeleteReq
-	(*QuantDeleteResp)(nil),                          // 157: QuantDeleteResp
-	(*QuantGetReq)(nil),                              // 158: QuantGetReq
-	(*QuantGetResp)(nil),                             // 159: QuantGetResp
-	(*QuantLastOutputGetReq)(nil),                    // 160: QuantLastOutputGetReq
-	(*QuantLastOutputGetResp)(nil),                   // 161: QuantLastOutputGetResp
-	(*QuantListReq)(nil),                             // 162: QuantListReq
-	(*QuantListResp)(nil),                            // 163: QuantListResp
-	(*QuantLogGetReq)(nil),                           // 164: QuantLogGetReq
-	(*QuantLogGetResp)(nil),                          // 165: QuantLogGetResp
-	(*QuantLogListReq)(nil),                          // 166: QuantLogListReq
-	(*QuantLogListResp)(nil),                         // 167: QuantLogListResp
-	(*QuantPublishReq)(nil),                          // 168: QuantPublishReq
-	(*QuantPublishResp)(nil),                         // 169: QuantPublishResp
-	(*QuantRawDataGetReq)(nil),                       // 170: QuantRawDataGetReq
-	(*QuantRawDataGetResp)(nil),                      // 171: QuantRawDataGetResp
-	(*QuantUploadReq)(nil),                           // 172: QuantUploadReq
-	(*QuantUploadResp)(nil),                          // 173: QuantUploadResp
-	(*ReferenceDataBulkWriteReq)(nil),                // 174: ReferenceDataBulkWriteReq
-	(*ReferenceDataBulkWriteResp)(nil),               // 175: ReferenceDataBulkWriteResp
-	(*ReferenceDataDeleteReq)(nil),                   // 176: ReferenceDataDeleteReq
-	(*ReferenceDataDeleteResp)(nil),                  // 177: ReferenceDataDeleteResp
-	(*ReferenceDataGetReq)(nil),                      // 178: ReferenceDataGetReq
-	(*ReferenceDataGetResp)(nil),                     // 179: ReferenceDataGetResp
-	(*ReferenceDataListReq)(nil),                     // 180: ReferenceDataListReq
-	(*ReferenceDataListResp)(nil),                    // 181: ReferenceDataListResp
-	(*ReferenceDataWriteReq)(nil),                    // 182: ReferenceDataWriteReq
-	(*ReferenceDataWriteResp)(nil),                   // 183: ReferenceDataWriteResp
-	(*RegionOfInterestBulkDuplicateReq)(nil),         // 184: RegionOfInterestBulkDuplicateReq
-	(*RegionOfInterestBulkDuplicateResp)(nil),        // 185: RegionOfInterestBulkDuplicateResp
-	(*RegionOfInterestBulkWriteReq)(nil),             // 186: RegionOfInterestBulkWriteReq
-	(*RegionOfInterestBulkWriteResp)(nil),            // 187: RegionOfInterestBulkWriteResp
-	(*RegionOfInterestDeleteReq)(nil),                // 188: RegionOfInterestDeleteReq
-	(*RegionOfInterestDeleteResp)(nil),               // 189: RegionOfInterestDeleteResp
-	(*RegionOfInterestDisplaySettingsGetReq)(nil),    // 190: RegionOfInterestDisplaySettingsGetReq
-	(*RegionOfInterestDisplaySettingsGetResp)(nil),   // 191: RegionOfInterestDisplaySettingsGetResp
-	(*RegionOfInterestDisplaySettingsWriteReq)(nil),  // 192: RegionOfInterestDisplaySettingsWriteReq
-	(*RegionOfInterestDisplaySettingsWriteResp)(nil), // 193: RegionOfInterestDisplaySettingsWriteResp
-	(*RegionOfInterestGetReq)(nil),                   // 194: RegionOfInterestGetReq
-	(*RegionOfInterestGetResp)(nil),                  // 195: RegionOfInterestGetResp
-	(*RegionOfInterestListReq)(nil),                  // 196: RegionOfInterestListReq
-	(*RegionOfInterestListResp)(nil),                 // 197: RegionOfInterestListResp
-	(*RegionOfInterestWriteReq)(nil),                 // 198: RegionOfInterestWriteReq
-	(*RegionOfInterestWriteResp)(nil),                // 199: RegionOfInterestWriteResp
-	(*RestoreDBReq)(nil),                             // 200: RestoreDBReq
-	(*RestoreDBResp)(nil),                            // 201: RestoreDBResp
-	(*ReviewerMagicLinkCreateReq)(nil),               // 202: ReviewerMagicLinkCreateReq
-	(*ReviewerMagicLinkCreateResp)(nil),              // 203: ReviewerMagicLinkCreateResp
-	(*ReviewerMagicLinkLoginReq)(nil),                // 204: ReviewerMagicLinkLoginReq
-	(*ReviewerMagicLinkLoginResp)(nil),               // 205: ReviewerMagicLinkLoginResp
-	(*RunTestReq)(nil),                               // 206: RunTestReq
-	(*RunTestResp)(nil),                              // 207: RunTestResp
-	(*ScanAutoShareReq)(nil),                         // 208: ScanAutoShareReq
-	(*ScanAutoShareResp)(nil),                        // 209: ScanAutoShareResp
-	(*ScanAutoShareWriteReq)(nil),                    // 210: ScanAutoShareWriteReq
-	(*ScanAutoShareWriteResp)(nil),                   // 211: ScanAutoShareWriteResp
-	(*ScanBeamLocationsReq)(nil),                     // 212: ScanBeamLocationsReq
-	(*ScanBeamLocationsResp)(nil),                    // 213: ScanBeamLocationsResp
-	(*ScanCreateUserDefinedReq)(nil),                 // 214: ScanCreateUserDefinedReq
-	(*ScanCreateUserDefinedResp)(nil),                // 215: ScanCreateUserDefinedResp
-	(*ScanDeleteReq)(nil),                            // 216: ScanDeleteReq
-	(*ScanDeleteResp)(nil),                           // 217: ScanDeleteResp
-	(*ScanEntryMetadataReq)(nil),                     // 218: ScanEntryMetadataReq
-	(*ScanEntryMetadataResp)(nil),                    // 219: ScanEntryMetadataResp
-	(*ScanEntryReq)(nil),                             // 220: ScanEntryReq
-	(*ScanEntryResp)(nil),                            // 221: ScanEntryResp
-	(*ScanGetReq)(nil),                               // 222: ScanGetReq
-	(*ScanGetResp)(nil),                              // 223: ScanGetResp
-	(*ScanListJobsReq)(nil),                          // 224: ScanListJobsReq
-	(*ScanListJobsResp)(nil),                         // 225: ScanListJobsResp
-	(*ScanListReq)(nil),                              // 226: ScanListReq
-	(*ScanListResp)(nil),                             // 227: ScanListResp
-	(*ScanListUpd)(nil),                              // 228: ScanListUpd
-	(*ScanMetaLabelsAndTypesReq)(nil),                // 229: ScanMetaLabelsAndTypesReq
-	(*ScanMetaLabelsAndTypesResp)(nil),               // 230: ScanMetaLabelsAndTypesResp
-	(*ScanMetaWriteReq)(nil),                         // 231: ScanMetaWriteReq
-	(*ScanMetaWriteResp)(nil),                        // 232: ScanMetaWriteResp
-	(*ScanTriggerJobReq)(nil),                        // 233: ScanTriggerJobReq
-	(*ScanTriggerJobResp)(nil),                       // 234: ScanTriggerJobResp
-	(*ScanTriggerReImportReq)(nil),                   // 235: ScanTriggerReImportReq
-	(*ScanTriggerReImportResp)(nil),                  // 236: ScanTriggerReImportResp
-	(*ScanTriggerReImportUpd)(nil),                   // 237: ScanTriggerReImportUpd
-	(*ScanUploadReq)(nil),                            // 238: ScanUploadReq
-	(*ScanUploadResp)(nil),                           // 239: ScanUploadResp
-	(*ScanUploadUpd)(nil),                            // 240: ScanUploadUpd
-	(*ScanWriteJobReq)(nil),                          // 241: ScanWriteJobReq
-	(*ScanWriteJobResp)(nil),                         // 242: ScanWriteJobResp
-	(*ScreenConfigurationDeleteReq)(nil),             // 243: ScreenConfigurationDeleteReq
-	(*ScreenConfigurationDeleteResp)(nil),            // 244: ScreenConfigurationDeleteResp
-	(*ScreenConfigurationGetReq)(nil),                // 245: ScreenConfigurationGetReq
-	(*ScreenConfigurationGetResp)(nil),               // 246: ScreenConfigurationGetResp
-	(*ScreenConfigurationListReq)(nil),               // 247: ScreenConfigurationListReq
-	(*ScreenConfigurationListResp)(nil),              // 248: ScreenConfigurationListResp
-	(*ScreenConfigurationWriteReq)(nil),              // 249: ScreenConfigurationWriteReq
-	(*ScreenConfigurationWriteResp)(nil),             // 250: ScreenConfigurationWriteResp
-	(*SelectedImagePixelsReq)(nil),                   // 251: SelectedImagePixelsReq
-	(*SelectedImagePixelsResp)(nil),                  // 252: SelectedImagePixelsResp
-	(*SelectedImagePixelsWriteReq)(nil),              // 253: SelectedImagePixelsWriteReq
-	(*SelectedImagePixelsWriteResp)(nil),             // 254: SelectedImagePixelsWriteResp
-	(*SelectedScanEntriesReq)(nil),                   // 255: SelectedScanEntriesReq
-	(*SelectedScanEntriesResp)(nil),                  // 256: SelectedScanEntriesResp
-	(*SelectedScanEntriesWriteReq)(nil),              // 257: SelectedScanEntriesWriteReq
-	(*SelectedScanEntriesWriteResp)(nil),             // 258: SelectedScanEntriesWriteResp
-	(*SendUserNotificationReq)(nil),                  // 259: SendUserNotificationReq
-	(*SendUserNotificationResp)(nil),                 // 260: SendUserNotificationResp
-	(*SpectrumReq)(nil),                              // 261: SpectrumReq
-	(*SpectrumResp)(nil),                             // 262: SpectrumResp
-	(*TagCreateReq)(nil),                             // 263: TagCreateReq
-	(*TagCreateResp)(nil),                            // 264: TagCreateResp
-	(*TagDeleteReq)(nil),                             // 265: TagDeleteReq
-	(*TagDeleteResp)(nil),                            // 266: TagDeleteResp
-	(*TagListReq)(nil),                               // 267: TagListReq
-	(*TagListResp)(nil),                              // 268: TagListResp
-	(*UserAddRoleReq)(nil),                           // 269: UserAddRoleReq
-	(*UserAddRoleResp)(nil),                          // 270: UserAddRoleResp
-	(*UserDeleteRoleReq)(nil),                        // 271: UserDeleteRoleReq
-	(*UserDeleteRoleResp)(nil),                       // 272: UserDeleteRoleResp
-	(*UserDetailsReq)(nil),                           // 273: UserDetailsReq
-	(*UserDetailsResp)(nil),                          // 274: UserDetailsResp
-	(*UserDetailsWriteReq)(nil),                      // 275: UserDetailsWriteReq
-	(*UserDetailsWriteResp)(nil),                     // 276: UserDetailsWriteResp
-	(*UserGroupAddAdminReq)(nil),                     // 277: UserGroupAddAdminReq
-	(*UserGroupAddAdminResp)(nil),                    // 278: UserGroupAddAdminResp
-	(*UserGroupAddMemberReq)(nil),                    // 279: UserGroupAddMemberReq
-	(*UserGroupAddMemberResp)(nil),                   // 280: UserGroupAddMemberResp
-	(*UserGroupAddViewerReq)(nil),                    // 281: UserGroupAddViewerReq
-	(*UserGroupAddViewerResp)(nil),                   // 282: UserGroupAddViewerResp
-	(*UserGroupCreateReq)(nil),                       // 283: UserGroupCreateReq
-	(*UserGroupCreateResp)(nil),                      // 284: UserGroupCreateResp
-	(*UserGroupDeleteAdminReq)(nil),                  // 285: UserGroupDeleteAdminReq
-	(*UserGroupDeleteAdminResp)(nil),                 // 286: UserGroupDeleteAdminResp
-	(*UserGroupDeleteMemberReq)(nil),                 // 287: UserGroupDeleteMemberReq
-	(*UserGroupDeleteMemberResp)(nil),                // 288: UserGroupDeleteMemberResp
-	(*UserGroupDeleteReq)(nil),                       // 289: UserGroupDeleteReq
-	(*UserGroupDeleteResp)(nil),                      // 290: UserGroupDeleteResp
-	(*UserGroupDeleteViewerReq)(nil),                 // 291: UserGroupDeleteViewerReq
-	(*UserGroupDeleteViewerResp)(nil),                // 292: UserGroupDeleteViewerResp
-	(*UserGroupEditDetailsReq)(nil),                  // 293: UserGroupEditDetailsReq
-	(*UserGroupEditDetailsResp)(nil),                 // 294: UserGroupEditDetailsResp
-	(*UserGroupIgnoreJoinReq)(nil),                   // 295: UserGroupIgnoreJoinReq
-	(*UserGroupIgnoreJoinResp)(nil),                  // 296: UserGroupIgnoreJoinResp
-	(*UserGroupJoinListReq)(nil),                     // 297: UserGroupJoinListReq
-	(*UserGroupJoinListResp)(nil),                    // 298: UserGroupJoinListResp
-	(*UserGroupJoinReq)(nil),                         // 299: UserGroupJoinReq
-	(*UserGroupJoinResp)(nil),                        // 300: UserGroupJoinResp
-	(*UserGroupListJoinableReq)(nil),                 // 301: UserGroupListJoinableReq
-	(*UserGroupListJoinableResp)(nil),                // 302: UserGroupListJoinableResp
-	(*UserGroupListReq)(nil),                         // 303: UserGroupListReq
-	(*UserGroupListResp)(nil),                        // 304: UserGroupListResp
-	(*UserGroupReq)(nil),                             // 305: UserGroupReq
-	(*UserGroupResp)(nil),                            // 306: UserGroupResp
-	(*UserImpersonateGetReq)(nil),                    // 307: UserImpersonateGetReq
-	(*UserImpersonateGetResp)(nil),                   // 308: UserImpersonateGetResp
-	(*UserImpersonateReq)(nil),                       // 309: UserImpersonateReq
-	(*UserImpersonateResp)(nil),                      // 310: UserImpersonateResp
-	(*UserListReq)(nil),                              // 311: UserListReq
-	(*UserListResp)(nil),                             // 312: UserListResp
-	(*UserNotificationSettingsReq)(nil),              // 313: UserNotificationSettingsReq
-	(*UserNotificationSettingsResp)(nil),             // 314: UserNotificationSettingsResp
-	(*UserNotificationSettingsUpd)(nil),              // 315: UserNotificationSettingsUpd
-	(*UserNotificationSettingsWriteReq)(nil),         // 316: UserNotificationSettingsWriteReq
-	(*UserNotificationSettingsWriteResp)(nil),        // 317: UserNotificationSettingsWriteResp
-	(*UserRoleListReq)(nil),                          // 318: UserRoleListReq
-	(*UserRoleListResp)(nil),                         // 319: UserRoleListResp
-	(*UserRolesListReq)(nil),                         // 320: UserRolesListReq
-	(*UserRolesListResp)(nil),                        // 321: UserRolesListResp
-	(*UserSearchReq)(nil),                            // 322: UserSearchReq
-	(*UserSearchResp)(nil),                           // 323: UserSearchResp
-	(*WidgetDataGetReq)(nil),                         // 324: WidgetDataGetReq
-	(*WidgetDataGetResp)(nil),                        // 325: WidgetDataGetResp
-	(*WidgetDataWriteReq)(nil),                       // 326: WidgetDataWriteReq
-	(*WidgetDataWriteResp)(nil),                      // 327: WidgetDataWriteResp
-	(*WidgetMetadataGetReq)(nil),                     // 328: WidgetMetadataGetReq
-	(*WidgetMetadataGetResp)(nil),                    // 329: WidgetMetadataGetResp
-	(*WidgetMetadataWriteReq)(nil),                   // 330: WidgetMetadataWriteReq
-	(*WidgetMetadataWriteResp)(nil),                  // 331: WidgetMetadataWriteResp
-	(*ZenodoDOIGetReq)(nil),                          // 332: ZenodoDOIGetReq
-	(*ZenodoDOIGetResp)(nil),                         // 333: ZenodoDOIGetResp
+	(*AuditLogListReq)(nil),                          // 2: AuditLogListReq
+	(*AuditLogListResp)(nil),                         // 3: AuditLogListResp
+	(*AutoQuantProfileDeleteReq)(nil),                // 4: AutoQuantProfileDeleteReq
+	(*AutoQuantProfileDeleteResp)(nil),               // 5: AutoQuantProfileDeleteResp
+	(*AutoQuantProfileListReq)(nil),                  // 6: AutoQuantProfileListReq
+	(*AutoQuantProfileListResp)(nil),                 // 7: AutoQuantProfileListResp
+	(*AutoQuantProfileWriteReq)(nil),                 // 8: AutoQuantProfileWriteReq
+	(*AutoQuantProfileWriteResp)(nil),                // 9: AutoQuantProfileWriteResp
+	(*BackupDBReq)(nil),                              // 10: BackupDBReq
+	(*BackupDBResp)(nil),                             // 11: BackupDBResp
+	(*BackupDBUpd)(nil),                              // 12: BackupDBUpd
+	(*BackupSetListReq)(nil),                         // 13: BackupSetListReq
+	(*BackupSetListResp)(nil),                        // 14: BackupSetListResp
+	(*DBAdminConfigGetReq)(nil),                      // 15: DBAdminConfigGetReq
+	(*DBAdminConfigGetResp)(nil),                     // 16: DBAdminConfigGetResp
+	(*DataModuleAddVersionReq)(nil),                  // 17: DataModuleAddVersionReq
+	(*DataModuleAddVersionResp)(nil),                 // 18: DataModuleAddVersionResp
+	(*DataModuleGetReq)(nil),                         // 19: DataModuleGetReq
+	(*DataModuleGetResp)(nil),                        // 20: DataModuleGetResp
+	(*DataModuleListReq)(nil),                        // 21: DataModuleListReq
+	(*DataModuleListResp)(nil),                       // 22: DataModuleListResp
+	(*DataModuleWriteReq)(nil),                       // 23: DataModuleWriteReq
+	(*DataModuleWriteResp)(nil),                      // 24: DataModuleWriteResp
+	(*DetectedDiffractionPeaksReq)(nil),              // 25: DetectedDiffractionPeaksReq
+	(*DetectedDiffractionPeaksResp)(nil),             // 26: DetectedDiffractionPeaksResp
+	(*DetectorConfigListReq)(nil),                    // 27: DetectorConfigListReq
+	(*DetectorConfigListResp)(nil),                   // 28: DetectorConfigListResp
+	(*DetectorConfigReq)(nil),                        // 29: DetectorConfigReq
+	(*DetectorConfigResp)(nil),                       // 30: DetectorConfigResp
+	(*DiffractionPeakManualDeleteReq)(nil),           // 31: DiffractionPeakManualDeleteReq
+	(*DiffractionPeakManualDeleteResp)(nil),          // 32: DiffractionPeakManualDeleteResp
+	(*DiffractionPeakManualInsertReq)(nil),           // 33: DiffractionPeakManualInsertReq
+	(*DiffractionPeakManualInsertResp)(nil),          // 34: DiffractionPeakManualInsertResp
+	(*DiffractionPeakManualListReq)(nil),             // 35: DiffractionPeakManualListReq
+	(*DiffractionPeakManualListResp)(nil),            // 36: DiffractionPeakManualListResp
+	(*DiffractionPeakStatusDeleteReq)(nil),           // 37: DiffractionPeakStatusDeleteReq
+	(*DiffractionPeakStatusDeleteResp)(nil),          // 38: DiffractionPeakStatusDeleteResp
+	(*DiffractionPeakStatusListReq)(nil),             // 39: DiffractionPeakStatusListReq
+	(*DiffractionPeakStatusListResp)(nil),            // 40: DiffractionPeakStatusListResp
+	(*DiffractionPeakStatusWriteReq)(nil),            // 41: DiffractionPeakStatusWriteReq
+	(*DiffractionPeakStatusWriteResp)(nil),           // 42: DiffractionPeakStatusWriteResp
+	(*ElementSetDeleteReq)(nil),                      // 43: ElementSetDeleteReq
+	(*ElementSetDeleteResp)(nil),                     // 44: ElementSetDeleteResp
+	(*ElementSetGetReq)(nil),                         // 45: ElementSetGetReq
+	(*ElementSetGetResp)(nil),                        // 46: ElementSetGetResp
+	(*ElementSetListReq)(nil),                        // 47: ElementSetListReq
+	(*ElementSetListResp)(nil),                       // 48: ElementSetListResp
+	(*ElementSetWriteReq)(nil),                       // 49: ElementSetWriteReq
+	(*ElementSetWriteResp)(nil),                      // 50: ElementSetWriteResp
+	(*ExportFilesReq)(nil),                           // 51: ExportFilesReq
+	(*ExportFilesResp)(nil),                          // 52: ExportFilesResp
+	(*ExpressionBatchCalculateReq)(nil),              // 53: ExpressionBatchCalculateReq
+	(*ExpressionBatchCalculateResp)(nil),             // 54: ExpressionBatchCalculateResp
+	(*ExpressionCalculateReq)(nil),                   // 55: ExpressionCalculateReq
+	(*ExpressionCalculateResp)(nil),                  // 56: ExpressionCalculateResp
+	(*ExpressionDebugCommandReq)(nil),                // 57: ExpressionDebugCommandReq
+	(*ExpressionDebugCommandResp)(nil),               // 58: ExpressionDebugCommandResp
+	(*ExpressionDebugStartReq)(nil),                  // 59: ExpressionDebugStartReq
+	(*ExpressionDebugStartResp)(nil),                 // 60: ExpressionDebugStartResp
+	(*ExpressionDebugUpd)(nil),                       // 61: ExpressionDebugUpd
+	(*ExpressionDeleteReq)(nil),                      // 62: ExpressionDeleteReq
+	(*ExpressionDeleteResp)(nil),                     // 63: ExpressionDeleteResp
+	(*ExpressionDisplaySettingsGetReq)(nil),          // 64: ExpressionDisplaySettingsGetReq
+	(*ExpressionDisplaySettingsGetResp)(nil),         // 65: ExpressionDisplaySettingsGetResp
+	(*ExpressionDisplaySettingsWriteReq)(nil),        // 66: ExpressionDisplaySettingsWriteReq
+	(*ExpressionDisplaySettingsWriteResp)(nil),       // 67: ExpressionDisplaySettingsWriteResp
+	(*ExpressionGetReq)(nil),                         // 68: ExpressionGetReq
+	(*ExpressionGetResp)(nil),                        // 69: ExpressionGetResp
+	(*ExpressionGroupDeleteReq)(nil),                 // 70: ExpressionGroupDeleteReq
+	(*ExpressionGroupDeleteResp)(nil),                // 71: ExpressionGroupDeleteResp
+	(*ExpressionGroupGetReq)(nil),                    // 72: ExpressionGroupGetReq
+	(*ExpressionGroupGetResp)(nil),                   // 73: ExpressionGroupGetResp
+	(*ExpressionGroupListReq)(nil),                   // 74: ExpressionGroupListReq
+	(*ExpressionGroupListResp)(nil),                  // 75: ExpressionGroupListResp
+	(*ExpressionGroupWriteReq)(nil),                  // 76: ExpressionGroupWriteReq
+	(*ExpressionGroupWriteResp)(nil),                 // 77: ExpressionGroupWriteResp
+	(*ExpressionListReq)(nil),                        // 78: ExpressionListReq
+	(*ExpressionListResp)(nil),                       // 79: ExpressionListResp
+	(*ExpressionWriteExecStatReq)(nil),               // 80: ExpressionWriteExecStatReq
+	(*ExpressionWriteExecStatResp)(nil),              // 81: ExpressionWriteExecStatResp
+	(*ExpressionWriteReq)(nil),                       // 82: ExpressionWriteReq
+	(*ExpressionWriteResp)(nil),                      // 83: ExpressionWriteResp
+	(*GetOwnershipDescriptionReq)(nil),               // 84: GetOwnershipDescriptionReq
+	(*GetOwnershipDescriptionResp)(nil),              // 85: GetOwnershipDescriptionResp
+	(*GetOwnershipReq)(nil),                          // 86: GetOwnershipReq
+	(*GetOwnershipResp)(nil),                         // 87: GetOwnershipResp
+	(*Image3DModelPointUploadReq)(nil),               // 88: Image3DModelPointUploadReq
+	(*Image3DModelPointUploadResp)(nil),              // 89: Image3DModelPointUploadResp
+	(*Image3DModelPointsReq)(nil),                    // 90: Image3DModelPointsReq
+	(*Image3DModelPointsResp)(nil),                   // 91: Image3DModelPointsResp
+	(*ImageBeamLocationUploadReq)(nil),               // 92: ImageBeamLocationUploadReq
+	(*ImageBeamLocationUploadResp)(nil),              // 93: ImageBeamLocationUploadResp
+	(*ImageBeamLocationVersionsReq)(nil),             // 94: ImageBeamLocationVersionsReq
+	(*ImageBeamLocationVersionsResp)(nil),            // 95: ImageBeamLocationVersionsResp
+	(*ImageBeamLocationsReq)(nil),                    // 96: ImageBeamLocationsReq
+	(*ImageBeamLocationsResp)(nil),                   // 97: ImageBeamLocationsResp
+	(*ImageDeleteReq)(nil),                           // 98: ImageDeleteReq
+	(*ImageDeleteResp)(nil),                          // 99: ImageDeleteResp
+	(*ImageGetDefaultReq)(nil),                       // 100: ImageGetDefaultReq
+	(*ImageGetDefaultResp)(nil),                      // 101: ImageGetDefaultResp
+	(*ImageGetReq)(nil),                              // 102: ImageGetReq
+	(*ImageGetResp)(nil),                             // 103: ImageGetResp
+	(*ImageListReq)(nil),                             // 104: ImageListReq
+	(*ImageListResp)(nil),                            // 105: ImageListResp
+	(*ImageListUpd)(nil),                             // 106: ImageListUpd
+	(*ImagePyramidGetReq)(nil),                       // 107: ImagePyramidGetReq
+	(*ImagePyramidGetResp)(nil),                      // 108: ImagePyramidGetResp
+	(*ImageScanEntryDisplayElementsGetReq)(nil),      // 109: ImageScanEntryDisplayElementsGetReq
+	(*ImageScanEntryDisplayElementsGetResp)(nil),     // 110: ImageScanEntryDisplayElementsGetResp
+	(*ImageSetDefaultReq)(nil),                       // 111: ImageSetDefaultReq
+	(*ImageSetDefaultResp)(nil),                      // 112: ImageSetDefaultResp
+	(*ImageSetMatchTransformReq)(nil),                // 113: ImageSetMatchTransformReq
+	(*ImageSetMatchTransformResp)(nil),               // 114: ImageSetMatchTransformResp
+	(*ImageTileDataGetReq)(nil),                      // 115: ImageTileDataGetReq
+	(*ImageTileDataGetResp)(nil),                     // 116: ImageTileDataGetResp
+	(*ImageTileStructureGetReq)(nil),                 // 117: ImageTileStructureGetReq
+	(*ImageTileStructureGetResp)(nil),                // 118: ImageTileStructureGetResp
+	(*ImportMarsViewerImageReq)(nil),                 // 119: ImportMarsViewerImageReq
+	(*ImportMarsViewerImageResp)(nil),                // 120: ImportMarsViewerImageResp
+	(*ImportMarsViewerImageUpd)(nil),                 // 121: ImportMarsViewerImageUpd
+	(*JobCancelReq)(nil),                             // 122: JobCancelReq
+	(*JobCancelResp)(nil),                            // 123: JobCancelResp
+	(*JobListReq)(nil),                               // 124: JobListReq
+	(*JobListResp)(nil),                              // 125: JobListResp
+	(*JobListUpd)(nil),                               // 126: JobListUpd
+	(*LogGetLevelReq)(nil),                           // 127: LogGetLevelReq
+	(*LogGetLevelResp)(nil),                          // 128: LogGetLevelResp
+	(*LogReadReq)(nil),                               // 129: LogReadReq
+	(*LogReadResp)(nil),                              // 130: LogReadResp
+	(*LogSetLevelReq)(nil),                           // 131: LogSetLevelReq
+	(*LogSetLevelResp)(nil),                          // 132: LogSetLevelResp
+	(*MemoiseDeleteByRegexReq)(nil),                  // 133: MemoiseDeleteByRegexReq
+	(*MemoiseDeleteByRegexResp)(nil),                 // 134: MemoiseDeleteByRegexResp
+	(*MemoiseDeleteReq)(nil),                         // 135: MemoiseDeleteReq
+	(*MemoiseDeleteResp)(nil),                        // 136: MemoiseDeleteResp
+	(*MultiQuantCompareReq)(nil),                     // 137: MultiQuantCompareReq
+	(*MultiQuantCompareResp)(nil),                    // 138: MultiQuantCompareResp
+	(*NotificationDismissReq)(nil),                   // 139: NotificationDismissReq
+	(*NotificationDismissResp)(nil),                  // 140: NotificationDismissResp
+	(*NotificationReq)(nil),                          // 141: NotificationReq
+	(*NotificationResp)(nil),                         // 142: NotificationResp
+	(*NotificationUpd)(nil),                          // 143: NotificationUpd
+	(*ObjectEditAccessReq)(nil),                      // 144: ObjectEditAccessReq
+	(*ObjectEditAccessResp)(nil),                     // 145: ObjectEditAccessResp
+	(*ObjectRevisionGetReq)(nil),                     // 146: ObjectRevisionGetReq
+	(*ObjectRevisionGetResp)(nil),                    // 147: ObjectRevisionGetResp
+	(*ObjectRevisionListReq)(nil),                    // 148: ObjectRevisionListReq
+	(*ObjectRevisionListResp)(nil),                   // 149: ObjectRevisionListResp
+	(*ObjectRevisionRestoreReq)(nil),                 // 150: ObjectRevisionRestoreReq
+	(*ObjectRevisionRestoreResp)(nil),                // 151: ObjectRevisionRestoreResp
+	(*PiquantConfigFileReq)(nil),                     // 152: PiquantConfigFileReq
+	(*PiquantConfigFileResp)(nil),                    // 153: PiquantConfigFileResp
+	(*PiquantConfigListReq)(nil),                     // 154: PiquantConfigListReq
+	(*PiquantConfigListResp)(nil),                    // 155: PiquantConfigListResp
+	(*PiquantConfigVersionReq)(nil),                  // 156: PiquantConfigVersionReq
+	(*PiquantConfigVersionResp)(nil),                 // 157: PiquantConfigVersionResp
+	(*PiquantConfigVersionsListReq)(nil),             // 158: PiquantConfigVersionsListReq
+	(*PiquantConfigVersionsListResp)(nil),            // 159: PiquantConfigVersionsListResp
+	(*PiquantCurrentVersionReq)(nil),                 // 160: PiquantCurrentVersionReq
+	(*PiquantCurrentVersionResp)(nil),                // 161: PiquantCurrentVersionResp
+	(*PiquantRegressionReq)(nil),                     // 162: PiquantRegressionReq
+	(*PiquantRegressionResp)(nil),                    // 163: PiquantRegressionResp
+	(*PiquantRegressionResultReq)(nil),               // 164: PiquantRegressionResultReq
+	(*PiquantRegressionResultResp)(nil),              // 165: PiquantRegressionResultResp
+	(*PiquantRegressionUpd)(nil),                     // 166: PiquantRegressionUpd
+	(*PiquantVersionListReq)(nil),                    // 167: PiquantVersionListReq
+	(*PiquantVersionListResp)(nil),                   // 168: PiquantVersionListResp
+	(*PiquantWriteCurrentVersionReq)(nil),            // 169: PiquantWriteCurrentVersionReq
+	(*PiquantWriteCurrentVersionResp)(nil),           // 170: PiquantWriteCurrentVersionResp
+	(*PseudoIntensityReq)(nil),                       // 171: PseudoIntensityReq
+	(*PseudoIntensityResp)(nil),                      // 172: PseudoIntensityResp
+	(*PublishExpressionToZenodoReq)(nil),             // 173: PublishExpressionToZenodoReq
+	(*PublishExpressionToZenodoResp)(nil),            // 174: PublishExpressionToZenodoResp
+	(*QuantBlessReq)(nil),                            // 175: QuantBlessReq
+	(*QuantBlessResp)(nil),                           // 176: QuantBlessResp
+	(*QuantCombineListGetReq)(nil),                   // 177: QuantCombineListGetReq
+	(*QuantCombineListGetResp)(nil),                  // 178: QuantCombineListGetResp
+	(*QuantCombineListWriteReq)(nil),                 // 179: QuantCombineListWriteReq
+	(*QuantCombineListWriteResp)(nil),                // 180: QuantCombineListWriteResp
+	(*QuantCombineReq)(nil),                          // 181: QuantCombineReq
+	(*QuantCombineResp)(nil),                         // 182: QuantCombineResp
+	(*QuantCreateReq)(nil),                           // 183: QuantCreateReq
+	(*QuantCreateResp)(nil),                          // 184: QuantCreateResp
+	(*QuantCreateUpd)(nil),                           // 185: QuantCreateUpd
+	(*QuantDiffReq)(nil),                             // 186: QuantDiffReq
+	(*QuantDiffResp)(nil),                            // 187: QuantDiffResp
+	(*QuantDeleteReq)(nil),                           // 188: QuantDeleteReq
+	(*QuantDeleteResp)(nil),                          // 189: QuantDeleteResp
+	(*QuantGetReq)(nil),                              // 190: QuantGetReq
+	(*QuantGetResp)(nil),                             // 191: QuantGetResp
+	(*QuantLastOutputGetReq)(nil),                    // 192: QuantLastOutputGetReq
+	(*QuantLastOutputGetResp)(nil),                   // 193: QuantLastOutputGetResp
+	(*QuantListReq)(nil),                             // 194: QuantListReq
+	(*QuantListResp)(nil),                            // 195: QuantListResp
+	(*QuantLogGetReq)(nil),                           // 196: QuantLogGetReq
+	(*QuantLogGetResp)(nil),                          // 197: QuantLogGetResp
+	(*QuantLogListReq)(nil),                          // 198: QuantLogListReq
+	(*QuantLogListResp)(nil),                         // 199: QuantLogListResp
+	(*QuantPublishReq)(nil),                          // 200: QuantPublishReq
+	(*QuantPublishResp)(nil),                         // 201: QuantPublishResp
+	(*QuantRawDataGetReq)(nil),                       // 202: QuantRawDataGetReq
+	(*QuantRawDataGetResp)(nil),                      // 203: QuantRawDataGetResp
+	(*QuantUploadReq)(nil),                           // 204: QuantUploadReq
+	(*QuantUploadResp)(nil),                          // 205: QuantUploadResp
+	(*ReferenceDataBulkWriteReq)(nil),                // 206: ReferenceDataBulkWriteReq
+	(*ReferenceDataBulkWriteResp)(nil),               // 207: ReferenceDataBulkWriteResp
+	(*ReferenceDataDeleteReq)(nil),                   // 208: ReferenceDataDeleteReq
+	(*ReferenceDataDeleteResp)(nil),                  // 209: ReferenceDataDeleteResp
+	(*ReferenceDataGetReq)(nil),                      // 210: ReferenceDataGetReq
+	(*ReferenceDataGetResp)(nil),                     // 211: ReferenceDataGetResp
+	(*ReferenceDataListReq)(nil),                     // 212: ReferenceDataListReq
+	(*ReferenceDataListResp)(nil),                    // 213: ReferenceDataListResp
+	(*ReferenceDataWriteReq)(nil),                    // 214: ReferenceDataWriteReq
+	(*ReferenceDataWriteResp)(nil),                   // 215: ReferenceDataWriteResp
+	(*RegionOfInterestBulkDuplicateReq)(nil),         // 216: RegionOfInterestBulkDuplicateReq
+	(*RegionOfInterestBulkDuplicateResp)(nil),        // 217: RegionOfInterestBulkDuplicateResp
+	(*RegionOfInterestBulkWriteReq)(nil),             // 218: RegionOfInterestBulkWriteReq
+	(*RegionOfInterestBulkWriteResp)(nil),            // 219: RegionOfInterestBulkWriteResp
+	(*RegionOfInterestDeleteReq)(nil),                // 220: RegionOfInterestDeleteReq
+	(*RegionOfInterestDeleteResp)(nil),               // 221: RegionOfInterestDeleteResp
+	(*RegionOfInterestDisplaySettingsGetReq)(nil),    // 222: RegionOfInterestDisplaySettingsGetReq
+	(*RegionOfInterestDisplaySettingsGetResp)(nil),   // 223: RegionOfInterestDisplaySettingsGetResp
+	(*RegionOfInterestDisplaySettingsWriteReq)(nil),  // 224: RegionOfInterestDisplaySettingsWriteReq
+	(*RegionOfInterestDisplaySettingsWriteResp)(nil), // 225: RegionOfInterestDisplaySettingsWriteResp
+	(*RegionOfInterestGetReq)(nil),                   // 226: RegionOfInterestGetReq
+	(*RegionOfInterestGetResp)(nil),                  // 227: RegionOfInterestGetResp
+	(*RegionOfInterestListReq)(nil),                  // 228: RegionOfInterestListReq
+	(*RegionOfInterestListResp)(nil),                 // 229: RegionOfInterestListResp
+	(*RegionOfInterestWriteReq)(nil),                 // 230: RegionOfInterestWriteReq
+	(*RegionOfInterestWriteResp)(nil),                // 231: RegionOfInterestWriteResp
+	(*RestoreDBReq)(nil),                             // 232: RestoreDBReq
+	(*RestoreDBResp)(nil),                            // 233: RestoreDBResp
+	(*ReviewerMagicLinkCreateReq)(nil),               // 234: ReviewerMagicLinkCreateReq
+	(*ReviewerMagicLinkCreateResp)(nil),              // 235: ReviewerMagicLinkCreateResp
+	(*ReviewerMagicLinkLoginReq)(nil),                // 236: ReviewerMagicLinkLoginReq
+	(*ReviewerMagicLinkLoginResp)(nil),               // 237: ReviewerMagicLinkLoginResp
+	(*RunTestReq)(nil),                               // 238: RunTestReq
+	(*RunTestResp)(nil),                              // 239: RunTestResp
+	(*ScanAutoShareReq)(nil),                         // 240: ScanAutoShareReq
+	(*ScanAutoShareResp)(nil),                        // 241: ScanAutoShareResp
+	(*ScanAutoShareWriteReq)(nil),                    // 242: ScanAutoShareWriteReq
+	(*ScanAutoShareWriteResp)(nil),                   // 243: ScanAutoShareWriteResp
+	(*ScanBeamLocationsReq)(nil),                     // 244: ScanBeamLocationsReq
+	(*ScanBeamLocationsResp)(nil),                    // 245: ScanBeamLocationsResp
+	(*ScanCreateUserDefinedReq)(nil),                 // 246: ScanCreateUserDefinedReq
+	(*ScanCreateUserDefinedResp)(nil),                // 247: ScanCreateUserDefinedResp
+	(*ScanDeleteReq)(nil),                            // 248: ScanDeleteReq
+	(*ScanDeleteResp)(nil),                           // 249: ScanDeleteResp
+	(*ScanEntryMetadataReq)(nil),                     // 250: ScanEntryMetadataReq
+	(*ScanEntryMetadataResp)(nil),                    // 251: ScanEntryMetadataResp
+	(*ScanEntryReq)(nil),                             // 252: ScanEntryReq
+	(*ScanEntryResp)(nil),                            // 253: ScanEntryResp
+	(*ScanGetReq)(nil),                               // 254: ScanGetReq
+	(*ScanGetResp)(nil),                              // 255: ScanGetResp
+	(*ScanListJobsReq)(nil),                          // 256: ScanListJobsReq
+	(*ScanListJobsResp)(nil),                         // 257: ScanListJobsResp
+	(*ScanListReq)(nil),                              // 258: ScanListReq
+	(*ScanListResp)(nil),                             // 259: ScanListResp
+	(*ScanListUpd)(nil),                              // 260: ScanListUpd
+	(*ScanMetaLabelsAndTypesReq)(nil),                // 261: ScanMetaLabelsAndTypesReq
+	(*ScanMetaLabelsAndTypesResp)(nil),               // 262: ScanMetaLabelsAndTypesResp
+	(*ScanMetaWriteReq)(nil),                         // 263: ScanMetaWriteReq
+	(*ScanMetaWriteResp)(nil),                        // 264: ScanMetaWriteResp
+	(*ScanTriggerJobReq)(nil),                        // 265: ScanTriggerJobReq
+	(*ScanTriggerJobResp)(nil),                       // 266: ScanTriggerJobResp
+	(*ScanTriggerReImportReq)(nil),                   // 267: ScanTriggerReImportReq
+	(*ScanTriggerReImportResp)(nil),                  // 268: ScanTriggerReImportResp
+	(*ScanTriggerReImportUpd)(nil),                   // 269: ScanTriggerReImportUpd
+	(*ScanUploadReq)(nil),                            // 270: ScanUploadReq
+	(*ScanUploadResp)(nil),                           // 271: ScanUploadResp
+	(*ScanUploadUpd)(nil),                            // 272: ScanUploadUpd
+	(*ScanWriteJobReq)(nil),                          // 273: ScanWriteJobReq
+	(*ScanWriteJobResp)(nil),                         // 274: ScanWriteJobResp
+	(*ScreenConfigurationDeleteReq)(nil),             // 275: ScreenConfigurationDeleteReq
+	(*ScreenConfigurationDeleteResp)(nil),            // 276: ScreenConfigurationDeleteResp
+	(*ScreenConfigurationGetReq)(nil),                // 277: ScreenConfigurationGetReq
+	(*ScreenConfigurationGetResp)(nil),               // 278: ScreenConfigurationGetResp
+	(*ScreenConfigurationListReq)(nil),               // 279: ScreenConfigurationListReq
+	(*ScreenConfigurationListResp)(nil),              // 280: ScreenConfigurationListResp
+	(*ScreenConfigurationWriteReq)(nil),              // 281: ScreenConfigurationWriteReq
+	(*ScreenConfigurationWriteResp)(nil),             // 282: ScreenConfigurationWriteResp
+	(*SelectedImagePixelsReq)(nil),                   // 283: SelectedImagePixelsReq
+	(*SelectedImagePixelsResp)(nil),                  // 284: SelectedImagePixelsResp
+	(*SelectedImagePixelsWriteReq)(nil),              // 285: SelectedImagePixelsWriteReq
+	(*SelectedImagePixelsWriteResp)(nil),             // 286: SelectedImagePixelsWriteResp
+	(*SelectedScanEntriesReq)(nil),                   // 287: SelectedScanEntriesReq
+	(*SelectedScanEntriesResp)(nil),                  // 288: SelectedScanEntriesResp
+	(*SelectedScanEntriesWriteReq)(nil),              // 289: SelectedScanEntriesWriteReq
+	(*SelectedScanEntriesWriteResp)(nil),             // 290: SelectedScanEntriesWriteResp
+	(*SendUserNotificationReq)(nil),                  // 291: SendUserNotificationReq
+	(*SendUserNotificationResp)(nil),                 // 292: SendUserNotificationResp
+	(*SpectrumReq)(nil),                              // 293: SpectrumReq
+	(*SpectrumResp)(nil),                             // 294: SpectrumResp
+	(*TagCreateReq)(nil),                             // 295: TagCreateReq
+	(*TagCreateResp)(nil),                            // 296: TagCreateResp
+	(*TagDeleteReq)(nil),                             // 297: TagDeleteReq
+	(*TagDeleteResp)(nil),                            // 298: TagDeleteResp
+	(*TagListReq)(nil),                               // 299: TagListReq
+	(*TagListResp)(nil),                              // 300: TagListResp
+	(*UserAddRoleReq)(nil),                           // 301: UserAddRoleReq
+	(*UserAddRoleResp)(nil),                          // 302: UserAddRoleResp
+	(*UserDeleteRoleReq)(nil),                        // 303: UserDeleteRoleReq
+	(*UserDeleteRoleResp)(nil),                       // 304: UserDeleteRoleResp
+	(*UserDetailsReq)(nil),                           // 305: UserDetailsReq
+	(*UserDetailsResp)(nil),                          // 306: UserDetailsResp
+	(*UserDetailsWriteReq)(nil),                      // 307: UserDetailsWriteReq
+	(*UserDetailsWriteResp)(nil),                     // 308: UserDetailsWriteResp
+	(*UserGroupAddAdminReq)(nil),                     // 309: UserGroupAddAdminReq
+	(*UserGroupAddAdminResp)(nil),                    // 310: UserGroupAddAdminResp
+	(*UserGroupAddMemberReq)(nil),                    // 311: UserGroupAddMemberReq
+	(*UserGroupAddMemberResp)(nil),                   // 312: UserGroupAddMemberResp
+	(*UserGroupAddViewerReq)(nil),                    // 313: UserGroupAddViewerReq
+	(*UserGroupAddViewerResp)(nil),                   // 314: UserGroupAddViewerResp
+	(*UserGroupCreateReq)(nil),                       // 315: UserGroupCreateReq
+	(*UserGroupCreateResp)(nil),                      // 316: UserGroupCreateResp
+	(*UserGroupDeleteAdminReq)(nil),                  // 317: UserGroupDeleteAdminReq
+	(*UserGroupDeleteAdminResp)(nil),                 // 318: UserGroupDeleteAdminResp
+	(*UserGroupDeleteMemberReq)(nil),                 // 319: UserGroupDeleteMemberReq
+	(*UserGroupDeleteMemberResp)(nil),                // 320: UserGroupDeleteMemberResp
+	(*UserGroupDeleteReq)(nil),                       // 321: UserGroupDeleteReq
+	(*UserGroupDeleteResp)(nil),                      // 322: UserGroupDeleteResp
+	(*UserGroupDeleteViewerReq)(nil),                 // 323: UserGroupDeleteViewerReq
+	(*UserGroupDeleteViewerResp)(nil),                // 324: UserGroupDeleteViewerResp
+	(*UserGroupEditDetailsReq)(nil),                  // 325: UserGroupEditDetailsReq
+	(*UserGroupEditDetailsResp)(nil),                 // 326: UserGroupEditDetailsResp
+	(*UserGroupIgnoreJoinReq)(nil),                   // 327: UserGroupIgnoreJoinReq
+	(*UserGroupIgnoreJoinResp)(nil),                  // 328: UserGroupIgnoreJoinResp
+	(*UserGroupJoinListReq)(nil),                     // 329: UserGroupJoinListReq
+	(*UserGroupJoinListResp)(nil),                    // 330: UserGroupJoinListResp
+	(*UserGroupJoinReq)(nil),                         // 331: UserGroupJoinReq
+	(*UserGroupJoinResp)(nil),                        // 332: UserGroupJoinResp
+	(*UserGroupListJoinableReq)(nil),                 // 333: UserGroupListJoinableReq
+	(*UserGroupListJoinableResp)(nil),                // 334: UserGroupListJoinableResp
+	(*UserGroupListReq)(nil),                         // 335: UserGroupListReq
+	(*UserGroupListResp)(nil),                        // 336: UserGroupListResp
+	(*UserGroupReq)(nil),                             // 337: UserGroupReq
+	(*UserGroupResp)(nil),                            // 338: UserGroupResp
+	(*UserImpersonateGetReq)(nil),                    // 339: UserImpersonateGetReq
+	(*UserImpersonateGetResp)(nil),                   // 340: UserImpersonateGetResp
+	(*UserImpersonateReq)(nil),                       // 341: UserImpersonateReq
+	(*UserImpersonateResp)(nil),                      // 342: UserImpersonateResp
+	(*UserListReq)(nil),                              // 343: UserListReq
+	(*UserListResp)(nil),                             // 344: UserListResp
+	(*UserNotificationSettingsReq)(nil),              // 345: UserNotificationSettingsReq
+	(*UserNotificationSettingsResp)(nil),             // 346: UserNotificationSettingsResp
+	(*UserNotificationSettingsUpd)(nil),              // 347: UserNotificationSettingsUpd
+	(*UserNotificationSettingsWriteReq)(nil),         // 348: UserNotificationSettingsWriteReq
+	(*UserNotificationSettingsWriteResp)(nil),        // 349: UserNotificationSettingsWriteResp
+	(*UserRoleListReq)(nil),                          // 350: UserRoleListReq
+	(*UserRoleListResp)(nil),                         // 351: UserRoleListResp
+	(*UserRolesListReq)(nil),                         // 352: UserRolesListReq
+	(*UserRolesListResp)(nil),                        // 353: UserRolesListResp
+	(*UserSearchReq)(nil),                            // 354: UserSearchReq
+	(*UserSearchResp)(nil),                           // 355: UserSearchResp
+	(*WidgetDataGetReq)(nil),                         // 356: WidgetDataGetReq
+	(*WidgetDataGetResp)(nil),                        // 357: WidgetDataGetResp
+	(*WidgetDataWriteReq)(nil),                       // 358: WidgetDataWriteReq
+	(*WidgetDataWriteResp)(nil),                      // 359: WidgetDataWriteResp
+	(*WidgetMetadataGetReq)(nil),                     // 360: WidgetMetadataGetReq
+	(*WidgetMetadataGetResp)(nil),                    // 361: WidgetMetadataGetResp
+	(*WidgetMetadataWriteReq)(nil),                   // 362: WidgetMetadataWriteReq
+	(*WidgetMetadataWriteResp)(nil),                  // 363: WidgetMetadataWriteResp
+	(*ZenodoDOIGetReq)(nil),                          // 364: ZenodoDOIGetReq
+	(*ZenodoDOIGetResp)(nil),                         // 365: ZenodoDOIGetResp
 }
 var file_websocket_proto_depIdxs = []int32{
 	0,   // 0: WSMessage.status:type_name -> ResponseStatus
-	2,   // 1: WSMessage.backupDBReq:type_name -> BackupDBReq
-	3,   // 2: WSMessage.backupDBResp:type_name -> BackupDBResp
-	4,   // 3: WSMessage.backupDBUpd:type_name -> BackupDBUpd
-	5,   // 4: WSMessage.dBAdminConfigGetReq:type_name -> DBAdminConfigGetReq
-	6,   // 5: WSMessage.dBAdminConfigGetResp:type_name -> DBAdminConfigGetResp
-	7,   // 6: WSMessage.dataModuleAddVersionReq:type_name -> DataModuleAddVersionReq
-	8,   // 7: WSMessage.dataModuleAddVersionResp:type_name -> DataModuleAddVersionResp
-	9,   // 8: WSMessage.dataModuleGetReq:type_name -> DataModuleGetReq
-	10,  // 9: WSMessage.dataModuleGetResp:type_name -> DataModuleGetResp
-	11,  // 10: WSMessage.dataModuleListReq:type_name -> DataModuleListReq
-	12,  // 11: WSMessage.dataModuleListResp:type_name -> DataModuleListResp
-	13,  // 12: WSMessage.dataModuleWriteReq:type_name -> DataModuleWriteReq
-	14,  // 13: WSMessage.dataModuleWriteResp:type_name -> DataModuleWriteResp
-	15,  // 14: WSMessage.detectedDiffractionPeaksReq:type_name -> DetectedDiffractionPeaksReq
-	16,  // 15: WSMessage.detectedDiffractionPeaksResp:type_name -> DetectedDiffractionPeaksResp
-	17,  // 16: WSMessage.detectorConfigListReq:type_name -> DetectorConfigListReq
-	18,  // 17: WSMessage.detectorConfigListResp:type_name -> DetectorConfigListResp
-	19,  // 18: WSMessage.detectorConfigReq:type_name -> DetectorConfigReq
-	20,  // 19: WSMessage.detectorConfigResp:type_name -> DetectorConfigResp
-	21,  // 20: WSMessage.diffractionPeakManualDeleteReq:type_name -> DiffractionPeakManualDeleteReq
-	22,  // 21: WSMessage.diffractionPeakManualDeleteResp:type_name -> DiffractionPeakManualDeleteResp
-	23,  // 22: WSMessage.diffractionPeakManualInsertReq:type_name -> DiffractionPeakManualInsertReq
-	24,  // 23: WSMessage.diffractionPeakManualInsertResp:type_name -> DiffractionPeakManualInsertResp
-	25,  // 24: WSMessage.diffractionPeakManualListReq:type_name -> DiffractionPeakManualListReq
-	26,  // 25: WSMessage.diffractionPeakManualListResp:type_name -> DiffractionPeakManualListResp
-	27,  // 26: WSMessage.diffractionPeakStatusDeleteReq:type_name -> DiffractionPeakStatusDeleteReq
-	28,  // 27: WSMessage.diffractionPeakStatusDeleteResp:type_name -> DiffractionPeakStatusDeleteResp
-	29,  // 28: WSMessage.diffractionPeakStatusListReq:type_name -> DiffractionPeakStatusListReq
-	30,  // 29: WSMessage.diffractionPeakStatusListResp:type_name -> DiffractionPeakStatusListResp
-	31,  // 30: WSMessage.diffractionPeakStatusWriteReq:type_name -> DiffractionPeakStatusWriteReq
-	32,  // 31: WSMessage.diffractionPeakStatusWriteResp:type_name -> DiffractionPeakStatusWriteResp
-	33,  // 32: WSMessage.elementSetDeleteReq:type_name -> ElementSetDeleteReq
-	34,  // 33: WSMessage.elementSetDeleteResp:type_name -> ElementSetDeleteResp
-	35,  // 34: WSMessage.elementSetGetReq:type_name -> ElementSetGetReq
-	36,  // 35: WSMessage.elementSetGetResp:type_name -> ElementSetGetResp
-	37,  // 36: WSMessage.elementSetListReq:type_name -> ElementSetListReq
-	38,  // 37: WSMessage.elementSetListResp:type_name -> ElementSetListResp
-	39,  // 38: WSMessage.elementSetWriteReq:type_name -> ElementSetWriteReq
-	40,  // 39: WSMessage.elementSetWriteResp:type_name -> ElementSetWriteResp
-	41,  // 40: WSMessage.exportFilesReq:type_name -> ExportFilesReq
-	42,  // 41: WSMessage.exportFilesResp:type_name -> ExportFilesResp
-	43,  // 42: WSMessage.expressionCalculateReq:type_name -> ExpressionCalculateReq
-	44,  // 43: WSMessage.expressionCalculateResp:type_name -> ExpressionCalculateResp
-	45,  // 44: WSMessage.expressionDeleteReq:type_name -> ExpressionDeleteReq
-	46,  // 45: WSMessage.expressionDeleteResp:type_name -> ExpressionDeleteResp
-	47,  // 46: WSMessage.expressionDisplaySettingsGetReq:type_name -> ExpressionDisplaySettingsGetReq
-	48,  // 47: WSMessage.expressionDisplaySettingsGetResp:type_name -> ExpressionDisplaySettingsGetResp
-	49,  // 48: WSMessage.expressionDisplaySettingsWriteReq:type_name -> ExpressionDisplaySettingsWriteReq
-	50,  // 49: WSMessage.expressionDisplaySettingsWriteResp:type_name -> ExpressionDisplaySettingsWriteResp
-	51,  // 50: WSMessage.expressionGetReq:type_name -> ExpressionGetReq
-	52,  // 51: WSMessage.expressionGetResp:type_name -> ExpressionGetResp
-	53,  // 52: WSMessage.expressionGroupDeleteReq:type_name -> ExpressionGroupDeleteReq
-	54,  // 53: WSMessage.expressionGroupDeleteResp:type_name -> ExpressionGroupDeleteResp
-	55,  // 54: WSMessage.expressionGroupGetReq:type_name -> ExpressionGroupGetReq
-	56,  // 55: WSMessage.expressionGroupGetResp:type_name -> ExpressionGroupGetResp
-	57,  // 56: WSMessage.expressionGroupListReq:type_name -> ExpressionGroupListReq
-	58,  // 57: WSMessage.expressionGroupListResp:type_name -> ExpressionGroupListResp
-	59,  // 58: WSMessage.expressionGroupWriteReq:type_name -> ExpressionGroupWriteReq
-	60,  // 59: WSMessage.expressionGroupWriteResp:type_name -> ExpressionGroupWriteResp
-	61,  // 60: WSMessage.expressionListReq:type_name -> ExpressionListReq
-	62,  // 61: WSMessage.expressionListResp:type_name -> ExpressionListResp
-	63,  // 62: WSMessage.expressionWriteExecStatReq:type_name -> ExpressionWriteExecStatReq
-	64,  // 63: WSMessage.expressionWriteExecStatResp:type_name -> ExpressionWriteExecStatResp
-	65,  // 64: WSMessage.expressionWriteReq:type_name -> ExpressionWriteReq
-	66,  // 65: WSMessage.expressionWriteResp:type_name -> ExpressionWriteResp
-	67,  // 66: WSMessage.getOwnershipDescriptionReq:type_name -> GetOwnershipDescriptionReq
-	68,  // 67: WSMessage.getOwnershipDescriptionResp:type_name -> GetOwnershipDescriptionResp
-	69,  // 68: WSMessage.getOwnershipReq:type_name -> GetOwnershipReq
-	70,  // 69: WSMessage.getOwnershipResp:type_name -> GetOwnershipResp
-	71,  // 70: WSMessage.image3DModelPointUploadReq:type_name -> Image3DModelPointUploadReq
-	72,  // 71: WSMessage.image3DModelPointUploadResp:type_name -> Image3DModelPointUploadResp
-	73,  // 72: WSMessage.image3DModelPointsReq:type_name -> Image3DModelPointsReq
-	74,  // 73: WSMessage.image3DModelPointsResp:type_name -> Image3DModelPointsResp
-	75,  // 74: WSMessage.imageBeamLocationUploadReq:type_name -> ImageBeamLocationUploadReq
-	76,  // 75: WSMessage.imageBeamLocationUploadResp:type_name -> ImageBeamLocationUploadResp
-	77,  // 76: WSMessage.imageBeamLocationVersionsReq:type_name -> ImageBeamLocationVersionsReq
-	78,  // 77: WSMessage.imageBeamLocationVersionsResp:type_name -> ImageBeamLocationVersionsResp
-	79,  // 78: WSMessage.imageBeamLocationsReq:type_name -> ImageBeamLocationsReq
-	80,  // 79: WSMessage.imageBeamLocationsResp:type_name -> ImageBeamLocationsResp
-	81,  // 80: WSMessage.imageDeleteReq:type_name -> ImageDeleteReq
-	82,  // 81: WSMessage.imageDeleteResp:type_name -> ImageDeleteResp
-	83,  // 82: WSMessage.imageGetDefaultReq:type_name -> ImageGetDefaultReq
-	84,  // 83: WSMessage.imageGetDefaultResp:type_name -> ImageGetDefaultResp
-	85,  // 84: WSMessage.imageGetReq:type_name -> ImageGetReq
-	86,  // 85: WSMessage.imageGetResp:type_name -> ImageGetResp
-	87,  // 86: WSMessage.imageListReq:type_name -> ImageListReq
-	88,  // 87: WSMessage.imageListResp:type_name -> ImageListResp
-	89,  // 88: WSMessage.imageListUpd:type_name -> ImageListUpd
-	90,  // 89: WSMessage.imagePyramidGetReq:type_name -> ImagePyramidGetReq
-	91,  // 90: WSMessage.imagePyramidGetResp:type_name -> ImagePyramidGetResp
-	92,  // 91: WSMessage.imageScanEntryDisplayElementsGetReq:type_name -> ImageScanEntryDisplayElementsGetReq
-	93,  // 92: WSMessage.imageScanEntryDisplayElementsGetResp:type_name -> ImageScanEntryDisplayElementsGetResp
-	94,  // 93: WSMessage.imageSetDefaultReq:type_name -> ImageSetDefaultReq
-	95,  // 94: WSMessage.imageSetDefaultResp:type_name -> ImageSetDefaultResp
-	96,  // 95: WSMessage.imageSetMatchTransformReq:type_name -> ImageSetMatchTransformReq
-	97,  // 96: WSMessage.imageSetMatchTransformResp:type_name -> ImageSetMatchTransformResp
-	98,  // 97: WSMessage.imageTileDataGetReq:type_name -> ImageTileDataGetReq
-	99,  // 98: WSMessage.imageTileDataGetResp:type_name -> ImageTileDataGetResp
-	100, // 99: WSMessage.imageTileStructureGetReq:type_name -> ImageTileStructureGetReq
-	101, // 100: WSMessage.imageTileStructureGetResp:type_name -> ImageTileStructureGetResp
-	102, // 101: WSMessage.importMarsViewerImageReq:type_name -> ImportMarsViewerImageReq
-	103, // 102: WSMessage.importMarsViewerImageResp:type_name -> ImportMarsViewerImageResp
-	104, // 103: WSMessage.importMarsViewerImageUpd:type_name -> ImportMarsViewerImageUpd
-	105, // 104: WSMessage.jobListReq:type_name -> JobListReq
-	106, // 105: WSMessage.jobListResp:type_name -> JobListResp
-	107, // 106: WSMessage.jobListUpd:type_name -> JobListUpd
-	108, // 107: WSMessage.logGetLevelReq:type_name -> LogGetLevelReq
-	109, // 108: WSMessage.logGetLevelResp:type_name -> LogGetLevelResp
-	110, // 109: WSMessage.logReadReq:type_name -> LogReadReq
-	111, // 110: WSMessage.logReadResp:type_name -> LogReadResp
-	112, // 111: WSMessage.logSetLevelReq:type_name -> LogSetLevelReq
-	113, // 112: WSMessage.logSetLevelResp:type_name -> LogSetLevelResp
-	114, // 113: WSMessage.memoiseDeleteByRegexReq:type_name -> MemoiseDeleteByRegexReq
-	115, // 114: WSMessage.memoiseDeleteByRegexResp:type_name -> MemoiseDeleteByRegexResp
-	116, // 115: WSMessage.memoiseDeleteReq:type_name -> MemoiseDeleteReq
-	117, // 116: WSMessage.memoiseDeleteResp:type_name -> MemoiseDeleteResp
-	118, // 117: WSMessage.multiQuantCompareReq:type_name -> MultiQuantCompareReq
-	119, // 118: WSMessage.multiQuantCompareResp:type_name -> MultiQuantCompareResp
-	120, // 119: WSMessage.notificationDismissReq:type_name -> NotificationDismissReq
-	121, // 120: WSMessage.notificationDismissResp:type_name -> NotificationDismissResp
-	122, // 121: WSMessage.notificationReq:type_name -> NotificationReq
-	123, // 122: WSMessage.notificationResp:type_name -> NotificationResp
-	124, // 123: WSMessage.notificationUpd:type_name -> NotificationUpd
-	125, // 124: WSMessage.objectEditAccessReq:type_name -> ObjectEditAccessReq
-	126, // 125: WSMessage.objectEditAccessResp:type_name -> ObjectEditAccessResp
-	127, // 126: WSMessage.piquantConfigFileReq:type_name -> PiquantConfigFileReq
-	128, // 127: WSMessage.piquantConfigFileResp:type_name -> PiquantConfigFileResp
-	129, // 128: WSMessage.piquantConfigListReq:type_name -> PiquantConfigListReq
-	130, // 129: WSMessage.piquantConfigListResp:type_name -> PiquantConfigListResp
-	131, // 130: WSMessage.piquantConfigVersionReq:type_name -> PiquantConfigVersionReq
-	132, // 131: WSMessage.piquantConfigVersionResp:type_name -> PiquantConfigVersionResp
-	133, // 132: WSMessage.piquantConfigVersionsListReq:type_name -> PiquantConfigVersionsListReq
-	134, // 133: WSMessage.piquantConfigVersionsListResp:type_name -> PiquantConfigVersionsListResp
-	135, // 134: WSMessage.piquantCurrentVersionReq:type_name -> PiquantCurrentVersionReq
-	136, // 135: WSMessage.piquantCurrentVersionResp:type_name -> PiquantCurrentVersionResp
-	137, // 136: WSMessage.piquantVersionListReq:type_name -> PiquantVersionListReq
-	138, // 137: WSMessage.piquantVersionListResp:type_name -> PiquantVersionListResp
-	139, // 138: WSMessage.piquantWriteCurrentVersionReq:type_name -> PiquantWriteCurrentVersionReq
-	140, // 139: WSMessage.piquantWriteCurrentVersionResp:type_name -> PiquantWriteCurrentVersionResp
-	141, // 140: WSMessage.pseudoIntensityReq:type_name -> PseudoIntensityReq
-	142, // 141: WSMessage.pseudoIntensityResp:type_name -> PseudoIntensityResp
-	143, // 142: WSMessage.publishExpressionToZenodoReq:type_name -> PublishExpressionToZenodoReq
-	144, // 143: WSMessage.publishExpressionToZenodoResp:type_name -> PublishExpressionToZenodoResp
-	145, // 144: WSMessage.quantBlessReq:type_name -> QuantBlessReq
-	146, // 145: WSMessage.quantBlessResp:type_name -> QuantBlessResp
-	147, // 146: WSMessage.quantCombineListGetReq:type_name -> QuantCombineListGetReq
-	148, // 147: WSMessage.quantCombineListGetResp:type_name -> QuantCombineListGetResp
-	149, // 148: WSMessage.quantCombineListWriteReq:type_name -> QuantCombineListWriteReq
-	150, // 149: WSMessage.quantCombineListWriteResp:type_name -> QuantCombineListWriteResp
-	151, // 150: WSMessage.quantCombineReq:type_name -> QuantCombineReq
-	152, // 151: WSMessage.quantCombineResp:type_name -> QuantCombineResp
-	153, // 152: WSMessage.quantCreateReq:type_name -> QuantCreateReq
-	154, // 153: WSMessage.quantCreateResp:type_name -> QuantCreateResp
-	155, // 154: WSMessage.quantCreateUpd:type_name -> QuantCreateUpd
-	156, // 155: WSMessage.quantDeleteReq:type_name -> QuantDeleteReq
-	157, // 156: WSMessage.quantDeleteResp:type_name -> QuantDeleteResp
-	158, // 157: WSMessage.quantGetReq:type_name -> QuantGetReq
-	159, // 158: WSMessage.quantGetResp:type_name -> QuantGetResp
-	160, // 159: WSMessage.quantLastOutputGetReq:type_name -> QuantLastOutputGetReq
-	161, // 160: WSMessage.quantLastOutputGetResp:type_name -> QuantLastOutputGetResp
-	162, // 161: WSMessage.quantListReq:type_name -> QuantListReq
-	163, // 162: WSMessage.quantListResp:type_name -> QuantListResp
-	164, // 163: WSMessage.quantLogGetReq:type_name -> QuantLogGetReq
-	165, // 164: WSMessage.quantLogGetResp:type_name -> QuantLogGetResp
-	166, // 165: WSMessage.quantLogListReq:type_name -> QuantLogListReq
-	167, // 166: WSMessage.quantLogListResp:type_name -> QuantLogListResp
-	168, // 167: WSMessage.quantPublishReq:type_name -> QuantPublishReq
-	169, // 168: WSMessage.quantPublishResp:type_name -> QuantPublishResp
-	170, // 169: WSMessage.quantRawDataGetReq:type_name -> QuantRawDataGetReq
-	171, // 170: WSMessage.quantRawDataGetResp:type_name -> QuantRawDataGetResp
-	172, // 171: WSMessage.quantUploadReq:type_name -> QuantUploadReq
-	173, // 172: WSMessage.quantUploadResp:type_name -> QuantUploadResp
-	174, // 173: WSMessage.referenceDataBulkWriteReq:type_name -> ReferenceDataBulkWriteReq
-	175, // 174: WSMessage.referenceDataBulkWriteResp:type_name -> ReferenceDataBulkWriteResp
-	176, // 175: WSMessage.referenceDataDeleteReq:type_name -> ReferenceDataDeleteReq
-	177, // 176: WSMessage.referenceDataDeleteResp:type_name -> ReferenceDataDeleteResp
-	178, // 177: WSMessage.referenceDataGetReq:type_name -> ReferenceDataGetReq
-	179, // 178: WSMessage.referenceDataGetResp:type_name -> ReferenceDataGetResp
-	180, // 179: WSMessage.referenceDataListReq:type_name -> ReferenceDataListReq
-	181, // 180: WSMessage.referenceDataListResp:type_name -> ReferenceDataListResp
-	182, // 181: WSMessage.referenceDataWriteReq:type_name -> ReferenceDataWriteReq
-	183, // 182: WSMessage.referenceDataWriteResp:type_name -> ReferenceDataWriteResp
-	184, // 183: WSMessage.regionOfInterestBulkDuplicateReq:type_name -> RegionOfInterestBulkDuplicateReq
-	185, // 184: WSMessage.regionOfInterestBulkDuplicateResp:type_name -> RegionOfInterestBulkDuplicateResp
-	186, // 185: WSMessage.regionOfInterestBulkWriteReq:type_name -> RegionOfInterestBulkWriteReq
-	187, // 186: WSMessage.regionOfInterestBulkWriteResp:type_name -> RegionOfInterestBulkWriteResp
-	188, // 187: WSMessage.regionOfInterestDeleteReq:type_name -> RegionOfInterestDeleteReq
-	189, // 188: WSMessage.regionOfInterestDeleteResp:type_name -> RegionOfInterestDeleteResp
-	190, // 189: WSMessage.regionOfInterestDisplaySettingsGetReq:type_name -> RegionOfInterestDisplaySettingsGetReq
-	191, // 190: WSMessage.regionOfInterestDisplaySettingsGetResp:type_name -> RegionOfInterestDisplaySettingsGetResp
-	192, // 191: WSMessage.regionOfInterestDisplaySettingsWriteReq:type_name -> RegionOfInterestDisplaySettingsWriteReq
-	193, // 192: WSMessage.regionOfInterestDisplaySettingsWriteResp:type_name -> RegionOfInterestDisplaySettingsWriteResp
-	194, // 193: WSMessage.regionOfInterestGetReq:type_name -> RegionOfInterestGetReq
-	195, // 194: WSMessage.regionOfInterestGetResp:type_name -> RegionOfInterestGetResp
-	196, // 195: WSMessage.regionOfInterestListReq:type_name -> RegionOfInterestListReq
-	197, // 196: WSMessage.regionOfInterestListResp:type_name -> RegionOfInterestListResp
-	198, // 197: WSMessage.regionOfInterestWriteReq:type_name -> RegionOfInterestWriteReq
-	199, // 198: WSMessage.regionOfInterestWriteResp:type_name -> RegionOfInterestWriteResp
-	200, // 199: WSMessage.restoreDBReq:type_name -> RestoreDBReq
-	201, // 200: WSMessage.restoreDBResp:type_name -> RestoreDBResp
-	202, // 201: WSMessage.reviewerMagicLinkCreateReq:type_name -> ReviewerMagicLinkCreateReq
-	203, // 202: WSMessage.reviewerMagicLinkCreateResp:type_name -> ReviewerMagicLinkCreateResp
-	204, // 203: WSMessage.reviewerMagicLinkLoginReq:type_name -> ReviewerMagicLinkLoginReq
-	205, // 204: WSMessage.reviewerMagicLinkLoginResp:type_name -> ReviewerMagicLinkLoginResp
-	206, // 205: WSMessage.runTestReq:type_name -> RunTestReq
-	207, // 206: WSMessage.runTestResp:type_name -> RunTestResp
-	208, // 207: WSMessage.scanAutoShareReq:type_name -> ScanAutoShareReq
-	209, // 208: WSMessage.scanAutoShareResp:type_name -> ScanAutoShareResp
-	210, // 209: WSMessage.scanAutoShareWriteReq:type_name -> ScanAutoShareWriteReq
-	211, // 210: WSMessage.scanAutoShareWriteResp:type_name -> ScanAutoShareWriteResp
-	212, // 211: WSMessage.scanBeamLocationsReq:type_name -> ScanBeamLocationsReq
-	213, // 212: WSMessage.scanBeamLocationsResp:type_name -> ScanBeamLocationsResp
-	214, // 213: WSMessage.scanCreateUserDefinedReq:type_name -> ScanCreateUserDefinedReq
-	215, // 214: WSMessage.scanCreateUserDefinedResp:type_name -> ScanCreateUserDefinedResp
-	216, // 215: WSMessage.scanDeleteReq:type_name -> ScanDeleteReq
-	217, // 216: WSMessage.scanDeleteResp:type_name -> ScanDeleteResp
-	218, // 217: WSMessage.scanEntryMetadataReq:type_name -> ScanEntryMetadataReq
-	219, // 218: WSMessage.scanEntryMetadataResp:type_name -> ScanEntryMetadataResp
-	220, // 219: WSMessage.scanEntryReq:type_name -> ScanEntryReq
-	221, // 220: WSMessage.scanEntryResp:type_name -> ScanEntryResp
-	222, // 221: WSMessage.scanGetReq:type_name -> ScanGetReq
-	223, // 222: WSMessage.scanGetResp:type_name -> ScanGetResp
-	224, // 223: WSMessage.scanListJobsReq:type_name -> ScanListJobsReq
-	225, // 224: WSMessage.scanListJobsResp:type_name -> ScanListJobsResp
-	226, // 225: WSMessage.scanListReq:type_name -> ScanListReq
-	227, // 226: WSMessage.scanListResp:type_name -> ScanListResp
-	228, // 227: WSMessage.scanListUpd:type_name -> ScanListUpd
-	229, // 228: WSMessage.scanMetaLabelsAndTypesReq:type_name -> ScanMetaLabelsAndTypesReq
-	230, // 229: WSMessage.scanMetaLabelsAndTypesResp:type_name -> ScanMetaLabelsAndTypesResp
-	231, // 230: WSMessage.scanMetaWriteReq:type_name -> ScanMetaWriteReq
-	232, // 231: WSMessage.scanMetaWriteResp:type_name -> ScanMetaWriteResp
-	233, // 232: WSMessage.scanTriggerJobReq:type_name -> ScanTriggerJobReq
-	234, // 233: WSMessage.scanTriggerJobResp:type_name -> ScanTriggerJobResp
-	235, // 234: WSMessage.scanTriggerReImportReq:type_name -> ScanTriggerReImportReq
-	236, // 235: WSMessage.scanTriggerReImportResp:type_name -> ScanTriggerReImportResp
-	237, // 236: WSMessage.scanTriggerReImportUpd:type_name -> ScanTriggerReImportUpd
-	238, // 237: WSMessage.scanUploadReq:type_name -> ScanUploadReq
-	239, // 238: WSMessage.scanUploadResp:type_name -> ScanUploadResp
-	240, // 239: WSMessage.scanUploadUpd:type_name -> ScanUploadUpd
-	241, // 240: WSMessage.scanWriteJobReq:type_name -> ScanWriteJobReq
-	242, // 241: WSMessage.scanWriteJobResp:type_name -> ScanWriteJobResp
-	243, // 242: WSMessage.screenConfigurationDeleteReq:type_name -> ScreenConfigurationDeleteReq
-	244, // 243: WSMessage.screenConfigurationDeleteResp:type_name -> ScreenConfigurationDeleteResp
-	245, // 244: WSMessage.screenConfigurationGetReq:type_name -> ScreenConfigurationGetReq
-	246, // 245: WSMessage.screenConfigurationGetResp:type_name -> ScreenConfigurationGetResp
-	247, // 246: WSMessage.screenConfigurationListReq:type_name -> ScreenConfigurationListReq
-	248, // 247: WSMessage.screenConfigurationListResp:type_name -> ScreenConfigurationListResp
-	249, // 248: WSMessage.screenConfigurationWriteReq:type_name -> ScreenConfigurationWriteReq
-	250, // 249: WSMessage.screenConfigurationWriteResp:type_name -> ScreenConfigurationWriteResp
-	251, // 250: WSMessage.selectedImagePixelsReq:type_name -> SelectedImagePixelsReq
-	252, // 251: WSMessage.selectedImagePixelsResp:type_name -> SelectedImagePixelsResp
-	253, // 252: WSMessage.selectedImagePixelsWriteReq:type_name -> SelectedImagePixelsWriteReq
-	254, // 253: WSMessage.selectedImagePixelsWriteResp:type_name -> SelectedImagePixelsWriteResp
-	255, // 254: WSMessage.selectedScanEntriesReq:type_name -> SelectedScanEntriesReq
-	256, // 255: WSMessage.selectedScanEntriesResp:type_name -> SelectedScanEntriesResp
-	257, // 256: WSMessage.selectedScanEntriesWriteReq:type_name -> SelectedScanEntriesWriteReq
-	258, // 257: WSMessage.selectedScanEntriesWriteResp:type_name -> SelectedScanEntriesWriteResp
-	259, // 258: WSMessage.sendUserNotificationReq:type_name -> SendUserNotificationReq
-	260, // 259: WSMessage.sendUserNotificationResp:type_name -> SendUserNotificationResp
-	261, // 260: WSMessage.spectrumReq:type_name -> SpectrumReq
-	262, // 261: WSMessage.spectrumResp:type_name -> SpectrumResp
-	263, // 262: WSMessage.tagCreateReq:type_name -> TagCreateReq
-	264, // 263: WSMessage.tagCreateResp:type_name -> TagCreateResp
-	265, // 264: WSMessage.tagDeleteReq:type_name -> TagDeleteReq
-	266, // 265: WSMessage.tagDeleteResp:type_name -> TagDeleteResp
-	267, // 266: WSMessage.tagListReq:type_name -> TagListReq
-	268, // 267: WSMessage.tagListResp:type_name -> TagListResp
-	269, // 268: WSMessage.userAddRoleReq:type_name -> UserAddRoleReq
-	270, // 269: WSMessage.userAddRoleResp:type_name -> UserAddRoleResp
-	271, // 270: WSMessage.userDeleteRoleReq:type_name -> UserDeleteRoleReq
-	272, // 271: WSMessage.userDeleteRoleResp:type_name -> UserDeleteRoleResp
-	273, // 272: WSMessage.userDetailsReq:type_name -> UserDetailsReq
-	274, // 273: WSMessage.userDetailsResp:type_name -> UserDetailsResp
-	275, // 274: WSMessage.userDetailsWriteReq:type_name -> UserDetailsWriteReq
-	276, // 275: WSMessage.userDetailsWriteResp:type_name -> UserDetailsWriteResp
-	277, // 276: WSMessage.userGroupAddAdminReq:type_name -> UserGroupAddAdminReq
-	278, // 277: WSMessage.userGroupAddAdminResp:type_name -> UserGroupAddAdminResp
-	279, // 278: WSMessage.userGroupAddMemberReq:type_name -> UserGroupAddMemberReq
-	280, // 279: WSMessage.userGroupAddMemberResp:type_name -> UserGroupAddMemberResp
-	281, // 280: WSMessage.userGroupAddViewerReq:type_name -> UserGroupAddViewerReq
-	282, // 281: WSMessage.userGroupAddViewerResp:type_name -> UserGroupAddViewerResp
-	283, // 282: WSMessage.userGroupCreateReq:type_name -> UserGroupCreateReq
-	284, // 283: WSMessage.userGroupCreateResp:type_name -> UserGroupCreateResp
-	285, // 284: WSMessage.userGroupDeleteAdminReq:type_name -> UserGroupDeleteAdminReq
-	286, // 285: WSMessage.userGroupDeleteAdminResp:type_name -> UserGroupDeleteAdminResp
-	287, // 286: WSMessage.userGroupDeleteMemberReq:type_name -> UserGroupDeleteMemberReq
-	288, // 287: WSMessage.userGroupDeleteMemberResp:type_name -> UserGroupDeleteMemberResp
-	289, // 288: WSMessage.userGroupDeleteReq:type_name -> UserGroupDeleteReq
-	290, // 289: WSMessage.userGroupDeleteResp:type_name -> UserGroupDeleteResp
-	291, // 290: WSMessage.userGroupDeleteViewerReq:type_name -> UserGroupDeleteViewerReq
-	292, // 291: WSMessage.userGroupDeleteViewerResp:type_name -> UserGroupDeleteViewerResp
-	293, // 292: WSMessage.userGroupEditDetailsReq:type_name -> UserGroupEditDetailsReq
-	294, // 293: WSMessage.userGroupEditDetailsResp:type_name -> UserGroupEditDetailsResp
-	295, // 294: WSMessage.userGroupIgnoreJoinReq:type_name -> UserGroupIgnoreJoinReq
-	296, // 295: WSMessage.userGroupIgnoreJoinResp:type_name -> UserGroupIgnoreJoinResp
-	297, // 296: WSMessage.userGroupJoinListReq:type_name -> UserGroupJoinListReq
-	298, // 297: WSMessage.userGroupJoinListResp:type_name -> UserGroupJoinListResp
-	299, // 298: WSMessage.userGroupJoinReq:type_name -> UserGroupJoinReq
-	300, // 299: WSMessage.userGroupJoinResp:type_name -> UserGroupJoinResp
-	301, // 300: WSMessage.userGroupListJoinableReq:type_name -> UserGroupListJoinableReq
-	302, // 301: WSMessage.userGroupListJoinableResp:type_name -> UserGroupListJoinableResp
-	303, // 302: WSMessage.userGroupListReq:type_name -> UserGroupListReq
-	304, // 303: WSMessage.userGroupListResp:type_name -> UserGroupListResp
-	305, // 304: WSMessage.userGroupReq:type_name -> UserGroupReq
-	306, // 305: WSMessage.userGroupResp:type_name -> UserGroupResp
-	307, // 306: WSMessage.userImpersonateGetReq:type_name -> UserImpersonateGetReq
-	308, // 307: WSMessage.userImpersonateGetResp:type_name -> UserImpersonateGetResp
-	309, // 308: WSMessage.userImpersonateReq:type_name -> UserImpersonateReq
-	310, // 309: WSMessage.userImpersonateResp:type_name -> UserImpersonateResp
-	311, // 310: WSMessage.userListReq:type_name -> UserListReq
-	312, // 311: WSMessage.userListResp:type_name -> UserListResp
-	313, // 312: WSMessage.userNotificationSettingsReq:type_name -> UserNotificationSettingsReq
-	314, // 313: WSMessage.userNotificationSettingsResp:type_name -> UserNotificationSettingsResp
-	315, // 314: WSMessage.userNotificationSettingsUpd:type_name -> UserNotificationSettingsUpd
-	316, // 315: WSMessage.userNotificationSettingsWriteReq:type_name -> UserNotificationSettingsWriteReq
-	317, // 316: WSMessage.userNotificationSettingsWriteResp:type_name -> UserNotificationSettingsWriteResp
-	318, // 317: WSMessage.userRoleListReq:type_name -> UserRoleListReq
-	319, // 318: WSMessage.userRoleListResp:type_name -> UserRoleListResp
-	320, // 319: WSMessage.userRolesListReq:type_name -> UserRolesListReq
-	321, // 320: WSMessage.userRolesListResp:type_name -> UserRolesListResp
-	322, // 321: WSMessage.userSearchReq:type_name -> UserSearchReq
-	323, // 322: WSMessage.userSearchResp:type_name -> UserSearchResp
-	324, // 323: WSMessage.widgetDataGetReq:type_name -> WidgetDataGetReq
-	325, // 324: WSMessage.widgetDataGetResp:type_name -> WidgetDataGetResp
-	326, // 325: WSMessage.widgetDataWriteReq:type_name -> WidgetDataWriteReq
-	327, // 326: WSMessage.widgetDataWriteResp:type_name -> WidgetDataWriteResp
-	328, // 327: WSMessage.widgetMetadataGetReq:type_name -> WidgetMetadataGetReq
-	329, // 328: WSMessage.widgetMetadataGetResp:type_name -> WidgetMetadataGetResp
-	330, // 329: WSMessage.widgetMetadataWriteReq:type_name -> WidgetMetadataWriteReq
-	331, // 330: WSMessage.widgetMetadataWriteResp:type_name -> WidgetMetadataWriteResp
-	332, // 331: WSMessage.zenodoDOIGetReq:type_name -> ZenodoDOIGetReq
-	333, // 332: WSMessage.zenodoDOIGetResp:type_name -> ZenodoDOIGetResp
-	333, // [333:333] is the sub-list for method output_type
-	333, // [333:333] is the sub-list for method input_type
-	333, // [333:333] is the sub-list for extension type_name
-	333, // [333:333] is the sub-list for extension extendee
-	0,   // [0:333] is the sub-list for field type_name
+	2,   // 1: WSMessage.auditLogListReq:type_name -> AuditLogListReq
+	3,   // 2: WSMessage.auditLogListResp:type_name -> AuditLogListResp
+	4,   // 3: WSMessage.autoQuantProfileDeleteReq:type_name -> AutoQuantProfileDeleteReq
+	5,   // 4: WSMessage.autoQuantProfileDeleteResp:type_name -> AutoQuantProfileDeleteResp
+	6,   // 5: WSMessage.autoQuantProfileListReq:type_name -> AutoQuantProfileListReq
+	7,   // 6: WSMessage.autoQuantProfileListResp:type_name -> AutoQuantProfileListResp
+	8,   // 7: WSMessage.autoQuantProfileWriteReq:type_name -> AutoQuantProfileWriteReq
+	9,   // 8: WSMessage.autoQuantProfileWriteResp:type_name -> AutoQuantProfileWriteResp
+	10,  // 9: WSMessage.backupDBReq:type_name -> BackupDBReq
+	11,  // 10: WSMessage.backupDBResp:type_name -> BackupDBResp
+	12,  // 11: WSMessage.backupDBUpd:type_name -> BackupDBUpd
+	13,  // 12: WSMessage.backupSetListReq:type_name -> BackupSetListReq
+	14,  // 13: WSMessage.backupSetListResp:type_name -> BackupSetListResp
+	15,  // 14: WSMessage.dBAdminConfigGetReq:type_name -> DBAdminConfigGetReq
+	16,  // 15: WSMessage.dBAdminConfigGetResp:type_name -> DBAdminConfigGetResp
+	17,  // 16: WSMessage.dataModuleAddVersionReq:type_name -> DataModuleAddVersionReq
+	18,  // 17: WSMessage.dataModuleAddVersionResp:type_name -> DataModuleAddVersionResp
+	19,  // 18: WSMessage.dataModuleGetReq:type_name -> DataModuleGetReq
+	20,  // 19: WSMessage.dataModuleGetResp:type_name -> DataModuleGetResp
+	21,  // 20: WSMessage.dataModuleListReq:type_name -> DataModuleListReq
+	22,  // 21: WSMessage.dataModuleListResp:type_name -> DataModuleListResp
+	23,  // 22: WSMessage.dataModuleWriteReq:type_name -> DataModuleWriteReq
+	24,  // 23: WSMessage.dataModuleWriteResp:type_name -> DataModuleWriteResp
+	25,  // 24: WSMessage.detectedDiffractionPeaksReq:type_name -> DetectedDiffractionPeaksReq
+	26,  // 25: WSMessage.detectedDiffractionPeaksResp:type_name -> DetectedDiffractionPeaksResp
+	27,  // 26: WSMessage.detectorConfigListReq:type_name -> DetectorConfigListReq
+	28,  // 27: WSMessage.detectorConfigListResp:type_name -> DetectorConfigListResp
+	29,  // 28: WSMessage.detectorConfigReq:type_name -> DetectorConfigReq
+	30,  // 29: WSMessage.detectorConfigResp:type_name -> DetectorConfigResp
+	31,  // 30: WSMessage.diffractionPeakManualDeleteReq:type_name -> DiffractionPeakManualDeleteReq
+	32,  // 31: WSMessage.diffractionPeakManualDeleteResp:type_name -> DiffractionPeakManualDeleteResp
+	33,  // 32: WSMessage.diffractionPeakManualInsertReq:type_name -> DiffractionPeakManualInsertReq
+	34,  // 33: WSMessage.diffractionPeakManualInsertResp:type_name -> DiffractionPeakManualInsertResp
+	35,  // 34: WSMessage.diffractionPeakManualListReq:type_name -> DiffractionPeakManualListReq
+	36,  // 35: WSMessage.diffractionPeakManualListResp:type_name -> DiffractionPeakManualListResp
+	37,  // 36: WSMessage.diffractionPeakStatusDeleteReq:type_name -> DiffractionPeakStatusDeleteReq
+	38,  // 37: WSMessage.diffractionPeakStatusDeleteResp:type_name -> DiffractionPeakStatusDeleteResp
+	39,  // 38: WSMessage.diffractionPeakStatusListReq:type_name -> DiffractionPeakStatusListReq
+	40,  // 39: WSMessage.diffractionPeakStatusListResp:type_name -> DiffractionPeakStatusListResp
+	41,  // 40: WSMessage.diffractionPeakStatusWriteReq:type_name -> DiffractionPeakStatusWriteReq
+	42,  // 41: WSMessage.diffractionPeakStatusWriteResp:type_name -> DiffractionPeakStatusWriteResp
+	43,  // 42: WSMessage.elementSetDeleteReq:type_name -> ElementSetDeleteReq
+	44,  // 43: WSMessage.elementSetDeleteResp:type_name -> ElementSetDeleteResp
+	45,  // 44: WSMessage.elementSetGetReq:type_name -> ElementSetGetReq
+	46,  // 45: WSMessage.elementSetGetResp:type_name -> ElementSetGetResp
+	47,  // 46: WSMessage.elementSetListReq:type_name -> ElementSetListReq
+	48,  // 47: WSMessage.elementSetListResp:type_name -> ElementSetListResp
+	49,  // 48: WSMessage.elementSetWriteReq:type_name -> ElementSetWriteReq
+	50,  // 49: WSMessage.elementSetWriteResp:type_name -> ElementSetWriteResp
+	51,  // 50: WSMessage.exportFilesReq:type_name -> ExportFilesReq
+	52,  // 51: WSMessage.exportFilesResp:type_name -> ExportFilesResp
+	53,  // 52: WSMessage.expressionBatchCalculateReq:type_name -> ExpressionBatchCalculateReq
+	54,  // 53: WSMessage.expressionBatchCalculateResp:type_name -> ExpressionBatchCalculateResp
+	55,  // 54: WSMessage.expressionCalculateReq:type_name -> ExpressionCalculateReq
+	56,  // 55: WSMessage.expressionCalculateResp:type_name -> ExpressionCalculateResp
+	57,  // 56: WSMessage.expressionDebugCommandReq:type_name -> ExpressionDebugCommandReq
+	58,  // 57: WSMessage.expressionDebugCommandResp:type_name -> ExpressionDebugCommandResp
+	59,  // 58: WSMessage.expressionDebugStartReq:type_name -> ExpressionDebugStartReq
+	60,  // 59: WSMessage.expressionDebugStartResp:type_name -> ExpressionDebugStartResp
+	61,  // 60: WSMessage.expressionDebugUpd:type_name -> ExpressionDebugUpd
+	62,  // 61: WSMessage.expressionDeleteReq:type_name -> ExpressionDeleteReq
+	63,  // 62: WSMessage.expressionDeleteResp:type_name -> ExpressionDeleteResp
+	64,  // 63: WSMessage.expressionDisplaySettingsGetReq:type_name -> ExpressionDisplaySettingsGetReq
+	65,  // 64: WSMessage.expressionDisplaySettingsGetResp:type_name -> ExpressionDisplaySettingsGetResp
+	66,  // 65: WSMessage.expressionDisplaySettingsWriteReq:type_name -> ExpressionDisplaySettingsWriteReq
+	67,  // 66: WSMessage.expressionDisplaySettingsWriteResp:type_name -> ExpressionDisplaySettingsWriteResp
+	68,  // 67: WSMessage.expressionGetReq:type_name -> ExpressionGetReq
+	69,  // 68: WSMessage.expressionGetResp:type_name -> ExpressionGetResp
+	70,  // 69: WSMessage.expressionGroupDeleteReq:type_name -> ExpressionGroupDeleteReq
+	71,  // 70: WSMessage.expressionGroupDeleteResp:type_name -> ExpressionGroupDeleteResp
+	72,  // 71: WSMessage.expressionGroupGetReq:type_name -> ExpressionGroupGetReq
+	73,  // 72: WSMessage.expressionGroupGetResp:type_name -> ExpressionGroupGetResp
+	74,  // 73: WSMessage.expressionGroupListReq:type_name -> ExpressionGroupListReq
+	75,  // 74: WSMessage.expressionGroupListResp:type_name -> ExpressionGroupListResp
+	76,  // 75: WSMessage.expressionGroupWriteReq:type_name -> ExpressionGroupWriteReq
+	77,  // 76: WSMessage.expressionGroupWriteResp:type_name -> ExpressionGroupWriteResp
+	78,  // 77: WSMessage.expressionListReq:type_name -> ExpressionListReq
+	79,  // 78: WSMessage.expressionListResp:type_name -> ExpressionListResp
+	80,  // 79: WSMessage.expressionWriteExecStatReq:type_name -> ExpressionWriteExecStatReq
+	81,  // 80: WSMessage.expressionWriteExecStatResp:type_name -> ExpressionWriteExecStatResp
+	82,  // 81: WSMessage.expressionWriteReq:type_name -> ExpressionWriteReq
+	83,  // 82: WSMessage.expressionWriteResp:type_name -> ExpressionWriteResp
+	84,  // 83: WSMessage.getOwnershipDescriptionReq:type_name -> GetOwnershipDescriptionReq
+	85,  // 84: WSMessage.getOwnershipDescriptionResp:type_name -> GetOwnershipDescriptionResp
+	86,  // 85: WSMessage.getOwnershipReq:type_name -> GetOwnershipReq
+	87,  // 86: WSMessage.getOwnershipResp:type_name -> GetOwnershipResp
+	88,  // 87: WSMessage.image3DModelPointUploadReq:type_name -> Image3DModelPointUploadReq
+	89,  // 88: WSMessage.image3DModelPointUploadResp:type_name -> Image3DModelPointUploadResp
+	90,  // 89: WSMessage.image3DModelPointsReq:type_name -> Image3DModelPointsReq
+	91,  // 90: WSMessage.image3DModelPointsResp:type_name -> Image3DModelPointsResp
+	92,  // 91: WSMessage.imageBeamLocationUploadReq:type_name -> ImageBeamLocationUploadReq
+	93,  // 92: WSMessage.imageBeamLocationUploadResp:type_name -> ImageBeamLocationUploadResp
+	94,  // 93: WSMessage.imageBeamLocationVersionsReq:type_name -> ImageBeamLocationVersionsReq
+	95,  // 94: WSMessage.imageBeamLocationVersionsResp:type_name -> ImageBeamLocationVersionsResp
+	96,  // 95: WSMessage.imageBeamLocationsReq:type_name -> ImageBeamLocationsReq
+	97,  // 96: WSMessage.imageBeamLocationsResp:type_name -> ImageBeamLocationsResp
+	98,  // 97: WSMessage.imageDeleteReq:type_name -> ImageDeleteReq
+	99,  // 98: WSMessage.imageDeleteResp:type_name -> ImageDeleteResp
+	100, // 99: WSMessage.imageGetDefaultReq:type_name -> ImageGetDefaultReq
+	101, // 100: WSMessage.imageGetDefaultResp:type_name -> ImageGetDefaultResp
+	102, // 101: WSMessage.imageGetReq:type_name -> ImageGetReq
+	103, // 102: WSMessage.imageGetResp:type_name -> ImageGetResp
+	104, // 103: WSMessage.imageListReq:type_name -> ImageListReq
+	105, // 104: WSMessage.imageListResp:type_name -> ImageListResp
+	106, // 105: WSMessage.imageListUpd:type_name -> ImageListUpd
+	107, // 106: WSMessage.imagePyramidGetReq:type_name -> ImagePyramidGetReq
+	108, // 107: WSMessage.imagePyramidGetResp:type_name -> ImagePyramidGetResp
+	109, // 108: WSMessage.imageScanEntryDisplayElementsGetReq:type_name -> ImageScanEntryDisplayElementsGetReq
+	110, // 109: WSMessage.imageScanEntryDisplayElementsGetResp:type_name -> ImageScanEntryDisplayElementsGetResp
+	111, // 110: WSMessage.imageSetDefaultReq:type_name -> ImageSetDefaultReq
+	112, // 111: WSMessage.imageSetDefaultResp:type_name -> ImageSetDefaultResp
+	113, // 112: WSMessage.imageSetMatchTransformReq:type_name -> ImageSetMatchTransformReq
+	114, // 113: WSMessage.imageSetMatchTransformResp:type_name -> ImageSetMatchTransformResp
+	115, // 114: WSMessage.imageTileDataGetReq:type_name -> ImageTileDataGetReq
+	116, // 115: WSMessage.imageTileDataGetResp:type_name -> ImageTileDataGetResp
+	117, // 116: WSMessage.imageTileStructureGetReq:type_name -> ImageTileStructureGetReq
+	118, // 117: WSMessage.imageTileStructureGetResp:type_name -> ImageTileStructureGetResp
+	119, // 118: WSMessage.importMarsViewerImageReq:type_name -> ImportMarsViewerImageReq
+	120, // 119: WSMessage.importMarsViewerImageResp:type_name -> ImportMarsViewerImageResp
+	121, // 120: WSMessage.importMarsViewerImageUpd:type_name -> ImportMarsViewerImageUpd
+	122, // 121: WSMessage.jobCancelReq:type_name -> JobCancelReq
+	123, // 122: WSMessage.jobCancelResp:type_name -> JobCancelResp
+	124, // 123: WSMessage.jobListReq:type_name -> JobListReq
+	125, // 124: WSMessage.jobListResp:type_name -> JobListResp
+	126, // 125: WSMessage.jobListUpd:type_name -> JobListUpd
+	127, // 126: WSMessage.logGetLevelReq:type_name -> LogGetLevelReq
+	128, // 127: WSMessage.logGetLevelResp:type_name -> LogGetLevelResp
+	129, // 128: WSMessage.logReadReq:type_name -> LogReadReq
+	130, // 129: WSMessage.logReadResp:type_name -> LogReadResp
+	131, // 130: WSMessage.logSetLevelReq:type_name -> LogSetLevelReq
+	132, // 131: WSMessage.logSetLevelResp:type_name -> LogSetLevelResp
+	133, // 132: WSMessage.memoiseDeleteByRegexReq:type_name -> MemoiseDeleteByRegexReq
+	134, // 133: WSMessage.memoiseDeleteByRegexResp:type_name -> MemoiseDeleteByRegexResp
+	135, // 134: WSMessage.memoiseDeleteReq:type_name -> MemoiseDeleteReq
+	136, // 135: WSMessage.memoiseDeleteResp:type_name -> MemoiseDeleteResp
+	137, // 136: WSMessage.multiQuantCompareReq:type_name -> MultiQuantCompareReq
+	138, // 137: WSMessage.multiQuantCompareResp:type_name -> MultiQuantCompareResp
+	139, // 138: WSMessage.notificationDismissReq:type_name -> NotificationDismissReq
+	140, // 139: WSMessage.notificationDismissResp:type_name -> NotificationDismissResp
+	141, // 140: WSMessage.notificationReq:type_name -> NotificationReq
+	142, // 141: WSMessage.notificationResp:type_name -> NotificationResp
+	143, // 142: WSMessage.notificationUpd:type_name -> NotificationUpd
+	144, // 143: WSMessage.objectEditAccessReq:type_name -> ObjectEditAccessReq
+	145, // 144: WSMessage.objectEditAccessResp:type_name -> ObjectEditAccessResp
+	146, // 145: WSMessage.objectRevisionGetReq:type_name -> ObjectRevisionGetReq
+	147, // 146: WSMessage.objectRevisionGetResp:type_name -> ObjectRevisionGetResp
+	148, // 147: WSMessage.objectRevisionListReq:type_name -> ObjectRevisionListReq
+	149, // 148: WSMessage.objectRevisionListResp:type_name -> ObjectRevisionListResp
+	150, // 149: WSMessage.objectRevisionRestoreReq:type_name -> ObjectRevisionRestoreReq
+	151, // 150: WSMessage.objectRevisionRestoreResp:type_name -> ObjectRevisionRestoreResp
+	152, // 151: WSMessage.piquantConfigFileReq:type_name -> PiquantConfigFileReq
+	153, // 152: WSMessage.piquantConfigFileResp:type_name -> PiquantConfigFileResp
+	154, // 153: WSMessage.piquantConfigListReq:type_name -> PiquantConfigListReq
+	155, // 154: WSMessage.piquantConfigListResp:type_name -> PiquantConfigListResp
+	156, // 155: WSMessage.piquantConfigVersionReq:type_name -> PiquantConfigVersionReq
+	157, // 156: WSMessage.piquantConfigVersionResp:type_name -> PiquantConfigVersionResp
+	158, // 157: WSMessage.piquantConfigVersionsListReq:type_name -> PiquantConfigVersionsListReq
+	159, // 158: WSMessage.piquantConfigVersionsListResp:type_name -> PiquantConfigVersionsListResp
+	160, // 159: WSMessage.piquantCurrentVersionReq:type_name -> PiquantCurrentVersionReq
+	161, // 160: WSMessage.piquantCurrentVersionResp:type_name -> PiquantCurrentVersionResp
+	162, // 161: WSMessage.piquantRegressionReq:type_name -> PiquantRegressionReq
+	163, // 162: WSMessage.piquantRegressionResp:type_name -> PiquantRegressionResp
+	164, // 163: WSMessage.piquantRegressionResultReq:type_name -> PiquantRegressionResultReq
+	165, // 164: WSMessage.piquantRegressionResultResp:type_name -> PiquantRegressionResultResp
+	166, // 165: WSMessage.piquantRegressionUpd:type_name -> PiquantRegressionUpd
+	167, // 166: WSMessage.piquantVersionListReq:type_name -> PiquantVersionListReq
+	168, // 167: WSMessage.piquantVersionListResp:type_name -> PiquantVersionListResp
+	169, // 168: WSMessage.piquantWriteCurrentVersionReq:type_name -> PiquantWriteCurrentVersionReq
+	170, // 169: WSMessage.piquantWriteCurrentVersionResp:type_name -> PiquantWriteCurrentVersionResp
+	171, // 170: WSMessage.pseudoIntensityReq:type_name -> PseudoIntensityReq
+	172, // 171: WSMessage.pseudoIntensityResp:type_name -> PseudoIntensityResp
+	173, // 172: WSMessage.publishExpressionToZenodoReq:type_name -> PublishExpressionToZenodoReq
+	174, // 173: WSMessage.publishExpressionToZenodoResp:type_name -> PublishExpressionToZenodoResp
+	175, // 174: WSMessage.quantBlessReq:type_name -> QuantBlessReq
+	176, // 175: WSMessage.quantBlessResp:type_name -> QuantBlessResp
+	177, // 176: WSMessage.quantCombineListGetReq:type_name -> QuantCombineListGetReq
+	178, // 177: WSMessage.quantCombineListGetResp:type_name -> QuantCombineListGetResp
+	179, // 178: WSMessage.quantCombineListWriteReq:type_name -> QuantCombineListWriteReq
+	180, // 179: WSMessage.quantCombineListWriteResp:type_name -> QuantCombineListWriteResp
+	181, // 180: WSMessage.quantCombineReq:type_name -> QuantCombineReq
+	182, // 181: WSMessage.quantCombineResp:type_name -> QuantCombineResp
+	183, // 182: WSMessage.quantCreateReq:type_name -> QuantCreateReq
+	184, // 183: WSMessage.quantCreateResp:type_name -> QuantCreateResp
+	185, // 184: WSMessage.quantCreateUpd:type_name -> QuantCreateUpd
+	186, // 185: WSMessage.quantDiffReq:type_name -> QuantDiffReq
+	187, // 186: WSMessage.quantDiffResp:type_name -> QuantDiffResp
+	188, // 187: WSMessage.quantDeleteReq:type_name -> QuantDeleteReq
+	189, // 188: WSMessage.quantDeleteResp:type_name -> QuantDeleteResp
+	190, // 189: WSMessage.quantGetReq:type_name -> QuantGetReq
+	191, // 190: WSMessage.quantGetResp:type_name -> QuantGetResp
+	192, // 191: WSMessage.quantLastOutputGetReq:type_name -> QuantLastOutputGetReq
+	193, // 192: WSMessage.quantLastOutputGetResp:type_name -> QuantLastOutputGetResp
+	194, // 193: WSMessage.quantListReq:type_name -> QuantListReq
+	195, // 194: WSMessage.quantListResp:type_name -> QuantListResp
+	196, // 195: WSMessage.quantLogGetReq:type_name -> QuantLogGetReq
+	197, // 196: WSMessage.quantLogGetResp:type_name -> QuantLogGetResp
+	198, // 197: WSMessage.quantLogListReq:type_name -> QuantLogListReq
+	199, // 198: WSMessage.quantLogListResp:type_name -> QuantLogListResp
+	200, // 199: WSMessage.quantPublishReq:type_name -> QuantPublishReq
+	201, // 200: WSMessage.quantPublishResp:type_name -> QuantPublishResp
+	202, // 201: WSMessage.quantRawDataGetReq:type_name -> QuantRawDataGetReq
+	203, // 202: WSMessage.quantRawDataGetResp:type_name -> QuantRawDataGetResp
+	204, // 203: WSMessage.quantUploadReq:type_name -> QuantUploadReq
+	205, // 204: WSMessage.quantUploadResp:type_name -> QuantUploadResp
+	206, // 205: WSMessage.referenceDataBulkWriteReq:type_name -> ReferenceDataBulkWriteReq
+	207, // 206: WSMessage.referenceDataBulkWriteResp:type_name -> ReferenceDataBulkWriteResp
+	208, // 207: WSMessage.referenceDataDeleteReq:type_name -> ReferenceDataDeleteReq
+	209, // 208: WSMessage.referenceDataDeleteResp:type_name -> ReferenceDataDeleteResp
+	210, // 209: WSMessage.referenceDataGetReq:type_name -> ReferenceDataGetReq
+	211, // 210: WSMessage.referenceDataGetResp:type_name -> ReferenceDataGetResp
+	212, // 211: WSMessage.referenceDataListReq:type_name -> ReferenceDataListReq
+	213, // 212: WSMessage.referenceDataListResp:type_name -> ReferenceDataListResp
+	214, // 213: WSMessage.referenceDataWriteReq:type_name -> ReferenceDataWriteReq
+	215, // 214: WSMessage.referenceDataWriteResp:type_name -> ReferenceDataWriteResp
+	216, // 215: WSMessage.regionOfInterestBulkDuplicateReq:type_name -> RegionOfInterestBulkDuplicateReq
+	217, // 216: WSMessage.regionOfInterestBulkDuplicateResp:type_name -> RegionOfInterestBulkDuplicateResp
+	218, // 217: WSMessage.regionOfInterestBulkWriteReq:type_name -> RegionOfInterestBulkWriteReq
+	219, // 218: WSMessage.regionOfInterestBulkWriteResp:type_name -> RegionOfInterestBulkWriteResp
+	220, // 219: WSMessage.regionOfInterestDeleteReq:type_name -> RegionOfInterestDeleteReq
+	221, // 220: WSMessage.regionOfInterestDeleteResp:type_name -> RegionOfInterestDeleteResp
+	222, // 221: WSMessage.regionOfInterestDisplaySettingsGetReq:type_name -> RegionOfInterestDisplaySettingsGetReq
+	223, // 222: WSMessage.regionOfInterestDisplaySettingsGetResp:type_name -> RegionOfInterestDisplaySettingsGetResp
+	224, // 223: WSMessage.regionOfInterestDisplaySettingsWriteReq:type_name -> RegionOfInterestDisplaySettingsWriteReq
+	225, // 224: WSMessage.regionOfInterestDisplaySettingsWriteResp:type_name -> RegionOfInterestDisplaySettingsWriteResp
+	226, // 225: WSMessage.regionOfInterestGetReq:type_name -> RegionOfInterestGetReq
+	227, // 226: WSMessage.regionOfInterestGetResp:type_name -> RegionOfInterestGetResp
+	228, // 227: WSMessage.regionOfInterestListReq:type_name -> RegionOfInterestListReq
+	229, // 228: WSMessage.regionOfInterestListResp:type_name -> RegionOfInterestListResp
+	230, // 229: WSMessage.regionOfInterestWriteReq:type_name -> RegionOfInterestWriteReq
+	231, // 230: WSMessage.regionOfInterestWriteResp:type_name -> RegionOfInterestWriteResp
+	232, // 231: WSMessage.restoreDBReq:type_name -> RestoreDBReq
+	233, // 232: WSMessage.restoreDBResp:type_name -> RestoreDBResp
+	234, // 233: WSMessage.reviewerMagicLinkCreateReq:type_name -> ReviewerMagicLinkCreateReq
+	235, // 234: WSMessage.reviewerMagicLinkCreateResp:type_name -> ReviewerMagicLinkCreateResp
+	236, // 235: WSMessage.reviewerMagicLinkLoginReq:type_name -> ReviewerMagicLinkLoginReq
+	237, // 236: WSMessage.reviewerMagicLinkLoginResp:type_name -> ReviewerMagicLinkLoginResp
+	238, // 237: WSMessage.runTestReq:type_name -> RunTestReq
+	239, // 238: WSMessage.runTestResp:type_name -> RunTestResp
+	240, // 239: WSMessage.scanAutoShareReq:type_name -> ScanAutoShareReq
+	241, // 240: WSMessage.scanAutoShareResp:type_name -> ScanAutoShareResp
+	242, // 241: WSMessage.scanAutoShareWriteReq:type_name -> ScanAutoShareWriteReq
+	243, // 242: WSMessage.scanAutoShareWriteResp:type_name -> ScanAutoShareWriteResp
+	244, // 243: WSMessage.scanBeamLocationsReq:type_name -> ScanBeamLocationsReq
+	245, // 244: WSMessage.scanBeamLocationsResp:type_name -> ScanBeamLocationsResp
+	246, // 245: WSMessage.scanCreateUserDefinedReq:type_name -> ScanCreateUserDefinedReq
+	247, // 246: WSMessage.scanCreateUserDefinedResp:type_name -> ScanCreateUserDefinedResp
+	248, // 247: WSMessage.scanDeleteReq:type_name -> ScanDeleteReq
+	249, // 248: WSMessage.scanDeleteResp:type_name -> ScanDeleteResp
+	250, // 249: WSMessage.scanEntryMetadataReq:type_name -> ScanEntryMetadataReq
+	251, // 250: WSMessage.scanEntryMetadataResp:type_name -> ScanEntryMetadataResp
+	252, // 251: WSMessage.scanEntryReq:type_name -> ScanEntryReq
+	253, // 252: WSMessage.scanEntryResp:type_name -> ScanEntryResp
+	254, // 253: WSMessage.scanGetReq:type_name -> ScanGetReq
+	255, // 254: WSMessage.scanGetResp:type_name -> ScanGetResp
+	256, // 255: WSMessage.scanListJobsReq:type_name -> ScanListJobsReq
+	257, // 256: WSMessage.scanListJobsResp:type_name -> ScanListJobsResp
+	258, // 257: WSMessage.scanListReq:type_name -> ScanListReq
+	259, // 258: WSMessage.scanListResp:type_name -> ScanListResp
+	260, // 259: WSMessage.scanListUpd:type_name -> ScanListUpd
+	261, // 260: WSMessage.scanMetaLabelsAndTypesReq:type_name -> ScanMetaLabelsAndTypesReq
+	262, // 261: WSMessage.scanMetaLabelsAndTypesResp:type_name -> ScanMetaLabelsAndTypesResp
+	263, // 262: WSMessage.scanMetaWriteReq:type_name -> ScanMetaWriteReq
+	264, // 263: WSMessage.scanMetaWriteResp:type_name -> ScanMetaWriteResp
+	265, // 264: WSMessage.scanTriggerJobReq:type_name -> ScanTriggerJobReq
+	266, // 265: WSMessage.scanTriggerJobResp:type_name -> ScanTriggerJobResp
+	267, // 266: WSMessage.scanTriggerReImportReq:type_name -> ScanTriggerReImportReq
+	268, // 267: WSMessage.scanTriggerReImportResp:type_name -> ScanTriggerReImportResp
+	269, // 268: WSMessage.scanTriggerReImportUpd:type_name -> ScanTriggerReImportUpd
+	270, // 269: WSMessage.scanUploadReq:type_name -> ScanUploadReq
+	271, // 270: WSMessage.scanUploadResp:type_name -> ScanUploadResp
+	272, // 271: WSMessage.scanUploadUpd:type_name -> ScanUploadUpd
+	273, // 272: WSMessage.scanWriteJobReq:type_name -> ScanWriteJobReq
+	274, // 273: WSMessage.scanWriteJobResp:type_name -> ScanWriteJobResp
+	275, // 274: WSMessage.screenConfigurationDeleteReq:type_name -> ScreenConfigurationDeleteReq
+	276, // 275: WSMessage.screenConfigurationDeleteResp:type_name -> ScreenConfigurationDeleteResp
+	277, // 276: WSMessage.screenConfigurationGetReq:type_name -> ScreenConfigurationGetReq
+	278, // 277: WSMessage.screenConfigurationGetResp:type_name -> ScreenConfigurationGetResp
+	279, // 278: WSMessage.screenConfigurationListReq:type_name -> ScreenConfigurationListReq
+	280, // 279: WSMessage.screenConfigurationListResp:type_name -> ScreenConfigurationListResp
+	281, // 280: WSMessage.screenConfigurationWriteReq:type_name -> ScreenConfigurationWriteReq
+	282, // 281: WSMessage.screenConfigurationWriteResp:type_name -> ScreenConfigurationWriteResp
+	283, // 282: WSMessage.selectedImagePixelsReq:type_name -> SelectedImagePixelsReq
+	284, // 283: WSMessage.selectedImagePixelsResp:type_name -> SelectedImagePixelsResp
+	285, // 284: WSMessage.selectedImagePixelsWriteReq:type_name -> SelectedImagePixelsWriteReq
+	286, // 285: WSMessage.selectedImagePixelsWriteResp:type_name -> SelectedImagePixelsWriteResp
+	287, // 286: WSMessage.selectedScanEntriesReq:type_name -> SelectedScanEntriesReq
+	288, // 287: WSMessage.selectedScanEntriesResp:type_name -> SelectedScanEntriesResp
+	289, // 288: WSMessage.selectedScanEntriesWriteReq:type_name -> SelectedScanEntriesWriteReq
+	290, // 289: WSMessage.selectedScanEntriesWriteResp:type_name -> SelectedScanEntriesWriteResp
+	291, // 290: WSMessage.sendUserNotificationReq:type_name -> SendUserNotificationReq
+	292, // 291: WSMessage.sendUserNotificationResp:type_name -> SendUserNotificationResp
+	293, // 292: WSMessage.spectrumReq:type_name -> SpectrumReq
+	294, // 293: WSMessage.spectrumResp:type_name -> SpectrumResp
+	295, // 294: WSMessage.tagCreateReq:type_name -> TagCreateReq
+	296, // 295: WSMessage.tagCreateResp:type_name -> TagCreateResp
+	297, // 296: WSMessage.tagDeleteReq:type_name -> TagDeleteReq
+	298, // 297: WSMessage.tagDeleteResp:type_name -> TagDeleteResp
+	299, // 298: WSMessage.tagListReq:type_name -> TagListReq
+	300, // 299: WSMessage.tagListResp:type_name -> TagListResp
+	301, // 300: WSMessage.userAddRoleReq:type_name -> UserAddRoleReq
+	302, // 301: WSMessage.userAddRoleResp:type_name -> UserAddRoleResp
+	303, // 302: WSMessage.userDeleteRoleReq:type_name -> UserDeleteRoleReq
+	304, // 303: WSMessage.userDeleteRoleResp:type_name -> UserDeleteRoleResp
+	305, // 304: WSMessage.userDetailsReq:type_name -> UserDetailsReq
+	306, // 305: WSMessage.userDetailsResp:type_name -> UserDetailsResp
+	307, // 306: WSMessage.userDetailsWriteReq:type_name -> UserDetailsWriteReq
+	308, // 307: WSMessage.userDetailsWriteResp:type_name -> UserDetailsWriteResp
+	309, // 308: WSMessage.userGroupAddAdminReq:type_name -> UserGroupAddAdminReq
+	310, // 309: WSMessage.userGroupAddAdminResp:type_name -> UserGroupAddAdminResp
+	311, // 310: WSMessage.userGroupAddMemberReq:type_name -> UserGroupAddMemberReq
+	312, // 311: WSMessage.userGroupAddMemberResp:type_name -> UserGroupAddMemberResp
+	313, // 312: WSMessage.userGroupAddViewerReq:type_name -> UserGroupAddViewerReq
+	314, // 313: WSMessage.userGroupAddViewerResp:type_name -> UserGroupAddViewerResp
+	315, // 314: WSMessage.userGroupCreateReq:type_name -> UserGroupCreateReq
+	316, // 315: WSMessage.userGroupCreateResp:type_name -> UserGroupCreateResp
+	317, // 316: WSMessage.userGroupDeleteAdminReq:type_name -> UserGroupDeleteAdminReq
+	318, // 317: WSMessage.userGroupDeleteAdminResp:type_name -> UserGroupDeleteAdminResp
+	319, // 318: WSMessage.userGroupDeleteMemberReq:type_name -> UserGroupDeleteMemberReq
+	320, // 319: WSMessage.userGroupDeleteMemberResp:type_name -> UserGroupDeleteMemberResp
+	321, // 320: WSMessage.userGroupDeleteReq:type_name -> UserGroupDeleteReq
+	322, // 321: WSMessage.userGroupDeleteResp:type_name -> UserGroupDeleteResp
+	323, // 322: WSMessage.userGroupDeleteViewerReq:type_name -> UserGroupDeleteViewerReq
+	324, // 323: WSMessage.userGroupDeleteViewerResp:type_name -> UserGroupDeleteViewerResp
+	325, // 324: WSMessage.userGroupEditDetailsReq:type_name -> UserGroupEditDetailsReq
+	326, // 325: WSMessage.userGroupEditDetailsResp:type_name -> UserGroupEditDetailsResp
+	327, // 326: WSMessage.userGroupIgnoreJoinReq:type_name -> UserGroupIgnoreJoinReq
+	328, // 327: WSMessage.userGroupIgnoreJoinResp:type_name -> UserGroupIgnoreJoinResp
+	329, // 328: WSMessage.userGroupJoinListReq:type_name -> UserGroupJoinListReq
+	330, // 329: WSMessage.userGroupJoinListResp:type_name -> UserGroupJoinListResp
+	331, // 330: WSMessage.userGroupJoinReq:type_name -> UserGroupJoinReq
+	332, // 331: WSMessage.userGroupJoinResp:type_name -> UserGroupJoinResp
+	333, // 332: WSMessage.userGroupListJoinableReq:type_name -> UserGroupListJoinableReq
+	334, // 333: WSMessage.userGroupListJoinableResp:type_name -> UserGroupListJoinableResp
+	335, // 334: WSMessage.userGroupListReq:type_name -> UserGroupListReq
+	336, // 335: WSMessage.userGroupListResp:type_name -> UserGroupListResp
+	337, // 336: WSMessage.userGroupReq:type_name -> UserGroupReq
+	338, // 337: WSMessage.userGroupResp:type_name -> UserGroupResp
+	339, // 338: WSMessage.userImpersonateGetReq:type_name -> UserImpersonateGetReq
+	340, // 339: WSMessage.userImpersonateGetResp:type_name -> UserImpersonateGetResp
+	341, // 340: WSMessage.userImpersonateReq:type_name -> UserImpersonateReq
+	342, // 341: WSMessage.userImpersonateResp:type_name -> UserImpersonateResp
+	343, // 342: WSMessage.userListReq:type_name -> UserListReq
+	344, // 343: WSMessage.userListResp:type_name -> UserListResp
+	345, // 344: WSMessage.userNotificationSettingsReq:type_name -> UserNotificationSettingsReq
+	346, // 345: WSMessage.userNotificationSettingsResp:type_name -> UserNotificationSettingsResp
+	347, // 346: WSMessage.userNotificationSettingsUpd:type_name -> UserNotificationSettingsUpd
+	348, // 347: WSMessage.userNotificationSettingsWriteReq:type_name -> UserNotificationSettingsWriteReq
+	349, // 348: WSMessage.userNotificationSettingsWriteResp:type_name -> UserNotificationSettingsWriteResp
+	350, // 349: WSMessage.userRoleListReq:type_name -> UserRoleListReq
+	351, // 350: WSMessage.userRoleListResp:type_name -> UserRoleListResp
+	352, // 351: WSMessage.userRolesListReq:type_name -> UserRolesListReq
+	353, // 352: WSMessage.userRolesListResp:type_name -> UserRolesListResp
+	354, // 353: WSMessage.userSearchReq:type_name -> UserSearchReq
+	355, // 354: WSMessage.userSearchResp:type_name -> UserSearchResp
+	356, // 355: WSMessage.widgetDataGetReq:type_name -> WidgetDataGetReq
+	357, // 356: WSMessage.widgetDataGetResp:type_name -> WidgetDataGetResp
+	358, // 357: WSMessage.widgetDataWriteReq:type_name -> WidgetDataWriteReq
+	359, // 358: WSMessage.widgetDataWriteResp:type_name -> WidgetDataWriteResp
+	360, // 359: WSMessage.widgetMetadataGetReq:type_name -> WidgetMetadataGetReq
+	361, // 360: WSMessage.widgetMetadataGetResp:type_name -> WidgetMetadataGetResp
+	362, // 361: WSMessage.widgetMetadataWriteReq:type_name -> WidgetMetadataWriteReq
+	363, // 362: WSMessage.widgetMetadataWriteResp:type_name -> WidgetMetadataWriteResp
+	364, // 363: WSMessage.zenodoDOIGetReq:type_name -> ZenodoDOIGetReq
+	365, // 364: WSMessage.zenodoDOIGetResp:type_name -> ZenodoDOIGetResp
+	365, // [365:365] is the sub-list for method output_type
+	365, // [365:365] is the sub-list for method input_type
+	365, // [365:365] is the sub-list for extension type_name
+	365, // [365:365] is the sub-list for extension extendee
+	0,   // [0:365] is the sub-list for field type_name
 }
 
 func init() { file_websocket_proto_init() }
@@ -6544,6 +7152,8 @@ func file_websocket_proto_init() {
 	if File_websocket_proto != nil {
 		return
 	}
+	file_audit_msgs_proto_init()
+	file_auto_quant_profile_msgs_proto_init()
 	file_detector_config_msgs_proto_init()
 	file_diffraction_detected_peak_msgs_proto_init()
 	file_diffraction_manual_msgs_proto_init()
@@ -6553,6 +7163,7 @@ func file_websocket_proto_init() {
 	file_expression_group_msgs_proto_init()
 	file_expression_msgs_proto_init()
 	file_expression_calculate_msgs_proto_init()
+	file_expression_debug_msgs_proto_init()
 	file_image_3d_model_point_msgs_proto_init()
 	file_image_beam_location_msgs_proto_init()
 	file_image_msgs_proto_init()
@@ -6564,12 +7175,14 @@ func file_websocket_proto_init() {
 	file_module_msgs_proto_init()
 	file_ownership_access_msgs_proto_init()
 	file_piquant_msgs_proto_init()
+	file_piquant_regression_msgs_proto_init()
 	file_pseudo_intensities_msgs_proto_init()
 	file_quantification_create_proto_init()
 	file_quantification_management_msgs_proto_init()
 	file_quantification_multi_msgs_proto_init()
 	file_quantification_retrieval_msgs_proto_init()
 	file_quantification_upload_msgs_proto_init()
+	file_revision_msgs_proto_init()
 	file_roi_msgs_proto_init()
 	file_scan_beam_location_msgs_proto_init()
 	file_scan_entry_metadata_msgs_proto_init()
@@ -6596,9 +7209,19 @@ func file_websocket_proto_init() {
 	file_system_proto_init()
 	file_references_msgs_proto_init()
 	file_websocket_proto_msgTypes[0].OneofWrappers = []any{
+		(*WSMessage_AuditLogListReq)(nil),
+		(*WSMessage_AuditLogListResp)(nil),
+		(*WSMessage_AutoQuantProfileDeleteReq)(nil),
+		(*WSMessage_AutoQuantProfileDeleteResp)(nil),
+		(*WSMessage_AutoQuantProfileListReq)(nil),
+		(*WSMessage_AutoQuantProfileListResp)(nil),
+		(*WSMessage_AutoQuantProfileWriteReq)(nil),
+		(*WSMessage_AutoQuantProfileWriteResp)(nil),
 		(*WSMessage_BackupDBReq)(nil),
 		(*WSMessage_BackupDBResp)(nil),
 		(*WSMessage_BackupDBUpd)(nil),
+		(*WSMessage_BackupSetListReq)(nil),
+		(*WSMessage_BackupSetListResp)(nil),
 		(*WSMessage_DBAdminConfigGetReq)(nil),
 		(*WSMessage_DBAdminConfigGetResp)(nil),
 		(*WSMessage_DataModuleAddVersionReq)(nil),
@@ -6637,8 +7260,15 @@ func file_websocket_proto_init() {
 		(*WSMessage_ElementSetWriteResp)(nil),
 		(*WSMessage_ExportFilesReq)(nil),
 		(*WSMessage_ExportFilesResp)(nil),
+		(*WSMessage_ExpressionBatchCalculateReq)(nil),
+		(*WSMessage_ExpressionBatchCalculateResp)(nil),
 		(*WSMessage_ExpressionCalculateReq)(nil),
 		(*WSMessage_ExpressionCalculateResp)(nil),
+		(*WSMessage_ExpressionDebugCommandReq)(nil),
+		(*WSMessage_ExpressionDebugCommandResp)(nil),
+		(*WSMessage_ExpressionDebugStartReq)(nil),
+		(*WSMessage_ExpressionDebugStartResp)(nil),
+		(*WSMessage_ExpressionDebugUpd)(nil),
 		(*WSMessage_ExpressionDeleteReq)(nil),
 		(*WSMessage_ExpressionDeleteResp)(nil),
 		(*WSMessage_ExpressionDisplaySettingsGetReq)(nil),
@@ -6699,6 +7329,8 @@ func file_websocket_proto_init() {
 		(*WSMessage_ImportMarsViewerImageReq)(nil),
 		(*WSMessage_ImportMarsViewerImageResp)(nil),
 		(*WSMessage_ImportMarsViewerImageUpd)(nil),
+		(*WSMessage_JobCancelReq)(nil),
+		(*WSMessage_JobCancelResp)(nil),
 		(*WSMessage_JobListReq)(nil),
 		(*WSMessage_JobListResp)(nil),
 		(*WSMessage_JobListUpd)(nil),
@@ -6721,6 +7353,12 @@ func file_websocket_proto_init() {
 		(*WSMessage_NotificationUpd)(nil),
 		(*WSMessage_ObjectEditAccessReq)(nil),
 		(*WSMessage_ObjectEditAccessResp)(nil),
+		(*WSMessage_ObjectRevisionGetReq)(nil),
+		(*WSMessage_ObjectRevisionGetResp)(nil),
+		(*WSMessage_ObjectRevisionListReq)(nil),
+		(*WSMessage_ObjectRevisionListResp)(nil),
+		(*WSMessage_ObjectRevisionRestoreReq)(nil),
+		(*WSMessage_ObjectRevisionRestoreResp)(nil),
 		(*WSMessage_PiquantConfigFileReq)(nil),
 		(*WSMessage_PiquantConfigFileResp)(nil),
 		(*WSMessage_PiquantConfigListReq)(nil),
@@ -6731,6 +7369,11 @@ func file_websocket_proto_init() {
 		(*WSMessage_PiquantConfigVersionsListResp)(nil),
 		(*WSMessage_PiquantCurrentVersionReq)(nil),
 		(*WSMessage_PiquantCurrentVersionResp)(nil),
+		(*WSMessage_PiquantRegressionReq)(nil),
+		(*WSMessage_PiquantRegressionResp)(nil),
+		(*WSMessage_PiquantRegressionResultReq)(nil),
+		(*WSMessage_PiquantRegressionResultResp)(nil),
+		(*WSMessage_PiquantRegressionUpd)(nil),
 		(*WSMessage_PiquantVersionListReq)(nil),
 		(*WSMessage_PiquantVersionListResp)(nil),
 		(*WSMessage_PiquantWriteCurrentVersionReq)(nil),
@@ -6750,6 +7393,8 @@ func file_websocket_proto_init() {
 		(*WSMessage_QuantCreateReq)(nil),
 		(*WSMessage_QuantCreateResp)(nil),
 		(*WSMessage_QuantCreateUpd)(nil),
+		(*WSMessage_QuantDiffReq)(nil),
+		(*WSMessage_QuantDiffResp)(nil),
 		(*WSMessage_QuantDeleteReq)(nil),
 		(*WSMessage_QuantDeleteResp)(nil),
 		(*WSMessage_QuantGetReq)(nil),
@@ -8,6 +8,8 @@ require (
 	github.com/auth0-community/go-auth0 v1.0.0
 	github.com/aws/aws-lambda-go v1.36.0
 	github.com/aws/aws-sdk-go v1.53.11
+	github.com/aws/aws-sdk-go-v2/config v1.32.21
+	github.com/aws/aws-sdk-go-v2/feature/ec2/imds v1.18.26
 	github.com/aws/aws-secretsmanager-caching-go v1.1.0
 	github.com/engelsjk/polygol v0.0.3
 	github.com/getsentry/sentry-go v0.16.0
@@ -20,10 +22,12 @@ require (
 	github.com/pixlise/diffraction-peak-detection/v2 v2.0.3
 	github.com/pkg/errors v0.9.1
 	github.com/prometheus/client_golang v1.15.1
+	github.com/yuin/gopher-lua v1.1.2
 	go.mongodb.org/mongo-driver v1.16.0
 	golang.org/x/exp v0.0.0-20240529005216-23cca8864a10
 	golang.org/x/image v0.9.0
 	golang.org/x/sys v0.26.0
+	golang.org/x/text v0.16.0
 	google.golang.org/protobuf v1.36.6
 	gopkg.in/auth0.v4 v4.7.0
 	gopkg.in/square/go-jose.v2 v2.6.0
@@ -36,9 +40,7 @@ require (
 	github.com/PuerkitoBio/rehttp v1.1.0 // indirect
 	github.com/Shopify/go-lua v0.0.0-20250718183320-1e37f32ad7d0 // indirect
 	github.com/aws/aws-sdk-go-v2 v1.41.10 // indirect
-	github.com/aws/aws-sdk-go-v2/config v1.32.21 // indirect
 	github.com/aws/aws-sdk-go-v2/credentials v1.19.20 // indirect
-	github.com/aws/aws-sdk-go-v2/feature/ec2/imds v1.18.26 // indirect
 	github.com/aws/aws-sdk-go-v2/internal/configsources v1.4.26 // indirect
 	github.com/aws/aws-sdk-go-v2/internal/endpoints/v2 v2.7.26 // indirect
 	github.com/aws/aws-sdk-go-v2/internal/v4a v1.4.27 // indirect
@@ -87,13 +89,11 @@ require (
 	github.com/xdg-go/scram v1.1.2 // indirect
 	github.com/xdg-go/stringprep v1.0.4 // indirect
 	github.com/youmark/pkcs8 v0.0.0-20201027041543-1326539a0a0a // indirect
-	github.com/yuin/gopher-lua v1.1.2 // indirect
 	golang.org/x/crypto v0.25.0 // indirect
 	golang.org/x/net v0.25.0 // indirect
 	golang.org/x/oauth2 v0.8.0 // indirect
 	golang.org/x/sync v0.7.0 // indirect
 	golang.org/x/term v0.22.0 // indirect
-	golang.org/x/text v0.16.0 // indirect
 	golang.org/x/time v0.3.0 // indirect
 	google.golang.org/appengine v1.6.7 // indirect
 	gopkg.in/inf.v0 v0.9.1 // indirect
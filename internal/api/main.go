@@ -2,13 +2,17 @@ package main
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
 	"strings"
 	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
 	"github.com/aws/aws-sdk-go/service/secretsmanager"
 	"github.com/aws/aws-sdk-go/service/sns"
 	"github.com/aws/aws-sdk-go/service/sqs"
@@ -179,6 +183,7 @@ func main() {
 	authware := endpoints.AuthMiddleWareData{
 		RoutePermissionsRequired: routePermissions,
 		JWTValidator:             jwtValidator,
+		PermissionClaims:         svcs.JWTReader.GetClaimNames().Permissions,
 		Logger:                   svcs.Log,
 	}
 	logware := endpoints.LoggerMiddleware{
@@ -228,6 +233,81 @@ func loadConfig() config.APIConfig {
 	return cfg
 }
 
+// Creates the file access for reading/writing our buckets, depending on which storage backend is configured
+func makeFileAccess(cfg *config.APIConfig, sess *session.Session) (s3iface.S3API, fileaccess.FileAccess, error) {
+	switch cfg.StorageBackend {
+	case "local":
+		if len(cfg.LocalStorageRoot) <= 0 {
+			return nil, nil, errors.New("LocalStorageRoot must be set when using local storage backend")
+		}
+
+		// Still return an S3 service, but nothing should be reading buckets through it
+		s3svc, err := awsutil.GetS3(sess)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		log.Printf("Using local storage backend at: %v", cfg.LocalStorageRoot)
+		return s3svc, fileaccess.MakeFSAccessS3Simulator(cfg.LocalStorageRoot), nil
+	case "", "s3":
+		s3Sess := sess
+		if len(cfg.S3Endpoint) > 0 {
+			var err error
+			s3Sess, err = awsutil.GetSessionWithS3Endpoint(aws.StringValue(sess.Config.Region), cfg.S3Endpoint)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			log.Printf("Using S3 endpoint: %v", cfg.S3Endpoint)
+		}
+
+		s3svc, err := awsutil.GetS3(s3Sess)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return s3svc, fileaccess.MakeS3Access(s3svc), nil
+	}
+
+	return nil, nil, fmt.Errorf("Unknown storage backend: %v", cfg.StorageBackend)
+}
+
+// Environments the local identity provider can be used in. Anyone with its secret can make a token with any permissions
+// so we refuse to start with it anywhere else, in case it was configured by mistake
+var localIdentityProviderEnvironments = map[string]bool{"local": true, "dev": true, "test": true}
+
+// Creates what validates the JWTs users connect with, depending on which identity provider is configured
+func makeIdentityProvider(cfg *config.APIConfig, fs fileaccess.FileAccess) (jwtparser.IdentityProvider, error) {
+	switch cfg.IdentityProvider {
+	case "local":
+		if !localIdentityProviderEnvironments[strings.ToLower(cfg.EnvironmentName)] {
+			return nil, fmt.Errorf("Local identity provider is not allowed in environment: \"%v\", only in: local, dev, test", cfg.EnvironmentName)
+		}
+
+		log.Println("Using local identity provider, this must NOT be used in any deployed environment!")
+		return jwtparser.InitLocalIdentityProvider(cfg.LocalJWTSecret)
+	case "oidc":
+		claims := jwtparser.ClaimNames{
+			Username:    cfg.OIDCUsernameClaim,
+			Email:       cfg.OIDCEmailClaim,
+			Permissions: cfg.OIDCPermissionClaims,
+		}
+
+		log.Printf("Using OIDC identity provider: %v", cfg.OIDCIssuerURL)
+		return jwtparser.InitOIDCIdentityProvider(cfg.OIDCIssuerURL, cfg.OIDCAudience, claims)
+	case "", "auth0":
+		return jwtparser.InitAuth0IdentityProvider(
+			cfg.Auth0Domain,
+			cfg.Auth0Namespace,
+			cfg.ConfigBucket,
+			filepaths.GetConfigFilePath(filepaths.Auth0PemFileName),
+			fs,
+		)
+	}
+
+	return nil, fmt.Errorf("Unknown identity provider: %v", cfg.IdentityProvider)
+}
+
 func initServices(cfg *config.APIConfig, apiInstanceId string) *services.APIServices {
 	// Get a session for the bucket region
 	sess, err := awsutil.GetSession()
@@ -235,13 +315,11 @@ func initServices(cfg *config.APIConfig, apiInstanceId string) *services.APIServ
 		log.Fatalf("Failed to create AWS session. Error: %v", err)
 	}
 
-	s3svc, err := awsutil.GetS3(sess)
+	s3svc, fs, err := makeFileAccess(cfg, sess)
 	if err != nil {
-		log.Fatalf("Failed to create AWS S3 service. Error: %v", err)
+		log.Fatalf("Failed to create file access. Error: %v", err)
 	}
 
-	fs := fileaccess.MakeS3Access(s3svc)
-
 	// TODO: Remove this once we switch to new environments and have full control over our configs again
 	//       This is only here so we can run easily on old environments without editing their configs!
 
@@ -294,19 +372,12 @@ func initServices(cfg *config.APIConfig, apiInstanceId string) *services.APIServ
 	dbCollections.InitCollections(db, iLog, cfg.EnvironmentName)
 
 	// Authenticaton for endpoints
-	jwtValidator, err := jwtparser.InitJWTValidator(
-		cfg.Auth0Domain,
-		cfg.Auth0Namespace,
-		cfg.ConfigBucket,
-		filepaths.GetConfigFilePath(filepaths.Auth0PemFileName),
-		fs,
-	)
-
+	identityProvider, err := makeIdentityProvider(cfg, fs)
 	if err != nil {
-		log.Fatalf("Failed to init JWT validator. Error: %v", err)
+		log.Fatalf("Failed to init identity provider. Error: %v", err)
 	}
 
-	jwt := jwtparser.RealJWTReader{Validator: jwtValidator, Auth0Namespace: cfg.Auth0Namespace}
+	jwt := jwtparser.MakeJWTReader(identityProvider)
 
 	snsSvc := sns.New(sess)
 	sqsSvc := sqs.New(sess)
@@ -0,0 +1,75 @@
+// Licensed to NASA JPL under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. NASA JPL licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/pixlise/core/v4/core/jwtparser"
+)
+
+// Makes a JWT for an API configured with the "local" identity provider, so it can be run and connected to offline.
+// The secret must match the API's LocalJWTSecret. The printed token can be sent in the Authorization header as:
+// Bearer <token>
+
+func main() {
+	var secret string
+	var userId string
+	var name string
+	var email string
+	var permissions string
+	var validHours uint
+
+	flag.StringVar(&secret, "secret", "", "Secret the API is configured with as LocalJWTSecret")
+	flag.StringVar(&userId, "user", "", "User ID")
+	flag.StringVar(&name, "name", "", "User name")
+	flag.StringVar(&email, "email", "", "User email address")
+	flag.StringVar(&permissions, "permissions", "", "Comma separated permissions, eg PIXLISE_ADMIN,EDIT_EXPRESSION (optional)")
+	flag.UintVar(&validHours, "hours", 24, "How many hours the token is valid for (optional)")
+
+	flag.Parse()
+
+	if len(userId) <= 0 || len(name) <= 0 || len(email) <= 0 {
+		log.Fatalln("user, name and email must be specified")
+	}
+
+	provider, err := jwtparser.InitLocalIdentityProvider(secret)
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	perms := []string{}
+	for _, perm := range strings.Split(permissions, ",") {
+		perm = strings.TrimSpace(perm)
+		if len(perm) > 0 {
+			perms = append(perms, perm)
+		}
+	}
+
+	user := jwtparser.JWTUserInfo{UserID: userId, Name: name, Email: email}
+	token, err := provider.MakeToken(user, perms, time.Now(), time.Duration(validHours)*time.Hour)
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	fmt.Println(token)
+}